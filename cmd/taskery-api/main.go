@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -10,13 +11,30 @@ import (
 	"time"
 
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/auth/jwt"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/clientip"
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/config"
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/database"
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/database/postgres"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/health"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/idempotency"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/jobs"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/metrics"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/pubsub"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/ratelimit"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/tracing"
+	grpcTransport "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/grpc"
 	v1 "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/graphql"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
 	"github.com/cyberbrain-dev/taskery-api/internal/services"
-	"github.com/go-playground/validator/v10"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
 	httpSwagger "github.com/swaggo/http-swagger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/grpc"
 
 	_ "github.com/cyberbrain-dev/taskery-api/docs"
 )
@@ -34,11 +52,34 @@ func main() {
 
 	logger := setupLogger(cfg.Environment)
 
+	if err := cfg.Validate(); err != nil {
+		logger.Error("Invalid configuration", slog.Any("err", err))
+		os.Exit(-1)
+	}
+
 	logger.Info("Starting taskery-api...")
 
+	tracerProvider, shutdownTracing, err := tracing.NewProvider(context.Background(), tracing.Options{
+		Exporter:     cfg.Tracing.Exporter,
+		OTLPEndpoint: cfg.Tracing.OTLPEndpoint,
+		OTLPInsecure: cfg.Tracing.OTLPInsecure,
+		SampleRate:   cfg.Tracing.SampleRate,
+	})
+	if err != nil {
+		logger.Error("Failed to init tracing", slog.Any("err", err))
+		os.Exit(-1)
+	}
+
+	propagator := propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagator)
+
+	tracingEnabled := cfg.Tracing.Exporter != tracing.ExporterNone
+
 	db := postgres.MustConnect(cfg.PostgresConnection)
 
-	err := database.RunMigrations(postgres.DSN(cfg.PostgresConnection))
+	err = database.RunMigrations(postgres.DSN(cfg.PostgresConnection))
 	if err != nil {
 		logger.Error("Failed to apply migrations", slog.Any("err", err))
 		os.Exit(-1)
@@ -58,37 +99,257 @@ func main() {
 		os.Exit(-1)
 	}
 
+	var taskEventRepo services.TaskEventRepository
+
+	taskEventRepo, err = postgres.NewTaskEventRepository(db)
+	if err != nil {
+		logger.Error("Failed to init task event repository", slog.Any("err", err))
+		os.Exit(-1)
+	}
+
+	calendarFeedRepo, err := postgres.NewCalendarFeedRepository(db)
+	if err != nil {
+		logger.Error("Failed to init calendar feed repository", slog.Any("err", err))
+		os.Exit(-1)
+	}
+
+	var transactor services.Transactor
+
+	transactor, err = postgres.NewTransactor(db)
+	if err != nil {
+		logger.Error("Failed to init transactor", slog.Any("err", err))
+		os.Exit(-1)
+	}
+
+	var idempotencyStore idempotency.Store
+
+	switch cfg.Idempotency.Store {
+	case "postgres":
+		idempotencyStore, err = postgres.NewIdempotencyStore(db)
+		if err != nil {
+			logger.Error("Failed to init idempotency store", slog.Any("err", err))
+			os.Exit(-1)
+		}
+	case "memory":
+		idempotencyStore = idempotency.NewMemoryStore()
+	default:
+		logger.Error("Unknown idempotency store", slog.String("store", cfg.Idempotency.Store))
+		os.Exit(-1)
+	}
+
+	var rateLimitStore ratelimit.Store
+
+	switch cfg.RateLimit.Store {
+	case "postgres":
+		rateLimitStore, err = postgres.NewRateLimitStore(db)
+		if err != nil {
+			logger.Error("Failed to init rate limit store", slog.Any("err", err))
+			os.Exit(-1)
+		}
+	case "memory":
+		rateLimitStore = ratelimit.NewMemoryStore()
+	default:
+		logger.Error("Unknown rate limit store", slog.String("store", cfg.RateLimit.Store))
+		os.Exit(-1)
+	}
+
 	logger.Info("Repositories initialization succeeded.")
 
-	jwtProvider := jwt.NewProvider([]byte(cfg.JWT.Secret), cfg.JWT.TTL, cfg.JWT.Issuer)
+	var m *metrics.Metrics
+
+	if cfg.Metrics.Enabled {
+		m = metrics.New()
+
+		if err := m.RegisterDB(db, cfg.PostgresConnection.DBName); err != nil {
+			logger.Error("Failed to register database metrics", slog.Any("err", err))
+			os.Exit(-1)
+		}
+
+		// the completed tasks are counted by the recorded events once their transaction is committed
+		taskEventRepo = metrics.NewTaskEventRepository(taskEventRepo, m)
+		transactor = metrics.NewTransactor(transactor, m)
+	}
+
+	var (
+		users  services.UserRepository         = userRepo
+		tasks  services.TaskRepository         = taskRepo
+		events services.TaskEventRepository    = taskEventRepo
+		feeds  services.CalendarFeedRepository = calendarFeedRepo
+	)
+
+	if tracingEnabled {
+		users = tracing.NewUserRepository(userRepo, tracerProvider)
+		tasks = tracing.NewTaskRepository(taskRepo, tracerProvider)
+		events = tracing.NewTaskEventRepository(taskEventRepo, tracerProvider)
+		transactor = tracing.NewTransactor(transactor, tracerProvider)
+		idempotencyStore = tracing.NewIdempotencyStore(idempotencyStore, tracerProvider)
+		rateLimitStore = tracing.NewRateLimitStore(rateLimitStore, tracerProvider)
+		feeds = tracing.NewCalendarFeedRepository(calendarFeedRepo, tracerProvider)
+	}
+
+	clk := clock.Real{}
 
-	userSvc, err := services.NewUserService(userRepo, jwtProvider)
+	jwtProvider := jwt.NewProvider([]byte(cfg.JWT.Secret), cfg.JWT.TTL, cfg.JWT.Issuer, clk)
+
+	userSvc, err := services.NewUserService(users, jwtProvider, clk)
 	if err != nil {
 		logger.Error("Failed to init user service", slog.Any("err", err))
 		os.Exit(-1)
 	}
 
-	taskSvc, err := services.NewTaskService(taskRepo)
+	taskChanges := pubsub.NewBroker(cfg.TaskEvents.ReplaySize, cfg.TaskEvents.SubscriberBuffer)
+
+	var (
+		taskChangePublisher services.TaskChangePublisher = taskChanges
+		taskChangeListener  *postgres.TaskChangeListener
+	)
+
+	switch cfg.TaskEvents.Fanout {
+	case "postgres":
+		taskChangePublisher, err = postgres.NewTaskChangeNotifier(db)
+		if err != nil {
+			logger.Error("Failed to init task change notifier", slog.Any("err", err))
+			os.Exit(-1)
+		}
+
+		taskChangeListener, err = postgres.NewTaskChangeListener(
+			postgres.DSN(cfg.PostgresConnection),
+			events,
+			taskChanges,
+			logger,
+		)
+		if err != nil {
+			logger.Error("Failed to init task change listener", slog.Any("err", err))
+			os.Exit(-1)
+		}
+	case "memory":
+	default:
+		logger.Error("Unknown task events fanout", slog.String("fanout", cfg.TaskEvents.Fanout))
+		os.Exit(-1)
+	}
+
+	taskSvc, err := services.NewTaskService(tasks, events, transactor, clk, taskChangePublisher)
 	if err != nil {
 		logger.Error("Failed to init task service", slog.Any("err", err))
 		os.Exit(-1)
 	}
 
-	vld := validator.New()
+	calendarSvc, err := services.NewCalendarService(feeds, tasks, clk)
+	if err != nil {
+		logger.Error("Failed to init calendar service", slog.Any("err", err))
+		os.Exit(-1)
+	}
+
+	dbHealthChecker, err := postgres.NewHealthChecker(db)
+	if err != nil {
+		logger.Error("Failed to init health checker", slog.Any("err", err))
+		os.Exit(-1)
+	}
+
+	clientIPResolver, err := clientip.NewResolver(cfg.HTTPServer.TrustedProxies)
+	if err != nil {
+		logger.Error("Failed to init client IP resolver", slog.Any("err", err))
+		os.Exit(-1)
+	}
+
+	healthChecks := health.New(cfg.Health.CheckTimeout)
+	healthChecks.AddReadinessCheck(dbHealthChecker)
+
+	var (
+		userService     tracing.UserService     = userSvc
+		taskService     tracing.TaskService     = taskSvc
+		calendarService tracing.CalendarService = calendarSvc
+	)
 
-	router := v1.NewRouter(v1.RouterOptions{
-		UserService:   userSvc,
-		TaskService:   taskSvc,
+	routerOpts := v1.RouterOptions{
 		Logger:        logger,
 		TokenProvider: jwtProvider,
-		Validator:     vld,
-		Timeout:       cfg.HTTPServer.Timeout,
-	})
+		Validator:     handlers.NewValidator(),
+		Clock:         clk,
+
+		IdempotencyStore: idempotencyStore,
+		IdempotencyTTL:   cfg.Idempotency.TTL,
+
+		RateLimitStore: rateLimitStore,
+		RateLimits: v1.RateLimits{
+			Auth:     ratelimit.Limit(cfg.RateLimit.Auth),
+			Users:    ratelimit.Limit(cfg.RateLimit.Users),
+			Tasks:    ratelimit.Limit(cfg.RateLimit.Tasks),
+			Calendar: ratelimit.Limit(cfg.RateLimit.Calendar),
+		},
+
+		ClientIP: clientIPResolver,
+
+		CORS: myMw.CORSOptions{
+			AllowedOrigins:   cfg.CORS.AllowedOrigins,
+			AllowedMethods:   cfg.CORS.AllowedMethods,
+			AllowedHeaders:   cfg.CORS.AllowedHeaders,
+			ExposedHeaders:   cfg.CORS.ExposedHeaders,
+			AllowCredentials: cfg.CORS.AllowCredentials,
+			MaxAge:           cfg.CORS.MaxAge,
+		},
+		SecurityHeaders: myMw.SecurityHeadersOptions{
+			HSTSMaxAge: cfg.SecurityHeaders.HSTSMaxAge,
+		},
+		RequestBody: handlers.DecodeOptions{
+			MaxBodySize: cfg.RequestBody.MaxSize,
+			Strict:      cfg.RequestBody.Strict,
+		},
+
+		AccessLog: myMw.AccessLogOptions{
+			Enabled:    cfg.AccessLog.Enabled,
+			SampleRate: cfg.AccessLog.SampleRate,
+			LogHeaders: cfg.AccessLog.LogHeaders,
+		},
+
+		LivenessHandler:  healthChecks.LivenessHandler(),
+		ReadinessHandler: healthChecks.ReadinessHandler(),
+
+		TaskEvents:          taskChanges,
+		TaskEventsHeartbeat: cfg.TaskEvents.Heartbeat,
+		Socket: task.SocketOptions{
+			MaxConnections:   cfg.WebSocket.MaxConnections,
+			MaxSubscriptions: cfg.WebSocket.MaxSubscriptions,
+			MaxMessageSize:   cfg.WebSocket.MaxMessageSize,
+			PingInterval:     cfg.WebSocket.PingInterval,
+			AllowedOrigins:   cfg.CORS.AllowedOrigins,
+		},
+		GraphQL: graphql.Limits{
+			MaxDepth:      cfg.GraphQL.MaxDepth,
+			MaxComplexity: cfg.GraphQL.MaxComplexity,
+		},
+
+		Timeout:      cfg.HTTPServer.Timeout,
+		MaxBatchSize: cfg.Batch.MaxSize,
+	}
+
+	if m != nil {
+		userService = metrics.NewUserService(userSvc, m)
+		taskService = metrics.NewTaskService(taskSvc, m)
+		routerOpts.Metrics = m
+		routerOpts.MetricsHandler = m.Handler()
+	}
+
+	if tracingEnabled {
+		userService = tracing.NewUserService(userService, tracerProvider)
+		taskService = tracing.NewTaskService(taskService, tracerProvider)
+		calendarService = tracing.NewCalendarService(calendarService, tracerProvider)
+		routerOpts.TracerProvider = tracerProvider
+		routerOpts.Propagator = propagator
+	}
+
+	routerOpts.UserService = userService
+	routerOpts.TaskService = taskService
+	routerOpts.CalendarService = calendarService
+
+	router := v1.NewRouter(routerOpts)
 
 	logger.Info(cfg.Environment)
 
 	if cfg.Environment == "local" || cfg.Environment == "dev" {
-		router.Get("/swagger/*", httpSwagger.Handler(
+		// the Swagger UI is a web page, so it needs a more relaxed policy than the API
+		swagger := router.With(myMw.ContentSecurityPolicy(myMw.SwaggerContentSecurityPolicy))
+		swagger.Get("/swagger/*", httpSwagger.Handler(
 			httpSwagger.URL("/swagger/doc.json"),
 		))
 	}
@@ -109,6 +370,49 @@ func main() {
 		IdleTimeout:  cfg.HTTPServer.IdleTimeout,
 	}
 
+	// both APIs share the same, possibly decorated, service instances
+	var grpcSrv *grpc.Server
+	if cfg.GRPCServer.Enabled {
+		grpcSrv = grpcTransport.NewServer(grpcTransport.ServerOptions{
+			UserService:    userService,
+			TaskService:    taskService,
+			TokenValidator: jwtProvider,
+			Logger:         logger,
+			RateLimitStore: rateLimitStore,
+			AuthRateLimit:  ratelimit.Limit(cfg.RateLimit.Auth),
+			Clock:          clk,
+			ClientIP:       clientIPResolver,
+			Timeout:        cfg.HTTPServer.Timeout,
+			MaxBatchSize:   cfg.Batch.MaxSize,
+		})
+
+		if cfg.GRPCServer.Address == "" {
+			logger.Info("Serving gRPC alongside HTTP", slog.String("address", cfg.HTTPServer.Address))
+
+			srv.Handler = grpcTransport.Multiplex(grpcSrv, router)
+			srv.Protocols = new(http.Protocols)
+			srv.Protocols.SetHTTP1(true)
+			srv.Protocols.SetUnencryptedHTTP2(true)
+		} else {
+			lis, err := net.Listen("tcp", cfg.GRPCServer.Address)
+			if err != nil {
+				logger.Error("Failed to listen for gRPC", slog.Any("err", err))
+				os.Exit(-1)
+			}
+
+			logger.Info("Launching the gRPC server...", slog.String("address", cfg.GRPCServer.Address))
+
+			go func() {
+				if err := grpcSrv.Serve(lis); err != nil {
+					logger.Error("gRPC server not running", slog.Any("err", err))
+				}
+			}()
+		}
+	}
+
+	// the streams and the sockets of task changes would otherwise hold the shutdown until its timeout
+	srv.RegisterOnShutdown(taskChanges.Close)
+
 	go func() {
 		if err := srv.ListenAndServe(); err != nil {
 			logger.Error("Server not running", slog.Any("err", err))
@@ -117,19 +421,72 @@ func main() {
 
 	logger.Info("Server started")
 
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	purgeTrashJob := jobs.NewPurgeTrashJob(
+		taskService,
+		cfg.Trash.Retention,
+		cfg.Trash.PurgeInterval,
+		cfg.HTTPServer.Timeout,
+		logger,
+	)
+	go purgeTrashJob.Run(jobsCtx)
+
+	purgeIdempotencyKeysJob := jobs.NewPurgeIdempotencyKeysJob(
+		idempotencyStore,
+		clk,
+		cfg.Idempotency.PurgeInterval,
+		cfg.HTTPServer.Timeout,
+		logger,
+	)
+	go purgeIdempotencyKeysJob.Run(jobsCtx)
+
+	purgeRateLimitBucketsJob := jobs.NewPurgeRateLimitBucketsJob(
+		rateLimitStore,
+		clk,
+		cfg.RateLimit.PurgeInterval,
+		cfg.HTTPServer.Timeout,
+		logger,
+	)
+	go purgeRateLimitBucketsJob.Run(jobsCtx)
+
+	if taskChangeListener != nil {
+		go func() {
+			if err := taskChangeListener.Run(jobsCtx); err != nil {
+				logger.Error("Task change listener stopped", slog.Any("err", err))
+			}
+		}()
+	}
+
 	<-done
 
+	logger.Info("Draining the traffic", slog.Duration("delay", cfg.Health.DrainDelay))
+
+	healthChecks.SetShuttingDown()
+	time.Sleep(cfg.Health.DrainDelay)
+
 	logger.Info("Launching server shutdown")
 
+	stopJobs()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	if grpcSrv != nil {
+		stopGRPCServer(ctx, grpcSrv)
+	}
+
 	if err := srv.Shutdown(ctx); err != nil {
 		logger.Error("Failed to shutdown the server")
 
 		return
 	}
 
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Error("Failed to flush the traces", slog.Any("err", err))
+	}
+
 	if err := db.Close(); err != nil {
 		logger.Error(
 			"Unable to close the connection to Postgres database",
@@ -142,23 +499,40 @@ func main() {
 	logger.Info("Server stopped")
 }
 
+// stopGRPCServer stops the server gracefully, waiting for the pending calls,
+// unless ctx is done first; the calls left are canceled then.
+func stopGRPCServer(ctx context.Context, srv *grpc.Server) {
+	stopped := make(chan struct{})
+
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		srv.Stop()
+	}
+}
+
 func setupLogger(env string) *slog.Logger {
 	var logger *slog.Logger
 
 	switch env {
 	case "local":
 		logger = slog.New(
-			slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+			slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug, ReplaceAttr: slogx.Redact}),
 		)
 
 	case "dev":
 		logger = slog.New(
-			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug, ReplaceAttr: slogx.Redact}),
 		)
 	case "production":
 	default:
 		logger = slog.New(
-			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}),
+			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo, ReplaceAttr: slogx.Redact}),
 		)
 	}
 
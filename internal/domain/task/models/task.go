@@ -6,11 +6,13 @@ import (
 	"time"
 
 	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/vo"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
 	"github.com/google/uuid"
 )
 
 // Task is a model that represents a task.
-// It includes the task's ID, title, description, completion status, and deadline.
+// It includes the task's ID, title, description, completion status, deadline,
+// audit timestamps, archiving and deletion times and the version used for optimistic concurrency control.
 type Task struct {
 	id      uuid.UUID
 	ownerID uuid.UUID
@@ -22,6 +24,14 @@ type Task struct {
 
 	isCompleted bool
 	completedAt *time.Time
+
+	createdAt time.Time
+	updatedAt time.Time
+
+	archivedAt *time.Time
+	deletedAt  *time.Time
+
+	version int64
 }
 
 func (t *Task) ID() uuid.UUID               { return t.id }
@@ -30,6 +40,15 @@ func (t *Task) Title() vo.Title             { return t.title }
 func (t *Task) Description() vo.Description { return t.description }
 func (t *Task) Deadline() *vo.Deadline      { return t.deadline }
 func (t *Task) IsCompleted() bool           { return t.isCompleted }
+func (t *Task) CreatedAt() time.Time        { return t.createdAt }
+
+// UpdatedAt returns the time of the last change of the task.
+// For a task that has never been changed it equals CreatedAt.
+func (t *Task) UpdatedAt() time.Time { return t.updatedAt }
+
+// Version returns the version of the task that was loaded from the storage.
+// A newly created task has version 1.
+func (t *Task) Version() int64 { return t.version }
 
 // CompletedAt returns the timestamp when the task was completed.
 //
@@ -48,10 +67,50 @@ func (t *Task) CompletedAt() *time.Time {
 	return &completedAtCopy
 }
 
+// ArchivedAt returns the time when the task was archived.
+//
+// If the task is not archived, it returns nil. The returned value
+// is a copy of the internal timestamp.
+func (t *Task) ArchivedAt() *time.Time {
+	if t.archivedAt == nil {
+		return nil
+	}
+
+	archivedAtCopy := *t.archivedAt
+	return &archivedAtCopy
+}
+
+// IsArchived reports whether the task is archived.
+func (t *Task) IsArchived() bool { return t.archivedAt != nil }
+
+// DeletedAt returns the time when the task was moved to the trash.
+//
+// If the task is not in the trash, it returns nil. The returned value
+// is a copy of the internal timestamp.
+func (t *Task) DeletedAt() *time.Time {
+	if t.deletedAt == nil {
+		return nil
+	}
+
+	deletedAtCopy := *t.deletedAt
+	return &deletedAtCopy
+}
+
+// IsDeleted reports whether the task is in the trash.
+func (t *Task) IsDeleted() bool { return t.deletedAt != nil }
+
 var ErrTaskFailedCreateFromDB = errors.New("failed to create task from DB")
 
+// ErrTaskNotCompleted is returned by Archive if the task is not completed and archiving is not forced.
+var ErrTaskNotCompleted = errors.New("task is not completed")
+
+// ErrTaskCompletionInvalid is returned by RevertTo if the completion status and the time
+// of completion contradict each other or the time of completion is in the future.
+var ErrTaskCompletionInvalid = errors.New("task completion is invalid")
+
 // NewTask creates a new Task instance with the given title, description, and ownerID. It does not set a deadline.
-func NewTask(title string, description string, owner uuid.UUID) (*Task, error) {
+// The creation and update timestamps are taken from clk.
+func NewTask(title string, description string, owner uuid.UUID, clk clock.Clock) (*Task, error) {
 	titleVO, err := vo.NewTitle(title)
 	if err != nil {
 		return nil, err
@@ -62,6 +121,8 @@ func NewTask(title string, description string, owner uuid.UUID) (*Task, error) {
 		return nil, err
 	}
 
+	now := clk.Now()
+
 	return &Task{
 		id:      uuid.New(),
 		ownerID: owner,
@@ -73,6 +134,11 @@ func NewTask(title string, description string, owner uuid.UUID) (*Task, error) {
 
 		isCompleted: false,
 		completedAt: nil,
+
+		createdAt: now,
+		updatedAt: now,
+
+		version: 1,
 	}, nil
 }
 
@@ -93,14 +159,23 @@ type TaskFromDBParams struct {
 
 	IsCompleted bool
 	CompletedAt *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	ArchivedAt *time.Time
+	DeletedAt  *time.Time
+
+	Version int64
 }
 
 // NewTaskFromDB creates a Task from database parameters.
 // It validates that the completedAt and isCompleted fields are consistent:
 // completedAt must be non-nil if and only if isCompleted is true.
-// It returns an error if any of the value objects (title, description, deadline) fail to be created.
+// It returns an error if any of the value objects (title, description) fail to be created.
 //
 // If p.Deadline is not nil, the deadline field of the Task will be set.
+// A stored deadline is not required to be in the future, since it may have passed after it was set.
 func NewTaskFromDB(p TaskFromDBParams) (*Task, error) {
 	if (p.IsCompleted && p.CompletedAt == nil) || (!p.IsCompleted && p.CompletedAt != nil) {
 		return nil, fmt.Errorf("%w: %s", ErrTaskFailedCreateFromDB, "completedAt and isCompleted fields contradict")
@@ -136,14 +211,18 @@ func NewTaskFromDB(p TaskFromDBParams) (*Task, error) {
 
 		isCompleted: p.IsCompleted,
 		completedAt: p.CompletedAt,
+
+		createdAt: p.CreatedAt,
+		updatedAt: p.UpdatedAt,
+
+		archivedAt: p.ArchivedAt,
+		deletedAt:  p.DeletedAt,
+
+		version: p.Version,
 	}
 
 	if p.Deadline != nil {
-		deadlineVO, err := vo.NewDeadline(*p.Deadline)
-		if err != nil {
-			return nil, err
-		}
-
+		deadlineVO := vo.RestoreDeadline(*p.Deadline)
 		task.deadline = &deadlineVO
 	}
 
@@ -151,13 +230,13 @@ func NewTaskFromDB(p TaskFromDBParams) (*Task, error) {
 }
 
 // NewTaskWithDeadline creates a new Task instance with the given title, description, ownerID, and deadline.
-func NewTaskWithDeadline(title string, description string, owner uuid.UUID, deadline time.Time) (*Task, error) {
-	task, err := NewTask(title, description, owner)
+func NewTaskWithDeadline(title string, description string, owner uuid.UUID, deadline time.Time, clk clock.Clock) (*Task, error) {
+	task, err := NewTask(title, description, owner, clk)
 	if err != nil {
 		return nil, err
 	}
 
-	deadlineVO, err := vo.NewDeadline(deadline)
+	deadlineVO, err := vo.NewDeadline(deadline, clk)
 	if err != nil {
 		return nil, err
 	}
@@ -167,33 +246,36 @@ func NewTaskWithDeadline(title string, description string, owner uuid.UUID, dead
 }
 
 // ChangeTitle changes the title of the task.
-func (t *Task) ChangeTitle(newTitle string) error {
+func (t *Task) ChangeTitle(newTitle string, clk clock.Clock) error {
 	newTitleVO, err := vo.NewTitle(newTitle)
 	if err != nil {
 		return err
 	}
 	t.title = newTitleVO
+	t.touch(clk)
 	return nil
 }
 
 // ChangeDescription changes the description of the task.
-func (t *Task) ChangeDescription(newDescription string) error {
+func (t *Task) ChangeDescription(newDescription string, clk clock.Clock) error {
 	newDescriptionVO, err := vo.NewDescription(newDescription)
 	if err != nil {
 		return err
 	}
 	t.description = newDescriptionVO
+	t.touch(clk)
 	return nil
 }
 
 // SetDeadline sets the deadline in case it is not already set or edits the deadline.
-func (t *Task) SetDeadline(deadline time.Time) error {
-	deadlineVO, err := vo.NewDeadline(deadline)
+func (t *Task) SetDeadline(deadline time.Time, clk clock.Clock) error {
+	deadlineVO, err := vo.NewDeadline(deadline, clk)
 	if err != nil {
 		return err
 	}
 
 	t.deadline = &deadlineVO
+	t.touch(clk)
 	return nil
 }
 
@@ -203,33 +285,184 @@ func (t *Task) HasDeadline() bool {
 }
 
 // RemoveDeadline removes the deadline from the task if it is set.
-func (t *Task) RemoveDeadline() {
+func (t *Task) RemoveDeadline(clk clock.Clock) {
 	t.deadline = nil
+	t.touch(clk)
 }
 
-// IsOverdue checks if the task is overdue.
+// IsOverdue checks if the task is overdue according to clk.
 // It returns a boolean indicating whether the task is overdue.
 //
 // If the task is completed, it returns false.
-func (t *Task) IsOverdue() bool {
+func (t *Task) IsOverdue(clk clock.Clock) bool {
 	if t.deadline == nil {
 		return false
 	}
 
-	return t.deadline.IsOverdue() && !t.isCompleted
+	return t.deadline.IsOverdue(clk) && !t.isCompleted
 }
 
 // Complete marks the task as complete and sets the time of completion.
-func (t *Task) Complete() {
+func (t *Task) Complete(clk clock.Clock) {
 	if !t.isCompleted {
 		t.isCompleted = true
-		now := time.Now()
+		now := clk.Now()
 		t.completedAt = &now
+		t.updatedAt = now
 	}
 }
 
 // Reopen marks the task as incomplete and clears the time of completion.
-func (t *Task) Reopen() {
-	t.isCompleted = false
+func (t *Task) Reopen(clk clock.Clock) {
+	if t.isCompleted {
+		t.isCompleted = false
+		t.completedAt = nil
+		t.touch(clk)
+	}
+}
+
+// Archive marks the task as archived at the current time of clk.
+// Only a completed task can be archived unless force is true,
+// otherwise Archive returns ErrTaskNotCompleted.
+// Calling it on a task that is already archived does nothing.
+func (t *Task) Archive(force bool, clk clock.Clock) error {
+	if t.archivedAt != nil {
+		return nil
+	}
+
+	if !t.isCompleted && !force {
+		return ErrTaskNotCompleted
+	}
+
+	now := clk.Now()
+	t.archivedAt = &now
+	t.updatedAt = now
+	return nil
+}
+
+// Unarchive takes the task out of the archive.
+// Calling it on a task that is not archived does nothing.
+func (t *Task) Unarchive(clk clock.Clock) {
+	if t.archivedAt != nil {
+		t.archivedAt = nil
+		t.touch(clk)
+	}
+}
+
+// MoveToTrash marks the task as deleted at the current time of clk.
+// Calling it on a task that is already in the trash does nothing.
+func (t *Task) MoveToTrash(clk clock.Clock) {
+	if t.deletedAt == nil {
+		now := clk.Now()
+		t.deletedAt = &now
+		t.updatedAt = now
+	}
+}
+
+// Restore takes the task out of the trash.
+// Calling it on a task that is not in the trash does nothing.
+func (t *Task) Restore(clk clock.Clock) {
+	if t.deletedAt != nil {
+		t.deletedAt = nil
+		t.touch(clk)
+	}
+}
+
+// State returns a snapshot of the user-visible fields of the task.
+func (t *Task) State() TaskState {
+	return TaskState{
+		Title:       t.title.String(),
+		Description: t.description.String(),
+		Deadline:    convertDeadline(t.deadline),
+		IsCompleted: t.isCompleted,
+		CompletedAt: t.CompletedAt(),
+		ArchivedAt:  t.ArchivedAt(),
+		DeletedAt:   t.DeletedAt(),
+	}
+}
+
+// RevertTo brings the title, description, deadline, completion status and time
+// of completion of the task back to the given state. The values are validated the same way
+// as when they are changed directly, so a deadline that has already passed
+// cannot be restored. If any of the values is invalid, the task is left unchanged.
+//
+// The archiving and deletion state of the task is not affected.
+func (t *Task) RevertTo(state TaskState, clk clock.Clock) error {
+	titleVO, err := vo.NewTitle(state.Title)
+	if err != nil {
+		return err
+	}
+
+	descriptionVO, err := vo.NewDescription(state.Description)
+	if err != nil {
+		return err
+	}
+
+	var deadlineVO *vo.Deadline
+	if state.Deadline != nil {
+		current := convertDeadline(t.deadline)
+		if current != nil && current.Equal(*state.Deadline) {
+			deadlineVO = t.deadline
+		} else {
+			newDeadline, err := vo.NewDeadline(*state.Deadline, clk)
+			if err != nil {
+				return err
+			}
+			deadlineVO = &newDeadline
+		}
+	}
+
+	before := t.State()
+
+	if err := t.restoreCompletion(state.IsCompleted, state.CompletedAt, clk); err != nil {
+		return err
+	}
+
+	t.title = titleVO
+	t.description = descriptionVO
+	t.deadline = deadlineVO
+
+	if len(before.Diff(t.State())) > 0 {
+		t.touch(clk)
+	}
+
+	return nil
+}
+
+// restoreCompletion sets the completion status and the time of completion of the task
+// to the given ones. completedAt must be non-nil if and only if isCompleted is true
+// and must not be after the current time of clk, otherwise ErrTaskCompletionInvalid
+// is returned and the task is left unchanged.
+func (t *Task) restoreCompletion(isCompleted bool, completedAt *time.Time, clk clock.Clock) error {
+	if isCompleted != (completedAt != nil) {
+		return fmt.Errorf("%w: %s", ErrTaskCompletionInvalid, "completedAt and isCompleted fields contradict")
+	}
+
+	if completedAt != nil && completedAt.After(clk.Now()) {
+		return fmt.Errorf("%w: %s", ErrTaskCompletionInvalid, "completedAt is in the future")
+	}
+
+	t.isCompleted = isCompleted
 	t.completedAt = nil
+
+	if completedAt != nil {
+		completedAtCopy := *completedAt
+		t.completedAt = &completedAtCopy
+	}
+
+	return nil
+}
+
+// touch sets the update timestamp of the task to the current time of clk.
+func (t *Task) touch(clk clock.Clock) {
+	t.updatedAt = clk.Now()
+}
+
+func convertDeadline(deadline *vo.Deadline) *time.Time {
+	if deadline == nil {
+		return nil
+	}
+
+	deadlineTime := deadline.Time()
+	return &deadlineTime
 }
@@ -0,0 +1,196 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
+	"github.com/google/uuid"
+)
+
+// TaskEventType is the kind of change that a TaskEvent records.
+type TaskEventType string
+
+const (
+	TaskEventCreated         TaskEventType = "created"
+	TaskEventUpdated         TaskEventType = "updated"
+	TaskEventDeadlineRemoved TaskEventType = "deadline_removed"
+	TaskEventCompleted       TaskEventType = "completed"
+	TaskEventReopened        TaskEventType = "reopened"
+	TaskEventDeleted         TaskEventType = "deleted"
+	TaskEventRestored        TaskEventType = "restored"
+	TaskEventArchived        TaskEventType = "archived"
+	TaskEventUnarchived      TaskEventType = "unarchived"
+	TaskEventReverted        TaskEventType = "reverted"
+	TaskEventPurged          TaskEventType = "purged"
+)
+
+// TaskState is a snapshot of the user-visible fields of a task.
+type TaskState struct {
+	Title       string
+	Description string
+	Deadline    *time.Time
+	IsCompleted bool
+	CompletedAt *time.Time
+	ArchivedAt  *time.Time
+	DeletedAt   *time.Time
+}
+
+// FieldChange describes how a single field of a task has changed.
+// Before is nil for the fields of a newly created task.
+type FieldChange struct {
+	Field  string
+	Before any
+	After  any
+}
+
+// Diff returns the changes of the fields that differ between s and other,
+// with the values of s as Before and the values of other as After.
+func (s TaskState) Diff(other TaskState) []FieldChange {
+	changes := make([]FieldChange, 0)
+
+	if s.Title != other.Title {
+		changes = append(changes, FieldChange{Field: "title", Before: s.Title, After: other.Title})
+	}
+	if s.Description != other.Description {
+		changes = append(changes, FieldChange{Field: "description", Before: s.Description, After: other.Description})
+	}
+	if !equalTimes(s.Deadline, other.Deadline) {
+		changes = append(changes, FieldChange{Field: "deadline", Before: s.Deadline, After: other.Deadline})
+	}
+	if s.IsCompleted != other.IsCompleted {
+		changes = append(changes, FieldChange{Field: "is_completed", Before: s.IsCompleted, After: other.IsCompleted})
+	}
+	if !equalTimes(s.CompletedAt, other.CompletedAt) {
+		changes = append(changes, FieldChange{Field: "completed_at", Before: s.CompletedAt, After: other.CompletedAt})
+	}
+	if !equalTimes(s.ArchivedAt, other.ArchivedAt) {
+		changes = append(changes, FieldChange{Field: "archived_at", Before: s.ArchivedAt, After: other.ArchivedAt})
+	}
+	if !equalTimes(s.DeletedAt, other.DeletedAt) {
+		changes = append(changes, FieldChange{Field: "deleted_at", Before: s.DeletedAt, After: other.DeletedAt})
+	}
+
+	return changes
+}
+
+// TaskEvent is an entry of the change history of a task.
+// It records who changed the task and when, together with
+// the state of the task before and after the change.
+type TaskEvent struct {
+	id      uuid.UUID
+	taskID  uuid.UUID
+	actorID uuid.UUID
+
+	eventType TaskEventType
+
+	before *TaskState
+	after  TaskState
+
+	occurredAt time.Time
+}
+
+func (e *TaskEvent) ID() uuid.UUID         { return e.id }
+func (e *TaskEvent) TaskID() uuid.UUID     { return e.taskID }
+func (e *TaskEvent) ActorID() uuid.UUID    { return e.actorID }
+func (e *TaskEvent) Type() TaskEventType   { return e.eventType }
+func (e *TaskEvent) After() TaskState      { return e.after }
+func (e *TaskEvent) OccurredAt() time.Time { return e.occurredAt }
+
+// Before returns the state of the task before the change.
+// It returns nil for the event that records the creation of the task.
+func (e *TaskEvent) Before() *TaskState {
+	if e.before == nil {
+		return nil
+	}
+
+	beforeCopy := *e.before
+	return &beforeCopy
+}
+
+// Changes returns the fields that were changed by the event.
+// For the creation of a task, all of its non-empty fields are returned with a nil Before.
+func (e *TaskEvent) Changes() []FieldChange {
+	if e.before != nil {
+		return e.before.Diff(e.after)
+	}
+
+	changes := TaskState{}.Diff(e.after)
+	for i := range changes {
+		changes[i].Before = nil
+	}
+
+	return changes
+}
+
+// NewTaskEvent creates an event of the given type that records the change of task made by actorID.
+// before is the state of the task prior to the change and must be nil for TaskEventCreated.
+// The time of the event is taken from clk.
+func NewTaskEvent(
+	task *Task,
+	eventType TaskEventType,
+	actorID uuid.UUID,
+	before *TaskState,
+	clk clock.Clock,
+) *TaskEvent {
+	return &TaskEvent{
+		id:         uuid.New(),
+		taskID:     task.ID(),
+		actorID:    actorID,
+		eventType:  eventType,
+		before:     before,
+		after:      task.State(),
+		occurredAt: clk.Now(),
+	}
+}
+
+// TaskEventFromDBParams contains raw task event data loaded from the database.
+type TaskEventFromDBParams struct {
+	ID      string
+	TaskID  string
+	ActorID string
+
+	Type string
+
+	Before *TaskState
+	After  TaskState
+
+	OccurredAt time.Time
+}
+
+// NewTaskEventFromDB creates a TaskEvent from database parameters.
+// It returns an error if any of the identifiers is not a valid UUID.
+func NewTaskEventFromDB(p TaskEventFromDBParams) (*TaskEvent, error) {
+	id, err := uuid.Parse(p.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrTaskFailedCreateFromDB, "invalid event ID")
+	}
+
+	taskID, err := uuid.Parse(p.TaskID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrTaskFailedCreateFromDB, "invalid task ID")
+	}
+
+	actorID, err := uuid.Parse(p.ActorID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrTaskFailedCreateFromDB, "invalid actor ID")
+	}
+
+	return &TaskEvent{
+		id:         id,
+		taskID:     taskID,
+		actorID:    actorID,
+		eventType:  TaskEventType(p.Type),
+		before:     p.Before,
+		after:      p.After,
+		occurredAt: p.OccurredAt,
+	}, nil
+}
+
+func equalTimes(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Equal(*b)
+}
@@ -0,0 +1,109 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskEvent_Changes(t *testing.T) {
+	now := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(now)
+	actorID := uuid.New()
+
+	task, err := models.NewTask("Title", "Description", actorID, clk)
+	require.NoError(t, err)
+
+	t.Run("created", func(t *testing.T) {
+		event := models.NewTaskEvent(task, models.TaskEventCreated, actorID, nil, clk)
+
+		require.Equal(t, task.ID(), event.TaskID())
+		require.Equal(t, actorID, event.ActorID())
+		require.Equal(t, models.TaskEventCreated, event.Type())
+		require.Equal(t, now, event.OccurredAt())
+		require.Nil(t, event.Before())
+		require.Equal(t, []models.FieldChange{
+			{Field: "title", Before: nil, After: "Title"},
+			{Field: "description", Before: nil, After: "Description"},
+		}, event.Changes())
+	})
+
+	t.Run("completed", func(t *testing.T) {
+		before := task.State()
+
+		clk.Advance(time.Hour)
+		task.Complete(clk)
+
+		event := models.NewTaskEvent(task, models.TaskEventCompleted, actorID, &before, clk)
+
+		completedAt := now.Add(time.Hour)
+		require.Equal(t, []models.FieldChange{
+			{Field: "is_completed", Before: false, After: true},
+			{Field: "completed_at", Before: (*time.Time)(nil), After: &completedAt},
+		}, event.Changes())
+	})
+}
+
+func TestNewTaskEventFromDB(t *testing.T) {
+	valid := models.TaskEventFromDBParams{
+		ID:         uuid.NewString(),
+		TaskID:     uuid.NewString(),
+		ActorID:    uuid.NewString(),
+		Type:       string(models.TaskEventUpdated),
+		Before:     &models.TaskState{Title: "Old"},
+		After:      models.TaskState{Title: "New"},
+		OccurredAt: time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(p *models.TaskEventFromDBParams)
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			modify:  func(p *models.TaskEventFromDBParams) {},
+			wantErr: false,
+		},
+		{
+			name:    "invalid id",
+			modify:  func(p *models.TaskEventFromDBParams) { p.ID = "invalid" },
+			wantErr: true,
+		},
+		{
+			name:    "invalid task id",
+			modify:  func(p *models.TaskEventFromDBParams) { p.TaskID = "invalid" },
+			wantErr: true,
+		},
+		{
+			name:    "invalid actor id",
+			modify:  func(p *models.TaskEventFromDBParams) { p.ActorID = "invalid" },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := valid
+			tt.modify(&params)
+
+			event, err := models.NewTaskEventFromDB(params)
+			if tt.wantErr {
+				require.ErrorIs(t, err, models.ErrTaskFailedCreateFromDB)
+				require.Nil(t, event)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, valid.ID, event.ID().String())
+			require.Equal(t, models.TaskEventUpdated, event.Type())
+			require.Equal(t, []models.FieldChange{
+				{Field: "title", Before: "Old", After: "New"},
+			}, event.Changes())
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
 	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/vo"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
@@ -14,6 +15,7 @@ func TestNewTask(t *testing.T) {
 	t.Parallel()
 
 	validOwner := uuid.New()
+	now := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
 
 	tests := []struct {
 		name        string
@@ -55,7 +57,7 @@ func TestNewTask(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			taskEntity, err := models.NewTask(tt.title, tt.description, tt.owner)
+			taskEntity, err := models.NewTask(tt.title, tt.description, tt.owner, clock.NewFake(now))
 
 			if tt.expectedError != nil {
 				require.Error(t, err)
@@ -76,6 +78,9 @@ func TestNewTask(t *testing.T) {
 			require.False(t, taskEntity.IsCompleted())
 			require.Nil(t, taskEntity.Deadline())
 			require.Nil(t, taskEntity.CompletedAt())
+
+			require.Equal(t, now, taskEntity.CreatedAt())
+			require.Equal(t, now, taskEntity.UpdatedAt())
 		})
 	}
 }
@@ -84,7 +89,8 @@ func TestNewTaskWithDeadline(t *testing.T) {
 	t.Parallel()
 
 	validOwner := uuid.New()
-	validDeadline := time.Now().Add(24 * time.Hour)
+	now := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+	validDeadline := now.Add(24 * time.Hour)
 
 	tests := []struct {
 		name        string
@@ -116,7 +122,7 @@ func TestNewTaskWithDeadline(t *testing.T) {
 			title:       "Test title",
 			description: "Test description",
 			owner:       validOwner,
-			deadline:    time.Now().Add(-24 * time.Hour),
+			deadline:    now.Add(-24 * time.Hour),
 			expectedErr: vo.ErrDeadlineBeforeNow,
 		},
 	}
@@ -128,6 +134,7 @@ func TestNewTaskWithDeadline(t *testing.T) {
 				tt.description,
 				tt.owner,
 				tt.deadline,
+				clock.NewFake(now),
 			)
 
 			if tt.expectedErr != nil {
@@ -150,6 +157,7 @@ func TestNewTaskWithDeadline(t *testing.T) {
 func TestNewTaskFromDB(t *testing.T) {
 	now := time.Now()
 	future := now.Add(24 * time.Hour)
+	past := now.Add(-24 * time.Hour)
 
 	validID := uuid.New()
 	validOwner := uuid.New()
@@ -210,13 +218,24 @@ func TestNewTaskFromDB(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "error when deadline is invalid",
+			name: "success with deadline in the past",
 			params: models.TaskFromDBParams{
 				ID:          validID.String(),
 				OwnerID:     validOwner.String(),
 				Title:       "Valid title",
 				Description: "Valid description",
-				Deadline:    &now, // предполагаем, что прошедший дедлайн невалиден
+				Deadline:    &past,
+				IsCompleted: false,
+			},
+			wantErr: false,
+		},
+		{
+			name: "error when title is invalid",
+			params: models.TaskFromDBParams{
+				ID:          validID.String(),
+				OwnerID:     validOwner.String(),
+				Title:       "",
+				Description: "Valid description",
 				IsCompleted: false,
 			},
 			wantErr: true,
@@ -239,7 +258,8 @@ func TestNewTaskFromDB(t *testing.T) {
 }
 
 func TestTask_SetDeadline(t *testing.T) {
-	validDeadline := time.Now().Add(24 * time.Hour)
+	now := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+	validDeadline := now.Add(24 * time.Hour)
 	invalidDeadline := time.Time{}
 
 	tests := []struct {
@@ -264,18 +284,389 @@ func TestTask_SetDeadline(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			task := &models.Task{}
 
-			err := task.SetDeadline(tt.deadline)
+			err := task.SetDeadline(tt.deadline, clock.NewFake(now))
 
 			if tt.expectedErr != nil {
 				require.Error(t, err)
 				require.ErrorIs(t, err, tt.expectedErr)
 				require.Nil(t, task.Deadline())
+				require.True(t, task.UpdatedAt().IsZero())
 				return
 			}
 
 			require.NoError(t, err)
+			require.Equal(t, now, task.UpdatedAt())
 			require.NotNil(t, task.Deadline())
 			require.Equal(t, tt.deadline, task.Deadline().Time())
 		})
 	}
 }
+
+func TestTask_UpdatedAt(t *testing.T) {
+	createdAt := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+	changedAt := createdAt.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		mutate  func(task *models.Task, clk clock.Clock) error
+		touched bool
+	}{
+		{
+			name:    "change title",
+			mutate:  func(task *models.Task, clk clock.Clock) error { return task.ChangeTitle("New title", clk) },
+			touched: true,
+		},
+		{
+			name: "change description",
+			mutate: func(task *models.Task, clk clock.Clock) error {
+				return task.ChangeDescription("New description", clk)
+			},
+			touched: true,
+		},
+		{
+			name: "set deadline",
+			mutate: func(task *models.Task, clk clock.Clock) error {
+				return task.SetDeadline(changedAt.Add(time.Hour), clk)
+			},
+			touched: true,
+		},
+		{
+			name: "remove deadline",
+			mutate: func(task *models.Task, clk clock.Clock) error {
+				task.RemoveDeadline(clk)
+				return nil
+			},
+			touched: true,
+		},
+		{
+			name: "complete",
+			mutate: func(task *models.Task, clk clock.Clock) error {
+				task.Complete(clk)
+				return nil
+			},
+			touched: true,
+		},
+		{
+			name: "reopen not completed task",
+			mutate: func(task *models.Task, clk clock.Clock) error {
+				task.Reopen(clk)
+				return nil
+			},
+			touched: false,
+		},
+		{
+			name:    "invalid title",
+			mutate:  func(task *models.Task, clk clock.Clock) error { return task.ChangeTitle("", clk) },
+			touched: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task, err := models.NewTask("Valid title", "Valid description", uuid.New(), clock.NewFake(createdAt))
+			require.NoError(t, err)
+
+			_ = tt.mutate(task, clock.NewFake(changedAt))
+
+			require.Equal(t, createdAt, task.CreatedAt())
+			if tt.touched {
+				require.Equal(t, changedAt, task.UpdatedAt())
+			} else {
+				require.Equal(t, createdAt, task.UpdatedAt())
+			}
+		})
+	}
+}
+
+func TestTask_CompleteAndReopen(t *testing.T) {
+	createdAt := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(createdAt)
+
+	task, err := models.NewTask("Valid title", "Valid description", uuid.New(), clk)
+	require.NoError(t, err)
+
+	clk.Advance(time.Hour)
+	task.Complete(clk)
+
+	require.True(t, task.IsCompleted())
+	require.NotNil(t, task.CompletedAt())
+	require.Equal(t, createdAt.Add(time.Hour), *task.CompletedAt())
+	require.Equal(t, createdAt.Add(time.Hour), task.UpdatedAt())
+
+	// completing an already completed task keeps the original timestamp
+	clk.Advance(time.Hour)
+	task.Complete(clk)
+
+	require.Equal(t, createdAt.Add(time.Hour), *task.CompletedAt())
+	require.Equal(t, createdAt.Add(time.Hour), task.UpdatedAt())
+
+	task.Reopen(clk)
+
+	require.False(t, task.IsCompleted())
+	require.Nil(t, task.CompletedAt())
+	require.Equal(t, createdAt.Add(2*time.Hour), task.UpdatedAt())
+}
+
+func TestTask_IsOverdue(t *testing.T) {
+	now := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(now)
+
+	withoutDeadline, err := models.NewTask("Valid title", "", uuid.New(), clk)
+	require.NoError(t, err)
+
+	withDeadline, err := models.NewTaskWithDeadline("Valid title", "", uuid.New(), now.Add(time.Hour), clk)
+	require.NoError(t, err)
+
+	completed, err := models.NewTaskWithDeadline("Valid title", "", uuid.New(), now.Add(time.Hour), clk)
+	require.NoError(t, err)
+	completed.Complete(clk)
+
+	require.False(t, withoutDeadline.IsOverdue(clk))
+	require.False(t, withDeadline.IsOverdue(clk))
+	require.False(t, completed.IsOverdue(clk))
+
+	clk.Advance(time.Hour)
+
+	require.False(t, withoutDeadline.IsOverdue(clk))
+	require.True(t, withDeadline.IsOverdue(clk))
+	require.False(t, completed.IsOverdue(clk))
+}
+
+func TestTask_MoveToTrashAndRestore(t *testing.T) {
+	createdAt := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(createdAt)
+
+	task, err := models.NewTask("Valid title", "Valid description", uuid.New(), clk)
+	require.NoError(t, err)
+	require.False(t, task.IsDeleted())
+	require.Nil(t, task.DeletedAt())
+
+	clk.Advance(time.Hour)
+	task.MoveToTrash(clk)
+
+	require.True(t, task.IsDeleted())
+	require.Equal(t, createdAt.Add(time.Hour), *task.DeletedAt())
+	require.Equal(t, createdAt.Add(time.Hour), task.UpdatedAt())
+
+	// moving to the trash twice keeps the original deletion time
+	clk.Advance(time.Hour)
+	task.MoveToTrash(clk)
+
+	require.Equal(t, createdAt.Add(time.Hour), *task.DeletedAt())
+	require.Equal(t, createdAt.Add(time.Hour), task.UpdatedAt())
+
+	task.Restore(clk)
+
+	require.False(t, task.IsDeleted())
+	require.Nil(t, task.DeletedAt())
+	require.Equal(t, createdAt.Add(2*time.Hour), task.UpdatedAt())
+
+	// restoring a task that is not in the trash does nothing
+	clk.Advance(time.Hour)
+	task.Restore(clk)
+
+	require.Equal(t, createdAt.Add(2*time.Hour), task.UpdatedAt())
+}
+
+func TestTask_ArchiveAndUnarchive(t *testing.T) {
+	createdAt := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+
+	t.Run("completed task", func(t *testing.T) {
+		clk := clock.NewFake(createdAt)
+
+		task, err := models.NewTask("Valid title", "Valid description", uuid.New(), clk)
+		require.NoError(t, err)
+		require.False(t, task.IsArchived())
+		require.Nil(t, task.ArchivedAt())
+
+		task.Complete(clk)
+
+		clk.Advance(time.Hour)
+		err = task.Archive(false, clk)
+		require.NoError(t, err)
+
+		require.True(t, task.IsArchived())
+		require.Equal(t, createdAt.Add(time.Hour), *task.ArchivedAt())
+		require.Equal(t, createdAt.Add(time.Hour), task.UpdatedAt())
+
+		// archiving twice keeps the original archiving time
+		clk.Advance(time.Hour)
+		err = task.Archive(false, clk)
+		require.NoError(t, err)
+
+		require.Equal(t, createdAt.Add(time.Hour), *task.ArchivedAt())
+		require.Equal(t, createdAt.Add(time.Hour), task.UpdatedAt())
+
+		task.Unarchive(clk)
+
+		require.False(t, task.IsArchived())
+		require.Nil(t, task.ArchivedAt())
+		require.Equal(t, createdAt.Add(2*time.Hour), task.UpdatedAt())
+
+		// unarchiving a task that is not archived does nothing
+		clk.Advance(time.Hour)
+		task.Unarchive(clk)
+
+		require.Equal(t, createdAt.Add(2*time.Hour), task.UpdatedAt())
+	})
+
+	t.Run("not completed task", func(t *testing.T) {
+		clk := clock.NewFake(createdAt)
+
+		task, err := models.NewTask("Valid title", "Valid description", uuid.New(), clk)
+		require.NoError(t, err)
+
+		clk.Advance(time.Hour)
+		err = task.Archive(false, clk)
+		require.ErrorIs(t, err, models.ErrTaskNotCompleted)
+		require.False(t, task.IsArchived())
+		require.Equal(t, createdAt, task.UpdatedAt())
+
+		err = task.Archive(true, clk)
+		require.NoError(t, err)
+		require.True(t, task.IsArchived())
+		require.False(t, task.IsCompleted())
+		require.Equal(t, createdAt.Add(time.Hour), *task.ArchivedAt())
+	})
+}
+
+func TestTask_RevertTo(t *testing.T) {
+	createdAt := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		clk := clock.NewFake(createdAt)
+
+		task, err := models.NewTaskWithDeadline("Old title", "Old description", uuid.New(), createdAt.Add(48*time.Hour), clk)
+		require.NoError(t, err)
+
+		state := task.State()
+
+		clk.Advance(time.Hour)
+		require.NoError(t, task.ChangeTitle("New title", clk))
+		require.NoError(t, task.ChangeDescription("New description", clk))
+		task.RemoveDeadline(clk)
+		task.Complete(clk)
+
+		clk.Advance(time.Hour)
+		err = task.RevertTo(state, clk)
+		require.NoError(t, err)
+
+		require.Equal(t, "Old title", task.Title().String())
+		require.Equal(t, "Old description", task.Description().String())
+		require.NotNil(t, task.Deadline())
+		require.Equal(t, createdAt.Add(48*time.Hour), task.Deadline().Time())
+		require.False(t, task.IsCompleted())
+		require.Equal(t, createdAt.Add(2*time.Hour), task.UpdatedAt())
+		require.Empty(t, state.Diff(task.State()))
+	})
+
+	t.Run("time of completion is restored", func(t *testing.T) {
+		clk := clock.NewFake(createdAt)
+
+		task, err := models.NewTask("Title", "Description", uuid.New(), clk)
+		require.NoError(t, err)
+
+		clk.Advance(time.Hour)
+		task.Complete(clk)
+
+		state := task.State()
+
+		clk.Advance(time.Hour)
+		task.Reopen(clk)
+
+		clk.Advance(time.Hour)
+		err = task.RevertTo(state, clk)
+		require.NoError(t, err)
+
+		require.True(t, task.IsCompleted())
+		require.Equal(t, createdAt.Add(time.Hour), *task.CompletedAt())
+		require.Equal(t, createdAt.Add(3*time.Hour), task.UpdatedAt())
+	})
+
+	t.Run("contradicting completion", func(t *testing.T) {
+		clk := clock.NewFake(createdAt)
+
+		task, err := models.NewTask("Old title", "Description", uuid.New(), clk)
+		require.NoError(t, err)
+
+		state := task.State()
+		state.Title = "New title"
+		state.IsCompleted = true
+
+		clk.Advance(time.Hour)
+		err = task.RevertTo(state, clk)
+		require.ErrorIs(t, err, models.ErrTaskCompletionInvalid)
+
+		require.Equal(t, "Old title", task.Title().String())
+		require.False(t, task.IsCompleted())
+		require.Equal(t, createdAt, task.UpdatedAt())
+	})
+
+	t.Run("completion in the future", func(t *testing.T) {
+		clk := clock.NewFake(createdAt)
+
+		task, err := models.NewTask("Title", "Description", uuid.New(), clk)
+		require.NoError(t, err)
+
+		completedAt := createdAt.Add(time.Hour)
+		state := task.State()
+		state.IsCompleted = true
+		state.CompletedAt = &completedAt
+
+		err = task.RevertTo(state, clk)
+		require.ErrorIs(t, err, models.ErrTaskCompletionInvalid)
+
+		require.False(t, task.IsCompleted())
+		require.Nil(t, task.CompletedAt())
+	})
+
+	t.Run("deadline in the past", func(t *testing.T) {
+		clk := clock.NewFake(createdAt)
+
+		task, err := models.NewTaskWithDeadline("Old title", "Description", uuid.New(), createdAt.Add(time.Hour), clk)
+		require.NoError(t, err)
+
+		state := task.State()
+
+		require.NoError(t, task.ChangeTitle("New title", clk))
+		task.RemoveDeadline(clk)
+
+		clk.Advance(2 * time.Hour)
+		err = task.RevertTo(state, clk)
+		require.ErrorIs(t, err, vo.ErrDeadlineBeforeNow)
+
+		require.Equal(t, "New title", task.Title().String())
+		require.Nil(t, task.Deadline())
+		require.Equal(t, createdAt, task.UpdatedAt())
+	})
+
+	t.Run("unchanged deadline in the past is kept", func(t *testing.T) {
+		clk := clock.NewFake(createdAt)
+
+		task, err := models.NewTaskWithDeadline("Old title", "Description", uuid.New(), createdAt.Add(time.Hour), clk)
+		require.NoError(t, err)
+
+		state := task.State()
+
+		require.NoError(t, task.ChangeTitle("New title", clk))
+
+		clk.Advance(2 * time.Hour)
+		err = task.RevertTo(state, clk)
+		require.NoError(t, err)
+
+		require.Equal(t, "Old title", task.Title().String())
+		require.Equal(t, createdAt.Add(time.Hour), task.Deadline().Time())
+	})
+
+	t.Run("nothing to revert", func(t *testing.T) {
+		clk := clock.NewFake(createdAt)
+
+		task, err := models.NewTask("Title", "Description", uuid.New(), clk)
+		require.NoError(t, err)
+
+		clk.Advance(time.Hour)
+		err = task.RevertTo(task.State(), clk)
+		require.NoError(t, err)
+		require.Equal(t, createdAt, task.UpdatedAt())
+	})
+}
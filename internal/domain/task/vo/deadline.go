@@ -3,6 +3,8 @@ package vo
 import (
 	"errors"
 	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
 )
 
 // Deadline is a VO that represents a deadline for the task.
@@ -13,14 +15,22 @@ type Deadline struct {
 var ErrDeadlineBeforeNow = errors.New("deadline is in the past")
 
 // NewDeadline creates a new Deadline instance.
-func NewDeadline(value time.Time) (Deadline, error) {
-	if value.Before(time.Now()) {
+// It returns ErrDeadlineBeforeNow if value is not after the current time of clk.
+func NewDeadline(value time.Time, clk clock.Clock) (Deadline, error) {
+	if !value.After(clk.Now()) {
 		return Deadline{}, ErrDeadlineBeforeNow
 	}
 
 	return Deadline{value: value}, nil
 }
 
+// RestoreDeadline creates a Deadline from a value that has already been
+// validated, e.g. loaded from the storage. Unlike NewDeadline, it accepts
+// values in the past, because a stored deadline may have passed since it was set.
+func RestoreDeadline(value time.Time) Deadline {
+	return Deadline{value: value}
+}
+
 func (d Deadline) Time() time.Time {
 	return d.value
 }
@@ -35,7 +45,8 @@ func (d Deadline) IsAfter(t time.Time) bool {
 	return d.value.After(t)
 }
 
-// IsOverdue checks if the deadline is overdue.
-func (d Deadline) IsOverdue() bool {
-	return d.value.Before(time.Now())
+// IsOverdue checks if the deadline is overdue according to clk.
+// A deadline that equals the current time is considered overdue.
+func (d Deadline) IsOverdue(clk clock.Clock) bool {
+	return !d.value.After(clk.Now())
 }
@@ -4,11 +4,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
 	"github.com/stretchr/testify/require"
 )
 
 func TestNewDeadline(t *testing.T) {
-	now := time.Now()
+	now := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(now)
 
 	tests := []struct {
 		name      string
@@ -16,15 +18,16 @@ func TestNewDeadline(t *testing.T) {
 		wantError bool
 	}{
 		{"Future date", now.Add(time.Hour), false},
+		{"One nanosecond ahead", now.Add(time.Nanosecond), false},
 		{"Now", now, true},
 		{"Past date", now.Add(-time.Hour), true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			d, err := NewDeadline(tt.input)
+			d, err := NewDeadline(tt.input, clk)
 			if tt.wantError {
-				require.Error(t, err)
+				require.ErrorIs(t, err, ErrDeadlineBeforeNow)
 			} else {
 				require.NoError(t, err)
 				require.Equal(t, tt.input, d.Time())
@@ -33,12 +36,19 @@ func TestNewDeadline(t *testing.T) {
 	}
 }
 
+func TestRestoreDeadline(t *testing.T) {
+	past := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	d := RestoreDeadline(past)
+	require.Equal(t, past, d.Time())
+}
+
 func TestDeadline_IsBefore_IsAfter(t *testing.T) {
-	now := time.Now()
+	now := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
 	future := now.Add(time.Hour)
 	past := now.Add(-time.Hour)
 
-	d, err := NewDeadline(future)
+	d, err := NewDeadline(future, clock.NewFake(now))
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -61,7 +71,8 @@ func TestDeadline_IsBefore_IsAfter(t *testing.T) {
 }
 
 func TestDeadline_IsOverdue(t *testing.T) {
-	now := time.Now()
+	now := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(now)
 
 	tests := []struct {
 		name     string
@@ -76,7 +87,22 @@ func TestDeadline_IsOverdue(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			d := Deadline{value: tt.input}
-			require.Equal(t, tt.wantOver, d.IsOverdue())
+			require.Equal(t, tt.wantOver, d.IsOverdue(clk))
 		})
 	}
 }
+
+func TestDeadline_IsOverdue_Transition(t *testing.T) {
+	now := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(now)
+
+	d, err := NewDeadline(now.Add(time.Hour), clk)
+	require.NoError(t, err)
+	require.False(t, d.IsOverdue(clk))
+
+	clk.Advance(time.Hour - time.Nanosecond)
+	require.False(t, d.IsOverdue(clk))
+
+	clk.Advance(time.Nanosecond)
+	require.True(t, d.IsOverdue(clk))
+}
@@ -0,0 +1,145 @@
+package vo
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// SearchQuery is a VO that represents a full-text search query over tasks.
+//
+// A query consists of terms separated by whitespace. A term is either a single
+// word or a phrase enclosed in double quotes, whose words must appear next to each other.
+// A term that ends with an asterisk matches the words starting with its last word.
+type SearchQuery struct {
+	terms []SearchTerm
+}
+
+// SearchTerm is a single term of a SearchQuery.
+type SearchTerm struct {
+	words  []string
+	prefix bool
+}
+
+const (
+	SearchQueryMaxLength = 200
+	SearchQueryMaxTerms  = 10
+)
+
+var (
+	ErrSearchQueryEmpty        = errors.New("search query is empty")
+	ErrSearchQueryTooLong      = errors.New("search query is too long")
+	ErrSearchQueryTooManyTerms = errors.New("search query has too many terms")
+)
+
+// NewSearchQuery parses the given value into a new SearchQuery instance.
+// Punctuation inside the terms is treated as a word separator and an unterminated
+// quote makes the rest of the value a phrase.
+func NewSearchQuery(value string) (SearchQuery, error) {
+	value = strings.TrimSpace(value)
+
+	if len([]rune(value)) > SearchQueryMaxLength {
+		return SearchQuery{}, ErrSearchQueryTooLong
+	}
+
+	var terms []SearchTerm
+
+	for rest := value; rest != ""; {
+		var raw string
+
+		if strings.HasPrefix(rest, `"`) {
+			raw, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			end := strings.IndexFunc(rest, unicode.IsSpace)
+			if end < 0 {
+				end = len(rest)
+			}
+
+			raw, rest = rest[:end], rest[end:]
+		}
+
+		prefix := false
+		if trimmed := strings.TrimRightFunc(raw, unicode.IsSpace); strings.HasSuffix(trimmed, "*") {
+			raw, prefix = trimmed, true
+		} else if strings.HasPrefix(rest, "*") {
+			// the asterisk follows the closing quote of a phrase
+			rest, prefix = rest[1:], true
+		}
+
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+
+		words := SearchWords(raw)
+		if len(words) == 0 {
+			continue
+		}
+
+		terms = append(terms, SearchTerm{words: words, prefix: prefix})
+	}
+
+	if len(terms) == 0 {
+		return SearchQuery{}, ErrSearchQueryEmpty
+	}
+
+	if len(terms) > SearchQueryMaxTerms {
+		return SearchQuery{}, ErrSearchQueryTooManyTerms
+	}
+
+	return SearchQuery{terms: terms}, nil
+}
+
+// Terms returns the terms of the query, all of which a task has to match.
+func (q SearchQuery) Terms() []SearchTerm {
+	terms := make([]SearchTerm, len(q.terms))
+	copy(terms, q.terms)
+
+	return terms
+}
+
+// String returns the normalized representation of the query.
+func (q SearchQuery) String() string {
+	parts := make([]string, len(q.terms))
+	for i, term := range q.terms {
+		parts[i] = term.String()
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Words returns the lowercase words of the term in their order.
+func (t SearchTerm) Words() []string {
+	words := make([]string, len(t.words))
+	copy(words, t.words)
+
+	return words
+}
+
+// IsPhrase reports whether the term consists of more than one word.
+func (t SearchTerm) IsPhrase() bool {
+	return len(t.words) > 1
+}
+
+// IsPrefix reports whether the last word of the term matches any word starting with it.
+func (t SearchTerm) IsPrefix() bool {
+	return t.prefix
+}
+
+func (t SearchTerm) String() string {
+	s := strings.Join(t.words, " ")
+	if t.prefix {
+		s += "*"
+	}
+
+	if t.IsPhrase() {
+		s = `"` + s + `"`
+	}
+
+	return s
+}
+
+// SearchWords splits the text into lowercase words the same way search queries are split.
+// Any character that is neither a letter nor a digit separates words.
+func SearchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,124 @@
+package vo_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/vo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSearchQuery(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantErr    error
+		wantString string
+		wantTerms  int
+	}{
+		{
+			name:       "single word",
+			input:      "Milk",
+			wantString: "milk",
+			wantTerms:  1,
+		},
+		{
+			name:       "several words",
+			input:      "  buy   fresh milk ",
+			wantString: "buy fresh milk",
+			wantTerms:  3,
+		},
+		{
+			name:       "quoted phrase",
+			input:      `"Buy milk" today`,
+			wantString: `"buy milk" today`,
+			wantTerms:  2,
+		},
+		{
+			name:       "prefix word",
+			input:      "rep*",
+			wantString: "rep*",
+			wantTerms:  1,
+		},
+		{
+			name:       "prefix inside phrase",
+			input:      `"buy mil*"`,
+			wantString: `"buy mil*"`,
+			wantTerms:  1,
+		},
+		{
+			name:       "prefix after phrase",
+			input:      `"buy mil"* now`,
+			wantString: `"buy mil*" now`,
+			wantTerms:  2,
+		},
+		{
+			name:       "unterminated quote",
+			input:      `today "buy milk`,
+			wantString: `today "buy milk"`,
+			wantTerms:  2,
+		},
+		{
+			name:       "punctuation splits words",
+			input:      "e-mail, report!",
+			wantString: `"e mail" report`,
+			wantTerms:  2,
+		},
+		{
+			name:       "cyrillic words",
+			input:      "Купить МОЛОКО",
+			wantString: "купить молоко",
+			wantTerms:  2,
+		},
+		{
+			name:    "empty query",
+			input:   "   ",
+			wantErr: vo.ErrSearchQueryEmpty,
+		},
+		{
+			name:    "only punctuation",
+			input:   `"" * -`,
+			wantErr: vo.ErrSearchQueryEmpty,
+		},
+		{
+			name:    "query too long",
+			input:   strings.Repeat("a", vo.SearchQueryMaxLength+1),
+			wantErr: vo.ErrSearchQueryTooLong,
+		},
+		{
+			name:    "too many terms",
+			input:   strings.Repeat("word ", vo.SearchQueryMaxTerms+1),
+			wantErr: vo.ErrSearchQueryTooManyTerms,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := vo.NewSearchQuery(tt.input)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantString, query.String())
+				require.Equal(t, tt.wantTerms, len(query.Terms()))
+			}
+		})
+	}
+}
+
+func TestSearchTerm(t *testing.T) {
+	query, err := vo.NewSearchQuery(`"buy milk"* report`)
+	require.NoError(t, err)
+
+	terms := query.Terms()
+	require.Equal(t, 2, len(terms))
+
+	require.True(t, terms[0].IsPhrase())
+	require.True(t, terms[0].IsPrefix())
+	require.Equal(t, []string{"buy", "milk"}, terms[0].Words())
+
+	require.False(t, terms[1].IsPhrase())
+	require.False(t, terms[1].IsPrefix())
+	require.Equal(t, []string{"report"}, terms[1].Words())
+}
@@ -2,8 +2,10 @@ package models
 
 import (
 	"errors"
+	"time"
 
 	"github.com/cyberbrain-dev/taskery-api/internal/domain/user/vo"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
 	"github.com/google/uuid"
 )
 
@@ -14,6 +16,9 @@ type User struct {
 	username     vo.Username
 	email        vo.Email
 	passwordHash vo.Password
+	createdAt    time.Time
+	updatedAt    time.Time
+	version      int64
 }
 
 // ID returns the user's unique identifier.
@@ -28,6 +33,16 @@ func (u *User) Email() vo.Email { return u.email }
 // PasswordHash returns the user's password hash value object.
 func (u *User) PasswordHash() vo.Password { return u.passwordHash }
 
+// CreatedAt returns the time when the user was registered.
+func (u *User) CreatedAt() time.Time { return u.createdAt }
+
+// UpdatedAt returns the time of the last change of the user.
+func (u *User) UpdatedAt() time.Time { return u.updatedAt }
+
+// Version returns the version of the user that was loaded from the storage.
+// A newly created user has version 1.
+func (u *User) Version() int64 { return u.version }
+
 var (
 	// ErrUserIDInvalid indicates that a provided user ID is not a valid UUID.
 	ErrUserIDInvalid = errors.New("user ID is invalid")
@@ -35,8 +50,9 @@ var (
 
 // NewUser creates a new User from raw string inputs.
 // It validates the username, email, and password, converts them into value objects,
-// hashes the password, and generates a new UUID for the user
-func NewUser(username, email, password string) (*User, error) {
+// hashes the password, and generates a new UUID for the user.
+// The creation and update timestamps are taken from clk.
+func NewUser(username, email, password string, clk clock.Clock) (*User, error) {
 	usernameVO, err := vo.NewUsername(username)
 	if err != nil {
 		return nil, err
@@ -52,11 +68,16 @@ func NewUser(username, email, password string) (*User, error) {
 		return nil, err
 	}
 
+	now := clk.Now()
+
 	return &User{
 		id:           uuid.New(),
 		username:     usernameVO,
 		email:        emailVO,
 		passwordHash: passwordVO,
+		createdAt:    now,
+		updatedAt:    now,
+		version:      1,
 	}, nil
 }
 
@@ -73,6 +94,9 @@ type UserFromDBParams struct {
 	Username     string
 	Email        string
 	PasswordHash string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	Version      int64
 }
 
 // NewUserFromDB creates a new User with a specified UUID.
@@ -101,6 +125,9 @@ func NewUserFromDB(p UserFromDBParams) (*User, error) {
 		username:     usernameVO,
 		email:        emailVO,
 		passwordHash: passwordVO,
+		createdAt:    p.CreatedAt,
+		updatedAt:    p.UpdatedAt,
+		version:      p.Version,
 	}
 
 	return user, nil
@@ -108,25 +135,27 @@ func NewUserFromDB(p UserFromDBParams) (*User, error) {
 
 // ChangeUsername updates the user's username after validating it.
 // Returns an error if the new username is invalid.
-func (u *User) ChangeUsername(new string) error {
+func (u *User) ChangeUsername(new string, clk clock.Clock) error {
 	newUsernameVO, err := vo.NewUsername(new)
 	if err != nil {
 		return err
 	}
 
 	u.username = newUsernameVO
+	u.updatedAt = clk.Now()
 	return nil
 }
 
 // ChangeEmail updates the user's email after validating it.
 // Returns an error if the new email is invalid.
-func (u *User) ChangeEmail(new string) error {
+func (u *User) ChangeEmail(new string, clk clock.Clock) error {
 	newEmailVO, err := vo.NewEmail(new)
 	if err != nil {
 		return err
 	}
 
 	u.email = newEmailVO
+	u.updatedAt = clk.Now()
 
 	return nil
 }
@@ -134,7 +163,7 @@ func (u *User) ChangeEmail(new string) error {
 // ChangePassword updates the user's password.
 // The old password is verified in this method.
 // Returns an error if verification fails or the new password is invalid.
-func (u *User) ChangePassword(old, new string) error {
+func (u *User) ChangePassword(old, new string, clk clock.Clock) error {
 	err := u.passwordHash.Verify(old)
 	if err != nil {
 		return err
@@ -146,5 +175,6 @@ func (u *User) ChangePassword(old, new string) error {
 	}
 
 	u.passwordHash = newPasswordVO
+	u.updatedAt = clk.Now()
 	return nil
 }
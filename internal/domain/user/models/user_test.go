@@ -2,14 +2,18 @@ package models_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/cyberbrain-dev/taskery-api/internal/domain/user/models"
 	"github.com/cyberbrain-dev/taskery-api/internal/domain/user/vo"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
 func TestNewUser(t *testing.T) {
+	now := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+
 	tests := []struct {
 		name        string
 		username    string
@@ -49,7 +53,7 @@ func TestNewUser(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			u, err := models.NewUser(tt.username, tt.email, tt.password)
+			u, err := models.NewUser(tt.username, tt.email, tt.password, clock.NewFake(now))
 
 			if tt.expectedErr != nil {
 				require.ErrorIs(t, err, tt.expectedErr)
@@ -59,6 +63,8 @@ func TestNewUser(t *testing.T) {
 			require.NoError(t, err)
 			require.NotNil(t, u)
 			require.NotEqual(t, uuid.Nil, u.ID())
+			require.Equal(t, now, u.CreatedAt())
+			require.Equal(t, now, u.UpdatedAt())
 
 			// Check that VOs are populated correctly
 			require.Equal(t, tt.username, u.Username().String())
@@ -125,7 +131,10 @@ func TestNewUserFromDB(t *testing.T) {
 }
 
 func TestChangeUsername(t *testing.T) {
-	u, err := models.NewUser("john_doe", "john@example.com", "Str0ngP@ssw0rd!")
+	createdAt := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(createdAt)
+
+	u, err := models.NewUser("john_doe", "john@example.com", "Str0ngP@ssw0rd!", clk)
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -147,19 +156,27 @@ func TestChangeUsername(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := u.ChangeUsername(tt.newVal)
+			before := u.UpdatedAt()
+			clk.Advance(time.Minute)
+
+			err := u.ChangeUsername(tt.newVal, clk)
 			if tt.wantErr {
 				require.Error(t, err)
+				require.Equal(t, before, u.UpdatedAt())
 				return
 			}
 			require.NoError(t, err)
+			require.Equal(t, clk.Now(), u.UpdatedAt())
 			require.Equal(t, tt.newVal, u.Username().String())
 		})
 	}
 }
 
 func TestChangeEmail(t *testing.T) {
-	u, err := models.NewUser("john_doe", "john@example.com", "Str0ngP@ssw0rd!")
+	createdAt := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(createdAt)
+
+	u, err := models.NewUser("john_doe", "john@example.com", "Str0ngP@ssw0rd!", clk)
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -181,19 +198,27 @@ func TestChangeEmail(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := u.ChangeEmail(tt.newVal)
+			before := u.UpdatedAt()
+			clk.Advance(time.Minute)
+
+			err := u.ChangeEmail(tt.newVal, clk)
 			if tt.wantErr {
 				require.Error(t, err)
+				require.Equal(t, before, u.UpdatedAt())
 				return
 			}
 			require.NoError(t, err)
+			require.Equal(t, clk.Now(), u.UpdatedAt())
 			require.Equal(t, tt.newVal, u.Email().String())
 		})
 	}
 }
 
 func TestChangePassword(t *testing.T) {
-	u, err := models.NewUser("john_doe", "john@example.com", "Str0ngP@ssw0rd!")
+	createdAt := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(createdAt)
+
+	u, err := models.NewUser("john_doe", "john@example.com", "Str0ngP@ssw0rd!", clk)
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -224,12 +249,17 @@ func TestChangePassword(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := u.ChangePassword(tt.oldPass, tt.newPass)
+			before := u.UpdatedAt()
+			clk.Advance(time.Minute)
+
+			err := u.ChangePassword(tt.oldPass, tt.newPass, clk)
 			if tt.wantErr {
 				require.Error(t, err)
+				require.Equal(t, before, u.UpdatedAt())
 				return
 			}
 			require.NoError(t, err)
+			require.Equal(t, clk.Now(), u.UpdatedAt())
 			// Verify the new password works
 			require.NoError(t, u.PasswordHash().Verify(tt.newPass))
 			// And old no longer works
@@ -1,30 +1,60 @@
 package jwt
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
 	"github.com/golang-jwt/jwt/v5"
 )
 
 // Provider is responsible for issuing and validating JSON Web Tokens (JWT).
 //
 // It encapsulates the signing secret, token time-to-live (TTL),
-// and the issuer identifier used in JWT claims.
+// the issuer identifier used in JWT claims and the clock
+// used to issue tokens and check their expiration.
 // The provider is typically used by authentication or authorization
 // layers to generate access tokens and verify their validity.
 type Provider struct {
 	secret []byte
 	ttl    time.Duration
 	issuer string
+	clock  clock.Clock
 }
 
-// NewProvider a new instance of jwt.Provider
-func NewProvider(secret []byte, ttl time.Duration, issuer string) *Provider {
-	return &Provider{secret: secret, ttl: ttl, issuer: issuer}
+// NewProvider a new instance of jwt.Provider.
+// If clk is nil, the system clock is used.
+func NewProvider(secret []byte, ttl time.Duration, issuer string, clk clock.Clock) *Provider {
+	if clk == nil {
+		clk = clock.Real{}
+	}
+
+	return &Provider{secret: secret, ttl: ttl, issuer: issuer, clock: clk}
 }
 
+// Errors returned by Provider.Validate. The error chain also contains
+// the underlying error of the JWT library, if any.
+var (
+	// ErrTokenMalformed is returned if the token cannot be parsed.
+	ErrTokenMalformed = errors.New("token is malformed")
+
+	// ErrTokenExpired is returned if the token has expired and a new one has to be obtained.
+	ErrTokenExpired = errors.New("token is expired")
+
+	// ErrTokenSignatureInvalid is returned if the token is not signed with the provider's secret
+	// or is signed with an unexpected method.
+	ErrTokenSignatureInvalid = errors.New("token signature is invalid")
+
+	// ErrTokenIssuerInvalid is returned if the token was issued by another issuer.
+	ErrTokenIssuerInvalid = errors.New("token issuer is invalid")
+
+	// ErrTokenInvalid is returned if the token is invalid for any other reason,
+	// e.g. it is not valid yet or has no subject.
+	ErrTokenInvalid = errors.New("token is invalid")
+)
+
 type Claims struct {
 	jwt.RegisteredClaims
 }
@@ -40,12 +70,14 @@ type Claims struct {
 func (p *Provider) Generate(userID string) (string, error) {
 	const op = "jwt.Provider.Generate"
 
+	now := p.clock.Now()
+
 	claims := &Claims{
 		jwt.RegisteredClaims{
 			Issuer:    p.issuer,
 			Subject:   userID,
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(p.ttl)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(now.Add(p.ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
 
@@ -59,7 +91,11 @@ func (p *Provider) Generate(userID string) (string, error) {
 	return signed, nil
 }
 
-// Validate checks a JWT token and returns its "sub" claim if valid. It returns user's ID and an error
+// Validate checks a JWT token and returns its "sub" claim if valid. It returns user's ID and an error.
+//
+// The returned error matches one of ErrTokenMalformed, ErrTokenExpired, ErrTokenSignatureInvalid,
+// ErrTokenIssuerInvalid and ErrTokenInvalid, so that the caller can tell the client
+// whether the token has to be refreshed.
 func (p *Provider) Validate(token string) (string, error) {
 	const op = "jwt.Provider.Validate"
 
@@ -70,33 +106,51 @@ func (p *Provider) Validate(token string) (string, error) {
 		claims,
 		func(token *jwt.Token) (any, error) {
 			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("%s: unexpected signing method: %v", op, token.Header["alg"])
+				return nil, fmt.Errorf("%w: unexpected signing method: %v", ErrTokenSignatureInvalid, token.Header["alg"])
 			}
 
 			return p.secret, nil
 		},
+		jwt.WithTimeFunc(p.clock.Now),
 	)
 	if err != nil {
-		return "", fmt.Errorf("%s: parse token: %w", op, err)
+		return "", fmt.Errorf("%s: %w: %w", op, validationError(err), err)
 	}
 
 	if !parsedToken.Valid {
-		return "", fmt.Errorf("%s: token is invalid", op)
+		return "", fmt.Errorf("%s: %w", op, ErrTokenInvalid)
 	}
 
 	if claims.Issuer != p.issuer {
-		return "", fmt.Errorf("%s: invalid issuer", op)
+		return "", fmt.Errorf("%s: %w", op, ErrTokenIssuerInvalid)
 	}
 
-	if claims.ExpiresAt == nil || claims.ExpiresAt.Time.Before(time.Now()) {
-		return "", fmt.Errorf("%s: expired token", op)
+	if claims.ExpiresAt == nil || !claims.ExpiresAt.Time.After(p.clock.Now()) {
+		return "", fmt.Errorf("%s: %w", op, ErrTokenExpired)
 	}
 
 	if claims.Subject == "" {
-		return "", fmt.Errorf("%s: subject is empty", op)
+		return "", fmt.Errorf("%s: %w: subject is empty", op, ErrTokenInvalid)
 	}
 
 	return claims.Subject, nil
 }
 
+// validationError maps an error of the JWT library to the error returned by Provider.Validate.
+func validationError(err error) error {
+	switch {
+	case errors.Is(err, jwt.ErrTokenMalformed):
+		return ErrTokenMalformed
+
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return ErrTokenExpired
+
+	case errors.Is(err, ErrTokenSignatureInvalid), errors.Is(err, jwt.ErrTokenSignatureInvalid):
+		return ErrTokenSignatureInvalid
+
+	default:
+		return ErrTokenInvalid
+	}
+}
+
 var _ services.TokenProvider = (*Provider)(nil)
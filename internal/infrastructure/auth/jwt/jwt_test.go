@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/auth/jwt"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
+	jwtlib "github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/require"
 )
 
@@ -13,6 +15,7 @@ func TestProvider_GenerateAndValidate_OK(t *testing.T) {
 		[]byte("test-secret"),
 		time.Minute,
 		"test-issuer",
+		clock.Real{},
 	)
 
 	userID := "test-user-123"
@@ -27,23 +30,49 @@ func TestProvider_GenerateAndValidate_OK(t *testing.T) {
 }
 
 func TestProvider_GenerateAndValidate_Expired(t *testing.T) {
-	p := jwt.NewProvider(
-		[]byte("test-secret"),
-		10*time.Millisecond,
-		"test-issuer",
-	)
-
-	userID := "test-user-123"
-
-	token, err := p.Generate(userID)
-	require.NoError(t, err)
-	require.NotEmpty(t, token)
-
-	time.Sleep(20 * time.Millisecond)
-
-	got, err := p.Validate(token)
-	require.Error(t, err)
-	require.Empty(t, got)
+	issuedAt := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		wantErr bool
+	}{
+		{"just issued", 0, false},
+		{"one second before expiry", time.Minute - time.Second, false},
+		{"at expiry", time.Minute, true},
+		{"after expiry", time.Hour, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clk := clock.NewFake(issuedAt)
+
+			p := jwt.NewProvider(
+				[]byte("test-secret"),
+				time.Minute,
+				"test-issuer",
+				clk,
+			)
+
+			userID := "test-user-123"
+
+			token, err := p.Generate(userID)
+			require.NoError(t, err)
+			require.NotEmpty(t, token)
+
+			clk.Advance(tt.elapsed)
+
+			got, err := p.Validate(token)
+			if tt.wantErr {
+				require.ErrorIs(t, err, jwt.ErrTokenExpired)
+				require.Empty(t, got)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, userID, got)
+		})
+	}
 }
 
 func TestProvider_GenerateAndValidate_WrongIssuer(t *testing.T) {
@@ -51,6 +80,7 @@ func TestProvider_GenerateAndValidate_WrongIssuer(t *testing.T) {
 		[]byte("test-secret"),
 		time.Minute,
 		"test-issuer",
+		clock.Real{},
 	)
 
 	userID := "test-user-123"
@@ -63,10 +93,11 @@ func TestProvider_GenerateAndValidate_WrongIssuer(t *testing.T) {
 		[]byte("test-secret"),
 		time.Minute,
 		"another-issuer",
+		clock.Real{},
 	)
 
 	got, err := other.Validate(token)
-	require.Error(t, err)
+	require.ErrorIs(t, err, jwt.ErrTokenIssuerInvalid)
 	require.Empty(t, got)
 }
 
@@ -75,6 +106,7 @@ func TestProvider_GenerateAndValidate_WrongSecret(t *testing.T) {
 		[]byte("test-secret"),
 		time.Minute,
 		"test-issuer",
+		clock.Real{},
 	)
 
 	userID := "test-user-123"
@@ -87,10 +119,11 @@ func TestProvider_GenerateAndValidate_WrongSecret(t *testing.T) {
 		[]byte("another-secret"),
 		time.Minute,
 		"test-issuer",
+		clock.Real{},
 	)
 
 	got, err := other.Validate(token)
-	require.Error(t, err)
+	require.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
 	require.Empty(t, got)
 }
 
@@ -99,9 +132,49 @@ func TestProvider_GenerateAndValidate_InvalidToken(t *testing.T) {
 		[]byte("test-secret"),
 		time.Minute,
 		"test-issuer",
+		clock.Real{},
 	)
 
 	got, err := p.Validate("not a token")
-	require.Error(t, err)
+	require.ErrorIs(t, err, jwt.ErrTokenMalformed)
+	require.Empty(t, got)
+}
+
+func TestProvider_Validate_UnexpectedSigningMethod(t *testing.T) {
+	p := jwt.NewProvider(
+		[]byte("test-secret"),
+		time.Minute,
+		"test-issuer",
+		clock.Real{},
+	)
+
+	now := time.Now()
+
+	token, err := jwtlib.NewWithClaims(jwtlib.SigningMethodNone, jwtlib.RegisteredClaims{
+		Issuer:    "test-issuer",
+		Subject:   "test-user-123",
+		ExpiresAt: jwtlib.NewNumericDate(now.Add(time.Minute)),
+		IssuedAt:  jwtlib.NewNumericDate(now),
+	}).SignedString(jwtlib.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	got, err := p.Validate(token)
+	require.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
+	require.Empty(t, got)
+}
+
+func TestProvider_Validate_EmptySubject(t *testing.T) {
+	p := jwt.NewProvider(
+		[]byte("test-secret"),
+		time.Minute,
+		"test-issuer",
+		clock.Real{},
+	)
+
+	token, err := p.Generate("")
+	require.NoError(t, err)
+
+	got, err := p.Validate(token)
+	require.ErrorIs(t, err, jwt.ErrTokenInvalid)
 	require.Empty(t, got)
 }
@@ -0,0 +1,114 @@
+// Package clientip resolves the IP address of the client that made a request,
+// which may be forwarded by the trusted proxies, e.g. the load balancers.
+package clientip
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// ForwardedForHeader is the header that the proxies append the address of their peer to.
+const ForwardedForHeader = "X-Forwarded-For"
+
+// Resolver resolves the IP address of the client of a request.
+//
+// The address of the peer of a request is the address of the client unless the peer is
+// a trusted proxy. Only then the X-Forwarded-For header is taken into account:
+// it is read from the right, skipping the trusted proxies, and the first address that
+// is not one of them is the client. The addresses to the left of it are not trusted,
+// since the client can send any of them.
+//
+// The zero Resolver trusts no proxies, so the address of the peer is always the address of the client.
+type Resolver struct {
+	trusted []netip.Prefix
+}
+
+// NewResolver creates a new Resolver that trusts the proxies with the given addresses.
+// An address is either an IP address or a CIDR range, e.g. "10.0.0.0/8".
+// It returns an error if any of them is invalid.
+func NewResolver(trustedProxies []string) (*Resolver, error) {
+	trusted := make([]netip.Prefix, 0, len(trustedProxies))
+
+	for _, raw := range trustedProxies {
+		if strings.Contains(raw, "/") {
+			prefix, err := netip.ParsePrefix(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %w", raw, err)
+			}
+
+			trusted = append(trusted, prefix.Masked())
+			continue
+		}
+
+		addr, err := netip.ParseAddr(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", raw, err)
+		}
+
+		addr = addr.Unmap()
+		trusted = append(trusted, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+
+	return &Resolver{trusted: trusted}, nil
+}
+
+// ClientIP returns the IP address of the client of a request that came from remoteAddr,
+// which is a "host:port" address or a bare IP, with the given values of the X-Forwarded-For header.
+func (r *Resolver) ClientIP(remoteAddr string, forwardedFor []string) string {
+	peer := host(remoteAddr)
+
+	addr, err := netip.ParseAddr(peer)
+	if err != nil || !r.isTrusted(addr) {
+		return peer
+	}
+
+	hops := make([]string, 0, len(forwardedFor))
+	for _, value := range forwardedFor {
+		for hop := range strings.SplitSeq(value, ",") {
+			hops = append(hops, strings.TrimSpace(hop))
+		}
+	}
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, err := netip.ParseAddr(host(hops[i]))
+		if err != nil {
+			// the hops to the left of a malformed one can not be attributed to anyone
+			break
+		}
+
+		peer = hop.Unmap().String()
+		if !r.isTrusted(hop) {
+			break
+		}
+	}
+
+	return peer
+}
+
+// isTrusted reports whether addr is the address of a trusted proxy.
+func (r *Resolver) isTrusted(addr netip.Addr) bool {
+	if r == nil {
+		return false
+	}
+
+	addr = addr.Unmap()
+	for _, prefix := range r.trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// host returns the host of a "host:port" address, or the address as is if it has no port.
+func host(addr string) string {
+	h, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+
+	return h
+}
@@ -0,0 +1,106 @@
+package clientip_test
+
+import (
+	"testing"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/clientip"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewResolver(t *testing.T) {
+	_, err := clientip.NewResolver([]string{"10.0.0.1", "192.168.0.0/16", "::1"})
+	require.NoError(t, err)
+
+	_, err = clientip.NewResolver([]string{"not-an-ip"})
+	require.Error(t, err)
+
+	_, err = clientip.NewResolver([]string{"10.0.0.0/33"})
+	require.Error(t, err)
+}
+
+func TestResolver_ClientIP(t *testing.T) {
+	resolver, err := clientip.NewResolver([]string{"10.0.0.0/8", "192.168.1.1"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		resolver     *clientip.Resolver
+		remoteAddr   string
+		forwardedFor []string
+		want         string
+	}{
+		{
+			name:       "direct client",
+			resolver:   resolver,
+			remoteAddr: "203.0.113.7:5123",
+			want:       "203.0.113.7",
+		},
+		{
+			name:         "untrusted peer can not forge the header",
+			resolver:     resolver,
+			remoteAddr:   "203.0.113.7:5123",
+			forwardedFor: []string{"198.51.100.1"},
+			want:         "203.0.113.7",
+		},
+		{
+			name:         "trusted proxy",
+			resolver:     resolver,
+			remoteAddr:   "10.1.2.3:443",
+			forwardedFor: []string{"198.51.100.1"},
+			want:         "198.51.100.1",
+		},
+		{
+			name:         "spoofed hops to the left are ignored",
+			resolver:     resolver,
+			remoteAddr:   "10.1.2.3:443",
+			forwardedFor: []string{"1.1.1.1, 198.51.100.1", "192.168.1.1"},
+			want:         "198.51.100.1",
+		},
+		{
+			name:         "only trusted hops",
+			resolver:     resolver,
+			remoteAddr:   "10.1.2.3:443",
+			forwardedFor: []string{"10.0.0.5"},
+			want:         "10.0.0.5",
+		},
+		{
+			name:         "malformed hop",
+			resolver:     resolver,
+			remoteAddr:   "10.1.2.3:443",
+			forwardedFor: []string{"198.51.100.1, garbage"},
+			want:         "10.1.2.3",
+		},
+		{
+			name:       "trusted proxy without the header",
+			resolver:   resolver,
+			remoteAddr: "10.1.2.3:443",
+			want:       "10.1.2.3",
+		},
+		{
+			name:         "zero resolver trusts no proxies",
+			resolver:     &clientip.Resolver{},
+			remoteAddr:   "10.1.2.3:443",
+			forwardedFor: []string{"198.51.100.1"},
+			want:         "10.1.2.3",
+		},
+		{
+			name:         "nil resolver trusts no proxies",
+			resolver:     nil,
+			remoteAddr:   "10.1.2.3:443",
+			forwardedFor: []string{"198.51.100.1"},
+			want:         "10.1.2.3",
+		},
+		{
+			name:       "address without port",
+			resolver:   resolver,
+			remoteAddr: "203.0.113.7",
+			want:       "203.0.113.7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.resolver.ClientIP(tt.remoteAddr, tt.forwardedFor))
+		})
+	}
+}
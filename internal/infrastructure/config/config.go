@@ -1,8 +1,10 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
@@ -13,15 +15,41 @@ import (
 type Configuration struct {
 	Environment        string             `yaml:"env" env-default:"local"`
 	HTTPServer         HTTPServer         `yaml:"http_server" env-required:"true"`
+	GRPCServer         GRPCServer         `yaml:"grpc_server"`
 	PostgresConnection PostgresConnection `yaml:"postgres_connection" env-required:"true"`
 	JWT                JWT                `yaml:"jwt" env-required:"true"`
+	Trash              Trash              `yaml:"trash"`
+	Batch              Batch              `yaml:"batch"`
+	Idempotency        Idempotency        `yaml:"idempotency"`
+	AccessLog          AccessLog          `yaml:"access_log"`
+	Metrics            Metrics            `yaml:"metrics"`
+	Tracing            Tracing            `yaml:"tracing"`
+	Health             Health             `yaml:"health"`
+	RateLimit          RateLimit          `yaml:"rate_limit"`
+	CORS               CORS               `yaml:"cors"`
+	SecurityHeaders    SecurityHeaders    `yaml:"security_headers"`
+	RequestBody        RequestBody        `yaml:"request_body"`
+	TaskEvents         TaskEvents         `yaml:"task_events"`
+	WebSocket          WebSocket          `yaml:"websocket"`
+	GraphQL            GraphQL            `yaml:"graphql"`
 }
 
-// HTTPServer represents config of the application server
+// HTTPServer represents config of the application server.
+// TrustedProxies are the addresses or CIDR ranges of the proxies, e.g. the load balancers,
+// whose X-Forwarded-For header tells the address of the client. Empty trusts no proxies.
 type HTTPServer struct {
-	Address     string        `yaml:"address" env-required:"true"`
-	Timeout     time.Duration `yaml:"timeout" env-required:"true"`
-	IdleTimeout time.Duration `yaml:"idle_timeout" env-required:"true"`
+	Address        string        `yaml:"address" env-required:"true"`
+	Timeout        time.Duration `yaml:"timeout" env-required:"true"`
+	IdleTimeout    time.Duration `yaml:"idle_timeout" env-required:"true"`
+	TrustedProxies []string      `yaml:"trusted_proxies"`
+}
+
+// GRPCServer represents config of the gRPC API for the internal services.
+// If Address is empty, the gRPC calls are served on the address of the HTTP server
+// alongside the REST API over unencrypted HTTP/2 (h2c).
+type GRPCServer struct {
+	Enabled bool   `yaml:"enabled" env-default:"false"`
+	Address string `yaml:"address"`
 }
 
 // PostgresConnection represents config of postgres credentials
@@ -40,6 +68,108 @@ type JWT struct {
 	Issuer string        `yaml:"issuer" env-required:"true"`
 }
 
+// Trash represents config of the deleted tasks retention
+type Trash struct {
+	Retention     time.Duration `yaml:"retention" env-default:"720h"`
+	PurgeInterval time.Duration `yaml:"purge_interval" env-default:"1h"`
+}
+
+// Batch represents config of the bulk task operations, including the changes pushed by a sync
+type Batch struct {
+	MaxSize int `yaml:"max_size" env-default:"100"`
+}
+
+// Idempotency represents config of the idempotency keys storage.
+// Store is either "postgres" or "memory".
+type Idempotency struct {
+	Store         string        `yaml:"store" env-default:"postgres"`
+	TTL           time.Duration `yaml:"ttl" env-default:"24h"`
+	PurgeInterval time.Duration `yaml:"purge_interval" env-default:"1h"`
+}
+
+// AccessLog represents config of the HTTP access log.
+// SampleRate is the fraction of the successful requests that are logged,
+// the failed ones are always logged.
+type AccessLog struct {
+	Enabled    bool    `yaml:"enabled" env-default:"true"`
+	SampleRate float64 `yaml:"sample_rate" env-default:"1"`
+	LogHeaders bool    `yaml:"log_headers" env-default:"false"`
+}
+
+// Metrics represents config of the Prometheus metrics exposed on /metrics
+type Metrics struct {
+	Enabled bool `yaml:"enabled" env-default:"true"`
+}
+
+// Tracing represents config of the OpenTelemetry tracing.
+// Exporter is either "otlp", "stdout" or "none", OTLPEndpoint is used by the "otlp" one.
+type Tracing struct {
+	Exporter     string  `yaml:"exporter" env-default:"none"`
+	OTLPEndpoint string  `yaml:"otlp_endpoint" env-default:"localhost:4318"`
+	OTLPInsecure bool    `yaml:"otlp_insecure" env-default:"false"`
+	SampleRate   float64 `yaml:"sample_rate" env-default:"1"`
+}
+
+// Health represents config of the health checks.
+// CheckTimeout limits every check, DrainDelay is the time between failing the readiness
+// on shutdown and shutting the server down, so that the load balancers stop sending the traffic.
+type Health struct {
+	CheckTimeout time.Duration `yaml:"check_timeout" env-default:"2s"`
+	DrainDelay   time.Duration `yaml:"drain_delay" env-default:"5s"`
+}
+
+// RateLimit represents config of the request rate limits.
+// Store is either "postgres", which is shared by the replicas, or "memory".
+type RateLimit struct {
+	Store         string         `yaml:"store" env-default:"memory"`
+	PurgeInterval time.Duration  `yaml:"purge_interval" env-default:"10m"`
+	Auth          RateLimitGroup `yaml:"auth"`
+	Users         RateLimitGroup `yaml:"users"`
+	Tasks         RateLimitGroup `yaml:"tasks"`
+	Calendar      RateLimitGroup `yaml:"calendar"`
+}
+
+// RateLimitGroup represents the rate limit of a route group:
+// Requests requests per Period, which is also the burst size. Zero requests turn the limit off.
+type RateLimitGroup struct {
+	Requests int           `yaml:"requests" env-default:"100"`
+	Period   time.Duration `yaml:"period" env-default:"1m"`
+}
+
+// CORS represents config of the cross-origin requests from the browsers.
+// No allowed origins turn CORS off, "*" allows any origin.
+type CORS struct {
+	AllowedOrigins   []string      `yaml:"allowed_origins"`
+	AllowedMethods   []string      `yaml:"allowed_methods" env-default:"GET,POST,PATCH,DELETE"`
+	AllowedHeaders   []string      `yaml:"allowed_headers" env-default:"Authorization,Content-Type,If-Match,Idempotency-Key,Last-Event-ID"`
+	ExposedHeaders   []string      `yaml:"exposed_headers" env-default:"ETag,Retry-After,RateLimit-Limit,RateLimit-Remaining,RateLimit-Reset,Idempotent-Replayed"`
+	AllowCredentials bool          `yaml:"allow_credentials" env-default:"false"`
+	MaxAge           time.Duration `yaml:"max_age" env-default:"10m"`
+}
+
+// validate reports an error if the config lets any website make credentialed calls to the API.
+func (c CORS) validate() error {
+	if c.AllowCredentials && slices.Contains(c.AllowedOrigins, "*") {
+		return errors.New(`allow_credentials can not be combined with "*" in allowed_origins`)
+	}
+
+	return nil
+}
+
+// SecurityHeaders represents config of the security headers of the responses.
+// Zero HSTSMaxAge turns HSTS off, e.g. for a local server over HTTP.
+type SecurityHeaders struct {
+	HSTSMaxAge time.Duration `yaml:"hsts_max_age" env-default:"8760h"`
+}
+
+// RequestBody represents config of the request bodies.
+// MaxSize is in bytes, zero means no limit. Strict rejects unknown fields
+// and the requests without the Content-Type header.
+type RequestBody struct {
+	MaxSize int64 `yaml:"max_size" env-default:"1048576"`
+	Strict  bool  `yaml:"strict" env-default:"true"`
+}
+
 // MustLoad loads the configuration from the file,
 // which path is given in CONFIG_PATH environment variable
 func MustLoad() Configuration {
@@ -64,3 +194,42 @@ func MustLoad() Configuration {
 
 	return cfg
 }
+
+// Validate reports an error if the values of the configuration contradict each other.
+// It is not called by MustLoad, so that the caller decides how to handle an invalid configuration.
+func (c Configuration) Validate() error {
+	if err := c.CORS.validate(); err != nil {
+		return fmt.Errorf("invalid cors config: %w", err)
+	}
+
+	return nil
+}
+
+// TaskEvents represents config of the stream of task changes.
+// Fanout is either "postgres", which delivers the changes to the streams of all the replicas, or "memory".
+// ReplaySize is the number of the last changes that a reconnected stream can resume from,
+// SubscriberBuffer is the number of the changes a slow stream can fall behind before it is closed.
+type TaskEvents struct {
+	Fanout           string        `yaml:"fanout" env-default:"memory"`
+	ReplaySize       int           `yaml:"replay_size" env-default:"1000"`
+	SubscriberBuffer int           `yaml:"subscriber_buffer" env-default:"64"`
+	Heartbeat        time.Duration `yaml:"heartbeat" env-default:"15s"`
+}
+
+// WebSocket represents config of the real-time channel.
+// MaxConnections and MaxSubscriptions are per user, zero turns a limit off.
+// MaxMessageSize limits the messages from the clients in bytes.
+type WebSocket struct {
+	MaxConnections   int           `yaml:"max_connections" env-default:"5"`
+	MaxSubscriptions int           `yaml:"max_subscriptions" env-default:"100"`
+	MaxMessageSize   int64         `yaml:"max_message_size" env-default:"4096"`
+	PingInterval     time.Duration `yaml:"ping_interval" env-default:"30s"`
+}
+
+// GraphQL represents config of the GraphQL API.
+// MaxDepth limits the nesting of the fields of a query, MaxComplexity limits the estimated
+// number of the fields it resolves. Zero turns a limit off.
+type GraphQL struct {
+	MaxDepth      int `yaml:"max_depth" env-default:"8"`
+	MaxComplexity int `yaml:"max_complexity" env-default:"5000"`
+}
@@ -61,6 +61,48 @@ func TestMustLoad_OK(t *testing.T) {
 	require.Equal(t, "localhost:6666", cfg.HTTPServer.Address)
 	require.Equal(t, 15*time.Second, cfg.HTTPServer.Timeout)
 	require.Equal(t, 90*time.Second, cfg.HTTPServer.IdleTimeout)
+	require.Empty(t, cfg.HTTPServer.TrustedProxies)
+	require.False(t, cfg.GRPCServer.Enabled)
+	require.Empty(t, cfg.GRPCServer.Address)
+	require.Equal(t, 720*time.Hour, cfg.Trash.Retention)
+	require.Equal(t, time.Hour, cfg.Trash.PurgeInterval)
+	require.Equal(t, 100, cfg.Batch.MaxSize)
+	require.Equal(t, "postgres", cfg.Idempotency.Store)
+	require.Equal(t, 24*time.Hour, cfg.Idempotency.TTL)
+	require.Equal(t, time.Hour, cfg.Idempotency.PurgeInterval)
+	require.True(t, cfg.AccessLog.Enabled)
+	require.Equal(t, 1.0, cfg.AccessLog.SampleRate)
+	require.False(t, cfg.AccessLog.LogHeaders)
+	require.True(t, cfg.Metrics.Enabled)
+	require.Equal(t, "none", cfg.Tracing.Exporter)
+	require.Equal(t, "localhost:4318", cfg.Tracing.OTLPEndpoint)
+	require.False(t, cfg.Tracing.OTLPInsecure)
+	require.Equal(t, 1.0, cfg.Tracing.SampleRate)
+	require.Equal(t, 2*time.Second, cfg.Health.CheckTimeout)
+	require.Equal(t, 5*time.Second, cfg.Health.DrainDelay)
+	require.Equal(t, "memory", cfg.RateLimit.Store)
+	require.Equal(t, 10*time.Minute, cfg.RateLimit.PurgeInterval)
+	require.Equal(t, config.RateLimitGroup{Requests: 100, Period: time.Minute}, cfg.RateLimit.Auth)
+	require.Equal(t, config.RateLimitGroup{Requests: 100, Period: time.Minute}, cfg.RateLimit.Tasks)
+	require.Equal(t, config.RateLimitGroup{Requests: 100, Period: time.Minute}, cfg.RateLimit.Calendar)
+	require.Empty(t, cfg.CORS.AllowedOrigins)
+	require.Equal(t, []string{"GET", "POST", "PATCH", "DELETE"}, cfg.CORS.AllowedMethods)
+	require.Equal(t, []string{"Authorization", "Content-Type", "If-Match", "Idempotency-Key", "Last-Event-ID"}, cfg.CORS.AllowedHeaders)
+	require.False(t, cfg.CORS.AllowCredentials)
+	require.Equal(t, 10*time.Minute, cfg.CORS.MaxAge)
+	require.Equal(t, 8760*time.Hour, cfg.SecurityHeaders.HSTSMaxAge)
+	require.Equal(t, int64(1<<20), cfg.RequestBody.MaxSize)
+	require.True(t, cfg.RequestBody.Strict)
+	require.Equal(t, "memory", cfg.TaskEvents.Fanout)
+	require.Equal(t, 1000, cfg.TaskEvents.ReplaySize)
+	require.Equal(t, 64, cfg.TaskEvents.SubscriberBuffer)
+	require.Equal(t, 15*time.Second, cfg.TaskEvents.Heartbeat)
+	require.Equal(t, 5, cfg.WebSocket.MaxConnections)
+	require.Equal(t, 100, cfg.WebSocket.MaxSubscriptions)
+	require.Equal(t, int64(4096), cfg.WebSocket.MaxMessageSize)
+	require.Equal(t, 30*time.Second, cfg.WebSocket.PingInterval)
+	require.Equal(t, 8, cfg.GraphQL.MaxDepth)
+	require.Equal(t, 5000, cfg.GraphQL.MaxComplexity)
 }
 
 func TestMustLoad_NoEnv(t *testing.T) {
@@ -132,3 +174,42 @@ func TestMustLoad_ConfigParsingFailed(t *testing.T) {
 
 	_ = config.MustLoad()
 }
+
+func TestConfiguration_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cors    config.CORS
+		wantErr bool
+	}{
+		{
+			name: "no origins",
+			cors: config.CORS{AllowCredentials: true},
+		},
+		{
+			name: "origins with credentials",
+			cors: config.CORS{AllowedOrigins: []string{"https://app.taskery.dev"}, AllowCredentials: true},
+		},
+		{
+			name: "any origin",
+			cors: config.CORS{AllowedOrigins: []string{"*"}},
+		},
+		{
+			name:    "any origin with credentials",
+			cors:    config.CORS{AllowedOrigins: []string{"https://app.taskery.dev", "*"}, AllowCredentials: true},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := config.Configuration{CORS: tt.cors}.Validate()
+
+			if tt.wantErr {
+				require.ErrorContains(t, err, "invalid cors config")
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
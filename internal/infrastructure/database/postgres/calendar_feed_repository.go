@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/lib/pq"
+)
+
+// CalendarFeedRepository represents a repository of the calendar feed tokens in PostgreSQL database
+type CalendarFeedRepository struct {
+	db *sql.DB
+}
+
+// NewCalendarFeedRepository creates a new CalendarFeedRepository using the provided sql.DB.
+// It returns an error if the db argument is nil.
+func NewCalendarFeedRepository(db *sql.DB) (*CalendarFeedRepository, error) {
+	const op = "postgres.CalendarFeedRepository.NewCalendarFeedRepository"
+
+	if db == nil {
+		return nil, fmt.Errorf("%s: db is nil", op)
+	}
+
+	return &CalendarFeedRepository{db: db}, nil
+}
+
+// Save stores the hash of the feed token of the user, replacing the previous one, if any.
+//
+// If the user does not exist, Save returns services.ErrCalendarFeedRepoUserNotFound.
+// Other database errors are returned wrapped.
+func (r *CalendarFeedRepository) Save(ctx context.Context, userID string, tokenHash string, createdAt time.Time) error {
+	const op = "postgres.CalendarFeedRepository.Save"
+
+	const query = `
+		INSERT INTO calendar_feeds(user_id, token_hash, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE
+		SET token_hash = EXCLUDED.token_hash, created_at = EXCLUDED.created_at`
+
+	_, err := r.db.ExecContext(ctx, query, userID, tokenHash, createdAt)
+	if err != nil {
+		if pqErr, ok := errors.AsType[*pq.Error](err); ok && pqErr.Code == "23503" { // foreign key constraint
+			return services.ErrCalendarFeedRepoUserNotFound
+		}
+
+		return fmt.Errorf("%s: save calendar feed: %w", op, err)
+	}
+
+	return nil
+}
+
+// FindUserID returns the id of the user whose feed token has the given hash.
+//
+// If there is no such token, FindUserID returns services.ErrCalendarFeedRepoNotFound.
+// Other database errors are returned wrapped.
+func (r *CalendarFeedRepository) FindUserID(ctx context.Context, tokenHash string) (string, error) {
+	const op = "postgres.CalendarFeedRepository.FindUserID"
+
+	const query = `SELECT user_id FROM calendar_feeds WHERE token_hash = $1`
+
+	var userID string
+	if err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(&userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", services.ErrCalendarFeedRepoNotFound
+		}
+
+		return "", fmt.Errorf("%s: find calendar feed: %w", op, err)
+	}
+
+	return userID, nil
+}
+
+// Delete removes the feed token of the user. It does nothing if the user has no token.
+//
+// Any database error encountered is returned wrapped.
+func (r *CalendarFeedRepository) Delete(ctx context.Context, userID string) error {
+	const op = "postgres.CalendarFeedRepository.Delete"
+
+	const query = `DELETE FROM calendar_feeds WHERE user_id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("%s: delete calendar feed: %w", op, err)
+	}
+
+	return nil
+}
+
+var _ services.CalendarFeedRepository = (*CalendarFeedRepository)(nil)
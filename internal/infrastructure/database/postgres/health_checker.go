@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrMigrationsNotApplied is reported by HealthChecker if no migration has been applied.
+	ErrMigrationsNotApplied = errors.New("migrations are not applied")
+
+	// ErrMigrationDirty is reported by HealthChecker if the last migration has failed halfway.
+	ErrMigrationDirty = errors.New("migration is dirty")
+)
+
+// HealthChecker checks the connectivity to the database and the state of its migrations.
+type HealthChecker struct {
+	db *sql.DB
+}
+
+// NewHealthChecker creates a new HealthChecker using the provided sql.DB.
+// It returns an error if the db argument is nil.
+func NewHealthChecker(db *sql.DB) (*HealthChecker, error) {
+	const op = "postgres.HealthChecker.NewHealthChecker"
+
+	if db == nil {
+		return nil, fmt.Errorf("%s: db is nil", op)
+	}
+
+	return &HealthChecker{db: db}, nil
+}
+
+// Name returns the name of the check.
+func (hc *HealthChecker) Name() string {
+	return "postgres"
+}
+
+// Check pings the database and reads the version of the last applied migration
+// and whether it is dirty, which are returned as the details of the check.
+func (hc *HealthChecker) Check(ctx context.Context) (map[string]any, error) {
+	const op = "postgres.HealthChecker.Check"
+
+	if err := hc.db.PingContext(ctx); err != nil {
+		return nil, fmt.Errorf("%s: ping db: %w", op, err)
+	}
+
+	var version int64
+	var dirty bool
+
+	err := hc.db.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%s: %w", op, ErrMigrationsNotApplied)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: read migration version: %w", op, err)
+	}
+
+	details := map[string]any{
+		"migration_version": version,
+		"migration_dirty":   dirty,
+	}
+
+	if dirty {
+		return details, fmt.Errorf("%s: %w", op, ErrMigrationDirty)
+	}
+
+	return details, nil
+}
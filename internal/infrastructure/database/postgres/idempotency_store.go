@@ -0,0 +1,197 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/idempotency"
+)
+
+// IdempotencyStore represents a store of idempotency keys in PostgreSQL database
+type IdempotencyStore struct {
+	db *sql.DB
+}
+
+// NewIdempotencyStore creates a new IdempotencyStore using the provided sql.DB.
+// It returns an error if the db argument is nil.
+func NewIdempotencyStore(db *sql.DB) (*IdempotencyStore, error) {
+	const op = "postgres.IdempotencyStore.NewIdempotencyStore"
+
+	if db == nil {
+		return nil, fmt.Errorf("%s: db is nil", op)
+	}
+
+	return &IdempotencyStore{db: db}, nil
+}
+
+// Reserve creates a pending record with the fingerprint for the key
+// unless the key has a record that has not expired by now, in which case
+// the existing record is returned. An expired record is replaced.
+//
+// It returns nil and a nil error if the key has been reserved.
+// Any database or execution error encountered is returned.
+func (s *IdempotencyStore) Reserve(
+	ctx context.Context,
+	key string,
+	fingerprint string,
+	now time.Time,
+	expiresAt time.Time,
+) (*idempotency.Record, error) {
+	const op = "postgres.IdempotencyStore.Reserve"
+
+	const reserveQuery = `
+		INSERT INTO idempotency_keys (key, fingerprint, expires_at) VALUES ($1, $2, $3)
+		ON CONFLICT (key) DO UPDATE SET
+			fingerprint = EXCLUDED.fingerprint,
+			status_code = NULL,
+			header = NULL,
+			body = NULL,
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= $4
+		RETURNING key`
+
+	const selectQuery = `
+		SELECT fingerprint, status_code, header, body, expires_at
+		FROM idempotency_keys WHERE key = $1`
+
+	// the existing record may expire and be deleted between the two queries,
+	// in which case the key is reserved again
+	for range 2 {
+		var reserved string
+
+		err := conn(ctx, s.db).QueryRowContext(ctx, reserveQuery, key, fingerprint, expiresAt, now).Scan(&reserved)
+		if err == nil {
+			return nil, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: reserve key: %w", op, err)
+		}
+
+		var (
+			record     idempotency.Record
+			statusCode *int
+			header     []byte
+			body       []byte
+		)
+
+		err = conn(ctx, s.db).QueryRowContext(ctx, selectQuery, key).Scan(
+			&record.Fingerprint,
+			&statusCode,
+			&header,
+			&body,
+			&record.ExpiresAt,
+		)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: find key: %w", op, err)
+		}
+
+		if statusCode != nil {
+			response := &idempotency.Response{StatusCode: *statusCode, Body: body}
+
+			if header != nil {
+				if err := json.Unmarshal(header, &response.Header); err != nil {
+					return nil, fmt.Errorf("%s: unmarshal header: %w", op, err)
+				}
+			}
+
+			record.Response = response
+		}
+
+		return &record, nil
+	}
+
+	return nil, fmt.Errorf("%s: key %q was concurrently deleted", op, key)
+}
+
+// Complete saves the response to the request the key was reserved for.
+//
+// It returns idempotency.ErrKeyNotReserved if the key has no pending record.
+// Any database or execution error encountered is returned.
+func (s *IdempotencyStore) Complete(ctx context.Context, key string, response idempotency.Response) error {
+	const op = "postgres.IdempotencyStore.Complete"
+
+	const query = `
+		UPDATE idempotency_keys SET
+			status_code = $1,
+			header = $2,
+			body = $3
+		WHERE key = $4 AND status_code IS NULL`
+
+	header := response.Header
+	if header == nil {
+		header = http.Header{}
+	}
+
+	headerData, err := json.Marshal(header)
+	if err != nil {
+		return fmt.Errorf("%s: marshal header: %w", op, err)
+	}
+
+	body := response.Body
+	if body == nil {
+		body = []byte{}
+	}
+
+	res, err := conn(ctx, s.db).ExecContext(ctx, query, response.StatusCode, headerData, body, key)
+	if err != nil {
+		return fmt.Errorf("%s: complete key: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: get affected rows: %w", op, err)
+	}
+
+	if affected == 0 {
+		return idempotency.ErrKeyNotReserved
+	}
+
+	return nil
+}
+
+// Release removes the pending record of the key.
+// Releasing a key that has no pending record does nothing.
+//
+// Any database or execution error encountered is returned.
+func (s *IdempotencyStore) Release(ctx context.Context, key string) error {
+	const op = "postgres.IdempotencyStore.Release"
+
+	const query = `DELETE FROM idempotency_keys WHERE key = $1 AND status_code IS NULL`
+
+	if _, err := conn(ctx, s.db).ExecContext(ctx, query, key); err != nil {
+		return fmt.Errorf("%s: release key: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeleteExpired removes the records that expired by now and returns their number.
+//
+// Any database or execution error encountered during the deletion is returned.
+func (s *IdempotencyStore) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	const op = "postgres.IdempotencyStore.DeleteExpired"
+
+	const query = `DELETE FROM idempotency_keys WHERE expires_at <= $1`
+
+	res, err := conn(ctx, s.db).ExecContext(ctx, query, now)
+	if err != nil {
+		return 0, fmt.Errorf("%s: delete expired keys: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: get affected rows: %w", op, err)
+	}
+
+	return affected, nil
+}
+
+var _ idempotency.Store = (*IdempotencyStore)(nil)
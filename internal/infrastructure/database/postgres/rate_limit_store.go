@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/ratelimit"
+)
+
+// RateLimitStore represents a store of rate limit buckets in PostgreSQL database,
+// which is shared by all instances of the application
+type RateLimitStore struct {
+	db *sql.DB
+}
+
+// NewRateLimitStore creates a new RateLimitStore using the provided sql.DB.
+// It returns an error if the db argument is nil.
+func NewRateLimitStore(db *sql.DB) (*RateLimitStore, error) {
+	const op = "postgres.RateLimitStore.NewRateLimitStore"
+
+	if db == nil {
+		return nil, fmt.Errorf("%s: db is nil", op)
+	}
+
+	return &RateLimitStore{db: db}, nil
+}
+
+// Take takes a token from the bucket of the key at now according to the limit.
+// The bucket is created, refilled and taken from by a single statement,
+// so that concurrent requests of all instances take the tokens one by one.
+//
+// Any database or execution error encountered is returned.
+func (s *RateLimitStore) Take(
+	ctx context.Context,
+	key string,
+	limit ratelimit.Limit,
+	now time.Time,
+) (ratelimit.Result, error) {
+	const op = "postgres.RateLimitStore.Take"
+
+	// $2 is the capacity of the bucket and $3 is the period in seconds, in which it is refilled completely;
+	// a new bucket is full
+	const query = `
+		INSERT INTO rate_limit_buckets AS b (key, tokens, allowed, updated_at, full_at)
+		VALUES (
+			$1,
+			$2::DOUBLE PRECISION - 1,
+			TRUE,
+			$4::TIMESTAMPTZ,
+			$4::TIMESTAMPTZ + make_interval(secs => $3::DOUBLE PRECISION / $2::DOUBLE PRECISION)
+		)
+		ON CONFLICT (key) DO UPDATE SET (tokens, allowed, updated_at, full_at) = (
+			SELECT
+				t.tokens,
+				t.allowed,
+				$4::TIMESTAMPTZ,
+				$4::TIMESTAMPTZ + make_interval(
+					secs => ($2::DOUBLE PRECISION - t.tokens) * $3::DOUBLE PRECISION / $2::DOUBLE PRECISION
+				)
+			FROM (
+				SELECT
+					CASE WHEN r.tokens >= 1 THEN r.tokens - 1 ELSE r.tokens END AS tokens,
+					r.tokens >= 1 AS allowed
+				FROM (
+					SELECT LEAST(
+						$2::DOUBLE PRECISION,
+						b.tokens + GREATEST(EXTRACT(EPOCH FROM $4::TIMESTAMPTZ - b.updated_at)::DOUBLE PRECISION, 0)
+							* $2::DOUBLE PRECISION / $3::DOUBLE PRECISION
+					) AS tokens
+				) AS r
+			) AS t
+		)
+		RETURNING tokens, allowed`
+
+	var tokens float64
+	var allowed bool
+
+	err := s.db.QueryRowContext(ctx, query, key, float64(limit.Requests), limit.Period.Seconds(), now).
+		Scan(&tokens, &allowed)
+	if err != nil {
+		return ratelimit.Result{}, fmt.Errorf("%s: take token: %w", op, err)
+	}
+
+	return limit.Result(tokens, allowed), nil
+}
+
+// DeleteExpired removes the buckets that are refilled completely by now and returns their number.
+//
+// Any database or execution error encountered during the deletion is returned.
+func (s *RateLimitStore) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	const op = "postgres.RateLimitStore.DeleteExpired"
+
+	const query = `DELETE FROM rate_limit_buckets WHERE full_at <= $1`
+
+	res, err := s.db.ExecContext(ctx, query, now)
+	if err != nil {
+		return 0, fmt.Errorf("%s: delete expired buckets: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: get affected rows: %w", op, err)
+	}
+
+	return affected, nil
+}
+
+var _ ratelimit.Store = (*RateLimitStore)(nil)
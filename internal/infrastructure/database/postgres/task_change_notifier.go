@@ -0,0 +1,174 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/lib/pq"
+)
+
+// TaskChangesChannel is the channel of the notifications about the changes of tasks.
+const TaskChangesChannel = "task_changes"
+
+// taskChangeNotification is the payload of a notification about a change of a task.
+// The event itself is loaded from task_events by the listeners, as it may not fit
+// in the payload, which is limited to 8000 bytes.
+type taskChangeNotification struct {
+	EventID string `json:"event_id"`
+	OwnerID string `json:"owner_id"`
+	Version int64  `json:"version"`
+}
+
+// TaskChangeNotifier is a services.TaskChangePublisher that notifies all the instances
+// of the application about the changes of tasks with NOTIFY, so that each of them
+// delivers the changes to its own subscribers. See TaskChangeListener.
+type TaskChangeNotifier struct {
+	db *sql.DB
+}
+
+var _ services.TaskChangePublisher = (*TaskChangeNotifier)(nil)
+
+// NewTaskChangeNotifier creates a new TaskChangeNotifier using the provided sql.DB.
+// It returns an error if the db argument is nil.
+func NewTaskChangeNotifier(db *sql.DB) (*TaskChangeNotifier, error) {
+	const op = "postgres.TaskChangeNotifier.NewTaskChangeNotifier"
+
+	if db == nil {
+		return nil, fmt.Errorf("%s: db is nil", op)
+	}
+
+	return &TaskChangeNotifier{db: db}, nil
+}
+
+// Publish sends a notification about the change to TaskChangesChannel.
+func (n *TaskChangeNotifier) Publish(ctx context.Context, change services.TaskChange) error {
+	const op = "postgres.TaskChangeNotifier.Publish"
+
+	payload, err := json.Marshal(taskChangeNotification{
+		EventID: change.Event.ID().String(),
+		OwnerID: change.OwnerID,
+		Version: change.Version,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := n.db.ExecContext(ctx, `SELECT pg_notify($1, $2)`, TaskChangesChannel, string(payload)); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// TaskChangeListener listens to the notifications sent by TaskChangeNotifier
+// and publishes the changes they are about to the local publisher, e.g. a pubsub.Broker.
+type TaskChangeListener struct {
+	dsn    string
+	events services.TaskEventRepository
+	local  services.TaskChangePublisher
+	logger *slog.Logger
+}
+
+// NewTaskChangeListener creates a new TaskChangeListener that connects to the database with dsn
+// and loads the events of the changes from events.
+// It returns an error if any of the dependencies is missing.
+func NewTaskChangeListener(
+	dsn string,
+	events services.TaskEventRepository,
+	local services.TaskChangePublisher,
+	logger *slog.Logger,
+) (*TaskChangeListener, error) {
+	const op = "postgres.TaskChangeListener.NewTaskChangeListener"
+
+	if dsn == "" {
+		return nil, fmt.Errorf("%s: dsn is empty", op)
+	}
+	if events == nil {
+		return nil, fmt.Errorf("%s: events repository is nil", op)
+	}
+	if local == nil {
+		return nil, fmt.Errorf("%s: publisher is nil", op)
+	}
+
+	return &TaskChangeListener{
+		dsn:    dsn,
+		events: events,
+		local:  local,
+		logger: logger,
+	}, nil
+}
+
+// Run listens to TaskChangesChannel until ctx is cancelled.
+//
+// The listener reconnects to the database when the connection is lost.
+// The notifications sent while it is disconnected are lost, in which case
+// the subscribers cannot replay the missed changes from the local publisher.
+func (l *TaskChangeListener) Run(ctx context.Context) error {
+	const op = "postgres.TaskChangeListener.Run"
+
+	logger := l.logger.With(slog.String("op", op))
+
+	listener := pq.NewListener(l.dsn, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		switch event {
+		case pq.ListenerEventDisconnected, pq.ListenerEventConnectionAttemptFailed:
+			logger.Error("task changes listener is disconnected", slog.Any("err", err))
+		case pq.ListenerEventReconnected:
+			logger.Info("task changes listener is reconnected")
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(TaskChangesChannel); err != nil {
+		return fmt.Errorf("%s: listen: %w", op, err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case n := <-listener.NotificationChannel():
+			// a nil notification is sent after the connection is re-established
+			if n == nil {
+				continue
+			}
+
+			if err := l.handle(ctx, n.Extra); err != nil {
+				logger.Error("failed to handle task change notification", slog.String("err", err.Error()))
+			}
+
+		case <-time.After(time.Minute):
+			// make sure that the connection is alive, so that a lost one is re-established
+			go func() { _ = listener.Ping() }()
+		}
+	}
+}
+
+// handle loads the event of the notified change and publishes the change locally.
+func (l *TaskChangeListener) handle(ctx context.Context, payload string) error {
+	var notification taskChangeNotification
+	if err := json.Unmarshal([]byte(payload), &notification); err != nil {
+		return fmt.Errorf("decode payload: %w", err)
+	}
+
+	event, err := l.events.FindByID(ctx, notification.EventID)
+	if errors.Is(err, services.ErrTaskEventRepoNotFound) {
+		// the task has been deleted permanently together with its history
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("load event %s: %w", notification.EventID, err)
+	}
+
+	return l.local.Publish(ctx, services.TaskChange{
+		OwnerID: notification.OwnerID,
+		Version: notification.Version,
+		Event:   event,
+	})
+}
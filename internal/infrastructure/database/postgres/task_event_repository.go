@@ -0,0 +1,248 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/lib/pq"
+)
+
+// TaskEventRepository represents a repository of task history events in PostgreSQL database
+type TaskEventRepository struct {
+	db *sql.DB
+}
+
+// NewTaskEventRepository creates a new TaskEventRepository using the provided sql.DB.
+// It returns an error if the db argument is nil.
+func NewTaskEventRepository(db *sql.DB) (*TaskEventRepository, error) {
+	const op = "postgres.TaskEventRepository.NewTaskEventRepository"
+
+	if db == nil {
+		return nil, fmt.Errorf("%s: db is nil", op)
+	}
+
+	return &TaskEventRepository{db: db}, nil
+}
+
+// taskStateRecord is the JSON representation of models.TaskState
+// that is stored in the before and after columns of task_events.
+type taskStateRecord struct {
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Deadline    *time.Time `json:"deadline"`
+	IsCompleted bool       `json:"is_completed"`
+	CompletedAt *time.Time `json:"completed_at"`
+	ArchivedAt  *time.Time `json:"archived_at"`
+	DeletedAt   *time.Time `json:"deleted_at"`
+}
+
+func newTaskStateRecord(s models.TaskState) taskStateRecord {
+	return taskStateRecord(s)
+}
+
+func (r taskStateRecord) toState() models.TaskState {
+	return models.TaskState(r)
+}
+
+// Create inserts a new task event into the database.
+// It returns an error if the insertion fails.
+func (er *TaskEventRepository) Create(ctx context.Context, event *models.TaskEvent) error {
+	const op = "postgres.TaskEventRepository.Create"
+
+	const query = `INSERT INTO task_events (
+		id,
+		task_id,
+		actor_id,
+		type,
+		before,
+		after,
+		occurred_at
+	) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	var before []byte
+	if event.Before() != nil {
+		var err error
+
+		before, err = json.Marshal(newTaskStateRecord(*event.Before()))
+		if err != nil {
+			return fmt.Errorf("%s: marshal before: %w", op, err)
+		}
+	}
+
+	after, err := json.Marshal(newTaskStateRecord(event.After()))
+	if err != nil {
+		return fmt.Errorf("%s: marshal after: %w", op, err)
+	}
+
+	_, err = conn(ctx, er.db).ExecContext(
+		ctx,
+		query,
+		event.ID().String(),
+		event.TaskID().String(),
+		event.ActorID().String(),
+		string(event.Type()),
+		before,
+		after,
+		event.OccurredAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("%s: create task event: %w", op, err)
+	}
+
+	return nil
+}
+
+// FindByID returns the task event with the given id.
+//
+// If no event with the specified id exists, FindByID returns
+// services.ErrTaskEventRepoNotFound.
+func (er *TaskEventRepository) FindByID(ctx context.Context, id string) (*models.TaskEvent, error) {
+	const op = "postgres.TaskEventRepository.FindByID"
+
+	const query = `
+		SELECT id, task_id, actor_id, type, before, after, occurred_at
+		FROM task_events WHERE id = $1`
+
+	event, err := scanTaskEvent(conn(ctx, er.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, services.ErrTaskEventRepoNotFound
+		}
+
+		return nil, fmt.Errorf("%s: find by id: %w", op, err)
+	}
+
+	return event, nil
+}
+
+// FindByTask returns the history of the task with the given id from the oldest event to the newest.
+// If the task has no events, it returns an empty slice and a nil error.
+func (er *TaskEventRepository) FindByTask(ctx context.Context, taskID string) ([]*models.TaskEvent, error) {
+	const op = "postgres.TaskEventRepository.FindByTask"
+
+	const query = `
+		SELECT id, task_id, actor_id, type, before, after, occurred_at
+		FROM task_events
+		WHERE task_id = $1
+		ORDER BY occurred_at ASC, id ASC`
+
+	rows, err := conn(ctx, er.db).QueryContext(ctx, query, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: find task events: %w", op, err)
+	}
+	defer rows.Close()
+
+	events := make([]*models.TaskEvent, 0)
+
+	for rows.Next() {
+		event, err := scanTaskEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows: %w", op, err)
+	}
+
+	return events, nil
+}
+
+// FindByTasks returns the histories of the tasks with the given ids, all together
+// from the oldest event to the newest. If the tasks have no events, it returns an empty slice and a nil error.
+func (er *TaskEventRepository) FindByTasks(ctx context.Context, taskIDs []string) ([]*models.TaskEvent, error) {
+	const op = "postgres.TaskEventRepository.FindByTasks"
+
+	const query = `
+		SELECT id, task_id, actor_id, type, before, after, occurred_at
+		FROM task_events
+		WHERE task_id = ANY($1::uuid[])
+		ORDER BY occurred_at ASC, id ASC`
+
+	rows, err := conn(ctx, er.db).QueryContext(ctx, query, pq.Array(taskIDs))
+	if err != nil {
+		return nil, fmt.Errorf("%s: find task events: %w", op, err)
+	}
+	defer rows.Close()
+
+	events := make([]*models.TaskEvent, 0)
+
+	for rows.Next() {
+		event, err := scanTaskEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows: %w", op, err)
+	}
+
+	return events, nil
+}
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanTaskEvent reads a task event from a row selected
+// as id, task_id, actor_id, type, before, after, occurred_at.
+func scanTaskEvent(row rowScanner) (*models.TaskEvent, error) {
+	var (
+		id         string
+		taskID     string
+		actorID    string
+		eventType  string
+		before     []byte
+		after      []byte
+		occurredAt time.Time
+	)
+
+	if err := row.Scan(&id, &taskID, &actorID, &eventType, &before, &after, &occurredAt); err != nil {
+		return nil, err
+	}
+
+	params := models.TaskEventFromDBParams{
+		ID:         id,
+		TaskID:     taskID,
+		ActorID:    actorID,
+		Type:       eventType,
+		OccurredAt: occurredAt,
+	}
+
+	if before != nil {
+		var record taskStateRecord
+		if err := json.Unmarshal(before, &record); err != nil {
+			return nil, fmt.Errorf("unmarshal before: %w", err)
+		}
+
+		state := record.toState()
+		params.Before = &state
+	}
+
+	var record taskStateRecord
+	if err := json.Unmarshal(after, &record); err != nil {
+		return nil, fmt.Errorf("unmarshal after: %w", err)
+	}
+	params.After = record.toState()
+
+	event, err := models.NewTaskEventFromDB(params)
+	if err != nil {
+		return nil, fmt.Errorf("restore task event: %w", err)
+	}
+
+	return event, nil
+}
+
+var _ services.TaskEventRepository = (*TaskEventRepository)(nil)
@@ -5,9 +5,12 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"html"
+	"strings"
 	"time"
 
 	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/vo"
 	"github.com/cyberbrain-dev/taskery-api/internal/services"
 	"github.com/lib/pq"
 )
@@ -39,18 +42,34 @@ func NewTaskRepository(db *sql.DB) (*TaskRepository, error) {
 //
 // The task's deadline is optional; if nil, it is stored as NULL in the database.
 // Completed tasks can have a completion timestamp, which is also stored in the database.
+// The task gets the next change sequence number of its owner.
 func (tr *TaskRepository) Create(ctx context.Context, task *models.Task) error {
 	const op = "postgres.TaskRepository.Create"
 
-	const query = `INSERT INTO tasks (
-        id,
-		owner_id,
-		title,
-		description,
-		deadline,
-		is_completed,
-		completed_at
-	) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	const query = `
+		WITH seq AS (
+			UPDATE users SET change_seq = change_seq + 1 WHERE id = $2 RETURNING change_seq
+		)
+		INSERT INTO tasks (
+			id,
+			owner_id,
+			title,
+			description,
+			deadline,
+			is_completed,
+			completed_at,
+			created_at,
+			updated_at,
+			archived_at,
+			deleted_at,
+			version,
+			created_seq,
+			change_seq
+		)
+		SELECT $1::uuid, $2::uuid, $3::text, $4::text, $5::timestamptz, $6::boolean, $7::timestamptz,
+			$8::timestamptz, $9::timestamptz, $10::timestamptz, $11::timestamptz, $12::bigint,
+			seq.change_seq, seq.change_seq
+		FROM seq`
 
 	var deadlineToInsert *time.Time = nil
 	if task.Deadline() != nil {
@@ -58,7 +77,7 @@ func (tr *TaskRepository) Create(ctx context.Context, task *models.Task) error {
 		deadlineToInsert = &deadlineTime
 	}
 
-	_, err := tr.db.ExecContext(
+	res, err := conn(ctx, tr.db).ExecContext(
 		ctx,
 		query,
 		task.ID().String(),
@@ -68,6 +87,11 @@ func (tr *TaskRepository) Create(ctx context.Context, task *models.Task) error {
 		deadlineToInsert,
 		task.IsCompleted(),
 		task.CompletedAt(),
+		task.CreatedAt(),
+		task.UpdatedAt(),
+		task.ArchivedAt(),
+		task.DeletedAt(),
+		task.Version(),
 	)
 	if err != nil {
 		if pqErr, ok := errors.AsType[*pq.Error](err); ok {
@@ -86,10 +110,20 @@ func (tr *TaskRepository) Create(ctx context.Context, task *models.Task) error {
 		}
 	}
 
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: get affected rows: %w", op, err)
+	}
+
+	// nothing is inserted if there is no owner to take the sequence number from
+	if affected == 0 {
+		return services.ErrTaskRepoOwnerNotFound
+	}
+
 	return nil
 }
 
-// FindByID returns the task with the given id.
+// FindByID returns the task with the given id, including a task that is in the trash.
 //
 // If no task with the specified id exists, FindByID returns
 // services.ErrTaskRepoNotFound.
@@ -100,10 +134,11 @@ func (tr *TaskRepository) FindByID(ctx context.Context, id string) (*models.Task
 	const op = "postgres.TaskRepository.FindByID"
 
 	const query = `
-		SELECT id, owner_id, title, description, deadline, is_completed, completed_at
+		SELECT id, owner_id, title, description, deadline, is_completed, completed_at,
+			created_at, updated_at, archived_at, deleted_at, version
 		FROM tasks WHERE id = $1`
 
-	row := tr.db.QueryRowContext(ctx, query, id)
+	row := conn(ctx, tr.db).QueryRowContext(ctx, query, id)
 
 	var (
 		userID      string
@@ -113,9 +148,27 @@ func (tr *TaskRepository) FindByID(ctx context.Context, id string) (*models.Task
 		deadline    *time.Time
 		isCompleted bool
 		completedAt *time.Time
+		createdAt   time.Time
+		updatedAt   time.Time
+		archivedAt  *time.Time
+		deletedAt   *time.Time
+		version     int64
 	)
 
-	err := row.Scan(&userID, &ownerId, &title, &description, &deadline, &isCompleted, &completedAt)
+	err := row.Scan(
+		&userID,
+		&ownerId,
+		&title,
+		&description,
+		&deadline,
+		&isCompleted,
+		&completedAt,
+		&createdAt,
+		&updatedAt,
+		&archivedAt,
+		&deletedAt,
+		&version,
+	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, services.ErrTaskRepoNotFound
@@ -132,6 +185,11 @@ func (tr *TaskRepository) FindByID(ctx context.Context, id string) (*models.Task
 		Deadline:    deadline,
 		IsCompleted: isCompleted,
 		CompletedAt: completedAt,
+		CreatedAt:   createdAt,
+		UpdatedAt:   updatedAt,
+		ArchivedAt:  archivedAt,
+		DeletedAt:   deletedAt,
+		Version:     version,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("%s: restore task: %w", op, err)
@@ -140,24 +198,125 @@ func (tr *TaskRepository) FindByID(ctx context.Context, id string) (*models.Task
 	return task, nil
 }
 
+// FindByIDs returns the tasks with the given ids in no particular order,
+// including the tasks that are in the trash. The ids of the tasks that do not exist are skipped.
+//
+// An error is returned if the query execution fails, a row cannot be scanned,
+// or a task cannot be restored from the database representation.
+func (tr *TaskRepository) FindByIDs(ctx context.Context, ids []string) ([]*models.Task, error) {
+	const op = "postgres.TaskRepository.FindByIDs"
+
+	const query = `
+		SELECT id, owner_id, title, description, deadline, is_completed, completed_at,
+			created_at, updated_at, archived_at, deleted_at, version
+		FROM tasks WHERE id = ANY($1::uuid[])`
+
+	rows, err := conn(ctx, tr.db).QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("%s: find tasks: %w", op, err)
+	}
+	defer rows.Close()
+
+	tasks := make([]*models.Task, 0, len(ids))
+
+	for rows.Next() {
+		var (
+			taskID      string
+			ownerID     string
+			title       string
+			description string
+			deadline    *time.Time
+			isCompleted bool
+			completedAt *time.Time
+			createdAt   time.Time
+			updatedAt   time.Time
+			archivedAt  *time.Time
+			deletedAt   *time.Time
+			version     int64
+		)
+
+		err := rows.Scan(
+			&taskID,
+			&ownerID,
+			&title,
+			&description,
+			&deadline,
+			&isCompleted,
+			&completedAt,
+			&createdAt,
+			&updatedAt,
+			&archivedAt,
+			&deletedAt,
+			&version,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: scan row: %w", op, err)
+		}
+
+		task, err := models.NewTaskFromDB(models.TaskFromDBParams{
+			ID:          taskID,
+			OwnerID:     ownerID,
+			Title:       title,
+			Description: description,
+			Deadline:    deadline,
+			IsCompleted: isCompleted,
+			CompletedAt: completedAt,
+			CreatedAt:   createdAt,
+			UpdatedAt:   updatedAt,
+			ArchivedAt:  archivedAt,
+			DeletedAt:   deletedAt,
+			Version:     version,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s: restore task: %w", op, err)
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows: %w", op, err)
+	}
+
+	return tasks, nil
+}
+
 // Update updates the stored task identified by task.ID using the values from task.
 //
 // It updates the task's title, description, deadline, completion status,
-// and completion time. If task.Deadline is nil, the deadline field is set to NULL.
+// completion time, update timestamp, archiving and deletion times. If task.Deadline is nil, the deadline field is set to NULL.
+// The update is applied only if the stored version equals task.Version,
+// in which case the stored version is incremented and the task gets the next
+// change sequence number of its owner.
 //
-// Update returns services.ErrTaskRepoNotFound if no task with the given ID exists.
+// Update returns services.ErrTaskRepoNotFound if no task with the given ID exists,
+// services.ErrTaskRepoConflict if the stored version differs from task.Version
+// and services.ErrTaskRepoExists if the owner already has an active task with the same title.
 // Any database or execution error encountered during the update is returned.
 func (tr *TaskRepository) Update(ctx context.Context, task *models.Task) error {
 	const op = "postgres.TaskRepository.Update"
 
+	// the owner is locked by taking the sequence number before the task,
+	// so that the changes of the owner are committed in the order of their numbers
 	const query = `
+		WITH seq AS (
+			UPDATE users SET change_seq = change_seq + 1
+			WHERE id = (SELECT owner_id FROM tasks WHERE id = $9 AND version = $10)
+			RETURNING change_seq
+		)
 		UPDATE tasks SET
 			 title = $1,
 			 description = $2,
 			 deadline = $3,
 			 is_completed = $4,
-			 completed_at = $5
-		WHERE id = $6`
+			 completed_at = $5,
+			 updated_at = $6,
+			 archived_at = $7,
+			 deleted_at = $8,
+			 version = version + 1,
+			 change_seq = seq.change_seq
+		FROM seq
+		WHERE tasks.id = $9 AND tasks.version = $10`
 
 	var deadlineToUpdate *time.Time = nil
 	if task.Deadline() != nil {
@@ -165,7 +324,7 @@ func (tr *TaskRepository) Update(ctx context.Context, task *models.Task) error {
 		deadlineToUpdate = &deadlineTime
 	}
 
-	res, err := tr.db.ExecContext(
+	res, err := conn(ctx, tr.db).ExecContext(
 		ctx,
 		query,
 		task.Title().String(),
@@ -173,9 +332,17 @@ func (tr *TaskRepository) Update(ctx context.Context, task *models.Task) error {
 		deadlineToUpdate,
 		task.IsCompleted(),
 		task.CompletedAt(),
+		task.UpdatedAt(),
+		task.ArchivedAt(),
+		task.DeletedAt(),
 		task.ID().String(),
+		task.Version(),
 	)
 	if err != nil {
+		if pqErr, ok := errors.AsType[*pq.Error](err); ok && pqErr.Code == "23505" {
+			return services.ErrTaskRepoExists
+		}
+
 		return fmt.Errorf("%s: update task: %w", op, err)
 	}
 
@@ -185,22 +352,50 @@ func (tr *TaskRepository) Update(ctx context.Context, task *models.Task) error {
 	}
 
 	if affected == 0 {
+		var exists bool
+
+		err := conn(ctx, tr.db).QueryRowContext(
+			ctx,
+			`SELECT EXISTS(SELECT 1 FROM tasks WHERE id = $1)`,
+			task.ID().String(),
+		).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("%s: check task existence: %w", op, err)
+		}
+
+		if exists {
+			return services.ErrTaskRepoConflict
+		}
+
 		return services.ErrTaskRepoNotFound
 	}
 
 	return nil
 }
 
-// Delete removes the task with the given ID from the repository.
+// Delete permanently removes the task with the given ID from the repository
+// and leaves a tombstone with the next change sequence number of its owner in its place.
+// The task is removed only if its stored version equals version.
 //
-// Delete returns services.ErrTaskRepoNotFound if no task with the given ID exists.
+// Delete returns services.ErrTaskRepoNotFound if no task with the given ID exists
+// and services.ErrTaskRepoConflict if the stored version differs from version.
 // Any database or execution error encountered during the deletion is returned.
-func (tr *TaskRepository) Delete(ctx context.Context, id string) error {
+func (tr *TaskRepository) Delete(ctx context.Context, id string, version int64) error {
 	const op = "postgres.TaskRepository.Delete"
 
-	const query = `DELETE FROM tasks WHERE id = $1`
+	const query = `
+		WITH seq AS (
+			UPDATE users SET change_seq = change_seq + 1
+			WHERE id = (SELECT owner_id FROM tasks WHERE id = $1 AND version = $2)
+			RETURNING change_seq
+		), deleted AS (
+			DELETE FROM tasks USING seq WHERE tasks.id = $1 AND tasks.version = $2
+			RETURNING tasks.id, tasks.owner_id, seq.change_seq
+		)
+		INSERT INTO task_tombstones (task_id, owner_id, change_seq)
+		SELECT id, owner_id, change_seq FROM deleted`
 
-	res, err := tr.db.ExecContext(ctx, query, id)
+	res, err := conn(ctx, tr.db).ExecContext(ctx, query, id, version)
 	if err != nil {
 		return fmt.Errorf("%s: delete task: %w", op, err)
 	}
@@ -211,32 +406,415 @@ func (tr *TaskRepository) Delete(ctx context.Context, id string) error {
 	}
 
 	if affected == 0 {
+		var exists bool
+
+		err := conn(ctx, tr.db).QueryRowContext(
+			ctx,
+			`SELECT EXISTS(SELECT 1 FROM tasks WHERE id = $1)`,
+			id,
+		).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("%s: check task existence: %w", op, err)
+		}
+
+		if exists {
+			return services.ErrTaskRepoConflict
+		}
+
 		return services.ErrTaskRepoNotFound
 	}
 
 	return nil
 }
 
-// FindByOwner returns all tasks that belong to the given ownerID.
+// FindByOwner returns all tasks that belong to the given ownerID and match the query.
 //
-// The tasks are ordered as returned by the database. If no tasks are found,
-// it returns an empty slice and a nil error.
+// Tasks in the trash are excluded unless query.InTrash is set, in which case only they are returned.
+// Archived tasks are excluded from the active ones unless query.IncludeArchived is set.
+// If query.UpdatedSince is set, only the tasks updated at or after that time are returned.
+// If query.Sort is services.TaskSortDefault, the tasks are ordered as returned by the database,
+// or from the most recently deleted when listing the trash.
+// If no tasks are found, it returns an empty slice and a nil error.
 //
-// An error is returned if the query execution fails, a row cannot be scanned,
-// or a task cannot be restored from the database representation. In case an error occurred nil slice is returned.
-func (tr *TaskRepository) FindByOwner(ctx context.Context, ownerID string) ([]*models.Task, error) {
+// An error is returned if the sort order is not supported, the query execution fails,
+// a row cannot be scanned, or a task cannot be restored from the database representation.
+// In case an error occurred nil slice is returned.
+func (tr *TaskRepository) FindByOwner(
+	ctx context.Context,
+	ownerID string,
+	query services.FindByOwnerQuery,
+) ([]*models.Task, error) {
 	const op = "postgres.TaskRepository.FindByOwner"
 
+	var sb strings.Builder
+	sb.WriteString(`
+		SELECT id, owner_id, title, description, deadline, is_completed, completed_at,
+			created_at, updated_at, archived_at, deleted_at, version
+		FROM tasks
+		WHERE owner_id = $1`)
+
+	args := []any{ownerID}
+
+	if query.InTrash {
+		sb.WriteString(" AND deleted_at IS NOT NULL")
+	} else {
+		sb.WriteString(" AND deleted_at IS NULL")
+
+		if !query.IncludeArchived {
+			sb.WriteString(" AND archived_at IS NULL")
+		}
+	}
+
+	if query.UpdatedSince != nil {
+		args = append(args, *query.UpdatedSince)
+		fmt.Fprintf(&sb, " AND updated_at >= $%d", len(args))
+	}
+
+	switch query.Sort {
+	case services.TaskSortDefault:
+		if query.InTrash {
+			sb.WriteString(" ORDER BY deleted_at DESC, id DESC")
+		}
+	case services.TaskSortCreatedAt:
+		sb.WriteString(" ORDER BY created_at ASC, id ASC")
+	case services.TaskSortCreatedAtDesc:
+		sb.WriteString(" ORDER BY created_at DESC, id DESC")
+	default:
+		return nil, fmt.Errorf("%s: unsupported sort order %q", op, query.Sort)
+	}
+
+	rows, err := conn(ctx, tr.db).QueryContext(ctx, sb.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: find tasks: %w", op, err)
+	}
+	defer rows.Close()
+
+	tasks, err := scanTasks(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tasks, nil
+}
+
+// FindWithDeadlines returns the active tasks of the owner that have a deadline,
+// ordered from the earliest deadline to the latest. Archived tasks and tasks in the trash are skipped.
+// If no tasks are found, it returns an empty slice and a nil error.
+//
+// An error is returned if the query execution fails, a row cannot be scanned,
+// or a task cannot be restored from the database representation.
+func (tr *TaskRepository) FindWithDeadlines(ctx context.Context, ownerID string) ([]*models.Task, error) {
+	const op = "postgres.TaskRepository.FindWithDeadlines"
+
 	const query = `
-		SELECT id, owner_id, title, description, deadline, is_completed, completed_at FROM tasks
-		WHERE owner_id = $1`
+		SELECT id, owner_id, title, description, deadline, is_completed, completed_at,
+			created_at, updated_at, archived_at, deleted_at, version
+		FROM tasks
+		WHERE owner_id = $1 AND deadline IS NOT NULL AND deleted_at IS NULL AND archived_at IS NULL
+		ORDER BY deadline ASC, id ASC`
 
-	rows, err := tr.db.QueryContext(ctx, query, ownerID)
+	rows, err := conn(ctx, tr.db).QueryContext(ctx, query, ownerID)
 	if err != nil {
 		return nil, fmt.Errorf("%s: find tasks: %w", op, err)
 	}
 	defer rows.Close()
 
+	tasks, err := scanTasks(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tasks, nil
+}
+
+// DeleteTrashedBefore permanently removes all tasks that were moved to the trash
+// before the given time and returns the removed tasks as they were stored.
+// Every removed task leaves a tombstone with the next change sequence number of its owner.
+//
+// Any database or execution error encountered during the deletion is returned.
+func (tr *TaskRepository) DeleteTrashedBefore(ctx context.Context, before time.Time) ([]*models.Task, error) {
+	const op = "postgres.TaskRepository.DeleteTrashedBefore"
+
+	const query = `
+		WITH trashed AS (
+			SELECT owner_id, count(*) AS n FROM tasks
+			WHERE deleted_at IS NOT NULL AND deleted_at < $1
+			GROUP BY owner_id
+		), seq AS (
+			UPDATE users SET change_seq = users.change_seq + trashed.n
+			FROM trashed WHERE users.id = trashed.owner_id
+			RETURNING users.id, users.change_seq - trashed.n AS base
+		), deleted AS (
+			DELETE FROM tasks USING seq
+			WHERE tasks.owner_id = seq.id AND tasks.deleted_at IS NOT NULL AND tasks.deleted_at < $1
+			RETURNING tasks.id, tasks.owner_id, tasks.title, tasks.description, tasks.deadline,
+				tasks.is_completed, tasks.completed_at, tasks.created_at, tasks.updated_at,
+				tasks.archived_at, tasks.deleted_at, tasks.version, seq.base
+		), tombstones AS (
+			INSERT INTO task_tombstones (task_id, owner_id, change_seq)
+			SELECT id, owner_id, base + row_number() OVER (PARTITION BY owner_id ORDER BY id) FROM deleted
+		)
+		SELECT id, owner_id, title, description, deadline, is_completed, completed_at,
+			created_at, updated_at, archived_at, deleted_at, version
+		FROM deleted`
+
+	rows, err := conn(ctx, tr.db).QueryContext(ctx, query, before)
+	if err != nil {
+		return nil, fmt.Errorf("%s: delete trashed tasks: %w", op, err)
+	}
+	defer rows.Close()
+
+	tasks, err := scanTasks(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tasks, nil
+}
+
+// ArchiveCompletedBefore archives all active tasks of the given owner that were
+// completed before completedBefore, setting their archiving and update time to archivedAt,
+// incrementing their version and giving each of them the next change sequence number of the owner.
+// It returns the archived tasks as they are after the update.
+//
+// Any database or execution error encountered during the update is returned.
+func (tr *TaskRepository) ArchiveCompletedBefore(
+	ctx context.Context,
+	ownerID string,
+	completedBefore time.Time,
+	archivedAt time.Time,
+) ([]*models.Task, error) {
+	const op = "postgres.TaskRepository.ArchiveCompletedBefore"
+
+	const query = `
+		WITH completed AS (
+			SELECT id, row_number() OVER (ORDER BY id) AS n FROM tasks
+			WHERE owner_id = $2
+				AND is_completed
+				AND completed_at < $3
+				AND archived_at IS NULL
+				AND deleted_at IS NULL
+		), seq AS (
+			UPDATE users SET change_seq = change_seq + (SELECT count(*) FROM completed)
+			WHERE id = $2
+			RETURNING change_seq - (SELECT count(*) FROM completed) AS base
+		)
+		UPDATE tasks SET
+			 archived_at = $1,
+			 updated_at = $1,
+			 version = version + 1,
+			 change_seq = seq.base + completed.n
+		FROM completed, seq
+		WHERE tasks.id = completed.id
+			AND tasks.is_completed
+			AND tasks.completed_at < $3
+			AND tasks.archived_at IS NULL
+			AND tasks.deleted_at IS NULL
+		RETURNING tasks.id, tasks.owner_id, tasks.title, tasks.description, tasks.deadline,
+			tasks.is_completed, tasks.completed_at, tasks.created_at, tasks.updated_at,
+			tasks.archived_at, tasks.deleted_at, tasks.version`
+
+	rows, err := conn(ctx, tr.db).QueryContext(ctx, query, archivedAt, ownerID, completedBefore)
+	if err != nil {
+		return nil, fmt.Errorf("%s: archive completed tasks: %w", op, err)
+	}
+	defer rows.Close()
+
+	tasks, err := scanTasks(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tasks, nil
+}
+
+// Search returns at most limit active tasks of the given owner that match the query,
+// ordered by their rank and then from the most recently updated.
+//
+// The search is performed on the search_vector column, which contains the words
+// of the title with the weight A and the words of the description with the weight B.
+// Archived tasks are excluded unless includeArchived is true, tasks in the trash are always excluded.
+// If no tasks match the query, it returns an empty slice and a nil error.
+//
+// An error is returned if the query execution fails, a row cannot be scanned,
+// or a task cannot be restored from the database representation.
+func (tr *TaskRepository) Search(
+	ctx context.Context,
+	ownerID string,
+	query vo.SearchQuery,
+	limit int,
+	includeArchived bool,
+) ([]services.TaskSearchResult, error) {
+	const op = "postgres.TaskRepository.Search"
+
+	var sb strings.Builder
+	sb.WriteString(`
+		SELECT id, owner_id, title, description, deadline, is_completed, completed_at,
+			created_at, updated_at, archived_at, deleted_at, version,
+			ts_rank(search_vector, q) AS rank,
+			ts_headline('simple', title, q, $4),
+			ts_headline('simple', description, q, $5)
+		FROM tasks, to_tsquery('simple', $2) AS q
+		WHERE owner_id = $1 AND deleted_at IS NULL AND search_vector @@ q`)
+
+	if !includeArchived {
+		sb.WriteString(" AND archived_at IS NULL")
+	}
+
+	sb.WriteString(" ORDER BY rank DESC, updated_at DESC, id DESC LIMIT $3")
+
+	rows, err := conn(ctx, tr.db).QueryContext(
+		ctx,
+		sb.String(),
+		ownerID,
+		toTSQuery(query),
+		limit,
+		titleHeadlineOptions,
+		descriptionHeadlineOptions,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: search tasks: %w", op, err)
+	}
+	defer rows.Close()
+
+	results := make([]services.TaskSearchResult, 0)
+
+	for rows.Next() {
+		var (
+			taskID             string
+			ownerID            string
+			title              string
+			description        string
+			deadline           *time.Time
+			isCompleted        bool
+			completedAt        *time.Time
+			createdAt          time.Time
+			updatedAt          time.Time
+			archivedAt         *time.Time
+			deletedAt          *time.Time
+			version            int64
+			rank               float64
+			titleSnippet       string
+			descriptionSnippet string
+		)
+
+		err := rows.Scan(
+			&taskID,
+			&ownerID,
+			&title,
+			&description,
+			&deadline,
+			&isCompleted,
+			&completedAt,
+			&createdAt,
+			&updatedAt,
+			&archivedAt,
+			&deletedAt,
+			&version,
+			&rank,
+			&titleSnippet,
+			&descriptionSnippet,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: scan row: %w", op, err)
+		}
+
+		task, err := models.NewTaskFromDB(models.TaskFromDBParams{
+			ID:          taskID,
+			OwnerID:     ownerID,
+			Title:       title,
+			Description: description,
+			Deadline:    deadline,
+			IsCompleted: isCompleted,
+			CompletedAt: completedAt,
+			CreatedAt:   createdAt,
+			UpdatedAt:   updatedAt,
+			ArchivedAt:  archivedAt,
+			DeletedAt:   deletedAt,
+			Version:     version,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s: restore task: %w", op, err)
+		}
+
+		results = append(results, services.TaskSearchResult{
+			Task:               task,
+			Rank:               rank,
+			TitleSnippet:       snippet(titleSnippet),
+			DescriptionSnippet: snippet(descriptionSnippet),
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows: %w", op, err)
+	}
+
+	return results, nil
+}
+
+// FindChangedSince returns at most limit changes of the tasks of the given owner
+// with a change sequence number greater than since, ordered by their numbers.
+//
+// A task that still exists is reported as created if it was created after since
+// and as updated otherwise, including a task in the trash. A permanently removed task
+// is reported as deleted by its tombstone. If nothing has changed, it returns an empty slice and a nil error.
+//
+// An error is returned if the query execution fails or a row cannot be scanned.
+func (tr *TaskRepository) FindChangedSince(
+	ctx context.Context,
+	ownerID string,
+	since int64,
+	limit int,
+) ([]services.TaskSyncChange, error) {
+	const op = "postgres.TaskRepository.FindChangedSince"
+
+	const query = `
+		SELECT id, change_seq, CASE WHEN created_seq > $2 THEN 'created' ELSE 'updated' END
+		FROM tasks
+		WHERE owner_id = $1 AND change_seq > $2
+		UNION ALL
+		SELECT task_id, change_seq, 'deleted'
+		FROM task_tombstones
+		WHERE owner_id = $1 AND change_seq > $2
+		ORDER BY 2
+		LIMIT $3`
+
+	rows, err := conn(ctx, tr.db).QueryContext(ctx, query, ownerID, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: find changed tasks: %w", op, err)
+	}
+	defer rows.Close()
+
+	changes := make([]services.TaskSyncChange, 0)
+
+	for rows.Next() {
+		var (
+			taskID string
+			seq    int64
+			kind   string
+		)
+
+		if err := rows.Scan(&taskID, &seq, &kind); err != nil {
+			return nil, fmt.Errorf("%s: scan row: %w", op, err)
+		}
+
+		changes = append(changes, services.TaskSyncChange{
+			TaskID: taskID,
+			Seq:    seq,
+			Kind:   services.TaskSyncChangeKind(kind),
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows: %w", op, err)
+	}
+
+	return changes, nil
+}
+
+// scanTasks reads all rows of the given result set into tasks.
+// The rows must contain all columns of the tasks table in the order of FindByOwner.
+func scanTasks(rows *sql.Rows) ([]*models.Task, error) {
 	tasks := make([]*models.Task, 0)
 
 	for rows.Next() {
@@ -248,6 +826,11 @@ func (tr *TaskRepository) FindByOwner(ctx context.Context, ownerID string) ([]*m
 			deadline    *time.Time
 			isCompleted bool
 			completedAt *time.Time
+			createdAt   time.Time
+			updatedAt   time.Time
+			archivedAt  *time.Time
+			deletedAt   *time.Time
+			version     int64
 		)
 
 		err := rows.Scan(
@@ -258,9 +841,14 @@ func (tr *TaskRepository) FindByOwner(ctx context.Context, ownerID string) ([]*m
 			&deadline,
 			&isCompleted,
 			&completedAt,
+			&createdAt,
+			&updatedAt,
+			&archivedAt,
+			&deletedAt,
+			&version,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("%s: scan row: %w", op, err)
+			return nil, fmt.Errorf("scan row: %w", err)
 		}
 
 		task, err := models.NewTaskFromDB(models.TaskFromDBParams{
@@ -271,19 +859,69 @@ func (tr *TaskRepository) FindByOwner(ctx context.Context, ownerID string) ([]*m
 			Deadline:    deadline,
 			IsCompleted: isCompleted,
 			CompletedAt: completedAt,
+			CreatedAt:   createdAt,
+			UpdatedAt:   updatedAt,
+			ArchivedAt:  archivedAt,
+			DeletedAt:   deletedAt,
+			Version:     version,
 		})
 		if err != nil {
-			return nil, fmt.Errorf("%s: restore task: %w", op, err)
+			return nil, fmt.Errorf("restore task: %w", err)
 		}
 
 		tasks = append(tasks, task)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("%s: rows: %w", op, err)
+		return nil, fmt.Errorf("rows: %w", err)
 	}
 
 	return tasks, nil
 }
 
+// The options of ts_headline for the title and the description snippets.
+// The matched words are enclosed in control characters instead of <b> and </b>,
+// so that snippet can escape the text first and then turn them into the tags.
+const (
+	headlineStartSel = "\x02"
+	headlineStopSel  = "\x03"
+
+	titleHeadlineOptions       = `StartSel="` + headlineStartSel + `", StopSel="` + headlineStopSel + `", HighlightAll=true`
+	descriptionHeadlineOptions = `StartSel="` + headlineStartSel + `", StopSel="` + headlineStopSel + `", ` +
+		`MaxFragments=2, MaxWords=20, MinWords=5, FragmentDelimiter=" ... "`
+)
+
+var headlineSelectors = strings.NewReplacer(headlineStartSel, "<b>", headlineStopSel, "</b>")
+
+// snippet HTML-escapes the text of a ts_headline result
+// and encloses the matched words in <b> and </b>.
+func snippet(headline string) string {
+	return headlineSelectors.Replace(html.EscapeString(headline))
+}
+
+// toTSQuery converts the search query to the to_tsquery syntax:
+// the terms are joined with &, the words of a phrase with <-> and a prefix term gets :*.
+// The words consist of letters and digits only, so they need no escaping.
+func toTSQuery(query vo.SearchQuery) string {
+	terms := query.Terms()
+	parts := make([]string, len(terms))
+
+	for i, term := range terms {
+		words := term.Words()
+
+		lexemes := make([]string, len(words))
+		for j, word := range words {
+			lexemes[j] = "'" + word + "'"
+		}
+
+		if term.IsPrefix() {
+			lexemes[len(lexemes)-1] += ":*"
+		}
+
+		parts[i] = "(" + strings.Join(lexemes, " <-> ") + ")"
+	}
+
+	return strings.Join(parts, " & ")
+}
+
 var _ services.TaskRepository = (*TaskRepository)(nil)
@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// txKey is the context key under which the current transaction is stored.
+type txKey struct{}
+
+// executor is the subset of methods shared by *sql.DB and *sql.Tx
+// that the repositories use to run queries.
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// conn returns the transaction stored in ctx by Transactor.WithinTx,
+// or db if the call is not a part of a transaction.
+func conn(ctx context.Context, db *sql.DB) executor {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+
+	return db
+}
+
+// Transactor runs functions within a PostgreSQL transaction.
+type Transactor struct {
+	db *sql.DB
+}
+
+// NewTransactor creates a new Transactor using the provided sql.DB.
+// It returns an error if the db argument is nil.
+func NewTransactor(db *sql.DB) (*Transactor, error) {
+	const op = "postgres.Transactor.NewTransactor"
+
+	if db == nil {
+		return nil, fmt.Errorf("%s: db is nil", op)
+	}
+
+	return &Transactor{db: db}, nil
+}
+
+// WithinTx calls fn with a context that carries a new transaction, so that
+// all repository calls made with that context are a part of it.
+// The transaction is committed if fn returns nil and rolled back otherwise,
+// in which case the error of fn is returned as is.
+//
+// If ctx already carries a transaction, fn joins it instead of starting a new one.
+func (t *Transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	const op = "postgres.Transactor.WithinTx"
+
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: begin transaction: %w", op, err)
+	}
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: commit transaction: %w", op, err)
+	}
+
+	return nil
+}
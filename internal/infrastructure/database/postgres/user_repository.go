@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/cyberbrain-dev/taskery-api/internal/domain/user/models"
 	"github.com/cyberbrain-dev/taskery-api/internal/services"
@@ -38,7 +39,9 @@ func NewUserRepository(db *sql.DB) (*UserRepository, error) {
 func (ur *UserRepository) Create(ctx context.Context, u *models.User) error {
 	const op = "postgres.UserRepository.Create"
 
-	const query = `INSERT INTO users(id, username, email, password_hash) VALUES ($1, $2, $3, $4)`
+	const query = `
+		INSERT INTO users(id, username, email, password_hash, created_at, updated_at, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
 
 	_, err := ur.db.ExecContext(
 		ctx, query,
@@ -46,6 +49,9 @@ func (ur *UserRepository) Create(ctx context.Context, u *models.User) error {
 		u.Username().String(),
 		u.Email().String(),
 		u.PasswordHash().String(),
+		u.CreatedAt(),
+		u.UpdatedAt(),
+		u.Version(),
 	)
 	if err != nil {
 		var pqErr *pq.Error
@@ -75,7 +81,9 @@ func (ur *UserRepository) Create(ctx context.Context, u *models.User) error {
 func (ur *UserRepository) FindByID(ctx context.Context, id string) (*models.User, error) {
 	const op = "postgres.UserRepository.FindByID"
 
-	const query = `SELECT id, username, email, password_hash FROM users WHERE id = $1`
+	const query = `
+		SELECT id, username, email, password_hash, created_at, updated_at, version
+		FROM users WHERE id = $1`
 
 	row := ur.db.QueryRowContext(ctx, query, id)
 
@@ -84,9 +92,12 @@ func (ur *UserRepository) FindByID(ctx context.Context, id string) (*models.User
 		email        string
 		username     string
 		passwordHash string
+		createdAt    time.Time
+		updatedAt    time.Time
+		version      int64
 	)
 
-	err := row.Scan(&userID, &username, &email, &passwordHash)
+	err := row.Scan(&userID, &username, &email, &passwordHash, &createdAt, &updatedAt, &version)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, services.ErrUserRepoNotFound
@@ -100,6 +111,9 @@ func (ur *UserRepository) FindByID(ctx context.Context, id string) (*models.User
 		Email:        email,
 		Username:     username,
 		PasswordHash: passwordHash,
+		CreatedAt:    createdAt,
+		UpdatedAt:    updatedAt,
+		Version:      version,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("%s: restore user: %w", op, err)
@@ -108,6 +122,68 @@ func (ur *UserRepository) FindByID(ctx context.Context, id string) (*models.User
 	return user, nil
 }
 
+// FindByIDs looks up the users with the given ids in the repository.
+//
+// It returns the users that exist in no particular order; the ids
+// that do not belong to any user are skipped.
+//
+// If a database error occurs while querying or scanning the result,
+// or if a user cannot be restored from the persisted data,
+// FindByIDs returns a non-nil error wrapping the underlying failure.
+func (ur *UserRepository) FindByIDs(ctx context.Context, ids []string) ([]*models.User, error) {
+	const op = "postgres.UserRepository.FindByIDs"
+
+	const query = `
+		SELECT id, username, email, password_hash, created_at, updated_at, version
+		FROM users WHERE id = ANY($1::uuid[])`
+
+	rows, err := ur.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("%s: find users: %w", op, err)
+	}
+	defer rows.Close()
+
+	users := make([]*models.User, 0, len(ids))
+
+	for rows.Next() {
+		var (
+			userID       string
+			email        string
+			username     string
+			passwordHash string
+			createdAt    time.Time
+			updatedAt    time.Time
+			version      int64
+		)
+
+		err := rows.Scan(&userID, &username, &email, &passwordHash, &createdAt, &updatedAt, &version)
+		if err != nil {
+			return nil, fmt.Errorf("%s: scan row: %w", op, err)
+		}
+
+		user, err := models.NewUserFromDB(models.UserFromDBParams{
+			ID:           userID,
+			Email:        email,
+			Username:     username,
+			PasswordHash: passwordHash,
+			CreatedAt:    createdAt,
+			UpdatedAt:    updatedAt,
+			Version:      version,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s: restore user: %w", op, err)
+		}
+
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows: %w", op, err)
+	}
+
+	return users, nil
+}
+
 // FindByEmail looks up a user by its email in the repository.
 //
 // It returns the corresponding *models.User if the user exists.
@@ -122,7 +198,9 @@ func (ur *UserRepository) FindByID(ctx context.Context, id string) (*models.User
 func (ur *UserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
 	const op = "postgres.UserRepository.FindByEmail"
 
-	const query = `SELECT id, username, email, password_hash FROM users WHERE email = $1`
+	const query = `
+		SELECT id, username, email, password_hash, created_at, updated_at, version
+		FROM users WHERE email = $1`
 
 	row := ur.db.QueryRowContext(ctx, query, email)
 
@@ -131,9 +209,12 @@ func (ur *UserRepository) FindByEmail(ctx context.Context, email string) (*model
 		userEmail    string
 		username     string
 		passwordHash string
+		createdAt    time.Time
+		updatedAt    time.Time
+		version      int64
 	)
 
-	err := row.Scan(&userID, &username, &userEmail, &passwordHash)
+	err := row.Scan(&userID, &username, &userEmail, &passwordHash, &createdAt, &updatedAt, &version)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, services.ErrUserRepoNotFound
@@ -147,6 +228,9 @@ func (ur *UserRepository) FindByEmail(ctx context.Context, email string) (*model
 		Email:        userEmail,
 		Username:     username,
 		PasswordHash: passwordHash,
+		CreatedAt:    createdAt,
+		UpdatedAt:    updatedAt,
+		Version:      version,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("%s: restore user: %w", op, err)
@@ -157,11 +241,13 @@ func (ur *UserRepository) FindByEmail(ctx context.Context, email string) (*model
 
 // Update updates the persisted data of the given user u.
 //
-// It stores the user's current username, email, and password hash
-// identified by u.ID.
+// It stores the user's current username, email, password hash and
+// update timestamp identified by u.ID. The update is applied only if the stored version
+// equals u.Version, in which case the stored version is incremented.
 //
 // If no user with the given ID exists, Update returns
-// services.ErrUserRepoNotFound.
+// services.ErrUserRepoNotFound. If the stored version differs from
+// u.Version, Update returns services.ErrUserRepoConflict.
 //
 // If a database error occurs while executing the update or determining
 // the number of affected rows, Update returns a non-nil error wrapping
@@ -171,7 +257,14 @@ func (ur *UserRepository) FindByEmail(ctx context.Context, email string) (*model
 func (ur *UserRepository) Update(ctx context.Context, u *models.User) error {
 	const op = "postgres.UserRepository.Update"
 
-	const query = `UPDATE users SET username = $1, email = $2, password_hash = $3 WHERE id = $4`
+	const query = `
+		UPDATE users SET
+			username = $1,
+			email = $2,
+			password_hash = $3,
+			updated_at = $4,
+			version = version + 1
+		WHERE id = $5 AND version = $6`
 
 	res, err := ur.db.ExecContext(
 		ctx,
@@ -179,7 +272,9 @@ func (ur *UserRepository) Update(ctx context.Context, u *models.User) error {
 		u.Username().String(),
 		u.Email().String(),
 		u.PasswordHash().String(),
+		u.UpdatedAt(),
 		u.ID().String(),
+		u.Version(),
 	)
 	if err != nil {
 		return fmt.Errorf("%s: update user: %w", op, err)
@@ -191,6 +286,21 @@ func (ur *UserRepository) Update(ctx context.Context, u *models.User) error {
 	}
 
 	if affected == 0 {
+		var exists bool
+
+		err := ur.db.QueryRowContext(
+			ctx,
+			`SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)`,
+			u.ID().String(),
+		).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("%s: check user existence: %w", op, err)
+		}
+
+		if exists {
+			return services.ErrUserRepoConflict
+		}
+
 		return services.ErrUserRepoNotFound
 	}
 
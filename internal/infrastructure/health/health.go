@@ -0,0 +1,174 @@
+// Package health reports the liveness and the readiness of the application
+// based on a set of checks of its dependencies.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Status is the status of the application or of one of its dependencies.
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+)
+
+// ShutdownCheck is the name of the check that fails the readiness once the shutdown has begun.
+const ShutdownCheck = "shutdown"
+
+// ErrShuttingDown is reported by the shutdown check once the shutdown has begun.
+var ErrShuttingDown = errors.New("server is shutting down")
+
+// Checker checks a dependency of the application.
+type Checker interface {
+	// Name returns the name the check is reported under, e.g. "postgres".
+	Name() string
+
+	// Check returns the details of the dependency, which may be nil,
+	// and a non-nil error if the dependency is not available.
+	Check(ctx context.Context) (map[string]any, error)
+}
+
+// CheckResult is the result of a single check.
+type CheckResult struct {
+	Status  Status         `json:"status"`
+	Error   string         `json:"error,omitempty"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// Report is the result of all the checks. Its status is down if any of the checks is down.
+type Report struct {
+	Status Status                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Health runs the liveness and the readiness checks.
+// The checks must be added before the reports are requested.
+type Health struct {
+	timeout time.Duration
+
+	liveness  []Checker
+	readiness []Checker
+
+	shuttingDown atomic.Bool
+}
+
+// New creates a new Health that gives every check the timeout to complete.
+func New(timeout time.Duration) *Health {
+	return &Health{timeout: timeout}
+}
+
+// AddLivenessCheck adds a check that is run by Liveness.
+func (h *Health) AddLivenessCheck(c Checker) {
+	h.liveness = append(h.liveness, c)
+}
+
+// AddReadinessCheck adds a check that is run by Readiness.
+func (h *Health) AddReadinessCheck(c Checker) {
+	h.readiness = append(h.readiness, c)
+}
+
+// SetShuttingDown makes the readiness fail, so that the load balancers
+// stop sending the traffic before the server is shut down.
+func (h *Health) SetShuttingDown() {
+	h.shuttingDown.Store(true)
+}
+
+// Liveness reports whether the process is alive. Without liveness checks it is always up.
+func (h *Health) Liveness(ctx context.Context) Report {
+	return h.run(ctx, h.liveness)
+}
+
+// Readiness reports whether the application is ready to serve the traffic.
+// It is down once the shutdown has begun, whatever the results of the checks are.
+func (h *Health) Readiness(ctx context.Context) Report {
+	report := h.run(ctx, h.readiness)
+
+	if h.shuttingDown.Load() {
+		report.Status = StatusDown
+		report.Checks[ShutdownCheck] = CheckResult{Status: StatusDown, Error: ErrShuttingDown.Error()}
+	}
+
+	return report
+}
+
+// run runs the checks concurrently, each within the timeout.
+func (h *Health) run(ctx context.Context, checkers []Checker) Report {
+	report := Report{
+		Status: StatusUp,
+		Checks: make(map[string]CheckResult, len(checkers)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, c := range checkers {
+		wg.Go(func() {
+			result := h.check(ctx, c)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			report.Checks[c.Name()] = result
+			if result.Status == StatusDown {
+				report.Status = StatusDown
+			}
+		})
+	}
+
+	wg.Wait()
+
+	return report
+}
+
+func (h *Health) check(ctx context.Context, c Checker) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	details, err := c.Check(ctx)
+	if err != nil {
+		return CheckResult{Status: StatusDown, Error: err.Error(), Details: details}
+	}
+
+	return CheckResult{Status: StatusUp, Details: details}
+}
+
+// LivenessHandler returns the HTTP handler that writes the liveness report.
+func (h *Health) LivenessHandler() http.Handler {
+	return reportHandler(h.Liveness)
+}
+
+// ReadinessHandler returns the HTTP handler that writes the readiness report.
+func (h *Health) ReadinessHandler() http.Handler {
+	return reportHandler(h.Readiness)
+}
+
+// reportHandler writes the report as JSON with 200 if it is up and 503 otherwise.
+func reportHandler(report func(ctx context.Context) Report) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rep := report(r.Context())
+
+		status := http.StatusOK
+		if rep.Status != StatusUp {
+			status = http.StatusServiceUnavailable
+		}
+
+		data, err := json.Marshal(rep)
+		if err != nil {
+			http.Error(w, "failed to encode health report", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+		_, _ = w.Write(data)
+	})
+}
@@ -0,0 +1,126 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/health"
+	"github.com/stretchr/testify/require"
+)
+
+// checkerFunc is a health.Checker with the given name that calls the function.
+type checkerFunc struct {
+	name  string
+	check func(ctx context.Context) (map[string]any, error)
+}
+
+func (c checkerFunc) Name() string {
+	return c.name
+}
+
+func (c checkerFunc) Check(ctx context.Context) (map[string]any, error) {
+	return c.check(ctx)
+}
+
+func up(name string) health.Checker {
+	return checkerFunc{name: name, check: func(ctx context.Context) (map[string]any, error) {
+		return map[string]any{"version": 3}, nil
+	}}
+}
+
+func down(name string) health.Checker {
+	return checkerFunc{name: name, check: func(ctx context.Context) (map[string]any, error) {
+		return nil, errors.New("connection refused")
+	}}
+}
+
+// slow waits for the deadline of the check.
+func slow(name string) health.Checker {
+	return checkerFunc{name: name, check: func(ctx context.Context) (map[string]any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}}
+}
+
+func TestHealth_ReadinessHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		checks       []health.Checker
+		shuttingDown bool
+		wantStatus   int
+		wantBody     string
+	}{
+		{
+			name:       "no checks",
+			wantStatus: http.StatusOK,
+			wantBody:   `{"status":"up","checks":{}}`,
+		},
+		{
+			name:       "all checks are up",
+			checks:     []health.Checker{up("postgres"), up("cache")},
+			wantStatus: http.StatusOK,
+			wantBody: `{"status":"up","checks":{` +
+				`"postgres":{"status":"up","details":{"version":3}},` +
+				`"cache":{"status":"up","details":{"version":3}}}}`,
+		},
+		{
+			name:       "one check is down",
+			checks:     []health.Checker{up("postgres"), down("cache")},
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody: `{"status":"down","checks":{` +
+				`"postgres":{"status":"up","details":{"version":3}},` +
+				`"cache":{"status":"down","error":"connection refused"}}}`,
+		},
+		{
+			name:       "check times out",
+			checks:     []health.Checker{slow("postgres")},
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   `{"status":"down","checks":{"postgres":{"status":"down","error":"context deadline exceeded"}}}`,
+		},
+		{
+			name:         "shutting down",
+			checks:       []health.Checker{up("postgres")},
+			shuttingDown: true,
+			wantStatus:   http.StatusServiceUnavailable,
+			wantBody: `{"status":"down","checks":{` +
+				`"postgres":{"status":"up","details":{"version":3}},` +
+				`"shutdown":{"status":"down","error":"server is shutting down"}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := health.New(10 * time.Millisecond)
+			for _, c := range tt.checks {
+				h.AddReadinessCheck(c)
+			}
+
+			if tt.shuttingDown {
+				h.SetShuttingDown()
+			}
+
+			rr := httptest.NewRecorder()
+			h.ReadinessHandler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			require.Equal(t, tt.wantStatus, rr.Code)
+			require.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+			require.JSONEq(t, tt.wantBody, rr.Body.String())
+		})
+	}
+}
+
+func TestHealth_LivenessHandler(t *testing.T) {
+	h := health.New(time.Second)
+	h.AddReadinessCheck(down("postgres"))
+	h.SetShuttingDown()
+
+	rr := httptest.NewRecorder()
+	h.LivenessHandler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.JSONEq(t, `{"status":"up","checks":{}}`, rr.Body.String())
+}
@@ -0,0 +1,51 @@
+// Package idempotency stores the responses to requests made with an idempotency key,
+// so that a retried request gets the same response instead of being processed again.
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// ErrKeyNotReserved is returned by Store.Complete if the key has no pending record.
+var ErrKeyNotReserved = errors.New("idempotency key is not reserved")
+
+// Response is a stored response to a request.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Record is the state of an idempotency key.
+type Record struct {
+	// Fingerprint identifies the request that the key was first used with.
+	Fingerprint string
+
+	// Response is the response to the request, or nil if the request is still being processed.
+	Response *Response
+
+	// ExpiresAt is the time after which the key can be used again.
+	ExpiresAt time.Time
+}
+
+// Store keeps the records of idempotency keys until they expire.
+type Store interface {
+	// Reserve creates a pending record with the fingerprint for the key
+	// unless the key has a record that has not expired by now.
+	// It returns nil if the key has been reserved and the existing record otherwise.
+	Reserve(ctx context.Context, key string, fingerprint string, now time.Time, expiresAt time.Time) (*Record, error)
+
+	// Complete saves the response to the request the key was reserved for.
+	// It returns ErrKeyNotReserved if the key has no pending record.
+	Complete(ctx context.Context, key string, response Response) error
+
+	// Release removes the pending record of the key, so that the request can be retried.
+	// Releasing a key that has no pending record does nothing.
+	Release(ctx context.Context, key string) error
+
+	// DeleteExpired removes the records that expired by now and returns their number.
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,105 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore is a Store that keeps the records in memory.
+// The records are lost on restart and are not shared between instances of the application.
+// It is safe for concurrent use.
+type MemoryStore struct {
+	mu      sync.Mutex
+	records map[string]Record
+}
+
+// NewMemoryStore creates a new empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: make(map[string]Record)}
+}
+
+// Reserve creates a pending record with the fingerprint for the key
+// unless the key has a record that has not expired by now.
+// It returns nil if the key has been reserved and a copy of the existing record otherwise.
+func (s *MemoryStore) Reserve(
+	ctx context.Context,
+	key string,
+	fingerprint string,
+	now time.Time,
+	expiresAt time.Time,
+) (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record, ok := s.records[key]; ok && record.ExpiresAt.After(now) {
+		return copyRecord(record), nil
+	}
+
+	s.records[key] = Record{Fingerprint: fingerprint, ExpiresAt: expiresAt}
+
+	return nil, nil
+}
+
+// Complete saves the response to the request the key was reserved for.
+// It returns ErrKeyNotReserved if the key has no pending record.
+func (s *MemoryStore) Complete(ctx context.Context, key string, response Response) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[key]
+	if !ok || record.Response != nil {
+		return ErrKeyNotReserved
+	}
+
+	record.Response = copyResponse(response)
+	s.records[key] = record
+
+	return nil
+}
+
+// Release removes the pending record of the key.
+// Releasing a key that has no pending record does nothing.
+func (s *MemoryStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record, ok := s.records[key]; ok && record.Response == nil {
+		delete(s.records, key)
+	}
+
+	return nil
+}
+
+// DeleteExpired removes the records that expired by now and returns their number.
+func (s *MemoryStore) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deleted int64
+	for key, record := range s.records {
+		if !record.ExpiresAt.After(now) {
+			delete(s.records, key)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
+
+func copyRecord(record Record) *Record {
+	if record.Response != nil {
+		record.Response = copyResponse(*record.Response)
+	}
+
+	return &record
+}
+
+func copyResponse(response Response) *Response {
+	response.Header = response.Header.Clone()
+	response.Body = append([]byte(nil), response.Body...)
+
+	return &response
+}
+
+var _ Store = (*MemoryStore)(nil)
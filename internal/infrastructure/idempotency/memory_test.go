@@ -0,0 +1,86 @@
+package idempotency_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/idempotency"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	store := idempotency.NewMemoryStore()
+
+	ctx := context.Background()
+	now := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+
+	t.Run("reserve and complete", func(t *testing.T) {
+		record, err := store.Reserve(ctx, "user:key-1", "fingerprint", now, now.Add(time.Hour))
+		require.NoError(t, err)
+		require.Nil(t, record)
+
+		record, err = store.Reserve(ctx, "user:key-1", "other", now, now.Add(time.Hour))
+		require.NoError(t, err)
+		require.NotNil(t, record)
+		require.Equal(t, "fingerprint", record.Fingerprint)
+		require.Nil(t, record.Response)
+
+		header := http.Header{"Content-Type": []string{"application/json"}}
+		body := []byte(`{"task_id":"1"}`)
+
+		err = store.Complete(ctx, "user:key-1", idempotency.Response{
+			StatusCode: http.StatusCreated,
+			Header:     header,
+			Body:       body,
+		})
+		require.NoError(t, err)
+
+		// the stored response does not share memory with the saved one
+		header.Set("Content-Type", "text/plain")
+		body[0] = '['
+
+		record, err = store.Reserve(ctx, "user:key-1", "fingerprint", now, now.Add(time.Hour))
+		require.NoError(t, err)
+		require.NotNil(t, record)
+		require.Equal(t, http.StatusCreated, record.Response.StatusCode)
+		require.Equal(t, "application/json", record.Response.Header.Get("Content-Type"))
+		require.Equal(t, `{"task_id":"1"}`, string(record.Response.Body))
+	})
+	t.Run("complete without reservation", func(t *testing.T) {
+		err := store.Complete(ctx, "user:missing", idempotency.Response{StatusCode: http.StatusOK})
+		require.ErrorIs(t, err, idempotency.ErrKeyNotReserved)
+
+		err = store.Complete(ctx, "user:key-1", idempotency.Response{StatusCode: http.StatusOK})
+		require.ErrorIs(t, err, idempotency.ErrKeyNotReserved)
+	})
+	t.Run("release", func(t *testing.T) {
+		record, err := store.Reserve(ctx, "user:key-2", "fingerprint", now, now.Add(time.Hour))
+		require.NoError(t, err)
+		require.Nil(t, record)
+
+		require.NoError(t, store.Release(ctx, "user:key-2"))
+		require.NoError(t, store.Release(ctx, "user:key-1"))
+
+		record, err = store.Reserve(ctx, "user:key-2", "other", now, now.Add(time.Hour))
+		require.NoError(t, err)
+		require.Nil(t, record)
+
+		record, err = store.Reserve(ctx, "user:key-1", "fingerprint", now, now.Add(time.Hour))
+		require.NoError(t, err)
+		require.NotNil(t, record)
+	})
+	t.Run("expired key is reserved again", func(t *testing.T) {
+		later := now.Add(time.Hour)
+
+		record, err := store.Reserve(ctx, "user:key-1", "new fingerprint", later, later.Add(time.Hour))
+		require.NoError(t, err)
+		require.Nil(t, record)
+	})
+	t.Run("delete expired", func(t *testing.T) {
+		deleted, err := store.DeleteExpired(ctx, now.Add(90*time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, int64(1), deleted)
+	})
+}
@@ -0,0 +1,80 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
+)
+
+// ExpiredKeysDeleter removes the idempotency keys that expired by now.
+type ExpiredKeysDeleter interface {
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+// PurgeIdempotencyKeysJob is a background job that periodically removes expired idempotency keys.
+type PurgeIdempotencyKeysJob struct {
+	deleter  ExpiredKeysDeleter
+	clock    clock.Clock
+	interval time.Duration
+	timeout  time.Duration
+	logger   *slog.Logger
+}
+
+// NewPurgeIdempotencyKeysJob creates a job that removes the idempotency keys
+// that have expired by the current time of clk. The keys are checked every interval,
+// and each check is limited by timeout.
+func NewPurgeIdempotencyKeysJob(
+	deleter ExpiredKeysDeleter,
+	clk clock.Clock,
+	interval time.Duration,
+	timeout time.Duration,
+	logger *slog.Logger,
+) *PurgeIdempotencyKeysJob {
+	return &PurgeIdempotencyKeysJob{
+		deleter:  deleter,
+		clock:    clk,
+		interval: interval,
+		timeout:  timeout,
+		logger:   logger,
+	}
+}
+
+// Run removes expired keys immediately and then every interval until ctx is done.
+// It blocks, so it is usually started in a separate goroutine.
+func (j *PurgeIdempotencyKeysJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce removes expired keys once. Errors are logged and not returned,
+// so that a failed run does not stop the following ones.
+func (j *PurgeIdempotencyKeysJob) RunOnce(ctx context.Context) {
+	const op = "jobs.PurgeIdempotencyKeysJob.RunOnce"
+
+	logger := j.logger.With(slog.String("op", op))
+
+	ctx, cancel := context.WithTimeout(ctx, j.timeout)
+	defer cancel()
+
+	deleted, err := j.deleter.DeleteExpired(ctx, j.clock.Now())
+	if err != nil {
+		logger.Error("failed to delete expired idempotency keys", slog.String("err", err.Error()))
+		return
+	}
+
+	if deleted > 0 {
+		logger.Info("expired idempotency keys deleted", slog.Int64("deleted", deleted))
+	}
+}
@@ -0,0 +1,81 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
+)
+
+// ExpiredBucketsDeleter removes the rate limit buckets that are refilled completely by now.
+type ExpiredBucketsDeleter interface {
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+// PurgeRateLimitBucketsJob is a background job that periodically removes the rate limit buckets
+// that are refilled completely, as they are the same as the new ones.
+type PurgeRateLimitBucketsJob struct {
+	deleter  ExpiredBucketsDeleter
+	clock    clock.Clock
+	interval time.Duration
+	timeout  time.Duration
+	logger   *slog.Logger
+}
+
+// NewPurgeRateLimitBucketsJob creates a job that removes the rate limit buckets
+// that are refilled completely by the current time of clk. The buckets are checked every interval,
+// and each check is limited by timeout.
+func NewPurgeRateLimitBucketsJob(
+	deleter ExpiredBucketsDeleter,
+	clk clock.Clock,
+	interval time.Duration,
+	timeout time.Duration,
+	logger *slog.Logger,
+) *PurgeRateLimitBucketsJob {
+	return &PurgeRateLimitBucketsJob{
+		deleter:  deleter,
+		clock:    clk,
+		interval: interval,
+		timeout:  timeout,
+		logger:   logger,
+	}
+}
+
+// Run removes expired buckets immediately and then every interval until ctx is done.
+// It blocks, so it is usually started in a separate goroutine.
+func (j *PurgeRateLimitBucketsJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce removes expired buckets once. Errors are logged and not returned,
+// so that a failed run does not stop the following ones.
+func (j *PurgeRateLimitBucketsJob) RunOnce(ctx context.Context) {
+	const op = "jobs.PurgeRateLimitBucketsJob.RunOnce"
+
+	logger := j.logger.With(slog.String("op", op))
+
+	ctx, cancel := context.WithTimeout(ctx, j.timeout)
+	defer cancel()
+
+	deleted, err := j.deleter.DeleteExpired(ctx, j.clock.Now())
+	if err != nil {
+		logger.Error("failed to delete expired rate limit buckets", slog.String("err", err.Error()))
+		return
+	}
+
+	if deleted > 0 {
+		logger.Info("expired rate limit buckets deleted", slog.Int64("deleted", deleted))
+	}
+}
@@ -0,0 +1,94 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/jobs"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/jobs/mocks"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeRateLimitBucketsJob_RunOnce(t *testing.T) {
+	now := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		mockSetup func(deleter *mocks.ExpiredBucketsDeleter)
+	}{
+		{
+			name: "success",
+			mockSetup: func(deleter *mocks.ExpiredBucketsDeleter) {
+				deleter.On("DeleteExpired", mock.Anything, now).
+					Once().
+					Return(int64(3), nil)
+			},
+		},
+		{
+			name: "nothing to delete",
+			mockSetup: func(deleter *mocks.ExpiredBucketsDeleter) {
+				deleter.On("DeleteExpired", mock.Anything, now).
+					Once().
+					Return(int64(0), nil)
+			},
+		},
+		{
+			name: "delete failed",
+			mockSetup: func(deleter *mocks.ExpiredBucketsDeleter) {
+				deleter.On("DeleteExpired", mock.Anything, now).
+					Once().
+					Return(int64(0), errors.New("failed to connect to db"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deleter := new(mocks.ExpiredBucketsDeleter)
+			tt.mockSetup(deleter)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+
+			job := jobs.NewPurgeRateLimitBucketsJob(deleter, clock.NewFake(now), time.Hour, time.Second, logger)
+			job.RunOnce(context.Background())
+
+			deleter.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPurgeRateLimitBucketsJob_Run(t *testing.T) {
+	now := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	deleter := new(mocks.ExpiredBucketsDeleter)
+	deleter.On("DeleteExpired", mock.Anything, now).
+		Once().
+		Run(func(args mock.Arguments) { cancel() }).
+		Return(int64(0), nil)
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+
+	job := jobs.NewPurgeRateLimitBucketsJob(deleter, clock.NewFake(now), time.Hour, time.Second, logger)
+
+	done := make(chan struct{})
+	go func() {
+		job.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "job did not stop after the context was cancelled")
+	}
+
+	deleter.AssertExpectations(t)
+}
@@ -0,0 +1,78 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// TrashPurger permanently removes tasks that have been in the trash for longer than retention.
+type TrashPurger interface {
+	PurgeTrash(ctx context.Context, retention time.Duration) (int64, error)
+}
+
+// PurgeTrashJob is a background job that periodically purges the trash.
+type PurgeTrashJob struct {
+	purger    TrashPurger
+	retention time.Duration
+	interval  time.Duration
+	timeout   time.Duration
+	logger    *slog.Logger
+}
+
+// NewPurgeTrashJob creates a job that purges tasks which have been in the trash
+// for longer than retention. The trash is checked every interval, and each
+// check is limited by timeout.
+func NewPurgeTrashJob(
+	purger TrashPurger,
+	retention time.Duration,
+	interval time.Duration,
+	timeout time.Duration,
+	logger *slog.Logger,
+) *PurgeTrashJob {
+	return &PurgeTrashJob{
+		purger:    purger,
+		retention: retention,
+		interval:  interval,
+		timeout:   timeout,
+		logger:    logger,
+	}
+}
+
+// Run purges the trash immediately and then every interval until ctx is done.
+// It blocks, so it is usually started in a separate goroutine.
+func (j *PurgeTrashJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce purges the trash once. Errors are logged and not returned,
+// so that a failed run does not stop the following ones.
+func (j *PurgeTrashJob) RunOnce(ctx context.Context) {
+	const op = "jobs.PurgeTrashJob.RunOnce"
+
+	logger := j.logger.With(slog.String("op", op))
+
+	ctx, cancel := context.WithTimeout(ctx, j.timeout)
+	defer cancel()
+
+	purged, err := j.purger.PurgeTrash(ctx, j.retention)
+	if err != nil {
+		logger.Error("failed to purge trash", slog.String("err", err.Error()))
+		return
+	}
+
+	if purged > 0 {
+		logger.Info("trash purged", slog.Int64("purged", purged))
+	}
+}
@@ -0,0 +1,93 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/jobs"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/jobs/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeTrashJob_RunOnce(t *testing.T) {
+	retention := 30 * 24 * time.Hour
+
+	tests := []struct {
+		name      string
+		mockSetup func(purger *mocks.TrashPurger)
+	}{
+		{
+			name: "success",
+			mockSetup: func(purger *mocks.TrashPurger) {
+				purger.On("PurgeTrash", mock.Anything, retention).
+					Once().
+					Return(int64(3), nil)
+			},
+		},
+		{
+			name: "nothing to purge",
+			mockSetup: func(purger *mocks.TrashPurger) {
+				purger.On("PurgeTrash", mock.Anything, retention).
+					Once().
+					Return(int64(0), nil)
+			},
+		},
+		{
+			name: "purge failed",
+			mockSetup: func(purger *mocks.TrashPurger) {
+				purger.On("PurgeTrash", mock.Anything, retention).
+					Once().
+					Return(int64(0), errors.New("failed to connect to db"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			purger := new(mocks.TrashPurger)
+			tt.mockSetup(purger)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+
+			job := jobs.NewPurgeTrashJob(purger, retention, time.Hour, time.Second, logger)
+			job.RunOnce(context.Background())
+
+			purger.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPurgeTrashJob_Run(t *testing.T) {
+	retention := time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	purger := new(mocks.TrashPurger)
+	purger.On("PurgeTrash", mock.Anything, retention).
+		Once().
+		Run(func(args mock.Arguments) { cancel() }).
+		Return(int64(0), nil)
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+
+	job := jobs.NewPurgeTrashJob(purger, retention, time.Hour, time.Second, logger)
+
+	done := make(chan struct{})
+	go func() {
+		job.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "job did not stop after the context was cancelled")
+	}
+
+	purger.AssertExpectations(t)
+}
@@ -0,0 +1,128 @@
+// Package metrics exposes the Prometheus metrics of the application:
+// the HTTP requests, the database connection pool and the business events.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace is the prefix of the names of the application metrics.
+const Namespace = "taskery"
+
+// Login results used as the value of the "result" label of the logins counter.
+const (
+	LoginSucceeded = "succeeded"
+	LoginFailed    = "failed"
+)
+
+// Metrics holds the collectors of the application and the registry they are registered in.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+
+	tasksCreated   prometheus.Counter
+	tasksCompleted prometheus.Counter
+	logins         *prometheus.CounterVec
+}
+
+// New creates the application metrics in a new registry
+// along with the Go runtime and the process collectors.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Number of HTTP requests by method, route pattern and status code.",
+		}, []string{"method", "route", "status"}),
+
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of HTTP requests by method, route pattern and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+
+		tasksCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "tasks",
+			Name:      "created_total",
+			Help:      "Number of created tasks.",
+		}),
+
+		tasksCompleted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "tasks",
+			Name:      "completed_total",
+			Help:      "Number of completed tasks.",
+		}),
+
+		logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "users",
+			Name:      "logins_total",
+			Help:      "Number of login attempts by result.",
+		}, []string{"result"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.tasksCreated,
+		m.tasksCompleted,
+		m.logins,
+	)
+
+	// the series exist from the start, so that the rates can be computed before the first login
+	m.logins.WithLabelValues(LoginSucceeded)
+	m.logins.WithLabelValues(LoginFailed)
+
+	return m
+}
+
+// RegisterDB registers a collector of the db connection pool statistics from sql.DB.Stats.
+func (m *Metrics) RegisterDB(db *sql.DB, dbName string) error {
+	return m.registry.Register(collectors.NewDBStatsCollector(db, dbName))
+}
+
+// Handler returns the HTTP handler that exposes the metrics in the Prometheus format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// ObserveRequest records a served HTTP request.
+func (m *Metrics) ObserveRequest(method, route string, status int, duration time.Duration) {
+	code := strconv.Itoa(status)
+
+	m.httpRequests.WithLabelValues(method, route, code).Inc()
+	m.httpDuration.WithLabelValues(method, route, code).Observe(duration.Seconds())
+}
+
+// TaskCreated records a created task.
+func (m *Metrics) TaskCreated() {
+	m.tasksCreated.Inc()
+}
+
+// TaskCompleted records a completed task.
+func (m *Metrics) TaskCompleted() {
+	m.tasksCompleted.Inc()
+}
+
+// Login records a login attempt with the given result.
+func (m *Metrics) Login(result string) {
+	m.logins.WithLabelValues(result).Inc()
+}
@@ -0,0 +1,204 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/metrics"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/cyberbrain-dev/taskery-api/internal/services/mocks"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// inlineTransactor runs functions in place, without a real transaction.
+type inlineTransactor struct{}
+
+func (inlineTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// scrape returns the metrics exposed by the handler of m.
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	t.Helper()
+
+	rr := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	return rr.Body.String()
+}
+
+func TestMetrics_ObserveRequest(t *testing.T) {
+	m := metrics.New()
+
+	m.ObserveRequest(http.MethodGet, "/api/v1/tasks/{id}", http.StatusOK, 30*time.Millisecond)
+	m.ObserveRequest(http.MethodGet, "/api/v1/tasks/{id}", http.StatusOK, 20*time.Millisecond)
+
+	body := scrape(t, m)
+
+	require.Contains(t, body, `taskery_http_requests_total{method="GET",route="/api/v1/tasks/{id}",status="200"} 2`)
+	require.Contains(
+		t,
+		body,
+		`taskery_http_request_duration_seconds_count{method="GET",route="/api/v1/tasks/{id}",status="200"} 2`,
+	)
+	require.Contains(t, body, "go_goroutines")
+}
+
+func TestTaskService_Create(t *testing.T) {
+	repo := new(mocks.TaskRepository)
+	repo.On("Create", mock.Anything, mock.Anything).Once().Return(nil)
+	repo.On("Create", mock.Anything, mock.Anything).Once().Return(errors.New("failed to load db"))
+
+	events := new(mocks.TaskEventRepository)
+	events.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	svc, err := services.NewTaskService(repo, events, inlineTransactor{}, clock.Real{}, nil)
+	require.NoError(t, err)
+
+	m := metrics.New()
+	decorated := metrics.NewTaskService(svc, m)
+
+	cmd := services.CreateTaskCommand{Title: "title", OwnerID: uuid.New()}
+
+	_, err = decorated.Create(context.Background(), cmd)
+	require.NoError(t, err)
+
+	_, err = decorated.Create(context.Background(), cmd)
+	require.Error(t, err)
+
+	require.Contains(t, scrape(t, m), "taskery_tasks_created_total 1")
+}
+
+func TestTaskEventRepository_CountsCompletedTasks(t *testing.T) {
+	ownerID := uuid.New()
+	openID := uuid.New()
+	completedID := uuid.New()
+	missingID := uuid.New()
+
+	tests := []struct {
+		name          string
+		run           func(ctx context.Context, svc services.TaskService) error
+		wantCompleted int
+	}{
+		{
+			name: "completion",
+			run: func(ctx context.Context, svc services.TaskService) error {
+				_, err := svc.Complete(ctx, openID.String(), ownerID.String(), nil)
+				return err
+			},
+			wantCompleted: 1,
+		},
+		{
+			name: "repeated completion",
+			run: func(ctx context.Context, svc services.TaskService) error {
+				_, err := svc.Complete(ctx, completedID.String(), ownerID.String(), nil)
+				return err
+			},
+			wantCompleted: 0,
+		},
+		{
+			name: "batch",
+			run: func(ctx context.Context, svc services.TaskService) error {
+				_, err := svc.Batch(ctx, ownerID.String(), []services.BatchOperation{
+					{Type: services.BatchOperationComplete, TaskID: openID.String()},
+					{Type: services.BatchOperationComplete, TaskID: completedID.String()},
+					{Type: services.BatchOperationComplete, TaskID: missingID.String()},
+				}, false)
+				return err
+			},
+			wantCompleted: 1,
+		},
+		{
+			name: "aborted atomic batch",
+			run: func(ctx context.Context, svc services.TaskService) error {
+				_, err := svc.Batch(ctx, ownerID.String(), []services.BatchOperation{
+					{Type: services.BatchOperationComplete, TaskID: openID.String()},
+					{Type: services.BatchOperationComplete, TaskID: missingID.String()},
+				}, true)
+				if !errors.Is(err, services.ErrTaskBatchAborted) {
+					return err
+				}
+
+				return nil
+			},
+			wantCompleted: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			completedAt := time.Now().Add(-time.Hour)
+
+			open, err := models.NewTaskFromDB(models.TaskFromDBParams{
+				ID:      openID.String(),
+				OwnerID: ownerID.String(),
+				Title:   "open",
+				Version: 1,
+			})
+			require.NoError(t, err)
+
+			completed, err := models.NewTaskFromDB(models.TaskFromDBParams{
+				ID:          completedID.String(),
+				OwnerID:     ownerID.String(),
+				Title:       "completed",
+				IsCompleted: true,
+				CompletedAt: &completedAt,
+				Version:     1,
+			})
+			require.NoError(t, err)
+
+			repo := new(mocks.TaskRepository)
+			repo.On("FindByID", mock.Anything, openID.String()).Return(open, nil).Maybe()
+			repo.On("FindByID", mock.Anything, completedID.String()).Return(completed, nil).Maybe()
+			repo.On("FindByID", mock.Anything, missingID.String()).Return(nil, services.ErrTaskRepoNotFound).Maybe()
+			repo.On("Update", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			events := new(mocks.TaskEventRepository)
+			events.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			m := metrics.New()
+
+			svc, err := services.NewTaskService(
+				repo,
+				metrics.NewTaskEventRepository(events, m),
+				metrics.NewTransactor(inlineTransactor{}, m),
+				clock.Real{},
+				nil,
+			)
+			require.NoError(t, err)
+
+			require.NoError(t, tt.run(context.Background(), *svc))
+
+			require.Contains(t, scrape(t, m), fmt.Sprintf("taskery_tasks_completed_total %d", tt.wantCompleted))
+		})
+	}
+}
+
+func TestUserService_Login(t *testing.T) {
+	repo := new(mocks.UserRepository)
+	repo.On("FindByEmail", mock.Anything, "unknown@example.com").Return(nil, services.ErrUserRepoNotFound)
+
+	svc, err := services.NewUserService(repo, new(mocks.TokenProvider), clock.Real{})
+	require.NoError(t, err)
+
+	m := metrics.New()
+	decorated := metrics.NewUserService(svc, m)
+
+	_, err = decorated.Login(context.Background(), "unknown@example.com", "password")
+	require.ErrorIs(t, err, services.ErrUserNotFound)
+
+	body := scrape(t, m)
+
+	require.Contains(t, body, `taskery_users_logins_total{result="failed"} 1`)
+	require.Contains(t, body, `taskery_users_logins_total{result="succeeded"} 0`)
+}
@@ -0,0 +1,148 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+)
+
+// TaskService decorates services.TaskService with the business metrics,
+// so that the service itself stays unaware of them.
+// The completed tasks are counted by TaskEventRepository instead.
+type TaskService struct {
+	*services.TaskService
+
+	metrics *Metrics
+}
+
+// NewTaskService creates a new TaskService that records the metrics of svc in m.
+func NewTaskService(svc *services.TaskService, m *Metrics) *TaskService {
+	return &TaskService{TaskService: svc, metrics: m}
+}
+
+// Create creates a task and counts it if it has been created.
+func (ts *TaskService) Create(ctx context.Context, cmd services.CreateTaskCommand) (string, error) {
+	id, err := ts.TaskService.Create(ctx, cmd)
+	if err == nil {
+		ts.metrics.TaskCreated()
+	}
+
+	return id, err
+}
+
+// Push applies the changes pushed by a client and counts the tasks that have been created by them.
+func (ts *TaskService) Push(ctx context.Context, ownerID string, ops []services.SyncOperation) []services.SyncResult {
+	results := ts.TaskService.Push(ctx, ownerID, ops)
+
+	for i, result := range results {
+		if result.Err == nil && ops[i].Type == services.SyncOperationCreate {
+			ts.metrics.TaskCreated()
+		}
+	}
+
+	return results
+}
+
+// UserService decorates services.UserService with the business metrics,
+// so that the service itself stays unaware of them.
+type UserService struct {
+	*services.UserService
+
+	metrics *Metrics
+}
+
+// NewUserService creates a new UserService that records the metrics of svc in m.
+func NewUserService(svc *services.UserService, m *Metrics) *UserService {
+	return &UserService{UserService: svc, metrics: m}
+}
+
+// Login logs the user in and counts the attempt as succeeded or failed.
+func (us *UserService) Login(ctx context.Context, email, password string) (string, error) {
+	token, err := us.UserService.Login(ctx, email, password)
+	if err != nil {
+		us.metrics.Login(LoginFailed)
+		return "", err
+	}
+
+	us.metrics.Login(LoginSucceeded)
+
+	return token, nil
+}
+
+// pendingKey is the context key under which Transactor keeps
+// the completions recorded within the current transaction.
+type pendingKey struct{}
+
+// pending counts the tasks completed within a transaction that has not been committed yet.
+type pending struct {
+	completed int
+}
+
+// TaskEventRepository decorates services.TaskEventRepository and counts the completed tasks
+// by the recorded events, since an event of completion is recorded only when a task actually
+// changes from not completed to completed. An event recorded within a transaction of Transactor
+// is counted once the transaction has been committed.
+type TaskEventRepository struct {
+	services.TaskEventRepository
+
+	metrics *Metrics
+}
+
+// NewTaskEventRepository creates a new TaskEventRepository that records the metrics of repo in m.
+func NewTaskEventRepository(repo services.TaskEventRepository, m *Metrics) *TaskEventRepository {
+	return &TaskEventRepository{TaskEventRepository: repo, metrics: m}
+}
+
+// Create saves the event and counts the completion of the task it records.
+func (r *TaskEventRepository) Create(ctx context.Context, event *models.TaskEvent) error {
+	if err := r.TaskEventRepository.Create(ctx, event); err != nil {
+		return err
+	}
+
+	if event.Type() != models.TaskEventCompleted {
+		return nil
+	}
+
+	if p, ok := ctx.Value(pendingKey{}).(*pending); ok {
+		p.completed++
+		return nil
+	}
+
+	r.metrics.TaskCompleted()
+
+	return nil
+}
+
+// Transactor decorates services.Transactor, so that the completions recorded
+// by TaskEventRepository within a transaction are counted only if it is committed.
+type Transactor struct {
+	services.Transactor
+
+	metrics *Metrics
+}
+
+// NewTransactor creates a new Transactor that records the metrics of the transactions of t in m.
+func NewTransactor(t services.Transactor, m *Metrics) *Transactor {
+	return &Transactor{Transactor: t, metrics: m}
+}
+
+// WithinTx calls fn within a transaction and counts the completions recorded by it
+// once the transaction has been committed. A nested call joins the counting of the outer one.
+func (t *Transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(pendingKey{}).(*pending); ok {
+		return t.Transactor.WithinTx(ctx, fn)
+	}
+
+	p := &pending{}
+
+	if err := t.Transactor.WithinTx(context.WithValue(ctx, pendingKey{}, p), fn); err != nil {
+		return err
+	}
+
+	for range p.completed {
+		t.metrics.TaskCompleted()
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+// Package pubsub delivers the changes of tasks to the subscribers within the process.
+// With several instances of the application, the changes are fanned out to all of them
+// through Postgres, see postgres.TaskChangeNotifier and postgres.TaskChangeListener.
+package pubsub
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+)
+
+// Broker is an in-process services.TaskChangePublisher that delivers the changes of tasks
+// to the subscribers of their owners.
+//
+// The last changes of all users are kept in a bounded replay buffer, so that a subscriber
+// that has reconnected gets the changes it has missed. A subscriber that does not keep up
+// with the changes is closed, so that it reconnects and catches up from the buffer
+// instead of slowing the publishers down.
+type Broker struct {
+	mu sync.Mutex
+
+	// recent is a ring of the last changes, the oldest at head once it is full.
+	recent []services.TaskChange
+	head   int
+
+	subscribers map[string]map[*Subscription]struct{}
+	bufferSize  int
+	closed      bool
+}
+
+var _ services.TaskChangePublisher = (*Broker)(nil)
+
+// NewBroker creates a new Broker that replays up to replaySize last changes
+// and buffers up to bufferSize changes for each subscriber.
+func NewBroker(replaySize, bufferSize int) *Broker {
+	return &Broker{
+		recent:      make([]services.TaskChange, 0, max(replaySize, 0)),
+		subscribers: make(map[string]map[*Subscription]struct{}),
+		bufferSize:  max(bufferSize, 1),
+	}
+}
+
+// Subscription receives the changes of the tasks of a single user.
+type Subscription struct {
+	broker  *Broker
+	ownerID string
+
+	ch     chan services.TaskChange
+	replay []services.TaskChange
+	missed bool
+	once   sync.Once
+}
+
+// Changes returns the channel of the changes published after the subscription.
+// The channel is closed when the subscription is closed, either by Close,
+// by the broker when the subscriber falls behind, or when the broker is closed.
+func (s *Subscription) Changes() <-chan services.TaskChange {
+	return s.ch
+}
+
+// Replay returns the changes published after the last change seen by the subscriber,
+// from the oldest to the newest.
+func (s *Subscription) Replay() []services.TaskChange {
+	return s.replay
+}
+
+// Missed reports whether the last change seen by the subscriber is no longer in the replay buffer,
+// in which case some changes are lost and the subscriber has to reload the tasks.
+func (s *Subscription) Missed() bool {
+	return s.missed
+}
+
+// Close stops the delivery of the changes to the subscription.
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+
+	s.broker.unsubscribe(s)
+}
+
+// Subscribe subscribes to the changes of the tasks of the owner.
+//
+// lastEventID is the ID of the event of the last change seen by the subscriber, if any.
+// The changes of the owner published after it are returned by Replay of the subscription.
+func (b *Broker) Subscribe(ownerID, lastEventID string) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub := &Subscription{
+		broker:  b,
+		ownerID: ownerID,
+		ch:      make(chan services.TaskChange, b.bufferSize),
+	}
+
+	if lastEventID != "" {
+		sub.replay, sub.missed = b.replay(ownerID, lastEventID)
+	}
+
+	if b.closed {
+		b.unsubscribe(sub)
+		return sub
+	}
+
+	if b.subscribers[ownerID] == nil {
+		b.subscribers[ownerID] = make(map[*Subscription]struct{})
+	}
+	b.subscribers[ownerID][sub] = struct{}{}
+
+	return sub
+}
+
+// Publish saves the change in the replay buffer and delivers it to the subscribers of its owner.
+func (b *Broker) Publish(_ context.Context, change services.TaskChange) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if cap(b.recent) > 0 {
+		if len(b.recent) < cap(b.recent) {
+			b.recent = append(b.recent, change)
+		} else {
+			b.recent[b.head] = change
+			b.head = (b.head + 1) % len(b.recent)
+		}
+	}
+
+	for sub := range b.subscribers[change.OwnerID] {
+		select {
+		case sub.ch <- change:
+		default:
+			b.unsubscribe(sub)
+		}
+	}
+
+	return nil
+}
+
+// Close closes all the subscriptions, e.g. on shutdown so that the streams end.
+// The subscriptions made after Close are closed at once.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+
+	for _, subs := range b.subscribers {
+		for sub := range subs {
+			b.unsubscribe(sub)
+		}
+	}
+}
+
+// replay returns the changes of the owner that follow the change with the event lastEventID
+// and reports whether that change is no longer in the buffer.
+func (b *Broker) replay(ownerID, lastEventID string) ([]services.TaskChange, bool) {
+	found := false
+	var changes []services.TaskChange
+
+	for i := range b.recent {
+		change := b.recent[(b.head+i)%len(b.recent)]
+
+		if !found {
+			found = change.Event.ID().String() == lastEventID
+			continue
+		}
+
+		if change.OwnerID == ownerID {
+			changes = append(changes, change)
+		}
+	}
+
+	if !found {
+		return nil, true
+	}
+
+	return changes, false
+}
+
+// unsubscribe removes the subscription and closes its channel. b.mu must be held.
+func (b *Broker) unsubscribe(sub *Subscription) {
+	sub.once.Do(func() {
+		delete(b.subscribers[sub.ownerID], sub)
+		if len(b.subscribers[sub.ownerID]) == 0 {
+			delete(b.subscribers, sub.ownerID)
+		}
+
+		close(sub.ch)
+	})
+}
@@ -0,0 +1,157 @@
+package pubsub_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/pubsub"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func newChange(t *testing.T, ownerID string, version int64) services.TaskChange {
+	t.Helper()
+
+	event, err := models.NewTaskEventFromDB(models.TaskEventFromDBParams{
+		ID:         uuid.NewString(),
+		TaskID:     uuid.NewString(),
+		ActorID:    ownerID,
+		Type:       string(models.TaskEventUpdated),
+		OccurredAt: time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	return services.TaskChange{OwnerID: ownerID, Version: version, Event: event}
+}
+
+func receive(t *testing.T, sub *pubsub.Subscription) services.TaskChange {
+	t.Helper()
+
+	select {
+	case change, ok := <-sub.Changes():
+		require.True(t, ok, "subscription is closed")
+		return change
+	default:
+		require.Fail(t, "no change is delivered")
+		return services.TaskChange{}
+	}
+}
+
+func requireClosed(t *testing.T, sub *pubsub.Subscription) {
+	t.Helper()
+
+	select {
+	case _, ok := <-sub.Changes():
+		require.False(t, ok, "subscription is open")
+	default:
+		require.Fail(t, "subscription is open")
+	}
+}
+
+func TestBroker(t *testing.T) {
+	ctx := context.Background()
+
+	alice := uuid.NewString()
+	bob := uuid.NewString()
+
+	t.Run("delivers changes to subscribers of the owner", func(t *testing.T) {
+		broker := pubsub.NewBroker(10, 10)
+
+		aliceSub := broker.Subscribe(alice, "")
+		defer aliceSub.Close()
+		bobSub := broker.Subscribe(bob, "")
+		defer bobSub.Close()
+
+		change := newChange(t, alice, 1)
+		require.NoError(t, broker.Publish(ctx, change))
+
+		require.Equal(t, change, receive(t, aliceSub))
+		require.Empty(t, bobSub.Changes())
+		require.False(t, aliceSub.Missed())
+		require.Empty(t, aliceSub.Replay())
+	})
+	t.Run("replays changes after the last event", func(t *testing.T) {
+		broker := pubsub.NewBroker(10, 10)
+
+		first := newChange(t, alice, 1)
+		other := newChange(t, bob, 1)
+		second := newChange(t, alice, 2)
+		third := newChange(t, alice, 3)
+
+		for _, change := range []services.TaskChange{first, other, second, third} {
+			require.NoError(t, broker.Publish(ctx, change))
+		}
+
+		sub := broker.Subscribe(alice, first.Event.ID().String())
+		defer sub.Close()
+
+		require.False(t, sub.Missed())
+		require.Equal(t, []services.TaskChange{second, third}, sub.Replay())
+
+		sub = broker.Subscribe(alice, third.Event.ID().String())
+		defer sub.Close()
+
+		require.False(t, sub.Missed())
+		require.Empty(t, sub.Replay())
+	})
+	t.Run("reports missed changes evicted from the buffer", func(t *testing.T) {
+		broker := pubsub.NewBroker(2, 10)
+
+		first := newChange(t, alice, 1)
+		second := newChange(t, alice, 2)
+		third := newChange(t, alice, 3)
+
+		for _, change := range []services.TaskChange{first, second, third} {
+			require.NoError(t, broker.Publish(ctx, change))
+		}
+
+		sub := broker.Subscribe(alice, first.Event.ID().String())
+		defer sub.Close()
+
+		require.True(t, sub.Missed())
+		require.Empty(t, sub.Replay())
+
+		sub = broker.Subscribe(alice, second.Event.ID().String())
+		defer sub.Close()
+
+		require.False(t, sub.Missed())
+		require.Equal(t, []services.TaskChange{third}, sub.Replay())
+	})
+	t.Run("closes slow subscribers", func(t *testing.T) {
+		broker := pubsub.NewBroker(10, 1)
+
+		sub := broker.Subscribe(alice, "")
+		defer sub.Close()
+
+		first := newChange(t, alice, 1)
+		require.NoError(t, broker.Publish(ctx, first))
+		require.NoError(t, broker.Publish(ctx, newChange(t, alice, 2)))
+
+		require.Equal(t, first, receive(t, sub))
+		requireClosed(t, sub)
+	})
+	t.Run("close stops delivery", func(t *testing.T) {
+		broker := pubsub.NewBroker(10, 10)
+
+		sub := broker.Subscribe(alice, "")
+		sub.Close()
+		sub.Close()
+
+		require.NoError(t, broker.Publish(ctx, newChange(t, alice, 1)))
+		requireClosed(t, sub)
+	})
+	t.Run("closing the broker closes subscriptions", func(t *testing.T) {
+		broker := pubsub.NewBroker(10, 10)
+
+		sub := broker.Subscribe(alice, "")
+		broker.Close()
+		requireClosed(t, sub)
+		sub.Close()
+
+		sub = broker.Subscribe(alice, "")
+		requireClosed(t, sub)
+	})
+}
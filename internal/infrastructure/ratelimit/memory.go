@@ -0,0 +1,55 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore is a Store that keeps the buckets in memory.
+// The buckets are lost on restart and are not shared between instances of the application,
+// so every instance enforces the limits on its own.
+// It is safe for concurrent use.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]Bucket
+}
+
+// NewMemoryStore creates a new empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]Bucket)}
+}
+
+// Take takes a token from the bucket of the key at now according to the limit.
+func (s *MemoryStore) Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var bucket *Bucket
+	if b, ok := s.buckets[key]; ok {
+		bucket = &b
+	}
+
+	next, result := limit.Take(bucket, now)
+	s.buckets[key] = next
+
+	return result, nil
+}
+
+// DeleteExpired removes the buckets that are refilled completely by now and returns their number.
+func (s *MemoryStore) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deleted int64
+	for key, bucket := range s.buckets {
+		if !bucket.FullAt.After(now) {
+			delete(s.buckets, key)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
+
+var _ Store = (*MemoryStore)(nil)
@@ -0,0 +1,70 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/ratelimit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimit_Take(t *testing.T) {
+	limit := ratelimit.Limit{Requests: 2, Period: 2 * time.Second}
+	now := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+
+	bucket, result := limit.Take(nil, now)
+	require.Equal(t, ratelimit.Result{Allowed: true, Limit: 2, Remaining: 1, ResetAfter: time.Second}, result)
+	require.Equal(t, now.Add(time.Second), bucket.FullAt)
+
+	bucket, result = limit.Take(&bucket, now)
+	require.Equal(t, ratelimit.Result{Allowed: true, Limit: 2, Remaining: 0, ResetAfter: 2 * time.Second}, result)
+
+	bucket, result = limit.Take(&bucket, now.Add(500*time.Millisecond))
+	require.Equal(t, ratelimit.Result{
+		Allowed:    false,
+		Limit:      2,
+		Remaining:  0,
+		ResetAfter: 1500 * time.Millisecond,
+		RetryAfter: 500 * time.Millisecond,
+	}, result)
+
+	// a token is refilled every second and the bucket never exceeds its capacity
+	_, result = limit.Take(&bucket, now.Add(time.Hour))
+	require.Equal(t, ratelimit.Result{Allowed: true, Limit: 2, Remaining: 1, ResetAfter: time.Second}, result)
+}
+
+func TestLimit_Unlimited(t *testing.T) {
+	require.True(t, ratelimit.Limit{}.Unlimited())
+	require.True(t, ratelimit.Limit{Requests: 10}.Unlimited())
+	require.False(t, ratelimit.Limit{Requests: 10, Period: time.Minute}.Unlimited())
+}
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	limit := ratelimit.Limit{Requests: 1, Period: time.Minute}
+	now := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+
+	store := ratelimit.NewMemoryStore()
+
+	result, err := store.Take(ctx, "auth:ip:10.0.0.1", limit, now)
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+
+	result, err = store.Take(ctx, "auth:ip:10.0.0.1", limit, now)
+	require.NoError(t, err)
+	require.False(t, result.Allowed)
+	require.Equal(t, time.Minute, result.RetryAfter)
+
+	result, err = store.Take(ctx, "auth:ip:10.0.0.2", limit, now)
+	require.NoError(t, err)
+	require.True(t, result.Allowed, "buckets are separate per key")
+
+	deleted, err := store.DeleteExpired(ctx, now.Add(30*time.Second))
+	require.NoError(t, err)
+	require.Zero(t, deleted)
+
+	deleted, err = store.DeleteExpired(ctx, now.Add(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, int64(2), deleted)
+}
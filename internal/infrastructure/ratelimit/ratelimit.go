@@ -0,0 +1,107 @@
+// Package ratelimit limits the rate of requests with token buckets
+// that are kept in a store shared by the instances of the application.
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Limit allows Requests requests per Period with bursts of up to Requests requests.
+// A Limit with no requests does not limit anything.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// Unlimited reports whether l does not limit anything.
+func (l Limit) Unlimited() bool {
+	return l.Requests <= 0 || l.Period <= 0
+}
+
+// rate returns the number of tokens that are added to the bucket per second.
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// Bucket is the state of a token bucket.
+type Bucket struct {
+	// Tokens is the number of tokens left in the bucket at UpdatedAt.
+	Tokens    float64
+	UpdatedAt time.Time
+
+	// FullAt is the time when the bucket is refilled completely,
+	// after which it is the same as a new one and can be removed.
+	FullAt time.Time
+}
+
+// Result is the outcome of taking a token from a bucket.
+type Result struct {
+	// Allowed reports whether a token has been taken, i.e. the request is allowed.
+	Allowed bool
+
+	// Limit is the capacity of the bucket and Remaining is the number of whole tokens left in it.
+	Limit     int
+	Remaining int
+
+	// ResetAfter is the time until the bucket is refilled completely.
+	ResetAfter time.Duration
+
+	// RetryAfter is the time until the next token is available, if the request is not allowed.
+	RetryAfter time.Duration
+}
+
+// Take takes a token from the bucket at now and returns the new state of the bucket.
+// A nil bucket is a new one, which is full.
+func (l Limit) Take(bucket *Bucket, now time.Time) (Bucket, Result) {
+	capacity := float64(l.Requests)
+	rate := l.rate()
+
+	tokens := capacity
+	if bucket != nil {
+		elapsed := max(now.Sub(bucket.UpdatedAt).Seconds(), 0)
+		tokens = min(capacity, bucket.Tokens+elapsed*rate)
+	}
+
+	allowed := tokens >= 1
+	if allowed {
+		tokens--
+	}
+
+	result := l.Result(tokens, allowed)
+
+	return Bucket{Tokens: tokens, UpdatedAt: now, FullAt: now.Add(result.ResetAfter)}, result
+}
+
+// Result returns the outcome of a take that left the given number of tokens in the bucket.
+func (l Limit) Result(tokens float64, allowed bool) Result {
+	capacity := float64(l.Requests)
+	rate := l.rate()
+
+	result := Result{
+		Allowed:    allowed,
+		Limit:      l.Requests,
+		Remaining:  int(tokens),
+		ResetAfter: seconds((capacity - tokens) / rate),
+	}
+
+	if !allowed {
+		result.RetryAfter = seconds((1 - tokens) / rate)
+	}
+
+	return result
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// Store keeps the token buckets by key.
+type Store interface {
+	// Take takes a token from the bucket of the key at now according to the limit.
+	// A key that has no bucket gets a full one.
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error)
+
+	// DeleteExpired removes the buckets that are refilled completely by now and returns their number.
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,240 @@
+// Package memory provides an in-memory full-text search over tasks.
+// It follows the same query syntax and result order as the PostgreSQL search,
+// which makes it suitable for tests and small deployments without a database.
+package memory
+
+import (
+	"context"
+	"html"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/vo"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+)
+
+// Weights of the matches in the title and the description,
+// the same as the default weights of the A and B classes in PostgreSQL.
+const (
+	titleWeight       = 1.0
+	descriptionWeight = 0.4
+)
+
+// TaskSearcher is an in-memory full-text search index of tasks.
+// It is safe for concurrent use.
+type TaskSearcher struct {
+	mu    sync.RWMutex
+	tasks map[string]*models.Task
+}
+
+// NewTaskSearcher creates a new TaskSearcher with the given tasks indexed.
+func NewTaskSearcher(tasks ...*models.Task) *TaskSearcher {
+	s := &TaskSearcher{tasks: make(map[string]*models.Task, len(tasks))}
+
+	for _, task := range tasks {
+		s.Index(task)
+	}
+
+	return s
+}
+
+// Index adds the task to the index, replacing the task with the same ID if it is already there.
+func (s *TaskSearcher) Index(task *models.Task) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tasks[task.ID().String()] = task
+}
+
+// Remove removes the task with the given id from the index.
+// Removing a task that is not indexed does nothing.
+func (s *TaskSearcher) Remove(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.tasks, id)
+}
+
+// Search returns the indexed active tasks of the owner that match the query,
+// ordered by their rank and then from the most recently updated.
+// If no tasks match the query, it returns an empty slice.
+//
+// It returns the same validation errors as services.TaskService.Search.
+func (s *TaskSearcher) Search(
+	ctx context.Context,
+	ownerID string,
+	query services.SearchTasksQuery,
+) ([]services.TaskSearchResult, error) {
+	text, limit, err := query.Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	results := make([]services.TaskSearchResult, 0)
+
+	for _, task := range s.tasks {
+		if task.OwnerID().String() != ownerID || task.IsDeleted() {
+			continue
+		}
+
+		if task.IsArchived() && !query.IncludeArchived {
+			continue
+		}
+
+		title := newDocument(task.Title().String())
+		description := newDocument(task.Description().String())
+
+		rank, matched := 0.0, true
+		for _, term := range text.Terms() {
+			n := title.match(term)*titleWeight + description.match(term)*descriptionWeight
+			if n == 0 {
+				matched = false
+				break
+			}
+
+			rank += n
+		}
+
+		if !matched {
+			continue
+		}
+
+		results = append(results, services.TaskSearchResult{
+			Task:               task,
+			Rank:               rank,
+			TitleSnippet:       title.highlight(),
+			DescriptionSnippet: description.highlight(),
+		})
+	}
+
+	slices.SortFunc(results, func(a, b services.TaskSearchResult) int {
+		if a.Rank != b.Rank {
+			if a.Rank > b.Rank {
+				return -1
+			}
+
+			return 1
+		}
+
+		if c := b.Task.UpdatedAt().Compare(a.Task.UpdatedAt()); c != 0 {
+			return c
+		}
+
+		return strings.Compare(b.Task.ID().String(), a.Task.ID().String())
+	})
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results, nil
+}
+
+// token is a word of a document together with its position in the text.
+type token struct {
+	word       string
+	start, end int
+}
+
+// document is a text split into the words the same way as vo.SearchWords does.
+type document struct {
+	text        string
+	tokens      []token
+	highlighted []bool
+}
+
+func newDocument(text string) *document {
+	var tokens []token
+
+	start := -1
+	for i, r := range text {
+		isWordRune := unicode.IsLetter(r) || unicode.IsDigit(r)
+
+		switch {
+		case isWordRune && start < 0:
+			start = i
+		case !isWordRune && start >= 0:
+			tokens = append(tokens, token{word: strings.ToLower(text[start:i]), start: start, end: i})
+			start = -1
+		}
+	}
+
+	if start >= 0 {
+		tokens = append(tokens, token{word: strings.ToLower(text[start:]), start: start, end: len(text)})
+	}
+
+	return &document{
+		text:        text,
+		tokens:      tokens,
+		highlighted: make([]bool, len(tokens)),
+	}
+}
+
+// match returns the number of occurrences of the term in the document
+// and marks the matched words to be highlighted.
+func (d *document) match(term vo.SearchTerm) float64 {
+	words := term.Words()
+	count := 0
+
+	for i := 0; i+len(words) <= len(d.tokens); i++ {
+		matched := true
+
+		for j, word := range words {
+			got := d.tokens[i+j].word
+
+			if j == len(words)-1 && term.IsPrefix() {
+				matched = strings.HasPrefix(got, word)
+			} else {
+				matched = got == word
+			}
+
+			if !matched {
+				break
+			}
+		}
+
+		if !matched {
+			continue
+		}
+
+		count++
+		for j := range words {
+			d.highlighted[i+j] = true
+		}
+	}
+
+	return float64(count)
+}
+
+// highlight returns the HTML-escaped text with the matched words enclosed in <b> and </b>.
+func (d *document) highlight() string {
+	var sb strings.Builder
+
+	last := 0
+	for i, tok := range d.tokens {
+		if !d.highlighted[i] {
+			continue
+		}
+
+		sb.WriteString(html.EscapeString(d.text[last:tok.start]))
+		sb.WriteString("<b>")
+		sb.WriteString(html.EscapeString(d.text[tok.start:tok.end]))
+		sb.WriteString("</b>")
+
+		last = tok.end
+	}
+
+	sb.WriteString(html.EscapeString(d.text[last:]))
+
+	return sb.String()
+}
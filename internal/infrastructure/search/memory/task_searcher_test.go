@@ -0,0 +1,162 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/vo"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/search/memory"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskSearcher_Search(t *testing.T) {
+	ownerID := uuid.New()
+	clk := clock.NewFake(time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC))
+
+	newTask := func(title, description string) *models.Task {
+		task, err := models.NewTask(title, description, ownerID, clk)
+		require.NoError(t, err)
+
+		clk.Advance(time.Minute)
+
+		return task
+	}
+
+	milk := newTask("Buy milk", "Two bottles of fresh milk")
+	report := newTask("Write report", "Quarterly report about milk sales")
+	reportage := newTask("Reportage", "")
+	archived := newTask("Archived milk", "")
+	require.NoError(t, archived.Archive(true, clk))
+	trashed := newTask("Trashed milk", "")
+	trashed.MoveToTrash(clk)
+	markup := newTask("<b>Bread</b> & butter", `Say "bread" to the baker`)
+	foreign, err := models.NewTask("Foreign milk", "", uuid.New(), clk)
+	require.NoError(t, err)
+
+	searcher := memory.NewTaskSearcher(milk, report, reportage, archived, trashed, markup, foreign)
+
+	tests := []struct {
+		name                   string
+		query                  services.SearchTasksQuery
+		wantErr                error
+		wantIDs                []uuid.UUID
+		wantTitleSnippet       string
+		wantDescriptionSnippet string
+	}{
+		{
+			name:                   "title matches rank higher",
+			query:                  services.SearchTasksQuery{Text: "MILK"},
+			wantIDs:                []uuid.UUID{milk.ID(), report.ID()},
+			wantTitleSnippet:       "Buy <b>milk</b>",
+			wantDescriptionSnippet: "Two bottles of fresh <b>milk</b>",
+		},
+		{
+			name:    "archived tasks are included on request",
+			query:   services.SearchTasksQuery{Text: "milk", IncludeArchived: true},
+			wantIDs: []uuid.UUID{milk.ID(), archived.ID(), report.ID()},
+		},
+		{
+			name:                   "phrase",
+			query:                  services.SearchTasksQuery{Text: `"fresh milk"`},
+			wantIDs:                []uuid.UUID{milk.ID()},
+			wantTitleSnippet:       "Buy milk",
+			wantDescriptionSnippet: "Two bottles of <b>fresh</b> <b>milk</b>",
+		},
+		{
+			name:    "phrase words must be adjacent",
+			query:   services.SearchTasksQuery{Text: `"bottles milk"`},
+			wantIDs: []uuid.UUID{},
+		},
+		{
+			name:             "prefix",
+			query:            services.SearchTasksQuery{Text: "rep*"},
+			wantIDs:          []uuid.UUID{report.ID(), reportage.ID()},
+			wantTitleSnippet: "Write <b>report</b>",
+		},
+		{
+			name:                   "snippets are escaped",
+			query:                  services.SearchTasksQuery{Text: "bread"},
+			wantIDs:                []uuid.UUID{markup.ID()},
+			wantTitleSnippet:       "&lt;b&gt;<b>Bread</b>&lt;/b&gt; &amp; butter",
+			wantDescriptionSnippet: "Say &#34;<b>bread</b>&#34; to the baker",
+		},
+		{
+			name:    "all terms must match",
+			query:   services.SearchTasksQuery{Text: "report sales"},
+			wantIDs: []uuid.UUID{report.ID()},
+		},
+		{
+			name:    "limit",
+			query:   services.SearchTasksQuery{Text: "milk", Limit: 1},
+			wantIDs: []uuid.UUID{milk.ID()},
+		},
+		{
+			name:    "invalid query",
+			query:   services.SearchTasksQuery{Text: `""`},
+			wantErr: vo.ErrSearchQueryEmpty,
+		},
+		{
+			name:    "invalid limit",
+			query:   services.SearchTasksQuery{Text: "milk", Limit: services.MaxTaskSearchLimit + 1},
+			wantErr: services.ErrTaskSearchLimitInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := searcher.Search(context.Background(), ownerID.String(), tt.query)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, results)
+				return
+			}
+
+			require.NoError(t, err)
+
+			ids := make([]uuid.UUID, len(results))
+			for i, result := range results {
+				ids[i] = result.Task.ID()
+			}
+			require.Equal(t, tt.wantIDs, ids)
+
+			if tt.wantTitleSnippet != "" {
+				require.Equal(t, tt.wantTitleSnippet, results[0].TitleSnippet)
+			}
+
+			if tt.wantDescriptionSnippet != "" {
+				require.Equal(t, tt.wantDescriptionSnippet, results[0].DescriptionSnippet)
+			}
+		})
+	}
+}
+
+func TestTaskSearcher_IndexAndRemove(t *testing.T) {
+	ownerID := uuid.New()
+
+	task, err := models.NewTask("Buy milk", "", ownerID, clock.Real{})
+	require.NoError(t, err)
+
+	searcher := memory.NewTaskSearcher()
+	query := services.SearchTasksQuery{Text: "milk"}
+
+	results, err := searcher.Search(context.Background(), ownerID.String(), query)
+	require.NoError(t, err)
+	require.Empty(t, results)
+
+	searcher.Index(task)
+
+	results, err = searcher.Search(context.Background(), ownerID.String(), query)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	searcher.Remove(task.ID().String())
+
+	results, err = searcher.Search(context.Background(), ownerID.String(), query)
+	require.NoError(t, err)
+	require.Empty(t, results)
+}
@@ -0,0 +1,346 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/vo"
+	userModels "github.com/cyberbrain-dev/taskery-api/internal/domain/user/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/idempotency"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/ratelimit"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// repository traces the queries of a Postgres repository.
+type repository struct {
+	name   string
+	tracer trace.Tracer
+}
+
+func newRepository(name string, tp trace.TracerProvider) repository {
+	return repository{name: name, tracer: tp.Tracer(InstrumentationName)}
+}
+
+// spanOptions returns the options of the client span of the query made by the given method.
+func (r repository) spanOptions(method string) []trace.SpanStartOption {
+	return []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemNamePostgreSQL, semconv.DBOperationName(r.name+"."+method)),
+	}
+}
+
+func repoRun(ctx context.Context, r repository, method string, fn func(ctx context.Context) error) error {
+	return run(ctx, r.tracer, r.name+"."+method, fn, r.spanOptions(method)...)
+}
+
+func repoCall[T any](
+	ctx context.Context,
+	r repository,
+	method string,
+	fn func(ctx context.Context) (T, error),
+) (T, error) {
+	return call(ctx, r.tracer, r.name+"."+method, fn, r.spanOptions(method)...)
+}
+
+type taskRepository struct {
+	next services.TaskRepository
+	repository
+}
+
+// NewTaskRepository returns a services.TaskRepository that makes every query of next
+// within a client span, e.g. "TaskRepository.FindByID".
+func NewTaskRepository(next services.TaskRepository, tp trace.TracerProvider) services.TaskRepository {
+	return &taskRepository{next: next, repository: newRepository("TaskRepository", tp)}
+}
+
+func (tr *taskRepository) Create(ctx context.Context, task *models.Task) error {
+	return repoRun(ctx, tr.repository, "Create", func(ctx context.Context) error {
+		return tr.next.Create(ctx, task)
+	})
+}
+
+func (tr *taskRepository) FindByID(ctx context.Context, id string) (*models.Task, error) {
+	return repoCall(ctx, tr.repository, "FindByID", func(ctx context.Context) (*models.Task, error) {
+		return tr.next.FindByID(ctx, id)
+	})
+}
+
+func (tr *taskRepository) FindByIDs(ctx context.Context, ids []string) ([]*models.Task, error) {
+	return repoCall(ctx, tr.repository, "FindByIDs", func(ctx context.Context) ([]*models.Task, error) {
+		return tr.next.FindByIDs(ctx, ids)
+	})
+}
+
+func (tr *taskRepository) FindByOwner(
+	ctx context.Context,
+	ownerID string,
+	query services.FindByOwnerQuery,
+) ([]*models.Task, error) {
+	return repoCall(ctx, tr.repository, "FindByOwner", func(ctx context.Context) ([]*models.Task, error) {
+		return tr.next.FindByOwner(ctx, ownerID, query)
+	})
+}
+
+func (tr *taskRepository) FindWithDeadlines(ctx context.Context, ownerID string) ([]*models.Task, error) {
+	return repoCall(ctx, tr.repository, "FindWithDeadlines", func(ctx context.Context) ([]*models.Task, error) {
+		return tr.next.FindWithDeadlines(ctx, ownerID)
+	})
+}
+
+func (tr *taskRepository) Update(ctx context.Context, task *models.Task) error {
+	return repoRun(ctx, tr.repository, "Update", func(ctx context.Context) error {
+		return tr.next.Update(ctx, task)
+	})
+}
+
+func (tr *taskRepository) Delete(ctx context.Context, id string, version int64) error {
+	return repoRun(ctx, tr.repository, "Delete", func(ctx context.Context) error {
+		return tr.next.Delete(ctx, id, version)
+	})
+}
+
+func (tr *taskRepository) DeleteTrashedBefore(ctx context.Context, before time.Time) ([]*models.Task, error) {
+	return repoCall(ctx, tr.repository, "DeleteTrashedBefore", func(ctx context.Context) ([]*models.Task, error) {
+		return tr.next.DeleteTrashedBefore(ctx, before)
+	})
+}
+
+func (tr *taskRepository) ArchiveCompletedBefore(
+	ctx context.Context,
+	ownerID string,
+	completedBefore time.Time,
+	archivedAt time.Time,
+) ([]*models.Task, error) {
+	return repoCall(ctx, tr.repository, "ArchiveCompletedBefore", func(ctx context.Context) ([]*models.Task, error) {
+		return tr.next.ArchiveCompletedBefore(ctx, ownerID, completedBefore, archivedAt)
+	})
+}
+
+func (tr *taskRepository) Search(
+	ctx context.Context,
+	ownerID string,
+	query vo.SearchQuery,
+	limit int,
+	includeArchived bool,
+) ([]services.TaskSearchResult, error) {
+	return repoCall(ctx, tr.repository, "Search", func(ctx context.Context) ([]services.TaskSearchResult, error) {
+		return tr.next.Search(ctx, ownerID, query, limit, includeArchived)
+	})
+}
+
+func (tr *taskRepository) FindChangedSince(
+	ctx context.Context,
+	ownerID string,
+	since int64,
+	limit int,
+) ([]services.TaskSyncChange, error) {
+	return repoCall(ctx, tr.repository, "FindChangedSince", func(ctx context.Context) ([]services.TaskSyncChange, error) {
+		return tr.next.FindChangedSince(ctx, ownerID, since, limit)
+	})
+}
+
+type taskEventRepository struct {
+	next services.TaskEventRepository
+	repository
+}
+
+// NewTaskEventRepository returns a services.TaskEventRepository that makes every query of next
+// within a client span, e.g. "TaskEventRepository.FindByTask".
+func NewTaskEventRepository(
+	next services.TaskEventRepository,
+	tp trace.TracerProvider,
+) services.TaskEventRepository {
+	return &taskEventRepository{next: next, repository: newRepository("TaskEventRepository", tp)}
+}
+
+func (er *taskEventRepository) Create(ctx context.Context, event *models.TaskEvent) error {
+	return repoRun(ctx, er.repository, "Create", func(ctx context.Context) error {
+		return er.next.Create(ctx, event)
+	})
+}
+
+func (er *taskEventRepository) FindByID(ctx context.Context, id string) (*models.TaskEvent, error) {
+	return repoCall(ctx, er.repository, "FindByID", func(ctx context.Context) (*models.TaskEvent, error) {
+		return er.next.FindByID(ctx, id)
+	})
+}
+
+func (er *taskEventRepository) FindByTask(ctx context.Context, taskID string) ([]*models.TaskEvent, error) {
+	return repoCall(ctx, er.repository, "FindByTask", func(ctx context.Context) ([]*models.TaskEvent, error) {
+		return er.next.FindByTask(ctx, taskID)
+	})
+}
+
+func (er *taskEventRepository) FindByTasks(ctx context.Context, taskIDs []string) ([]*models.TaskEvent, error) {
+	return repoCall(ctx, er.repository, "FindByTasks", func(ctx context.Context) ([]*models.TaskEvent, error) {
+		return er.next.FindByTasks(ctx, taskIDs)
+	})
+}
+
+type userRepository struct {
+	next services.UserRepository
+	repository
+}
+
+// NewUserRepository returns a services.UserRepository that makes every query of next
+// within a client span, e.g. "UserRepository.FindByEmail".
+func NewUserRepository(next services.UserRepository, tp trace.TracerProvider) services.UserRepository {
+	return &userRepository{next: next, repository: newRepository("UserRepository", tp)}
+}
+
+func (ur *userRepository) Create(ctx context.Context, u *userModels.User) error {
+	return repoRun(ctx, ur.repository, "Create", func(ctx context.Context) error {
+		return ur.next.Create(ctx, u)
+	})
+}
+
+func (ur *userRepository) FindByID(ctx context.Context, id string) (*userModels.User, error) {
+	return repoCall(ctx, ur.repository, "FindByID", func(ctx context.Context) (*userModels.User, error) {
+		return ur.next.FindByID(ctx, id)
+	})
+}
+
+func (ur *userRepository) FindByIDs(ctx context.Context, ids []string) ([]*userModels.User, error) {
+	return repoCall(ctx, ur.repository, "FindByIDs", func(ctx context.Context) ([]*userModels.User, error) {
+		return ur.next.FindByIDs(ctx, ids)
+	})
+}
+
+func (ur *userRepository) FindByEmail(ctx context.Context, email string) (*userModels.User, error) {
+	return repoCall(ctx, ur.repository, "FindByEmail", func(ctx context.Context) (*userModels.User, error) {
+		return ur.next.FindByEmail(ctx, email)
+	})
+}
+
+func (ur *userRepository) Update(ctx context.Context, u *userModels.User) error {
+	return repoRun(ctx, ur.repository, "Update", func(ctx context.Context) error {
+		return ur.next.Update(ctx, u)
+	})
+}
+
+func (ur *userRepository) Delete(ctx context.Context, id string) error {
+	return repoRun(ctx, ur.repository, "Delete", func(ctx context.Context) error {
+		return ur.next.Delete(ctx, id)
+	})
+}
+
+type calendarFeedRepository struct {
+	next services.CalendarFeedRepository
+	repository
+}
+
+// NewCalendarFeedRepository returns a services.CalendarFeedRepository that makes every query of next
+// within a client span, e.g. "CalendarFeedRepository.FindUserID".
+func NewCalendarFeedRepository(next services.CalendarFeedRepository, tp trace.TracerProvider) services.CalendarFeedRepository {
+	return &calendarFeedRepository{next: next, repository: newRepository("CalendarFeedRepository", tp)}
+}
+
+func (fr *calendarFeedRepository) Save(ctx context.Context, userID string, tokenHash string, createdAt time.Time) error {
+	return repoRun(ctx, fr.repository, "Save", func(ctx context.Context) error {
+		return fr.next.Save(ctx, userID, tokenHash, createdAt)
+	})
+}
+
+func (fr *calendarFeedRepository) FindUserID(ctx context.Context, tokenHash string) (string, error) {
+	return repoCall(ctx, fr.repository, "FindUserID", func(ctx context.Context) (string, error) {
+		return fr.next.FindUserID(ctx, tokenHash)
+	})
+}
+
+func (fr *calendarFeedRepository) Delete(ctx context.Context, userID string) error {
+	return repoRun(ctx, fr.repository, "Delete", func(ctx context.Context) error {
+		return fr.next.Delete(ctx, userID)
+	})
+}
+
+type transactor struct {
+	next services.Transactor
+	repository
+}
+
+// NewTransactor returns a services.Transactor that runs every transaction of next
+// within a client span named "Transactor.WithinTx", so that the queries made
+// in the transaction are grouped under it.
+func NewTransactor(next services.Transactor, tp trace.TracerProvider) services.Transactor {
+	return &transactor{next: next, repository: newRepository("Transactor", tp)}
+}
+
+func (t *transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return repoRun(ctx, t.repository, "WithinTx", func(ctx context.Context) error {
+		return t.next.WithinTx(ctx, fn)
+	})
+}
+
+type idempotencyStore struct {
+	next   idempotency.Store
+	tracer trace.Tracer
+}
+
+// NewIdempotencyStore returns an idempotency.Store that calls every method of next
+// within a span, e.g. "IdempotencyStore.Reserve".
+// The spans carry no database attributes, since the store may be kept in memory.
+func NewIdempotencyStore(next idempotency.Store, tp trace.TracerProvider) idempotency.Store {
+	return &idempotencyStore{next: next, tracer: tp.Tracer(InstrumentationName)}
+}
+
+func (is *idempotencyStore) Reserve(
+	ctx context.Context,
+	key string,
+	fingerprint string,
+	now time.Time,
+	expiresAt time.Time,
+) (*idempotency.Record, error) {
+	return call(ctx, is.tracer, "IdempotencyStore.Reserve", func(ctx context.Context) (*idempotency.Record, error) {
+		return is.next.Reserve(ctx, key, fingerprint, now, expiresAt)
+	})
+}
+
+func (is *idempotencyStore) Complete(ctx context.Context, key string, response idempotency.Response) error {
+	return run(ctx, is.tracer, "IdempotencyStore.Complete", func(ctx context.Context) error {
+		return is.next.Complete(ctx, key, response)
+	})
+}
+
+func (is *idempotencyStore) Release(ctx context.Context, key string) error {
+	return run(ctx, is.tracer, "IdempotencyStore.Release", func(ctx context.Context) error {
+		return is.next.Release(ctx, key)
+	})
+}
+
+func (is *idempotencyStore) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	return call(ctx, is.tracer, "IdempotencyStore.DeleteExpired", func(ctx context.Context) (int64, error) {
+		return is.next.DeleteExpired(ctx, now)
+	})
+}
+
+type rateLimitStore struct {
+	next   ratelimit.Store
+	tracer trace.Tracer
+}
+
+// NewRateLimitStore returns a ratelimit.Store that calls every method of next
+// within a span, e.g. "RateLimitStore.Take".
+// The spans carry no database attributes, since the store may be kept in memory.
+func NewRateLimitStore(next ratelimit.Store, tp trace.TracerProvider) ratelimit.Store {
+	return &rateLimitStore{next: next, tracer: tp.Tracer(InstrumentationName)}
+}
+
+func (rs *rateLimitStore) Take(
+	ctx context.Context,
+	key string,
+	limit ratelimit.Limit,
+	now time.Time,
+) (ratelimit.Result, error) {
+	return call(ctx, rs.tracer, "RateLimitStore.Take", func(ctx context.Context) (ratelimit.Result, error) {
+		return rs.next.Take(ctx, key, limit, now)
+	})
+}
+
+func (rs *rateLimitStore) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	return call(ctx, rs.tracer, "RateLimitStore.DeleteExpired", func(ctx context.Context) (int64, error) {
+		return rs.next.DeleteExpired(ctx, now)
+	})
+}
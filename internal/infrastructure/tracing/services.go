@@ -0,0 +1,342 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	userModels "github.com/cyberbrain-dev/taskery-api/internal/domain/user/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TaskService is the task service that is traced by NewTaskService.
+type TaskService interface {
+	Create(ctx context.Context, cmd services.CreateTaskCommand) (string, error)
+	Update(
+		ctx context.Context,
+		id string,
+		ownerID string,
+		cmd services.UpdateTaskCommand,
+		expectedVersion *int64,
+	) (int64, error)
+	RemoveDeadline(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+	Complete(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+	Reopen(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+	FindByID(ctx context.Context, id string, ownerID string) (*models.Task, error)
+	FindByIDs(ctx context.Context, ids []string, ownerID string) ([]*models.Task, error)
+	FindByOwner(ctx context.Context, ownerID string, query services.FindByOwnerQuery) ([]*models.Task, error)
+	Delete(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+	DeletePermanently(ctx context.Context, id string, ownerID string, expectedVersion *int64) error
+	Restore(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+	FindTrash(ctx context.Context, ownerID string) ([]*models.Task, error)
+	PurgeTrash(ctx context.Context, retention time.Duration) (int64, error)
+	Search(ctx context.Context, ownerID string, query services.SearchTasksQuery) ([]services.TaskSearchResult, error)
+	Archive(ctx context.Context, id string, ownerID string, force bool, expectedVersion *int64) (int64, error)
+	Unarchive(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+	ArchiveCompleted(ctx context.Context, ownerID string, olderThan time.Duration) (int64, error)
+	History(ctx context.Context, id string, ownerID string) ([]*models.TaskEvent, error)
+	Histories(ctx context.Context, ids []string, ownerID string) (map[string][]*models.TaskEvent, error)
+	Revert(ctx context.Context, id string, ownerID string, eventID string, expectedVersion *int64) (int64, error)
+	Batch(ctx context.Context, ownerID string, ops []services.BatchOperation, atomic bool) ([]error, error)
+	ChangesSince(ctx context.Context, ownerID string, since int64, limit int) (*services.TaskSyncPage, error)
+	Push(ctx context.Context, ownerID string, ops []services.SyncOperation) []services.SyncResult
+}
+
+// UserService is the user service that is traced by NewUserService.
+type UserService interface {
+	Register(ctx context.Context, username, email, password string) error
+	Login(ctx context.Context, email, password string) (string, error)
+	ChangeUsername(ctx context.Context, id, newUsername, password string) error
+	ChangeEmail(ctx context.Context, id, newEmail, password string) error
+	ChangePassword(ctx context.Context, id, old, new string) error
+	Delete(ctx context.Context, id, password string) error
+	FindByIDs(ctx context.Context, ids []string) ([]*userModels.User, error)
+}
+
+// CalendarService is the calendar service that is traced by NewCalendarService.
+type CalendarService interface {
+	RotateFeedToken(ctx context.Context, userID string) (string, error)
+	RevokeFeedToken(ctx context.Context, userID string) error
+	Feed(ctx context.Context, token string) ([]*models.Task, error)
+}
+
+// Compile-time checks that the services can be traced.
+var (
+	_ TaskService     = (*services.TaskService)(nil)
+	_ UserService     = (*services.UserService)(nil)
+	_ CalendarService = (*services.CalendarService)(nil)
+)
+
+type taskService struct {
+	next   TaskService
+	tracer trace.Tracer
+}
+
+// NewTaskService returns a TaskService that calls every method of next within a span
+// named after the method, e.g. "TaskService.Create".
+func NewTaskService(next TaskService, tp trace.TracerProvider) TaskService {
+	return &taskService{next: next, tracer: tp.Tracer(InstrumentationName)}
+}
+
+func (ts *taskService) Create(ctx context.Context, cmd services.CreateTaskCommand) (string, error) {
+	return call(ctx, ts.tracer, "TaskService.Create", func(ctx context.Context) (string, error) {
+		return ts.next.Create(ctx, cmd)
+	})
+}
+
+func (ts *taskService) Update(
+	ctx context.Context,
+	id string,
+	ownerID string,
+	cmd services.UpdateTaskCommand,
+	expectedVersion *int64,
+) (int64, error) {
+	return call(ctx, ts.tracer, "TaskService.Update", func(ctx context.Context) (int64, error) {
+		return ts.next.Update(ctx, id, ownerID, cmd, expectedVersion)
+	})
+}
+
+func (ts *taskService) RemoveDeadline(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error) {
+	return call(ctx, ts.tracer, "TaskService.RemoveDeadline", func(ctx context.Context) (int64, error) {
+		return ts.next.RemoveDeadline(ctx, id, ownerID, expectedVersion)
+	})
+}
+
+func (ts *taskService) Complete(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error) {
+	return call(ctx, ts.tracer, "TaskService.Complete", func(ctx context.Context) (int64, error) {
+		return ts.next.Complete(ctx, id, ownerID, expectedVersion)
+	})
+}
+
+func (ts *taskService) Reopen(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error) {
+	return call(ctx, ts.tracer, "TaskService.Reopen", func(ctx context.Context) (int64, error) {
+		return ts.next.Reopen(ctx, id, ownerID, expectedVersion)
+	})
+}
+
+func (ts *taskService) FindByID(ctx context.Context, id string, ownerID string) (*models.Task, error) {
+	return call(ctx, ts.tracer, "TaskService.FindByID", func(ctx context.Context) (*models.Task, error) {
+		return ts.next.FindByID(ctx, id, ownerID)
+	})
+}
+
+func (ts *taskService) FindByIDs(ctx context.Context, ids []string, ownerID string) ([]*models.Task, error) {
+	return call(ctx, ts.tracer, "TaskService.FindByIDs", func(ctx context.Context) ([]*models.Task, error) {
+		return ts.next.FindByIDs(ctx, ids, ownerID)
+	})
+}
+
+func (ts *taskService) FindByOwner(
+	ctx context.Context,
+	ownerID string,
+	query services.FindByOwnerQuery,
+) ([]*models.Task, error) {
+	return call(ctx, ts.tracer, "TaskService.FindByOwner", func(ctx context.Context) ([]*models.Task, error) {
+		return ts.next.FindByOwner(ctx, ownerID, query)
+	})
+}
+
+func (ts *taskService) Delete(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error) {
+	return call(ctx, ts.tracer, "TaskService.Delete", func(ctx context.Context) (int64, error) {
+		return ts.next.Delete(ctx, id, ownerID, expectedVersion)
+	})
+}
+
+func (ts *taskService) DeletePermanently(ctx context.Context, id string, ownerID string, expectedVersion *int64) error {
+	return run(ctx, ts.tracer, "TaskService.DeletePermanently", func(ctx context.Context) error {
+		return ts.next.DeletePermanently(ctx, id, ownerID, expectedVersion)
+	})
+}
+
+func (ts *taskService) Restore(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error) {
+	return call(ctx, ts.tracer, "TaskService.Restore", func(ctx context.Context) (int64, error) {
+		return ts.next.Restore(ctx, id, ownerID, expectedVersion)
+	})
+}
+
+func (ts *taskService) FindTrash(ctx context.Context, ownerID string) ([]*models.Task, error) {
+	return call(ctx, ts.tracer, "TaskService.FindTrash", func(ctx context.Context) ([]*models.Task, error) {
+		return ts.next.FindTrash(ctx, ownerID)
+	})
+}
+
+func (ts *taskService) PurgeTrash(ctx context.Context, retention time.Duration) (int64, error) {
+	return call(ctx, ts.tracer, "TaskService.PurgeTrash", func(ctx context.Context) (int64, error) {
+		return ts.next.PurgeTrash(ctx, retention)
+	})
+}
+
+func (ts *taskService) Search(
+	ctx context.Context,
+	ownerID string,
+	query services.SearchTasksQuery,
+) ([]services.TaskSearchResult, error) {
+	return call(ctx, ts.tracer, "TaskService.Search", func(ctx context.Context) ([]services.TaskSearchResult, error) {
+		return ts.next.Search(ctx, ownerID, query)
+	})
+}
+
+func (ts *taskService) Archive(
+	ctx context.Context,
+	id string,
+	ownerID string,
+	force bool,
+	expectedVersion *int64,
+) (int64, error) {
+	return call(ctx, ts.tracer, "TaskService.Archive", func(ctx context.Context) (int64, error) {
+		return ts.next.Archive(ctx, id, ownerID, force, expectedVersion)
+	})
+}
+
+func (ts *taskService) Unarchive(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error) {
+	return call(ctx, ts.tracer, "TaskService.Unarchive", func(ctx context.Context) (int64, error) {
+		return ts.next.Unarchive(ctx, id, ownerID, expectedVersion)
+	})
+}
+
+func (ts *taskService) ArchiveCompleted(ctx context.Context, ownerID string, olderThan time.Duration) (int64, error) {
+	return call(ctx, ts.tracer, "TaskService.ArchiveCompleted", func(ctx context.Context) (int64, error) {
+		return ts.next.ArchiveCompleted(ctx, ownerID, olderThan)
+	})
+}
+
+func (ts *taskService) History(ctx context.Context, id string, ownerID string) ([]*models.TaskEvent, error) {
+	return call(ctx, ts.tracer, "TaskService.History", func(ctx context.Context) ([]*models.TaskEvent, error) {
+		return ts.next.History(ctx, id, ownerID)
+	})
+}
+
+func (ts *taskService) Histories(
+	ctx context.Context,
+	ids []string,
+	ownerID string,
+) (map[string][]*models.TaskEvent, error) {
+	return call(ctx, ts.tracer, "TaskService.Histories", func(ctx context.Context) (map[string][]*models.TaskEvent, error) {
+		return ts.next.Histories(ctx, ids, ownerID)
+	})
+}
+
+func (ts *taskService) Revert(
+	ctx context.Context,
+	id string,
+	ownerID string,
+	eventID string,
+	expectedVersion *int64,
+) (int64, error) {
+	return call(ctx, ts.tracer, "TaskService.Revert", func(ctx context.Context) (int64, error) {
+		return ts.next.Revert(ctx, id, ownerID, eventID, expectedVersion)
+	})
+}
+
+func (ts *taskService) Batch(
+	ctx context.Context,
+	ownerID string,
+	ops []services.BatchOperation,
+	atomic bool,
+) ([]error, error) {
+	return call(ctx, ts.tracer, "TaskService.Batch", func(ctx context.Context) ([]error, error) {
+		return ts.next.Batch(ctx, ownerID, ops, atomic)
+	})
+}
+
+func (ts *taskService) ChangesSince(
+	ctx context.Context,
+	ownerID string,
+	since int64,
+	limit int,
+) (*services.TaskSyncPage, error) {
+	return call(ctx, ts.tracer, "TaskService.ChangesSince", func(ctx context.Context) (*services.TaskSyncPage, error) {
+		return ts.next.ChangesSince(ctx, ownerID, since, limit)
+	})
+}
+
+func (ts *taskService) Push(ctx context.Context, ownerID string, ops []services.SyncOperation) []services.SyncResult {
+	results, _ := call(ctx, ts.tracer, "TaskService.Push", func(ctx context.Context) ([]services.SyncResult, error) {
+		return ts.next.Push(ctx, ownerID, ops), nil
+	})
+
+	return results
+}
+
+type userService struct {
+	next   UserService
+	tracer trace.Tracer
+}
+
+// NewUserService returns a UserService that calls every method of next within a span
+// named after the method, e.g. "UserService.Login".
+func NewUserService(next UserService, tp trace.TracerProvider) UserService {
+	return &userService{next: next, tracer: tp.Tracer(InstrumentationName)}
+}
+
+func (us *userService) Register(ctx context.Context, username, email, password string) error {
+	return run(ctx, us.tracer, "UserService.Register", func(ctx context.Context) error {
+		return us.next.Register(ctx, username, email, password)
+	})
+}
+
+func (us *userService) Login(ctx context.Context, email, password string) (string, error) {
+	return call(ctx, us.tracer, "UserService.Login", func(ctx context.Context) (string, error) {
+		return us.next.Login(ctx, email, password)
+	})
+}
+
+func (us *userService) ChangeUsername(ctx context.Context, id, newUsername, password string) error {
+	return run(ctx, us.tracer, "UserService.ChangeUsername", func(ctx context.Context) error {
+		return us.next.ChangeUsername(ctx, id, newUsername, password)
+	})
+}
+
+func (us *userService) ChangeEmail(ctx context.Context, id, newEmail, password string) error {
+	return run(ctx, us.tracer, "UserService.ChangeEmail", func(ctx context.Context) error {
+		return us.next.ChangeEmail(ctx, id, newEmail, password)
+	})
+}
+
+func (us *userService) ChangePassword(ctx context.Context, id, old, new string) error {
+	return run(ctx, us.tracer, "UserService.ChangePassword", func(ctx context.Context) error {
+		return us.next.ChangePassword(ctx, id, old, new)
+	})
+}
+
+func (us *userService) Delete(ctx context.Context, id, password string) error {
+	return run(ctx, us.tracer, "UserService.Delete", func(ctx context.Context) error {
+		return us.next.Delete(ctx, id, password)
+	})
+}
+
+func (us *userService) FindByIDs(ctx context.Context, ids []string) ([]*userModels.User, error) {
+	return call(ctx, us.tracer, "UserService.FindByIDs", func(ctx context.Context) ([]*userModels.User, error) {
+		return us.next.FindByIDs(ctx, ids)
+	})
+}
+
+type calendarService struct {
+	next   CalendarService
+	tracer trace.Tracer
+}
+
+// NewCalendarService returns a CalendarService that calls every method of next within a span
+// named after the method, e.g. "CalendarService.Feed".
+func NewCalendarService(next CalendarService, tp trace.TracerProvider) CalendarService {
+	return &calendarService{next: next, tracer: tp.Tracer(InstrumentationName)}
+}
+
+func (cs *calendarService) RotateFeedToken(ctx context.Context, userID string) (string, error) {
+	return call(ctx, cs.tracer, "CalendarService.RotateFeedToken", func(ctx context.Context) (string, error) {
+		return cs.next.RotateFeedToken(ctx, userID)
+	})
+}
+
+func (cs *calendarService) RevokeFeedToken(ctx context.Context, userID string) error {
+	return run(ctx, cs.tracer, "CalendarService.RevokeFeedToken", func(ctx context.Context) error {
+		return cs.next.RevokeFeedToken(ctx, userID)
+	})
+}
+
+func (cs *calendarService) Feed(ctx context.Context, token string) ([]*models.Task, error) {
+	return call(ctx, cs.tracer, "CalendarService.Feed", func(ctx context.Context) ([]*models.Task, error) {
+		return cs.next.Feed(ctx, token)
+	})
+}
@@ -0,0 +1,144 @@
+// Package tracing sets up the OpenTelemetry tracing of the application
+// and provides the decorators that trace the services and the repositories.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// InstrumentationName is the name of the tracer that creates the spans of the application.
+const InstrumentationName = "github.com/cyberbrain-dev/taskery-api"
+
+// ServiceName is the name of the service the spans are reported for.
+const ServiceName = "taskery-api"
+
+// Exporters of the spans.
+const (
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterNone   = "none"
+)
+
+// ErrExporterUnknown is returned by NewProvider if the exporter is not one of the known ones.
+var ErrExporterUnknown = errors.New("unknown trace exporter")
+
+// Options configures the tracer provider created by NewProvider.
+type Options struct {
+	// Exporter is either ExporterOTLP, ExporterStdout or ExporterNone.
+	Exporter string
+
+	// OTLPEndpoint is the host and port of the OTLP/HTTP collector, e.g. "localhost:4318".
+	OTLPEndpoint string
+
+	// OTLPInsecure disables TLS for the connection to the collector.
+	OTLPInsecure bool
+
+	// SampleRate is the fraction of the traces that are sampled, from 0 to 1.
+	// A trace that is sampled by the caller is always sampled.
+	SampleRate float64
+}
+
+// NewProvider creates a tracer provider that exports the spans as configured by opts,
+// along with a function that flushes the pending spans and stops the provider.
+//
+// With ExporterNone the returned provider does not record any spans.
+func NewProvider(ctx context.Context, opts Options) (trace.TracerProvider, func(context.Context) error, error) {
+	const op = "tracing.NewProvider"
+
+	var exporter sdktrace.SpanExporter
+	var err error
+
+	switch opts.Exporter {
+	case ExporterNone:
+		return noop.NewTracerProvider(), func(context.Context) error { return nil }, nil
+
+	case ExporterOTLP:
+		clientOpts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(opts.OTLPEndpoint)}
+		if opts.OTLPInsecure {
+			clientOpts = append(clientOpts, otlptracehttp.WithInsecure())
+		}
+
+		exporter, err = otlptracehttp.New(ctx, clientOpts...)
+
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+
+	default:
+		return nil, nil, fmt.Errorf("%s: %w: %q", op, ErrExporterUnknown, opts.Exporter)
+	}
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(ServiceName)),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRate))),
+	)
+
+	return provider, provider.Shutdown, nil
+}
+
+// run calls fn within a new span with the given name and records the error of fn in the span.
+func run(
+	ctx context.Context,
+	tracer trace.Tracer,
+	name string,
+	fn func(ctx context.Context) error,
+	opts ...trace.SpanStartOption,
+) error {
+	ctx, span := tracer.Start(ctx, name, opts...)
+	defer span.End()
+
+	err := fn(ctx)
+	recordError(span, err)
+
+	return err
+}
+
+// call is run for the functions that return a value along with an error.
+func call[T any](
+	ctx context.Context,
+	tracer trace.Tracer,
+	name string,
+	fn func(ctx context.Context) (T, error),
+	opts ...trace.SpanStartOption,
+) (T, error) {
+	ctx, span := tracer.Start(ctx, name, opts...)
+	defer span.End()
+
+	v, err := fn(ctx)
+	recordError(span, err)
+
+	return v, err
+}
+
+func recordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,258 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/idempotency"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/ratelimit"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/tracing"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/cyberbrain-dev/taskery-api/internal/services/mocks"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewProvider(t *testing.T) {
+	tests := []struct {
+		name     string
+		exporter string
+		wantErr  error
+	}{
+		{name: "none", exporter: tracing.ExporterNone},
+		{name: "stdout", exporter: tracing.ExporterStdout},
+		{name: "otlp", exporter: tracing.ExporterOTLP},
+		{name: "unknown", exporter: "jaeger", wantErr: tracing.ErrExporterUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, shutdown, err := tracing.NewProvider(context.Background(), tracing.Options{
+				Exporter:     tt.exporter,
+				OTLPEndpoint: "localhost:4318",
+				SampleRate:   1,
+			})
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, provider)
+			require.NoError(t, shutdown(context.Background()))
+		})
+	}
+}
+
+func TestUserService_Login(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	repo := new(mocks.UserRepository)
+	repo.On("FindByEmail", mock.Anything, "unknown@example.com").Return(nil, services.ErrUserRepoNotFound)
+
+	svc, err := services.NewUserService(
+		tracing.NewUserRepository(repo, provider),
+		new(mocks.TokenProvider),
+		clock.Real{},
+	)
+	require.NoError(t, err)
+
+	traced := tracing.NewUserService(svc, provider)
+
+	_, err = traced.Login(context.Background(), "unknown@example.com", "password")
+	require.ErrorIs(t, err, services.ErrUserNotFound)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	repoSpan, serviceSpan := spans[0], spans[1]
+
+	require.Equal(t, "UserRepository.FindByEmail", repoSpan.Name())
+	require.Equal(t, trace.SpanKindClient, repoSpan.SpanKind())
+	require.Contains(t, repoSpan.Attributes(), attribute.String("db.system.name", "postgresql"))
+	require.Equal(t, serviceSpan.SpanContext().SpanID(), repoSpan.Parent().SpanID())
+
+	require.Equal(t, "UserService.Login", serviceSpan.Name())
+	require.Equal(t, codes.Error, serviceSpan.Status().Code)
+	require.Equal(t, services.ErrUserNotFound.Error(), serviceSpan.Status().Description)
+}
+
+func TestDecorators(t *testing.T) {
+	errDB := errors.New("connection refused")
+
+	tests := []struct {
+		name       string
+		call       func(ctx context.Context, tp trace.TracerProvider) error
+		wantSpan   string
+		wantKind   trace.SpanKind
+		wantDB     bool
+		wantStatus codes.Code
+	}{
+		{
+			name: "task repository",
+			call: func(ctx context.Context, tp trace.TracerProvider) error {
+				repo := new(mocks.TaskRepository)
+				repo.On("Delete", mock.Anything, "id", int64(2)).Return(nil)
+
+				return tracing.NewTaskRepository(repo, tp).Delete(ctx, "id", 2)
+			},
+			wantSpan:   "TaskRepository.Delete",
+			wantKind:   trace.SpanKindClient,
+			wantDB:     true,
+			wantStatus: codes.Unset,
+		},
+		{
+			name: "task repository error",
+			call: func(ctx context.Context, tp trace.TracerProvider) error {
+				repo := new(mocks.TaskRepository)
+				repo.On("FindByID", mock.Anything, "id").Return(nil, errDB)
+
+				_, err := tracing.NewTaskRepository(repo, tp).FindByID(ctx, "id")
+				return err
+			},
+			wantSpan:   "TaskRepository.FindByID",
+			wantKind:   trace.SpanKindClient,
+			wantDB:     true,
+			wantStatus: codes.Error,
+		},
+		{
+			name: "task event repository",
+			call: func(ctx context.Context, tp trace.TracerProvider) error {
+				repo := new(mocks.TaskEventRepository)
+				repo.On("FindByTask", mock.Anything, "id").Return(nil, nil)
+
+				_, err := tracing.NewTaskEventRepository(repo, tp).FindByTask(ctx, "id")
+				return err
+			},
+			wantSpan:   "TaskEventRepository.FindByTask",
+			wantKind:   trace.SpanKindClient,
+			wantDB:     true,
+			wantStatus: codes.Unset,
+		},
+		{
+			name: "user repository",
+			call: func(ctx context.Context, tp trace.TracerProvider) error {
+				repo := new(mocks.UserRepository)
+				repo.On("Delete", mock.Anything, "id").Return(nil)
+
+				return tracing.NewUserRepository(repo, tp).Delete(ctx, "id")
+			},
+			wantSpan:   "UserRepository.Delete",
+			wantKind:   trace.SpanKindClient,
+			wantDB:     true,
+			wantStatus: codes.Unset,
+		},
+		{
+			name: "transactor",
+			call: func(ctx context.Context, tp trace.TracerProvider) error {
+				tx := new(mocks.Transactor)
+				tx.On("WithinTx", mock.Anything, mock.Anything).Return(errDB)
+
+				return tracing.NewTransactor(tx, tp).WithinTx(ctx, func(context.Context) error { return nil })
+			},
+			wantSpan:   "Transactor.WithinTx",
+			wantKind:   trace.SpanKindClient,
+			wantDB:     true,
+			wantStatus: codes.Error,
+		},
+		{
+			name: "idempotency store",
+			call: func(ctx context.Context, tp trace.TracerProvider) error {
+				now := time.Now()
+
+				_, err := tracing.NewIdempotencyStore(idempotency.NewMemoryStore(), tp).
+					Reserve(ctx, "key", "fingerprint", now, now.Add(time.Hour))
+				return err
+			},
+			wantSpan:   "IdempotencyStore.Reserve",
+			wantKind:   trace.SpanKindInternal,
+			wantStatus: codes.Unset,
+		},
+		{
+			name: "idempotency store error",
+			call: func(ctx context.Context, tp trace.TracerProvider) error {
+				return tracing.NewIdempotencyStore(idempotency.NewMemoryStore(), tp).
+					Complete(ctx, "key", idempotency.Response{StatusCode: 201})
+			},
+			wantSpan:   "IdempotencyStore.Complete",
+			wantKind:   trace.SpanKindInternal,
+			wantStatus: codes.Error,
+		},
+		{
+			name: "rate limit store",
+			call: func(ctx context.Context, tp trace.TracerProvider) error {
+				_, err := tracing.NewRateLimitStore(ratelimit.NewMemoryStore(), tp).
+					Take(ctx, "auth:ip:10.0.0.1", ratelimit.Limit{Requests: 1, Period: time.Minute}, time.Now())
+				return err
+			},
+			wantSpan:   "RateLimitStore.Take",
+			wantKind:   trace.SpanKindInternal,
+			wantStatus: codes.Unset,
+		},
+		{
+			name: "task service",
+			call: func(ctx context.Context, tp trace.TracerProvider) error {
+				repo := new(mocks.TaskRepository)
+				repo.On("FindByID", mock.Anything, "id").Return(nil, services.ErrTaskRepoNotFound)
+
+				svc, err := services.NewTaskService(
+					repo,
+					new(mocks.TaskEventRepository),
+					new(mocks.Transactor),
+					clock.Real{},
+					nil,
+				)
+				require.NoError(t, err)
+
+				_, err = tracing.NewTaskService(svc, tp).Complete(ctx, "id", "owner", nil)
+				return err
+			},
+			wantSpan:   "TaskService.Complete",
+			wantKind:   trace.SpanKindInternal,
+			wantStatus: codes.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+			err := tt.call(context.Background(), provider)
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+
+			span := spans[0]
+
+			require.Equal(t, tt.wantSpan, span.Name())
+			require.Equal(t, tt.wantKind, span.SpanKind())
+			require.Equal(t, tt.wantStatus, span.Status().Code)
+
+			if tt.wantStatus == codes.Error {
+				require.Error(t, err)
+				require.Equal(t, err.Error(), span.Status().Description)
+			} else {
+				require.NoError(t, err)
+			}
+
+			dbAttribute := attribute.String("db.system.name", "postgresql")
+			if tt.wantDB {
+				require.Contains(t, span.Attributes(), dbAttribute)
+				require.Contains(t, span.Attributes(), attribute.String("db.operation.name", tt.wantSpan))
+			} else {
+				require.NotContains(t, span.Attributes(), dbAttribute)
+			}
+		})
+	}
+}
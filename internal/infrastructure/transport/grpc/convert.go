@@ -0,0 +1,194 @@
+package grpc
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/grpc/taskeryv1"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// newTask converts the domain task into its protobuf representation.
+func newTask(task *models.Task) *taskeryv1.Task {
+	msg := &taskeryv1.Task{
+		Id:          task.ID().String(),
+		Title:       task.Title().String(),
+		Description: task.Description().String(),
+		IsCompleted: task.IsCompleted(),
+		CompletedAt: newTimestamp(task.CompletedAt()),
+		CreatedAt:   timestamppb.New(task.CreatedAt()),
+		UpdatedAt:   timestamppb.New(task.UpdatedAt()),
+		ArchivedAt:  newTimestamp(task.ArchivedAt()),
+		DeletedAt:   newTimestamp(task.DeletedAt()),
+		Version:     task.Version(),
+	}
+
+	if deadline := task.Deadline(); deadline != nil {
+		msg.Deadline = timestamppb.New(deadline.Time())
+	}
+
+	return msg
+}
+
+// newTasks converts the domain tasks into their protobuf representation.
+func newTasks(tasks []*models.Task) []*taskeryv1.Task {
+	msgs := make([]*taskeryv1.Task, len(tasks))
+	for i, task := range tasks {
+		msgs[i] = newTask(task)
+	}
+
+	return msgs
+}
+
+// newTaskEvent converts the domain task event into its protobuf representation.
+func newTaskEvent(event *models.TaskEvent) (*taskeryv1.TaskEvent, error) {
+	changes := event.Changes()
+
+	changeMsgs := make([]*taskeryv1.FieldChange, len(changes))
+	for i, change := range changes {
+		before, err := newValue(change.Before)
+		if err != nil {
+			return nil, err
+		}
+
+		after, err := newValue(change.After)
+		if err != nil {
+			return nil, err
+		}
+
+		changeMsgs[i] = &taskeryv1.FieldChange{
+			Field:  change.Field,
+			Before: before,
+			After:  after,
+		}
+	}
+
+	return &taskeryv1.TaskEvent{
+		Id:         event.ID().String(),
+		Type:       string(event.Type()),
+		ActorId:    event.ActorID().String(),
+		OccurredAt: timestamppb.New(event.OccurredAt()),
+		Changes:    changeMsgs,
+	}, nil
+}
+
+// newValue converts the value of a changed field into a protobuf value
+// that has the same JSON representation as the value in the REST API.
+func newValue(v any) (*structpb.Value, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	value := &structpb.Value{}
+	if err := protojson.Unmarshal(raw, value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// newTimestamp converts the optional time into a protobuf timestamp, which is nil if t is nil.
+func newTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
+
+// parseTimestamp converts the optional protobuf timestamp of the named field into a time,
+// which is nil if ts is nil.
+func parseTimestamp(name string, ts *timestamppb.Timestamp) (*time.Time, error) {
+	if ts == nil {
+		return nil, nil
+	}
+
+	if err := ts.CheckValid(); err != nil {
+		return nil, InvalidParameter(name)
+	}
+
+	return new(ts.AsTime()), nil
+}
+
+// taskSort converts the protobuf sort order into the one of the service.
+func taskSort(sort taskeryv1.TaskSort) (services.TaskSort, error) {
+	switch sort {
+	case taskeryv1.TaskSort_TASK_SORT_UNSPECIFIED:
+		return services.TaskSortDefault, nil
+	case taskeryv1.TaskSort_TASK_SORT_CREATED_AT:
+		return services.TaskSortCreatedAt, nil
+	case taskeryv1.TaskSort_TASK_SORT_CREATED_AT_DESC:
+		return services.TaskSortCreatedAtDesc, nil
+	default:
+		return "", services.ErrTaskSortInvalid
+	}
+}
+
+// operationTypes maps the protobuf task operations to the types of the batch and sync operations.
+// TASK_OPERATION_UNSPECIFIED is left out, so that the service rejects it as an unknown type.
+var operationTypes = map[taskeryv1.TaskOperation]services.BatchOperationType{
+	taskeryv1.TaskOperation_TASK_OPERATION_CREATE:          services.SyncOperationCreate,
+	taskeryv1.TaskOperation_TASK_OPERATION_UPDATE:          services.BatchOperationUpdate,
+	taskeryv1.TaskOperation_TASK_OPERATION_REMOVE_DEADLINE: services.BatchOperationRemoveDeadline,
+	taskeryv1.TaskOperation_TASK_OPERATION_COMPLETE:        services.BatchOperationComplete,
+	taskeryv1.TaskOperation_TASK_OPERATION_REOPEN:          services.BatchOperationReopen,
+	taskeryv1.TaskOperation_TASK_OPERATION_DELETE:          services.BatchOperationDelete,
+}
+
+// newOperationError converts the error of a batch operation or a pushed change into its protobuf
+// representation, the same error that the call of the operation would fail with.
+// It returns nil if err is nil.
+func newOperationError(err error, expectedVersion *int64) *taskeryv1.OperationError {
+	if err == nil {
+		return nil
+	}
+
+	statusErr := StatusError(PreconditionError(err, expectedVersion))
+
+	return &taskeryv1.OperationError{
+		Code:    int32(statusErr.Code),
+		Reason:  statusErr.Reason,
+		Message: statusErr.Message,
+	}
+}
+
+var errSyncTokenInvalid = InvalidParameter("since")
+
+// encodeSyncToken hides the change sequence number in an opaque token.
+// The tokens are the same as the ones of the REST API, so a client can use both.
+func encodeSyncToken(seq int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(seq, 10)))
+}
+
+// decodeSyncToken returns the change sequence number of the token created by encodeSyncToken.
+// The empty token means the sync from the start.
+func decodeSyncToken(token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, errSyncTokenInvalid
+	}
+
+	seq, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil || seq < 0 {
+		return 0, errSyncTokenInvalid
+	}
+
+	return seq, nil
+}
+
+// isInternal reports whether err is reported to the client as ErrInternal.
+func isInternal(err error) bool {
+	return StatusError(err).Code == codes.Internal
+}
@@ -0,0 +1,175 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	taskModels "github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	taskVO "github.com/cyberbrain-dev/taskery-api/internal/domain/task/vo"
+	userModels "github.com/cyberbrain-dev/taskery-api/internal/domain/user/models"
+	userVO "github.com/cyberbrain-dev/taskery-api/internal/domain/user/vo"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain is the domain of the google.rpc.ErrorInfo details of the errors.
+const ErrorDomain = "taskery-api"
+
+// Error is an error of the gRPC API.
+// Reason identifies the error for the clients and is the same code
+// that the REST API reports in its problems.
+type Error struct {
+	Code    codes.Code
+	Reason  string
+	Message string
+}
+
+// NewError returns an error with the given status code, reason and message.
+func NewError(code codes.Code, reason, message string) *Error {
+	return &Error{
+		Code:    code,
+		Reason:  reason,
+		Message: message,
+	}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// GRPCStatus returns the status of the error with its reason in the google.rpc.ErrorInfo details.
+func (e *Error) GRPCStatus() *status.Status {
+	st := status.New(e.Code, e.Message)
+
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: e.Reason, Domain: ErrorDomain})
+	if err != nil {
+		return st
+	}
+
+	return detailed
+}
+
+// Common errors of the gRPC layer.
+var (
+	ErrInternal            = NewError(codes.Internal, "INTERNAL_ERROR", "internal server error")
+	ErrUnauthenticated     = NewError(codes.Unauthenticated, "UNAUTHORIZED", "unauthorized")
+	ErrTaskVersionMismatch = NewError(codes.FailedPrecondition, "TASK_VERSION_MISMATCH", "task version mismatch")
+)
+
+// MissingParameter returns an error about the required request field that is missing.
+func MissingParameter(name string) *Error {
+	return NewError(codes.InvalidArgument, "MISSING_PARAMETER", name+" is required")
+}
+
+// InvalidParameter returns an error about the request field that has an invalid value.
+func InvalidParameter(name string) *Error {
+	return NewError(codes.InvalidArgument, "INVALID_PARAMETER", "invalid "+name+" parameter")
+}
+
+// statusErrors maps the domain and service errors to the errors that are reported to the client.
+// The first matching entry wins, so more specific errors go first.
+var statusErrors = []struct {
+	err       error
+	statusErr *Error
+}{
+	// tasks
+	{services.ErrTaskNotFound, NewError(codes.NotFound, "TASK_NOT_FOUND", "task not found")},
+	{services.ErrTaskEventNotFound, NewError(codes.NotFound, "TASK_EVENT_NOT_FOUND", "event not found")},
+	{services.ErrTaskOwnerNotFound, NewError(codes.NotFound, "TASK_OWNER_NOT_FOUND", "task owner not found")},
+	{services.ErrTaskAccessDenied, NewError(codes.PermissionDenied, "TASK_ACCESS_DENIED", "access denied")},
+	{services.ErrTaskExists, NewError(codes.AlreadyExists, "TASK_ALREADY_EXISTS", "task already exists")},
+	{services.ErrTaskConflict, NewError(codes.Aborted, "TASK_CONFLICT", "task was modified concurrently")},
+	{
+		services.ErrTaskNotInTrash,
+		NewError(codes.FailedPrecondition, "TASK_NOT_IN_TRASH", "task is not in the trash"),
+	},
+	{
+		taskModels.ErrTaskNotCompleted,
+		NewError(codes.FailedPrecondition, "TASK_NOT_COMPLETED", "task is not completed"),
+	},
+	{services.ErrTaskSortInvalid, InvalidParameter("sort")},
+	{services.ErrTaskSearchLimitInvalid, InvalidParameter("limit")},
+	{services.ErrTaskSyncLimitInvalid, InvalidParameter("limit")},
+	{services.ErrTaskSyncSeqInvalid, InvalidParameter("since")},
+	{
+		services.ErrTaskBatchOperationInvalid,
+		NewError(codes.InvalidArgument, "BATCH_OPERATION_INVALID", "invalid batch operation"),
+	},
+	{
+		services.ErrTaskBatchAborted,
+		NewError(codes.Aborted, "BATCH_OPERATION_NOT_APPLIED", "operation was not applied"),
+	},
+	{taskVO.ErrTitleEmpty, NewError(codes.InvalidArgument, "TITLE_EMPTY", "title is empty")},
+	{taskVO.ErrTitleTooLong, NewError(codes.InvalidArgument, "TITLE_TOO_LONG", "title is too long")},
+	{
+		taskVO.ErrDescriptionTooLong,
+		NewError(codes.InvalidArgument, "DESCRIPTION_TOO_LONG", "description is too long"),
+	},
+	{taskVO.ErrDeadlineBeforeNow, NewError(codes.InvalidArgument, "DEADLINE_IN_PAST", "deadline is in the past")},
+	{taskVO.ErrSearchQueryEmpty, NewError(codes.InvalidArgument, "SEARCH_QUERY_EMPTY", "search query is empty")},
+	{
+		taskVO.ErrSearchQueryTooLong,
+		NewError(codes.InvalidArgument, "SEARCH_QUERY_TOO_LONG", "search query is too long"),
+	},
+	{
+		taskVO.ErrSearchQueryTooManyTerms,
+		NewError(codes.InvalidArgument, "SEARCH_QUERY_TOO_MANY_TERMS", "search query has too many terms"),
+	},
+
+	// users
+	{services.ErrUserNotFound, NewError(codes.NotFound, "USER_NOT_FOUND", "user not found")},
+	{services.ErrUserUnauthorized, ErrUnauthenticated},
+	{services.ErrUserExists, NewError(codes.AlreadyExists, "USER_ALREADY_EXISTS", "user already exists")},
+	{
+		services.ErrUserEmailAlreadyTaken,
+		NewError(codes.AlreadyExists, "EMAIL_ALREADY_TAKEN", "email already taken"),
+	},
+	{services.ErrUserConflict, NewError(codes.Aborted, "USER_CONFLICT", "user was modified concurrently")},
+	{userModels.ErrUserIDInvalid, NewError(codes.InvalidArgument, "USER_ID_INVALID", "user ID is invalid")},
+	{userVO.ErrUsernameEmpty, NewError(codes.InvalidArgument, "USERNAME_EMPTY", "username is empty")},
+	{userVO.ErrUsernameTooShort, NewError(codes.InvalidArgument, "USERNAME_TOO_SHORT", "username is too short")},
+	{userVO.ErrUsernameTooLong, NewError(codes.InvalidArgument, "USERNAME_TOO_LONG", "username is too long")},
+	{userVO.ErrEmailEmpty, NewError(codes.InvalidArgument, "EMAIL_EMPTY", "email is empty")},
+	{userVO.ErrEmailInvalid, NewError(codes.InvalidArgument, "EMAIL_INVALID", "email is invalid")},
+	{userVO.ErrPasswordEmpty, NewError(codes.InvalidArgument, "PASSWORD_EMPTY", "password is empty")},
+	{userVO.ErrPasswordTooShort, NewError(codes.InvalidArgument, "PASSWORD_TOO_SHORT", "password is too short")},
+	{userVO.ErrPasswordTooLong, NewError(codes.InvalidArgument, "PASSWORD_TOO_LONG", "password is too long")},
+	{userVO.ErrPasswordInvalid, NewError(codes.InvalidArgument, "PASSWORD_INVALID", "password is invalid")},
+}
+
+// StatusError returns the error of the gRPC API for err.
+// An *Error is returned as is, the known domain and service errors are mapped to their errors,
+// the expired and canceled contexts to DeadlineExceeded and Canceled,
+// and everything else to ErrInternal, so that the details of the system errors do not leak.
+func StatusError(err error) *Error {
+	if e, ok := errors.AsType[*Error](err); ok {
+		return e
+	}
+
+	for _, se := range statusErrors {
+		if errors.Is(err, se.err) {
+			return se.statusErr
+		}
+	}
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return NewError(codes.DeadlineExceeded, "TIMEOUT", "request timed out")
+	case errors.Is(err, context.Canceled):
+		return NewError(codes.Canceled, "CANCELED", "request was canceled")
+	default:
+		return ErrInternal
+	}
+}
+
+// PreconditionError returns ErrTaskVersionMismatch if err is a version conflict of a conditional call,
+// i.e. the one with an expected version. Otherwise it returns err as is.
+func PreconditionError(err error, expectedVersion *int64) error {
+	if expectedVersion != nil && errors.Is(err, services.ErrTaskConflict) {
+		return ErrTaskVersionMismatch
+	}
+
+	return err
+}
@@ -0,0 +1,219 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/auth/jwt"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/clientip"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/ratelimit"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+type ctxKeyUserID string
+
+// userIDKey is the key of the authenticated user ID in the context of a call.
+const userIDKey ctxKeyUserID = "userID"
+
+var (
+	errTokenMissing = NewError(codes.Unauthenticated, "TOKEN_MISSING", "authorization token is missing")
+	errTokenExpired = NewError(codes.Unauthenticated, "TOKEN_EXPIRED", "token is expired")
+	errTokenInvalid = NewError(codes.Unauthenticated, "TOKEN_INVALID", "token is invalid")
+
+	errRateLimitExceeded = NewError(codes.ResourceExhausted, "RATE_LIMIT_EXCEEDED", "rate limit exceeded")
+)
+
+// Logging returns an interceptor that adds the logger with the method of the call to its context
+// and logs the completed calls. The errors returned by the handlers are converted with StatusError,
+// and the system ones are logged with their details, which are not reported to the client.
+// A panic of the handler is logged and reported as ErrInternal.
+func Logging(baseLogger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (resp any, err error) {
+		logger := slogx.FromContext(ctx, baseLogger).With(slog.String("method", info.FullMethod))
+		ctx = slogx.WithLogger(ctx, logger)
+
+		start := time.Now()
+
+		defer func() {
+			if rec := recover(); rec != nil {
+				logger.Error("call panicked", slog.Any("panic", rec))
+
+				resp, err = nil, ErrInternal
+			}
+		}()
+
+		resp, err = handler(ctx, req)
+
+		attrs := []any{slog.Duration("duration", time.Since(start))}
+
+		if err == nil {
+			logger.Info("call completed", append(attrs, slog.String("code", codes.OK.String()))...)
+			return resp, nil
+		}
+
+		statusErr := StatusError(err)
+		attrs = append(attrs, slog.String("code", statusErr.Code.String()), slog.String("err", err.Error()))
+
+		if statusErr.Code == codes.Internal {
+			logger.Error("call failed", attrs...)
+		} else {
+			logger.Info("call failed", attrs...)
+		}
+
+		return nil, statusErr
+	}
+}
+
+// Auth returns an interceptor that authenticates the calls using a JWT.
+// It extracts the token from the "authorization" metadata, which must use the "Bearer " schema,
+// as the Authorization header of the REST API does.
+//
+// If the token is valid, the interceptor adds the resulting user ID to the context of the call
+// and to its logger. Otherwise, it fails the call with the Unauthenticated code;
+// an expired token is reported with the TOKEN_EXPIRED reason.
+// The public methods are called without a token.
+func Auth(validator TokenValidator, public ...string) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if slices.Contains(public, info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		values := md.Get("authorization")
+		if len(values) == 0 {
+			return nil, errTokenMissing
+		}
+
+		scheme, token, ok := strings.Cut(values[0], " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			return nil, errTokenMissing
+		}
+
+		userID, err := validator.Validate(token)
+		if err != nil {
+			if errors.Is(err, jwt.ErrTokenExpired) {
+				return nil, errTokenExpired
+			}
+
+			return nil, errTokenInvalid
+		}
+
+		ctx = context.WithValue(ctx, userIDKey, userID)
+		ctx = slogx.WithLogger(ctx, slogx.FromContext(ctx, slog.Default()).With(slog.String("user_id", userID)))
+
+		return handler(ctx, req)
+	}
+}
+
+// RateLimit returns an interceptor that limits the rate of the calls of the given methods
+// with a token bucket per client IP kept in store. The IP address of the client is resolved
+// with resolver from the address of the peer and the "x-forwarded-for" metadata.
+//
+// The buckets are scoped by group in the same way as the ones of the REST API, so a group
+// that is shared with it limits a client by the calls of both APIs together.
+// A call over the limit fails with the ResourceExhausted code and the "retry-after" header
+// with the number of seconds to wait. If store fails, the call is let through,
+// so that the limiter does not take the API down. An unlimited limit turns the interceptor off.
+func RateLimit(
+	store ratelimit.Store,
+	group string,
+	limit ratelimit.Limit,
+	clk clock.Clock,
+	resolver *clientip.Resolver,
+	methods ...string,
+) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if store == nil || limit.Unlimited() || !slices.Contains(methods, info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		key := group + ":ip:" + peerIP(ctx, resolver)
+
+		result, err := store.Take(ctx, key, limit, clk.Now())
+		if err != nil {
+			slogx.FromContext(ctx, slog.Default()).Error(
+				"failed to take a rate limit token",
+				slog.String("error", err.Error()),
+			)
+
+			return handler(ctx, req)
+		}
+
+		if !result.Allowed {
+			retryAfter := strconv.FormatInt(int64(math.Ceil(result.RetryAfter.Seconds())), 10)
+			_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", retryAfter))
+
+			return nil, errRateLimitExceeded
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// peerIP returns the IP address of the client of the call.
+func peerIP(ctx context.Context, resolver *clientip.Resolver) string {
+	var remoteAddr string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		remoteAddr = p.Addr.String()
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	return resolver.ClientIP(remoteAddr, md.Get("x-forwarded-for"))
+}
+
+// Timeout returns an interceptor that limits the duration of the calls.
+// A zero timeout leaves the calls limited only by the deadlines of the clients.
+func Timeout(timeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		_ *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if timeout <= 0 {
+			return handler(ctx, req)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		return handler(ctx, req)
+	}
+}
+
+// GetUserID returns the ID of the user authenticated by the Auth interceptor.
+// Returns the empty string if a user ID cannot be found.
+func GetUserID(ctx context.Context) string {
+	if userID, ok := ctx.Value(userIDKey).(string); ok {
+		return userID
+	}
+	return ""
+}
@@ -0,0 +1,134 @@
+// Package grpc serves the API of the application over gRPC for the internal services.
+// It mirrors the REST API of the v1 package on top of the same services.
+package grpc
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/clientip"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/ratelimit"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/grpc/taskeryv1"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
+	"google.golang.org/grpc"
+)
+
+type UserService interface {
+	Register(ctx context.Context, username, email, password string) error
+	Login(ctx context.Context, email, password string) (string, error)
+	ChangeUsername(ctx context.Context, id, newUsername, password string) error
+	ChangeEmail(ctx context.Context, id, newEmail, password string) error
+	ChangePassword(ctx context.Context, id, old, new string) error
+	Delete(ctx context.Context, id, password string) error
+}
+
+type TaskService interface {
+	Create(ctx context.Context, cmd services.CreateTaskCommand) (string, error)
+	Update(
+		ctx context.Context,
+		id string,
+		ownerID string,
+		cmd services.UpdateTaskCommand,
+		expectedVersion *int64,
+	) (int64, error)
+	RemoveDeadline(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+	Complete(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+	Reopen(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+	FindByID(ctx context.Context, id string, ownerID string) (*models.Task, error)
+	FindByOwner(ctx context.Context, ownerID string, query services.FindByOwnerQuery) ([]*models.Task, error)
+	Delete(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+	DeletePermanently(ctx context.Context, id string, ownerID string, expectedVersion *int64) error
+	Restore(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+	FindTrash(ctx context.Context, ownerID string) ([]*models.Task, error)
+	Search(ctx context.Context, ownerID string, query services.SearchTasksQuery) ([]services.TaskSearchResult, error)
+	Archive(ctx context.Context, id string, ownerID string, force bool, expectedVersion *int64) (int64, error)
+	Unarchive(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+	ArchiveCompleted(ctx context.Context, ownerID string, olderThan time.Duration) (int64, error)
+	History(ctx context.Context, id string, ownerID string) ([]*models.TaskEvent, error)
+	Revert(ctx context.Context, id string, ownerID string, eventID string, expectedVersion *int64) (int64, error)
+	Batch(ctx context.Context, ownerID string, ops []services.BatchOperation, atomic bool) ([]error, error)
+	ChangesSince(ctx context.Context, ownerID string, since int64, limit int) (*services.TaskSyncPage, error)
+	Push(ctx context.Context, ownerID string, ops []services.SyncOperation) []services.SyncResult
+}
+
+// TokenValidator wraps a method for parsing and validating JWT token.
+type TokenValidator interface {
+	// Validate gets a JWT token and returns user ID from this token and an error, if occurred.
+	Validate(token string) (string, error)
+}
+
+type ServerOptions struct {
+	UserService    UserService
+	TaskService    TaskService
+	TokenValidator TokenValidator
+
+	Logger *slog.Logger
+
+	// RateLimitStore keeps the rate limit buckets of the public methods, which are limited by AuthRateLimit
+	// per client IP in the "auth" group shared with the REST API. The methods are not limited if it is nil.
+	// ClientIP resolves the IP addresses of the clients behind the trusted proxies.
+	RateLimitStore ratelimit.Store
+	AuthRateLimit  ratelimit.Limit
+	Clock          clock.Clock
+	ClientIP       *clientip.Resolver
+
+	// Timeout limits the duration of every call.
+	Timeout time.Duration
+
+	// MaxBatchSize limits the number of the operations of a batch and of the changes of a sync push.
+	MaxBatchSize int
+}
+
+// NewServer returns a gRPC server with the task and user services registered.
+// Every call is logged, recovered from panics and limited by opts.Timeout,
+// and all but Register and Login require a JWT in the "authorization" metadata.
+// Register and Login are rate limited per client IP instead.
+// The extra options are passed to the server as is.
+func NewServer(opts ServerOptions, extra ...grpc.ServerOption) *grpc.Server {
+	serverOpts := append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(
+			Logging(opts.Logger),
+			RateLimit(opts.RateLimitStore, "auth", opts.AuthRateLimit, opts.Clock, opts.ClientIP, publicMethods...),
+			Auth(opts.TokenValidator, publicMethods...),
+			Timeout(opts.Timeout),
+		),
+	}, extra...)
+
+	srv := grpc.NewServer(serverOpts...)
+
+	taskeryv1.RegisterTaskServiceServer(srv, &taskServer{
+		tasks:        opts.TaskService,
+		maxBatchSize: opts.MaxBatchSize,
+	})
+	taskeryv1.RegisterUserServiceServer(srv, &userServer{
+		users: opts.UserService,
+	})
+
+	return srv
+}
+
+// publicMethods are the methods that are called without a token, so they are rate limited per client IP.
+var publicMethods = []string{
+	taskeryv1.UserService_Register_FullMethodName,
+	taskeryv1.UserService_Login_FullMethodName,
+}
+
+// Multiplex returns a handler that serves the gRPC calls with srv and the other requests with next,
+// so that both APIs share a single port. The gRPC calls are recognized as HTTP/2 requests
+// with the application/grpc content type, so the HTTP server must accept HTTP/2,
+// i.e. unencrypted HTTP/2 (h2c) unless it serves TLS.
+func Multiplex(srv *grpc.Server, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+			srv.ServeHTTP(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,426 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/auth/jwt"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/ratelimit"
+	grpcTransport "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/grpc"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/grpc/mocks"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/grpc/taskeryv1"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testToken = "valid-token"
+
+type testServer struct {
+	tasks     *mocks.TaskService
+	users     *mocks.UserService
+	validator *mocks.TokenValidator
+
+	taskClient taskeryv1.TaskServiceClient
+	userClient taskeryv1.UserServiceClient
+}
+
+// newTestServer serves the gRPC API with mocked services over an in-memory connection.
+// The options are adjusted by configure, if any, before the server is created.
+func newTestServer(t *testing.T, maxBatchSize int, configure ...func(*grpcTransport.ServerOptions)) *testServer {
+	ts := &testServer{
+		tasks:     mocks.NewTaskService(t),
+		users:     mocks.NewUserService(t),
+		validator: mocks.NewTokenValidator(t),
+	}
+
+	opts := grpcTransport.ServerOptions{
+		UserService:    ts.users,
+		TaskService:    ts.tasks,
+		TokenValidator: ts.validator,
+		Logger:         slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Timeout:        time.Second,
+		MaxBatchSize:   maxBatchSize,
+	}
+	for _, c := range configure {
+		c(&opts)
+	}
+
+	srv := grpcTransport.NewServer(opts)
+
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	ts.taskClient = taskeryv1.NewTaskServiceClient(conn)
+	ts.userClient = taskeryv1.NewUserServiceClient(conn)
+
+	return ts
+}
+
+// authorized returns the context of a call with the test token,
+// which is validated as the token of the user.
+func (ts *testServer) authorized(userID string) context.Context {
+	ts.validator.On("Validate", testToken).Return(userID, nil)
+
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+testToken)
+}
+
+// requireStatus asserts that err is the status with the code and the reason in its details.
+func requireStatus(t *testing.T, err error, code codes.Code, reason string) {
+	t.Helper()
+
+	st, ok := status.FromError(err)
+	require.True(t, ok, "not a status: %v", err)
+	require.Equal(t, code, st.Code())
+
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			require.Equal(t, reason, info.GetReason())
+			require.Equal(t, grpcTransport.ErrorDomain, info.GetDomain())
+			return
+		}
+	}
+
+	t.Fatalf("status %v has no error info", st)
+}
+
+func TestServer_Auth(t *testing.T) {
+	tests := []struct {
+		name           string
+		authorization  string
+		validateErr    error
+		expectedCode   codes.Code
+		expectedReason string
+	}{
+		{
+			name:           "missing token",
+			expectedCode:   codes.Unauthenticated,
+			expectedReason: "TOKEN_MISSING",
+		},
+		{
+			name:           "wrong scheme",
+			authorization:  "Basic " + testToken,
+			expectedCode:   codes.Unauthenticated,
+			expectedReason: "TOKEN_MISSING",
+		},
+		{
+			name:           "expired token",
+			authorization:  "Bearer " + testToken,
+			validateErr:    jwt.ErrTokenExpired,
+			expectedCode:   codes.Unauthenticated,
+			expectedReason: "TOKEN_EXPIRED",
+		},
+		{
+			name:           "invalid token",
+			authorization:  "Bearer " + testToken,
+			validateErr:    jwt.ErrTokenSignatureInvalid,
+			expectedCode:   codes.Unauthenticated,
+			expectedReason: "TOKEN_INVALID",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestServer(t, 10)
+
+			ctx := context.Background()
+			if tt.authorization != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", tt.authorization)
+			}
+			if tt.validateErr != nil {
+				ts.validator.On("Validate", testToken).Return("", tt.validateErr)
+			}
+
+			_, err := ts.taskClient.GetTask(ctx, &taskeryv1.GetTaskRequest{Id: gofakeit.UUID()})
+			requireStatus(t, err, tt.expectedCode, tt.expectedReason)
+		})
+	}
+
+	t.Run("public method", func(t *testing.T) {
+		ts := newTestServer(t, 10)
+
+		ts.users.On("Login", mock.Anything, "user@example.com", "password").Return("token", nil)
+
+		resp, err := ts.userClient.Login(context.Background(), &taskeryv1.LoginRequest{
+			Email:    "user@example.com",
+			Password: "password",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "token", resp.GetToken())
+	})
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	ts := newTestServer(t, 10, func(opts *grpcTransport.ServerOptions) {
+		opts.RateLimitStore = ratelimit.NewMemoryStore()
+		opts.AuthRateLimit = ratelimit.Limit{Requests: 2, Period: time.Minute}
+		opts.Clock = clock.NewFake(time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC))
+	})
+
+	ts.users.On("Login", mock.Anything, "user@example.com", "password").Return("token", nil).Twice()
+
+	login := func(header *metadata.MD) error {
+		_, err := ts.userClient.Login(context.Background(), &taskeryv1.LoginRequest{
+			Email:    "user@example.com",
+			Password: "password",
+		}, grpc.Header(header))
+		return err
+	}
+
+	for range 2 {
+		require.NoError(t, login(new(metadata.MD)))
+	}
+
+	var header metadata.MD
+	err := login(&header)
+	requireStatus(t, err, codes.ResourceExhausted, "RATE_LIMIT_EXCEEDED")
+	require.Equal(t, []string{"30"}, header.Get("retry-after"))
+
+	// the methods that require a token do not share the bucket of the public ones
+	ts.tasks.On("FindByID", mock.Anything, mock.Anything, "user-1").Return(nil, services.ErrTaskNotFound)
+
+	_, err = ts.taskClient.GetTask(ts.authorized("user-1"), &taskeryv1.GetTaskRequest{Id: gofakeit.UUID()})
+	requireStatus(t, err, codes.NotFound, "TASK_NOT_FOUND")
+}
+
+func TestServer_Errors(t *testing.T) {
+	userID := gofakeit.UUID()
+	taskID := gofakeit.UUID()
+
+	tests := []struct {
+		name            string
+		expectedVersion *int64
+		serviceErr      error
+		expectedCode    codes.Code
+		expectedReason  string
+		expectedMessage string
+	}{
+		{
+			name:            "not found",
+			serviceErr:      services.ErrTaskNotFound,
+			expectedCode:    codes.NotFound,
+			expectedReason:  "TASK_NOT_FOUND",
+			expectedMessage: "task not found",
+		},
+		{
+			name:            "access denied",
+			serviceErr:      services.ErrTaskAccessDenied,
+			expectedCode:    codes.PermissionDenied,
+			expectedReason:  "TASK_ACCESS_DENIED",
+			expectedMessage: "access denied",
+		},
+		{
+			name:            "conflict",
+			serviceErr:      services.ErrTaskConflict,
+			expectedCode:    codes.Aborted,
+			expectedReason:  "TASK_CONFLICT",
+			expectedMessage: "task was modified concurrently",
+		},
+		{
+			name:            "version mismatch",
+			expectedVersion: new(int64(3)),
+			serviceErr:      services.ErrTaskConflict,
+			expectedCode:    codes.FailedPrecondition,
+			expectedReason:  "TASK_VERSION_MISMATCH",
+			expectedMessage: "task version mismatch",
+		},
+		{
+			name:            "internal error",
+			serviceErr:      errors.Join(services.ErrTaskCompleteFailed, errors.New("connection refused")),
+			expectedCode:    codes.Internal,
+			expectedReason:  "INTERNAL_ERROR",
+			expectedMessage: "internal server error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestServer(t, 10)
+
+			ts.tasks.On("Complete", mock.Anything, taskID, userID, tt.expectedVersion).Return(int64(0), tt.serviceErr)
+
+			_, err := ts.taskClient.CompleteTask(ts.authorized(userID), &taskeryv1.TaskRequest{
+				Id:              taskID,
+				ExpectedVersion: tt.expectedVersion,
+			})
+			requireStatus(t, err, tt.expectedCode, tt.expectedReason)
+			require.Equal(t, tt.expectedMessage, status.Convert(err).Message())
+		})
+	}
+
+	t.Run("missing id", func(t *testing.T) {
+		ts := newTestServer(t, 10)
+
+		_, err := ts.taskClient.CompleteTask(ts.authorized(userID), &taskeryv1.TaskRequest{})
+		requireStatus(t, err, codes.InvalidArgument, "MISSING_PARAMETER")
+	})
+}
+
+func TestServer_CompleteTask(t *testing.T) {
+	ts := newTestServer(t, 10)
+
+	userID := gofakeit.UUID()
+	taskID := gofakeit.UUID()
+
+	ts.tasks.On("Complete", mock.Anything, taskID, userID, new(int64(3))).Return(int64(4), nil)
+
+	var header metadata.MD
+	_, err := ts.taskClient.CompleteTask(ts.authorized(userID), &taskeryv1.TaskRequest{
+		Id:              taskID,
+		ExpectedVersion: new(int64(3)),
+	}, grpc.Header(&header))
+	require.NoError(t, err)
+	require.Equal(t, []string{"4"}, header.Get("version"))
+}
+
+func TestServer_GetTask(t *testing.T) {
+	ts := newTestServer(t, 10)
+
+	userID := uuid.New()
+	clk := clock.NewFake(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	task, err := models.NewTask("Write report", "Quarterly", userID, clk)
+	require.NoError(t, err)
+
+	ts.tasks.On("FindByID", mock.Anything, task.ID().String(), userID.String()).Return(task, nil)
+
+	resp, err := ts.taskClient.GetTask(ts.authorized(userID.String()), &taskeryv1.GetTaskRequest{
+		Id: task.ID().String(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, task.ID().String(), resp.GetId())
+	require.Equal(t, "Write report", resp.GetTitle())
+	require.Equal(t, "Quarterly", resp.GetDescription())
+	require.Nil(t, resp.GetDeadline())
+	require.Equal(t, clk.Now(), resp.GetCreatedAt().AsTime())
+	require.Equal(t, int64(1), resp.GetVersion())
+}
+
+func TestServer_BatchTasks(t *testing.T) {
+	userID := gofakeit.UUID()
+	firstID := gofakeit.UUID()
+	secondID := gofakeit.UUID()
+
+	t.Run("results", func(t *testing.T) {
+		ts := newTestServer(t, 10)
+
+		ops := []services.BatchOperation{
+			{Type: services.BatchOperationComplete, TaskID: firstID},
+			{Type: services.BatchOperationDelete, TaskID: secondID, ExpectedVersion: new(int64(2))},
+		}
+		ts.tasks.On("Batch", mock.Anything, userID, ops, true).
+			Return([]error{services.ErrTaskBatchAborted, services.ErrTaskConflict}, services.ErrTaskBatchAborted)
+
+		resp, err := ts.taskClient.BatchTasks(ts.authorized(userID), &taskeryv1.BatchTasksRequest{
+			Atomic: true,
+			Operations: []*taskeryv1.BatchOperation{
+				{Op: taskeryv1.TaskOperation_TASK_OPERATION_COMPLETE, TaskId: firstID},
+				{Op: taskeryv1.TaskOperation_TASK_OPERATION_DELETE, TaskId: secondID, ExpectedVersion: new(int64(2))},
+			},
+		})
+		require.NoError(t, err)
+		require.True(t, resp.GetAtomic())
+		require.Len(t, resp.GetResults(), 2)
+
+		require.Equal(t, firstID, resp.GetResults()[0].GetTaskId())
+		require.Equal(t, int32(codes.Aborted), resp.GetResults()[0].GetError().GetCode())
+		require.Equal(t, "BATCH_OPERATION_NOT_APPLIED", resp.GetResults()[0].GetError().GetReason())
+
+		require.Equal(t, int32(1), resp.GetResults()[1].GetIndex())
+		require.Equal(t, int32(codes.FailedPrecondition), resp.GetResults()[1].GetError().GetCode())
+		require.Equal(t, "TASK_VERSION_MISMATCH", resp.GetResults()[1].GetError().GetReason())
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		ts := newTestServer(t, 1)
+
+		_, err := ts.taskClient.BatchTasks(ts.authorized(userID), &taskeryv1.BatchTasksRequest{
+			Operations: []*taskeryv1.BatchOperation{
+				{Op: taskeryv1.TaskOperation_TASK_OPERATION_COMPLETE, TaskId: firstID},
+				{Op: taskeryv1.TaskOperation_TASK_OPERATION_REOPEN, TaskId: secondID},
+			},
+		})
+		requireStatus(t, err, codes.InvalidArgument, "BATCH_TOO_LARGE")
+	})
+}
+
+func TestServer_GetChanges(t *testing.T) {
+	userID := gofakeit.UUID()
+
+	t.Run("from token", func(t *testing.T) {
+		ts := newTestServer(t, 10)
+
+		// the token of the REST API for the sequence number 7
+		ts.tasks.On("ChangesSince", mock.Anything, userID, int64(7), 50).Return(&services.TaskSyncPage{
+			Created: []string{"a"},
+			Updated: []string{},
+			Deleted: []string{"b"},
+			Seq:     9,
+			HasMore: true,
+		}, nil)
+
+		resp, err := ts.taskClient.GetChanges(ts.authorized(userID), &taskeryv1.GetChangesRequest{
+			Since: "Nw",
+			Limit: 50,
+		})
+		require.NoError(t, err)
+		require.Equal(t, "OQ", resp.GetToken())
+		require.True(t, resp.GetHasMore())
+		require.Equal(t, []string{"a"}, resp.GetCreated())
+		require.Empty(t, resp.GetUpdated())
+		require.Equal(t, []string{"b"}, resp.GetDeleted())
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		ts := newTestServer(t, 10)
+
+		_, err := ts.taskClient.GetChanges(ts.authorized(userID), &taskeryv1.GetChangesRequest{Since: "!"})
+		requireStatus(t, err, codes.InvalidArgument, "INVALID_PARAMETER")
+	})
+}
+
+func TestMultiplex(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	handler := grpcTransport.Multiplex(grpc.NewServer(), next)
+
+	r := httptest.NewRequest(http.MethodPost, "/taskery.v1.TaskService/GetTask", nil)
+	r.Header.Set("Content-Type", "application/grpc")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	// an HTTP/1.1 request is never a gRPC call
+	require.Equal(t, http.StatusTeapot, w.Code)
+}
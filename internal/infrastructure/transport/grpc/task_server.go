@@ -0,0 +1,426 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/grpc/taskeryv1"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// versionHeader is the response header with the version of a changed task.
+const versionHeader = "version"
+
+// taskServer implements taskeryv1.TaskServiceServer on top of TaskService.
+// The errors it returns are converted to the statuses by the Logging interceptor.
+type taskServer struct {
+	taskeryv1.UnimplementedTaskServiceServer
+
+	tasks        TaskService
+	maxBatchSize int
+}
+
+func (s *taskServer) CreateTask(
+	ctx context.Context,
+	req *taskeryv1.CreateTaskRequest,
+) (*taskeryv1.CreateTaskResponse, error) {
+	ownerID, err := uuid.Parse(GetUserID(ctx))
+	if err != nil {
+		return nil, ErrUnauthenticated
+	}
+
+	deadline, err := parseTimestamp("deadline", req.GetDeadline())
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := s.tasks.Create(ctx, services.CreateTaskCommand{
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+		OwnerID:     ownerID,
+		Deadline:    deadline,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &taskeryv1.CreateTaskResponse{TaskId: id}, nil
+}
+
+func (s *taskServer) UpdateTask(ctx context.Context, req *taskeryv1.UpdateTaskRequest) (*emptypb.Empty, error) {
+	if req.GetId() == "" {
+		return nil, MissingParameter("id")
+	}
+
+	deadline, err := parseTimestamp("deadline", req.GetDeadline())
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := services.UpdateTaskCommand{
+		Title:       req.Title,
+		Description: req.Description,
+		Deadline:    deadline,
+	}
+
+	version, err := s.tasks.Update(ctx, req.GetId(), GetUserID(ctx), cmd, req.ExpectedVersion)
+	return changed(ctx, version, PreconditionError(err, req.ExpectedVersion))
+}
+
+func (s *taskServer) RemoveDeadline(ctx context.Context, req *taskeryv1.TaskRequest) (*emptypb.Empty, error) {
+	return s.change(ctx, req, s.tasks.RemoveDeadline)
+}
+
+func (s *taskServer) CompleteTask(ctx context.Context, req *taskeryv1.TaskRequest) (*emptypb.Empty, error) {
+	return s.change(ctx, req, s.tasks.Complete)
+}
+
+func (s *taskServer) ReopenTask(ctx context.Context, req *taskeryv1.TaskRequest) (*emptypb.Empty, error) {
+	return s.change(ctx, req, s.tasks.Reopen)
+}
+
+func (s *taskServer) GetTask(ctx context.Context, req *taskeryv1.GetTaskRequest) (*taskeryv1.Task, error) {
+	if req.GetId() == "" {
+		return nil, MissingParameter("id")
+	}
+
+	task, err := s.tasks.FindByID(ctx, req.GetId(), GetUserID(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return newTask(task), nil
+}
+
+func (s *taskServer) ListTasks(
+	ctx context.Context,
+	req *taskeryv1.ListTasksRequest,
+) (*taskeryv1.ListTasksResponse, error) {
+	sort, err := taskSort(req.GetSort())
+	if err != nil {
+		return nil, err
+	}
+
+	updatedSince, err := parseTimestamp("updated_since", req.GetUpdatedSince())
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.tasks.FindByOwner(ctx, GetUserID(ctx), services.FindByOwnerQuery{
+		Sort:            sort,
+		UpdatedSince:    updatedSince,
+		IncludeArchived: req.GetIncludeArchived(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &taskeryv1.ListTasksResponse{Tasks: newTasks(tasks)}, nil
+}
+
+func (s *taskServer) DeleteTask(ctx context.Context, req *taskeryv1.TaskRequest) (*emptypb.Empty, error) {
+	return s.change(ctx, req, s.tasks.Delete)
+}
+
+func (s *taskServer) DeleteTaskPermanently(ctx context.Context, req *taskeryv1.TaskRequest) (*emptypb.Empty, error) {
+	if req.GetId() == "" {
+		return nil, MissingParameter("id")
+	}
+
+	err := s.tasks.DeletePermanently(ctx, req.GetId(), GetUserID(ctx), req.ExpectedVersion)
+	return empty(PreconditionError(err, req.ExpectedVersion))
+}
+
+func (s *taskServer) RestoreTask(ctx context.Context, req *taskeryv1.TaskRequest) (*emptypb.Empty, error) {
+	return s.change(ctx, req, s.tasks.Restore)
+}
+
+func (s *taskServer) ListTrash(ctx context.Context, _ *emptypb.Empty) (*taskeryv1.ListTasksResponse, error) {
+	tasks, err := s.tasks.FindTrash(ctx, GetUserID(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &taskeryv1.ListTasksResponse{Tasks: newTasks(tasks)}, nil
+}
+
+func (s *taskServer) SearchTasks(
+	ctx context.Context,
+	req *taskeryv1.SearchTasksRequest,
+) (*taskeryv1.SearchTasksResponse, error) {
+	results, err := s.tasks.Search(ctx, GetUserID(ctx), services.SearchTasksQuery{
+		Text:            req.GetQuery(),
+		Limit:           int(req.GetLimit()),
+		IncludeArchived: req.GetIncludeArchived(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := make([]*taskeryv1.SearchResult, len(results))
+	for i, result := range results {
+		msgs[i] = &taskeryv1.SearchResult{
+			Task:               newTask(result.Task),
+			Rank:               result.Rank,
+			TitleSnippet:       result.TitleSnippet,
+			DescriptionSnippet: result.DescriptionSnippet,
+		}
+	}
+
+	return &taskeryv1.SearchTasksResponse{Results: msgs}, nil
+}
+
+func (s *taskServer) ArchiveTask(ctx context.Context, req *taskeryv1.ArchiveTaskRequest) (*emptypb.Empty, error) {
+	if req.GetId() == "" {
+		return nil, MissingParameter("id")
+	}
+
+	version, err := s.tasks.Archive(ctx, req.GetId(), GetUserID(ctx), req.GetForce(), req.ExpectedVersion)
+	return changed(ctx, version, PreconditionError(err, req.ExpectedVersion))
+}
+
+func (s *taskServer) UnarchiveTask(ctx context.Context, req *taskeryv1.TaskRequest) (*emptypb.Empty, error) {
+	return s.change(ctx, req, s.tasks.Unarchive)
+}
+
+func (s *taskServer) ArchiveCompleted(
+	ctx context.Context,
+	req *taskeryv1.ArchiveCompletedRequest,
+) (*taskeryv1.ArchiveCompletedResponse, error) {
+	if req.GetOlderThan() == nil {
+		return nil, MissingParameter("older_than")
+	}
+
+	if err := req.GetOlderThan().CheckValid(); err != nil || req.GetOlderThan().AsDuration() < 0 {
+		return nil, InvalidParameter("older_than")
+	}
+
+	archived, err := s.tasks.ArchiveCompleted(ctx, GetUserID(ctx), req.GetOlderThan().AsDuration())
+	if err != nil {
+		return nil, err
+	}
+
+	return &taskeryv1.ArchiveCompletedResponse{Archived: archived}, nil
+}
+
+func (s *taskServer) GetTaskHistory(
+	ctx context.Context,
+	req *taskeryv1.GetTaskRequest,
+) (*taskeryv1.GetTaskHistoryResponse, error) {
+	if req.GetId() == "" {
+		return nil, MissingParameter("id")
+	}
+
+	events, err := s.tasks.History(ctx, req.GetId(), GetUserID(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := make([]*taskeryv1.TaskEvent, len(events))
+	for i, event := range events {
+		msgs[i], err = newTaskEvent(event)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert task event: %w", err)
+		}
+	}
+
+	return &taskeryv1.GetTaskHistoryResponse{Events: msgs}, nil
+}
+
+func (s *taskServer) RevertTask(ctx context.Context, req *taskeryv1.RevertTaskRequest) (*emptypb.Empty, error) {
+	if req.GetId() == "" {
+		return nil, MissingParameter("id")
+	}
+
+	if req.GetEventId() == "" {
+		return nil, MissingParameter("event_id")
+	}
+
+	version, err := s.tasks.Revert(ctx, req.GetId(), GetUserID(ctx), req.GetEventId(), req.ExpectedVersion)
+	return changed(ctx, version, PreconditionError(err, req.ExpectedVersion))
+}
+
+func (s *taskServer) BatchTasks(
+	ctx context.Context,
+	req *taskeryv1.BatchTasksRequest,
+) (*taskeryv1.BatchTasksResponse, error) {
+	if len(req.GetOperations()) > s.maxBatchSize {
+		return nil, NewError(
+			codes.InvalidArgument,
+			"BATCH_TOO_LARGE",
+			fmt.Sprintf("batch must not contain more than %d operations", s.maxBatchSize),
+		)
+	}
+
+	ops := make([]services.BatchOperation, len(req.GetOperations()))
+	for i, o := range req.GetOperations() {
+		deadline, err := parseTimestamp(fmt.Sprintf("operations[%d].deadline", i), o.GetDeadline())
+		if err != nil {
+			return nil, err
+		}
+
+		ops[i] = services.BatchOperation{
+			Type:   operationTypes[o.GetOp()],
+			TaskID: o.GetTaskId(),
+			Update: services.UpdateTaskCommand{
+				Title:       o.Title,
+				Description: o.Description,
+				Deadline:    deadline,
+			},
+			ExpectedVersion: o.ExpectedVersion,
+		}
+	}
+
+	errs, err := s.tasks.Batch(ctx, GetUserID(ctx), ops, req.GetAtomic())
+	if err != nil && !errors.Is(err, services.ErrTaskBatchAborted) {
+		return nil, err
+	}
+
+	logger := slogx.FromContext(ctx, slog.Default())
+
+	results := make([]*taskeryv1.OperationResult, len(ops))
+	for i, opErr := range errs {
+		if opErr != nil && isInternal(opErr) {
+			logger.Error("failed to apply batch operation", slog.Int("index", i), slog.String("err", opErr.Error()))
+		}
+
+		results[i] = &taskeryv1.OperationResult{
+			Index:  int32(i),
+			TaskId: ops[i].TaskID,
+			Error:  newOperationError(opErr, ops[i].ExpectedVersion),
+		}
+	}
+
+	return &taskeryv1.BatchTasksResponse{Atomic: req.GetAtomic(), Results: results}, nil
+}
+
+func (s *taskServer) GetChanges(
+	ctx context.Context,
+	req *taskeryv1.GetChangesRequest,
+) (*taskeryv1.GetChangesResponse, error) {
+	since, err := decodeSyncToken(req.GetSince())
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.tasks.ChangesSince(ctx, GetUserID(ctx), since, int(req.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &taskeryv1.GetChangesResponse{
+		Token:   encodeSyncToken(page.Seq),
+		HasMore: page.HasMore,
+		Created: page.Created,
+		Updated: page.Updated,
+		Deleted: page.Deleted,
+	}, nil
+}
+
+func (s *taskServer) PushChanges(
+	ctx context.Context,
+	req *taskeryv1.PushChangesRequest,
+) (*taskeryv1.PushChangesResponse, error) {
+	if len(req.GetChanges()) > s.maxBatchSize {
+		return nil, NewError(
+			codes.InvalidArgument,
+			"SYNC_TOO_LARGE",
+			fmt.Sprintf("sync must not push more than %d changes", s.maxBatchSize),
+		)
+	}
+
+	ops := make([]services.SyncOperation, len(req.GetChanges()))
+	for i, c := range req.GetChanges() {
+		deadline, err := parseTimestamp(fmt.Sprintf("changes[%d].deadline", i), c.GetDeadline())
+		if err != nil {
+			return nil, err
+		}
+
+		ops[i] = services.SyncOperation{
+			BatchOperation: services.BatchOperation{
+				Type:   operationTypes[c.GetOp()],
+				TaskID: c.GetTaskId(),
+				Update: services.UpdateTaskCommand{
+					Title:       c.Title,
+					Description: c.Description,
+					Deadline:    deadline,
+				},
+				ExpectedVersion: c.ExpectedVersion,
+			},
+			Create: services.CreateTaskCommand{
+				Title:       c.GetTitle(),
+				Description: c.GetDescription(),
+				Deadline:    deadline,
+			},
+		}
+	}
+
+	logger := slogx.FromContext(ctx, slog.Default())
+
+	results := make([]*taskeryv1.SyncResult, len(ops))
+	for i, result := range s.tasks.Push(ctx, GetUserID(ctx), ops) {
+		if result.Err != nil && isInternal(result.Err) {
+			logger.Error("failed to push change", slog.Int("index", i), slog.String("err", result.Err.Error()))
+		}
+
+		results[i] = &taskeryv1.SyncResult{
+			Index:    int32(i),
+			ClientId: req.GetChanges()[i].GetClientId(),
+			TaskId:   result.TaskID,
+			Error:    newOperationError(result.Err, ops[i].ExpectedVersion),
+		}
+
+		if result.Task != nil {
+			results[i].Task = newTask(result.Task)
+		}
+	}
+
+	return &taskeryv1.PushChangesResponse{Results: results}, nil
+}
+
+// change applies the single-task operation to the task of the request.
+func (s *taskServer) change(
+	ctx context.Context,
+	req *taskeryv1.TaskRequest,
+	apply func(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error),
+) (*emptypb.Empty, error) {
+	if req.GetId() == "" {
+		return nil, MissingParameter("id")
+	}
+
+	version, err := apply(ctx, req.GetId(), GetUserID(ctx), req.ExpectedVersion)
+	return changed(ctx, version, PreconditionError(err, req.ExpectedVersion))
+}
+
+// changed returns the empty response of a successful change of a task or err.
+// The new version of the task is sent in the "version" header,
+// so a client can pass it as the expected version of its next change.
+func changed(ctx context.Context, version int64, err error) (*emptypb.Empty, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	if err := grpc.SetHeader(ctx, metadata.Pairs(versionHeader, strconv.FormatInt(version, 10))); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// empty returns the empty response of a successful call or err.
+func empty(err error) (*emptypb.Empty, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
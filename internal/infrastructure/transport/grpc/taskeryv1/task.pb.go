@@ -0,0 +1,2253 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.3
+// source: taskery/v1/task.proto
+
+package taskeryv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TaskSort int32
+
+const (
+	TaskSort_TASK_SORT_UNSPECIFIED     TaskSort = 0
+	TaskSort_TASK_SORT_CREATED_AT      TaskSort = 1
+	TaskSort_TASK_SORT_CREATED_AT_DESC TaskSort = 2
+)
+
+// Enum value maps for TaskSort.
+var (
+	TaskSort_name = map[int32]string{
+		0: "TASK_SORT_UNSPECIFIED",
+		1: "TASK_SORT_CREATED_AT",
+		2: "TASK_SORT_CREATED_AT_DESC",
+	}
+	TaskSort_value = map[string]int32{
+		"TASK_SORT_UNSPECIFIED":     0,
+		"TASK_SORT_CREATED_AT":      1,
+		"TASK_SORT_CREATED_AT_DESC": 2,
+	}
+)
+
+func (x TaskSort) Enum() *TaskSort {
+	p := new(TaskSort)
+	*p = x
+	return p
+}
+
+func (x TaskSort) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskSort) Descriptor() protoreflect.EnumDescriptor {
+	return file_taskery_v1_task_proto_enumTypes[0].Descriptor()
+}
+
+func (TaskSort) Type() protoreflect.EnumType {
+	return &file_taskery_v1_task_proto_enumTypes[0]
+}
+
+func (x TaskSort) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskSort.Descriptor instead.
+func (TaskSort) EnumDescriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{0}
+}
+
+// TaskOperation is the change made by a batch operation or a pushed change.
+// TASK_OPERATION_CREATE is supported only by PushChanges.
+type TaskOperation int32
+
+const (
+	TaskOperation_TASK_OPERATION_UNSPECIFIED     TaskOperation = 0
+	TaskOperation_TASK_OPERATION_CREATE          TaskOperation = 1
+	TaskOperation_TASK_OPERATION_UPDATE          TaskOperation = 2
+	TaskOperation_TASK_OPERATION_REMOVE_DEADLINE TaskOperation = 3
+	TaskOperation_TASK_OPERATION_COMPLETE        TaskOperation = 4
+	TaskOperation_TASK_OPERATION_REOPEN          TaskOperation = 5
+	TaskOperation_TASK_OPERATION_DELETE          TaskOperation = 6
+)
+
+// Enum value maps for TaskOperation.
+var (
+	TaskOperation_name = map[int32]string{
+		0: "TASK_OPERATION_UNSPECIFIED",
+		1: "TASK_OPERATION_CREATE",
+		2: "TASK_OPERATION_UPDATE",
+		3: "TASK_OPERATION_REMOVE_DEADLINE",
+		4: "TASK_OPERATION_COMPLETE",
+		5: "TASK_OPERATION_REOPEN",
+		6: "TASK_OPERATION_DELETE",
+	}
+	TaskOperation_value = map[string]int32{
+		"TASK_OPERATION_UNSPECIFIED":     0,
+		"TASK_OPERATION_CREATE":          1,
+		"TASK_OPERATION_UPDATE":          2,
+		"TASK_OPERATION_REMOVE_DEADLINE": 3,
+		"TASK_OPERATION_COMPLETE":        4,
+		"TASK_OPERATION_REOPEN":          5,
+		"TASK_OPERATION_DELETE":          6,
+	}
+)
+
+func (x TaskOperation) Enum() *TaskOperation {
+	p := new(TaskOperation)
+	*p = x
+	return p
+}
+
+func (x TaskOperation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskOperation) Descriptor() protoreflect.EnumDescriptor {
+	return file_taskery_v1_task_proto_enumTypes[1].Descriptor()
+}
+
+func (TaskOperation) Type() protoreflect.EnumType {
+	return &file_taskery_v1_task_proto_enumTypes[1]
+}
+
+func (x TaskOperation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskOperation.Descriptor instead.
+func (TaskOperation) EnumDescriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{1}
+}
+
+type Task struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Deadline      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	IsCompleted   bool                   `protobuf:"varint,5,opt,name=is_completed,json=isCompleted,proto3" json:"is_completed,omitempty"`
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ArchivedAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=archived_at,json=archivedAt,proto3" json:"archived_at,omitempty"`
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	Version       int64                  `protobuf:"varint,11,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Task) Reset() {
+	*x = Task{}
+	mi := &file_taskery_v1_task_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Task) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task) ProtoMessage() {}
+
+func (x *Task) ProtoReflect() protoreflect.Message {
+	mi := &file_taskery_v1_task_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Task.ProtoReflect.Descriptor instead.
+func (*Task) Descriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Task) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Task) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Task) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Task) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *Task) GetIsCompleted() bool {
+	if x != nil {
+		return x.IsCompleted
+	}
+	return false
+}
+
+func (x *Task) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *Task) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Task) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Task) GetArchivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArchivedAt
+	}
+	return nil
+}
+
+func (x *Task) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *Task) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// TaskRequest identifies the task of an operation.
+// If expected_version is set, the operation is applied only to that version of the task.
+type TaskRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion *int64                 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TaskRequest) Reset() {
+	*x = TaskRequest{}
+	mi := &file_taskery_v1_task_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskRequest) ProtoMessage() {}
+
+func (x *TaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskery_v1_task_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskRequest.ProtoReflect.Descriptor instead.
+func (*TaskRequest) Descriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TaskRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type GetTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskRequest) Reset() {
+	*x = GetTaskRequest{}
+	mi := &file_taskery_v1_task_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskRequest) ProtoMessage() {}
+
+func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskery_v1_task_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskRequest) Descriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CreateTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Deadline      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTaskRequest) Reset() {
+	*x = CreateTaskRequest{}
+	mi := &file_taskery_v1_task_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaskRequest) ProtoMessage() {}
+
+func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskery_v1_task_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaskRequest.ProtoReflect.Descriptor instead.
+func (*CreateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateTaskRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+type CreateTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTaskResponse) Reset() {
+	*x = CreateTaskResponse{}
+	mi := &file_taskery_v1_task_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaskResponse) ProtoMessage() {}
+
+func (x *CreateTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_taskery_v1_task_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaskResponse.ProtoReflect.Descriptor instead.
+func (*CreateTaskResponse) Descriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateTaskResponse) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+// UpdateTaskRequest changes only the fields that are set.
+type UpdateTaskRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title           *string                `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Description     *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Deadline        *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	ExpectedVersion *int64                 `protobuf:"varint,5,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateTaskRequest) Reset() {
+	*x = UpdateTaskRequest{}
+	mi := &file_taskery_v1_task_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskRequest) ProtoMessage() {}
+
+func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskery_v1_task_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type ListTasksRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Sort            TaskSort               `protobuf:"varint,1,opt,name=sort,proto3,enum=taskery.v1.TaskSort" json:"sort,omitempty"`
+	UpdatedSince    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=updated_since,json=updatedSince,proto3" json:"updated_since,omitempty"`
+	IncludeArchived bool                   `protobuf:"varint,3,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListTasksRequest) Reset() {
+	*x = ListTasksRequest{}
+	mi := &file_taskery_v1_task_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksRequest) ProtoMessage() {}
+
+func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskery_v1_task_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksRequest) Descriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListTasksRequest) GetSort() TaskSort {
+	if x != nil {
+		return x.Sort
+	}
+	return TaskSort_TASK_SORT_UNSPECIFIED
+}
+
+func (x *ListTasksRequest) GetUpdatedSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedSince
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+type ListTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksResponse) Reset() {
+	*x = ListTasksResponse{}
+	mi := &file_taskery_v1_task_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksResponse) ProtoMessage() {}
+
+func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_taskery_v1_task_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListTasksResponse) Descriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListTasksResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+type SearchTasksRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Query           string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Limit           int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	IncludeArchived bool                   `protobuf:"varint,3,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SearchTasksRequest) Reset() {
+	*x = SearchTasksRequest{}
+	mi := &file_taskery_v1_task_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchTasksRequest) ProtoMessage() {}
+
+func (x *SearchTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskery_v1_task_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchTasksRequest.ProtoReflect.Descriptor instead.
+func (*SearchTasksRequest) Descriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SearchTasksRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchTasksRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchTasksRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+type SearchResult struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Task               *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	Rank               float64                `protobuf:"fixed64,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	TitleSnippet       string                 `protobuf:"bytes,3,opt,name=title_snippet,json=titleSnippet,proto3" json:"title_snippet,omitempty"`
+	DescriptionSnippet string                 `protobuf:"bytes,4,opt,name=description_snippet,json=descriptionSnippet,proto3" json:"description_snippet,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_taskery_v1_task_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_taskery_v1_task_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SearchResult) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *SearchResult) GetRank() float64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *SearchResult) GetTitleSnippet() string {
+	if x != nil {
+		return x.TitleSnippet
+	}
+	return ""
+}
+
+func (x *SearchResult) GetDescriptionSnippet() string {
+	if x != nil {
+		return x.DescriptionSnippet
+	}
+	return ""
+}
+
+type SearchTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*SearchResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchTasksResponse) Reset() {
+	*x = SearchTasksResponse{}
+	mi := &file_taskery_v1_task_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchTasksResponse) ProtoMessage() {}
+
+func (x *SearchTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_taskery_v1_task_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchTasksResponse.ProtoReflect.Descriptor instead.
+func (*SearchTasksResponse) Descriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SearchTasksResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type ArchiveTaskRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Force           bool                   `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	ExpectedVersion *int64                 `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ArchiveTaskRequest) Reset() {
+	*x = ArchiveTaskRequest{}
+	mi := &file_taskery_v1_task_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchiveTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveTaskRequest) ProtoMessage() {}
+
+func (x *ArchiveTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskery_v1_task_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveTaskRequest.ProtoReflect.Descriptor instead.
+func (*ArchiveTaskRequest) Descriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ArchiveTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ArchiveTaskRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *ArchiveTaskRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type ArchiveCompletedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OlderThan     *durationpb.Duration   `protobuf:"bytes,1,opt,name=older_than,json=olderThan,proto3" json:"older_than,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArchiveCompletedRequest) Reset() {
+	*x = ArchiveCompletedRequest{}
+	mi := &file_taskery_v1_task_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchiveCompletedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveCompletedRequest) ProtoMessage() {}
+
+func (x *ArchiveCompletedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskery_v1_task_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveCompletedRequest.ProtoReflect.Descriptor instead.
+func (*ArchiveCompletedRequest) Descriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ArchiveCompletedRequest) GetOlderThan() *durationpb.Duration {
+	if x != nil {
+		return x.OlderThan
+	}
+	return nil
+}
+
+type ArchiveCompletedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Archived      int64                  `protobuf:"varint,1,opt,name=archived,proto3" json:"archived,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArchiveCompletedResponse) Reset() {
+	*x = ArchiveCompletedResponse{}
+	mi := &file_taskery_v1_task_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchiveCompletedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveCompletedResponse) ProtoMessage() {}
+
+func (x *ArchiveCompletedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_taskery_v1_task_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveCompletedResponse.ProtoReflect.Descriptor instead.
+func (*ArchiveCompletedResponse) Descriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ArchiveCompletedResponse) GetArchived() int64 {
+	if x != nil {
+		return x.Archived
+	}
+	return 0
+}
+
+type FieldChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Before        *structpb.Value        `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"`
+	After         *structpb.Value        `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldChange) Reset() {
+	*x = FieldChange{}
+	mi := &file_taskery_v1_task_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldChange) ProtoMessage() {}
+
+func (x *FieldChange) ProtoReflect() protoreflect.Message {
+	mi := &file_taskery_v1_task_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldChange.ProtoReflect.Descriptor instead.
+func (*FieldChange) Descriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *FieldChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldChange) GetBefore() *structpb.Value {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *FieldChange) GetAfter() *structpb.Value {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+type TaskEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	ActorId       string                 `protobuf:"bytes,3,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	Changes       []*FieldChange         `protobuf:"bytes,5,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskEvent) Reset() {
+	*x = TaskEvent{}
+	mi := &file_taskery_v1_task_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskEvent) ProtoMessage() {}
+
+func (x *TaskEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_taskery_v1_task_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskEvent.ProtoReflect.Descriptor instead.
+func (*TaskEvent) Descriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TaskEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TaskEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TaskEvent) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *TaskEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *TaskEvent) GetChanges() []*FieldChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type GetTaskHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*TaskEvent           `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskHistoryResponse) Reset() {
+	*x = GetTaskHistoryResponse{}
+	mi := &file_taskery_v1_task_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskHistoryResponse) ProtoMessage() {}
+
+func (x *GetTaskHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_taskery_v1_task_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetTaskHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetTaskHistoryResponse) GetEvents() []*TaskEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type RevertTaskRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	EventId         string                 `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	ExpectedVersion *int64                 `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RevertTaskRequest) Reset() {
+	*x = RevertTaskRequest{}
+	mi := &file_taskery_v1_task_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevertTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevertTaskRequest) ProtoMessage() {}
+
+func (x *RevertTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskery_v1_task_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevertTaskRequest.ProtoReflect.Descriptor instead.
+func (*RevertTaskRequest) Descriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RevertTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RevertTaskRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *RevertTaskRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type BatchOperation struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Op              TaskOperation          `protobuf:"varint,1,opt,name=op,proto3,enum=taskery.v1.TaskOperation" json:"op,omitempty"`
+	TaskId          string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Title           *string                `protobuf:"bytes,3,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Description     *string                `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Deadline        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	ExpectedVersion *int64                 `protobuf:"varint,6,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BatchOperation) Reset() {
+	*x = BatchOperation{}
+	mi := &file_taskery_v1_task_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchOperation) ProtoMessage() {}
+
+func (x *BatchOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_taskery_v1_task_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchOperation.ProtoReflect.Descriptor instead.
+func (*BatchOperation) Descriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *BatchOperation) GetOp() TaskOperation {
+	if x != nil {
+		return x.Op
+	}
+	return TaskOperation_TASK_OPERATION_UNSPECIFIED
+}
+
+func (x *BatchOperation) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *BatchOperation) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *BatchOperation) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *BatchOperation) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *BatchOperation) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type BatchTasksRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Operations []*BatchOperation      `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	// atomic applies the operations all or none.
+	Atomic        bool `protobuf:"varint,2,opt,name=atomic,proto3" json:"atomic,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchTasksRequest) Reset() {
+	*x = BatchTasksRequest{}
+	mi := &file_taskery_v1_task_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchTasksRequest) ProtoMessage() {}
+
+func (x *BatchTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskery_v1_task_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchTasksRequest.ProtoReflect.Descriptor instead.
+func (*BatchTasksRequest) Descriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *BatchTasksRequest) GetOperations() []*BatchOperation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+func (x *BatchTasksRequest) GetAtomic() bool {
+	if x != nil {
+		return x.Atomic
+	}
+	return false
+}
+
+// OperationError is the error of a single operation with the gRPC status code
+// and the reason that the call would fail with.
+type OperationError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperationError) Reset() {
+	*x = OperationError{}
+	mi := &file_taskery_v1_task_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperationError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationError) ProtoMessage() {}
+
+func (x *OperationError) ProtoReflect() protoreflect.Message {
+	mi := &file_taskery_v1_task_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationError.ProtoReflect.Descriptor instead.
+func (*OperationError) Descriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *OperationError) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *OperationError) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OperationError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type OperationResult struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Index  int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	TaskId string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// error is not set for an applied operation.
+	Error         *OperationError `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperationResult) Reset() {
+	*x = OperationResult{}
+	mi := &file_taskery_v1_task_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationResult) ProtoMessage() {}
+
+func (x *OperationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_taskery_v1_task_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationResult.ProtoReflect.Descriptor instead.
+func (*OperationResult) Descriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *OperationResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *OperationResult) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *OperationResult) GetError() *OperationError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type BatchTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Atomic        bool                   `protobuf:"varint,1,opt,name=atomic,proto3" json:"atomic,omitempty"`
+	Results       []*OperationResult     `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchTasksResponse) Reset() {
+	*x = BatchTasksResponse{}
+	mi := &file_taskery_v1_task_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchTasksResponse) ProtoMessage() {}
+
+func (x *BatchTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_taskery_v1_task_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchTasksResponse.ProtoReflect.Descriptor instead.
+func (*BatchTasksResponse) Descriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *BatchTasksResponse) GetAtomic() bool {
+	if x != nil {
+		return x.Atomic
+	}
+	return false
+}
+
+func (x *BatchTasksResponse) GetResults() []*OperationResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type GetChangesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// since is the token returned by the previous sync; without it all tasks are returned as created.
+	Since         string `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"`
+	Limit         int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChangesRequest) Reset() {
+	*x = GetChangesRequest{}
+	mi := &file_taskery_v1_task_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChangesRequest) ProtoMessage() {}
+
+func (x *GetChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskery_v1_task_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChangesRequest.ProtoReflect.Descriptor instead.
+func (*GetChangesRequest) Descriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetChangesRequest) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *GetChangesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetChangesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	HasMore       bool                   `protobuf:"varint,2,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	Created       []string               `protobuf:"bytes,3,rep,name=created,proto3" json:"created,omitempty"`
+	Updated       []string               `protobuf:"bytes,4,rep,name=updated,proto3" json:"updated,omitempty"`
+	Deleted       []string               `protobuf:"bytes,5,rep,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChangesResponse) Reset() {
+	*x = GetChangesResponse{}
+	mi := &file_taskery_v1_task_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChangesResponse) ProtoMessage() {}
+
+func (x *GetChangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_taskery_v1_task_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChangesResponse.ProtoReflect.Descriptor instead.
+func (*GetChangesResponse) Descriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetChangesResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GetChangesResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+func (x *GetChangesResponse) GetCreated() []string {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *GetChangesResponse) GetUpdated() []string {
+	if x != nil {
+		return x.Updated
+	}
+	return nil
+}
+
+func (x *GetChangesResponse) GetDeleted() []string {
+	if x != nil {
+		return x.Deleted
+	}
+	return nil
+}
+
+// SyncChange is a change that the client made while offline.
+// A created task is identified by client_id, since it has no ID until it is pushed.
+type SyncChange struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Op              TaskOperation          `protobuf:"varint,1,opt,name=op,proto3,enum=taskery.v1.TaskOperation" json:"op,omitempty"`
+	ClientId        string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	TaskId          string                 `protobuf:"bytes,3,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Title           *string                `protobuf:"bytes,4,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Description     *string                `protobuf:"bytes,5,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Deadline        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	ExpectedVersion *int64                 `protobuf:"varint,7,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SyncChange) Reset() {
+	*x = SyncChange{}
+	mi := &file_taskery_v1_task_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncChange) ProtoMessage() {}
+
+func (x *SyncChange) ProtoReflect() protoreflect.Message {
+	mi := &file_taskery_v1_task_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncChange.ProtoReflect.Descriptor instead.
+func (*SyncChange) Descriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SyncChange) GetOp() TaskOperation {
+	if x != nil {
+		return x.Op
+	}
+	return TaskOperation_TASK_OPERATION_UNSPECIFIED
+}
+
+func (x *SyncChange) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *SyncChange) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *SyncChange) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *SyncChange) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *SyncChange) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *SyncChange) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type PushChangesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changes       []*SyncChange          `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushChangesRequest) Reset() {
+	*x = PushChangesRequest{}
+	mi := &file_taskery_v1_task_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushChangesRequest) ProtoMessage() {}
+
+func (x *PushChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskery_v1_task_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushChangesRequest.ProtoReflect.Descriptor instead.
+func (*PushChangesRequest) Descriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PushChangesRequest) GetChanges() []*SyncChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type SyncResult struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Index    int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	ClientId string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	TaskId   string                 `protobuf:"bytes,3,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Error    *OperationError        `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// task is the current state of the task after an applied change or a version conflict.
+	Task          *Task `protobuf:"bytes,5,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncResult) Reset() {
+	*x = SyncResult{}
+	mi := &file_taskery_v1_task_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncResult) ProtoMessage() {}
+
+func (x *SyncResult) ProtoReflect() protoreflect.Message {
+	mi := &file_taskery_v1_task_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncResult.ProtoReflect.Descriptor instead.
+func (*SyncResult) Descriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SyncResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *SyncResult) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *SyncResult) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *SyncResult) GetError() *OperationError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *SyncResult) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type PushChangesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*SyncResult          `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushChangesResponse) Reset() {
+	*x = PushChangesResponse{}
+	mi := &file_taskery_v1_task_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushChangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushChangesResponse) ProtoMessage() {}
+
+func (x *PushChangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_taskery_v1_task_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushChangesResponse.ProtoReflect.Descriptor instead.
+func (*PushChangesResponse) Descriptor() ([]byte, []int) {
+	return file_taskery_v1_task_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *PushChangesResponse) GetResults() []*SyncResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+var File_taskery_v1_task_proto protoreflect.FileDescriptor
+
+const file_taskery_v1_task_proto_rawDesc = "" +
+	"\n" +
+	"\x15taskery/v1/task.proto\x12\n" +
+	"taskery.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf0\x03\n" +
+	"\x04Task\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x126\n" +
+	"\bdeadline\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\x12!\n" +
+	"\fis_completed\x18\x05 \x01(\bR\visCompleted\x12=\n" +
+	"\fcompleted_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12;\n" +
+	"\varchived_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"archivedAt\x129\n" +
+	"\n" +
+	"deleted_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12\x18\n" +
+	"\aversion\x18\v \x01(\x03R\aversion\"b\n" +
+	"\vTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12.\n" +
+	"\x10expected_version\x18\x02 \x01(\x03H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\" \n" +
+	"\x0eGetTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x83\x01\n" +
+	"\x11CreateTaskRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x126\n" +
+	"\bdeadline\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\"-\n" +
+	"\x12CreateTaskResponse\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\"\xfc\x01\n" +
+	"\x11UpdateTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x126\n" +
+	"\bdeadline\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\x12.\n" +
+	"\x10expected_version\x18\x05 \x01(\x03H\x02R\x0fexpectedVersion\x88\x01\x01B\b\n" +
+	"\x06_titleB\x0e\n" +
+	"\f_descriptionB\x13\n" +
+	"\x11_expected_version\"\xa8\x01\n" +
+	"\x10ListTasksRequest\x12(\n" +
+	"\x04sort\x18\x01 \x01(\x0e2\x14.taskery.v1.TaskSortR\x04sort\x12?\n" +
+	"\rupdated_since\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\fupdatedSince\x12)\n" +
+	"\x10include_archived\x18\x03 \x01(\bR\x0fincludeArchived\";\n" +
+	"\x11ListTasksResponse\x12&\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x10.taskery.v1.TaskR\x05tasks\"k\n" +
+	"\x12SearchTasksRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12)\n" +
+	"\x10include_archived\x18\x03 \x01(\bR\x0fincludeArchived\"\x9e\x01\n" +
+	"\fSearchResult\x12$\n" +
+	"\x04task\x18\x01 \x01(\v2\x10.taskery.v1.TaskR\x04task\x12\x12\n" +
+	"\x04rank\x18\x02 \x01(\x01R\x04rank\x12#\n" +
+	"\rtitle_snippet\x18\x03 \x01(\tR\ftitleSnippet\x12/\n" +
+	"\x13description_snippet\x18\x04 \x01(\tR\x12descriptionSnippet\"I\n" +
+	"\x13SearchTasksResponse\x122\n" +
+	"\aresults\x18\x01 \x03(\v2\x18.taskery.v1.SearchResultR\aresults\"\x7f\n" +
+	"\x12ArchiveTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05force\x18\x02 \x01(\bR\x05force\x12.\n" +
+	"\x10expected_version\x18\x03 \x01(\x03H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"S\n" +
+	"\x17ArchiveCompletedRequest\x128\n" +
+	"\n" +
+	"older_than\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\tolderThan\"6\n" +
+	"\x18ArchiveCompletedResponse\x12\x1a\n" +
+	"\barchived\x18\x01 \x01(\x03R\barchived\"\x81\x01\n" +
+	"\vFieldChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12.\n" +
+	"\x06before\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\x06before\x12,\n" +
+	"\x05after\x18\x03 \x01(\v2\x16.google.protobuf.ValueR\x05after\"\xba\x01\n" +
+	"\tTaskEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x19\n" +
+	"\bactor_id\x18\x03 \x01(\tR\aactorId\x12;\n" +
+	"\voccurred_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x121\n" +
+	"\achanges\x18\x05 \x03(\v2\x17.taskery.v1.FieldChangeR\achanges\"G\n" +
+	"\x16GetTaskHistoryResponse\x12-\n" +
+	"\x06events\x18\x01 \x03(\v2\x15.taskery.v1.TaskEventR\x06events\"\x83\x01\n" +
+	"\x11RevertTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bevent_id\x18\x02 \x01(\tR\aeventId\x12.\n" +
+	"\x10expected_version\x18\x03 \x01(\x03H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"\xad\x02\n" +
+	"\x0eBatchOperation\x12)\n" +
+	"\x02op\x18\x01 \x01(\x0e2\x19.taskery.v1.TaskOperationR\x02op\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\tR\x06taskId\x12\x19\n" +
+	"\x05title\x18\x03 \x01(\tH\x00R\x05title\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x04 \x01(\tH\x01R\vdescription\x88\x01\x01\x126\n" +
+	"\bdeadline\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\x12.\n" +
+	"\x10expected_version\x18\x06 \x01(\x03H\x02R\x0fexpectedVersion\x88\x01\x01B\b\n" +
+	"\x06_titleB\x0e\n" +
+	"\f_descriptionB\x13\n" +
+	"\x11_expected_version\"g\n" +
+	"\x11BatchTasksRequest\x12:\n" +
+	"\n" +
+	"operations\x18\x01 \x03(\v2\x1a.taskery.v1.BatchOperationR\n" +
+	"operations\x12\x16\n" +
+	"\x06atomic\x18\x02 \x01(\bR\x06atomic\"V\n" +
+	"\x0eOperationError\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"r\n" +
+	"\x0fOperationResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\tR\x06taskId\x120\n" +
+	"\x05error\x18\x03 \x01(\v2\x1a.taskery.v1.OperationErrorR\x05error\"c\n" +
+	"\x12BatchTasksResponse\x12\x16\n" +
+	"\x06atomic\x18\x01 \x01(\bR\x06atomic\x125\n" +
+	"\aresults\x18\x02 \x03(\v2\x1b.taskery.v1.OperationResultR\aresults\"?\n" +
+	"\x11GetChangesRequest\x12\x14\n" +
+	"\x05since\x18\x01 \x01(\tR\x05since\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\x93\x01\n" +
+	"\x12GetChangesResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x19\n" +
+	"\bhas_more\x18\x02 \x01(\bR\ahasMore\x12\x18\n" +
+	"\acreated\x18\x03 \x03(\tR\acreated\x12\x18\n" +
+	"\aupdated\x18\x04 \x03(\tR\aupdated\x12\x18\n" +
+	"\adeleted\x18\x05 \x03(\tR\adeleted\"\xc6\x02\n" +
+	"\n" +
+	"SyncChange\x12)\n" +
+	"\x02op\x18\x01 \x01(\x0e2\x19.taskery.v1.TaskOperationR\x02op\x12\x1b\n" +
+	"\tclient_id\x18\x02 \x01(\tR\bclientId\x12\x17\n" +
+	"\atask_id\x18\x03 \x01(\tR\x06taskId\x12\x19\n" +
+	"\x05title\x18\x04 \x01(\tH\x00R\x05title\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x05 \x01(\tH\x01R\vdescription\x88\x01\x01\x126\n" +
+	"\bdeadline\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\x12.\n" +
+	"\x10expected_version\x18\a \x01(\x03H\x02R\x0fexpectedVersion\x88\x01\x01B\b\n" +
+	"\x06_titleB\x0e\n" +
+	"\f_descriptionB\x13\n" +
+	"\x11_expected_version\"F\n" +
+	"\x12PushChangesRequest\x120\n" +
+	"\achanges\x18\x01 \x03(\v2\x16.taskery.v1.SyncChangeR\achanges\"\xb0\x01\n" +
+	"\n" +
+	"SyncResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x1b\n" +
+	"\tclient_id\x18\x02 \x01(\tR\bclientId\x12\x17\n" +
+	"\atask_id\x18\x03 \x01(\tR\x06taskId\x120\n" +
+	"\x05error\x18\x04 \x01(\v2\x1a.taskery.v1.OperationErrorR\x05error\x12$\n" +
+	"\x04task\x18\x05 \x01(\v2\x10.taskery.v1.TaskR\x04task\"G\n" +
+	"\x13PushChangesResponse\x120\n" +
+	"\aresults\x18\x01 \x03(\v2\x16.taskery.v1.SyncResultR\aresults*^\n" +
+	"\bTaskSort\x12\x19\n" +
+	"\x15TASK_SORT_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14TASK_SORT_CREATED_AT\x10\x01\x12\x1d\n" +
+	"\x19TASK_SORT_CREATED_AT_DESC\x10\x02*\xdc\x01\n" +
+	"\rTaskOperation\x12\x1e\n" +
+	"\x1aTASK_OPERATION_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15TASK_OPERATION_CREATE\x10\x01\x12\x19\n" +
+	"\x15TASK_OPERATION_UPDATE\x10\x02\x12\"\n" +
+	"\x1eTASK_OPERATION_REMOVE_DEADLINE\x10\x03\x12\x1b\n" +
+	"\x17TASK_OPERATION_COMPLETE\x10\x04\x12\x19\n" +
+	"\x15TASK_OPERATION_REOPEN\x10\x05\x12\x19\n" +
+	"\x15TASK_OPERATION_DELETE\x10\x062\xab\v\n" +
+	"\vTaskService\x12K\n" +
+	"\n" +
+	"CreateTask\x12\x1d.taskery.v1.CreateTaskRequest\x1a\x1e.taskery.v1.CreateTaskResponse\x12C\n" +
+	"\n" +
+	"UpdateTask\x12\x1d.taskery.v1.UpdateTaskRequest\x1a\x16.google.protobuf.Empty\x12A\n" +
+	"\x0eRemoveDeadline\x12\x17.taskery.v1.TaskRequest\x1a\x16.google.protobuf.Empty\x12?\n" +
+	"\fCompleteTask\x12\x17.taskery.v1.TaskRequest\x1a\x16.google.protobuf.Empty\x12=\n" +
+	"\n" +
+	"ReopenTask\x12\x17.taskery.v1.TaskRequest\x1a\x16.google.protobuf.Empty\x127\n" +
+	"\aGetTask\x12\x1a.taskery.v1.GetTaskRequest\x1a\x10.taskery.v1.Task\x12H\n" +
+	"\tListTasks\x12\x1c.taskery.v1.ListTasksRequest\x1a\x1d.taskery.v1.ListTasksResponse\x12=\n" +
+	"\n" +
+	"DeleteTask\x12\x17.taskery.v1.TaskRequest\x1a\x16.google.protobuf.Empty\x12H\n" +
+	"\x15DeleteTaskPermanently\x12\x17.taskery.v1.TaskRequest\x1a\x16.google.protobuf.Empty\x12>\n" +
+	"\vRestoreTask\x12\x17.taskery.v1.TaskRequest\x1a\x16.google.protobuf.Empty\x12B\n" +
+	"\tListTrash\x12\x16.google.protobuf.Empty\x1a\x1d.taskery.v1.ListTasksResponse\x12N\n" +
+	"\vSearchTasks\x12\x1e.taskery.v1.SearchTasksRequest\x1a\x1f.taskery.v1.SearchTasksResponse\x12E\n" +
+	"\vArchiveTask\x12\x1e.taskery.v1.ArchiveTaskRequest\x1a\x16.google.protobuf.Empty\x12@\n" +
+	"\rUnarchiveTask\x12\x17.taskery.v1.TaskRequest\x1a\x16.google.protobuf.Empty\x12]\n" +
+	"\x10ArchiveCompleted\x12#.taskery.v1.ArchiveCompletedRequest\x1a$.taskery.v1.ArchiveCompletedResponse\x12P\n" +
+	"\x0eGetTaskHistory\x12\x1a.taskery.v1.GetTaskRequest\x1a\".taskery.v1.GetTaskHistoryResponse\x12C\n" +
+	"\n" +
+	"RevertTask\x12\x1d.taskery.v1.RevertTaskRequest\x1a\x16.google.protobuf.Empty\x12K\n" +
+	"\n" +
+	"BatchTasks\x12\x1d.taskery.v1.BatchTasksRequest\x1a\x1e.taskery.v1.BatchTasksResponse\x12K\n" +
+	"\n" +
+	"GetChanges\x12\x1d.taskery.v1.GetChangesRequest\x1a\x1e.taskery.v1.GetChangesResponse\x12N\n" +
+	"\vPushChanges\x12\x1e.taskery.v1.PushChangesRequest\x1a\x1f.taskery.v1.PushChangesResponseBbZ`github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/grpc/taskeryv1;taskeryv1b\x06proto3"
+
+var (
+	file_taskery_v1_task_proto_rawDescOnce sync.Once
+	file_taskery_v1_task_proto_rawDescData []byte
+)
+
+func file_taskery_v1_task_proto_rawDescGZIP() []byte {
+	file_taskery_v1_task_proto_rawDescOnce.Do(func() {
+		file_taskery_v1_task_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_taskery_v1_task_proto_rawDesc), len(file_taskery_v1_task_proto_rawDesc)))
+	})
+	return file_taskery_v1_task_proto_rawDescData
+}
+
+var file_taskery_v1_task_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_taskery_v1_task_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_taskery_v1_task_proto_goTypes = []any{
+	(TaskSort)(0),                    // 0: taskery.v1.TaskSort
+	(TaskOperation)(0),               // 1: taskery.v1.TaskOperation
+	(*Task)(nil),                     // 2: taskery.v1.Task
+	(*TaskRequest)(nil),              // 3: taskery.v1.TaskRequest
+	(*GetTaskRequest)(nil),           // 4: taskery.v1.GetTaskRequest
+	(*CreateTaskRequest)(nil),        // 5: taskery.v1.CreateTaskRequest
+	(*CreateTaskResponse)(nil),       // 6: taskery.v1.CreateTaskResponse
+	(*UpdateTaskRequest)(nil),        // 7: taskery.v1.UpdateTaskRequest
+	(*ListTasksRequest)(nil),         // 8: taskery.v1.ListTasksRequest
+	(*ListTasksResponse)(nil),        // 9: taskery.v1.ListTasksResponse
+	(*SearchTasksRequest)(nil),       // 10: taskery.v1.SearchTasksRequest
+	(*SearchResult)(nil),             // 11: taskery.v1.SearchResult
+	(*SearchTasksResponse)(nil),      // 12: taskery.v1.SearchTasksResponse
+	(*ArchiveTaskRequest)(nil),       // 13: taskery.v1.ArchiveTaskRequest
+	(*ArchiveCompletedRequest)(nil),  // 14: taskery.v1.ArchiveCompletedRequest
+	(*ArchiveCompletedResponse)(nil), // 15: taskery.v1.ArchiveCompletedResponse
+	(*FieldChange)(nil),              // 16: taskery.v1.FieldChange
+	(*TaskEvent)(nil),                // 17: taskery.v1.TaskEvent
+	(*GetTaskHistoryResponse)(nil),   // 18: taskery.v1.GetTaskHistoryResponse
+	(*RevertTaskRequest)(nil),        // 19: taskery.v1.RevertTaskRequest
+	(*BatchOperation)(nil),           // 20: taskery.v1.BatchOperation
+	(*BatchTasksRequest)(nil),        // 21: taskery.v1.BatchTasksRequest
+	(*OperationError)(nil),           // 22: taskery.v1.OperationError
+	(*OperationResult)(nil),          // 23: taskery.v1.OperationResult
+	(*BatchTasksResponse)(nil),       // 24: taskery.v1.BatchTasksResponse
+	(*GetChangesRequest)(nil),        // 25: taskery.v1.GetChangesRequest
+	(*GetChangesResponse)(nil),       // 26: taskery.v1.GetChangesResponse
+	(*SyncChange)(nil),               // 27: taskery.v1.SyncChange
+	(*PushChangesRequest)(nil),       // 28: taskery.v1.PushChangesRequest
+	(*SyncResult)(nil),               // 29: taskery.v1.SyncResult
+	(*PushChangesResponse)(nil),      // 30: taskery.v1.PushChangesResponse
+	(*timestamppb.Timestamp)(nil),    // 31: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),      // 32: google.protobuf.Duration
+	(*structpb.Value)(nil),           // 33: google.protobuf.Value
+	(*emptypb.Empty)(nil),            // 34: google.protobuf.Empty
+}
+var file_taskery_v1_task_proto_depIdxs = []int32{
+	31, // 0: taskery.v1.Task.deadline:type_name -> google.protobuf.Timestamp
+	31, // 1: taskery.v1.Task.completed_at:type_name -> google.protobuf.Timestamp
+	31, // 2: taskery.v1.Task.created_at:type_name -> google.protobuf.Timestamp
+	31, // 3: taskery.v1.Task.updated_at:type_name -> google.protobuf.Timestamp
+	31, // 4: taskery.v1.Task.archived_at:type_name -> google.protobuf.Timestamp
+	31, // 5: taskery.v1.Task.deleted_at:type_name -> google.protobuf.Timestamp
+	31, // 6: taskery.v1.CreateTaskRequest.deadline:type_name -> google.protobuf.Timestamp
+	31, // 7: taskery.v1.UpdateTaskRequest.deadline:type_name -> google.protobuf.Timestamp
+	0,  // 8: taskery.v1.ListTasksRequest.sort:type_name -> taskery.v1.TaskSort
+	31, // 9: taskery.v1.ListTasksRequest.updated_since:type_name -> google.protobuf.Timestamp
+	2,  // 10: taskery.v1.ListTasksResponse.tasks:type_name -> taskery.v1.Task
+	2,  // 11: taskery.v1.SearchResult.task:type_name -> taskery.v1.Task
+	11, // 12: taskery.v1.SearchTasksResponse.results:type_name -> taskery.v1.SearchResult
+	32, // 13: taskery.v1.ArchiveCompletedRequest.older_than:type_name -> google.protobuf.Duration
+	33, // 14: taskery.v1.FieldChange.before:type_name -> google.protobuf.Value
+	33, // 15: taskery.v1.FieldChange.after:type_name -> google.protobuf.Value
+	31, // 16: taskery.v1.TaskEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	16, // 17: taskery.v1.TaskEvent.changes:type_name -> taskery.v1.FieldChange
+	17, // 18: taskery.v1.GetTaskHistoryResponse.events:type_name -> taskery.v1.TaskEvent
+	1,  // 19: taskery.v1.BatchOperation.op:type_name -> taskery.v1.TaskOperation
+	31, // 20: taskery.v1.BatchOperation.deadline:type_name -> google.protobuf.Timestamp
+	20, // 21: taskery.v1.BatchTasksRequest.operations:type_name -> taskery.v1.BatchOperation
+	22, // 22: taskery.v1.OperationResult.error:type_name -> taskery.v1.OperationError
+	23, // 23: taskery.v1.BatchTasksResponse.results:type_name -> taskery.v1.OperationResult
+	1,  // 24: taskery.v1.SyncChange.op:type_name -> taskery.v1.TaskOperation
+	31, // 25: taskery.v1.SyncChange.deadline:type_name -> google.protobuf.Timestamp
+	27, // 26: taskery.v1.PushChangesRequest.changes:type_name -> taskery.v1.SyncChange
+	22, // 27: taskery.v1.SyncResult.error:type_name -> taskery.v1.OperationError
+	2,  // 28: taskery.v1.SyncResult.task:type_name -> taskery.v1.Task
+	29, // 29: taskery.v1.PushChangesResponse.results:type_name -> taskery.v1.SyncResult
+	5,  // 30: taskery.v1.TaskService.CreateTask:input_type -> taskery.v1.CreateTaskRequest
+	7,  // 31: taskery.v1.TaskService.UpdateTask:input_type -> taskery.v1.UpdateTaskRequest
+	3,  // 32: taskery.v1.TaskService.RemoveDeadline:input_type -> taskery.v1.TaskRequest
+	3,  // 33: taskery.v1.TaskService.CompleteTask:input_type -> taskery.v1.TaskRequest
+	3,  // 34: taskery.v1.TaskService.ReopenTask:input_type -> taskery.v1.TaskRequest
+	4,  // 35: taskery.v1.TaskService.GetTask:input_type -> taskery.v1.GetTaskRequest
+	8,  // 36: taskery.v1.TaskService.ListTasks:input_type -> taskery.v1.ListTasksRequest
+	3,  // 37: taskery.v1.TaskService.DeleteTask:input_type -> taskery.v1.TaskRequest
+	3,  // 38: taskery.v1.TaskService.DeleteTaskPermanently:input_type -> taskery.v1.TaskRequest
+	3,  // 39: taskery.v1.TaskService.RestoreTask:input_type -> taskery.v1.TaskRequest
+	34, // 40: taskery.v1.TaskService.ListTrash:input_type -> google.protobuf.Empty
+	10, // 41: taskery.v1.TaskService.SearchTasks:input_type -> taskery.v1.SearchTasksRequest
+	13, // 42: taskery.v1.TaskService.ArchiveTask:input_type -> taskery.v1.ArchiveTaskRequest
+	3,  // 43: taskery.v1.TaskService.UnarchiveTask:input_type -> taskery.v1.TaskRequest
+	14, // 44: taskery.v1.TaskService.ArchiveCompleted:input_type -> taskery.v1.ArchiveCompletedRequest
+	4,  // 45: taskery.v1.TaskService.GetTaskHistory:input_type -> taskery.v1.GetTaskRequest
+	19, // 46: taskery.v1.TaskService.RevertTask:input_type -> taskery.v1.RevertTaskRequest
+	21, // 47: taskery.v1.TaskService.BatchTasks:input_type -> taskery.v1.BatchTasksRequest
+	25, // 48: taskery.v1.TaskService.GetChanges:input_type -> taskery.v1.GetChangesRequest
+	28, // 49: taskery.v1.TaskService.PushChanges:input_type -> taskery.v1.PushChangesRequest
+	6,  // 50: taskery.v1.TaskService.CreateTask:output_type -> taskery.v1.CreateTaskResponse
+	34, // 51: taskery.v1.TaskService.UpdateTask:output_type -> google.protobuf.Empty
+	34, // 52: taskery.v1.TaskService.RemoveDeadline:output_type -> google.protobuf.Empty
+	34, // 53: taskery.v1.TaskService.CompleteTask:output_type -> google.protobuf.Empty
+	34, // 54: taskery.v1.TaskService.ReopenTask:output_type -> google.protobuf.Empty
+	2,  // 55: taskery.v1.TaskService.GetTask:output_type -> taskery.v1.Task
+	9,  // 56: taskery.v1.TaskService.ListTasks:output_type -> taskery.v1.ListTasksResponse
+	34, // 57: taskery.v1.TaskService.DeleteTask:output_type -> google.protobuf.Empty
+	34, // 58: taskery.v1.TaskService.DeleteTaskPermanently:output_type -> google.protobuf.Empty
+	34, // 59: taskery.v1.TaskService.RestoreTask:output_type -> google.protobuf.Empty
+	9,  // 60: taskery.v1.TaskService.ListTrash:output_type -> taskery.v1.ListTasksResponse
+	12, // 61: taskery.v1.TaskService.SearchTasks:output_type -> taskery.v1.SearchTasksResponse
+	34, // 62: taskery.v1.TaskService.ArchiveTask:output_type -> google.protobuf.Empty
+	34, // 63: taskery.v1.TaskService.UnarchiveTask:output_type -> google.protobuf.Empty
+	15, // 64: taskery.v1.TaskService.ArchiveCompleted:output_type -> taskery.v1.ArchiveCompletedResponse
+	18, // 65: taskery.v1.TaskService.GetTaskHistory:output_type -> taskery.v1.GetTaskHistoryResponse
+	34, // 66: taskery.v1.TaskService.RevertTask:output_type -> google.protobuf.Empty
+	24, // 67: taskery.v1.TaskService.BatchTasks:output_type -> taskery.v1.BatchTasksResponse
+	26, // 68: taskery.v1.TaskService.GetChanges:output_type -> taskery.v1.GetChangesResponse
+	30, // 69: taskery.v1.TaskService.PushChanges:output_type -> taskery.v1.PushChangesResponse
+	50, // [50:70] is the sub-list for method output_type
+	30, // [30:50] is the sub-list for method input_type
+	30, // [30:30] is the sub-list for extension type_name
+	30, // [30:30] is the sub-list for extension extendee
+	0,  // [0:30] is the sub-list for field type_name
+}
+
+func init() { file_taskery_v1_task_proto_init() }
+func file_taskery_v1_task_proto_init() {
+	if File_taskery_v1_task_proto != nil {
+		return
+	}
+	file_taskery_v1_task_proto_msgTypes[1].OneofWrappers = []any{}
+	file_taskery_v1_task_proto_msgTypes[5].OneofWrappers = []any{}
+	file_taskery_v1_task_proto_msgTypes[11].OneofWrappers = []any{}
+	file_taskery_v1_task_proto_msgTypes[17].OneofWrappers = []any{}
+	file_taskery_v1_task_proto_msgTypes[18].OneofWrappers = []any{}
+	file_taskery_v1_task_proto_msgTypes[25].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_taskery_v1_task_proto_rawDesc), len(file_taskery_v1_task_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   29,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_taskery_v1_task_proto_goTypes,
+		DependencyIndexes: file_taskery_v1_task_proto_depIdxs,
+		EnumInfos:         file_taskery_v1_task_proto_enumTypes,
+		MessageInfos:      file_taskery_v1_task_proto_msgTypes,
+	}.Build()
+	File_taskery_v1_task_proto = out.File
+	file_taskery_v1_task_proto_goTypes = nil
+	file_taskery_v1_task_proto_depIdxs = nil
+}
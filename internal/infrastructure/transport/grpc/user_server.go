@@ -0,0 +1,51 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/grpc/taskeryv1"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// userServer implements taskeryv1.UserServiceServer on top of UserService.
+// The errors it returns are converted to the statuses by the Logging interceptor.
+type userServer struct {
+	taskeryv1.UnimplementedUserServiceServer
+
+	users UserService
+}
+
+func (s *userServer) Register(ctx context.Context, req *taskeryv1.RegisterRequest) (*emptypb.Empty, error) {
+	return empty(s.users.Register(ctx, req.GetUsername(), req.GetEmail(), req.GetPassword()))
+}
+
+func (s *userServer) Login(ctx context.Context, req *taskeryv1.LoginRequest) (*taskeryv1.LoginResponse, error) {
+	token, err := s.users.Login(ctx, req.GetEmail(), req.GetPassword())
+	if err != nil {
+		return nil, err
+	}
+
+	return &taskeryv1.LoginResponse{Token: token}, nil
+}
+
+func (s *userServer) ChangeUsername(
+	ctx context.Context,
+	req *taskeryv1.ChangeUsernameRequest,
+) (*emptypb.Empty, error) {
+	return empty(s.users.ChangeUsername(ctx, GetUserID(ctx), req.GetUsername(), req.GetPassword()))
+}
+
+func (s *userServer) ChangeEmail(ctx context.Context, req *taskeryv1.ChangeEmailRequest) (*emptypb.Empty, error) {
+	return empty(s.users.ChangeEmail(ctx, GetUserID(ctx), req.GetEmail(), req.GetPassword()))
+}
+
+func (s *userServer) ChangePassword(
+	ctx context.Context,
+	req *taskeryv1.ChangePasswordRequest,
+) (*emptypb.Empty, error) {
+	return empty(s.users.ChangePassword(ctx, GetUserID(ctx), req.GetOldPassword(), req.GetNewPassword()))
+}
+
+func (s *userServer) DeleteUser(ctx context.Context, req *taskeryv1.DeleteUserRequest) (*emptypb.Empty, error) {
+	return empty(s.users.Delete(ctx, GetUserID(ctx), req.GetPassword()))
+}
@@ -2,7 +2,6 @@ package auth
 
 import (
 	"context"
-	"errors"
 	"log/slog"
 	"net/http"
 	"time"
@@ -10,10 +9,14 @@ import (
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
 	"github.com/cyberbrain-dev/taskery-api/internal/services"
 	"github.com/cyberbrain-dev/taskery-api/pkg/errorsx"
-	"github.com/go-chi/chi/v5/middleware"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
 	"github.com/go-playground/validator/v10"
 )
 
+// errInvalidCredentials is reported instead of the user lookup errors,
+// so that the client cannot tell whether the email is registered.
+var errInvalidCredentials = handlers.NewError(http.StatusUnauthorized, "INVALID_CREDENTIALS", "invalid credentials")
+
 type Authenticator interface {
 	Login(ctx context.Context, email, password string) (string, error)
 }
@@ -45,9 +48,9 @@ func NewLoginHandler(
 // @Produce json
 // @Param request body LoginRequest true "Login request"
 // @Success 200 {object} LoginResponse
-// @Failure 400 {object} handlers.ErrorResponse
-// @Failure 401 {object} handlers.ErrorResponse
-// @Failure 500 {object} handlers.ErrorResponse
+// @Failure 400 {object} handlers.Problem
+// @Failure 401 {object} handlers.Problem
+// @Failure 500 {object} handlers.Problem
 // @Router /auth/login [post]
 func (h *LoginHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	const op = "handlers.auth.Login"
@@ -55,12 +58,9 @@ func (h *LoginHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
 	defer cancel()
 
-	logger := h.logger.With(
-		slog.String("op", op),
-		slog.String("request_id", middleware.GetReqID(r.Context())),
-	)
+	logger := slogx.FromContext(r.Context(), h.logger).With(slog.String("op", op))
 
-	req, ok := handlers.DecodeAndValidate[LoginRequest](w, r, h.logger, h.validate)
+	req, ok := handlers.DecodeAndValidate[LoginRequest](w, r, logger, h.validate)
 	if !ok {
 		return
 	}
@@ -68,18 +68,12 @@ func (h *LoginHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	token, err := h.authenticator.Login(ctx, req.Email, req.Password)
 	if err != nil {
 		logger.Error("failed to login", slog.String("error", err.Error()))
-
 		if errorsx.IsAny(err, services.ErrUserNotFound, services.ErrUserUnauthorized) {
-			handlers.WriteError(w, http.StatusUnauthorized, errors.New("invalid credentials"))
-			return
-		}
-
-		if errors.Is(err, services.ErrUserLoginFailed) {
-			handlers.WriteError(w, http.StatusInternalServerError, errors.New("login failed"))
+			handlers.WriteError(w, r, errInvalidCredentials)
 			return
 		}
 
-		handlers.WriteError(w, http.StatusBadRequest, err)
+		handlers.WriteError(w, r, err)
 		return
 	}
 
@@ -11,10 +11,10 @@ import (
 	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/auth"
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/auth/mocks"
 	"github.com/cyberbrain-dev/taskery-api/internal/services"
-	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -52,7 +52,7 @@ func TestLoginHandler(t *testing.T) {
 				Password: correctPassword,
 			},
 			expectedCode: http.StatusBadRequest,
-			expectedBody: `{"errors":[{"field":"Email","error":"field is not a valid email"}]}`,
+			expectedBody: `{"type":"/problems/validation-failed","title":"Bad Request","status":400,"detail":"request body is invalid","code":"VALIDATION_FAILED","errors":[{"field":"email","rule":"email","error":"must be a valid email"}]}`,
 			mockSetup: func(a *mocks.Authenticator) {
 				a.On("Login", mock.Anything, "invalid_email", correctPassword).
 					Return("", nil)
@@ -65,7 +65,7 @@ func TestLoginHandler(t *testing.T) {
 				Password: correctPassword,
 			},
 			expectedCode: http.StatusUnauthorized,
-			expectedBody: `{"error":"invalid credentials"}`,
+			expectedBody: `{"type":"/problems/invalid-credentials","title":"Unauthorized","status":401,"detail":"invalid credentials","code":"INVALID_CREDENTIALS"}`,
 			mockSetup: func(a *mocks.Authenticator) {
 				a.On("Login", mock.Anything, correctEmail, correctPassword).
 					Return("", services.ErrUserNotFound)
@@ -78,7 +78,7 @@ func TestLoginHandler(t *testing.T) {
 				Password: correctPassword,
 			},
 			expectedCode: http.StatusUnauthorized,
-			expectedBody: `{"error":"invalid credentials"}`,
+			expectedBody: `{"type":"/problems/invalid-credentials","title":"Unauthorized","status":401,"detail":"invalid credentials","code":"INVALID_CREDENTIALS"}`,
 			mockSetup: func(a *mocks.Authenticator) {
 				a.On("Login", mock.Anything, correctEmail, correctPassword).
 					Return("", services.ErrUserUnauthorized)
@@ -91,7 +91,7 @@ func TestLoginHandler(t *testing.T) {
 				Password: correctPassword,
 			},
 			expectedCode: http.StatusInternalServerError,
-			expectedBody: `{"error":"login failed"}`,
+			expectedBody: `{"type":"/problems/internal-error","title":"Internal Server Error","status":500,"detail":"internal server error","code":"INTERNAL_ERROR"}`,
 			mockSetup: func(a *mocks.Authenticator) {
 				a.On("Login", mock.Anything, correctEmail, correctPassword).
 					Return("", services.ErrUserLoginFailed)
@@ -113,7 +113,7 @@ func TestLoginHandler(t *testing.T) {
 			tt.mockSetup(authenticator)
 
 			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-			h := auth.NewLoginHandler(authenticator, 4*time.Second, logger, validator.New())
+			h := auth.NewLoginHandler(authenticator, 4*time.Second, logger, handlers.NewValidator())
 			h.ServeHTTP(rr, req)
 
 			require.Equal(t, tt.expectedCode, rr.Code)
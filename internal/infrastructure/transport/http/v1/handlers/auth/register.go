@@ -2,15 +2,12 @@ package auth
 
 import (
 	"context"
-	"errors"
 	"log/slog"
 	"net/http"
 	"time"
 
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
-	"github.com/cyberbrain-dev/taskery-api/internal/services"
-	"github.com/cyberbrain-dev/taskery-api/pkg/errorsx"
-	"github.com/go-chi/chi/v5/middleware"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -46,9 +43,9 @@ func NewRegisterHandler(
 // @Produce json
 // @Param request body RegisterRequest true "Registration request"
 // @Success 201 {object} RegisterResponse
-// @Failure 400 {object} handlers.ErrorResponse
-// @Failure 409 {object} handlers.ErrorResponse
-// @Failure 500 {object} handlers.ErrorResponse
+// @Failure 400 {object} handlers.Problem
+// @Failure 409 {object} handlers.Problem
+// @Failure 500 {object} handlers.Problem
 // @Router /auth/register [post]
 func (h *RegisterHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	const op = "handlers.auth.Register"
@@ -56,12 +53,9 @@ func (h *RegisterHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
 	defer cancel()
 
-	logger := h.logger.With(
-		slog.String("op", op),
-		slog.String("request_id", middleware.GetReqID(r.Context())),
-	)
+	logger := slogx.FromContext(r.Context(), h.logger).With(slog.String("op", op))
 
-	req, ok := handlers.DecodeAndValidate[RegisterRequest](w, r, h.logger, h.validate)
+	req, ok := handlers.DecodeAndValidate[RegisterRequest](w, r, logger, h.validate)
 	if !ok {
 		return
 	}
@@ -69,18 +63,7 @@ func (h *RegisterHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	err := h.registrar.Register(ctx, req.Username, req.Email, req.Password)
 	if err != nil {
 		logger.Error("failed to register user", slog.String("error", err.Error()))
-
-		if errorsx.IsAny(err, services.ErrUserExists) {
-			handlers.WriteError(w, http.StatusConflict, err)
-			return
-		}
-
-		if errors.Is(err, services.ErrUserRegisterFailed) {
-			handlers.WriteError(w, http.StatusInternalServerError, errors.New("register failed"))
-			return
-		}
-
-		handlers.WriteError(w, http.StatusBadRequest, err)
+		handlers.WriteError(w, r, err)
 		return
 	}
 
@@ -11,10 +11,10 @@ import (
 	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/auth"
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/auth/mocks"
 	"github.com/cyberbrain-dev/taskery-api/internal/services"
-	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -57,7 +57,7 @@ func TestRegisterHandler(t *testing.T) {
 			},
 
 			expectedCode: http.StatusBadRequest,
-			expectedBody: `{"errors":[{"field":"Email","error":"field is not a valid email"}]}`,
+			expectedBody: `{"type":"/problems/validation-failed","title":"Bad Request","status":400,"detail":"request body is invalid","code":"VALIDATION_FAILED","errors":[{"field":"email","rule":"email","error":"must be a valid email"}]}`,
 
 			mockSetup: func(r *mocks.Registrar) {
 				r.On("Register", mock.Anything, correctUsername, "not_correct", correctPassword).
@@ -73,7 +73,7 @@ func TestRegisterHandler(t *testing.T) {
 			},
 
 			expectedCode: http.StatusConflict,
-			expectedBody: `{"error":"user already exists"}`,
+			expectedBody: `{"type":"/problems/user-already-exists","title":"Conflict","status":409,"detail":"user already exists","code":"USER_ALREADY_EXISTS"}`,
 
 			mockSetup: func(r *mocks.Registrar) {
 				r.On("Register", mock.Anything, correctUsername, correctEmail, correctPassword).
@@ -89,7 +89,7 @@ func TestRegisterHandler(t *testing.T) {
 			},
 
 			expectedCode: http.StatusInternalServerError,
-			expectedBody: `{"error":"register failed"}`,
+			expectedBody: `{"type":"/problems/internal-error","title":"Internal Server Error","status":500,"detail":"internal server error","code":"INTERNAL_ERROR"}`,
 
 			mockSetup: func(r *mocks.Registrar) {
 				r.On("Register", mock.Anything, correctUsername, correctEmail, correctPassword).
@@ -113,7 +113,7 @@ func TestRegisterHandler(t *testing.T) {
 
 			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
 
-			h := auth.NewRegisterHandler(registrar, 4*time.Second, logger, validator.New())
+			h := auth.NewRegisterHandler(registrar, 4*time.Second, logger, handlers.NewValidator())
 			h.ServeHTTP(rr, req)
 
 			require.Equal(t, tt.expectedCode, rr.Code)
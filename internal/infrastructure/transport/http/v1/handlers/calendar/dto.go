@@ -0,0 +1,7 @@
+package calendar
+
+// TokenResponse contains the feed token that has been issued.
+// It is shown only once, since only its hash is stored.
+type TokenResponse struct {
+	Token string `json:"token"`
+}
@@ -0,0 +1,97 @@
+// Package calendar serves the calendar feeds, which let the calendar clients subscribe
+// to the deadlines of the tasks, and the management of the feed tokens.
+package calendar
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+type Feeder interface {
+	Feed(ctx context.Context, token string) ([]*models.Task, error)
+}
+
+type FeedHandler struct {
+	feeder   Feeder
+	timeout  time.Duration
+	logger   *slog.Logger
+	validate *validator.Validate
+}
+
+func NewFeedHandler(
+	feeder Feeder,
+	timeout time.Duration,
+	logger *slog.Logger,
+	validate *validator.Validate,
+) *FeedHandler {
+	return &FeedHandler{
+		feeder:   feeder,
+		timeout:  timeout,
+		logger:   logger,
+		validate: validate,
+	}
+}
+
+// @Summary Calendar feed
+// @Description Returns the active tasks with deadlines of the user that the feed token is issued to as an iCalendar (RFC 5545) feed,
+// @Description which the calendar clients subscribe to by its URL. The feed is authorized by the token in the path instead of the bearer token,
+// @Description since the calendar clients can not send one. The UID of an entry is the ID of its task.
+// @Description By default the tasks are events at their deadlines, type=todo renders them as to-dos that are due at their deadlines.
+// @Tags calendar
+// @Produce text/calendar
+// @Param token path string true "Feed token"
+// @Param type query string false "Kind of the entries" Enums(event, todo)
+// @Success 200 {string} string "iCalendar feed"
+// @Failure 400 {object} handlers.Problem
+// @Failure 404 {object} handlers.Problem
+// @Failure 429 {object} handlers.Problem
+// @Failure 500 {object} handlers.Problem
+// @Router /calendar/{token}.ics [get]
+func (h *FeedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.Calendar.Feed"
+
+	logger := slogx.FromContext(r.Context(), h.logger).With(slog.String("op", op))
+
+	component := ComponentEvent
+	if raw := r.URL.Query().Get("type"); raw != "" {
+		component = Component(raw)
+		if !component.IsValid() {
+			logger.Info("invalid type parameter", slog.String("type", raw))
+			handlers.WriteError(w, r, handlers.InvalidParameter("type"))
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	// the token is a secret, so it is not logged
+	tasks, err := h.feeder.Feed(ctx, chi.URLParam(r, "token"))
+	if err != nil {
+		logger.Error("failed to load calendar feed", slog.String("error", err.Error()))
+		handlers.WriteError(w, r, err)
+		return
+	}
+
+	var body bytes.Buffer
+	if err := writeCalendar(&body, tasks, component); err != nil {
+		logger.Error("failed to render calendar feed", slog.String("error", err.Error()))
+		handlers.WriteError(w, r, handlers.ErrInternal)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="taskery.ics"`)
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body.Bytes())
+}
@@ -0,0 +1,199 @@
+package calendar_test
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/calendar"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/calendar/mocks"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTask(t *testing.T, p models.TaskFromDBParams) *models.Task {
+	t.Helper()
+
+	task, err := models.NewTaskFromDB(p)
+	require.NoError(t, err)
+
+	return task
+}
+
+func TestFeedHandler(t *testing.T) {
+	const token = "feed-token"
+
+	createdAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	updatedAt := time.Date(2026, 3, 2, 10, 30, 0, 0, time.UTC)
+	deadline := time.Date(2026, 3, 5, 18, 0, 0, 0, time.FixedZone("UTC+3", 3*60*60))
+	completedAt := time.Date(2026, 3, 4, 8, 15, 0, 0, time.UTC)
+
+	ownerID := uuid.NewString()
+
+	open := newTask(t, models.TaskFromDBParams{
+		ID:          uuid.NewString(),
+		OwnerID:     ownerID,
+		Title:       "Report; draft, v2",
+		Description: "Line one\nLine two with a backslash \\ and " + strings.Repeat("ю", 40),
+		Deadline:    &deadline,
+		CreatedAt:   createdAt,
+		UpdatedAt:   updatedAt,
+		Version:     3,
+	})
+
+	completed := newTask(t, models.TaskFromDBParams{
+		ID:          uuid.NewString(),
+		OwnerID:     ownerID,
+		Title:       "Pay rent",
+		Deadline:    &deadline,
+		IsCompleted: true,
+		CompletedAt: &completedAt,
+		CreatedAt:   createdAt,
+		UpdatedAt:   completedAt,
+		Version:     2,
+	})
+
+	tests := []struct {
+		name  string
+		query string
+
+		expectedCode int
+		expectedBody string
+		expectedICS  []string
+		absentICS    []string
+
+		mockSetup func(f *mocks.Feeder)
+	}{
+		{
+			name: "events",
+
+			expectedCode: http.StatusOK,
+			expectedICS: []string{
+				"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+				"BEGIN:VEVENT\r\nUID:" + open.ID().String() + "\r\n",
+				"DTSTAMP:20260302T103000Z\r\n",
+				"CREATED:20260301T090000Z\r\n",
+				"SEQUENCE:2\r\n",
+				`SUMMARY:Report\; draft\, v2` + "\r\n",
+				`DESCRIPTION:Line one\nLine two with a backslash \\ and `,
+				"DTSTART:20260305T150000Z\r\n",
+				"SUMMARY:✓ Pay rent\r\n",
+				"END:VEVENT\r\nEND:VCALENDAR\r\n",
+			},
+			absentICS: []string{"VTODO"},
+
+			mockSetup: func(f *mocks.Feeder) {
+				f.On("Feed", mock.Anything, token).
+					Return([]*models.Task{open, completed}, nil)
+			},
+		},
+		{
+			name:  "to-dos",
+			query: "?type=todo",
+
+			expectedCode: http.StatusOK,
+			expectedICS: []string{
+				"BEGIN:VTODO\r\nUID:" + open.ID().String() + "\r\n",
+				"DUE:20260305T150000Z\r\nSTATUS:NEEDS-ACTION\r\nEND:VTODO\r\n",
+				"SUMMARY:Pay rent\r\n",
+				"STATUS:COMPLETED\r\nCOMPLETED:20260304T081500Z\r\nPERCENT-COMPLETE:100\r\n",
+			},
+			absentICS: []string{"VEVENT", "✓"},
+
+			mockSetup: func(f *mocks.Feeder) {
+				f.On("Feed", mock.Anything, token).
+					Return([]*models.Task{open, completed}, nil)
+			},
+		},
+		{
+			name:  "invalid type",
+			query: "?type=journal",
+
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/invalid-parameter","title":"Bad Request","status":400,"detail":"invalid type parameter","code":"INVALID_PARAMETER"}`,
+
+			mockSetup: func(f *mocks.Feeder) {},
+		},
+		{
+			name: "unknown token",
+
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"type":"/problems/calendar-feed-not-found","title":"Not Found","status":404,"detail":"calendar feed not found","code":"CALENDAR_FEED_NOT_FOUND"}`,
+
+			mockSetup: func(f *mocks.Feeder) {
+				f.On("Feed", mock.Anything, token).
+					Return(nil, services.ErrCalendarFeedNotFound)
+			},
+		},
+		{
+			name: "internal server error",
+
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"type":"/problems/internal-error","title":"Internal Server Error","status":500,"detail":"internal server error","code":"INTERNAL_ERROR"}`,
+
+			mockSetup: func(f *mocks.Feeder) {
+				f.On("Feed", mock.Anything, token).
+					Return(nil, services.ErrCalendarFeedFailed)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feeder := new(mocks.Feeder)
+			tt.mockSetup(feeder)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+
+			r := chi.NewRouter()
+			r.Method("GET", "/calendar/{token}.ics", calendar.NewFeedHandler(
+				feeder,
+				4*time.Second,
+				logger,
+				handlers.NewValidator(),
+			))
+
+			req := httptest.NewRequest(http.MethodGet, "/calendar/"+token+".ics"+tt.query, nil)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedCode, rr.Code)
+			if tt.expectedBody != "" {
+				require.Equal(t, tt.expectedBody, rr.Body.String())
+			}
+
+			if rr.Code == http.StatusOK {
+				require.Equal(t, "text/calendar; charset=utf-8", rr.Header().Get("Content-Type"))
+
+				ics := rr.Body.String()
+				for _, want := range tt.expectedICS {
+					require.Contains(t, ics, want)
+				}
+				for _, absent := range tt.absentICS {
+					require.NotContains(t, ics, absent)
+				}
+
+				lines := strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n")
+				for _, line := range lines {
+					require.LessOrEqual(t, len(line), 75, "line is not folded: %q", line)
+					require.NotContains(t, line, "\n")
+					require.True(t, strings.ToValidUTF8(line, "") == line, "line splits a character: %q", line)
+				}
+
+				unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+				require.Contains(t, unfolded, strings.Repeat("ю", 40)+"\r\n")
+			}
+
+			feeder.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package calendar
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+)
+
+// Component is the kind of the iCalendar components that the tasks are rendered as.
+type Component string
+
+const (
+	// ComponentEvent renders a task as a VEVENT that starts at its deadline.
+	// It is the default, since it is the only component that all calendar clients display.
+	ComponentEvent Component = "event"
+
+	// ComponentTodo renders a task as a VTODO that is due at its deadline.
+	ComponentTodo Component = "todo"
+)
+
+// IsValid reports whether c is a known component.
+func (c Component) IsValid() bool {
+	switch c {
+	case ComponentEvent, ComponentTodo:
+		return true
+	default:
+		return false
+	}
+}
+
+const (
+	// productID identifies the application that has created the calendar.
+	productID = "-//Taskery//Taskery API//EN"
+
+	// calendarName is the name that the clients show for the subscribed calendar.
+	calendarName = "Taskery"
+
+	// refreshInterval is the interval that the clients are suggested to poll the feed with.
+	refreshInterval = "PT1H"
+
+	// completedPrefix marks the completed tasks in the summaries of the events,
+	// since an event has no status of completion.
+	completedPrefix = "✓ "
+
+	// maxLineLength is the maximum length of a content line in octets, excluding the line break.
+	maxLineLength = 75
+
+	// dateTimeLayout is the layout of the date-time values in UTC.
+	dateTimeLayout = "20060102T150405Z"
+)
+
+// writeCalendar renders the tasks with deadlines as an iCalendar object (RFC 5545)
+// with a component of the given kind per task. The tasks without a deadline are skipped.
+//
+// The UID of a component is the ID of its task, so that the clients update the entries
+// of the tasks instead of duplicating them, and its SEQUENCE follows the task version.
+func writeCalendar(w io.Writer, tasks []*models.Task, component Component) error {
+	cw := &contentWriter{w: bufio.NewWriter(w)}
+
+	cw.line("BEGIN", "VCALENDAR")
+	cw.line("VERSION", "2.0")
+	cw.line("PRODID", productID)
+	cw.line("CALSCALE", "GREGORIAN")
+	cw.line("METHOD", "PUBLISH")
+	cw.line("X-WR-CALNAME", escapeText(calendarName))
+	cw.line("REFRESH-INTERVAL;VALUE=DURATION", refreshInterval)
+	cw.line("X-PUBLISHED-TTL", refreshInterval)
+
+	for _, task := range tasks {
+		if task.Deadline() == nil {
+			continue
+		}
+
+		if component == ComponentTodo {
+			writeTodo(cw, task)
+		} else {
+			writeEvent(cw, task)
+		}
+	}
+
+	cw.line("END", "VCALENDAR")
+
+	return cw.flush()
+}
+
+// writeEvent renders the task as a VEVENT, which takes no time at the deadline of the task.
+func writeEvent(cw *contentWriter, task *models.Task) {
+	summary := task.Title().String()
+	if task.IsCompleted() {
+		summary = completedPrefix + summary
+	}
+
+	cw.line("BEGIN", "VEVENT")
+	writeCommon(cw, task, summary)
+	cw.line("DTSTART", formatDateTime(task.Deadline().Time()))
+	cw.line("TRANSP", "TRANSPARENT")
+	cw.line("END", "VEVENT")
+}
+
+// writeTodo renders the task as a VTODO, which is due at the deadline of the task.
+func writeTodo(cw *contentWriter, task *models.Task) {
+	cw.line("BEGIN", "VTODO")
+	writeCommon(cw, task, task.Title().String())
+	cw.line("DUE", formatDateTime(task.Deadline().Time()))
+
+	if completedAt := task.CompletedAt(); task.IsCompleted() && completedAt != nil {
+		cw.line("STATUS", "COMPLETED")
+		cw.line("COMPLETED", formatDateTime(*completedAt))
+		cw.line("PERCENT-COMPLETE", "100")
+	} else {
+		cw.line("STATUS", "NEEDS-ACTION")
+	}
+
+	cw.line("END", "VTODO")
+}
+
+// writeCommon renders the properties that the events and the to-dos share.
+func writeCommon(cw *contentWriter, task *models.Task, summary string) {
+	cw.line("UID", escapeText(task.ID().String()))
+	cw.line("DTSTAMP", formatDateTime(task.UpdatedAt()))
+	cw.line("CREATED", formatDateTime(task.CreatedAt()))
+	cw.line("LAST-MODIFIED", formatDateTime(task.UpdatedAt()))
+	cw.line("SEQUENCE", strconv.FormatInt(max(task.Version()-1, 0), 10))
+	cw.line("SUMMARY", escapeText(summary))
+
+	if description := task.Description().String(); description != "" {
+		cw.line("DESCRIPTION", escapeText(description))
+	}
+}
+
+// formatDateTime formats t as a date-time value in UTC.
+func formatDateTime(t time.Time) string {
+	return t.UTC().Format(dateTimeLayout)
+}
+
+// textEscaper escapes the characters that have a special meaning in the TEXT values.
+// The carriage returns are dropped, since the line breaks are escaped as "\n" alone.
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", "",
+)
+
+// escapeText escapes s as a TEXT value.
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// contentWriter writes the content lines of an iCalendar object.
+// The lines end with CRLF and are folded so that they do not exceed maxLineLength octets.
+// The first write error is kept and reported by flush.
+type contentWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+// line writes the content line of the property with the given name, including its parameters,
+// and the value that is already escaped.
+func (cw *contentWriter) line(name, value string) {
+	cw.write(fold(name + ":" + value))
+}
+
+func (cw *contentWriter) write(s string) {
+	if cw.err != nil {
+		return
+	}
+
+	_, cw.err = cw.w.WriteString(s)
+}
+
+func (cw *contentWriter) flush() error {
+	if cw.err != nil {
+		return cw.err
+	}
+
+	return cw.w.Flush()
+}
+
+// fold splits the line into the lines of at most maxLineLength octets, each continuation line
+// starting with a space, and terminates them with CRLF. A line is never split
+// in the middle of a multi-octet UTF-8 sequence.
+func fold(line string) string {
+	var b strings.Builder
+	b.Grow(len(line) + len(line)/maxLineLength*3 + 2)
+
+	limit := maxLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+
+		// the leading space of a continuation line counts towards its length
+		limit = maxLineLength - 1
+	}
+
+	b.WriteString(line)
+	b.WriteString("\r\n")
+
+	return b.String()
+}
@@ -0,0 +1,76 @@
+package calendar
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
+	"github.com/go-playground/validator/v10"
+)
+
+type TokenRevoker interface {
+	RevokeFeedToken(ctx context.Context, userID string) error
+}
+
+type RevokeHandler struct {
+	revoker  TokenRevoker
+	timeout  time.Duration
+	logger   *slog.Logger
+	validate *validator.Validate
+}
+
+func NewRevokeHandler(
+	revoker TokenRevoker,
+	timeout time.Duration,
+	logger *slog.Logger,
+	validate *validator.Validate,
+) *RevokeHandler {
+	return &RevokeHandler{
+		revoker:  revoker,
+		timeout:  timeout,
+		logger:   logger,
+		validate: validate,
+	}
+}
+
+// @Summary Revoke the calendar feed token
+// @Description Revokes the feed token of the authenticated user, so that the feed is no longer available.
+// @Description Revoking when there is no token succeeds as well.
+// @Tags calendar
+// @Security     BearerAuth
+// @Success 204 {object} nil
+// @Failure 400 {object} handlers.Problem
+// @Failure 401 {object} handlers.Problem
+// @Failure 429 {object} handlers.Problem
+// @Failure 500 {object} handlers.Problem
+// @Router /calendar/token [delete]
+func (h *RevokeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.Calendar.Revoke"
+
+	logger := slogx.FromContext(r.Context(), h.logger).With(slog.String("op", op))
+
+	userID := myMw.GetUserID(r.Context())
+	if userID == "" {
+		logger.Error("failed to extract user id")
+		handlers.WriteError(w, r, handlers.ErrBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	if err := h.revoker.RevokeFeedToken(ctx, userID); err != nil {
+		logger.Error("failed to revoke calendar feed token",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID),
+		)
+		handlers.WriteError(w, r, err)
+		return
+	}
+
+	handlers.WriteJSON(w, http.StatusNoContent, nil)
+}
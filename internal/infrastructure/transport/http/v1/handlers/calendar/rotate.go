@@ -0,0 +1,80 @@
+package calendar
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
+	"github.com/go-playground/validator/v10"
+)
+
+type TokenRotator interface {
+	RotateFeedToken(ctx context.Context, userID string) (string, error)
+}
+
+type RotateHandler struct {
+	rotator  TokenRotator
+	timeout  time.Duration
+	logger   *slog.Logger
+	validate *validator.Validate
+}
+
+func NewRotateHandler(
+	rotator TokenRotator,
+	timeout time.Duration,
+	logger *slog.Logger,
+	validate *validator.Validate,
+) *RotateHandler {
+	return &RotateHandler{
+		rotator:  rotator,
+		timeout:  timeout,
+		logger:   logger,
+		validate: validate,
+	}
+}
+
+// @Summary Rotate the calendar feed token
+// @Description Issues a new feed token to the authenticated user, the feed is available on /calendar/{token}.ics.
+// @Description The previous token, if any, stops working. The token is shown only once.
+// @Tags calendar
+// @Produce json
+// @Security     BearerAuth
+// @Success 200 {object} TokenResponse
+// @Failure 400 {object} handlers.Problem
+// @Failure 401 {object} handlers.Problem
+// @Failure 404 {object} handlers.Problem
+// @Failure 429 {object} handlers.Problem
+// @Failure 500 {object} handlers.Problem
+// @Router /calendar/token [post]
+func (h *RotateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.Calendar.Rotate"
+
+	logger := slogx.FromContext(r.Context(), h.logger).With(slog.String("op", op))
+
+	userID := myMw.GetUserID(r.Context())
+	if userID == "" {
+		logger.Error("failed to extract user id")
+		handlers.WriteError(w, r, handlers.ErrBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	token, err := h.rotator.RotateFeedToken(ctx, userID)
+	if err != nil {
+		logger.Error("failed to rotate calendar feed token",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID),
+		)
+		handlers.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	handlers.WriteJSON(w, http.StatusOK, TokenResponse{Token: token})
+}
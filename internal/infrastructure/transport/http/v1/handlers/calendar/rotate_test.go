@@ -0,0 +1,111 @@
+package calendar_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/calendar"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/calendar/mocks"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotateHandler(t *testing.T) {
+	correctUserID := gofakeit.UUID()
+
+	tests := []struct {
+		name string
+
+		userID string
+
+		expectedCode int
+		expectedBody string
+
+		mockSetup func(r *mocks.TokenRotator)
+	}{
+		{
+			name: "success",
+
+			userID: correctUserID,
+
+			expectedCode: http.StatusOK,
+			expectedBody: `{"token":"new-token"}`,
+
+			mockSetup: func(r *mocks.TokenRotator) {
+				r.On("RotateFeedToken", mock.Anything, correctUserID).
+					Return("new-token", nil)
+			},
+		},
+		{
+			name: "missing user id",
+
+			userID: "",
+
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/bad-request","title":"Bad Request","status":400,"detail":"bad request","code":"BAD_REQUEST"}`,
+
+			mockSetup: func(r *mocks.TokenRotator) {},
+		},
+		{
+			name: "user not found",
+
+			userID: correctUserID,
+
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"type":"/problems/user-not-found","title":"Not Found","status":404,"detail":"user not found","code":"USER_NOT_FOUND"}`,
+
+			mockSetup: func(r *mocks.TokenRotator) {
+				r.On("RotateFeedToken", mock.Anything, correctUserID).
+					Return("", services.ErrUserNotFound)
+			},
+		},
+		{
+			name: "internal server error",
+
+			userID: correctUserID,
+
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"type":"/problems/internal-error","title":"Internal Server Error","status":500,"detail":"internal server error","code":"INTERNAL_ERROR"}`,
+
+			mockSetup: func(r *mocks.TokenRotator) {
+				r.On("RotateFeedToken", mock.Anything, correctUserID).
+					Return("", services.ErrCalendarFeedRotateFailed)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/calendar/token", nil)
+			req = req.WithContext(context.WithValue(req.Context(), myMw.UserIDKey, tt.userID))
+
+			rr := httptest.NewRecorder()
+
+			rotator := new(mocks.TokenRotator)
+			tt.mockSetup(rotator)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+
+			h := calendar.NewRotateHandler(rotator, 4*time.Second, logger, handlers.NewValidator())
+			h.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedCode, rr.Code)
+			require.Equal(t, tt.expectedBody, rr.Body.String())
+
+			if rr.Code == http.StatusOK {
+				require.Equal(t, "no-store", rr.Header().Get("Cache-Control"))
+			}
+
+			rotator.AssertExpectations(t)
+		})
+	}
+}
@@ -2,66 +2,202 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strings"
 
+	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-playground/validator/v10"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// ProblemContentType is the media type of the error responses.
+const ProblemContentType = "application/problem+json"
+
+// ProblemTypeBase is the prefix of the problem type URIs.
+// The rest of the URI is the problem code in kebab case, e.g. "/problems/task-not-found".
+const ProblemTypeBase = "/problems/"
+
+// FieldError describes an invalid field of the request.
 type FieldError struct {
+	// Field is the JSON name of the field, e.g. "title" or "operations[0].op".
 	Field string `json:"field"`
+
+	// Rule is the violated rule, e.g. "required" or "max".
+	Rule string `json:"rule"`
+
+	// Params are the parameters of the rule, e.g. {"max": 50}.
+	Params map[string]any `json:"params,omitempty"`
+
+	// Error is the message for the user in the language of the request.
 	Error string `json:"error"`
 }
 
-type ErrorResponse struct {
-	Error  string       `json:"error,omitempty"`
+// Problem is an RFC 7807 problem details object that is written
+// to the HTTP response in case of an error.
+type Problem struct {
+	// Type is a URI reference that identifies the problem type.
+	Type string `json:"type"`
+
+	// Title is a short summary of the problem, which is the status text of Status.
+	Title string `json:"title"`
+
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+
+	// Instance is the ID of the request that caused the problem.
+	Instance string `json:"instance,omitempty"`
+
+	// TraceID is the ID of the trace of the request, if the request is traced.
+	TraceID string `json:"trace_id,omitempty"`
+
+	// Code is a stable machine-readable code of the problem, e.g. TASK_NOT_FOUND.
+	Code string `json:"code"`
+
+	// Errors contains the invalid fields of the request body, if any.
 	Errors []FieldError `json:"errors,omitempty"`
 }
 
-// WriteError writes given code and error to the HTTP response.
-// WriteError handles validation errors as well.
-func WriteError(w http.ResponseWriter, code int, err error) {
-	resp := makeResponse(err)
+// Error is an error that is written to the HTTP response
+// with the given status code, problem code and detail.
+type Error struct {
+	Status int
+	Code   string
+	Detail string
+}
 
-	data, mErr := json.Marshal(resp)
-	if mErr != nil {
-		http.Error(w, "failed to encode error", http.StatusInternalServerError)
-		return
+// NewError creates a new Error.
+func NewError(status int, code, detail string) *Error {
+	return &Error{
+		Status: status,
+		Code:   code,
+		Detail: detail,
 	}
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(code)
-	_, _ = w.Write(data)
+func (e *Error) Error() string {
+	return e.Detail
 }
 
-func makeResponse(err error) ErrorResponse {
-	if vErrs, ok := err.(validator.ValidationErrors); ok {
-		return ErrorResponse{Errors: makeValidationErrors(vErrs)}
+// Problem returns the problem details of e.
+func (e *Error) Problem() Problem {
+	return Problem{
+		Type:   ProblemTypeBase + strings.ToLower(strings.ReplaceAll(e.Code, "_", "-")),
+		Title:  http.StatusText(e.Status),
+		Status: e.Status,
+		Detail: e.Detail,
+		Code:   e.Code,
 	}
+}
 
-	return ErrorResponse{Error: err.Error()}
+// Common errors of the HTTP layer.
+var (
+	ErrBadRequest           = NewError(http.StatusBadRequest, "BAD_REQUEST", "bad request")
+	ErrUnauthorized         = NewError(http.StatusUnauthorized, "UNAUTHORIZED", "unauthorized")
+	ErrNotFound             = NewError(http.StatusNotFound, "NOT_FOUND", "resource not found")
+	ErrMethodNotAllowed     = NewError(http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+	ErrInternal             = NewError(http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+	ErrRequestBodyEmpty     = NewError(http.StatusBadRequest, "REQUEST_BODY_EMPTY", "request body is empty")
+	ErrRequestBodyInvalid   = NewError(http.StatusBadRequest, "REQUEST_BODY_INVALID", "failed to decode request body")
+	ErrRequestBodyTooLarge  = NewError(http.StatusRequestEntityTooLarge, "REQUEST_BODY_TOO_LARGE", "request body is too large")
+	ErrUnsupportedMediaType = NewError(
+		http.StatusUnsupportedMediaType,
+		"UNSUPPORTED_MEDIA_TYPE",
+		"content type must be application/json",
+	)
+	ErrValidationFailed    = NewError(http.StatusBadRequest, "VALIDATION_FAILED", "request body is invalid")
+	ErrTaskVersionMismatch = NewError(http.StatusPreconditionFailed, "TASK_VERSION_MISMATCH", "task version mismatch")
+)
+
+// MissingParameter returns an error about the required request parameter that is missing.
+func MissingParameter(name string) *Error {
+	return NewError(http.StatusBadRequest, "MISSING_PARAMETER", name+" is required")
 }
 
-func makeValidationErrors(errs validator.ValidationErrors) []FieldError {
-	fieldErrors := make([]FieldError, 0, len(errs))
+// InvalidParameter returns an error about the request parameter that has an invalid value.
+func InvalidParameter(name string) *Error {
+	return NewError(http.StatusBadRequest, "INVALID_PARAMETER", "invalid "+name+" parameter")
+}
 
-	for _, err := range errs {
-		switch err.ActualTag() {
-		case "required":
-			fieldErrors = append(fieldErrors, FieldError{Field: err.Field(), Error: "field is required"})
+// NewProblem returns the problem details for err with the field error messages in English.
+func NewProblem(err error) Problem {
+	return NewLocalizedProblem(err, English)
+}
 
-		case "email":
-			fieldErrors = append(fieldErrors, FieldError{Field: err.Field(), Error: "field is not a valid email"})
+// NewLocalizedProblem returns the problem details for err with the field error messages in lang.
+//
+// Validation errors are reported with the invalid fields. An *Error in the chain of err
+// is reported as is, and the known domain and service errors are reported according to
+// the central mapping, together with the invalid field if the error is caused by one.
+// Any other error is reported as an internal server error, so that its text does not leak
+// to the client.
+func NewLocalizedProblem(err error, lang Language) Problem {
+	if vErrs, ok := errors.AsType[validator.ValidationErrors](err); ok {
+		problem := ErrValidationFailed.Problem()
+		for _, v := range validationViolations(vErrs) {
+			problem.Errors = append(problem.Errors, v.fieldError(lang))
+		}
 
-		case "printascii":
-			fieldErrors = append(
-				fieldErrors,
-				FieldError{Field: err.Field(), Error: "field contains invalid characters"},
-			)
+		return problem
+	}
 
-		default:
-			fieldErrors = append(fieldErrors, FieldError{Field: err.Field(), Error: "field is invalid"})
+	if e, ok := errors.AsType[*Error](err); ok {
+		return e.Problem()
+	}
+
+	for _, p := range problems {
+		if errors.Is(err, p.err) {
+			problem := p.problem.Problem()
+			if v, ok := fieldViolation(err); ok {
+				problem.Errors = []FieldError{v.fieldError(lang)}
+			}
+
+			return problem
 		}
 	}
 
-	return fieldErrors
+	return ErrInternal.Problem()
+}
+
+// WriteError writes the problem details for err to the HTTP response.
+// The status code of the response is taken from the problem, the ID of the request
+// is used as the problem instance and the ID of its trace is reported as well.
+// The field error messages are in the language negotiated with RequestLanguage.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	lang := RequestLanguage(r)
+
+	problem := NewLocalizedProblem(err, lang)
+	problem.Instance = middleware.GetReqID(r.Context())
+
+	if sc := trace.SpanContextFromContext(r.Context()); sc.HasTraceID() {
+		problem.TraceID = sc.TraceID().String()
+	}
+
+	if len(problem.Errors) > 0 {
+		w.Header().Set("Content-Language", string(lang))
+	}
+
+	writeProblem(w, problem)
+}
+
+func writeProblem(w http.ResponseWriter, problem Problem) {
+	data, err := json.Marshal(problem)
+	if err != nil {
+		http.Error(w, "failed to encode error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+	_, _ = w.Write(data)
+}
+
+// NotFound writes the problem for a request to an unknown route.
+func NotFound(w http.ResponseWriter, r *http.Request) {
+	WriteError(w, r, ErrNotFound)
+}
+
+// MethodNotAllowed writes the problem for a request with a method that the route does not support.
+func MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	WriteError(w, r, ErrMethodNotAllowed)
 }
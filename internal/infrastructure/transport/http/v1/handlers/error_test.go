@@ -0,0 +1,132 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/vo"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProblem(t *testing.T) {
+	type request struct {
+		Title string `json:"title" validate:"required"`
+	}
+
+	validationErr := handlers.NewValidator().Struct(request{})
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+		wantType   string
+		wantErrors []handlers.FieldError
+	}{
+		{
+			name:       "service error",
+			err:        services.ErrTaskNotFound,
+			wantStatus: http.StatusNotFound,
+			wantCode:   "TASK_NOT_FOUND",
+			wantDetail: "task not found",
+			wantType:   "/problems/task-not-found",
+		},
+		{
+			name:       "wrapped value object error",
+			err:        fmt.Errorf("operation 2: %w", vo.ErrTitleTooLong),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "TITLE_TOO_LONG",
+			wantDetail: "title is too long",
+			wantType:   "/problems/title-too-long",
+			wantErrors: []handlers.FieldError{{
+				Field:  "title",
+				Rule:   "max",
+				Params: map[string]any{"max": vo.TitleMaxLength},
+				Error:  "must be at most 50 characters",
+			}},
+		},
+		{
+			name:       "http layer error",
+			err:        handlers.InvalidParameter("limit"),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "INVALID_PARAMETER",
+			wantDetail: "invalid limit parameter",
+			wantType:   "/problems/invalid-parameter",
+		},
+		{
+			name:       "version conflict of a conditional request",
+			err:        handlers.PreconditionError(services.ErrTaskConflict, new(int64(3))),
+			wantStatus: http.StatusPreconditionFailed,
+			wantCode:   "TASK_VERSION_MISMATCH",
+			wantDetail: "task version mismatch",
+			wantType:   "/problems/task-version-mismatch",
+		},
+		{
+			name:       "version conflict of an unconditional request",
+			err:        handlers.PreconditionError(services.ErrTaskConflict, nil),
+			wantStatus: http.StatusConflict,
+			wantCode:   "TASK_CONFLICT",
+			wantDetail: "task was modified concurrently",
+			wantType:   "/problems/task-conflict",
+		},
+		{
+			name:       "validation error",
+			err:        validationErr,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "VALIDATION_FAILED",
+			wantDetail: "request body is invalid",
+			wantType:   "/problems/validation-failed",
+			wantErrors: []handlers.FieldError{{Field: "title", Rule: "required", Error: "is required"}},
+		},
+		{
+			name:       "internal failure does not leak its text",
+			err:        fmt.Errorf("%w: %s", services.ErrTaskUpdateFailed, errors.New("connection refused")),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "INTERNAL_ERROR",
+			wantDetail: "internal server error",
+			wantType:   "/problems/internal-error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problem := handlers.NewProblem(tt.err)
+
+			require.Equal(t, tt.wantStatus, problem.Status)
+			require.Equal(t, http.StatusText(tt.wantStatus), problem.Title)
+			require.Equal(t, tt.wantCode, problem.Code)
+			require.Equal(t, tt.wantDetail, problem.Detail)
+			require.Equal(t, tt.wantType, problem.Type)
+			require.Equal(t, tt.wantErrors, problem.Errors)
+		})
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	req := httptest.NewRequestWithContext(
+		context.WithValue(context.Background(), middleware.RequestIDKey, "host/abc-000001"),
+		http.MethodGet,
+		"/tasks/1",
+		nil,
+	)
+
+	rr := httptest.NewRecorder()
+	handlers.WriteError(rr, req, services.ErrTaskAccessDenied)
+
+	require.Equal(t, http.StatusForbidden, rr.Code)
+	require.Equal(t, handlers.ProblemContentType, rr.Header().Get("Content-Type"))
+	require.JSONEq(
+		t,
+		`{"type":"/problems/task-access-denied","title":"Forbidden","status":403,`+
+			`"detail":"access denied","instance":"host/abc-000001","code":"TASK_ACCESS_DENIED"}`,
+		rr.Body.String(),
+	)
+}
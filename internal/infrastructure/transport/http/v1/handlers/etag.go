@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ErrIfMatchInvalid is returned by ParseIfMatch if the If-Match header
+// does not contain a single strong entity tag produced by FormatETag.
+var ErrIfMatchInvalid = NewError(http.StatusBadRequest, "INVALID_IF_MATCH", "invalid If-Match header")
+
+// FormatETag returns a strong entity tag for the given resource version.
+func FormatETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// ParseIfMatch extracts the expected resource version from the If-Match header of r.
+//
+// It returns nil if the header is absent or equals "*", which means that
+// the request is not conditional. Weak tags and lists of tags are not supported
+// and make ParseIfMatch return ErrIfMatchInvalid.
+func ParseIfMatch(r *http.Request) (*int64, error) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+
+	if len(header) < 2 || header[0] != '"' || header[len(header)-1] != '"' {
+		return nil, ErrIfMatchInvalid
+	}
+
+	version, err := strconv.ParseInt(header[1:len(header)-1], 10, 64)
+	if err != nil || version < 1 {
+		return nil, ErrIfMatchInvalid
+	}
+
+	return &version, nil
+}
@@ -0,0 +1,19 @@
+package graphql
+
+import "github.com/graphql-go/graphql/gqlerrors"
+
+// Request is a GraphQL request as it is sent by the common clients.
+type Request struct {
+	Query         string         `json:"query" validate:"required"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+
+	// Extensions are sent by some clients, e.g. for persisted queries, which are not supported.
+	Extensions map[string]any `json:"extensions"`
+}
+
+// Response is the result of a GraphQL request.
+type Response struct {
+	Data   any                        `json:"data"`
+	Errors []gqlerrors.FormattedError `json:"errors,omitempty" swaggertype:"array,object"`
+}
@@ -0,0 +1,224 @@
+// Package graphql serves the GraphQL API, which lets the clients fetch the user and the tasks
+// with only the fields they need in a single request. It is resolved with the same services
+// as the REST API, and its errors are reported with the same problem codes.
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	taskModels "github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	userModels "github.com/cyberbrain-dev/taskery-api/internal/domain/user/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
+	"github.com/go-playground/validator/v10"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+type TaskService interface {
+	Create(ctx context.Context, cmd services.CreateTaskCommand) (string, error)
+	Update(
+		ctx context.Context,
+		id string,
+		ownerID string,
+		cmd services.UpdateTaskCommand,
+		expectedVersion *int64,
+	) (int64, error)
+	RemoveDeadline(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+	Complete(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+	Reopen(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+	FindByID(ctx context.Context, id string, ownerID string) (*taskModels.Task, error)
+	FindByIDs(ctx context.Context, ids []string, ownerID string) ([]*taskModels.Task, error)
+	FindByOwner(ctx context.Context, ownerID string, query services.FindByOwnerQuery) ([]*taskModels.Task, error)
+	Delete(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+	DeletePermanently(ctx context.Context, id string, ownerID string, expectedVersion *int64) error
+	Restore(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+	FindTrash(ctx context.Context, ownerID string) ([]*taskModels.Task, error)
+	Search(ctx context.Context, ownerID string, query services.SearchTasksQuery) ([]services.TaskSearchResult, error)
+	Archive(ctx context.Context, id string, ownerID string, force bool, expectedVersion *int64) (int64, error)
+	Unarchive(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+	ArchiveCompleted(ctx context.Context, ownerID string, olderThan time.Duration) (int64, error)
+	Histories(ctx context.Context, ids []string, ownerID string) (map[string][]*taskModels.TaskEvent, error)
+	Revert(ctx context.Context, id string, ownerID string, eventID string, expectedVersion *int64) (int64, error)
+}
+
+type UserService interface {
+	FindByIDs(ctx context.Context, ids []string) ([]*userModels.User, error)
+}
+
+type Handler struct {
+	taskService TaskService
+	userService UserService
+	schema      graphql.Schema
+	limits      Limits
+	timeout     time.Duration
+	logger      *slog.Logger
+	validate    *validator.Validate
+}
+
+// NewHandler creates a new Handler, which executes the queries that do not exceed limits.
+func NewHandler(
+	taskService TaskService,
+	userService UserService,
+	limits Limits,
+	timeout time.Duration,
+	logger *slog.Logger,
+	validate *validator.Validate,
+) *Handler {
+	schema, err := newSchema(&resolver{
+		taskService: taskService,
+		userService: userService,
+	})
+	if err != nil {
+		// the schema is static, so it is a bug in the code
+		panic(fmt.Sprintf("invalid graphql schema: %s", err))
+	}
+
+	return &Handler{
+		taskService: taskService,
+		userService: userService,
+		schema:      schema,
+		limits:      limits,
+		timeout:     timeout,
+		logger:      logger,
+		validate:    validate,
+	}
+}
+
+// @Summary GraphQL API
+// @Description Executes a GraphQL query or mutation on behalf of the authenticated user. The schema is available by introspection.
+// @Description The response is always 200 once the request is decoded: the failed fields are null and their errors are listed in errors.
+// @Description An error of a resolver has the problem code and the status that the REST API would respond with in its extensions,
+// @Description e.g. {"code": "TASK_NOT_FOUND", "status": 404}. The queries nested too deep or selecting too many fields
+// @Description are not executed and fail with the QUERY_TOO_DEEP and QUERY_TOO_COMPLEX codes.
+// @Tags graphql
+// @Accept json
+// @Produce json
+// @Param request body Request true "GraphQL request"
+// @Security     BearerAuth
+// @Success 200 {object} Response
+// @Failure 400 {object} handlers.Problem
+// @Failure 401 {object} handlers.Problem
+// @Failure 429 {object} handlers.Problem
+// @Router /graphql [post]
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.GraphQL"
+
+	logger := slogx.FromContext(r.Context(), h.logger).With(slog.String("op", op))
+
+	req, ok := handlers.DecodeAndValidate[Request](w, r, logger, h.validate)
+	if !ok {
+		return
+	}
+
+	userID := myMw.GetUserID(r.Context())
+	if userID == "" {
+		logger.Error("failed to extract user id")
+		handlers.WriteError(w, r, handlers.ErrBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	ctx = withLoaders(ctx, newLoaders(h.taskService, h.userService, userID))
+
+	result := h.execute(ctx, req)
+
+	handlers.WriteJSON(w, http.StatusOK, Response{
+		Data:   result.Data,
+		Errors: h.formatErrors(r, logger, result.Errors),
+	})
+}
+
+// execute parses and validates the query, checks it against the limits and executes it.
+func (h *Handler) execute(ctx context.Context, req *Request) *graphql.Result {
+	doc, err := parser.Parse(parser.ParseParams{Source: req.Query})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	if validation := graphql.ValidateDocument(&h.schema, doc, nil); !validation.IsValid {
+		return &graphql.Result{Errors: validation.Errors}
+	}
+
+	if err := h.limits.check(&h.schema, doc, req.OperationName, req.Variables); err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       ctx,
+	})
+}
+
+// formatErrors reports the errors of the resolvers as the problems of the REST API:
+// the message is the detail of the problem, and the code and the status of the problem
+// are added to the extensions, along with the invalid fields, if any. The internal errors
+// are logged, since their messages are not reported to the client.
+// The errors of the query itself, e.g. an unknown field, are reported as is.
+func (h *Handler) formatErrors(
+	r *http.Request,
+	logger *slog.Logger,
+	errs []gqlerrors.FormattedError,
+) []gqlerrors.FormattedError {
+	lang := handlers.RequestLanguage(r)
+
+	for i, e := range errs {
+		rErr, ok := originalResolverError(e)
+		if !ok {
+			continue
+		}
+
+		problem := handlers.NewLocalizedProblem(rErr.err, lang)
+		if problem.Status == http.StatusInternalServerError {
+			logger.Error(
+				"failed to resolve field",
+				slog.Any("path", e.Path),
+				slog.String("err", rErr.err.Error()),
+			)
+		}
+
+		extensions := map[string]any{
+			"code":   problem.Code,
+			"status": problem.Status,
+		}
+		if len(problem.Errors) > 0 {
+			extensions["errors"] = problem.Errors
+		}
+
+		errs[i].Message = problem.Detail
+		errs[i].Extensions = extensions
+	}
+
+	return errs
+}
+
+// originalResolverError returns the error of the resolver that caused err, if any.
+// The executor wraps the errors of the resolvers, so the chain of its errors is followed
+// until an error that it has not created.
+func originalResolverError(err error) (*resolverError, bool) {
+	for err != nil {
+		switch e := err.(type) {
+		case gqlerrors.FormattedError:
+			err = e.OriginalError()
+		case *gqlerrors.Error:
+			err = e.OriginalError
+		default:
+			return errors.AsType[*resolverError](err)
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,377 @@
+package graphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	taskModels "github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	taskVO "github.com/cyberbrain-dev/taskery-api/internal/domain/task/vo"
+	userModels "github.com/cyberbrain-dev/taskery-api/internal/domain/user/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/graphql"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/graphql/mocks"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type graphqlError struct {
+	Message    string         `json:"message"`
+	Extensions map[string]any `json:"extensions"`
+}
+
+type graphqlResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []graphqlError  `json:"errors"`
+}
+
+func TestHandler(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	clk := clock.NewFake(now)
+
+	user, err := userModels.NewUser("alex123", "alex@example.com", "correct_pass", clk)
+	require.NoError(t, err)
+	userID := user.ID().String()
+
+	first, err := taskModels.NewTask("First", "first task", user.ID(), clk)
+	require.NoError(t, err)
+	second, err := taskModels.NewTask("Second", "", user.ID(), clk)
+	require.NoError(t, err)
+	second.Complete(clk)
+
+	created := taskModels.NewTaskEvent(first, taskModels.TaskEventCreated, user.ID(), nil, clk)
+
+	limits := graphql.Limits{MaxDepth: 5, MaxComplexity: 3000}
+
+	tests := []struct {
+		name          string
+		body          string
+		userID        string
+		expectedCode  int
+		expectedData  string
+		expectedError *graphqlError
+		mocksSetup    func(tasks *mocks.TaskService, users *mocks.UserService)
+	}{
+		{
+			name:         "tasks with owners and histories are loaded in batches",
+			body:         `{"query": "{ tasks(limit: 2) { id title isCompleted owner { username } history(limit: 5) { type actor { id } changes { field after } } } }"}`,
+			userID:       userID,
+			expectedCode: http.StatusOK,
+			expectedData: `{"tasks": [
+				{
+					"id": "` + first.ID().String() + `",
+					"title": "First",
+					"isCompleted": false,
+					"owner": {"username": "alex123"},
+					"history": [{
+						"type": "created",
+						"actor": {"id": "` + userID + `"},
+						"changes": [
+							{"field": "title", "after": "First"},
+							{"field": "description", "after": "first task"}
+						]
+					}]
+				},
+				{
+					"id": "` + second.ID().String() + `",
+					"title": "Second",
+					"isCompleted": true,
+					"owner": {"username": "alex123"},
+					"history": []
+				}
+			]}`,
+			mocksSetup: func(tasks *mocks.TaskService, users *mocks.UserService) {
+				tasks.On("FindByOwner", mock.Anything, userID, services.FindByOwnerQuery{}).
+					Once().
+					Return([]*taskModels.Task{first, second}, nil)
+
+				tasks.On("Histories", mock.Anything, []string{first.ID().String(), second.ID().String()}, userID).
+					Once().
+					Return(map[string][]*taskModels.TaskEvent{
+						first.ID().String():  {created},
+						second.ID().String(): {},
+					}, nil)
+
+				users.On("FindByIDs", mock.Anything, []string{userID}).
+					Once().
+					Return([]*userModels.User{user}, nil)
+			},
+		},
+		{
+			name:         "me with filtered tasks",
+			body:         `{"query": "query Me($done: Boolean) { me { email tasks(filter: {sort: CREATED_AT_DESC, isCompleted: $done}) { title } projects { id } } }", "variables": {"done": true}}`,
+			userID:       userID,
+			expectedCode: http.StatusOK,
+			expectedData: `{"me": {"email": "alex@example.com", "tasks": [{"title": "Second"}], "projects": []}}`,
+			mocksSetup: func(tasks *mocks.TaskService, users *mocks.UserService) {
+				users.On("FindByIDs", mock.Anything, []string{userID}).
+					Once().
+					Return([]*userModels.User{user}, nil)
+
+				tasks.On("FindByOwner", mock.Anything, userID, services.FindByOwnerQuery{Sort: services.TaskSortCreatedAtDesc}).
+					Once().
+					Return([]*taskModels.Task{first, second}, nil)
+			},
+		},
+		{
+			name:         "task not found",
+			body:         `{"query": "{ task(id: \"` + first.ID().String() + `\") { id } }"}`,
+			userID:       userID,
+			expectedCode: http.StatusOK,
+			expectedData: `{"task": null}`,
+			mocksSetup: func(tasks *mocks.TaskService, users *mocks.UserService) {
+				tasks.On("FindByIDs", mock.Anything, []string{first.ID().String()}, userID).
+					Once().
+					Return([]*taskModels.Task{}, nil)
+			},
+		},
+		{
+			name:         "complete task",
+			body:         `{"query": "mutation { completeTask(id: \"` + second.ID().String() + `\", expectedVersion: 1) { isCompleted version } }"}`,
+			userID:       userID,
+			expectedCode: http.StatusOK,
+			expectedData: `{"completeTask": {"isCompleted": true, "version": 1}}`,
+			mocksSetup: func(tasks *mocks.TaskService, users *mocks.UserService) {
+				tasks.On("Complete", mock.Anything, second.ID().String(), userID, new(int64(1))).
+					Once().
+					Return(int64(2), nil)
+
+				tasks.On("FindByID", mock.Anything, second.ID().String(), userID).
+					Once().
+					Return(second, nil)
+			},
+		},
+		{
+			name:         "version mismatch",
+			body:         `{"query": "mutation { completeTask(id: \"` + first.ID().String() + `\", expectedVersion: 3) { id } }"}`,
+			userID:       userID,
+			expectedCode: http.StatusOK,
+			expectedData: `null`,
+			expectedError: &graphqlError{
+				Message:    "task version mismatch",
+				Extensions: map[string]any{"code": "TASK_VERSION_MISMATCH", "status": float64(http.StatusPreconditionFailed)},
+			},
+			mocksSetup: func(tasks *mocks.TaskService, users *mocks.UserService) {
+				tasks.On("Complete", mock.Anything, first.ID().String(), userID, new(int64(3))).
+					Once().
+					Return(int64(0), services.ErrTaskConflict)
+			},
+		},
+		{
+			name:         "delete task",
+			body:         `{"query": "mutation { deleteTask(id: \"` + first.ID().String() + `\") }"}`,
+			userID:       userID,
+			expectedCode: http.StatusOK,
+			expectedData: `{"deleteTask": "` + first.ID().String() + `"}`,
+			mocksSetup: func(tasks *mocks.TaskService, users *mocks.UserService) {
+				tasks.On("Delete", mock.Anything, first.ID().String(), userID, (*int64)(nil)).
+					Once().
+					Return(int64(2), nil)
+			},
+		},
+		{
+			name:         "invalid title",
+			body:         `{"query": "mutation { createTask(input: {title: \"\"}) { id } }"}`,
+			userID:       userID,
+			expectedCode: http.StatusOK,
+			expectedData: `null`,
+			expectedError: &graphqlError{
+				Message: "title is empty",
+				Extensions: map[string]any{
+					"code":   "TITLE_EMPTY",
+					"status": float64(http.StatusBadRequest),
+					"errors": []any{map[string]any{"field": "title", "rule": "required", "error": "is required"}},
+				},
+			},
+			mocksSetup: func(tasks *mocks.TaskService, users *mocks.UserService) {
+				tasks.On("Create", mock.Anything, services.CreateTaskCommand{OwnerID: user.ID()}).
+					Once().
+					Return("", taskVO.ErrTitleEmpty)
+			},
+		},
+		{
+			name:         "internal error of a loader",
+			body:         `{"query": "{ me { id } }"}`,
+			userID:       userID,
+			expectedCode: http.StatusOK,
+			expectedData: `{"me": null}`,
+			expectedError: &graphqlError{
+				Message:    "internal server error",
+				Extensions: map[string]any{"code": "INTERNAL_ERROR", "status": float64(http.StatusInternalServerError)},
+			},
+			mocksSetup: func(tasks *mocks.TaskService, users *mocks.UserService) {
+				users.On("FindByIDs", mock.Anything, []string{userID}).
+					Once().
+					Return(nil, errors.New("failed to connect to db"))
+			},
+		},
+		{
+			name:         "query too deep",
+			body:         `{"query": "{ tasks { history { actor { tasks { owner { id } } } } } }"}`,
+			userID:       userID,
+			expectedCode: http.StatusOK,
+			expectedData: `null`,
+			expectedError: &graphqlError{
+				Message: "query depth 6 exceeds the limit of 5",
+				Extensions: map[string]any{
+					"code":   "QUERY_TOO_DEEP",
+					"params": map[string]any{"depth": float64(6), "max": float64(5)},
+				},
+			},
+		},
+		{
+			name:         "fragments are measured in place",
+			body:         `{"query": "{ ...Deep } fragment Deep on Query { tasks { history { actor { tasks { owner { id } } } } } }"}`,
+			userID:       userID,
+			expectedCode: http.StatusOK,
+			expectedData: `null`,
+			expectedError: &graphqlError{
+				Message: "query depth 6 exceeds the limit of 5",
+				Extensions: map[string]any{
+					"code":   "QUERY_TOO_DEEP",
+					"params": map[string]any{"depth": float64(6), "max": float64(5)},
+				},
+			},
+		},
+		{
+			name:         "introspection is not limited",
+			body:         `{"query": "{ __type(name: \"FieldChange\") { fields { name type { ofType { ofType { ofType { name } } } } } } }"}`,
+			userID:       userID,
+			expectedCode: http.StatusOK,
+			expectedData: `{"__type": {"fields": [
+				{"name": "after", "type": {"ofType": null}},
+				{"name": "before", "type": {"ofType": null}},
+				{"name": "field", "type": {"ofType": {"ofType": null}}}
+			]}}`,
+		},
+		{
+			name:         "query too complex",
+			body:         `{"query": "{ searchTasks(query: \"milk\", limit: 100) { task { history { changes { field } } } } }"}`,
+			userID:       userID,
+			expectedCode: http.StatusOK,
+			expectedData: `null`,
+			expectedError: &graphqlError{
+				Message: "query complexity 55201 exceeds the limit of 3000",
+				Extensions: map[string]any{
+					"code":   "QUERY_TOO_COMPLEX",
+					"params": map[string]any{"complexity": float64(55201), "max": float64(3000)},
+				},
+			},
+		},
+		{
+			name:         "lists are limited",
+			body:         `{"query": "{ tasks(limit: 1) { title } trash(limit: 1) { title } }"}`,
+			userID:       userID,
+			expectedCode: http.StatusOK,
+			expectedData: `{"tasks": [{"title": "First"}], "trash": [{"title": "Second"}]}`,
+			mocksSetup: func(tasks *mocks.TaskService, users *mocks.UserService) {
+				tasks.On("FindByOwner", mock.Anything, userID, services.FindByOwnerQuery{}).
+					Once().
+					Return([]*taskModels.Task{first, second}, nil)
+
+				tasks.On("FindTrash", mock.Anything, userID).
+					Once().
+					Return([]*taskModels.Task{second, first}, nil)
+			},
+		},
+		{
+			name:         "limit too large",
+			body:         `{"query": "{ tasks(limit: 101) { title } }"}`,
+			userID:       userID,
+			expectedCode: http.StatusOK,
+			expectedData: `null`,
+			expectedError: &graphqlError{
+				Message:    "invalid limit parameter",
+				Extensions: map[string]any{"code": "INVALID_PARAMETER", "status": float64(http.StatusBadRequest)},
+			},
+		},
+		{
+			name:         "archive completed too long ago",
+			body:         `{"query": "mutation { archiveCompleted(olderThanDays: 36501) }"}`,
+			userID:       userID,
+			expectedCode: http.StatusOK,
+			expectedData: `null`,
+			expectedError: &graphqlError{
+				Message:    "invalid olderThanDays parameter",
+				Extensions: map[string]any{"code": "INVALID_PARAMETER", "status": float64(http.StatusBadRequest)},
+			},
+		},
+		{
+			name:         "unknown field",
+			body:         `{"query": "{ tasks { priority } }"}`,
+			userID:       userID,
+			expectedCode: http.StatusOK,
+			expectedData: `null`,
+			expectedError: &graphqlError{
+				Message: `Cannot query field "priority" on type "Task".`,
+			},
+		},
+		{
+			name:         "empty query",
+			body:         `{"query": ""}`,
+			userID:       userID,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "empty user id",
+			body:         `{"query": "{ me { id } }"}`,
+			userID:       "",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), myMw.UserIDKey, tt.userID)
+
+			req := httptest.NewRequestWithContext(ctx, http.MethodPost, "/graphql", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			rr := httptest.NewRecorder()
+
+			tasks := new(mocks.TaskService)
+			users := new(mocks.UserService)
+			if tt.mocksSetup != nil {
+				tt.mocksSetup(tasks, users)
+			}
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+
+			h := graphql.NewHandler(tasks, users, limits, 4*time.Second, logger, handlers.NewValidator())
+			h.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedCode, rr.Code)
+
+			tasks.AssertExpectations(t)
+			users.AssertExpectations(t)
+
+			if tt.expectedCode != http.StatusOK {
+				return
+			}
+
+			var resp graphqlResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			if tt.expectedError == nil {
+				require.Empty(t, resp.Errors)
+				require.JSONEq(t, tt.expectedData, string(resp.Data))
+				return
+			}
+
+			require.JSONEq(t, tt.expectedData, string(resp.Data))
+
+			require.Len(t, resp.Errors, 1)
+			require.Equal(t, *tt.expectedError, resp.Errors[0])
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/location"
+)
+
+// DefaultListSize is the number of items that a list field without a limit argument
+// is assumed to return when the complexity of a query is estimated.
+const DefaultListSize = 10
+
+// DefaultListLimit is the number of items that a list field with the limit argument returns
+// if the argument is left out, and MaxListLimit is the greatest limit that can be requested.
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 100
+)
+
+// Limits limit the queries, so that a single request cannot make the API
+// load the whole database. Zero turns a limit off.
+type Limits struct {
+	// MaxDepth limits the nesting of the fields, e.g. { me { tasks { owner { id } } } } has the depth of 4.
+	MaxDepth int
+
+	// MaxComplexity limits the estimated number of the resolved fields. Every field costs 1,
+	// and the fields selected on a list cost as many times as many items the list is assumed to have,
+	// which is the limit argument of the field, its default value, or DefaultListSize
+	// if the field has no limit argument.
+	MaxComplexity int
+}
+
+// check returns the error of the operation of doc that exceeds the limits, if any.
+// The document must be valid against the schema. The fields of the introspection, e.g. __schema,
+// are not counted, so that the tools can always fetch the schema.
+func (l Limits) check(schema *graphql.Schema, doc *ast.Document, operationName string, variables map[string]any) error {
+	if l.MaxDepth <= 0 && l.MaxComplexity <= 0 {
+		return nil
+	}
+
+	op, fragments := operation(doc, operationName)
+	if op == nil {
+		// the executor reports the missing operation
+		return nil
+	}
+
+	var root *graphql.Object
+	switch op.Operation {
+	case ast.OperationTypeMutation:
+		root = schema.MutationType()
+	case ast.OperationTypeSubscription:
+		root = schema.SubscriptionType()
+	default:
+		root = schema.QueryType()
+	}
+
+	m := measurer{schema: schema, fragments: fragments, variables: variables}
+	depth, complexity := m.measure(root, op.SelectionSet)
+
+	if l.MaxDepth > 0 && depth > l.MaxDepth {
+		return limitError(
+			"QUERY_TOO_DEEP",
+			fmt.Sprintf("query depth %d exceeds the limit of %d", depth, l.MaxDepth),
+			map[string]any{"depth": depth, "max": l.MaxDepth},
+		)
+	}
+
+	if l.MaxComplexity > 0 && complexity > l.MaxComplexity {
+		return limitError(
+			"QUERY_TOO_COMPLEX",
+			fmt.Sprintf("query complexity %d exceeds the limit of %d", complexity, l.MaxComplexity),
+			map[string]any{"complexity": complexity, "max": l.MaxComplexity},
+		)
+	}
+
+	return nil
+}
+
+// limitError returns the error of the query that exceeds a limit, which is reported
+// with the code and the params in its extensions.
+func limitError(code, message string, params map[string]any) gqlerrors.FormattedError {
+	return gqlerrors.FormattedError{
+		Message:   message,
+		Locations: []location.SourceLocation{},
+		Extensions: map[string]any{
+			"code":   code,
+			"params": params,
+		},
+	}
+}
+
+// operation returns the operation of doc that is executed and the fragments of doc by their names.
+func operation(doc *ast.Document, operationName string) (*ast.OperationDefinition, map[string]*ast.FragmentDefinition) {
+	var op *ast.OperationDefinition
+	fragments := make(map[string]*ast.FragmentDefinition)
+
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.OperationDefinition:
+			if operationName == "" || (def.Name != nil && def.Name.Value == operationName) {
+				op = def
+			}
+		case *ast.FragmentDefinition:
+			fragments[def.Name.Value] = def
+		}
+	}
+
+	return op, fragments
+}
+
+// measurer measures the depth and the complexity of the selections of an operation.
+type measurer struct {
+	schema    *graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]any
+}
+
+// measure returns the depth and the complexity of the selection set on an object of the type t.
+// The fragments are measured as if their fields were selected in place.
+func (m measurer) measure(t graphql.Type, set *ast.SelectionSet) (depth, complexity int) {
+	if set == nil {
+		return 0, 0
+	}
+
+	for _, selection := range set.Selections {
+		var d, c int
+
+		switch s := selection.(type) {
+		case *ast.Field:
+			d, c = m.measureField(t, s)
+		case *ast.InlineFragment:
+			d, c = m.measure(m.fragmentType(t, s.TypeCondition), s.SelectionSet)
+		case *ast.FragmentSpread:
+			if f, ok := m.fragments[s.Name.Value]; ok {
+				d, c = m.measure(m.fragmentType(t, f.TypeCondition), f.SelectionSet)
+			}
+		}
+
+		depth = max(depth, d)
+		complexity += c
+	}
+
+	return depth, complexity
+}
+
+func (m measurer) measureField(parent graphql.Type, field *ast.Field) (depth, complexity int) {
+	if strings.HasPrefix(field.Name.Value, "__") {
+		return 0, 0
+	}
+
+	var (
+		t   graphql.Type
+		def *graphql.FieldDefinition
+	)
+	if obj, ok := parent.(*graphql.Object); ok {
+		if def = obj.Fields()[field.Name.Value]; def != nil {
+			t = def.Type
+		}
+	}
+
+	isList := false
+	for {
+		if nonNull, ok := t.(*graphql.NonNull); ok {
+			t = nonNull.OfType
+			continue
+		}
+		if list, ok := t.(*graphql.List); ok {
+			isList = true
+			t = list.OfType
+			continue
+		}
+		break
+	}
+
+	childDepth, childComplexity := m.measure(t, field.SelectionSet)
+
+	if isList {
+		childComplexity *= m.listSize(def, field)
+	}
+
+	return 1 + childDepth, 1 + childComplexity
+}
+
+// listSize returns the number of items that the list field with the definition def is assumed to return.
+func (m measurer) listSize(def *graphql.FieldDefinition, field *ast.Field) int {
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != "limit" {
+			continue
+		}
+
+		switch v := arg.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(v.Value); err == nil && n > 0 {
+				return n
+			}
+		case *ast.Variable:
+			switch n := m.variables[v.Name.Value].(type) {
+			case int:
+				if n > 0 {
+					return n
+				}
+			case float64:
+				if n > 0 {
+					return int(n)
+				}
+			}
+		}
+	}
+
+	if def != nil {
+		for _, arg := range def.Args {
+			if n, ok := arg.DefaultValue.(int); ok && arg.Name() == "limit" && n > 0 {
+				return n
+			}
+		}
+	}
+
+	return DefaultListSize
+}
+
+// fragmentType returns the type of the fragment with the type condition on an object of the type t.
+func (m measurer) fragmentType(t graphql.Type, cond *ast.Named) graphql.Type {
+	if cond == nil || cond.Name == nil {
+		return t
+	}
+
+	return m.schema.Type(cond.Name.Value)
+}
@@ -0,0 +1,157 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+
+	taskModels "github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	userModels "github.com/cyberbrain-dev/taskery-api/internal/domain/user/models"
+)
+
+// batchFunc loads the values of many keys at once. The keys without a value are left out of the result.
+type batchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// loader batches the loads of the values by their keys, so that resolving a field
+// of every item of a list costs a single call of the service instead of one per item.
+//
+// The resolvers call load, which only queues the key and returns a thunk. The executor calls
+// the thunks breadth-first, after all the fields of the current level are resolved,
+// so the first thunk that is called loads all the keys queued by then at once.
+// The loaded values are cached for the rest of the request.
+type loader[K comparable, V any] struct {
+	batch batchFunc[K, V]
+
+	mu      sync.Mutex
+	queue   []K
+	results map[K]V
+	errs    map[K]error
+}
+
+func newLoader[K comparable, V any](batch batchFunc[K, V]) *loader[K, V] {
+	return &loader[K, V]{
+		batch:   batch,
+		results: make(map[K]V),
+		errs:    make(map[K]error),
+	}
+}
+
+// load queues the key and returns the thunk that resolves to the value of the key,
+// or to the zero value if the key has no value.
+func (l *loader[K, V]) load(ctx context.Context, key K) func() (V, error) {
+	l.mu.Lock()
+	if !l.loaded(key) {
+		l.queue = append(l.queue, key)
+	}
+	l.mu.Unlock()
+
+	return func() (V, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if !l.loaded(key) {
+			l.dispatch(ctx)
+		}
+
+		if err, ok := l.errs[key]; ok {
+			var zero V
+			return zero, err
+		}
+
+		return l.results[key], nil
+	}
+}
+
+// loaded reports whether the value of the key is loaded or failed to load.
+// The caller must hold l.mu.
+func (l *loader[K, V]) loaded(key K) bool {
+	if _, ok := l.results[key]; ok {
+		return true
+	}
+
+	_, ok := l.errs[key]
+	return ok
+}
+
+// dispatch loads the queued keys. A key without a value is cached with the zero value,
+// so that it is not loaded again. The caller must hold l.mu.
+func (l *loader[K, V]) dispatch(ctx context.Context) {
+	keys := make([]K, 0, len(l.queue))
+	queued := make(map[K]struct{}, len(l.queue))
+	for _, key := range l.queue {
+		if _, ok := queued[key]; ok || l.loaded(key) {
+			continue
+		}
+
+		queued[key] = struct{}{}
+		keys = append(keys, key)
+	}
+	l.queue = nil
+
+	if len(keys) == 0 {
+		return
+	}
+
+	values, err := l.batch(ctx, keys)
+	for _, key := range keys {
+		if err != nil {
+			l.errs[key] = err
+			continue
+		}
+
+		l.results[key] = values[key]
+	}
+}
+
+// loaders are the loaders of a request, which are scoped to its user.
+type loaders struct {
+	users     *loader[string, *userModels.User]
+	tasks     *loader[string, *taskModels.Task]
+	histories *loader[string, []*taskModels.TaskEvent]
+}
+
+func newLoaders(tasks TaskService, users UserService, userID string) *loaders {
+	return &loaders{
+		users: newLoader(func(ctx context.Context, ids []string) (map[string]*userModels.User, error) {
+			found, err := users.FindByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+
+			byID := make(map[string]*userModels.User, len(found))
+			for _, user := range found {
+				byID[user.ID().String()] = user
+			}
+
+			return byID, nil
+		}),
+		tasks: newLoader(func(ctx context.Context, ids []string) (map[string]*taskModels.Task, error) {
+			found, err := tasks.FindByIDs(ctx, ids, userID)
+			if err != nil {
+				return nil, err
+			}
+
+			byID := make(map[string]*taskModels.Task, len(found))
+			for _, task := range found {
+				byID[task.ID().String()] = task
+			}
+
+			return byID, nil
+		}),
+		histories: newLoader(func(ctx context.Context, ids []string) (map[string][]*taskModels.TaskEvent, error) {
+			return tasks.Histories(ctx, ids, userID)
+		}),
+	}
+}
+
+type ctxKeyLoaders struct{}
+
+// withLoaders returns a copy of ctx that carries the loaders of the request.
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, ctxKeyLoaders{}, l)
+}
+
+// loadersFromContext returns the loaders carried by ctx.
+func loadersFromContext(ctx context.Context) *loaders {
+	l, _ := ctx.Value(ctxKeyLoaders{}).(*loaders)
+	return l
+}
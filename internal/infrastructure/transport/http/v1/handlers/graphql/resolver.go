@@ -0,0 +1,485 @@
+package graphql
+
+import (
+	"time"
+
+	taskModels "github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	userModels "github.com/cyberbrain-dev/taskery-api/internal/domain/user/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+)
+
+// resolverError is an error of a resolver, which is reported to the client
+// with the problem details of err, see Handler.
+type resolverError struct {
+	err error
+}
+
+func (e *resolverError) Error() string {
+	return e.err.Error()
+}
+
+func (e *resolverError) Unwrap() error {
+	return e.err
+}
+
+// fail returns the error of a resolver that failed with err.
+func fail(err error) error {
+	return &resolverError{err: err}
+}
+
+// thunk adapts the thunk of a loader to the one that the executor calls.
+func thunk[V any](load func() (V, error)) func() (any, error) {
+	return func() (any, error) {
+		v, err := load()
+		if err != nil {
+			return nil, fail(err)
+		}
+
+		return v, nil
+	}
+}
+
+// resolver resolves the fields of the schema with the services.
+// The user of the request is the one authenticated by the JWTAuth middleware.
+type resolver struct {
+	taskService TaskService
+	userService UserService
+}
+
+// ========= Query ====================
+
+func (r *resolver) me(p graphql.ResolveParams) (any, error) {
+	return thunk(loadersFromContext(p.Context).users.load(p.Context, myMw.GetUserID(p.Context))), nil
+}
+
+func (r *resolver) task(p graphql.ResolveParams) (any, error) {
+	id, _ := p.Args["id"].(string)
+
+	return thunk(loadersFromContext(p.Context).tasks.load(p.Context, id)), nil
+}
+
+func (r *resolver) tasks(p graphql.ResolveParams) (any, error) {
+	return r.findTasks(p, myMw.GetUserID(p.Context))
+}
+
+func (r *resolver) trash(p graphql.ResolveParams) (any, error) {
+	limit, err := limitArg(p)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := r.taskService.FindTrash(p.Context, myMw.GetUserID(p.Context))
+	if err != nil {
+		return nil, fail(err)
+	}
+
+	return first(tasks, limit), nil
+}
+
+func (r *resolver) searchTasks(p graphql.ResolveParams) (any, error) {
+	text, _ := p.Args["query"].(string)
+	limit, _ := p.Args["limit"].(int)
+	includeArchived, _ := p.Args["includeArchived"].(bool)
+
+	results, err := r.taskService.Search(p.Context, myMw.GetUserID(p.Context), services.SearchTasksQuery{
+		Text:            text,
+		Limit:           limit,
+		IncludeArchived: includeArchived,
+	})
+	if err != nil {
+		return nil, fail(err)
+	}
+
+	return results, nil
+}
+
+// findTasks returns the active tasks of the owner that match the filter argument of the field.
+// Only the tasks of the authenticated user can be listed.
+func (r *resolver) findTasks(p graphql.ResolveParams, ownerID string) ([]*taskModels.Task, error) {
+	if ownerID != myMw.GetUserID(p.Context) {
+		return nil, fail(services.ErrTaskAccessDenied)
+	}
+
+	limit, err := limitArg(p)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, _ := p.Args["filter"].(map[string]any)
+
+	query := services.FindByOwnerQuery{}
+	query.Sort, _ = filter["sort"].(services.TaskSort)
+	query.IncludeArchived, _ = filter["includeArchived"].(bool)
+	if updatedSince, ok := filter["updatedSince"].(time.Time); ok {
+		query.UpdatedSince = &updatedSince
+	}
+
+	tasks, err := r.taskService.FindByOwner(p.Context, ownerID, query)
+	if err != nil {
+		return nil, fail(err)
+	}
+
+	isCompleted, filterCompleted := filter["isCompleted"].(bool)
+	hasDeadline, filterDeadline := filter["hasDeadline"].(bool)
+
+	filtered := make([]*taskModels.Task, 0, min(len(tasks), limit))
+	for _, task := range tasks {
+		if len(filtered) == limit {
+			break
+		}
+		if filterCompleted && task.IsCompleted() != isCompleted {
+			continue
+		}
+		if filterDeadline && (task.Deadline() != nil) != hasDeadline {
+			continue
+		}
+
+		filtered = append(filtered, task)
+	}
+
+	return filtered, nil
+}
+
+// limitArg returns the limit argument of the list field, which must be from 1 to MaxListLimit.
+func limitArg(p graphql.ResolveParams) (int, error) {
+	limit, _ := p.Args["limit"].(int)
+	if limit < 1 || limit > MaxListLimit {
+		return 0, fail(handlers.InvalidParameter("limit"))
+	}
+
+	return limit, nil
+}
+
+// first returns at most n first items.
+func first[T any](items []T, n int) []T {
+	return items[:min(n, len(items))]
+}
+
+// ========= Mutation =================
+
+func (r *resolver) createTask(p graphql.ResolveParams) (any, error) {
+	input, _ := p.Args["input"].(map[string]any)
+
+	ownerID, err := uuid.Parse(myMw.GetUserID(p.Context))
+	if err != nil {
+		return nil, fail(handlers.ErrBadRequest)
+	}
+
+	cmd := services.CreateTaskCommand{OwnerID: ownerID}
+	cmd.Title, _ = input["title"].(string)
+	cmd.Description, _ = input["description"].(string)
+	if deadline, ok := input["deadline"].(time.Time); ok {
+		cmd.Deadline = &deadline
+	}
+
+	id, err := r.taskService.Create(p.Context, cmd)
+	if err != nil {
+		return nil, fail(err)
+	}
+
+	return r.findTask(p, id)
+}
+
+func (r *resolver) updateTask(p graphql.ResolveParams) (any, error) {
+	input, _ := p.Args["input"].(map[string]any)
+
+	var cmd services.UpdateTaskCommand
+	if title, ok := input["title"].(string); ok {
+		cmd.Title = &title
+	}
+	if description, ok := input["description"].(string); ok {
+		cmd.Description = &description
+	}
+	if deadline, ok := input["deadline"].(time.Time); ok {
+		cmd.Deadline = &deadline
+	}
+
+	return r.mutateTask(p, func(id, ownerID string, expectedVersion *int64) (int64, error) {
+		return r.taskService.Update(p.Context, id, ownerID, cmd, expectedVersion)
+	})
+}
+
+func (r *resolver) removeDeadline(p graphql.ResolveParams) (any, error) {
+	return r.mutateTask(p, func(id, ownerID string, expectedVersion *int64) (int64, error) {
+		return r.taskService.RemoveDeadline(p.Context, id, ownerID, expectedVersion)
+	})
+}
+
+func (r *resolver) completeTask(p graphql.ResolveParams) (any, error) {
+	return r.mutateTask(p, func(id, ownerID string, expectedVersion *int64) (int64, error) {
+		return r.taskService.Complete(p.Context, id, ownerID, expectedVersion)
+	})
+}
+
+func (r *resolver) reopenTask(p graphql.ResolveParams) (any, error) {
+	return r.mutateTask(p, func(id, ownerID string, expectedVersion *int64) (int64, error) {
+		return r.taskService.Reopen(p.Context, id, ownerID, expectedVersion)
+	})
+}
+
+func (r *resolver) archiveTask(p graphql.ResolveParams) (any, error) {
+	force, _ := p.Args["force"].(bool)
+
+	return r.mutateTask(p, func(id, ownerID string, expectedVersion *int64) (int64, error) {
+		return r.taskService.Archive(p.Context, id, ownerID, force, expectedVersion)
+	})
+}
+
+func (r *resolver) unarchiveTask(p graphql.ResolveParams) (any, error) {
+	return r.mutateTask(p, func(id, ownerID string, expectedVersion *int64) (int64, error) {
+		return r.taskService.Unarchive(p.Context, id, ownerID, expectedVersion)
+	})
+}
+
+func (r *resolver) restoreTask(p graphql.ResolveParams) (any, error) {
+	return r.mutateTask(p, func(id, ownerID string, expectedVersion *int64) (int64, error) {
+		return r.taskService.Restore(p.Context, id, ownerID, expectedVersion)
+	})
+}
+
+func (r *resolver) revertTask(p graphql.ResolveParams) (any, error) {
+	eventID, _ := p.Args["eventId"].(string)
+
+	return r.mutateTask(p, func(id, ownerID string, expectedVersion *int64) (int64, error) {
+		return r.taskService.Revert(p.Context, id, ownerID, eventID, expectedVersion)
+	})
+}
+
+// maxOlderThanDays caps the olderThanDays argument of archiveCompleted,
+// so that the age of the archived tasks does not overflow time.Duration.
+const maxOlderThanDays = 36500
+
+func (r *resolver) archiveCompleted(p graphql.ResolveParams) (any, error) {
+	days, _ := p.Args["olderThanDays"].(int)
+	if days < 0 || days > maxOlderThanDays {
+		return nil, fail(handlers.InvalidParameter("olderThanDays"))
+	}
+
+	archived, err := r.taskService.ArchiveCompleted(p.Context, myMw.GetUserID(p.Context), time.Duration(days)*24*time.Hour)
+	if err != nil {
+		return nil, fail(err)
+	}
+
+	return archived, nil
+}
+
+func (r *resolver) deleteTask(p graphql.ResolveParams) (any, error) {
+	id, _ := p.Args["id"].(string)
+	expectedVersion := versionArg(p)
+
+	if _, err := r.taskService.Delete(p.Context, id, myMw.GetUserID(p.Context), expectedVersion); err != nil {
+		return nil, fail(handlers.PreconditionError(err, expectedVersion))
+	}
+
+	return id, nil
+}
+
+func (r *resolver) deleteTaskPermanently(p graphql.ResolveParams) (any, error) {
+	id, _ := p.Args["id"].(string)
+	expectedVersion := versionArg(p)
+
+	if err := r.taskService.DeletePermanently(p.Context, id, myMw.GetUserID(p.Context), expectedVersion); err != nil {
+		return nil, fail(handlers.PreconditionError(err, expectedVersion))
+	}
+
+	return id, nil
+}
+
+// mutateTask applies the mutation to the task with the id argument and returns the mutated task.
+// The version mismatch of the expectedVersion argument is reported as TASK_VERSION_MISMATCH,
+// as the If-Match header of the REST API is. The new version returned by the mutation is dropped,
+// since the client selects it from the returned task.
+func (r *resolver) mutateTask(
+	p graphql.ResolveParams,
+	mutate func(id, ownerID string, expectedVersion *int64) (int64, error),
+) (any, error) {
+	id, _ := p.Args["id"].(string)
+	expectedVersion := versionArg(p)
+
+	if _, err := mutate(id, myMw.GetUserID(p.Context), expectedVersion); err != nil {
+		return nil, fail(handlers.PreconditionError(err, expectedVersion))
+	}
+
+	return r.findTask(p, id)
+}
+
+// findTask returns the task after a mutation. The loaders are not used,
+// since they may have cached the task before it was mutated.
+func (r *resolver) findTask(p graphql.ResolveParams, id string) (any, error) {
+	task, err := r.taskService.FindByID(p.Context, id, myMw.GetUserID(p.Context))
+	if err != nil {
+		return nil, fail(err)
+	}
+
+	return task, nil
+}
+
+// versionArg returns the expectedVersion argument of the field, or nil if it is not given.
+func versionArg(p graphql.ResolveParams) *int64 {
+	version, ok := p.Args["expectedVersion"].(int)
+	if !ok {
+		return nil
+	}
+
+	return new(int64(version))
+}
+
+// ========= User =====================
+
+func (r *resolver) userID(p graphql.ResolveParams) (any, error) {
+	return p.Source.(*userModels.User).ID().String(), nil
+}
+
+func (r *resolver) userUsername(p graphql.ResolveParams) (any, error) {
+	return p.Source.(*userModels.User).Username().String(), nil
+}
+
+func (r *resolver) userEmail(p graphql.ResolveParams) (any, error) {
+	return p.Source.(*userModels.User).Email().String(), nil
+}
+
+func (r *resolver) userCreatedAt(p graphql.ResolveParams) (any, error) {
+	return p.Source.(*userModels.User).CreatedAt(), nil
+}
+
+func (r *resolver) userTasks(p graphql.ResolveParams) (any, error) {
+	return r.findTasks(p, p.Source.(*userModels.User).ID().String())
+}
+
+func (r *resolver) userProjects(graphql.ResolveParams) (any, error) {
+	return []any{}, nil
+}
+
+// ========= Task =====================
+
+func (r *resolver) taskID(p graphql.ResolveParams) (any, error) {
+	return p.Source.(*taskModels.Task).ID().String(), nil
+}
+
+func (r *resolver) taskTitle(p graphql.ResolveParams) (any, error) {
+	return p.Source.(*taskModels.Task).Title().String(), nil
+}
+
+func (r *resolver) taskDescription(p graphql.ResolveParams) (any, error) {
+	return p.Source.(*taskModels.Task).Description().String(), nil
+}
+
+func (r *resolver) taskDeadline(p graphql.ResolveParams) (any, error) {
+	deadline := p.Source.(*taskModels.Task).Deadline()
+	if deadline == nil {
+		return nil, nil
+	}
+
+	return deadline.Time(), nil
+}
+
+func (r *resolver) taskIsCompleted(p graphql.ResolveParams) (any, error) {
+	return p.Source.(*taskModels.Task).IsCompleted(), nil
+}
+
+func (r *resolver) taskCompletedAt(p graphql.ResolveParams) (any, error) {
+	return p.Source.(*taskModels.Task).CompletedAt(), nil
+}
+
+func (r *resolver) taskCreatedAt(p graphql.ResolveParams) (any, error) {
+	return p.Source.(*taskModels.Task).CreatedAt(), nil
+}
+
+func (r *resolver) taskUpdatedAt(p graphql.ResolveParams) (any, error) {
+	return p.Source.(*taskModels.Task).UpdatedAt(), nil
+}
+
+func (r *resolver) taskArchivedAt(p graphql.ResolveParams) (any, error) {
+	return p.Source.(*taskModels.Task).ArchivedAt(), nil
+}
+
+func (r *resolver) taskDeletedAt(p graphql.ResolveParams) (any, error) {
+	return p.Source.(*taskModels.Task).DeletedAt(), nil
+}
+
+func (r *resolver) taskVersion(p graphql.ResolveParams) (any, error) {
+	return p.Source.(*taskModels.Task).Version(), nil
+}
+
+func (r *resolver) taskOwner(p graphql.ResolveParams) (any, error) {
+	ownerID := p.Source.(*taskModels.Task).OwnerID().String()
+
+	return thunk(loadersFromContext(p.Context).users.load(p.Context, ownerID)), nil
+}
+
+func (r *resolver) taskHistory(p graphql.ResolveParams) (any, error) {
+	limit, err := limitArg(p)
+	if err != nil {
+		return nil, err
+	}
+
+	id := p.Source.(*taskModels.Task).ID().String()
+	load := loadersFromContext(p.Context).histories.load(p.Context, id)
+
+	return thunk(func() ([]*taskModels.TaskEvent, error) {
+		events, err := load()
+		return first(events, limit), err
+	}), nil
+}
+
+func (r *resolver) taskProject(graphql.ResolveParams) (any, error) {
+	return nil, nil
+}
+
+// ========= TaskEvent ================
+
+func (r *resolver) eventID(p graphql.ResolveParams) (any, error) {
+	return p.Source.(*taskModels.TaskEvent).ID().String(), nil
+}
+
+func (r *resolver) eventType(p graphql.ResolveParams) (any, error) {
+	return string(p.Source.(*taskModels.TaskEvent).Type()), nil
+}
+
+func (r *resolver) eventActor(p graphql.ResolveParams) (any, error) {
+	actorID := p.Source.(*taskModels.TaskEvent).ActorID().String()
+
+	return thunk(loadersFromContext(p.Context).users.load(p.Context, actorID)), nil
+}
+
+func (r *resolver) eventOccurredAt(p graphql.ResolveParams) (any, error) {
+	return p.Source.(*taskModels.TaskEvent).OccurredAt(), nil
+}
+
+func (r *resolver) eventChanges(p graphql.ResolveParams) (any, error) {
+	return p.Source.(*taskModels.TaskEvent).Changes(), nil
+}
+
+func (r *resolver) changeField(p graphql.ResolveParams) (any, error) {
+	return p.Source.(taskModels.FieldChange).Field, nil
+}
+
+func (r *resolver) changeBefore(p graphql.ResolveParams) (any, error) {
+	return p.Source.(taskModels.FieldChange).Before, nil
+}
+
+func (r *resolver) changeAfter(p graphql.ResolveParams) (any, error) {
+	return p.Source.(taskModels.FieldChange).After, nil
+}
+
+// ========= SearchResult =============
+
+func (r *resolver) searchTask(p graphql.ResolveParams) (any, error) {
+	return p.Source.(services.TaskSearchResult).Task, nil
+}
+
+func (r *resolver) searchRank(p graphql.ResolveParams) (any, error) {
+	return p.Source.(services.TaskSearchResult).Rank, nil
+}
+
+func (r *resolver) searchTitleSnippet(p graphql.ResolveParams) (any, error) {
+	return p.Source.(services.TaskSearchResult).TitleSnippet, nil
+}
+
+func (r *resolver) searchDescriptionSnippet(p graphql.ResolveParams) (any, error) {
+	return p.Source.(services.TaskSearchResult).DescriptionSnippet, nil
+}
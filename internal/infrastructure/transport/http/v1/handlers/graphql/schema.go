@@ -0,0 +1,337 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/graphql-go/graphql"
+)
+
+// jsonScalar is the scalar of the arbitrary JSON values, such as the values of the changed fields,
+// which are serialized the same as in the REST API.
+var jsonScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "JSON",
+	Description: "An arbitrary JSON value.",
+	Serialize: func(value any) any {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil
+		}
+
+		var v any
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return nil
+		}
+
+		return v
+	},
+})
+
+var taskSortEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name:        "TaskSort",
+	Description: "The order of the listed tasks.",
+	Values: graphql.EnumValueConfigMap{
+		"CREATED_AT":      {Value: services.TaskSortCreatedAt, Description: "From the oldest task."},
+		"CREATED_AT_DESC": {Value: services.TaskSortCreatedAtDesc, Description: "From the newest task."},
+	},
+})
+
+var taskFilterInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name:        "TaskFilter",
+	Description: "The filter of the listed tasks.",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"sort":            {Type: taskSortEnum},
+		"updatedSince":    {Type: graphql.DateTime, Description: "Only the tasks updated at or after the time."},
+		"includeArchived": {Type: graphql.Boolean, DefaultValue: false},
+		"isCompleted":     {Type: graphql.Boolean, Description: "Only the completed or the open tasks."},
+		"hasDeadline":     {Type: graphql.Boolean, Description: "Only the tasks with or without a deadline."},
+	},
+})
+
+var createTaskInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "CreateTaskInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"title":       {Type: graphql.NewNonNull(graphql.String)},
+		"description": {Type: graphql.String, DefaultValue: ""},
+		"deadline":    {Type: graphql.DateTime},
+	},
+})
+
+var updateTaskInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name:        "UpdateTaskInput",
+	Description: "The changes of a task. The fields that are left out are not changed.",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"title":       {Type: graphql.String},
+		"description": {Type: graphql.String},
+		"deadline":    {Type: graphql.DateTime},
+	},
+})
+
+// newSchema returns the schema of the API, which is resolved with r.
+// Every field of a list of tasks or events that refers to another object
+// is resolved with the loaders of the request, see loader.
+func newSchema(r *resolver) (graphql.Schema, error) {
+	var userType, taskType *graphql.Object
+
+	// The projects are not supported by the services yet. The type is a part of the schema,
+	// so that the clients can be written against it, but no task belongs to a project for now.
+	projectType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Project",
+		Description: "A group of tasks. Reserved for the future, no task belongs to a project yet.",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":    {Type: graphql.NewNonNull(graphql.ID)},
+				"name":  {Type: graphql.NewNonNull(graphql.String)},
+				"tasks": {Type: nonNullList(taskType)},
+			}
+		}),
+	})
+
+	fieldChangeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "FieldChange",
+		Fields: graphql.Fields{
+			"field":  {Type: graphql.NewNonNull(graphql.String), Resolve: r.changeField},
+			"before": {Type: jsonScalar, Resolve: r.changeBefore},
+			"after":  {Type: jsonScalar, Resolve: r.changeAfter},
+		},
+	})
+
+	taskEventType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "TaskEvent",
+		Description: "A change of a task.",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":         {Type: graphql.NewNonNull(graphql.ID), Resolve: r.eventID},
+				"type":       {Type: graphql.NewNonNull(graphql.String), Resolve: r.eventType},
+				"actor":      {Type: userType, Resolve: r.eventActor},
+				"occurredAt": {Type: graphql.NewNonNull(graphql.DateTime), Resolve: r.eventOccurredAt},
+				"changes":    {Type: nonNullList(fieldChangeType), Resolve: r.eventChanges},
+			}
+		}),
+	})
+
+	taskType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Task",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":          {Type: graphql.NewNonNull(graphql.ID), Resolve: r.taskID},
+				"title":       {Type: graphql.NewNonNull(graphql.String), Resolve: r.taskTitle},
+				"description": {Type: graphql.NewNonNull(graphql.String), Resolve: r.taskDescription},
+				"deadline":    {Type: graphql.DateTime, Resolve: r.taskDeadline},
+				"isCompleted": {Type: graphql.NewNonNull(graphql.Boolean), Resolve: r.taskIsCompleted},
+				"completedAt": {Type: graphql.DateTime, Resolve: r.taskCompletedAt},
+				"createdAt":   {Type: graphql.NewNonNull(graphql.DateTime), Resolve: r.taskCreatedAt},
+				"updatedAt":   {Type: graphql.NewNonNull(graphql.DateTime), Resolve: r.taskUpdatedAt},
+				"archivedAt":  {Type: graphql.DateTime, Resolve: r.taskArchivedAt},
+				"deletedAt":   {Type: graphql.DateTime, Resolve: r.taskDeletedAt},
+				"version":     {Type: graphql.NewNonNull(graphql.Int), Resolve: r.taskVersion},
+				"owner":       {Type: userType, Resolve: r.taskOwner},
+				"history": {
+					Type:        nonNullList(taskEventType),
+					Description: "The first changes of the task from the oldest to the newest.",
+					Args:        listArgs(graphql.FieldConfigArgument{}),
+					Resolve:     r.taskHistory,
+				},
+				"project": {
+					Type:        projectType,
+					Description: "The project of the task. Always null for now.",
+					Resolve:     r.taskProject,
+				},
+			}
+		}),
+	})
+
+	userType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"id":        {Type: graphql.NewNonNull(graphql.ID), Resolve: r.userID},
+			"username":  {Type: graphql.NewNonNull(graphql.String), Resolve: r.userUsername},
+			"email":     {Type: graphql.NewNonNull(graphql.String), Resolve: r.userEmail},
+			"createdAt": {Type: graphql.NewNonNull(graphql.DateTime), Resolve: r.userCreatedAt},
+			"tasks": {
+				Type:        nonNullList(taskType),
+				Description: "The active tasks of the user.",
+				Args:        listArgs(graphql.FieldConfigArgument{"filter": {Type: taskFilterInput}}),
+				Resolve:     r.userTasks,
+			},
+			"projects": {
+				Type:        nonNullList(projectType),
+				Description: "The projects of the user. Always empty for now.",
+				Resolve:     r.userProjects,
+			},
+		},
+	})
+
+	searchResultType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "SearchResult",
+		Fields: graphql.Fields{
+			"task": {Type: graphql.NewNonNull(taskType), Resolve: r.searchTask},
+			"rank": {
+				Type:        graphql.NewNonNull(graphql.Float),
+				Description: "The relevance of the task; a greater rank means a more relevant task.",
+				Resolve:     r.searchRank,
+			},
+			"titleSnippet": {
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The HTML-escaped title with the matched words enclosed in <b> and </b>.",
+				Resolve:     r.searchTitleSnippet,
+			},
+			"descriptionSnippet": {
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The HTML-escaped description with the matched words enclosed in <b> and </b>.",
+				Resolve:     r.searchDescriptionSnippet,
+			},
+		},
+	})
+
+	taskArgs := func(args graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+		args["id"] = &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}
+		args["expectedVersion"] = &graphql.ArgumentConfig{
+			Type:        graphql.Int,
+			Description: "Fails the mutation with TASK_VERSION_MISMATCH if the task has another version.",
+		}
+
+		return args
+	}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"me": {
+				Type:        userType,
+				Description: "The authenticated user.",
+				Resolve:     r.me,
+			},
+			"task": {
+				Type:        taskType,
+				Description: "The active task with the ID, or null if there is no such task of the user.",
+				Args:        graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve:     r.task,
+			},
+			"tasks": {
+				Type:        nonNullList(taskType),
+				Description: "The active tasks of the authenticated user.",
+				Args:        listArgs(graphql.FieldConfigArgument{"filter": {Type: taskFilterInput}}),
+				Resolve:     r.tasks,
+			},
+			"trash": {
+				Type:        nonNullList(taskType),
+				Description: "The tasks in the trash, from the most recently deleted.",
+				Args:        listArgs(graphql.FieldConfigArgument{}),
+				Resolve:     r.trash,
+			},
+			"searchTasks": {
+				Type:        nonNullList(searchResultType),
+				Description: "The active tasks that match the query, from the most relevant.",
+				Args: graphql.FieldConfigArgument{
+					"query":           {Type: graphql.NewNonNull(graphql.String)},
+					"limit":           {Type: graphql.Int, DefaultValue: services.DefaultTaskSearchLimit},
+					"includeArchived": {Type: graphql.Boolean, DefaultValue: false},
+				},
+				Resolve: r.searchTasks,
+			},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createTask": {
+				Type:    graphql.NewNonNull(taskType),
+				Args:    graphql.FieldConfigArgument{"input": {Type: graphql.NewNonNull(createTaskInput)}},
+				Resolve: r.createTask,
+			},
+			"updateTask": {
+				Type:    graphql.NewNonNull(taskType),
+				Args:    taskArgs(graphql.FieldConfigArgument{"input": {Type: graphql.NewNonNull(updateTaskInput)}}),
+				Resolve: r.updateTask,
+			},
+			"removeDeadline": {
+				Type:    graphql.NewNonNull(taskType),
+				Args:    taskArgs(graphql.FieldConfigArgument{}),
+				Resolve: r.removeDeadline,
+			},
+			"completeTask": {
+				Type:    graphql.NewNonNull(taskType),
+				Args:    taskArgs(graphql.FieldConfigArgument{}),
+				Resolve: r.completeTask,
+			},
+			"reopenTask": {
+				Type:    graphql.NewNonNull(taskType),
+				Args:    taskArgs(graphql.FieldConfigArgument{}),
+				Resolve: r.reopenTask,
+			},
+			"archiveTask": {
+				Type: graphql.NewNonNull(taskType),
+				Args: taskArgs(graphql.FieldConfigArgument{
+					"force": {
+						Type:         graphql.Boolean,
+						DefaultValue: false,
+						Description:  "Archives the task even if it is not completed.",
+					},
+				}),
+				Resolve: r.archiveTask,
+			},
+			"unarchiveTask": {
+				Type:    graphql.NewNonNull(taskType),
+				Args:    taskArgs(graphql.FieldConfigArgument{}),
+				Resolve: r.unarchiveTask,
+			},
+			"archiveCompleted": {
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "Archives the tasks completed at least olderThanDays days ago, at most 36500, and returns their number.",
+				Args: graphql.FieldConfigArgument{
+					"olderThanDays": {Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: r.archiveCompleted,
+			},
+			"deleteTask": {
+				Type:        graphql.NewNonNull(graphql.ID),
+				Description: "Moves the task to the trash and returns its ID.",
+				Args:        taskArgs(graphql.FieldConfigArgument{}),
+				Resolve:     r.deleteTask,
+			},
+			"deleteTaskPermanently": {
+				Type:        graphql.NewNonNull(graphql.ID),
+				Description: "Deletes the task in the trash for good and returns its ID.",
+				Args:        taskArgs(graphql.FieldConfigArgument{}),
+				Resolve:     r.deleteTaskPermanently,
+			},
+			"restoreTask": {
+				Type:    graphql.NewNonNull(taskType),
+				Args:    taskArgs(graphql.FieldConfigArgument{}),
+				Resolve: r.restoreTask,
+			},
+			"revertTask": {
+				Type:        graphql.NewNonNull(taskType),
+				Description: "Reverts the task to its state before the event.",
+				Args: taskArgs(graphql.FieldConfigArgument{
+					"eventId": {Type: graphql.NewNonNull(graphql.ID)},
+				}),
+				Resolve: r.revertTask,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query:    query,
+		Mutation: mutation,
+	})
+}
+
+// listArgs adds the limit argument of a list field to args, see limitArg.
+func listArgs(args graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+	args["limit"] = &graphql.ArgumentConfig{
+		Type:         graphql.Int,
+		DefaultValue: DefaultListLimit,
+		Description:  fmt.Sprintf("The maximum number of the items, from 1 to %d.", MaxListLimit),
+	}
+
+	return args
+}
+
+// nonNullList returns the type of the non-null list of the non-null items of t.
+func nonNullList(t graphql.Type) *graphql.NonNull {
+	return graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(t)))
+}
@@ -1,11 +1,17 @@
 package handlers
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
+	"mime"
 	"net/http"
+	"reflect"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -14,7 +20,7 @@ import (
 func WriteJSON(w http.ResponseWriter, code int, v any) {
 	data, err := json.Marshal(v)
 	if err != nil {
-		WriteError(w, http.StatusInternalServerError, errors.New("failed to encode JSON"))
+		writeProblem(w, ErrInternal.Problem())
 		return
 	}
 
@@ -25,11 +31,40 @@ func WriteJSON(w http.ResponseWriter, code int, v any) {
 	}
 }
 
+// DecodeOptions configures the decoding of the request bodies by DecodeAndValidate.
+type DecodeOptions struct {
+	// MaxBodySize is the maximum size of a request body in bytes. Zero means no limit.
+	MaxBodySize int64
+
+	// Strict makes DecodeAndValidate reject the bodies with unknown fields
+	// and the requests without the Content-Type header.
+	Strict bool
+}
+
+type ctxKeyDecodeOptions struct{}
+
+// WithDecodeOptions returns a copy of ctx that carries the decode options of the request.
+func WithDecodeOptions(ctx context.Context, opts DecodeOptions) context.Context {
+	return context.WithValue(ctx, ctxKeyDecodeOptions{}, opts)
+}
+
+// decodeOptionsFromContext returns the decode options carried by ctx, or the zero options if there are none.
+func decodeOptionsFromContext(ctx context.Context) DecodeOptions {
+	opts, _ := ctx.Value(ctxKeyDecodeOptions{}).(DecodeOptions)
+	return opts
+}
+
 // DecodeAndValidate decodes the JSON request body into a value of type T
 // and validates it using validate.
 //
-// If decoding or validation fails, DecodeAndValidate writes an HTTP 400
-// error response, logs the error using logger, and reports failure.
+// The body must be a single JSON value with the application/json content type,
+// or without the Content-Type header unless the decoding is strict. The decode options
+// are taken from the request context, see WithDecodeOptions.
+//
+// If decoding or validation fails, DecodeAndValidate writes a problem response,
+// logs the error using logger, and reports failure: 413 if the body is larger
+// than allowed, 415 if it is not JSON and 400 otherwise. A syntax or a type error
+// is reported with its offset and field, so that the client knows what to fix.
 // A request with an empty body is treated as an error.
 //
 // It returns a pointer to the decoded value and reports whether decoding
@@ -40,24 +75,143 @@ func DecodeAndValidate[T any](
 	logger *slog.Logger,
 	validate *validator.Validate) (*T, bool) {
 
-	var req T
-	err := json.NewDecoder(r.Body).Decode(&req)
-	if errors.Is(err, io.EOF) {
-		logger.Error("request body is empty")
-		WriteError(w, http.StatusBadRequest, errors.New("request body is empty"))
+	opts := decodeOptionsFromContext(r.Context())
+
+	if !isJSONContentType(r.Header.Get("Content-Type"), opts.Strict) {
+		logger.Error("unsupported content type", slog.String("content_type", r.Header.Get("Content-Type")))
+		WriteError(w, r, ErrUnsupportedMediaType)
 		return nil, false
 	}
-	if err != nil {
-		logger.Error("failed to decode request body")
-		WriteError(w, http.StatusBadRequest, errors.New("failed to decode request body"))
+
+	var req T
+	if err := decodeJSON(r.Body, &req, opts.Strict); err != nil {
+		logger.Error("failed to decode request body", slog.String("error", err.Error()))
+		WriteError(w, r, err)
 		return nil, false
 	}
 
 	if err := validate.Struct(req); err != nil {
 		logger.Error("failed to validate request body", slog.String("error", err.Error()))
-		WriteError(w, http.StatusBadRequest, err)
+		WriteError(w, r, err)
 		return nil, false
 	}
 
 	return &req, true
 }
+
+// DecodeMessage decodes and validates a JSON message that is received other than
+// in a request body, e.g. over a WebSocket. Unknown fields are rejected if strict is true.
+// It returns the same errors as DecodeAndValidate writes.
+func DecodeMessage[T any](data []byte, strict bool, validate *validator.Validate) (*T, error) {
+	var msg T
+	if err := decodeJSON(bytes.NewReader(data), &msg, strict); err != nil {
+		return nil, err
+	}
+
+	if err := validate.Struct(msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+// isJSONContentType reports whether contentType is application/json.
+// A missing content type is accepted unless strict is true.
+func isJSONContentType(contentType string, strict bool) bool {
+	if contentType == "" {
+		return !strict
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+
+	return err == nil && mediaType == "application/json"
+}
+
+// decodeJSON decodes a single JSON value from body into v
+// and returns an *Error that describes why the body is invalid, if it is.
+func decodeJSON(body io.Reader, v any, strict bool) error {
+	dec := json.NewDecoder(body)
+	if strict {
+		dec.DisallowUnknownFields()
+	}
+
+	if err := dec.Decode(v); err != nil {
+		return decodeError(err)
+	}
+
+	// the body must hold exactly one value
+	end := dec.InputOffset()
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		if err != nil {
+			return decodeError(err)
+		}
+
+		return requestBodyInvalid(fmt.Sprintf("unexpected data after the JSON value ending at offset %d", end))
+	}
+
+	return nil
+}
+
+// decodeError converts an error of json.Decoder to an *Error.
+func decodeError(err error) error {
+	if _, ok := errors.AsType[*http.MaxBytesError](err); ok {
+		return ErrRequestBodyTooLarge
+	}
+
+	if errors.Is(err, io.EOF) {
+		return ErrRequestBodyEmpty
+	}
+
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return requestBodyInvalid("request body ends unexpectedly")
+	}
+
+	if syntaxErr, ok := errors.AsType[*json.SyntaxError](err); ok {
+		return requestBodyInvalid(fmt.Sprintf("invalid JSON at offset %d: %s", syntaxErr.Offset, syntaxErr.Error()))
+	}
+
+	if typeErr, ok := errors.AsType[*json.UnmarshalTypeError](err); ok {
+		if typeErr.Field == "" {
+			return requestBodyInvalid(fmt.Sprintf(
+				"request body must be a JSON %s, got %s", jsonType(typeErr.Type), typeErr.Value,
+			))
+		}
+
+		return requestBodyInvalid(fmt.Sprintf(
+			"field %q must be %s, got %s at offset %d",
+			typeErr.Field,
+			jsonType(typeErr.Type),
+			typeErr.Value,
+			typeErr.Offset,
+		))
+	}
+
+	// encoding/json reports unknown fields with a plain error
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		return requestBodyInvalid("unknown field " + field)
+	}
+
+	return ErrRequestBodyInvalid
+}
+
+// jsonType returns the name of the JSON type that is decoded into a value of type t.
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map:
+		return "object"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Pointer:
+		return jsonType(t.Elem())
+	default:
+		return "number"
+	}
+}
+
+func requestBodyInvalid(detail string) *Error {
+	return NewError(ErrRequestBodyInvalid.Status, ErrRequestBodyInvalid.Code, detail)
+}
@@ -0,0 +1,190 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeAndValidate(t *testing.T) {
+	type request struct {
+		Title    string `json:"title" validate:"required"`
+		Priority int    `json:"priority"`
+	}
+
+	tests := []struct {
+		name        string
+		opts        handlers.DecodeOptions
+		contentType string
+		body        string
+		want        *request
+		wantStatus  int
+		wantCode    string
+		wantDetail  string
+	}{
+		{
+			name:        "valid body",
+			opts:        handlers.DecodeOptions{MaxBodySize: 1024, Strict: true},
+			contentType: "application/json; charset=utf-8",
+			body:        `{"title":"Buy milk","priority":2}`,
+			want:        &request{Title: "Buy milk", Priority: 2},
+		},
+		{
+			name: "no content type when not strict",
+			body: `{"title":"Buy milk"}`,
+			want: &request{Title: "Buy milk"},
+		},
+		{
+			name:       "no content type when strict",
+			opts:       handlers.DecodeOptions{Strict: true},
+			body:       `{"title":"Buy milk"}`,
+			wantStatus: http.StatusUnsupportedMediaType,
+			wantCode:   "UNSUPPORTED_MEDIA_TYPE",
+			wantDetail: "content type must be application/json",
+		},
+		{
+			name:        "not json content type",
+			contentType: "text/plain",
+			body:        `{"title":"Buy milk"}`,
+			wantStatus:  http.StatusUnsupportedMediaType,
+			wantCode:    "UNSUPPORTED_MEDIA_TYPE",
+			wantDetail:  "content type must be application/json",
+		},
+		{
+			name:        "body too large",
+			opts:        handlers.DecodeOptions{MaxBodySize: 16},
+			contentType: "application/json",
+			body:        `{"title":"` + strings.Repeat("a", 32) + `"}`,
+			wantStatus:  http.StatusRequestEntityTooLarge,
+			wantCode:    "REQUEST_BODY_TOO_LARGE",
+			wantDetail:  "request body is too large",
+		},
+		{
+			name:        "empty body",
+			contentType: "application/json",
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    "REQUEST_BODY_EMPTY",
+			wantDetail:  "request body is empty",
+		},
+		{
+			name:        "unknown field when strict",
+			opts:        handlers.DecodeOptions{Strict: true},
+			contentType: "application/json",
+			body:        `{"title":"Buy milk","color":"red"}`,
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    "REQUEST_BODY_INVALID",
+			wantDetail:  `unknown field "color"`,
+		},
+		{
+			name:        "unknown field when not strict",
+			contentType: "application/json",
+			body:        `{"title":"Buy milk","color":"red"}`,
+			want:        &request{Title: "Buy milk"},
+		},
+		{
+			name:        "trailing data",
+			contentType: "application/json",
+			body:        `{"title":"Buy milk"} {"title":"Buy bread"}`,
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    "REQUEST_BODY_INVALID",
+			wantDetail:  "unexpected data after the JSON value ending at offset 20",
+		},
+		{
+			name:        "trailing garbage",
+			contentType: "application/json",
+			body:        `{"title":"Buy milk"}}`,
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    "REQUEST_BODY_INVALID",
+			wantDetail:  "invalid JSON at offset 21: invalid character '}' looking for beginning of value",
+		},
+		{
+			name:        "trailing whitespace",
+			contentType: "application/json",
+			body:        "{\"title\":\"Buy milk\"}\n",
+			want:        &request{Title: "Buy milk"},
+		},
+		{
+			name:        "syntax error",
+			contentType: "application/json",
+			body:        `{"title":"Buy milk",}`,
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    "REQUEST_BODY_INVALID",
+			wantDetail:  "invalid JSON at offset 21: invalid character '}' looking for beginning of object key string",
+		},
+		{
+			name:        "truncated body",
+			contentType: "application/json",
+			body:        `{"title":"Buy`,
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    "REQUEST_BODY_INVALID",
+			wantDetail:  "request body ends unexpectedly",
+		},
+		{
+			name:        "wrong field type",
+			contentType: "application/json",
+			body:        `{"title":"Buy milk","priority":"high"}`,
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    "REQUEST_BODY_INVALID",
+			wantDetail:  `field "priority" must be number, got string at offset 37`,
+		},
+		{
+			name:        "not an object",
+			contentType: "application/json",
+			body:        `[1, 2]`,
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    "REQUEST_BODY_INVALID",
+			wantDetail:  "request body must be a JSON object, got array",
+		},
+		{
+			name:        "validation failed",
+			contentType: "application/json",
+			body:        `{"priority":2}`,
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    "VALIDATION_FAILED",
+			wantDetail:  "request body is invalid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			req = req.WithContext(handlers.WithDecodeOptions(req.Context(), tt.opts))
+
+			rr := httptest.NewRecorder()
+			if tt.opts.MaxBodySize > 0 {
+				req.Body = http.MaxBytesReader(rr, req.Body, tt.opts.MaxBodySize)
+			}
+
+			got, ok := handlers.DecodeAndValidate[request](
+				rr,
+				req,
+				slog.New(slog.DiscardHandler),
+				handlers.NewValidator(),
+			)
+
+			if tt.want != nil {
+				require.True(t, ok)
+				require.Equal(t, tt.want, got)
+				return
+			}
+
+			require.False(t, ok)
+			require.Nil(t, got)
+			require.Equal(t, tt.wantStatus, rr.Code)
+
+			var problem handlers.Problem
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&problem))
+			require.Equal(t, tt.wantCode, problem.Code)
+			require.Equal(t, tt.wantDetail, problem.Detail)
+		})
+	}
+}
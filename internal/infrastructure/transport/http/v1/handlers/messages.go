@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Language is a language of the field error messages.
+type Language string
+
+const (
+	English Language = "en"
+	Russian Language = "ru"
+)
+
+// languages are the languages with a message catalog. The first one is the default.
+var languages = []Language{English, Russian}
+
+var languageMatcher = language.NewMatcher([]language.Tag{language.English, language.Russian})
+
+// RequestLanguage returns the language of the messages preferred by the client
+// according to the Accept-Language header of r. It returns English if the header
+// is missing or none of the preferred languages is supported.
+func RequestLanguage(r *http.Request) Language {
+	tags, _, err := language.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+	if err != nil || len(tags) == 0 {
+		return English
+	}
+
+	_, i, confidence := languageMatcher.Match(tags...)
+	if confidence == language.No {
+		return English
+	}
+
+	return languages[i]
+}
+
+// valueKind is the kind of a field value that the messages of the length
+// and range rules depend on, e.g. "at least 2 characters" or "at least 2".
+type valueKind string
+
+const (
+	kindString valueKind = "string"
+	kindNumber valueKind = "number"
+	kindList   valueKind = "list"
+	kindOther  valueKind = ""
+)
+
+func kindOf(k reflect.Kind) valueKind {
+	switch k {
+	case reflect.String:
+		return kindString
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return kindList
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return kindNumber
+	default:
+		return kindOther
+	}
+}
+
+// violation is a rule that a field of the request violates.
+type violation struct {
+	field  string
+	rule   string
+	kind   valueKind
+	params map[string]any
+}
+
+// fieldError returns the field error for v with the message in lang.
+func (v violation) fieldError(lang Language) FieldError {
+	return FieldError{
+		Field:  v.field,
+		Rule:   v.rule,
+		Params: v.params,
+		Error:  message(lang, v),
+	}
+}
+
+// messageFunc formats the message of a violated rule with the given parameters.
+type messageFunc func(params map[string]any) string
+
+// catalogs contain the messages of the rules by language. A message is looked up
+// by the rule and the value kind, e.g. "min.string", and then by the rule alone.
+var catalogs = map[Language]map[string]messageFunc{
+	English: {
+		"required":        text("is required"),
+		"required_if":     text("is required"),
+		"required_unless": text("is required"),
+		"email":           text("must be a valid email"),
+		"printascii":      text("must contain only printable ASCII characters"),
+		"oneof": func(p map[string]any) string {
+			return "must be one of: " + joinValues(p["values"])
+		},
+		"future": text("must be in the future"),
+		"min.string": func(p map[string]any) string {
+			return fmt.Sprintf("must be at least %s", countEN(p["min"], "character", "characters"))
+		},
+		"max.string": func(p map[string]any) string {
+			return fmt.Sprintf("must be at most %s", countEN(p["max"], "character", "characters"))
+		},
+		"len.string": func(p map[string]any) string {
+			return fmt.Sprintf("must be exactly %s", countEN(p["len"], "character", "characters"))
+		},
+		"min.list": func(p map[string]any) string {
+			return fmt.Sprintf("must contain at least %s", countEN(p["min"], "item", "items"))
+		},
+		"max.list": func(p map[string]any) string {
+			return fmt.Sprintf("must contain at most %s", countEN(p["max"], "item", "items"))
+		},
+		"len.list": func(p map[string]any) string {
+			return fmt.Sprintf("must contain exactly %s", countEN(p["len"], "item", "items"))
+		},
+		"max_terms": func(p map[string]any) string {
+			return fmt.Sprintf("must contain at most %s", countEN(p["max"], "term", "terms"))
+		},
+		"min": func(p map[string]any) string {
+			return fmt.Sprintf("must be at least %v", p["min"])
+		},
+		"max": func(p map[string]any) string {
+			return fmt.Sprintf("must be at most %v", p["max"])
+		},
+		"len": func(p map[string]any) string {
+			return fmt.Sprintf("must be exactly %v", p["len"])
+		},
+		"invalid": text("is invalid"),
+	},
+	Russian: {
+		"required":        text("обязательное поле"),
+		"required_if":     text("обязательное поле"),
+		"required_unless": text("обязательное поле"),
+		"email":           text("должно быть корректным адресом электронной почты"),
+		"printascii":      text("должно содержать только печатные символы ASCII"),
+		"oneof": func(p map[string]any) string {
+			return "должно быть одним из: " + joinValues(p["values"])
+		},
+		"future": text("должно быть в будущем"),
+		"min.string": func(p map[string]any) string {
+			return fmt.Sprintf("должно содержать не менее %s", countRU(p["min"], "символа", "символов", "символов"))
+		},
+		"max.string": func(p map[string]any) string {
+			return fmt.Sprintf("должно содержать не более %s", countRU(p["max"], "символа", "символов", "символов"))
+		},
+		"len.string": func(p map[string]any) string {
+			return fmt.Sprintf("должно содержать ровно %s", countRU(p["len"], "символ", "символа", "символов"))
+		},
+		"min.list": func(p map[string]any) string {
+			return fmt.Sprintf("должно содержать не менее %s", countRU(p["min"], "элемента", "элементов", "элементов"))
+		},
+		"max.list": func(p map[string]any) string {
+			return fmt.Sprintf("должно содержать не более %s", countRU(p["max"], "элемента", "элементов", "элементов"))
+		},
+		"len.list": func(p map[string]any) string {
+			return fmt.Sprintf("должно содержать ровно %s", countRU(p["len"], "элемент", "элемента", "элементов"))
+		},
+		"max_terms": func(p map[string]any) string {
+			return fmt.Sprintf("должно содержать не более %s", countRU(p["max"], "слова", "слов", "слов"))
+		},
+		"min": func(p map[string]any) string {
+			return fmt.Sprintf("должно быть не меньше %v", p["min"])
+		},
+		"max": func(p map[string]any) string {
+			return fmt.Sprintf("должно быть не больше %v", p["max"])
+		},
+		"len": func(p map[string]any) string {
+			return fmt.Sprintf("должно быть равно %v", p["len"])
+		},
+		"invalid": text("некорректное значение"),
+	},
+}
+
+// message returns the message of v in lang, or the generic "invalid" message
+// if the catalog has no message for the rule.
+func message(lang Language, v violation) string {
+	catalog, ok := catalogs[lang]
+	if !ok {
+		catalog = catalogs[English]
+	}
+
+	if v.kind != kindOther {
+		if f, ok := catalog[v.rule+"."+string(v.kind)]; ok {
+			return f(v.params)
+		}
+	}
+
+	if f, ok := catalog[v.rule]; ok {
+		return f(v.params)
+	}
+
+	return catalog["invalid"](v.params)
+}
+
+func text(s string) messageFunc {
+	return func(map[string]any) string { return s }
+}
+
+func joinValues(values any) string {
+	if vs, ok := values.([]string); ok {
+		return strings.Join(vs, ", ")
+	}
+
+	return fmt.Sprint(values)
+}
+
+// countEN returns n followed by the English noun form that agrees with it, e.g. "1 character".
+func countEN(n any, one, other string) string {
+	if i, ok := n.(int); ok && i == 1 {
+		return "1 " + one
+	}
+
+	return fmt.Sprintf("%v %s", n, other)
+}
+
+// countRU returns n followed by the Russian noun form that agrees with it:
+// one is used for 1, 21, 31..., few for 2-4, 22-24... and many for the rest,
+// e.g. "2 символа" and "5 символов".
+func countRU(n any, one, few, many string) string {
+	i, ok := n.(int)
+	if !ok {
+		return fmt.Sprintf("%v %s", n, many)
+	}
+
+	form := many
+	switch {
+	case i%10 == 1 && i%100 != 11:
+		form = one
+	case i%10 >= 2 && i%10 <= 4 && (i%100 < 12 || i%100 > 14):
+		form = few
+	}
+
+	return fmt.Sprintf("%d %s", i, form)
+}
@@ -0,0 +1,122 @@
+package handlers_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/user/vo"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestLanguage(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		want           handlers.Language
+	}{
+		{name: "no header", want: handlers.English},
+		{name: "russian", acceptLanguage: "ru-RU,ru;q=0.9,en;q=0.8", want: handlers.Russian},
+		{name: "english preferred", acceptLanguage: "en-GB,ru;q=0.5", want: handlers.English},
+		{name: "russian preferred over unsupported", acceptLanguage: "de, ru;q=0.7", want: handlers.Russian},
+		{name: "unsupported", acceptLanguage: "de-DE", want: handlers.English},
+		{name: "malformed", acceptLanguage: "???", want: handlers.English},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+			if tt.acceptLanguage != "" {
+				r.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+
+			require.Equal(t, tt.want, handlers.RequestLanguage(r))
+		})
+	}
+}
+
+func TestNewLocalizedProblem(t *testing.T) {
+	type request struct {
+		Title  string   `json:"title" validate:"required"`
+		Op     string   `json:"op" validate:"oneof=complete reopen"`
+		Labels []string `json:"labels" validate:"min=2"`
+		Code   string   `json:"code" validate:"len=21"`
+	}
+
+	validationErr := handlers.NewValidator().Struct(request{Op: "delete", Labels: []string{"home"}, Code: "x"})
+
+	tests := []struct {
+		name       string
+		err        error
+		lang       handlers.Language
+		wantErrors []string
+	}{
+		{
+			name: "validation errors in english",
+			err:  validationErr,
+			lang: handlers.English,
+			wantErrors: []string{
+				"title: is required",
+				"op: must be one of: complete, reopen",
+				"labels: must contain at least 2 items",
+				"code: must be exactly 21 characters",
+			},
+		},
+		{
+			name: "validation errors in russian",
+			err:  validationErr,
+			lang: handlers.Russian,
+			wantErrors: []string{
+				"title: обязательное поле",
+				"op: должно быть одним из: complete, reopen",
+				"labels: должно содержать не менее 2 элементов",
+				"code: должно содержать ровно 21 символ",
+			},
+		},
+		{
+			name:       "value object error in english",
+			err:        vo.ErrUsernameTooShort,
+			lang:       handlers.English,
+			wantErrors: []string{"username: must be at least 2 characters"},
+		},
+		{
+			name:       "value object error in russian",
+			err:        fmt.Errorf("failed to register: %w", vo.ErrPasswordTooLong),
+			lang:       handlers.Russian,
+			wantErrors: []string{"password: должно содержать не более 72 символов"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problem := handlers.NewLocalizedProblem(tt.err, tt.lang)
+
+			var got []string
+			for _, e := range problem.Errors {
+				got = append(got, e.Field+": "+e.Error)
+			}
+
+			require.Equal(t, tt.wantErrors, got)
+		})
+	}
+}
+
+func TestWriteError_Localized(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/auth/register", nil)
+	req.Header.Set("Accept-Language", "ru")
+
+	rr := httptest.NewRecorder()
+	handlers.WriteError(rr, req, vo.ErrUsernameTooLong)
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+	require.Equal(t, "ru", rr.Header().Get("Content-Language"))
+	require.JSONEq(
+		t,
+		`{"type":"/problems/username-too-long","title":"Bad Request","status":400,"detail":"username is too long",`+
+			`"code":"USERNAME_TOO_LONG","errors":[{"field":"username","rule":"max","params":{"max":30},`+
+			`"error":"должно содержать не более 30 символов"}]}`,
+		rr.Body.String(),
+	)
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	taskModels "github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	taskVO "github.com/cyberbrain-dev/taskery-api/internal/domain/task/vo"
+	userModels "github.com/cyberbrain-dev/taskery-api/internal/domain/user/models"
+	userVO "github.com/cyberbrain-dev/taskery-api/internal/domain/user/vo"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+)
+
+// problems maps the domain and service errors to the problems that are reported to the client.
+// The first matching entry wins, so more specific errors go first.
+var problems = []struct {
+	err     error
+	problem *Error
+}{
+	// tasks
+	{services.ErrTaskNotFound, NewError(http.StatusNotFound, "TASK_NOT_FOUND", "task not found")},
+	{services.ErrTaskEventNotFound, NewError(http.StatusNotFound, "TASK_EVENT_NOT_FOUND", "event not found")},
+	{services.ErrTaskOwnerNotFound, NewError(http.StatusNotFound, "TASK_OWNER_NOT_FOUND", "task owner not found")},
+	{services.ErrTaskAccessDenied, NewError(http.StatusForbidden, "TASK_ACCESS_DENIED", "access denied")},
+	{services.ErrTaskExists, NewError(http.StatusConflict, "TASK_ALREADY_EXISTS", "task already exists")},
+	{services.ErrTaskConflict, NewError(http.StatusConflict, "TASK_CONFLICT", "task was modified concurrently")},
+	{services.ErrTaskNotInTrash, NewError(http.StatusConflict, "TASK_NOT_IN_TRASH", "task is not in the trash")},
+	{taskModels.ErrTaskNotCompleted, NewError(http.StatusConflict, "TASK_NOT_COMPLETED", "task is not completed")},
+	{services.ErrTaskSortInvalid, NewError(http.StatusBadRequest, "INVALID_PARAMETER", "invalid sort parameter")},
+	{services.ErrTaskSearchLimitInvalid, NewError(http.StatusBadRequest, "INVALID_PARAMETER", "invalid limit parameter")},
+	{services.ErrTaskSyncLimitInvalid, NewError(http.StatusBadRequest, "INVALID_PARAMETER", "invalid limit parameter")},
+	{services.ErrTaskSyncSeqInvalid, NewError(http.StatusBadRequest, "INVALID_PARAMETER", "invalid since parameter")},
+	{
+		services.ErrTaskBatchOperationInvalid,
+		NewError(http.StatusBadRequest, "BATCH_OPERATION_INVALID", "invalid batch operation"),
+	},
+	{
+		services.ErrTaskBatchAborted,
+		NewError(http.StatusFailedDependency, "BATCH_OPERATION_NOT_APPLIED", "operation was not applied"),
+	},
+	{taskVO.ErrTitleEmpty, NewError(http.StatusBadRequest, "TITLE_EMPTY", "title is empty")},
+	{taskVO.ErrTitleTooLong, NewError(http.StatusBadRequest, "TITLE_TOO_LONG", "title is too long")},
+	{taskVO.ErrDescriptionTooLong, NewError(http.StatusBadRequest, "DESCRIPTION_TOO_LONG", "description is too long")},
+	{taskVO.ErrDeadlineBeforeNow, NewError(http.StatusBadRequest, "DEADLINE_IN_PAST", "deadline is in the past")},
+	{taskVO.ErrSearchQueryEmpty, NewError(http.StatusBadRequest, "SEARCH_QUERY_EMPTY", "search query is empty")},
+	{
+		taskVO.ErrSearchQueryTooLong,
+		NewError(http.StatusBadRequest, "SEARCH_QUERY_TOO_LONG", "search query is too long"),
+	},
+	{
+		taskVO.ErrSearchQueryTooManyTerms,
+		NewError(http.StatusBadRequest, "SEARCH_QUERY_TOO_MANY_TERMS", "search query has too many terms"),
+	},
+
+	// users
+	{services.ErrUserNotFound, NewError(http.StatusNotFound, "USER_NOT_FOUND", "user not found")},
+	{services.ErrUserUnauthorized, ErrUnauthorized},
+	{services.ErrUserExists, NewError(http.StatusConflict, "USER_ALREADY_EXISTS", "user already exists")},
+	{
+		services.ErrUserEmailAlreadyTaken,
+		NewError(http.StatusConflict, "EMAIL_ALREADY_TAKEN", "email already taken"),
+	},
+	{services.ErrUserConflict, NewError(http.StatusConflict, "USER_CONFLICT", "user was modified concurrently")},
+	{userModels.ErrUserIDInvalid, NewError(http.StatusBadRequest, "USER_ID_INVALID", "user ID is invalid")},
+	{userVO.ErrUsernameEmpty, NewError(http.StatusBadRequest, "USERNAME_EMPTY", "username is empty")},
+	{userVO.ErrUsernameTooShort, NewError(http.StatusBadRequest, "USERNAME_TOO_SHORT", "username is too short")},
+	{userVO.ErrUsernameTooLong, NewError(http.StatusBadRequest, "USERNAME_TOO_LONG", "username is too long")},
+	{userVO.ErrEmailEmpty, NewError(http.StatusBadRequest, "EMAIL_EMPTY", "email is empty")},
+	{userVO.ErrEmailInvalid, NewError(http.StatusBadRequest, "EMAIL_INVALID", "email is invalid")},
+	{userVO.ErrPasswordEmpty, NewError(http.StatusBadRequest, "PASSWORD_EMPTY", "password is empty")},
+	{userVO.ErrPasswordTooShort, NewError(http.StatusBadRequest, "PASSWORD_TOO_SHORT", "password is too short")},
+	{userVO.ErrPasswordTooLong, NewError(http.StatusBadRequest, "PASSWORD_TOO_LONG", "password is too long")},
+	{userVO.ErrPasswordInvalid, NewError(http.StatusBadRequest, "PASSWORD_INVALID", "password is invalid")},
+
+	// calendar
+	{
+		services.ErrCalendarFeedNotFound,
+		NewError(http.StatusNotFound, "CALENDAR_FEED_NOT_FOUND", "calendar feed not found"),
+	},
+}
+
+// fieldViolations maps the value object errors to the fields of the requests they are caused by
+// and the rules with the limits of the value objects, so that the client can point the user
+// to the field to fix.
+var fieldViolations = []struct {
+	err       error
+	violation violation
+}{
+	// tasks
+	{taskVO.ErrTitleEmpty, violation{field: "title", rule: "required"}},
+	{
+		taskVO.ErrTitleTooLong,
+		violation{field: "title", rule: "max", kind: kindString, params: map[string]any{"max": taskVO.TitleMaxLength}},
+	},
+	{
+		taskVO.ErrDescriptionTooLong,
+		violation{
+			field:  "description",
+			rule:   "max",
+			kind:   kindString,
+			params: map[string]any{"max": taskVO.DescriptionMaxLength},
+		},
+	},
+	{taskVO.ErrDeadlineBeforeNow, violation{field: "deadline", rule: "future"}},
+	{taskVO.ErrSearchQueryEmpty, violation{field: "q", rule: "required"}},
+	{
+		taskVO.ErrSearchQueryTooLong,
+		violation{field: "q", rule: "max", kind: kindString, params: map[string]any{"max": taskVO.SearchQueryMaxLength}},
+	},
+	{
+		taskVO.ErrSearchQueryTooManyTerms,
+		violation{field: "q", rule: "max_terms", params: map[string]any{"max": taskVO.SearchQueryMaxTerms}},
+	},
+
+	// users
+	{userVO.ErrUsernameEmpty, violation{field: "username", rule: "required"}},
+	{
+		userVO.ErrUsernameTooShort,
+		violation{field: "username", rule: "min", kind: kindString, params: map[string]any{"min": userVO.UsernameMinLength}},
+	},
+	{
+		userVO.ErrUsernameTooLong,
+		violation{field: "username", rule: "max", kind: kindString, params: map[string]any{"max": userVO.UsernameMaxLength}},
+	},
+	{userVO.ErrEmailEmpty, violation{field: "email", rule: "required"}},
+	{userVO.ErrEmailInvalid, violation{field: "email", rule: "email"}},
+	{userVO.ErrPasswordEmpty, violation{field: "password", rule: "required"}},
+	{
+		userVO.ErrPasswordTooShort,
+		violation{field: "password", rule: "min", kind: kindString, params: map[string]any{"min": userVO.PasswordMinLength}},
+	},
+	{
+		userVO.ErrPasswordTooLong,
+		violation{field: "password", rule: "max", kind: kindString, params: map[string]any{"max": userVO.PasswordMaxLength}},
+	},
+	{userVO.ErrPasswordInvalid, violation{field: "password", rule: "printascii"}},
+}
+
+// fieldViolation returns the violated rule of the field that err is caused by, if any.
+func fieldViolation(err error) (violation, bool) {
+	for _, v := range fieldViolations {
+		if errors.Is(err, v.err) {
+			return v.violation, true
+		}
+	}
+
+	return violation{}, false
+}
+
+// PreconditionError returns ErrTaskVersionMismatch if err is a version conflict of a conditional request,
+// i.e. the one with an expected version from the If-Match header. Otherwise it returns err as is.
+func PreconditionError(err error, expectedVersion *int64) error {
+	if expectedVersion != nil && errors.Is(err, services.ErrTaskConflict) {
+		return ErrTaskVersionMismatch
+	}
+
+	return err
+}
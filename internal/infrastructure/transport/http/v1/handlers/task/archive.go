@@ -0,0 +1,111 @@
+package task
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+type Archiver interface {
+	Archive(ctx context.Context, id string, ownerID string, force bool, expectedVersion *int64) (int64, error)
+}
+
+type ArchiveHandler struct {
+	archiver Archiver
+	timeout  time.Duration
+	logger   *slog.Logger
+	validate *validator.Validate
+}
+
+func NewArchiveHandler(
+	archiver Archiver,
+	timeout time.Duration,
+	logger *slog.Logger,
+	validate *validator.Validate,
+) *ArchiveHandler {
+	return &ArchiveHandler{
+		archiver: archiver,
+		timeout:  timeout,
+		logger:   logger,
+		validate: validate,
+	}
+}
+
+// @Summary Archive a task
+// @Description Archives a completed task of the authenticated user. A task that is not completed is archived only if force is true
+// @Tags tasks
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param force query bool false "Archive the task even if it is not completed"
+// @Param If-Match header string false "Expected task version (ETag)"
+// @Security     BearerAuth
+// @Success 204 {object} nil
+// @Header 204 {string} ETag "New task version"
+// @Failure 400 {object} handlers.Problem
+// @Failure 401 {object} handlers.Problem
+// @Failure 403 {object} handlers.Problem
+// @Failure 404 {object} handlers.Problem
+// @Failure 409 {object} handlers.Problem
+// @Failure 412 {object} handlers.Problem
+// @Failure 500 {object} handlers.Problem
+// @Router /tasks/{id}/archive [post]
+func (h *ArchiveHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.Task.Archive"
+
+	logger := slogx.FromContext(r.Context(), h.logger).With(slog.String("op", op))
+
+	taskID := chi.URLParam(r, "id")
+	if taskID == "" {
+		logger.Error("task id is not provided")
+		handlers.WriteError(w, r, handlers.MissingParameter("task id"))
+		return
+	}
+
+	force := false
+	if raw := r.URL.Query().Get("force"); raw != "" {
+		var err error
+
+		force, err = strconv.ParseBool(raw)
+		if err != nil {
+			logger.Info("invalid force parameter", slog.String("err", err.Error()))
+			handlers.WriteError(w, r, handlers.InvalidParameter("force"))
+			return
+		}
+	}
+
+	expectedVersion, err := handlers.ParseIfMatch(r)
+	if err != nil {
+		logger.Error("failed to parse If-Match header", slog.String("err", err.Error()))
+		handlers.WriteError(w, r, err)
+		return
+	}
+
+	userID := myMw.GetUserID(r.Context())
+	if userID == "" {
+		logger.Error("failed to extract owner id")
+		handlers.WriteError(w, r, handlers.ErrBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	version, err := h.archiver.Archive(ctx, taskID, userID, force, expectedVersion)
+	if err != nil {
+		logger.Error("failed to archive task", slog.String("err", err.Error()))
+		handlers.WriteError(w, r, handlers.PreconditionError(err, expectedVersion))
+		return
+	}
+
+	logger.Info("task archived", slog.Bool("force", force))
+	w.Header().Set("ETag", handlers.FormatETag(version))
+	handlers.WriteJSON(w, http.StatusNoContent, nil)
+}
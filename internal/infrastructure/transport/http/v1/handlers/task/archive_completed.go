@@ -0,0 +1,85 @@
+package task
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
+	"github.com/go-playground/validator/v10"
+)
+
+type CompletedArchiver interface {
+	ArchiveCompleted(ctx context.Context, ownerID string, olderThan time.Duration) (int64, error)
+}
+
+type ArchiveCompletedHandler struct {
+	archiver CompletedArchiver
+	timeout  time.Duration
+	logger   *slog.Logger
+	validate *validator.Validate
+}
+
+func NewArchiveCompletedHandler(
+	archiver CompletedArchiver,
+	timeout time.Duration,
+	logger *slog.Logger,
+	validate *validator.Validate,
+) *ArchiveCompletedHandler {
+	return &ArchiveCompletedHandler{
+		archiver: archiver,
+		timeout:  timeout,
+		logger:   logger,
+		validate: validate,
+	}
+}
+
+// @Summary Archive completed tasks
+// @Description Archives all tasks of the authenticated user that were completed more than the given number of days ago
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param request body ArchiveCompletedRequest true "Bulk archiving request"
+// @Security     BearerAuth
+// @Success 200 {object} ArchiveCompletedResponse
+// @Failure 400 {object} handlers.Problem
+// @Failure 401 {object} handlers.Problem
+// @Failure 500 {object} handlers.Problem
+// @Router /tasks/archive [post]
+func (h *ArchiveCompletedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.Task.ArchiveCompleted"
+
+	logger := slogx.FromContext(r.Context(), h.logger).With(slog.String("op", op))
+
+	req, ok := handlers.DecodeAndValidate[ArchiveCompletedRequest](w, r, logger, h.validate)
+	if !ok {
+		return
+	}
+
+	userID := myMw.GetUserID(r.Context())
+	if userID == "" {
+		logger.Error("failed to extract owner id")
+		handlers.WriteError(w, r, handlers.ErrBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	olderThan := time.Duration(*req.OlderThanDays) * 24 * time.Hour
+
+	archived, err := h.archiver.ArchiveCompleted(ctx, userID, olderThan)
+	if err != nil {
+		logger.Error("failed to archive completed tasks", slog.String("err", err.Error()))
+		handlers.WriteError(w, r, handlers.ErrInternal)
+		return
+	}
+
+	logger.Info("completed tasks archived", slog.Int64("archived", archived))
+	handlers.WriteJSON(w, http.StatusOK, ArchiveCompletedResponse{
+		Archived: archived,
+	})
+}
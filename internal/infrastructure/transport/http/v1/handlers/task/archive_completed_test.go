@@ -0,0 +1,129 @@
+package task_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task/mocks"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveCompletedHandler(t *testing.T) {
+	validUserID := gofakeit.UUID()
+
+	tests := []struct {
+		name         string
+		body         string
+		expectedCode int
+		expectedBody string
+		userID       string
+		mockSetup    func(archiver *mocks.CompletedArchiver)
+	}{
+		{
+			name:         "success",
+			body:         `{"older_than_days":30}`,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"archived":4}`,
+			userID:       validUserID,
+			mockSetup: func(archiver *mocks.CompletedArchiver) {
+				archiver.On("ArchiveCompleted", mock.Anything, validUserID, 30*24*time.Hour).
+					Return(int64(4), nil)
+			},
+		},
+		{
+			name:         "success with zero days",
+			body:         `{"older_than_days":0}`,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"archived":0}`,
+			userID:       validUserID,
+			mockSetup: func(archiver *mocks.CompletedArchiver) {
+				archiver.On("ArchiveCompleted", mock.Anything, validUserID, time.Duration(0)).
+					Return(int64(0), nil)
+			},
+		},
+		{
+			name:         "missing older_than_days",
+			body:         `{}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/validation-failed","title":"Bad Request","status":400,"detail":"request body is invalid","code":"VALIDATION_FAILED","errors":[{"field":"older_than_days","rule":"required","error":"is required"}]}`,
+			userID:       validUserID,
+			mockSetup:    nil,
+		},
+		{
+			name:         "too large older_than_days",
+			body:         `{"older_than_days":200000}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/validation-failed","title":"Bad Request","status":400,"detail":"request body is invalid","code":"VALIDATION_FAILED","errors":[{"field":"older_than_days","rule":"max","params":{"max":36500},"error":"must be at most 36500"}]}`,
+			userID:       validUserID,
+			mockSetup:    nil,
+		},
+		{
+			name:         "negative older_than_days",
+			body:         `{"older_than_days":-1}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/validation-failed","title":"Bad Request","status":400,"detail":"request body is invalid","code":"VALIDATION_FAILED","errors":[{"field":"older_than_days","rule":"min","params":{"min":0},"error":"must be at least 0"}]}`,
+			userID:       validUserID,
+			mockSetup:    nil,
+		},
+		{
+			name:         "internal error",
+			body:         `{"older_than_days":30}`,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"type":"/problems/internal-error","title":"Internal Server Error","status":500,"detail":"internal server error","code":"INTERNAL_ERROR"}`,
+			userID:       validUserID,
+			mockSetup: func(archiver *mocks.CompletedArchiver) {
+				archiver.On("ArchiveCompleted", mock.Anything, validUserID, 30*24*time.Hour).
+					Return(int64(0), errors.New("unexpected error"))
+			},
+		},
+		{
+			name:         "empty user id",
+			body:         `{"older_than_days":30}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/bad-request","title":"Bad Request","status":400,"detail":"bad request","code":"BAD_REQUEST"}`,
+			userID:       "",
+			mockSetup:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequestWithContext(
+				context.WithValue(context.Background(), myMw.UserIDKey, tt.userID),
+				http.MethodPost,
+				"/tasks/archive",
+				bytes.NewBufferString(tt.body),
+			)
+			req.Header.Set("Content-Type", "application/json")
+
+			rr := httptest.NewRecorder()
+
+			archiver := new(mocks.CompletedArchiver)
+			if tt.mockSetup != nil {
+				tt.mockSetup(archiver)
+			}
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+
+			h := task.NewArchiveCompletedHandler(archiver, 4*time.Second, logger, handlers.NewValidator())
+			h.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedCode, rr.Code)
+			require.JSONEq(t, tt.expectedBody, rr.Body.String())
+
+			archiver.AssertExpectations(t)
+		})
+	}
+}
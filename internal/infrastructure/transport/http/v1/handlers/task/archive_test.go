@@ -0,0 +1,183 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task/mocks"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveHandler(t *testing.T) {
+	validUserID := gofakeit.UUID()
+	validTaskID := gofakeit.UUID()
+
+	tests := []struct {
+		name         string
+		taskID       string
+		expectedCode int
+		expectedETag string
+		expectedBody string
+		query        string
+		ifMatch      string
+		userID       string
+		mockSetup    func(archiver *mocks.Archiver)
+	}{
+		{
+			name:         "success",
+			taskID:       validTaskID,
+			expectedCode: http.StatusNoContent,
+			expectedETag: `"2"`,
+			expectedBody: "",
+			userID:       validUserID,
+			mockSetup: func(archiver *mocks.Archiver) {
+				archiver.On("Archive", mock.Anything, validTaskID, validUserID, false, (*int64)(nil)).
+					Return(int64(2), nil)
+			},
+		},
+		{
+			name:         "task not found",
+			taskID:       validTaskID,
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"type":"/problems/task-not-found","title":"Not Found","status":404,"detail":"task not found","code":"TASK_NOT_FOUND"}`,
+			userID:       validUserID,
+			mockSetup: func(archiver *mocks.Archiver) {
+				archiver.On("Archive", mock.Anything, validTaskID, validUserID, false, (*int64)(nil)).
+					Return(int64(0), services.ErrTaskNotFound)
+			},
+		},
+		{
+			name:         "access denied",
+			taskID:       validTaskID,
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"type":"/problems/task-access-denied","title":"Forbidden","status":403,"detail":"access denied","code":"TASK_ACCESS_DENIED"}`,
+			userID:       validUserID,
+			mockSetup: func(archiver *mocks.Archiver) {
+				archiver.On("Archive", mock.Anything, validTaskID, validUserID, false, (*int64)(nil)).
+					Return(int64(0), services.ErrTaskAccessDenied)
+			},
+		},
+		{
+			name:         "version mismatch",
+			taskID:       validTaskID,
+			expectedCode: http.StatusPreconditionFailed,
+			expectedBody: `{"type":"/problems/task-version-mismatch","title":"Precondition Failed","status":412,"detail":"task version mismatch","code":"TASK_VERSION_MISMATCH"}`,
+			ifMatch:      `"4"`,
+			userID:       validUserID,
+			mockSetup: func(archiver *mocks.Archiver) {
+				archiver.On("Archive", mock.Anything, validTaskID, validUserID, false, new(int64(4))).
+					Return(int64(0), services.ErrTaskConflict)
+			},
+		},
+		{
+			name:         "task is not completed",
+			taskID:       validTaskID,
+			expectedCode: http.StatusConflict,
+			expectedBody: `{"type":"/problems/task-not-completed","title":"Conflict","status":409,"detail":"task is not completed","code":"TASK_NOT_COMPLETED"}`,
+			userID:       validUserID,
+			mockSetup: func(archiver *mocks.Archiver) {
+				archiver.On("Archive", mock.Anything, validTaskID, validUserID, false, (*int64)(nil)).
+					Return(int64(0), models.ErrTaskNotCompleted)
+			},
+		},
+		{
+			name:         "success with force",
+			taskID:       validTaskID,
+			query:        "?force=true",
+			expectedCode: http.StatusNoContent,
+			expectedETag: `"2"`,
+			expectedBody: "",
+			userID:       validUserID,
+			mockSetup: func(archiver *mocks.Archiver) {
+				archiver.On("Archive", mock.Anything, validTaskID, validUserID, true, (*int64)(nil)).
+					Return(int64(2), nil)
+			},
+		},
+		{
+			name:         "invalid force parameter",
+			taskID:       validTaskID,
+			query:        "?force=yes-please",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/invalid-parameter","title":"Bad Request","status":400,"detail":"invalid force parameter","code":"INVALID_PARAMETER"}`,
+			userID:       validUserID,
+			mockSetup:    nil,
+		},
+		{
+			name:         "internal error",
+			taskID:       validTaskID,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"type":"/problems/internal-error","title":"Internal Server Error","status":500,"detail":"internal server error","code":"INTERNAL_ERROR"}`,
+			userID:       validUserID,
+			mockSetup: func(archiver *mocks.Archiver) {
+				archiver.On("Archive", mock.Anything, validTaskID, validUserID, false, (*int64)(nil)).
+					Return(int64(0), errors.New("unexpected error"))
+			},
+		},
+		{
+			name:         "empty user id",
+			taskID:       validTaskID,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/bad-request","title":"Bad Request","status":400,"detail":"bad request","code":"BAD_REQUEST"}`,
+			userID:       "",
+			mockSetup:    nil,
+		},
+		{
+			name:         "empty task id",
+			taskID:       "",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/missing-parameter","title":"Bad Request","status":400,"detail":"task id is required","code":"MISSING_PARAMETER"}`,
+			userID:       validUserID,
+			mockSetup:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			routeCtx := chi.NewRouteContext()
+			routeCtx.URLParams.Add("id", tt.taskID)
+
+			ctx := context.WithValue(context.Background(), myMw.UserIDKey, tt.userID)
+			ctx = context.WithValue(ctx, chi.RouteCtxKey, routeCtx)
+
+			req := httptest.NewRequestWithContext(ctx, http.MethodPost, "/tasks/"+tt.taskID+"/archive"+tt.query, nil)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			rr := httptest.NewRecorder()
+
+			archiver := new(mocks.Archiver)
+			if tt.mockSetup != nil {
+				tt.mockSetup(archiver)
+			}
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+
+			h := task.NewArchiveHandler(archiver, 4*time.Second, logger, handlers.NewValidator())
+			h.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedCode, rr.Code)
+			require.Equal(t, tt.expectedETag, rr.Header().Get("ETag"))
+
+			if tt.expectedBody != "" {
+				require.JSONEq(t, tt.expectedBody, rr.Body.String())
+			}
+
+			archiver.AssertExpectations(t)
+		})
+	}
+}
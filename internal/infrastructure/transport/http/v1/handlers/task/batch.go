@@ -0,0 +1,153 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
+	"github.com/go-playground/validator/v10"
+)
+
+type Batcher interface {
+	Batch(ctx context.Context, ownerID string, ops []services.BatchOperation, atomic bool) ([]error, error)
+}
+
+type BatchHandler struct {
+	batcher      Batcher
+	maxBatchSize int
+	timeout      time.Duration
+	logger       *slog.Logger
+	validate     *validator.Validate
+}
+
+// NewBatchHandler creates a new BatchHandler that accepts at most maxBatchSize operations in a single request.
+func NewBatchHandler(
+	batcher Batcher,
+	maxBatchSize int,
+	timeout time.Duration,
+	logger *slog.Logger,
+	validate *validator.Validate,
+) *BatchHandler {
+	return &BatchHandler{
+		batcher:      batcher,
+		maxBatchSize: maxBatchSize,
+		timeout:      timeout,
+		logger:       logger,
+		validate:     validate,
+	}
+}
+
+// @Summary Apply operations to several tasks
+// @Description Applies update, remove_deadline, complete, reopen and delete operations to the tasks of the authenticated user in their order.
+// @Description Every operation gets a result with the status code that the corresponding single-task endpoint would respond with; the optional version works like If-Match.
+// @Description In the atomic mode the operations are applied all or none: the batch stops at the first failed operation, whose status becomes the status of the response, and the other operations get 424.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param request body BatchRequest true "Batch request"
+// @Security     BearerAuth
+// @Success 200 {object} BatchResponse
+// @Failure 400 {object} handlers.Problem
+// @Failure 401 {object} handlers.Problem
+// @Failure 403 {object} BatchResponse
+// @Failure 404 {object} BatchResponse
+// @Failure 409 {object} BatchResponse
+// @Failure 412 {object} BatchResponse
+// @Failure 500 {object} handlers.Problem
+// @Router /tasks/batch [post]
+func (h *BatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.Task.Batch"
+
+	logger := slogx.FromContext(r.Context(), h.logger).With(slog.String("op", op))
+
+	req, ok := handlers.DecodeAndValidate[BatchRequest](w, r, logger, h.validate)
+	if !ok {
+		return
+	}
+
+	if len(req.Operations) > h.maxBatchSize {
+		logger.Info("batch is too large", slog.Int("size", len(req.Operations)))
+		handlers.WriteError(w, r, handlers.NewError(
+			http.StatusBadRequest,
+			"BATCH_TOO_LARGE",
+			fmt.Sprintf("batch must not contain more than %d operations", h.maxBatchSize),
+		))
+		return
+	}
+
+	ownerID := myMw.GetUserID(r.Context())
+	if ownerID == "" {
+		logger.Error("failed to extract owner id")
+		handlers.WriteError(w, r, handlers.ErrBadRequest)
+		return
+	}
+
+	ops := make([]services.BatchOperation, len(req.Operations))
+	for i, o := range req.Operations {
+		ops[i] = services.BatchOperation{
+			Type:   services.BatchOperationType(o.Op),
+			TaskID: o.TaskID,
+			Update: services.UpdateTaskCommand{
+				Title:       o.Title,
+				Description: o.Description,
+				Deadline:    o.Deadline,
+			},
+			ExpectedVersion: o.Version,
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	errs, err := h.batcher.Batch(ctx, ownerID, ops, req.Atomic)
+	if err != nil && !errors.Is(err, services.ErrTaskBatchAborted) {
+		logger.Error("failed to apply batch", slog.String("err", err.Error()))
+		handlers.WriteError(w, r, handlers.ErrInternal)
+		return
+	}
+
+	code := http.StatusOK
+	results := make([]BatchItemResult, len(ops))
+
+	for i, opErr := range errs {
+		results[i] = BatchItemResult{
+			Index:  i,
+			TaskID: ops[i].TaskID,
+			Status: http.StatusOK,
+		}
+
+		if opErr == nil {
+			continue
+		}
+
+		// the item gets the same problem as the single-task endpoint for the operation would respond with
+		problem := handlers.NewProblem(handlers.PreconditionError(opErr, ops[i].ExpectedVersion))
+		if problem.Status == http.StatusInternalServerError {
+			logger.Error("failed to apply batch operation", slog.Int("index", i), slog.String("err", opErr.Error()))
+		}
+
+		results[i].Status = problem.Status
+		results[i].Code = problem.Code
+		results[i].Error = problem.Detail
+
+		if err != nil && problem.Status != http.StatusFailedDependency {
+			code = problem.Status
+		}
+	}
+
+	if err != nil {
+		logger.Info("atomic batch was aborted", slog.String("err", err.Error()))
+	}
+
+	handlers.WriteJSON(w, code, BatchResponse{
+		Atomic:  req.Atomic,
+		Results: results,
+	})
+}
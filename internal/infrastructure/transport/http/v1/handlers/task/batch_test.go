@@ -0,0 +1,229 @@
+package task_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/vo"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task/mocks"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchHandler(t *testing.T) {
+	validUserID := gofakeit.UUID()
+	firstTaskID := gofakeit.UUID()
+	secondTaskID := gofakeit.UUID()
+	thirdTaskID := gofakeit.UUID()
+
+	tests := []struct {
+		name         string
+		expectedCode int
+		expectedBody string
+		userID       string
+		requestBody  string
+		mockSetup    func(batcher *mocks.Batcher)
+	}{
+		{
+			name:         "best effort with per-item results",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"atomic":false,"results":[` +
+				`{"index":0,"task_id":"` + firstTaskID + `","status":200},` +
+				`{"index":1,"task_id":"` + secondTaskID + `","status":404,"code":"TASK_NOT_FOUND","error":"task not found"},` +
+				`{"index":2,"task_id":"` + thirdTaskID + `","status":403,"code":"TASK_ACCESS_DENIED","error":"access denied"},` +
+				`{"index":3,"task_id":"` + firstTaskID + `","status":400,"code":"TITLE_EMPTY","error":"title is empty"},` +
+				`{"index":4,"task_id":"` + secondTaskID + `","status":412,"code":"TASK_VERSION_MISMATCH","error":"task version mismatch"},` +
+				`{"index":5,"task_id":"` + thirdTaskID + `","status":409,"code":"TASK_CONFLICT","error":"task was modified concurrently"}` +
+				`]}`,
+			userID: validUserID,
+			requestBody: `{"operations":[` +
+				`{"op":"update","task_id":"` + firstTaskID + `","title":"new title","deadline":"2030-01-02T03:04:05Z"},` +
+				`{"op":"complete","task_id":"` + secondTaskID + `"},` +
+				`{"op":"reopen","task_id":"` + thirdTaskID + `"},` +
+				`{"op":"update","task_id":"` + firstTaskID + `","title":" "},` +
+				`{"op":"delete","task_id":"` + secondTaskID + `","version":3},` +
+				`{"op":"remove_deadline","task_id":"` + thirdTaskID + `"}` +
+				`]}`,
+			mockSetup: func(batcher *mocks.Batcher) {
+				deadline := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+				batcher.On("Batch", mock.Anything, validUserID, []services.BatchOperation{
+					{
+						Type:   services.BatchOperationUpdate,
+						TaskID: firstTaskID,
+						Update: services.UpdateTaskCommand{Title: new("new title"), Deadline: &deadline},
+					},
+					{Type: services.BatchOperationComplete, TaskID: secondTaskID},
+					{Type: services.BatchOperationReopen, TaskID: thirdTaskID},
+					{
+						Type:   services.BatchOperationUpdate,
+						TaskID: firstTaskID,
+						Update: services.UpdateTaskCommand{Title: new(" ")},
+					},
+					{Type: services.BatchOperationDelete, TaskID: secondTaskID, ExpectedVersion: new(int64(3))},
+					{Type: services.BatchOperationRemoveDeadline, TaskID: thirdTaskID},
+				}, false).
+					Once().
+					Return([]error{
+						nil,
+						services.ErrTaskNotFound,
+						services.ErrTaskAccessDenied,
+						vo.ErrTitleEmpty,
+						services.ErrTaskConflict,
+						services.ErrTaskConflict,
+					}, nil)
+			},
+		},
+		{
+			name:         "atomic success",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"atomic":true,"results":[` +
+				`{"index":0,"task_id":"` + firstTaskID + `","status":200},` +
+				`{"index":1,"task_id":"` + secondTaskID + `","status":200}` +
+				`]}`,
+			userID: validUserID,
+			requestBody: `{"atomic":true,"operations":[` +
+				`{"op":"complete","task_id":"` + firstTaskID + `"},` +
+				`{"op":"complete","task_id":"` + secondTaskID + `"}` +
+				`]}`,
+			mockSetup: func(batcher *mocks.Batcher) {
+				batcher.On("Batch", mock.Anything, validUserID, mock.Anything, true).
+					Once().
+					Return([]error{nil, nil}, nil)
+			},
+		},
+		{
+			name:         "atomic batch is aborted",
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"atomic":true,"results":[` +
+				`{"index":0,"task_id":"` + firstTaskID + `","status":424,"code":"BATCH_OPERATION_NOT_APPLIED","error":"operation was not applied"},` +
+				`{"index":1,"task_id":"` + secondTaskID + `","status":404,"code":"TASK_NOT_FOUND","error":"task not found"},` +
+				`{"index":2,"task_id":"` + thirdTaskID + `","status":424,"code":"BATCH_OPERATION_NOT_APPLIED","error":"operation was not applied"}` +
+				`]}`,
+			userID: validUserID,
+			requestBody: `{"atomic":true,"operations":[` +
+				`{"op":"complete","task_id":"` + firstTaskID + `"},` +
+				`{"op":"complete","task_id":"` + secondTaskID + `"},` +
+				`{"op":"complete","task_id":"` + thirdTaskID + `"}` +
+				`]}`,
+			mockSetup: func(batcher *mocks.Batcher) {
+				batcher.On("Batch", mock.Anything, validUserID, mock.Anything, true).
+					Once().
+					Return(
+						[]error{services.ErrTaskBatchAborted, services.ErrTaskNotFound, services.ErrTaskBatchAborted},
+						errors.Join(services.ErrTaskBatchAborted, services.ErrTaskNotFound),
+					)
+			},
+		},
+		{
+			name:         "atomic transaction fails",
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"type":"/problems/internal-error","title":"Internal Server Error","status":500,"detail":"internal server error","code":"INTERNAL_ERROR"}`,
+			userID:       validUserID,
+			requestBody:  `{"atomic":true,"operations":[{"op":"complete","task_id":"` + firstTaskID + `"}]}`,
+			mockSetup: func(batcher *mocks.Batcher) {
+				batcher.On("Batch", mock.Anything, validUserID, mock.Anything, true).
+					Once().
+					Return([]error{services.ErrTaskBatchAborted}, services.ErrTaskBatchFailed)
+			},
+		},
+		{
+			name:         "internal error of an operation",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"atomic":false,"results":[` +
+				`{"index":0,"task_id":"` + firstTaskID + `","status":500,"code":"INTERNAL_ERROR","error":"internal server error"}` +
+				`]}`,
+			userID:      validUserID,
+			requestBody: `{"operations":[{"op":"complete","task_id":"` + firstTaskID + `"}]}`,
+			mockSetup: func(batcher *mocks.Batcher) {
+				batcher.On("Batch", mock.Anything, validUserID, mock.Anything, false).
+					Once().
+					Return([]error{services.ErrTaskCompleteFailed}, nil)
+			},
+		},
+		{
+			name:         "too many operations",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/batch-too-large","title":"Bad Request","status":400,"detail":"batch must not contain more than 6 operations","code":"BATCH_TOO_LARGE"}`,
+			userID:       validUserID,
+			requestBody: `{"operations":[` +
+				strings.Repeat(`{"op":"complete","task_id":"`+firstTaskID+`"},`, 6) +
+				`{"op":"complete","task_id":"` + firstTaskID + `"}` +
+				`]}`,
+			mockSetup: nil,
+		},
+		{
+			name:         "no operations",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/validation-failed","title":"Bad Request","status":400,"detail":"request body is invalid","code":"VALIDATION_FAILED","errors":[{"field":"operations","rule":"min","params":{"min":1},"error":"must contain at least 1 item"}]}`,
+			userID:       validUserID,
+			requestBody:  `{"operations":[]}`,
+			mockSetup:    nil,
+		},
+		{
+			name:         "unknown operation",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/validation-failed","title":"Bad Request","status":400,"detail":"request body is invalid","code":"VALIDATION_FAILED","errors":[{"field":"operations[0].op","rule":"oneof","params":{"values":["update","remove_deadline","complete","reopen","delete"]},"error":"must be one of: update, remove_deadline, complete, reopen, delete"}]}`,
+			userID:       validUserID,
+			requestBody:  `{"operations":[{"op":"rename","task_id":"` + firstTaskID + `"}]}`,
+			mockSetup:    nil,
+		},
+		{
+			name:         "missing task id",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/validation-failed","title":"Bad Request","status":400,"detail":"request body is invalid","code":"VALIDATION_FAILED","errors":[{"field":"operations[0].task_id","rule":"required","error":"is required"}]}`,
+			userID:       validUserID,
+			requestBody:  `{"operations":[{"op":"complete"}]}`,
+			mockSetup:    nil,
+		},
+		{
+			name:         "empty user id",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/bad-request","title":"Bad Request","status":400,"detail":"bad request","code":"BAD_REQUEST"}`,
+			userID:       "",
+			requestBody:  `{"operations":[{"op":"complete","task_id":"` + firstTaskID + `"}]}`,
+			mockSetup:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequestWithContext(
+				context.WithValue(context.Background(), myMw.UserIDKey, tt.userID),
+				http.MethodPost,
+				"/tasks/batch",
+				bytes.NewReader([]byte(tt.requestBody)),
+			)
+
+			rr := httptest.NewRecorder()
+
+			batcher := new(mocks.Batcher)
+			if tt.mockSetup != nil {
+				tt.mockSetup(batcher)
+			}
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+
+			h := task.NewBatchHandler(batcher, 6, 4*time.Second, logger, handlers.NewValidator())
+			h.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedCode, rr.Code)
+			require.JSONEq(t, tt.expectedBody, rr.Body.String())
+
+			batcher.AssertExpectations(t)
+		})
+	}
+}
@@ -2,20 +2,18 @@ package task
 
 import (
 	"context"
-	"errors"
 	"log/slog"
 	"net/http"
 	"time"
 
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
 	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
-	"github.com/cyberbrain-dev/taskery-api/internal/services"
-	"github.com/go-chi/chi/v5/middleware"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
 	"github.com/go-playground/validator/v10"
 )
 
 type Completer interface {
-	Complete(ctx context.Context, id string, ownerID string) error
+	Complete(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
 }
 
 type CompleteHandler struct {
@@ -45,55 +43,53 @@ func NewCompleteHandler(
 // @Accept json
 // @Produce json
 // @Param request body CompleteRequest true "Task completion request"
+// @Param If-Match header string false "Expected task version (ETag)"
 // @Security     BearerAuth
 // @Success 204 {object} nil
-// @Failure 400 {object} handlers.ErrorResponse
-// @Failure 401 {object} handlers.ErrorResponse
-// @Failure 403 {object} handlers.ErrorResponse
-// @Failure 404 {object} handlers.ErrorResponse
-// @Failure 500 {object} handlers.ErrorResponse
+// @Header 204 {string} ETag "New task version"
+// @Failure 400 {object} handlers.Problem
+// @Failure 401 {object} handlers.Problem
+// @Failure 403 {object} handlers.Problem
+// @Failure 404 {object} handlers.Problem
+// @Failure 409 {object} handlers.Problem
+// @Failure 412 {object} handlers.Problem
+// @Failure 500 {object} handlers.Problem
 // @Router /tasks/complete [patch]
 func (h *CompleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	const op = "handlers.Task.Complete"
 
-	logger := h.logger.With(
-		slog.String("op", op),
-		slog.String("request_id", middleware.GetReqID(r.Context())),
-	)
+	logger := slogx.FromContext(r.Context(), h.logger).With(slog.String("op", op))
 
 	req, ok := handlers.DecodeAndValidate[CompleteRequest](w, r, logger, h.validate)
 	if !ok {
 		return
 	}
 
+	expectedVersion, err := handlers.ParseIfMatch(r)
+	if err != nil {
+		logger.Error("failed to parse If-Match header", slog.String("err", err.Error()))
+		handlers.WriteError(w, r, err)
+		return
+	}
+
 	userID := myMw.GetUserID(r.Context())
 	if userID == "" {
 		logger.Error("failed to extract owner id")
-		handlers.WriteError(w, http.StatusBadRequest, errors.New("bad request"))
+		handlers.WriteError(w, r, handlers.ErrBadRequest)
 		return
 	}
 
 	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
 	defer cancel()
 
-	err := h.completer.Complete(ctx, req.TaskID, userID)
+	version, err := h.completer.Complete(ctx, req.TaskID, userID, expectedVersion)
 	if err != nil {
 		logger.Error("failed to complete task", slog.String("err", err.Error()))
-
-		if errors.Is(err, services.ErrTaskNotFound) {
-			handlers.WriteError(w, http.StatusNotFound, errors.New("task not found"))
-			return
-		}
-
-		if errors.Is(err, services.ErrTaskAccessDenied) {
-			handlers.WriteError(w, http.StatusForbidden, errors.New("access denied"))
-			return
-		}
-
-		handlers.WriteError(w, http.StatusInternalServerError, errors.New("internal server error"))
+		handlers.WriteError(w, r, handlers.PreconditionError(err, expectedVersion))
 		return
 	}
 
 	logger.Info("task completed")
+	w.Header().Set("ETag", handlers.FormatETag(version))
 	handlers.WriteJSON(w, http.StatusNoContent, nil)
 }
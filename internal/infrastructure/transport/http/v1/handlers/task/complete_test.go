@@ -12,11 +12,11 @@ import (
 	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task"
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task/mocks"
 	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
 	"github.com/cyberbrain-dev/taskery-api/internal/services"
-	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -29,7 +29,9 @@ func TestCompleteHandler(t *testing.T) {
 		name         string
 		payload      task.CompleteRequest
 		expectedCode int
+		expectedETag string
 		expectedBody string
+		ifMatch      string
 		userID       string
 		mockSetup    func(completer *mocks.Completer)
 	}{
@@ -39,11 +41,12 @@ func TestCompleteHandler(t *testing.T) {
 				TaskID: validTaskID,
 			},
 			expectedCode: http.StatusNoContent,
+			expectedETag: `"2"`,
 			expectedBody: "",
 			userID:       validUserID,
 			mockSetup: func(completer *mocks.Completer) {
-				completer.On("Complete", mock.Anything, validTaskID, validUserID).
-					Return(nil)
+				completer.On("Complete", mock.Anything, validTaskID, validUserID, (*int64)(nil)).
+					Return(int64(2), nil)
 			},
 		},
 		{
@@ -52,11 +55,11 @@ func TestCompleteHandler(t *testing.T) {
 				TaskID: validTaskID,
 			},
 			expectedCode: http.StatusNotFound,
-			expectedBody: `{"error":"task not found"}`,
+			expectedBody: `{"type":"/problems/task-not-found","title":"Not Found","status":404,"detail":"task not found","code":"TASK_NOT_FOUND"}`,
 			userID:       validUserID,
 			mockSetup: func(completer *mocks.Completer) {
-				completer.On("Complete", mock.Anything, validTaskID, validUserID).
-					Return(services.ErrTaskNotFound)
+				completer.On("Complete", mock.Anything, validTaskID, validUserID, (*int64)(nil)).
+					Return(int64(0), services.ErrTaskNotFound)
 			},
 		},
 		{
@@ -65,11 +68,11 @@ func TestCompleteHandler(t *testing.T) {
 				TaskID: validTaskID,
 			},
 			expectedCode: http.StatusForbidden,
-			expectedBody: `{"error":"access denied"}`,
+			expectedBody: `{"type":"/problems/task-access-denied","title":"Forbidden","status":403,"detail":"access denied","code":"TASK_ACCESS_DENIED"}`,
 			userID:       validUserID,
 			mockSetup: func(completer *mocks.Completer) {
-				completer.On("Complete", mock.Anything, validTaskID, validUserID).
-					Return(services.ErrTaskAccessDenied)
+				completer.On("Complete", mock.Anything, validTaskID, validUserID, (*int64)(nil)).
+					Return(int64(0), services.ErrTaskAccessDenied)
 			},
 		},
 		{
@@ -78,11 +81,11 @@ func TestCompleteHandler(t *testing.T) {
 				TaskID: validTaskID,
 			},
 			expectedCode: http.StatusInternalServerError,
-			expectedBody: `{"error":"internal server error"}`,
+			expectedBody: `{"type":"/problems/internal-error","title":"Internal Server Error","status":500,"detail":"internal server error","code":"INTERNAL_ERROR"}`,
 			userID:       validUserID,
 			mockSetup: func(completer *mocks.Completer) {
-				completer.On("Complete", mock.Anything, validTaskID, validUserID).
-					Return(services.ErrTaskCompleteFailed)
+				completer.On("Complete", mock.Anything, validTaskID, validUserID, (*int64)(nil)).
+					Return(int64(0), services.ErrTaskCompleteFailed)
 			},
 		},
 		{
@@ -91,7 +94,7 @@ func TestCompleteHandler(t *testing.T) {
 				TaskID: validTaskID,
 			},
 			expectedCode: http.StatusBadRequest,
-			expectedBody: `{"error":"bad request"}`,
+			expectedBody: `{"type":"/problems/bad-request","title":"Bad Request","status":400,"detail":"bad request","code":"BAD_REQUEST"}`,
 			userID:       "",
 			mockSetup:    nil,
 		},
@@ -101,10 +104,24 @@ func TestCompleteHandler(t *testing.T) {
 				TaskID: "",
 			},
 			expectedCode: http.StatusBadRequest,
-			expectedBody: `{"errors":[{"field":"TaskID","error":"field is required"}]}`,
+			expectedBody: `{"type":"/problems/validation-failed","title":"Bad Request","status":400,"detail":"request body is invalid","code":"VALIDATION_FAILED","errors":[{"field":"task_id","rule":"required","error":"is required"}]}`,
 			userID:       validUserID,
 			mockSetup:    nil,
 		},
+		{
+			name: "version mismatch",
+			payload: task.CompleteRequest{
+				TaskID: validTaskID,
+			},
+			expectedCode: http.StatusPreconditionFailed,
+			expectedBody: `{"type":"/problems/task-version-mismatch","title":"Precondition Failed","status":412,"detail":"task version mismatch","code":"TASK_VERSION_MISMATCH"}`,
+			ifMatch:      `"2"`,
+			userID:       validUserID,
+			mockSetup: func(completer *mocks.Completer) {
+				completer.On("Complete", mock.Anything, validTaskID, validUserID, new(int64(2))).
+					Return(int64(0), services.ErrTaskConflict)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -119,6 +136,9 @@ func TestCompleteHandler(t *testing.T) {
 				bytes.NewBuffer(body),
 			)
 			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
 
 			rr := httptest.NewRecorder()
 
@@ -129,10 +149,11 @@ func TestCompleteHandler(t *testing.T) {
 
 			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
 
-			h := task.NewCompleteHandler(completer, 4*time.Second, logger, validator.New())
+			h := task.NewCompleteHandler(completer, 4*time.Second, logger, handlers.NewValidator())
 			h.ServeHTTP(rr, req)
 
 			require.Equal(t, tt.expectedCode, rr.Code)
+			require.Equal(t, tt.expectedETag, rr.Header().Get("ETag"))
 
 			if tt.expectedBody != "" {
 				require.Equal(t, tt.expectedBody, rr.Body.String())
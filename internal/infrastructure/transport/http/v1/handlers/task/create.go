@@ -2,7 +2,6 @@ package task
 
 import (
 	"context"
-	"errors"
 	"log/slog"
 	"net/http"
 	"time"
@@ -10,7 +9,7 @@ import (
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
 	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
 	"github.com/cyberbrain-dev/taskery-api/internal/services"
-	"github.com/go-chi/chi/v5/middleware"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 )
@@ -48,18 +47,15 @@ func NewCreateHandler(
 // @Param request body CreateRequest true "Task creation request"
 // @Security     BearerAuth
 // @Success 201 {object} CreateResponse
-// @Failure 400 {object} handlers.ErrorResponse
-// @Failure 401 {object} handlers.ErrorResponse
-// @Failure 403 {object} handlers.ErrorResponse
-// @Failure 500 {object} handlers.ErrorResponse
+// @Failure 400 {object} handlers.Problem
+// @Failure 401 {object} handlers.Problem
+// @Failure 403 {object} handlers.Problem
+// @Failure 500 {object} handlers.Problem
 // @Router /tasks [post]
 func (h *CreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	const op = "handlers.Task.Create"
 
-	logger := h.logger.With(
-		slog.String("op", op),
-		slog.String("request_id", middleware.GetReqID(r.Context())),
-	)
+	logger := slogx.FromContext(r.Context(), h.logger).With(slog.String("op", op))
 
 	req, ok := handlers.DecodeAndValidate[CreateRequest](w, r, logger, h.validate)
 	if !ok {
@@ -69,14 +65,14 @@ func (h *CreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ownerIDStr := myMw.GetUserID(r.Context())
 	if ownerIDStr == "" {
 		logger.Error("failed to extract owner id")
-		handlers.WriteError(w, http.StatusBadRequest, errors.New("bad request"))
+		handlers.WriteError(w, r, handlers.ErrBadRequest)
 		return
 	}
 
 	ownerID, err := uuid.Parse(ownerIDStr)
 	if err != nil {
 		logger.Error("failed to parse owner ID", slog.String("err", err.Error()))
-		handlers.WriteError(w, http.StatusBadRequest, errors.New("bad request"))
+		handlers.WriteError(w, r, handlers.ErrBadRequest)
 		return
 	}
 
@@ -91,23 +87,7 @@ func (h *CreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	})
 	if err != nil {
 		logger.Error("failed to create task", slog.String("err", err.Error()))
-
-		if errors.Is(err, services.ErrTaskExists) {
-			handlers.WriteError(w, http.StatusConflict, errors.New("task already exists"))
-			return
-		}
-
-		if errors.Is(err, services.ErrTaskOwnerNotFound) {
-			handlers.WriteError(w, http.StatusNotFound, errors.New("task owner not found"))
-			return
-		}
-
-		if errors.Is(err, services.ErrTaskCreateFailed) {
-			handlers.WriteError(w, http.StatusInternalServerError, errors.New("task creation failed"))
-			return
-		}
-
-		handlers.WriteError(w, http.StatusBadRequest, err)
+		handlers.WriteError(w, r, err)
 		return
 	}
 
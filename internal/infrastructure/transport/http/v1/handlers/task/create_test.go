@@ -14,11 +14,11 @@ import (
 
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/vo"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task"
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task/mocks"
 	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
 	"github.com/cyberbrain-dev/taskery-api/internal/services"
-	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -82,7 +82,7 @@ func TestCreateHandler(t *testing.T) {
 				Deadline:    nil,
 			},
 			expectedCode: http.StatusConflict,
-			expectedBody: `{"error":"task already exists"}`,
+			expectedBody: `{"type":"/problems/task-already-exists","title":"Conflict","status":409,"detail":"task already exists","code":"TASK_ALREADY_EXISTS"}`,
 
 			userID: validUserID,
 
@@ -99,7 +99,7 @@ func TestCreateHandler(t *testing.T) {
 				Deadline:    nil,
 			},
 			expectedCode: http.StatusBadRequest,
-			expectedBody: `{"errors":[{"field":"Title","error":"field is required"}]}`,
+			expectedBody: `{"type":"/problems/validation-failed","title":"Bad Request","status":400,"detail":"request body is invalid","code":"VALIDATION_FAILED","errors":[{"field":"title","rule":"required","error":"is required"}]}`,
 
 			userID: validUserID,
 
@@ -117,7 +117,7 @@ func TestCreateHandler(t *testing.T) {
 			},
 
 			expectedCode: http.StatusNotFound,
-			expectedBody: `{"error":"task owner not found"}`,
+			expectedBody: `{"type":"/problems/task-owner-not-found","title":"Not Found","status":404,"detail":"task owner not found","code":"TASK_OWNER_NOT_FOUND"}`,
 
 			userID: gofakeit.UUID(),
 
@@ -135,7 +135,7 @@ func TestCreateHandler(t *testing.T) {
 			},
 
 			expectedCode: http.StatusInternalServerError,
-			expectedBody: `{"error":"task creation failed"}`,
+			expectedBody: `{"type":"/problems/internal-error","title":"Internal Server Error","status":500,"detail":"internal server error","code":"INTERNAL_ERROR"}`,
 
 			userID: validUserID,
 
@@ -167,7 +167,7 @@ func TestCreateHandler(t *testing.T) {
 
 			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
 
-			h := task.NewCreateHandler(creator, 4*time.Second, logger, validator.New())
+			h := task.NewCreateHandler(creator, 4*time.Second, logger, handlers.NewValidator())
 			h.ServeHTTP(rr, req)
 
 			require.Equal(t, tt.expectedCode, rr.Code)
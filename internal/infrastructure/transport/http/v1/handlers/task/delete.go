@@ -2,20 +2,21 @@ package task
 
 import (
 	"context"
-	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
 	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
-	"github.com/cyberbrain-dev/taskery-api/internal/services"
-	"github.com/go-chi/chi/v5/middleware"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
+	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
 )
 
 type Deleter interface {
-	Delete(ctx context.Context, id string, ownerID string) error
+	Delete(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+	DeletePermanently(ctx context.Context, id string, ownerID string, expectedVersion *int64) error
 }
 
 type DeleteHandler struct {
@@ -39,60 +40,89 @@ func NewDeleteHandler(
 }
 
 // @Summary Delete a task
-// @Description Deletes an existing task for the authenticated user
+// @Description Moves a task of the authenticated user to the trash, or removes it for good if permanent is true.
+// @Description The task ID is taken from the path or, for DELETE /tasks, from the request body.
 // @Tags tasks
 // @Accept json
 // @Produce json
-// @Param request body DeleteRequest true "Task deletion request"
+// @Param id path string false "Task ID"
+// @Param request body DeleteRequest false "Task deletion request"
+// @Param permanent query bool false "Delete the task permanently instead of moving it to the trash"
+// @Param If-Match header string false "Expected task version (ETag)"
 // @Security     BearerAuth
 // @Success 204 {object} nil
-// @Failure 400 {object} handlers.ErrorResponse
-// @Failure 401 {object} handlers.ErrorResponse
-// @Failure 403 {object} handlers.ErrorResponse
-// @Failure 404 {object} handlers.ErrorResponse
-// @Failure 500 {object} handlers.ErrorResponse
+// @Header 204 {string} ETag "Version of the task in the trash"
+// @Failure 400 {object} handlers.Problem
+// @Failure 401 {object} handlers.Problem
+// @Failure 403 {object} handlers.Problem
+// @Failure 404 {object} handlers.Problem
+// @Failure 409 {object} handlers.Problem
+// @Failure 412 {object} handlers.Problem
+// @Failure 500 {object} handlers.Problem
+// @Router /tasks/{id} [delete]
 // @Router /tasks [delete]
 func (h *DeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	const op = "handlers.Task.Delete"
 
-	logger := h.logger.With(
-		slog.String("op", op),
-		slog.String("request_id", middleware.GetReqID(r.Context())),
-	)
+	logger := slogx.FromContext(r.Context(), h.logger).With(slog.String("op", op))
 
-	req, ok := handlers.DecodeAndValidate[DeleteRequest](w, r, logger, h.validate)
-	if !ok {
+	taskID := chi.URLParam(r, "id")
+	if taskID == "" {
+		req, ok := handlers.DecodeAndValidate[DeleteRequest](w, r, logger, h.validate)
+		if !ok {
+			return
+		}
+
+		taskID = req.TaskID
+	}
+
+	permanent := false
+	if raw := r.URL.Query().Get("permanent"); raw != "" {
+		var err error
+
+		permanent, err = strconv.ParseBool(raw)
+		if err != nil {
+			logger.Info("invalid permanent parameter", slog.String("err", err.Error()))
+			handlers.WriteError(w, r, handlers.InvalidParameter("permanent"))
+			return
+		}
+	}
+
+	expectedVersion, err := handlers.ParseIfMatch(r)
+	if err != nil {
+		logger.Error("failed to parse If-Match header", slog.String("err", err.Error()))
+		handlers.WriteError(w, r, err)
 		return
 	}
 
 	userID := myMw.GetUserID(r.Context())
 	if userID == "" {
 		logger.Error("failed to extract owner id")
-		handlers.WriteError(w, http.StatusBadRequest, errors.New("bad request"))
+		handlers.WriteError(w, r, handlers.ErrBadRequest)
 		return
 	}
 
 	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
 	defer cancel()
 
-	err := h.deleter.Delete(ctx, req.TaskID, userID)
+	// a task in the trash keeps its version, while a permanently deleted one has none
+	var version int64
+	if permanent {
+		err = h.deleter.DeletePermanently(ctx, taskID, userID, expectedVersion)
+	} else {
+		version, err = h.deleter.Delete(ctx, taskID, userID, expectedVersion)
+	}
 	if err != nil {
 		logger.Error("failed to delete task", slog.String("err", err.Error()))
+		handlers.WriteError(w, r, handlers.PreconditionError(err, expectedVersion))
+		return
+	}
 
-		if errors.Is(err, services.ErrTaskNotFound) {
-			handlers.WriteError(w, http.StatusNotFound, errors.New("task not found"))
-			return
-		}
-
-		if errors.Is(err, services.ErrTaskAccessDenied) {
-			handlers.WriteError(w, http.StatusForbidden, errors.New("access denied"))
-			return
-		}
+	logger.Info("task deleted", slog.Bool("permanent", permanent))
 
-		handlers.WriteError(w, http.StatusInternalServerError, errors.New("internal server error"))
-		return
+	if !permanent {
+		w.Header().Set("ETag", handlers.FormatETag(version))
 	}
 
-	logger.Info("task deleted")
 	handlers.WriteJSON(w, http.StatusNoContent, nil)
 }
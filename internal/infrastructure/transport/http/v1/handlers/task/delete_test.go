@@ -13,11 +13,12 @@ import (
 	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task"
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task/mocks"
 	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
 	"github.com/cyberbrain-dev/taskery-api/internal/services"
-	"github.com/go-playground/validator/v10"
+	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -30,7 +31,11 @@ func TestDeleteHandler(t *testing.T) {
 		name         string
 		payload      task.DeleteRequest
 		expectedCode int
+		expectedETag string
 		expectedBody string
+		ifMatch      string
+		urlTaskID    string
+		query        string
 
 		userID string
 
@@ -42,14 +47,15 @@ func TestDeleteHandler(t *testing.T) {
 				TaskID: validTaskID,
 			},
 			expectedCode: http.StatusNoContent,
+			expectedETag: `"2"`,
 			expectedBody: "",
 
 			userID: validUserID,
 
 			mockSetup: func(deleter *mocks.Deleter) {
 				deleter.
-					On("Delete", mock.Anything, validTaskID, validUserID).
-					Return(nil)
+					On("Delete", mock.Anything, validTaskID, validUserID, (*int64)(nil)).
+					Return(int64(2), nil)
 			},
 		},
 		{
@@ -58,14 +64,14 @@ func TestDeleteHandler(t *testing.T) {
 				TaskID: validTaskID,
 			},
 			expectedCode: http.StatusNotFound,
-			expectedBody: `{"error":"task not found"}`,
+			expectedBody: `{"type":"/problems/task-not-found","title":"Not Found","status":404,"detail":"task not found","code":"TASK_NOT_FOUND"}`,
 
 			userID: validUserID,
 
 			mockSetup: func(deleter *mocks.Deleter) {
 				deleter.
-					On("Delete", mock.Anything, validTaskID, validUserID).
-					Return(services.ErrTaskNotFound)
+					On("Delete", mock.Anything, validTaskID, validUserID, (*int64)(nil)).
+					Return(int64(0), services.ErrTaskNotFound)
 			},
 		},
 		{
@@ -74,14 +80,14 @@ func TestDeleteHandler(t *testing.T) {
 				TaskID: validTaskID,
 			},
 			expectedCode: http.StatusForbidden,
-			expectedBody: `{"error":"access denied"}`,
+			expectedBody: `{"type":"/problems/task-access-denied","title":"Forbidden","status":403,"detail":"access denied","code":"TASK_ACCESS_DENIED"}`,
 
 			userID: validUserID,
 
 			mockSetup: func(deleter *mocks.Deleter) {
 				deleter.
-					On("Delete", mock.Anything, validTaskID, validUserID).
-					Return(services.ErrTaskAccessDenied)
+					On("Delete", mock.Anything, validTaskID, validUserID, (*int64)(nil)).
+					Return(int64(0), services.ErrTaskAccessDenied)
 			},
 		},
 		{
@@ -90,14 +96,14 @@ func TestDeleteHandler(t *testing.T) {
 				TaskID: validTaskID,
 			},
 			expectedCode: http.StatusInternalServerError,
-			expectedBody: `{"error":"internal server error"}`,
+			expectedBody: `{"type":"/problems/internal-error","title":"Internal Server Error","status":500,"detail":"internal server error","code":"INTERNAL_ERROR"}`,
 
 			userID: validUserID,
 
 			mockSetup: func(deleter *mocks.Deleter) {
 				deleter.
-					On("Delete", mock.Anything, validTaskID, validUserID).
-					Return(errors.New("unexpected error"))
+					On("Delete", mock.Anything, validTaskID, validUserID, (*int64)(nil)).
+					Return(int64(0), errors.New("unexpected error"))
 			},
 		},
 		{
@@ -106,7 +112,7 @@ func TestDeleteHandler(t *testing.T) {
 				TaskID: validTaskID,
 			},
 			expectedCode: http.StatusBadRequest,
-			expectedBody: `{"error":"bad request"}`,
+			expectedBody: `{"type":"/problems/bad-request","title":"Bad Request","status":400,"detail":"bad request","code":"BAD_REQUEST"}`,
 
 			userID: "",
 
@@ -119,7 +125,7 @@ func TestDeleteHandler(t *testing.T) {
 			},
 			expectedCode: http.StatusBadRequest,
 			// зависит от твоего DecodeAndValidate, при необходимости скорректируй
-			expectedBody: `{"errors":[{"field":"TaskID","error":"field is required"}]}`,
+			expectedBody: `{"type":"/problems/validation-failed","title":"Bad Request","status":400,"detail":"request body is invalid","code":"VALIDATION_FAILED","errors":[{"field":"task_id","rule":"required","error":"is required"}]}`,
 
 			userID: validUserID,
 
@@ -127,6 +133,79 @@ func TestDeleteHandler(t *testing.T) {
 				// Delete не должен вызываться
 			},
 		},
+		{
+			name: "version mismatch",
+			payload: task.DeleteRequest{
+				TaskID: validTaskID,
+			},
+			expectedCode: http.StatusPreconditionFailed,
+			expectedBody: `{"type":"/problems/task-version-mismatch","title":"Precondition Failed","status":412,"detail":"task version mismatch","code":"TASK_VERSION_MISMATCH"}`,
+			ifMatch:      `"5"`,
+
+			userID: validUserID,
+
+			mockSetup: func(deleter *mocks.Deleter) {
+				deleter.
+					On("Delete", mock.Anything, validTaskID, validUserID, new(int64(5))).
+					Return(int64(0), services.ErrTaskConflict)
+			},
+		},
+		{
+			name:         "success with task id in path",
+			urlTaskID:    validTaskID,
+			expectedCode: http.StatusNoContent,
+			expectedETag: `"2"`,
+			expectedBody: "",
+
+			userID: validUserID,
+
+			mockSetup: func(deleter *mocks.Deleter) {
+				deleter.
+					On("Delete", mock.Anything, validTaskID, validUserID, (*int64)(nil)).
+					Return(int64(2), nil)
+			},
+		},
+		{
+			name:         "permanent deletion",
+			urlTaskID:    validTaskID,
+			query:        "?permanent=true",
+			expectedCode: http.StatusNoContent,
+			expectedBody: "",
+
+			userID: validUserID,
+
+			mockSetup: func(deleter *mocks.Deleter) {
+				deleter.
+					On("DeletePermanently", mock.Anything, validTaskID, validUserID, (*int64)(nil)).
+					Return(nil)
+			},
+		},
+		{
+			name:         "permanent deletion - task not found",
+			urlTaskID:    validTaskID,
+			query:        "?permanent=1",
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"type":"/problems/task-not-found","title":"Not Found","status":404,"detail":"task not found","code":"TASK_NOT_FOUND"}`,
+
+			userID: validUserID,
+
+			mockSetup: func(deleter *mocks.Deleter) {
+				deleter.
+					On("DeletePermanently", mock.Anything, validTaskID, validUserID, (*int64)(nil)).
+					Return(services.ErrTaskNotFound)
+			},
+		},
+		{
+			name:         "invalid permanent parameter",
+			urlTaskID:    validTaskID,
+			query:        "?permanent=maybe",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/invalid-parameter","title":"Bad Request","status":400,"detail":"invalid permanent parameter","code":"INVALID_PARAMETER"}`,
+
+			userID: validUserID,
+
+			mockSetup: nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -134,13 +213,23 @@ func TestDeleteHandler(t *testing.T) {
 			body, err := json.Marshal(tt.payload)
 			require.NoError(t, err)
 
+			ctx := context.WithValue(context.Background(), myMw.UserIDKey, tt.userID)
+			if tt.urlTaskID != "" {
+				routeCtx := chi.NewRouteContext()
+				routeCtx.URLParams.Add("id", tt.urlTaskID)
+				ctx = context.WithValue(ctx, chi.RouteCtxKey, routeCtx)
+			}
+
 			req := httptest.NewRequestWithContext(
-				context.WithValue(context.Background(), myMw.UserIDKey, tt.userID),
+				ctx,
 				http.MethodDelete,
-				"/task",
+				"/task"+tt.query,
 				bytes.NewBuffer(body),
 			)
 			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
 
 			rr := httptest.NewRecorder()
 
@@ -151,10 +240,11 @@ func TestDeleteHandler(t *testing.T) {
 
 			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
 
-			h := task.NewDeleteHandler(deleter, 4*time.Second, logger, validator.New())
+			h := task.NewDeleteHandler(deleter, 4*time.Second, logger, handlers.NewValidator())
 			h.ServeHTTP(rr, req)
 
 			require.Equal(t, tt.expectedCode, rr.Code)
+			require.Equal(t, tt.expectedETag, rr.Header().Get("ETag"))
 
 			if tt.expectedBody != "" {
 				require.Equal(t, tt.expectedBody, rr.Body.String())
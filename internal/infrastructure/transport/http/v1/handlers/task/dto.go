@@ -1,6 +1,13 @@
 package task
 
-import "time"
+import (
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/vo"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+)
 
 // ========= Requests =================
 
@@ -33,6 +40,52 @@ type ReopenRequest struct {
 	TaskID string `json:"task_id" validate:"required"`
 }
 
+// ArchiveCompletedRequest is limited to a hundred years, so that the age in days
+// does not overflow the duration it is converted to.
+type ArchiveCompletedRequest struct {
+	OlderThanDays *int `json:"older_than_days" validate:"required,min=0,max=36500"`
+}
+
+type BatchOperationRequest struct {
+	Op          string     `json:"op" validate:"required,oneof=update remove_deadline complete reopen delete"`
+	TaskID      string     `json:"task_id" validate:"required"`
+	Title       *string    `json:"title"`
+	Description *string    `json:"description"`
+	Deadline    *time.Time `json:"deadline"`
+	Version     *int64     `json:"version" validate:"omitempty,min=1"`
+}
+
+type BatchRequest struct {
+	Atomic     bool                    `json:"atomic"`
+	Operations []BatchOperationRequest `json:"operations" validate:"required,min=1,dive"`
+}
+
+// SyncChangeRequest is a change that the client made while offline.
+// A created task is identified by ClientID, since it has no ID until it is pushed.
+type SyncChangeRequest struct {
+	Op          string     `json:"op" validate:"required,oneof=create update remove_deadline complete reopen delete"`
+	ClientID    string     `json:"client_id" validate:"required_if=Op create"`
+	TaskID      string     `json:"task_id" validate:"required_unless=Op create"`
+	Title       *string    `json:"title"`
+	Description *string    `json:"description"`
+	Deadline    *time.Time `json:"deadline"`
+	Version     *int64     `json:"version" validate:"omitempty,min=1"`
+}
+
+type SyncPushRequest struct {
+	Changes []SyncChangeRequest `json:"changes" validate:"required,min=1,dive"`
+}
+
+// SocketRequest is a message from the client of the socket.
+type SocketRequest struct {
+	ID      string `json:"id" validate:"required"`
+	Type    string `json:"type" validate:"required,oneof=subscribe unsubscribe command"`
+	Channel string `json:"channel" validate:"required_unless=Type command"`
+	Command string `json:"command" validate:"required_if=Type command,omitempty,oneof=complete reopen remove_deadline delete restore"`
+	TaskID  string `json:"task_id" validate:"required_if=Type command"`
+	Version *int64 `json:"version" validate:"omitempty,min=1"`
+}
+
 // ========= Responses ================
 
 type CreateResponse struct {
@@ -50,9 +103,182 @@ type TaskDTO struct {
 	Deadline    *time.Time `json:"deadline"`
 	IsCompleted bool       `json:"is_completed"`
 	CompletedAt *time.Time `json:"completed_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	ArchivedAt  *time.Time `json:"archived_at"`
+	DeletedAt   *time.Time `json:"deleted_at"`
+	Version     int64      `json:"version"`
 }
 
 type FindByOwnerResponse struct {
 	OwnerID string    `json:"owner_id"`
 	Tasks   []TaskDTO `json:"tasks"`
 }
+
+type FindTrashResponse struct {
+	OwnerID string    `json:"owner_id"`
+	Tasks   []TaskDTO `json:"tasks"`
+}
+
+type ArchiveCompletedResponse struct {
+	Archived int64 `json:"archived"`
+}
+
+type FieldChangeDTO struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+type TaskEventDTO struct {
+	ID         string           `json:"id"`
+	Type       string           `json:"type"`
+	ActorID    string           `json:"actor_id"`
+	OccurredAt time.Time        `json:"occurred_at"`
+	Changes    []FieldChangeDTO `json:"changes"`
+}
+
+// TaskChangeDTO is the data of an event of the stream of task changes.
+type TaskChangeDTO struct {
+	TaskID  string `json:"task_id"`
+	Version int64  `json:"version"`
+	TaskEventDTO
+}
+
+// SocketAckDTO acknowledges a message of the client. Error is the problem of a failed message,
+// Version is the version of the task after a successful command.
+type SocketAckDTO struct {
+	Type    string            `json:"type"`
+	ID      string            `json:"id,omitempty"`
+	Version int64             `json:"version,omitempty"`
+	Error   *handlers.Problem `json:"error,omitempty"`
+}
+
+// SocketEventDTO is a change of a task pushed to a channel of the socket.
+type SocketEventDTO struct {
+	Type    string        `json:"type"`
+	Channel string        `json:"channel"`
+	Event   string        `json:"event"`
+	Data    TaskChangeDTO `json:"data"`
+}
+
+type SocketViewerDTO struct {
+	UserID      string `json:"user_id"`
+	Connections int    `json:"connections"`
+}
+
+// SocketPresenceDTO lists the users viewing a channel of the socket.
+type SocketPresenceDTO struct {
+	Type    string            `json:"type"`
+	Channel string            `json:"channel"`
+	Viewers []SocketViewerDTO `json:"viewers"`
+}
+
+type HistoryResponse struct {
+	TaskID string         `json:"task_id"`
+	Events []TaskEventDTO `json:"events"`
+}
+
+type BatchItemResult struct {
+	Index  int    `json:"index"`
+	TaskID string `json:"task_id"`
+	Status int    `json:"status"`
+	Code   string `json:"code,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+type BatchResponse struct {
+	Atomic  bool              `json:"atomic"`
+	Results []BatchItemResult `json:"results"`
+}
+
+type SyncResponse struct {
+	Token   string   `json:"token"`
+	HasMore bool     `json:"has_more"`
+	Created []string `json:"created"`
+	Updated []string `json:"updated"`
+	Deleted []string `json:"deleted"`
+}
+
+type SyncPushResult struct {
+	Index    int      `json:"index"`
+	ClientID string   `json:"client_id,omitempty"`
+	TaskID   string   `json:"task_id,omitempty"`
+	Status   int      `json:"status"`
+	Code     string   `json:"code,omitempty"`
+	Error    string   `json:"error,omitempty"`
+	Task     *TaskDTO `json:"task,omitempty"`
+}
+
+type SyncPushResponse struct {
+	Results []SyncPushResult `json:"results"`
+}
+
+type SearchResultDTO struct {
+	Task               TaskDTO `json:"task"`
+	Rank               float64 `json:"rank"`
+	TitleSnippet       string  `json:"title_snippet"`
+	DescriptionSnippet string  `json:"description_snippet"`
+}
+
+type SearchResponse struct {
+	OwnerID string            `json:"owner_id"`
+	Query   string            `json:"query"`
+	Results []SearchResultDTO `json:"results"`
+}
+
+// newTaskDTO converts the domain task into its transport representation.
+func newTaskDTO(task *models.Task) TaskDTO {
+	return TaskDTO{
+		ID:          task.ID().String(),
+		Title:       task.Title().String(),
+		Description: task.Description().String(),
+		Deadline:    convertDeadline(task.Deadline()),
+		IsCompleted: task.IsCompleted(),
+		CompletedAt: task.CompletedAt(),
+		CreatedAt:   task.CreatedAt(),
+		UpdatedAt:   task.UpdatedAt(),
+		ArchivedAt:  task.ArchivedAt(),
+		DeletedAt:   task.DeletedAt(),
+		Version:     task.Version(),
+	}
+}
+
+func convertDeadline(deadline *vo.Deadline) *time.Time {
+	if deadline == nil {
+		return nil
+	}
+	t := deadline.Time()
+	return &t
+}
+
+// newTaskEventDTO converts the domain task event into its transport representation.
+func newTaskEventDTO(event *models.TaskEvent) TaskEventDTO {
+	changes := event.Changes()
+
+	changeDTOs := make([]FieldChangeDTO, len(changes))
+	for i, change := range changes {
+		changeDTOs[i] = FieldChangeDTO{
+			Field:  change.Field,
+			Before: change.Before,
+			After:  change.After,
+		}
+	}
+
+	return TaskEventDTO{
+		ID:         event.ID().String(),
+		Type:       string(event.Type()),
+		ActorID:    event.ActorID().String(),
+		OccurredAt: event.OccurredAt(),
+		Changes:    changeDTOs,
+	}
+}
+
+// newTaskChangeDTO converts the change of a task into its transport representation.
+func newTaskChangeDTO(change services.TaskChange) TaskChangeDTO {
+	return TaskChangeDTO{
+		TaskID:       change.Event.TaskID().String(),
+		Version:      change.Version,
+		TaskEventDTO: newTaskEventDTO(change.Event),
+	}
+}
@@ -0,0 +1,144 @@
+package task
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/pubsub"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
+)
+
+type ChangeSubscriber interface {
+	Subscribe(ownerID, lastEventID string) *pubsub.Subscription
+}
+
+type EventsHandler struct {
+	subscriber ChangeSubscriber
+	heartbeat  time.Duration
+	logger     *slog.Logger
+}
+
+func NewEventsHandler(
+	subscriber ChangeSubscriber,
+	heartbeat time.Duration,
+	logger *slog.Logger,
+) *EventsHandler {
+	return &EventsHandler{
+		subscriber: subscriber,
+		heartbeat:  heartbeat,
+		logger:     logger,
+	}
+}
+
+// @Summary Stream task changes
+// @Description Streams the changes of the tasks of the authenticated user as Server-Sent Events.
+// @Description Each event is named after the type of the change and has the ID of the task event,
+// @Description so a client that reconnects with the Last-Event-ID header gets the changes it has missed.
+// @Description A "reset" event is sent if the missed changes are no longer available and the tasks have to be reloaded.
+// @Description A task that is removed permanently, including by the purge of the trash, is reported with a "purged" event.
+// @Tags tasks
+// @Produce text/event-stream
+// @Param Last-Event-ID header string false "ID of the last event received by the client"
+// @Security     BearerAuth
+// @Success 200 {object} TaskChangeDTO
+// @Failure 400 {object} handlers.Problem
+// @Failure 401 {object} handlers.Problem
+// @Failure 500 {object} handlers.Problem
+// @Router /tasks/events [get]
+func (h *EventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.Task.Events"
+
+	logger := slogx.FromContext(r.Context(), h.logger).With(slog.String("op", op))
+
+	userID := myMw.GetUserID(r.Context())
+	if userID == "" {
+		logger.Error("failed to extract owner id")
+		handlers.WriteError(w, r, handlers.ErrBadRequest)
+		return
+	}
+
+	rc := http.NewResponseController(w)
+
+	// the stream outlives the write timeout of the server
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		logger.Error("failed to clear write deadline", slog.String("err", err.Error()))
+		handlers.WriteError(w, r, err)
+		return
+	}
+
+	sub := h.subscriber.Subscribe(userID, r.Header.Get("Last-Event-ID"))
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if sub.Missed() {
+		if _, err := fmt.Fprint(w, "event: reset\ndata: {}\n\n"); err != nil {
+			return
+		}
+	}
+
+	for _, change := range sub.Replay() {
+		if err := writeChange(w, change); err != nil {
+			logger.Error("failed to write task change", slog.String("err", err.Error()))
+			return
+		}
+	}
+
+	if err := rc.Flush(); err != nil {
+		logger.Error("failed to flush task changes", slog.String("err", err.Error()))
+		return
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+
+		case change, ok := <-sub.Changes():
+			if !ok {
+				// the subscriber fell behind or the server is shutting down,
+				// the client reconnects and replays the missed changes
+				return
+			}
+
+			if err := writeChange(w, change); err != nil {
+				logger.Error("failed to write task change", slog.String("err", err.Error()))
+				return
+			}
+
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// writeChange writes the change as an event named after the type of the change.
+func writeChange(w http.ResponseWriter, change services.TaskChange) error {
+	data, err := json.Marshal(newTaskChangeDTO(change))
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", change.Event.ID(), change.Event.Type(), data)
+
+	return err
+}
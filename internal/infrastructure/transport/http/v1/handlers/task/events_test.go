@@ -0,0 +1,164 @@
+package task_test
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/pubsub"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// readEvent reads the next event of the stream, skipping the comments.
+func readEvent(t *testing.T, r *bufio.Reader) string {
+	t.Helper()
+
+	var lines []string
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "" && len(lines) > 0:
+			return strings.Join(lines, "\n")
+		case line == "", strings.HasPrefix(line, ":"):
+			continue
+		default:
+			lines = append(lines, line)
+		}
+	}
+}
+
+func TestEventsHandler(t *testing.T) {
+	userID := uuid.New()
+	otherUserID := uuid.New()
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	clk := clock.NewFake(now)
+
+	taskModel, err := models.NewTask("Test task", "", userID, clk)
+	require.NoError(t, err)
+	created := services.TaskChange{
+		OwnerID: userID.String(),
+		Version: 1,
+		Event:   models.NewTaskEvent(taskModel, models.TaskEventCreated, userID, nil, clk),
+	}
+
+	before := taskModel.State()
+	taskModel.Complete(clk)
+	completed := services.TaskChange{
+		OwnerID: userID.String(),
+		Version: 2,
+		Event:   models.NewTaskEvent(taskModel, models.TaskEventCompleted, userID, &before, clk),
+	}
+
+	otherTask, err := models.NewTask("Other task", "", otherUserID, clk)
+	require.NoError(t, err)
+	other := services.TaskChange{
+		OwnerID: otherUserID.String(),
+		Version: 1,
+		Event:   models.NewTaskEvent(otherTask, models.TaskEventCreated, otherUserID, nil, clk),
+	}
+
+	newServer := func(t *testing.T, broker *pubsub.Broker, userID string) *httptest.Server {
+		handler := task.NewEventsHandler(broker, time.Hour, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), myMw.UserIDKey, userID)))
+		}))
+		t.Cleanup(srv.Close)
+
+		return srv
+	}
+
+	connect := func(t *testing.T, srv *httptest.Server, lastEventID string) (*http.Response, *bufio.Reader) {
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+		require.NoError(t, err)
+		if lastEventID != "" {
+			req.Header.Set("Last-Event-ID", lastEventID)
+		}
+
+		resp, err := srv.Client().Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+
+		return resp, bufio.NewReader(resp.Body)
+	}
+
+	t.Run("streams changes of the user", func(t *testing.T) {
+		broker := pubsub.NewBroker(10, 10)
+		srv := newServer(t, broker, userID.String())
+
+		resp, stream := connect(t, srv, "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+		require.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+
+		require.NoError(t, broker.Publish(context.Background(), other))
+		require.NoError(t, broker.Publish(context.Background(), created))
+
+		event := readEvent(t, stream)
+		require.Equal(
+			t,
+			"id: "+created.Event.ID().String()+"\n"+
+				"event: created\n"+
+				`data: {"task_id":"`+taskModel.ID().String()+`","version":1,`+
+				`"id":"`+created.Event.ID().String()+`","type":"created","actor_id":"`+userID.String()+`",`+
+				`"occurred_at":"2026-01-02T03:04:05Z","changes":[{"field":"title","before":null,"after":"Test task"}]}`,
+			event,
+		)
+	})
+	t.Run("resumes after the last event", func(t *testing.T) {
+		broker := pubsub.NewBroker(10, 10)
+		require.NoError(t, broker.Publish(context.Background(), created))
+		require.NoError(t, broker.Publish(context.Background(), other))
+		require.NoError(t, broker.Publish(context.Background(), completed))
+
+		srv := newServer(t, broker, userID.String())
+		_, stream := connect(t, srv, created.Event.ID().String())
+
+		event := readEvent(t, stream)
+		require.True(t, strings.HasPrefix(event, "id: "+completed.Event.ID().String()+"\nevent: completed\n"), event)
+	})
+	t.Run("resets when the last event is no longer available", func(t *testing.T) {
+		broker := pubsub.NewBroker(10, 10)
+		require.NoError(t, broker.Publish(context.Background(), completed))
+
+		srv := newServer(t, broker, userID.String())
+		_, stream := connect(t, srv, uuid.NewString())
+
+		require.Equal(t, "event: reset\ndata: {}", readEvent(t, stream))
+	})
+	t.Run("ends the stream when the broker is closed", func(t *testing.T) {
+		broker := pubsub.NewBroker(10, 10)
+		srv := newServer(t, broker, userID.String())
+
+		_, stream := connect(t, srv, "")
+
+		broker.Close()
+
+		_, err := io.ReadAll(stream)
+		require.NoError(t, err)
+	})
+	t.Run("empty user id", func(t *testing.T) {
+		srv := newServer(t, pubsub.NewBroker(10, 10), "")
+
+		resp, _ := connect(t, srv, "")
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
@@ -0,0 +1,87 @@
+package task
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+type IDFinder interface {
+	FindByID(ctx context.Context, id string, ownerID string) (*models.Task, error)
+}
+
+type FindByIDHandler struct {
+	finder   IDFinder
+	timeout  time.Duration
+	logger   *slog.Logger
+	validate *validator.Validate
+}
+
+func NewFindByIDHandler(
+	finder IDFinder,
+	timeout time.Duration,
+	logger *slog.Logger,
+	validate *validator.Validate,
+) *FindByIDHandler {
+	return &FindByIDHandler{
+		finder:   finder,
+		timeout:  timeout,
+		logger:   logger,
+		validate: validate,
+	}
+}
+
+// @Summary Get a task
+// @Description Retrieves a task of the authenticated user. The task version is returned in the ETag header.
+// @Tags tasks
+// @Produce json
+// @Param id path string true "Task ID"
+// @Security     BearerAuth
+// @Success 200 {object} TaskDTO
+// @Header 200 {string} ETag "Task version"
+// @Failure 400 {object} handlers.Problem
+// @Failure 401 {object} handlers.Problem
+// @Failure 403 {object} handlers.Problem
+// @Failure 404 {object} handlers.Problem
+// @Failure 500 {object} handlers.Problem
+// @Router /tasks/{id} [get]
+func (h *FindByIDHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.Task.FindByID"
+
+	logger := slogx.FromContext(r.Context(), h.logger).With(slog.String("op", op))
+
+	taskID := chi.URLParam(r, "id")
+	if taskID == "" {
+		logger.Error("task id is not provided")
+		handlers.WriteError(w, r, handlers.MissingParameter("task id"))
+		return
+	}
+
+	userID := myMw.GetUserID(r.Context())
+	if userID == "" {
+		logger.Error("failed to extract owner id")
+		handlers.WriteError(w, r, handlers.ErrBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	task, err := h.finder.FindByID(ctx, taskID, userID)
+	if err != nil {
+		logger.Error("failed to find task", slog.String("err", err.Error()))
+		handlers.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("ETag", handlers.FormatETag(task.Version()))
+	handlers.WriteJSON(w, http.StatusOK, newTaskDTO(task))
+}
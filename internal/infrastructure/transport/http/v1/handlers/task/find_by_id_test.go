@@ -0,0 +1,149 @@
+package task_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task/mocks"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindByIDHandler(t *testing.T) {
+	validUserID := gofakeit.UUID()
+	validTaskID := gofakeit.UUID()
+
+	tests := []struct {
+		name         string
+		taskID       string
+		expectedCode int
+		expectedBody string
+		expectedETag string
+		userID       string
+		mockSetup    func(finder *mocks.IDFinder)
+	}{
+		{
+			name:         "success",
+			taskID:       validTaskID,
+			expectedCode: http.StatusOK,
+			expectedBody: func() string {
+				dto, _ := json.Marshal(task.TaskDTO{
+					ID:          validTaskID,
+					Title:       "Test task",
+					Description: "Test description",
+					Version:     7,
+				})
+				return string(dto)
+			}(),
+			expectedETag: `"7"`,
+			userID:       validUserID,
+			mockSetup: func(finder *mocks.IDFinder) {
+				task, err := models.NewTaskFromDB(models.TaskFromDBParams{
+					ID:          validTaskID,
+					OwnerID:     validUserID,
+					Title:       "Test task",
+					Description: "Test description",
+					Version:     7,
+				})
+				require.NoError(t, err)
+
+				finder.On("FindByID", mock.Anything, validTaskID, validUserID).
+					Return(task, nil)
+			},
+		},
+		{
+			name:         "task not found",
+			taskID:       validTaskID,
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"type":"/problems/task-not-found","title":"Not Found","status":404,"detail":"task not found","code":"TASK_NOT_FOUND"}`,
+			userID:       validUserID,
+			mockSetup: func(finder *mocks.IDFinder) {
+				finder.On("FindByID", mock.Anything, validTaskID, validUserID).
+					Return(nil, services.ErrTaskNotFound)
+			},
+		},
+		{
+			name:         "access denied",
+			taskID:       validTaskID,
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"type":"/problems/task-access-denied","title":"Forbidden","status":403,"detail":"access denied","code":"TASK_ACCESS_DENIED"}`,
+			userID:       validUserID,
+			mockSetup: func(finder *mocks.IDFinder) {
+				finder.On("FindByID", mock.Anything, validTaskID, validUserID).
+					Return(nil, services.ErrTaskAccessDenied)
+			},
+		},
+		{
+			name:         "internal error",
+			taskID:       validTaskID,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"type":"/problems/internal-error","title":"Internal Server Error","status":500,"detail":"internal server error","code":"INTERNAL_ERROR"}`,
+			userID:       validUserID,
+			mockSetup: func(finder *mocks.IDFinder) {
+				finder.On("FindByID", mock.Anything, validTaskID, validUserID).
+					Return(nil, services.ErrTaskFindByIDFailed)
+			},
+		},
+		{
+			name:         "empty user id",
+			taskID:       validTaskID,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/bad-request","title":"Bad Request","status":400,"detail":"bad request","code":"BAD_REQUEST"}`,
+			userID:       "",
+			mockSetup:    nil,
+		},
+		{
+			name:         "empty task id",
+			taskID:       "",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/missing-parameter","title":"Bad Request","status":400,"detail":"task id is required","code":"MISSING_PARAMETER"}`,
+			userID:       validUserID,
+			mockSetup:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			routeCtx := chi.NewRouteContext()
+			routeCtx.URLParams.Add("id", tt.taskID)
+
+			ctx := context.WithValue(context.Background(), myMw.UserIDKey, tt.userID)
+			ctx = context.WithValue(ctx, chi.RouteCtxKey, routeCtx)
+
+			req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/tasks/"+tt.taskID, nil)
+
+			rr := httptest.NewRecorder()
+
+			finder := new(mocks.IDFinder)
+			if tt.mockSetup != nil {
+				tt.mockSetup(finder)
+			}
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+
+			h := task.NewFindByIDHandler(finder, 4*time.Second, logger, handlers.NewValidator())
+			h.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedCode, rr.Code)
+			require.JSONEq(t, tt.expectedBody, rr.Body.String())
+			require.Equal(t, tt.expectedETag, rr.Header().Get("ETag"))
+
+			if tt.mockSetup != nil {
+				finder.AssertExpectations(t)
+			}
+		})
+	}
+}
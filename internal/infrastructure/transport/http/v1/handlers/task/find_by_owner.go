@@ -2,22 +2,21 @@ package task
 
 import (
 	"context"
-	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
-	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/vo"
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
 	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
 	"github.com/cyberbrain-dev/taskery-api/internal/services"
-	"github.com/go-chi/chi/v5/middleware"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
 	"github.com/go-playground/validator/v10"
 )
 
 type Finder interface {
-	FindByOwner(ctx context.Context, ownerID string) ([]*models.Task, error)
+	FindByOwner(ctx context.Context, ownerID string, query services.FindByOwnerQuery) ([]*models.Task, error)
 }
 
 type FindByOwnerHandler struct {
@@ -42,56 +41,69 @@ func NewFindByOwnerHandler(
 }
 
 // @Summary List tasks by owner
-// @Description Retrieves all tasks for the authenticated user
+// @Description Retrieves the tasks of the authenticated user that are not in the trash. Archived tasks are excluded unless include_archived is true
 // @Tags tasks
 // @Produce json
 // @Security     BearerAuth
+// @Param sort query string false "Sort order" Enums(created_at, -created_at)
+// @Param updated_since query string false "Return only tasks updated at or after this RFC 3339 timestamp"
+// @Param include_archived query bool false "Include archived tasks"
 // @Success 200 {object} FindByOwnerResponse
-// @Failure 400 {object} handlers.ErrorResponse
-// @Failure 401 {object} handlers.ErrorResponse
-// @Failure 500 {object} handlers.ErrorResponse
+// @Failure 400 {object} handlers.Problem
+// @Failure 401 {object} handlers.Problem
+// @Failure 500 {object} handlers.Problem
 // @Router /tasks [get]
 func (h *FindByOwnerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	const op = "handlers.Task.FindByOwner"
 
-	logger := h.logger.With(
-		slog.String("op", op),
-		slog.String("request_id", middleware.GetReqID(r.Context())),
-	)
+	logger := slogx.FromContext(r.Context(), h.logger).With(slog.String("op", op))
 
 	userID := myMw.GetUserID(r.Context())
 	if userID == "" {
 		logger.Error("failed to extract owner id")
-		handlers.WriteError(w, http.StatusBadRequest, errors.New("bad request"))
+		handlers.WriteError(w, r, handlers.ErrBadRequest)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
-	defer cancel()
+	query := services.FindByOwnerQuery{
+		Sort: services.TaskSort(r.URL.Query().Get("sort")),
+	}
 
-	tasks, err := h.finder.FindByOwner(ctx, userID)
-	if err != nil {
-		logger.Error("failed to find tasks by owner", slog.String("err", err.Error()))
+	if raw := r.URL.Query().Get("updated_since"); raw != "" {
+		updatedSince, err := time.Parse(time.RFC3339Nano, raw)
+		if err != nil {
+			logger.Info("invalid updated_since parameter", slog.String("err", err.Error()))
+			handlers.WriteError(w, r, handlers.InvalidParameter("updated_since"))
+			return
+		}
 
-		if errors.Is(err, services.ErrTaskFindByOwnerFailed) {
-			handlers.WriteError(w, http.StatusInternalServerError, errors.New("internal server error"))
+		query.UpdatedSince = &updatedSince
+	}
+
+	if raw := r.URL.Query().Get("include_archived"); raw != "" {
+		includeArchived, err := strconv.ParseBool(raw)
+		if err != nil {
+			logger.Info("invalid include_archived parameter", slog.String("err", err.Error()))
+			handlers.WriteError(w, r, handlers.InvalidParameter("include_archived"))
 			return
 		}
 
-		handlers.WriteError(w, http.StatusInternalServerError, errors.New("internal server error"))
+		query.IncludeArchived = includeArchived
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	tasks, err := h.finder.FindByOwner(ctx, userID, query)
+	if err != nil {
+		logger.Error("failed to find tasks by owner", slog.String("err", err.Error()))
+		handlers.WriteError(w, r, err)
 		return
 	}
 
 	taskDTOs := make([]TaskDTO, len(tasks))
 	for i, task := range tasks {
-		taskDTOs[i] = TaskDTO{
-			ID:          task.ID().String(),
-			Title:       task.Title().String(),
-			Description: task.Description().String(),
-			Deadline:    convertDeadline(task.Deadline()),
-			IsCompleted: task.IsCompleted(),
-			CompletedAt: task.CompletedAt(),
-		}
+		taskDTOs[i] = newTaskDTO(task)
 	}
 
 	handlers.WriteJSON(w, http.StatusOK, FindByOwnerResponse{
@@ -99,11 +111,3 @@ func (h *FindByOwnerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		Tasks:   taskDTOs,
 	})
 }
-
-func convertDeadline(deadline *vo.Deadline) *time.Time {
-	if deadline == nil {
-		return nil
-	}
-	t := deadline.Time()
-	return &t
-}
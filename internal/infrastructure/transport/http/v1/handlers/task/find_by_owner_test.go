@@ -13,11 +13,11 @@ import (
 
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task"
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task/mocks"
 	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
 	"github.com/cyberbrain-dev/taskery-api/internal/services"
-	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -25,9 +25,12 @@ import (
 func TestFindByOwnerHandler(t *testing.T) {
 	validUserID := gofakeit.UUID()
 	validTaskID := gofakeit.UUID()
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	updatedAt := createdAt.Add(time.Hour)
 
 	tests := []struct {
 		name         string
+		query        string
 		expectedCode int
 		expectedBody string
 		userID       string
@@ -36,20 +39,73 @@ func TestFindByOwnerHandler(t *testing.T) {
 		{
 			name:         "empty user id",
 			expectedCode: http.StatusBadRequest,
-			expectedBody: `{"error":"bad request"}`,
+			expectedBody: `{"type":"/problems/bad-request","title":"Bad Request","status":400,"detail":"bad request","code":"BAD_REQUEST"}`,
 			userID:       "",
 			mockSetup:    nil,
 		},
 		{
 			name:         "internal error",
 			expectedCode: http.StatusInternalServerError,
-			expectedBody: `{"error":"internal server error"}`,
+			expectedBody: `{"type":"/problems/internal-error","title":"Internal Server Error","status":500,"detail":"internal server error","code":"INTERNAL_ERROR"}`,
 			userID:       validUserID,
 			mockSetup: func(finder *mocks.Finder) {
-				finder.On("FindByOwner", mock.Anything, validUserID).
+				finder.On("FindByOwner", mock.Anything, validUserID, services.FindByOwnerQuery{}).
 					Return(nil, services.ErrTaskFindByOwnerFailed)
 			},
 		},
+		{
+			name:         "invalid sort",
+			query:        "?sort=title",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/invalid-parameter","title":"Bad Request","status":400,"detail":"invalid sort parameter","code":"INVALID_PARAMETER"}`,
+			userID:       validUserID,
+			mockSetup: func(finder *mocks.Finder) {
+				finder.On("FindByOwner", mock.Anything, validUserID, services.FindByOwnerQuery{Sort: "title"}).
+					Return(nil, services.ErrTaskSortInvalid)
+			},
+		},
+		{
+			name:         "invalid updated_since",
+			query:        "?updated_since=yesterday",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/invalid-parameter","title":"Bad Request","status":400,"detail":"invalid updated_since parameter","code":"INVALID_PARAMETER"}`,
+			userID:       validUserID,
+			mockSetup:    nil,
+		},
+		{
+			name:         "success with sort and updated_since",
+			query:        "?sort=-created_at&updated_since=2026-01-02T03:04:05Z",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"owner_id":"` + validUserID + `","tasks":[]}`,
+			userID:       validUserID,
+			mockSetup: func(finder *mocks.Finder) {
+				query := services.FindByOwnerQuery{
+					Sort:         services.TaskSortCreatedAtDesc,
+					UpdatedSince: &createdAt,
+				}
+				finder.On("FindByOwner", mock.Anything, validUserID, query).
+					Return([]*models.Task{}, nil)
+			},
+		},
+		{
+			name:         "success with archived tasks",
+			query:        "?include_archived=true",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"owner_id":"` + validUserID + `","tasks":[]}`,
+			userID:       validUserID,
+			mockSetup: func(finder *mocks.Finder) {
+				finder.On("FindByOwner", mock.Anything, validUserID, services.FindByOwnerQuery{IncludeArchived: true}).
+					Return([]*models.Task{}, nil)
+			},
+		},
+		{
+			name:         "invalid include_archived",
+			query:        "?include_archived=sometimes",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/invalid-parameter","title":"Bad Request","status":400,"detail":"invalid include_archived parameter","code":"INVALID_PARAMETER"}`,
+			userID:       validUserID,
+			mockSetup:    nil,
+		},
 		{
 			name:         "success with tasks",
 			expectedCode: http.StatusOK,
@@ -62,6 +118,8 @@ func TestFindByOwnerHandler(t *testing.T) {
 						Deadline:    nil,
 						IsCompleted: false,
 						CompletedAt: nil,
+						CreatedAt:   createdAt,
+						UpdatedAt:   updatedAt,
 					},
 				})
 				return `{"owner_id":"` + validUserID + `","tasks":` + string(taskDTOs) + `}`
@@ -76,10 +134,12 @@ func TestFindByOwnerHandler(t *testing.T) {
 					Deadline:    nil,
 					IsCompleted: false,
 					CompletedAt: nil,
+					CreatedAt:   createdAt,
+					UpdatedAt:   updatedAt,
 				}
 				task, err := models.NewTaskFromDB(params)
 				require.NoError(t, err)
-				finder.On("FindByOwner", mock.Anything, validUserID).
+				finder.On("FindByOwner", mock.Anything, validUserID, services.FindByOwnerQuery{}).
 					Return([]*models.Task{task}, nil)
 			},
 		},
@@ -90,7 +150,7 @@ func TestFindByOwnerHandler(t *testing.T) {
 			req := httptest.NewRequestWithContext(
 				context.WithValue(context.Background(), myMw.UserIDKey, tt.userID),
 				http.MethodGet,
-				"/task/owner",
+				"/tasks"+tt.query,
 				bytes.NewBufferString(""),
 			)
 			req.Header.Set("Content-Type", "application/json")
@@ -104,7 +164,7 @@ func TestFindByOwnerHandler(t *testing.T) {
 
 			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
 
-			h := task.NewFindByOwnerHandler(finder, 4*time.Second, logger, validator.New())
+			h := task.NewFindByOwnerHandler(finder, 4*time.Second, logger, handlers.NewValidator())
 			h.ServeHTTP(rr, req)
 
 			require.Equal(t, tt.expectedCode, rr.Code)
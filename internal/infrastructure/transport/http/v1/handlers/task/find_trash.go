@@ -0,0 +1,82 @@
+package task
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
+	"github.com/go-playground/validator/v10"
+)
+
+type TrashFinder interface {
+	FindTrash(ctx context.Context, ownerID string) ([]*models.Task, error)
+}
+
+type FindTrashHandler struct {
+	finder   TrashFinder
+	timeout  time.Duration
+	logger   *slog.Logger
+	validate *validator.Validate
+}
+
+func NewFindTrashHandler(
+	finder TrashFinder,
+	timeout time.Duration,
+	logger *slog.Logger,
+	validate *validator.Validate,
+) *FindTrashHandler {
+	return &FindTrashHandler{
+		finder:   finder,
+		timeout:  timeout,
+		logger:   logger,
+		validate: validate,
+	}
+}
+
+// @Summary List deleted tasks
+// @Description Retrieves the tasks of the authenticated user that are in the trash, the most recently deleted first
+// @Tags tasks
+// @Produce json
+// @Security     BearerAuth
+// @Success 200 {object} FindTrashResponse
+// @Failure 400 {object} handlers.Problem
+// @Failure 401 {object} handlers.Problem
+// @Failure 500 {object} handlers.Problem
+// @Router /tasks/trash [get]
+func (h *FindTrashHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.Task.FindTrash"
+
+	logger := slogx.FromContext(r.Context(), h.logger).With(slog.String("op", op))
+
+	userID := myMw.GetUserID(r.Context())
+	if userID == "" {
+		logger.Error("failed to extract owner id")
+		handlers.WriteError(w, r, handlers.ErrBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	tasks, err := h.finder.FindTrash(ctx, userID)
+	if err != nil {
+		logger.Error("failed to find tasks in trash", slog.String("err", err.Error()))
+		handlers.WriteError(w, r, handlers.ErrInternal)
+		return
+	}
+
+	taskDTOs := make([]TaskDTO, len(tasks))
+	for i, task := range tasks {
+		taskDTOs[i] = newTaskDTO(task)
+	}
+
+	handlers.WriteJSON(w, http.StatusOK, FindTrashResponse{
+		OwnerID: userID,
+		Tasks:   taskDTOs,
+	})
+}
@@ -0,0 +1,126 @@
+package task_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task/mocks"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindTrashHandler(t *testing.T) {
+	validUserID := gofakeit.UUID()
+	validTaskID := gofakeit.UUID()
+	deletedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		expectedCode int
+		expectedBody string
+		userID       string
+		mockSetup    func(finder *mocks.TrashFinder)
+	}{
+		{
+			name:         "success",
+			expectedCode: http.StatusOK,
+			expectedBody: func() string {
+				body, _ := json.Marshal(task.FindTrashResponse{
+					OwnerID: validUserID,
+					Tasks: []task.TaskDTO{
+						{
+							ID:          validTaskID,
+							Title:       "Deleted task",
+							Description: "Deleted description",
+							DeletedAt:   &deletedAt,
+							Version:     3,
+						},
+					},
+				})
+				return string(body)
+			}(),
+			userID: validUserID,
+			mockSetup: func(finder *mocks.TrashFinder) {
+				task, err := models.NewTaskFromDB(models.TaskFromDBParams{
+					ID:          validTaskID,
+					OwnerID:     validUserID,
+					Title:       "Deleted task",
+					Description: "Deleted description",
+					DeletedAt:   &deletedAt,
+					Version:     3,
+				})
+				require.NoError(t, err)
+
+				finder.On("FindTrash", mock.Anything, validUserID).
+					Return([]*models.Task{task}, nil)
+			},
+		},
+		{
+			name:         "empty trash",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"owner_id":"` + validUserID + `","tasks":[]}`,
+			userID:       validUserID,
+			mockSetup: func(finder *mocks.TrashFinder) {
+				finder.On("FindTrash", mock.Anything, validUserID).
+					Return([]*models.Task{}, nil)
+			},
+		},
+		{
+			name:         "internal error",
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"type":"/problems/internal-error","title":"Internal Server Error","status":500,"detail":"internal server error","code":"INTERNAL_ERROR"}`,
+			userID:       validUserID,
+			mockSetup: func(finder *mocks.TrashFinder) {
+				finder.On("FindTrash", mock.Anything, validUserID).
+					Return(nil, services.ErrTaskFindTrashFailed)
+			},
+		},
+		{
+			name:         "empty user id",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/bad-request","title":"Bad Request","status":400,"detail":"bad request","code":"BAD_REQUEST"}`,
+			userID:       "",
+			mockSetup:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequestWithContext(
+				context.WithValue(context.Background(), myMw.UserIDKey, tt.userID),
+				http.MethodGet,
+				"/tasks/trash",
+				nil,
+			)
+
+			rr := httptest.NewRecorder()
+
+			finder := new(mocks.TrashFinder)
+			if tt.mockSetup != nil {
+				tt.mockSetup(finder)
+			}
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+
+			h := task.NewFindTrashHandler(finder, 4*time.Second, logger, handlers.NewValidator())
+			h.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedCode, rr.Code)
+			require.JSONEq(t, tt.expectedBody, rr.Body.String())
+
+			finder.AssertExpectations(t)
+		})
+	}
+}
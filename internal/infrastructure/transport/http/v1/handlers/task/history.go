@@ -0,0 +1,93 @@
+package task
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+type HistoryFinder interface {
+	History(ctx context.Context, id string, ownerID string) ([]*models.TaskEvent, error)
+}
+
+type HistoryHandler struct {
+	finder   HistoryFinder
+	timeout  time.Duration
+	logger   *slog.Logger
+	validate *validator.Validate
+}
+
+func NewHistoryHandler(
+	finder HistoryFinder,
+	timeout time.Duration,
+	logger *slog.Logger,
+	validate *validator.Validate,
+) *HistoryHandler {
+	return &HistoryHandler{
+		finder:   finder,
+		timeout:  timeout,
+		logger:   logger,
+		validate: validate,
+	}
+}
+
+// @Summary Get task history
+// @Description Retrieves the changes of a task of the authenticated user from the oldest to the newest
+// @Tags tasks
+// @Produce json
+// @Param id path string true "Task ID"
+// @Security     BearerAuth
+// @Success 200 {object} HistoryResponse
+// @Failure 400 {object} handlers.Problem
+// @Failure 401 {object} handlers.Problem
+// @Failure 403 {object} handlers.Problem
+// @Failure 404 {object} handlers.Problem
+// @Failure 500 {object} handlers.Problem
+// @Router /tasks/{id}/history [get]
+func (h *HistoryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.Task.History"
+
+	logger := slogx.FromContext(r.Context(), h.logger).With(slog.String("op", op))
+
+	taskID := chi.URLParam(r, "id")
+	if taskID == "" {
+		logger.Error("task id is not provided")
+		handlers.WriteError(w, r, handlers.MissingParameter("task id"))
+		return
+	}
+
+	userID := myMw.GetUserID(r.Context())
+	if userID == "" {
+		logger.Error("failed to extract owner id")
+		handlers.WriteError(w, r, handlers.ErrBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	events, err := h.finder.History(ctx, taskID, userID)
+	if err != nil {
+		logger.Error("failed to load task history", slog.String("err", err.Error()))
+		handlers.WriteError(w, r, err)
+		return
+	}
+
+	eventDTOs := make([]TaskEventDTO, len(events))
+	for i, event := range events {
+		eventDTOs[i] = newTaskEventDTO(event)
+	}
+
+	handlers.WriteJSON(w, http.StatusOK, HistoryResponse{
+		TaskID: taskID,
+		Events: eventDTOs,
+	})
+}
@@ -0,0 +1,161 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task/mocks"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryHandler(t *testing.T) {
+	validUserID := uuid.New()
+	validTaskID := gofakeit.UUID()
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	clk := clock.NewFake(now)
+
+	taskModel, err := models.NewTask("Test task", "", validUserID, clk)
+	require.NoError(t, err)
+	created := models.NewTaskEvent(taskModel, models.TaskEventCreated, validUserID, nil, clk)
+
+	before := taskModel.State()
+	clk.Advance(time.Hour)
+	taskModel.Complete(clk)
+	completed := models.NewTaskEvent(taskModel, models.TaskEventCompleted, validUserID, &before, clk)
+
+	tests := []struct {
+		name         string
+		taskID       string
+		expectedCode int
+		expectedBody string
+		userID       string
+		mockSetup    func(finder *mocks.HistoryFinder)
+	}{
+		{
+			name:         "success",
+			taskID:       validTaskID,
+			expectedCode: http.StatusOK,
+			expectedBody: `{
+				"task_id": "` + validTaskID + `",
+				"events": [
+					{
+						"id": "` + created.ID().String() + `",
+						"type": "created",
+						"actor_id": "` + validUserID.String() + `",
+						"occurred_at": "2026-01-02T03:04:05Z",
+						"changes": [{"field": "title", "before": null, "after": "Test task"}]
+					},
+					{
+						"id": "` + completed.ID().String() + `",
+						"type": "completed",
+						"actor_id": "` + validUserID.String() + `",
+						"occurred_at": "2026-01-02T04:04:05Z",
+						"changes": [
+							{"field": "is_completed", "before": false, "after": true},
+							{"field": "completed_at", "before": null, "after": "2026-01-02T04:04:05Z"}
+						]
+					}
+				]
+			}`,
+			userID: validUserID.String(),
+			mockSetup: func(finder *mocks.HistoryFinder) {
+				finder.On("History", mock.Anything, validTaskID, validUserID.String()).
+					Return([]*models.TaskEvent{created, completed}, nil)
+			},
+		},
+		{
+			name:         "task not found",
+			taskID:       validTaskID,
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"type":"/problems/task-not-found","title":"Not Found","status":404,"detail":"task not found","code":"TASK_NOT_FOUND"}`,
+			userID:       validUserID.String(),
+			mockSetup: func(finder *mocks.HistoryFinder) {
+				finder.On("History", mock.Anything, validTaskID, validUserID.String()).
+					Return(nil, services.ErrTaskNotFound)
+			},
+		},
+		{
+			name:         "access denied",
+			taskID:       validTaskID,
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"type":"/problems/task-access-denied","title":"Forbidden","status":403,"detail":"access denied","code":"TASK_ACCESS_DENIED"}`,
+			userID:       validUserID.String(),
+			mockSetup: func(finder *mocks.HistoryFinder) {
+				finder.On("History", mock.Anything, validTaskID, validUserID.String()).
+					Return(nil, services.ErrTaskAccessDenied)
+			},
+		},
+		{
+			name:         "internal error",
+			taskID:       validTaskID,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"type":"/problems/internal-error","title":"Internal Server Error","status":500,"detail":"internal server error","code":"INTERNAL_ERROR"}`,
+			userID:       validUserID.String(),
+			mockSetup: func(finder *mocks.HistoryFinder) {
+				finder.On("History", mock.Anything, validTaskID, validUserID.String()).
+					Return(nil, errors.New("unexpected error"))
+			},
+		},
+		{
+			name:         "empty user id",
+			taskID:       validTaskID,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/bad-request","title":"Bad Request","status":400,"detail":"bad request","code":"BAD_REQUEST"}`,
+			userID:       "",
+			mockSetup:    nil,
+		},
+		{
+			name:         "empty task id",
+			taskID:       "",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/missing-parameter","title":"Bad Request","status":400,"detail":"task id is required","code":"MISSING_PARAMETER"}`,
+			userID:       validUserID.String(),
+			mockSetup:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			routeCtx := chi.NewRouteContext()
+			routeCtx.URLParams.Add("id", tt.taskID)
+
+			ctx := context.WithValue(context.Background(), myMw.UserIDKey, tt.userID)
+			ctx = context.WithValue(ctx, chi.RouteCtxKey, routeCtx)
+
+			req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/tasks/"+tt.taskID+"/history", nil)
+
+			rr := httptest.NewRecorder()
+
+			finder := new(mocks.HistoryFinder)
+			if tt.mockSetup != nil {
+				tt.mockSetup(finder)
+			}
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+
+			h := task.NewHistoryHandler(finder, 4*time.Second, logger, handlers.NewValidator())
+			h.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedCode, rr.Code)
+			require.JSONEq(t, tt.expectedBody, rr.Body.String())
+
+			finder.AssertExpectations(t)
+		})
+	}
+}
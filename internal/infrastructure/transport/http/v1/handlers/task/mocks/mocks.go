@@ -6,12 +6,331 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/pubsub"
 	"github.com/cyberbrain-dev/taskery-api/internal/services"
 	mock "github.com/stretchr/testify/mock"
 )
 
+// NewArchiver creates a new instance of Archiver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewArchiver(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Archiver {
+	mock := &Archiver{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// Archiver is an autogenerated mock type for the Archiver type
+type Archiver struct {
+	mock.Mock
+}
+
+type Archiver_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Archiver) EXPECT() *Archiver_Expecter {
+	return &Archiver_Expecter{mock: &_m.Mock}
+}
+
+// Archive provides a mock function for the type Archiver
+func (_mock *Archiver) Archive(ctx context.Context, id string, ownerID string, force bool, expectedVersion *int64) (int64, error) {
+	ret := _mock.Called(ctx, id, ownerID, force, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Archive")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, bool, *int64) (int64, error)); ok {
+		return returnFunc(ctx, id, ownerID, force, expectedVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, bool, *int64) int64); ok {
+		r0 = returnFunc(ctx, id, ownerID, force, expectedVersion)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, bool, *int64) error); ok {
+		r1 = returnFunc(ctx, id, ownerID, force, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Archiver_Archive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Archive'
+type Archiver_Archive_Call struct {
+	*mock.Call
+}
+
+// Archive is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - ownerID string
+//   - force bool
+//   - expectedVersion *int64
+func (_e *Archiver_Expecter) Archive(ctx interface{}, id interface{}, ownerID interface{}, force interface{}, expectedVersion interface{}) *Archiver_Archive_Call {
+	return &Archiver_Archive_Call{Call: _e.mock.On("Archive", ctx, id, ownerID, force, expectedVersion)}
+}
+
+func (_c *Archiver_Archive_Call) Run(run func(ctx context.Context, id string, ownerID string, force bool, expectedVersion *int64)) *Archiver_Archive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
+		}
+		var arg4 *int64
+		if args[4] != nil {
+			arg4 = args[4].(*int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *Archiver_Archive_Call) Return(n int64, err error) *Archiver_Archive_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *Archiver_Archive_Call) RunAndReturn(run func(ctx context.Context, id string, ownerID string, force bool, expectedVersion *int64) (int64, error)) *Archiver_Archive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewCompletedArchiver creates a new instance of CompletedArchiver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCompletedArchiver(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CompletedArchiver {
+	mock := &CompletedArchiver{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// CompletedArchiver is an autogenerated mock type for the CompletedArchiver type
+type CompletedArchiver struct {
+	mock.Mock
+}
+
+type CompletedArchiver_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *CompletedArchiver) EXPECT() *CompletedArchiver_Expecter {
+	return &CompletedArchiver_Expecter{mock: &_m.Mock}
+}
+
+// ArchiveCompleted provides a mock function for the type CompletedArchiver
+func (_mock *CompletedArchiver) ArchiveCompleted(ctx context.Context, ownerID string, olderThan time.Duration) (int64, error) {
+	ret := _mock.Called(ctx, ownerID, olderThan)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArchiveCompleted")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Duration) (int64, error)); ok {
+		return returnFunc(ctx, ownerID, olderThan)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Duration) int64); ok {
+		r0 = returnFunc(ctx, ownerID, olderThan)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = returnFunc(ctx, ownerID, olderThan)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// CompletedArchiver_ArchiveCompleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchiveCompleted'
+type CompletedArchiver_ArchiveCompleted_Call struct {
+	*mock.Call
+}
+
+// ArchiveCompleted is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ownerID string
+//   - olderThan time.Duration
+func (_e *CompletedArchiver_Expecter) ArchiveCompleted(ctx interface{}, ownerID interface{}, olderThan interface{}) *CompletedArchiver_ArchiveCompleted_Call {
+	return &CompletedArchiver_ArchiveCompleted_Call{Call: _e.mock.On("ArchiveCompleted", ctx, ownerID, olderThan)}
+}
+
+func (_c *CompletedArchiver_ArchiveCompleted_Call) Run(run func(ctx context.Context, ownerID string, olderThan time.Duration)) *CompletedArchiver_ArchiveCompleted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Duration
+		if args[2] != nil {
+			arg2 = args[2].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *CompletedArchiver_ArchiveCompleted_Call) Return(n int64, err error) *CompletedArchiver_ArchiveCompleted_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *CompletedArchiver_ArchiveCompleted_Call) RunAndReturn(run func(ctx context.Context, ownerID string, olderThan time.Duration) (int64, error)) *CompletedArchiver_ArchiveCompleted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBatcher creates a new instance of Batcher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBatcher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Batcher {
+	mock := &Batcher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// Batcher is an autogenerated mock type for the Batcher type
+type Batcher struct {
+	mock.Mock
+}
+
+type Batcher_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Batcher) EXPECT() *Batcher_Expecter {
+	return &Batcher_Expecter{mock: &_m.Mock}
+}
+
+// Batch provides a mock function for the type Batcher
+func (_mock *Batcher) Batch(ctx context.Context, ownerID string, ops []services.BatchOperation, atomic bool) ([]error, error) {
+	ret := _mock.Called(ctx, ownerID, ops, atomic)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Batch")
+	}
+
+	var r0 []error
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []services.BatchOperation, bool) ([]error, error)); ok {
+		return returnFunc(ctx, ownerID, ops, atomic)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []services.BatchOperation, bool) []error); ok {
+		r0 = returnFunc(ctx, ownerID, ops, atomic)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]error)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []services.BatchOperation, bool) error); ok {
+		r1 = returnFunc(ctx, ownerID, ops, atomic)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Batcher_Batch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Batch'
+type Batcher_Batch_Call struct {
+	*mock.Call
+}
+
+// Batch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ownerID string
+//   - ops []services.BatchOperation
+//   - atomic bool
+func (_e *Batcher_Expecter) Batch(ctx interface{}, ownerID interface{}, ops interface{}, atomic interface{}) *Batcher_Batch_Call {
+	return &Batcher_Batch_Call{Call: _e.mock.On("Batch", ctx, ownerID, ops, atomic)}
+}
+
+func (_c *Batcher_Batch_Call) Run(run func(ctx context.Context, ownerID string, ops []services.BatchOperation, atomic bool)) *Batcher_Batch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []services.BatchOperation
+		if args[2] != nil {
+			arg2 = args[2].([]services.BatchOperation)
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Batcher_Batch_Call) Return(errs []error, err error) *Batcher_Batch_Call {
+	_c.Call.Return(errs, err)
+	return _c
+}
+
+func (_c *Batcher_Batch_Call) RunAndReturn(run func(ctx context.Context, ownerID string, ops []services.BatchOperation, atomic bool) ([]error, error)) *Batcher_Batch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewCompleter creates a new instance of Completer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewCompleter(t interface {
@@ -40,36 +359,1586 @@ func (_m *Completer) EXPECT() *Completer_Expecter {
 }
 
 // Complete provides a mock function for the type Completer
-func (_mock *Completer) Complete(ctx context.Context, id string, ownerID string) error {
-	ret := _mock.Called(ctx, id, ownerID)
+func (_mock *Completer) Complete(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error) {
+	ret := _mock.Called(ctx, id, ownerID, expectedVersion)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Complete")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *int64) (int64, error)); ok {
+		return returnFunc(ctx, id, ownerID, expectedVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *int64) int64); ok {
+		r0 = returnFunc(ctx, id, ownerID, expectedVersion)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *int64) error); ok {
+		r1 = returnFunc(ctx, id, ownerID, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Completer_Complete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Complete'
+type Completer_Complete_Call struct {
+	*mock.Call
+}
+
+// Complete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - ownerID string
+//   - expectedVersion *int64
+func (_e *Completer_Expecter) Complete(ctx interface{}, id interface{}, ownerID interface{}, expectedVersion interface{}) *Completer_Complete_Call {
+	return &Completer_Complete_Call{Call: _e.mock.On("Complete", ctx, id, ownerID, expectedVersion)}
+}
+
+func (_c *Completer_Complete_Call) Run(run func(ctx context.Context, id string, ownerID string, expectedVersion *int64)) *Completer_Complete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *int64
+		if args[3] != nil {
+			arg3 = args[3].(*int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Completer_Complete_Call) Return(n int64, err error) *Completer_Complete_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *Completer_Complete_Call) RunAndReturn(run func(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)) *Completer_Complete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewCreator creates a new instance of Creator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCreator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Creator {
+	mock := &Creator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// Creator is an autogenerated mock type for the Creator type
+type Creator struct {
+	mock.Mock
+}
+
+type Creator_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Creator) EXPECT() *Creator_Expecter {
+	return &Creator_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type Creator
+func (_mock *Creator) Create(ctx context.Context, cmd services.CreateTaskCommand) (string, error) {
+	ret := _mock.Called(ctx, cmd)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, services.CreateTaskCommand) (string, error)); ok {
+		return returnFunc(ctx, cmd)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, services.CreateTaskCommand) string); ok {
+		r0 = returnFunc(ctx, cmd)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, services.CreateTaskCommand) error); ok {
+		r1 = returnFunc(ctx, cmd)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Creator_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type Creator_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cmd services.CreateTaskCommand
+func (_e *Creator_Expecter) Create(ctx interface{}, cmd interface{}) *Creator_Create_Call {
+	return &Creator_Create_Call{Call: _e.mock.On("Create", ctx, cmd)}
+}
+
+func (_c *Creator_Create_Call) Run(run func(ctx context.Context, cmd services.CreateTaskCommand)) *Creator_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 services.CreateTaskCommand
+		if args[1] != nil {
+			arg1 = args[1].(services.CreateTaskCommand)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Creator_Create_Call) Return(s string, err error) *Creator_Create_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *Creator_Create_Call) RunAndReturn(run func(ctx context.Context, cmd services.CreateTaskCommand) (string, error)) *Creator_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDeleter creates a new instance of Deleter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDeleter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Deleter {
+	mock := &Deleter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// Deleter is an autogenerated mock type for the Deleter type
+type Deleter struct {
+	mock.Mock
+}
+
+type Deleter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Deleter) EXPECT() *Deleter_Expecter {
+	return &Deleter_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function for the type Deleter
+func (_mock *Deleter) Delete(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error) {
+	ret := _mock.Called(ctx, id, ownerID, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *int64) (int64, error)); ok {
+		return returnFunc(ctx, id, ownerID, expectedVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *int64) int64); ok {
+		r0 = returnFunc(ctx, id, ownerID, expectedVersion)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *int64) error); ok {
+		r1 = returnFunc(ctx, id, ownerID, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Deleter_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type Deleter_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - ownerID string
+//   - expectedVersion *int64
+func (_e *Deleter_Expecter) Delete(ctx interface{}, id interface{}, ownerID interface{}, expectedVersion interface{}) *Deleter_Delete_Call {
+	return &Deleter_Delete_Call{Call: _e.mock.On("Delete", ctx, id, ownerID, expectedVersion)}
+}
+
+func (_c *Deleter_Delete_Call) Run(run func(ctx context.Context, id string, ownerID string, expectedVersion *int64)) *Deleter_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *int64
+		if args[3] != nil {
+			arg3 = args[3].(*int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Deleter_Delete_Call) Return(n int64, err error) *Deleter_Delete_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *Deleter_Delete_Call) RunAndReturn(run func(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)) *Deleter_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeletePermanently provides a mock function for the type Deleter
+func (_mock *Deleter) DeletePermanently(ctx context.Context, id string, ownerID string, expectedVersion *int64) error {
+	ret := _mock.Called(ctx, id, ownerID, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePermanently")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *int64) error); ok {
+		r0 = returnFunc(ctx, id, ownerID, expectedVersion)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Deleter_DeletePermanently_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePermanently'
+type Deleter_DeletePermanently_Call struct {
+	*mock.Call
+}
+
+// DeletePermanently is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - ownerID string
+//   - expectedVersion *int64
+func (_e *Deleter_Expecter) DeletePermanently(ctx interface{}, id interface{}, ownerID interface{}, expectedVersion interface{}) *Deleter_DeletePermanently_Call {
+	return &Deleter_DeletePermanently_Call{Call: _e.mock.On("DeletePermanently", ctx, id, ownerID, expectedVersion)}
+}
+
+func (_c *Deleter_DeletePermanently_Call) Run(run func(ctx context.Context, id string, ownerID string, expectedVersion *int64)) *Deleter_DeletePermanently_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *int64
+		if args[3] != nil {
+			arg3 = args[3].(*int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Deleter_DeletePermanently_Call) Return(err error) *Deleter_DeletePermanently_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Deleter_DeletePermanently_Call) RunAndReturn(run func(ctx context.Context, id string, ownerID string, expectedVersion *int64) error) *Deleter_DeletePermanently_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewChangeSubscriber creates a new instance of ChangeSubscriber. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewChangeSubscriber(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ChangeSubscriber {
+	mock := &ChangeSubscriber{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// ChangeSubscriber is an autogenerated mock type for the ChangeSubscriber type
+type ChangeSubscriber struct {
+	mock.Mock
+}
+
+type ChangeSubscriber_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ChangeSubscriber) EXPECT() *ChangeSubscriber_Expecter {
+	return &ChangeSubscriber_Expecter{mock: &_m.Mock}
+}
+
+// Subscribe provides a mock function for the type ChangeSubscriber
+func (_mock *ChangeSubscriber) Subscribe(ownerID string, lastEventID string) *pubsub.Subscription {
+	ret := _mock.Called(ownerID, lastEventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 *pubsub.Subscription
+	if returnFunc, ok := ret.Get(0).(func(string, string) *pubsub.Subscription); ok {
+		r0 = returnFunc(ownerID, lastEventID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pubsub.Subscription)
+		}
+	}
+	return r0
+}
+
+// ChangeSubscriber_Subscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subscribe'
+type ChangeSubscriber_Subscribe_Call struct {
+	*mock.Call
+}
+
+// Subscribe is a helper method to define mock.On call
+//   - ownerID string
+//   - lastEventID string
+func (_e *ChangeSubscriber_Expecter) Subscribe(ownerID interface{}, lastEventID interface{}) *ChangeSubscriber_Subscribe_Call {
+	return &ChangeSubscriber_Subscribe_Call{Call: _e.mock.On("Subscribe", ownerID, lastEventID)}
+}
+
+func (_c *ChangeSubscriber_Subscribe_Call) Run(run func(ownerID string, lastEventID string)) *ChangeSubscriber_Subscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ChangeSubscriber_Subscribe_Call) Return(subscription *pubsub.Subscription) *ChangeSubscriber_Subscribe_Call {
+	_c.Call.Return(subscription)
+	return _c
+}
+
+func (_c *ChangeSubscriber_Subscribe_Call) RunAndReturn(run func(ownerID string, lastEventID string) *pubsub.Subscription) *ChangeSubscriber_Subscribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIDFinder creates a new instance of IDFinder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIDFinder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IDFinder {
+	mock := &IDFinder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// IDFinder is an autogenerated mock type for the IDFinder type
+type IDFinder struct {
+	mock.Mock
+}
+
+type IDFinder_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IDFinder) EXPECT() *IDFinder_Expecter {
+	return &IDFinder_Expecter{mock: &_m.Mock}
+}
+
+// FindByID provides a mock function for the type IDFinder
+func (_mock *IDFinder) FindByID(ctx context.Context, id string, ownerID string) (*models.Task, error) {
+	ret := _mock.Called(ctx, id, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *models.Task
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*models.Task, error)); ok {
+		return returnFunc(ctx, id, ownerID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *models.Task); ok {
+		r0 = returnFunc(ctx, id, ownerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Task)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, id, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// IDFinder_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type IDFinder_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - ownerID string
+func (_e *IDFinder_Expecter) FindByID(ctx interface{}, id interface{}, ownerID interface{}) *IDFinder_FindByID_Call {
+	return &IDFinder_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id, ownerID)}
+}
+
+func (_c *IDFinder_FindByID_Call) Run(run func(ctx context.Context, id string, ownerID string)) *IDFinder_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *IDFinder_FindByID_Call) Return(task *models.Task, err error) *IDFinder_FindByID_Call {
+	_c.Call.Return(task, err)
+	return _c
+}
+
+func (_c *IDFinder_FindByID_Call) RunAndReturn(run func(ctx context.Context, id string, ownerID string) (*models.Task, error)) *IDFinder_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewFinder creates a new instance of Finder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFinder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Finder {
+	mock := &Finder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// Finder is an autogenerated mock type for the Finder type
+type Finder struct {
+	mock.Mock
+}
+
+type Finder_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Finder) EXPECT() *Finder_Expecter {
+	return &Finder_Expecter{mock: &_m.Mock}
+}
+
+// FindByOwner provides a mock function for the type Finder
+func (_mock *Finder) FindByOwner(ctx context.Context, ownerID string, query services.FindByOwnerQuery) ([]*models.Task, error) {
+	ret := _mock.Called(ctx, ownerID, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByOwner")
+	}
+
+	var r0 []*models.Task
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, services.FindByOwnerQuery) ([]*models.Task, error)); ok {
+		return returnFunc(ctx, ownerID, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, services.FindByOwnerQuery) []*models.Task); ok {
+		r0 = returnFunc(ctx, ownerID, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Task)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, services.FindByOwnerQuery) error); ok {
+		r1 = returnFunc(ctx, ownerID, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Finder_FindByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByOwner'
+type Finder_FindByOwner_Call struct {
+	*mock.Call
+}
+
+// FindByOwner is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ownerID string
+//   - query services.FindByOwnerQuery
+func (_e *Finder_Expecter) FindByOwner(ctx interface{}, ownerID interface{}, query interface{}) *Finder_FindByOwner_Call {
+	return &Finder_FindByOwner_Call{Call: _e.mock.On("FindByOwner", ctx, ownerID, query)}
+}
+
+func (_c *Finder_FindByOwner_Call) Run(run func(ctx context.Context, ownerID string, query services.FindByOwnerQuery)) *Finder_FindByOwner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 services.FindByOwnerQuery
+		if args[2] != nil {
+			arg2 = args[2].(services.FindByOwnerQuery)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Finder_FindByOwner_Call) Return(tasks []*models.Task, err error) *Finder_FindByOwner_Call {
+	_c.Call.Return(tasks, err)
+	return _c
+}
+
+func (_c *Finder_FindByOwner_Call) RunAndReturn(run func(ctx context.Context, ownerID string, query services.FindByOwnerQuery) ([]*models.Task, error)) *Finder_FindByOwner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTrashFinder creates a new instance of TrashFinder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTrashFinder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TrashFinder {
+	mock := &TrashFinder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// TrashFinder is an autogenerated mock type for the TrashFinder type
+type TrashFinder struct {
+	mock.Mock
+}
+
+type TrashFinder_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TrashFinder) EXPECT() *TrashFinder_Expecter {
+	return &TrashFinder_Expecter{mock: &_m.Mock}
+}
+
+// FindTrash provides a mock function for the type TrashFinder
+func (_mock *TrashFinder) FindTrash(ctx context.Context, ownerID string) ([]*models.Task, error) {
+	ret := _mock.Called(ctx, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTrash")
+	}
+
+	var r0 []*models.Task
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*models.Task, error)); ok {
+		return returnFunc(ctx, ownerID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*models.Task); ok {
+		r0 = returnFunc(ctx, ownerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Task)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TrashFinder_FindTrash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindTrash'
+type TrashFinder_FindTrash_Call struct {
+	*mock.Call
+}
+
+// FindTrash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ownerID string
+func (_e *TrashFinder_Expecter) FindTrash(ctx interface{}, ownerID interface{}) *TrashFinder_FindTrash_Call {
+	return &TrashFinder_FindTrash_Call{Call: _e.mock.On("FindTrash", ctx, ownerID)}
+}
+
+func (_c *TrashFinder_FindTrash_Call) Run(run func(ctx context.Context, ownerID string)) *TrashFinder_FindTrash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *TrashFinder_FindTrash_Call) Return(tasks []*models.Task, err error) *TrashFinder_FindTrash_Call {
+	_c.Call.Return(tasks, err)
+	return _c
+}
+
+func (_c *TrashFinder_FindTrash_Call) RunAndReturn(run func(ctx context.Context, ownerID string) ([]*models.Task, error)) *TrashFinder_FindTrash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewHistoryFinder creates a new instance of HistoryFinder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHistoryFinder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HistoryFinder {
+	mock := &HistoryFinder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// HistoryFinder is an autogenerated mock type for the HistoryFinder type
+type HistoryFinder struct {
+	mock.Mock
+}
+
+type HistoryFinder_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *HistoryFinder) EXPECT() *HistoryFinder_Expecter {
+	return &HistoryFinder_Expecter{mock: &_m.Mock}
+}
+
+// History provides a mock function for the type HistoryFinder
+func (_mock *HistoryFinder) History(ctx context.Context, id string, ownerID string) ([]*models.TaskEvent, error) {
+	ret := _mock.Called(ctx, id, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for History")
+	}
+
+	var r0 []*models.TaskEvent
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]*models.TaskEvent, error)); ok {
+		return returnFunc(ctx, id, ownerID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []*models.TaskEvent); ok {
+		r0 = returnFunc(ctx, id, ownerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.TaskEvent)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, id, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// HistoryFinder_History_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'History'
+type HistoryFinder_History_Call struct {
+	*mock.Call
+}
+
+// History is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - ownerID string
+func (_e *HistoryFinder_Expecter) History(ctx interface{}, id interface{}, ownerID interface{}) *HistoryFinder_History_Call {
+	return &HistoryFinder_History_Call{Call: _e.mock.On("History", ctx, id, ownerID)}
+}
+
+func (_c *HistoryFinder_History_Call) Run(run func(ctx context.Context, id string, ownerID string)) *HistoryFinder_History_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *HistoryFinder_History_Call) Return(taskEvents []*models.TaskEvent, err error) *HistoryFinder_History_Call {
+	_c.Call.Return(taskEvents, err)
+	return _c
+}
+
+func (_c *HistoryFinder_History_Call) RunAndReturn(run func(ctx context.Context, id string, ownerID string) ([]*models.TaskEvent, error)) *HistoryFinder_History_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDeadlineRemover creates a new instance of DeadlineRemover. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDeadlineRemover(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DeadlineRemover {
+	mock := &DeadlineRemover{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// DeadlineRemover is an autogenerated mock type for the DeadlineRemover type
+type DeadlineRemover struct {
+	mock.Mock
+}
+
+type DeadlineRemover_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DeadlineRemover) EXPECT() *DeadlineRemover_Expecter {
+	return &DeadlineRemover_Expecter{mock: &_m.Mock}
+}
+
+// RemoveDeadline provides a mock function for the type DeadlineRemover
+func (_mock *DeadlineRemover) RemoveDeadline(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error) {
+	ret := _mock.Called(ctx, id, ownerID, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveDeadline")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *int64) (int64, error)); ok {
+		return returnFunc(ctx, id, ownerID, expectedVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *int64) int64); ok {
+		r0 = returnFunc(ctx, id, ownerID, expectedVersion)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *int64) error); ok {
+		r1 = returnFunc(ctx, id, ownerID, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// DeadlineRemover_RemoveDeadline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveDeadline'
+type DeadlineRemover_RemoveDeadline_Call struct {
+	*mock.Call
+}
+
+// RemoveDeadline is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - ownerID string
+//   - expectedVersion *int64
+func (_e *DeadlineRemover_Expecter) RemoveDeadline(ctx interface{}, id interface{}, ownerID interface{}, expectedVersion interface{}) *DeadlineRemover_RemoveDeadline_Call {
+	return &DeadlineRemover_RemoveDeadline_Call{Call: _e.mock.On("RemoveDeadline", ctx, id, ownerID, expectedVersion)}
+}
+
+func (_c *DeadlineRemover_RemoveDeadline_Call) Run(run func(ctx context.Context, id string, ownerID string, expectedVersion *int64)) *DeadlineRemover_RemoveDeadline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *int64
+		if args[3] != nil {
+			arg3 = args[3].(*int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *DeadlineRemover_RemoveDeadline_Call) Return(n int64, err error) *DeadlineRemover_RemoveDeadline_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *DeadlineRemover_RemoveDeadline_Call) RunAndReturn(run func(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)) *DeadlineRemover_RemoveDeadline_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewReopener creates a new instance of Reopener. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReopener(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Reopener {
+	mock := &Reopener{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// Reopener is an autogenerated mock type for the Reopener type
+type Reopener struct {
+	mock.Mock
+}
+
+type Reopener_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Reopener) EXPECT() *Reopener_Expecter {
+	return &Reopener_Expecter{mock: &_m.Mock}
+}
+
+// Reopen provides a mock function for the type Reopener
+func (_mock *Reopener) Reopen(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error) {
+	ret := _mock.Called(ctx, id, ownerID, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reopen")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *int64) (int64, error)); ok {
+		return returnFunc(ctx, id, ownerID, expectedVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *int64) int64); ok {
+		r0 = returnFunc(ctx, id, ownerID, expectedVersion)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *int64) error); ok {
+		r1 = returnFunc(ctx, id, ownerID, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Reopener_Reopen_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reopen'
+type Reopener_Reopen_Call struct {
+	*mock.Call
+}
+
+// Reopen is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - ownerID string
+//   - expectedVersion *int64
+func (_e *Reopener_Expecter) Reopen(ctx interface{}, id interface{}, ownerID interface{}, expectedVersion interface{}) *Reopener_Reopen_Call {
+	return &Reopener_Reopen_Call{Call: _e.mock.On("Reopen", ctx, id, ownerID, expectedVersion)}
+}
+
+func (_c *Reopener_Reopen_Call) Run(run func(ctx context.Context, id string, ownerID string, expectedVersion *int64)) *Reopener_Reopen_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *int64
+		if args[3] != nil {
+			arg3 = args[3].(*int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Reopener_Reopen_Call) Return(n int64, err error) *Reopener_Reopen_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *Reopener_Reopen_Call) RunAndReturn(run func(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)) *Reopener_Reopen_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRestorer creates a new instance of Restorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRestorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Restorer {
+	mock := &Restorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// Restorer is an autogenerated mock type for the Restorer type
+type Restorer struct {
+	mock.Mock
+}
+
+type Restorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Restorer) EXPECT() *Restorer_Expecter {
+	return &Restorer_Expecter{mock: &_m.Mock}
+}
+
+// Restore provides a mock function for the type Restorer
+func (_mock *Restorer) Restore(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error) {
+	ret := _mock.Called(ctx, id, ownerID, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *int64) (int64, error)); ok {
+		return returnFunc(ctx, id, ownerID, expectedVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *int64) int64); ok {
+		r0 = returnFunc(ctx, id, ownerID, expectedVersion)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *int64) error); ok {
+		r1 = returnFunc(ctx, id, ownerID, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Restorer_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type Restorer_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - ownerID string
+//   - expectedVersion *int64
+func (_e *Restorer_Expecter) Restore(ctx interface{}, id interface{}, ownerID interface{}, expectedVersion interface{}) *Restorer_Restore_Call {
+	return &Restorer_Restore_Call{Call: _e.mock.On("Restore", ctx, id, ownerID, expectedVersion)}
+}
+
+func (_c *Restorer_Restore_Call) Run(run func(ctx context.Context, id string, ownerID string, expectedVersion *int64)) *Restorer_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *int64
+		if args[3] != nil {
+			arg3 = args[3].(*int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Restorer_Restore_Call) Return(n int64, err error) *Restorer_Restore_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *Restorer_Restore_Call) RunAndReturn(run func(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)) *Restorer_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewReverter creates a new instance of Reverter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReverter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Reverter {
+	mock := &Reverter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// Reverter is an autogenerated mock type for the Reverter type
+type Reverter struct {
+	mock.Mock
+}
+
+type Reverter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Reverter) EXPECT() *Reverter_Expecter {
+	return &Reverter_Expecter{mock: &_m.Mock}
+}
+
+// Revert provides a mock function for the type Reverter
+func (_mock *Reverter) Revert(ctx context.Context, id string, ownerID string, eventID string, expectedVersion *int64) (int64, error) {
+	ret := _mock.Called(ctx, id, ownerID, eventID, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revert")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, *int64) (int64, error)); ok {
+		return returnFunc(ctx, id, ownerID, eventID, expectedVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, *int64) int64); ok {
+		r0 = returnFunc(ctx, id, ownerID, eventID, expectedVersion)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, *int64) error); ok {
+		r1 = returnFunc(ctx, id, ownerID, eventID, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Reverter_Revert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revert'
+type Reverter_Revert_Call struct {
+	*mock.Call
+}
+
+// Revert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - ownerID string
+//   - eventID string
+//   - expectedVersion *int64
+func (_e *Reverter_Expecter) Revert(ctx interface{}, id interface{}, ownerID interface{}, eventID interface{}, expectedVersion interface{}) *Reverter_Revert_Call {
+	return &Reverter_Revert_Call{Call: _e.mock.On("Revert", ctx, id, ownerID, eventID, expectedVersion)}
+}
+
+func (_c *Reverter_Revert_Call) Run(run func(ctx context.Context, id string, ownerID string, eventID string, expectedVersion *int64)) *Reverter_Revert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 *int64
+		if args[4] != nil {
+			arg4 = args[4].(*int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *Reverter_Revert_Call) Return(n int64, err error) *Reverter_Revert_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *Reverter_Revert_Call) RunAndReturn(run func(ctx context.Context, id string, ownerID string, eventID string, expectedVersion *int64) (int64, error)) *Reverter_Revert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTaskSearcher creates a new instance of TaskSearcher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTaskSearcher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TaskSearcher {
+	mock := &TaskSearcher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// TaskSearcher is an autogenerated mock type for the TaskSearcher type
+type TaskSearcher struct {
+	mock.Mock
+}
+
+type TaskSearcher_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TaskSearcher) EXPECT() *TaskSearcher_Expecter {
+	return &TaskSearcher_Expecter{mock: &_m.Mock}
+}
+
+// Search provides a mock function for the type TaskSearcher
+func (_mock *TaskSearcher) Search(ctx context.Context, ownerID string, query services.SearchTasksQuery) ([]services.TaskSearchResult, error) {
+	ret := _mock.Called(ctx, ownerID, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []services.TaskSearchResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, services.SearchTasksQuery) ([]services.TaskSearchResult, error)); ok {
+		return returnFunc(ctx, ownerID, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, services.SearchTasksQuery) []services.TaskSearchResult); ok {
+		r0 = returnFunc(ctx, ownerID, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]services.TaskSearchResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, services.SearchTasksQuery) error); ok {
+		r1 = returnFunc(ctx, ownerID, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TaskSearcher_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type TaskSearcher_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ownerID string
+//   - query services.SearchTasksQuery
+func (_e *TaskSearcher_Expecter) Search(ctx interface{}, ownerID interface{}, query interface{}) *TaskSearcher_Search_Call {
+	return &TaskSearcher_Search_Call{Call: _e.mock.On("Search", ctx, ownerID, query)}
+}
+
+func (_c *TaskSearcher_Search_Call) Run(run func(ctx context.Context, ownerID string, query services.SearchTasksQuery)) *TaskSearcher_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 services.SearchTasksQuery
+		if args[2] != nil {
+			arg2 = args[2].(services.SearchTasksQuery)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskSearcher_Search_Call) Return(taskSearchResults []services.TaskSearchResult, err error) *TaskSearcher_Search_Call {
+	_c.Call.Return(taskSearchResults, err)
+	return _c
+}
+
+func (_c *TaskSearcher_Search_Call) RunAndReturn(run func(ctx context.Context, ownerID string, query services.SearchTasksQuery) ([]services.TaskSearchResult, error)) *TaskSearcher_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSocketTaskService creates a new instance of SocketTaskService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSocketTaskService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SocketTaskService {
+	mock := &SocketTaskService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// SocketTaskService is an autogenerated mock type for the SocketTaskService type
+type SocketTaskService struct {
+	mock.Mock
+}
+
+type SocketTaskService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SocketTaskService) EXPECT() *SocketTaskService_Expecter {
+	return &SocketTaskService_Expecter{mock: &_m.Mock}
+}
+
+// Complete provides a mock function for the type SocketTaskService
+func (_mock *SocketTaskService) Complete(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error) {
+	ret := _mock.Called(ctx, id, ownerID, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *int64) (int64, error)); ok {
+		return returnFunc(ctx, id, ownerID, expectedVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *int64) int64); ok {
+		r0 = returnFunc(ctx, id, ownerID, expectedVersion)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *int64) error); ok {
+		r1 = returnFunc(ctx, id, ownerID, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// SocketTaskService_Complete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Complete'
+type SocketTaskService_Complete_Call struct {
+	*mock.Call
+}
+
+// Complete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - ownerID string
+//   - expectedVersion *int64
+func (_e *SocketTaskService_Expecter) Complete(ctx interface{}, id interface{}, ownerID interface{}, expectedVersion interface{}) *SocketTaskService_Complete_Call {
+	return &SocketTaskService_Complete_Call{Call: _e.mock.On("Complete", ctx, id, ownerID, expectedVersion)}
+}
+
+func (_c *SocketTaskService_Complete_Call) Run(run func(ctx context.Context, id string, ownerID string, expectedVersion *int64)) *SocketTaskService_Complete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *int64
+		if args[3] != nil {
+			arg3 = args[3].(*int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *SocketTaskService_Complete_Call) Return(n int64, err error) *SocketTaskService_Complete_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *SocketTaskService_Complete_Call) RunAndReturn(run func(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)) *SocketTaskService_Complete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type SocketTaskService
+func (_mock *SocketTaskService) Delete(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error) {
+	ret := _mock.Called(ctx, id, ownerID, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *int64) (int64, error)); ok {
+		return returnFunc(ctx, id, ownerID, expectedVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *int64) int64); ok {
+		r0 = returnFunc(ctx, id, ownerID, expectedVersion)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *int64) error); ok {
+		r1 = returnFunc(ctx, id, ownerID, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// SocketTaskService_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type SocketTaskService_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - ownerID string
+//   - expectedVersion *int64
+func (_e *SocketTaskService_Expecter) Delete(ctx interface{}, id interface{}, ownerID interface{}, expectedVersion interface{}) *SocketTaskService_Delete_Call {
+	return &SocketTaskService_Delete_Call{Call: _e.mock.On("Delete", ctx, id, ownerID, expectedVersion)}
+}
+
+func (_c *SocketTaskService_Delete_Call) Run(run func(ctx context.Context, id string, ownerID string, expectedVersion *int64)) *SocketTaskService_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *int64
+		if args[3] != nil {
+			arg3 = args[3].(*int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *SocketTaskService_Delete_Call) Return(n int64, err error) *SocketTaskService_Delete_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *SocketTaskService_Delete_Call) RunAndReturn(run func(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)) *SocketTaskService_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function for the type SocketTaskService
+func (_mock *SocketTaskService) FindByID(ctx context.Context, id string, ownerID string) (*models.Task, error) {
+	ret := _mock.Called(ctx, id, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *models.Task
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*models.Task, error)); ok {
+		return returnFunc(ctx, id, ownerID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *models.Task); ok {
 		r0 = returnFunc(ctx, id, ownerID)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Task)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, id, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// Completer_Complete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Complete'
-type Completer_Complete_Call struct {
+// SocketTaskService_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type SocketTaskService_FindByID_Call struct {
 	*mock.Call
 }
 
-// Complete is a helper method to define mock.On call
+// FindByID is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id string
 //   - ownerID string
-func (_e *Completer_Expecter) Complete(ctx interface{}, id interface{}, ownerID interface{}) *Completer_Complete_Call {
-	return &Completer_Complete_Call{Call: _e.mock.On("Complete", ctx, id, ownerID)}
+func (_e *SocketTaskService_Expecter) FindByID(ctx interface{}, id interface{}, ownerID interface{}) *SocketTaskService_FindByID_Call {
+	return &SocketTaskService_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id, ownerID)}
 }
 
-func (_c *Completer_Complete_Call) Run(run func(ctx context.Context, id string, ownerID string)) *Completer_Complete_Call {
+func (_c *SocketTaskService_FindByID_Call) Run(run func(ctx context.Context, id string, ownerID string)) *SocketTaskService_FindByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -92,167 +1961,213 @@ func (_c *Completer_Complete_Call) Run(run func(ctx context.Context, id string,
 	return _c
 }
 
-func (_c *Completer_Complete_Call) Return(err error) *Completer_Complete_Call {
-	_c.Call.Return(err)
+func (_c *SocketTaskService_FindByID_Call) Return(task *models.Task, err error) *SocketTaskService_FindByID_Call {
+	_c.Call.Return(task, err)
 	return _c
 }
 
-func (_c *Completer_Complete_Call) RunAndReturn(run func(ctx context.Context, id string, ownerID string) error) *Completer_Complete_Call {
+func (_c *SocketTaskService_FindByID_Call) RunAndReturn(run func(ctx context.Context, id string, ownerID string) (*models.Task, error)) *SocketTaskService_FindByID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewCreator creates a new instance of Creator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewCreator(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *Creator {
-	mock := &Creator{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// Creator is an autogenerated mock type for the Creator type
-type Creator struct {
-	mock.Mock
-}
-
-type Creator_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *Creator) EXPECT() *Creator_Expecter {
-	return &Creator_Expecter{mock: &_m.Mock}
-}
-
-// Create provides a mock function for the type Creator
-func (_mock *Creator) Create(ctx context.Context, cmd services.CreateTaskCommand) (string, error) {
-	ret := _mock.Called(ctx, cmd)
+// RemoveDeadline provides a mock function for the type SocketTaskService
+func (_mock *SocketTaskService) RemoveDeadline(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error) {
+	ret := _mock.Called(ctx, id, ownerID, expectedVersion)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Create")
+		panic("no return value specified for RemoveDeadline")
 	}
 
-	var r0 string
+	var r0 int64
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, services.CreateTaskCommand) (string, error)); ok {
-		return returnFunc(ctx, cmd)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *int64) (int64, error)); ok {
+		return returnFunc(ctx, id, ownerID, expectedVersion)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, services.CreateTaskCommand) string); ok {
-		r0 = returnFunc(ctx, cmd)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *int64) int64); ok {
+		r0 = returnFunc(ctx, id, ownerID, expectedVersion)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(int64)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, services.CreateTaskCommand) error); ok {
-		r1 = returnFunc(ctx, cmd)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *int64) error); ok {
+		r1 = returnFunc(ctx, id, ownerID, expectedVersion)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// Creator_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
-type Creator_Create_Call struct {
+// SocketTaskService_RemoveDeadline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveDeadline'
+type SocketTaskService_RemoveDeadline_Call struct {
 	*mock.Call
 }
 
-// Create is a helper method to define mock.On call
+// RemoveDeadline is a helper method to define mock.On call
 //   - ctx context.Context
-//   - cmd services.CreateTaskCommand
-func (_e *Creator_Expecter) Create(ctx interface{}, cmd interface{}) *Creator_Create_Call {
-	return &Creator_Create_Call{Call: _e.mock.On("Create", ctx, cmd)}
+//   - id string
+//   - ownerID string
+//   - expectedVersion *int64
+func (_e *SocketTaskService_Expecter) RemoveDeadline(ctx interface{}, id interface{}, ownerID interface{}, expectedVersion interface{}) *SocketTaskService_RemoveDeadline_Call {
+	return &SocketTaskService_RemoveDeadline_Call{Call: _e.mock.On("RemoveDeadline", ctx, id, ownerID, expectedVersion)}
 }
 
-func (_c *Creator_Create_Call) Run(run func(ctx context.Context, cmd services.CreateTaskCommand)) *Creator_Create_Call {
+func (_c *SocketTaskService_RemoveDeadline_Call) Run(run func(ctx context.Context, id string, ownerID string, expectedVersion *int64)) *SocketTaskService_RemoveDeadline_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 services.CreateTaskCommand
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(services.CreateTaskCommand)
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *int64
+		if args[3] != nil {
+			arg3 = args[3].(*int64)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *Creator_Create_Call) Return(s string, err error) *Creator_Create_Call {
-	_c.Call.Return(s, err)
+func (_c *SocketTaskService_RemoveDeadline_Call) Return(n int64, err error) *SocketTaskService_RemoveDeadline_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *Creator_Create_Call) RunAndReturn(run func(ctx context.Context, cmd services.CreateTaskCommand) (string, error)) *Creator_Create_Call {
+func (_c *SocketTaskService_RemoveDeadline_Call) RunAndReturn(run func(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)) *SocketTaskService_RemoveDeadline_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewDeleter creates a new instance of Deleter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewDeleter(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *Deleter {
-	mock := &Deleter{}
-	mock.Mock.Test(t)
+// Reopen provides a mock function for the type SocketTaskService
+func (_mock *SocketTaskService) Reopen(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error) {
+	ret := _mock.Called(ctx, id, ownerID, expectedVersion)
 
-	t.Cleanup(func() { mock.AssertExpectations(t) })
+	if len(ret) == 0 {
+		panic("no return value specified for Reopen")
+	}
 
-	return mock
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *int64) (int64, error)); ok {
+		return returnFunc(ctx, id, ownerID, expectedVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *int64) int64); ok {
+		r0 = returnFunc(ctx, id, ownerID, expectedVersion)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *int64) error); ok {
+		r1 = returnFunc(ctx, id, ownerID, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// Deleter is an autogenerated mock type for the Deleter type
-type Deleter struct {
-	mock.Mock
+// SocketTaskService_Reopen_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reopen'
+type SocketTaskService_Reopen_Call struct {
+	*mock.Call
 }
 
-type Deleter_Expecter struct {
-	mock *mock.Mock
+// Reopen is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - ownerID string
+//   - expectedVersion *int64
+func (_e *SocketTaskService_Expecter) Reopen(ctx interface{}, id interface{}, ownerID interface{}, expectedVersion interface{}) *SocketTaskService_Reopen_Call {
+	return &SocketTaskService_Reopen_Call{Call: _e.mock.On("Reopen", ctx, id, ownerID, expectedVersion)}
 }
 
-func (_m *Deleter) EXPECT() *Deleter_Expecter {
-	return &Deleter_Expecter{mock: &_m.Mock}
+func (_c *SocketTaskService_Reopen_Call) Run(run func(ctx context.Context, id string, ownerID string, expectedVersion *int64)) *SocketTaskService_Reopen_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *int64
+		if args[3] != nil {
+			arg3 = args[3].(*int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
 }
 
-// Delete provides a mock function for the type Deleter
-func (_mock *Deleter) Delete(ctx context.Context, id string, ownerID string) error {
-	ret := _mock.Called(ctx, id, ownerID)
+func (_c *SocketTaskService_Reopen_Call) Return(n int64, err error) *SocketTaskService_Reopen_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *SocketTaskService_Reopen_Call) RunAndReturn(run func(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)) *SocketTaskService_Reopen_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function for the type SocketTaskService
+func (_mock *SocketTaskService) Restore(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error) {
+	ret := _mock.Called(ctx, id, ownerID, expectedVersion)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Delete")
+		panic("no return value specified for Restore")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, id, ownerID)
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *int64) (int64, error)); ok {
+		return returnFunc(ctx, id, ownerID, expectedVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *int64) int64); ok {
+		r0 = returnFunc(ctx, id, ownerID, expectedVersion)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *int64) error); ok {
+		r1 = returnFunc(ctx, id, ownerID, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// Deleter_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
-type Deleter_Delete_Call struct {
+// SocketTaskService_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type SocketTaskService_Restore_Call struct {
 	*mock.Call
 }
 
-// Delete is a helper method to define mock.On call
+// Restore is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id string
 //   - ownerID string
-func (_e *Deleter_Expecter) Delete(ctx interface{}, id interface{}, ownerID interface{}) *Deleter_Delete_Call {
-	return &Deleter_Delete_Call{Call: _e.mock.On("Delete", ctx, id, ownerID)}
+//   - expectedVersion *int64
+func (_e *SocketTaskService_Expecter) Restore(ctx interface{}, id interface{}, ownerID interface{}, expectedVersion interface{}) *SocketTaskService_Restore_Call {
+	return &SocketTaskService_Restore_Call{Call: _e.mock.On("Restore", ctx, id, ownerID, expectedVersion)}
 }
 
-func (_c *Deleter_Delete_Call) Run(run func(ctx context.Context, id string, ownerID string)) *Deleter_Delete_Call {
+func (_c *SocketTaskService_Restore_Call) Run(run func(ctx context.Context, id string, ownerID string, expectedVersion *int64)) *SocketTaskService_Restore_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -266,32 +2181,37 @@ func (_c *Deleter_Delete_Call) Run(run func(ctx context.Context, id string, owne
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
+		var arg3 *int64
+		if args[3] != nil {
+			arg3 = args[3].(*int64)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *Deleter_Delete_Call) Return(err error) *Deleter_Delete_Call {
-	_c.Call.Return(err)
+func (_c *SocketTaskService_Restore_Call) Return(n int64, err error) *SocketTaskService_Restore_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *Deleter_Delete_Call) RunAndReturn(run func(ctx context.Context, id string, ownerID string) error) *Deleter_Delete_Call {
+func (_c *SocketTaskService_Restore_Call) RunAndReturn(run func(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)) *SocketTaskService_Restore_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewFinder creates a new instance of Finder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewChangeLister creates a new instance of ChangeLister. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewFinder(t interface {
+func NewChangeLister(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *Finder {
-	mock := &Finder{}
+}) *ChangeLister {
+	mock := &ChangeLister{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -299,60 +2219,62 @@ func NewFinder(t interface {
 	return mock
 }
 
-// Finder is an autogenerated mock type for the Finder type
-type Finder struct {
+// ChangeLister is an autogenerated mock type for the ChangeLister type
+type ChangeLister struct {
 	mock.Mock
 }
 
-type Finder_Expecter struct {
+type ChangeLister_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *Finder) EXPECT() *Finder_Expecter {
-	return &Finder_Expecter{mock: &_m.Mock}
+func (_m *ChangeLister) EXPECT() *ChangeLister_Expecter {
+	return &ChangeLister_Expecter{mock: &_m.Mock}
 }
 
-// FindByOwner provides a mock function for the type Finder
-func (_mock *Finder) FindByOwner(ctx context.Context, ownerID string) ([]*models.Task, error) {
-	ret := _mock.Called(ctx, ownerID)
+// ChangesSince provides a mock function for the type ChangeLister
+func (_mock *ChangeLister) ChangesSince(ctx context.Context, ownerID string, since int64, limit int) (*services.TaskSyncPage, error) {
+	ret := _mock.Called(ctx, ownerID, since, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindByOwner")
+		panic("no return value specified for ChangesSince")
 	}
 
-	var r0 []*models.Task
+	var r0 *services.TaskSyncPage
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*models.Task, error)); ok {
-		return returnFunc(ctx, ownerID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64, int) (*services.TaskSyncPage, error)); ok {
+		return returnFunc(ctx, ownerID, since, limit)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*models.Task); ok {
-		r0 = returnFunc(ctx, ownerID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64, int) *services.TaskSyncPage); ok {
+		r0 = returnFunc(ctx, ownerID, since, limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.Task)
+			r0 = ret.Get(0).(*services.TaskSyncPage)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, ownerID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int64, int) error); ok {
+		r1 = returnFunc(ctx, ownerID, since, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// Finder_FindByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByOwner'
-type Finder_FindByOwner_Call struct {
+// ChangeLister_ChangesSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangesSince'
+type ChangeLister_ChangesSince_Call struct {
 	*mock.Call
 }
 
-// FindByOwner is a helper method to define mock.On call
+// ChangesSince is a helper method to define mock.On call
 //   - ctx context.Context
 //   - ownerID string
-func (_e *Finder_Expecter) FindByOwner(ctx interface{}, ownerID interface{}) *Finder_FindByOwner_Call {
-	return &Finder_FindByOwner_Call{Call: _e.mock.On("FindByOwner", ctx, ownerID)}
+//   - since int64
+//   - limit int
+func (_e *ChangeLister_Expecter) ChangesSince(ctx interface{}, ownerID interface{}, since interface{}, limit interface{}) *ChangeLister_ChangesSince_Call {
+	return &ChangeLister_ChangesSince_Call{Call: _e.mock.On("ChangesSince", ctx, ownerID, since, limit)}
 }
 
-func (_c *Finder_FindByOwner_Call) Run(run func(ctx context.Context, ownerID string)) *Finder_FindByOwner_Call {
+func (_c *ChangeLister_ChangesSince_Call) Run(run func(ctx context.Context, ownerID string, since int64, limit int)) *ChangeLister_ChangesSince_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -362,31 +2284,41 @@ func (_c *Finder_FindByOwner_Call) Run(run func(ctx context.Context, ownerID str
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *Finder_FindByOwner_Call) Return(tasks []*models.Task, err error) *Finder_FindByOwner_Call {
-	_c.Call.Return(tasks, err)
+func (_c *ChangeLister_ChangesSince_Call) Return(taskSyncPage *services.TaskSyncPage, err error) *ChangeLister_ChangesSince_Call {
+	_c.Call.Return(taskSyncPage, err)
 	return _c
 }
 
-func (_c *Finder_FindByOwner_Call) RunAndReturn(run func(ctx context.Context, ownerID string) ([]*models.Task, error)) *Finder_FindByOwner_Call {
+func (_c *ChangeLister_ChangesSince_Call) RunAndReturn(run func(ctx context.Context, ownerID string, since int64, limit int) (*services.TaskSyncPage, error)) *ChangeLister_ChangesSince_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewDeadlineRemover creates a new instance of DeadlineRemover. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewChangePusher creates a new instance of ChangePusher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewDeadlineRemover(t interface {
+func NewChangePusher(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *DeadlineRemover {
-	mock := &DeadlineRemover{}
+}) *ChangePusher {
+	mock := &ChangePusher{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -394,50 +2326,52 @@ func NewDeadlineRemover(t interface {
 	return mock
 }
 
-// DeadlineRemover is an autogenerated mock type for the DeadlineRemover type
-type DeadlineRemover struct {
+// ChangePusher is an autogenerated mock type for the ChangePusher type
+type ChangePusher struct {
 	mock.Mock
 }
 
-type DeadlineRemover_Expecter struct {
+type ChangePusher_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *DeadlineRemover) EXPECT() *DeadlineRemover_Expecter {
-	return &DeadlineRemover_Expecter{mock: &_m.Mock}
+func (_m *ChangePusher) EXPECT() *ChangePusher_Expecter {
+	return &ChangePusher_Expecter{mock: &_m.Mock}
 }
 
-// RemoveDeadline provides a mock function for the type DeadlineRemover
-func (_mock *DeadlineRemover) RemoveDeadline(ctx context.Context, id string, ownerID string) error {
-	ret := _mock.Called(ctx, id, ownerID)
+// Push provides a mock function for the type ChangePusher
+func (_mock *ChangePusher) Push(ctx context.Context, ownerID string, ops []services.SyncOperation) []services.SyncResult {
+	ret := _mock.Called(ctx, ownerID, ops)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RemoveDeadline")
+		panic("no return value specified for Push")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, id, ownerID)
+	var r0 []services.SyncResult
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []services.SyncOperation) []services.SyncResult); ok {
+		r0 = returnFunc(ctx, ownerID, ops)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]services.SyncResult)
+		}
 	}
 	return r0
 }
 
-// DeadlineRemover_RemoveDeadline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveDeadline'
-type DeadlineRemover_RemoveDeadline_Call struct {
+// ChangePusher_Push_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Push'
+type ChangePusher_Push_Call struct {
 	*mock.Call
 }
 
-// RemoveDeadline is a helper method to define mock.On call
+// Push is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
 //   - ownerID string
-func (_e *DeadlineRemover_Expecter) RemoveDeadline(ctx interface{}, id interface{}, ownerID interface{}) *DeadlineRemover_RemoveDeadline_Call {
-	return &DeadlineRemover_RemoveDeadline_Call{Call: _e.mock.On("RemoveDeadline", ctx, id, ownerID)}
+//   - ops []services.SyncOperation
+func (_e *ChangePusher_Expecter) Push(ctx interface{}, ownerID interface{}, ops interface{}) *ChangePusher_Push_Call {
+	return &ChangePusher_Push_Call{Call: _e.mock.On("Push", ctx, ownerID, ops)}
 }
 
-func (_c *DeadlineRemover_RemoveDeadline_Call) Run(run func(ctx context.Context, id string, ownerID string)) *DeadlineRemover_RemoveDeadline_Call {
+func (_c *ChangePusher_Push_Call) Run(run func(ctx context.Context, ownerID string, ops []services.SyncOperation)) *ChangePusher_Push_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -447,9 +2381,9 @@ func (_c *DeadlineRemover_RemoveDeadline_Call) Run(run func(ctx context.Context,
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 []services.SyncOperation
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].([]services.SyncOperation)
 		}
 		run(
 			arg0,
@@ -460,23 +2394,23 @@ func (_c *DeadlineRemover_RemoveDeadline_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *DeadlineRemover_RemoveDeadline_Call) Return(err error) *DeadlineRemover_RemoveDeadline_Call {
-	_c.Call.Return(err)
+func (_c *ChangePusher_Push_Call) Return(syncResults []services.SyncResult) *ChangePusher_Push_Call {
+	_c.Call.Return(syncResults)
 	return _c
 }
 
-func (_c *DeadlineRemover_RemoveDeadline_Call) RunAndReturn(run func(ctx context.Context, id string, ownerID string) error) *DeadlineRemover_RemoveDeadline_Call {
+func (_c *ChangePusher_Push_Call) RunAndReturn(run func(ctx context.Context, ownerID string, ops []services.SyncOperation) []services.SyncResult) *ChangePusher_Push_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewReopener creates a new instance of Reopener. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewUnarchiver creates a new instance of Unarchiver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewReopener(t interface {
+func NewUnarchiver(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *Reopener {
-	mock := &Reopener{}
+}) *Unarchiver {
+	mock := &Unarchiver{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -484,50 +2418,60 @@ func NewReopener(t interface {
 	return mock
 }
 
-// Reopener is an autogenerated mock type for the Reopener type
-type Reopener struct {
+// Unarchiver is an autogenerated mock type for the Unarchiver type
+type Unarchiver struct {
 	mock.Mock
 }
 
-type Reopener_Expecter struct {
+type Unarchiver_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *Reopener) EXPECT() *Reopener_Expecter {
-	return &Reopener_Expecter{mock: &_m.Mock}
+func (_m *Unarchiver) EXPECT() *Unarchiver_Expecter {
+	return &Unarchiver_Expecter{mock: &_m.Mock}
 }
 
-// Reopen provides a mock function for the type Reopener
-func (_mock *Reopener) Reopen(ctx context.Context, id string, ownerID string) error {
-	ret := _mock.Called(ctx, id, ownerID)
+// Unarchive provides a mock function for the type Unarchiver
+func (_mock *Unarchiver) Unarchive(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error) {
+	ret := _mock.Called(ctx, id, ownerID, expectedVersion)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Reopen")
+		panic("no return value specified for Unarchive")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, id, ownerID)
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *int64) (int64, error)); ok {
+		return returnFunc(ctx, id, ownerID, expectedVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *int64) int64); ok {
+		r0 = returnFunc(ctx, id, ownerID, expectedVersion)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *int64) error); ok {
+		r1 = returnFunc(ctx, id, ownerID, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// Reopener_Reopen_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reopen'
-type Reopener_Reopen_Call struct {
+// Unarchiver_Unarchive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unarchive'
+type Unarchiver_Unarchive_Call struct {
 	*mock.Call
 }
 
-// Reopen is a helper method to define mock.On call
+// Unarchive is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id string
 //   - ownerID string
-func (_e *Reopener_Expecter) Reopen(ctx interface{}, id interface{}, ownerID interface{}) *Reopener_Reopen_Call {
-	return &Reopener_Reopen_Call{Call: _e.mock.On("Reopen", ctx, id, ownerID)}
+//   - expectedVersion *int64
+func (_e *Unarchiver_Expecter) Unarchive(ctx interface{}, id interface{}, ownerID interface{}, expectedVersion interface{}) *Unarchiver_Unarchive_Call {
+	return &Unarchiver_Unarchive_Call{Call: _e.mock.On("Unarchive", ctx, id, ownerID, expectedVersion)}
 }
 
-func (_c *Reopener_Reopen_Call) Run(run func(ctx context.Context, id string, ownerID string)) *Reopener_Reopen_Call {
+func (_c *Unarchiver_Unarchive_Call) Run(run func(ctx context.Context, id string, ownerID string, expectedVersion *int64)) *Unarchiver_Unarchive_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -541,21 +2485,26 @@ func (_c *Reopener_Reopen_Call) Run(run func(ctx context.Context, id string, own
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
+		var arg3 *int64
+		if args[3] != nil {
+			arg3 = args[3].(*int64)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *Reopener_Reopen_Call) Return(err error) *Reopener_Reopen_Call {
-	_c.Call.Return(err)
+func (_c *Unarchiver_Unarchive_Call) Return(n int64, err error) *Unarchiver_Unarchive_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *Reopener_Reopen_Call) RunAndReturn(run func(ctx context.Context, id string, ownerID string) error) *Reopener_Reopen_Call {
+func (_c *Unarchiver_Unarchive_Call) RunAndReturn(run func(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)) *Unarchiver_Unarchive_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -588,20 +2537,29 @@ func (_m *Updater) EXPECT() *Updater_Expecter {
 }
 
 // Update provides a mock function for the type Updater
-func (_mock *Updater) Update(ctx context.Context, id string, ownerID string, cmd services.UpdateTaskCommand) error {
-	ret := _mock.Called(ctx, id, ownerID, cmd)
+func (_mock *Updater) Update(ctx context.Context, id string, ownerID string, cmd services.UpdateTaskCommand, expectedVersion *int64) (int64, error) {
+	ret := _mock.Called(ctx, id, ownerID, cmd, expectedVersion)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, services.UpdateTaskCommand) error); ok {
-		r0 = returnFunc(ctx, id, ownerID, cmd)
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, services.UpdateTaskCommand, *int64) (int64, error)); ok {
+		return returnFunc(ctx, id, ownerID, cmd, expectedVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, services.UpdateTaskCommand, *int64) int64); ok {
+		r0 = returnFunc(ctx, id, ownerID, cmd, expectedVersion)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, services.UpdateTaskCommand, *int64) error); ok {
+		r1 = returnFunc(ctx, id, ownerID, cmd, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
 // Updater_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
@@ -614,11 +2572,12 @@ type Updater_Update_Call struct {
 //   - id string
 //   - ownerID string
 //   - cmd services.UpdateTaskCommand
-func (_e *Updater_Expecter) Update(ctx interface{}, id interface{}, ownerID interface{}, cmd interface{}) *Updater_Update_Call {
-	return &Updater_Update_Call{Call: _e.mock.On("Update", ctx, id, ownerID, cmd)}
+//   - expectedVersion *int64
+func (_e *Updater_Expecter) Update(ctx interface{}, id interface{}, ownerID interface{}, cmd interface{}, expectedVersion interface{}) *Updater_Update_Call {
+	return &Updater_Update_Call{Call: _e.mock.On("Update", ctx, id, ownerID, cmd, expectedVersion)}
 }
 
-func (_c *Updater_Update_Call) Run(run func(ctx context.Context, id string, ownerID string, cmd services.UpdateTaskCommand)) *Updater_Update_Call {
+func (_c *Updater_Update_Call) Run(run func(ctx context.Context, id string, ownerID string, cmd services.UpdateTaskCommand, expectedVersion *int64)) *Updater_Update_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -636,22 +2595,27 @@ func (_c *Updater_Update_Call) Run(run func(ctx context.Context, id string, owne
 		if args[3] != nil {
 			arg3 = args[3].(services.UpdateTaskCommand)
 		}
+		var arg4 *int64
+		if args[4] != nil {
+			arg4 = args[4].(*int64)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
+			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *Updater_Update_Call) Return(err error) *Updater_Update_Call {
-	_c.Call.Return(err)
+func (_c *Updater_Update_Call) Return(n int64, err error) *Updater_Update_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *Updater_Update_Call) RunAndReturn(run func(ctx context.Context, id string, ownerID string, cmd services.UpdateTaskCommand) error) *Updater_Update_Call {
+func (_c *Updater_Update_Call) RunAndReturn(run func(ctx context.Context, id string, ownerID string, cmd services.UpdateTaskCommand, expectedVersion *int64) (int64, error)) *Updater_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
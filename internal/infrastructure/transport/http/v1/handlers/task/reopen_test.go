@@ -12,11 +12,11 @@ import (
 	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task"
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task/mocks"
 	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
 	"github.com/cyberbrain-dev/taskery-api/internal/services"
-	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -29,6 +29,7 @@ func TestReopenHandler(t *testing.T) {
 		name         string
 		payload      task.ReopenRequest
 		expectedCode int
+		expectedETag string
 		expectedBody string
 		userID       string
 		mockSetup    func(reopener *mocks.Reopener)
@@ -39,11 +40,12 @@ func TestReopenHandler(t *testing.T) {
 				TaskID: validTaskID,
 			},
 			expectedCode: http.StatusNoContent,
+			expectedETag: `"2"`,
 			expectedBody: "",
 			userID:       validUserID,
 			mockSetup: func(reopener *mocks.Reopener) {
-				reopener.On("Reopen", mock.Anything, validTaskID, validUserID).
-					Return(nil)
+				reopener.On("Reopen", mock.Anything, validTaskID, validUserID, (*int64)(nil)).
+					Return(int64(2), nil)
 			},
 		},
 		{
@@ -52,11 +54,11 @@ func TestReopenHandler(t *testing.T) {
 				TaskID: validTaskID,
 			},
 			expectedCode: http.StatusNotFound,
-			expectedBody: `{"error":"task not found"}`,
+			expectedBody: `{"type":"/problems/task-not-found","title":"Not Found","status":404,"detail":"task not found","code":"TASK_NOT_FOUND"}`,
 			userID:       validUserID,
 			mockSetup: func(reopener *mocks.Reopener) {
-				reopener.On("Reopen", mock.Anything, validTaskID, validUserID).
-					Return(services.ErrTaskNotFound)
+				reopener.On("Reopen", mock.Anything, validTaskID, validUserID, (*int64)(nil)).
+					Return(int64(0), services.ErrTaskNotFound)
 			},
 		},
 		{
@@ -65,11 +67,11 @@ func TestReopenHandler(t *testing.T) {
 				TaskID: validTaskID,
 			},
 			expectedCode: http.StatusForbidden,
-			expectedBody: `{"error":"access denied"}`,
+			expectedBody: `{"type":"/problems/task-access-denied","title":"Forbidden","status":403,"detail":"access denied","code":"TASK_ACCESS_DENIED"}`,
 			userID:       validUserID,
 			mockSetup: func(reopener *mocks.Reopener) {
-				reopener.On("Reopen", mock.Anything, validTaskID, validUserID).
-					Return(services.ErrTaskAccessDenied)
+				reopener.On("Reopen", mock.Anything, validTaskID, validUserID, (*int64)(nil)).
+					Return(int64(0), services.ErrTaskAccessDenied)
 			},
 		},
 		{
@@ -78,11 +80,11 @@ func TestReopenHandler(t *testing.T) {
 				TaskID: validTaskID,
 			},
 			expectedCode: http.StatusInternalServerError,
-			expectedBody: `{"error":"internal server error"}`,
+			expectedBody: `{"type":"/problems/internal-error","title":"Internal Server Error","status":500,"detail":"internal server error","code":"INTERNAL_ERROR"}`,
 			userID:       validUserID,
 			mockSetup: func(reopener *mocks.Reopener) {
-				reopener.On("Reopen", mock.Anything, validTaskID, validUserID).
-					Return(services.ErrTaskReopenFailed)
+				reopener.On("Reopen", mock.Anything, validTaskID, validUserID, (*int64)(nil)).
+					Return(int64(0), services.ErrTaskReopenFailed)
 			},
 		},
 		{
@@ -91,7 +93,7 @@ func TestReopenHandler(t *testing.T) {
 				TaskID: validTaskID,
 			},
 			expectedCode: http.StatusBadRequest,
-			expectedBody: `{"error":"bad request"}`,
+			expectedBody: `{"type":"/problems/bad-request","title":"Bad Request","status":400,"detail":"bad request","code":"BAD_REQUEST"}`,
 			userID:       "",
 			mockSetup:    nil,
 		},
@@ -101,7 +103,7 @@ func TestReopenHandler(t *testing.T) {
 				TaskID: "",
 			},
 			expectedCode: http.StatusBadRequest,
-			expectedBody: `{"errors":[{"field":"TaskID","error":"field is required"}]}`,
+			expectedBody: `{"type":"/problems/validation-failed","title":"Bad Request","status":400,"detail":"request body is invalid","code":"VALIDATION_FAILED","errors":[{"field":"task_id","rule":"required","error":"is required"}]}`,
 			userID:       validUserID,
 			mockSetup:    nil,
 		},
@@ -129,10 +131,11 @@ func TestReopenHandler(t *testing.T) {
 
 			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
 
-			h := task.NewReopenHandler(reopener, 4*time.Second, logger, validator.New())
+			h := task.NewReopenHandler(reopener, 4*time.Second, logger, handlers.NewValidator())
 			h.ServeHTTP(rr, req)
 
 			require.Equal(t, tt.expectedCode, rr.Code)
+			require.Equal(t, tt.expectedETag, rr.Header().Get("ETag"))
 
 			if tt.expectedBody != "" {
 				require.Equal(t, tt.expectedBody, rr.Body.String())
@@ -0,0 +1,107 @@
+package task
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+type Reverter interface {
+	Revert(ctx context.Context, id string, ownerID string, eventID string, expectedVersion *int64) (int64, error)
+}
+
+type RevertHandler struct {
+	reverter Reverter
+	timeout  time.Duration
+	logger   *slog.Logger
+	validate *validator.Validate
+}
+
+func NewRevertHandler(
+	reverter Reverter,
+	timeout time.Duration,
+	logger *slog.Logger,
+	validate *validator.Validate,
+) *RevertHandler {
+	return &RevertHandler{
+		reverter: reverter,
+		timeout:  timeout,
+		logger:   logger,
+		validate: validate,
+	}
+}
+
+// @Summary Revert a task
+// @Description Brings the title, description, deadline and completion status of a task
+// @Description back to the state it had right after the given history event.
+// @Description The values are validated again, so e.g. a deadline that has passed cannot be restored.
+// @Tags tasks
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param to query string true "ID of the history event to revert to"
+// @Param If-Match header string false "Expected task version (ETag)"
+// @Security     BearerAuth
+// @Success 204 {object} nil
+// @Header 204 {string} ETag "New task version"
+// @Failure 400 {object} handlers.Problem
+// @Failure 401 {object} handlers.Problem
+// @Failure 403 {object} handlers.Problem
+// @Failure 404 {object} handlers.Problem
+// @Failure 409 {object} handlers.Problem
+// @Failure 412 {object} handlers.Problem
+// @Failure 500 {object} handlers.Problem
+// @Router /tasks/{id}/revert [post]
+func (h *RevertHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.Task.Revert"
+
+	logger := slogx.FromContext(r.Context(), h.logger).With(slog.String("op", op))
+
+	taskID := chi.URLParam(r, "id")
+	if taskID == "" {
+		logger.Error("task id is not provided")
+		handlers.WriteError(w, r, handlers.MissingParameter("task id"))
+		return
+	}
+
+	eventID := r.URL.Query().Get("to")
+	if eventID == "" {
+		logger.Info("event id is not provided")
+		handlers.WriteError(w, r, handlers.MissingParameter("event id"))
+		return
+	}
+
+	expectedVersion, err := handlers.ParseIfMatch(r)
+	if err != nil {
+		logger.Error("failed to parse If-Match header", slog.String("err", err.Error()))
+		handlers.WriteError(w, r, err)
+		return
+	}
+
+	userID := myMw.GetUserID(r.Context())
+	if userID == "" {
+		logger.Error("failed to extract owner id")
+		handlers.WriteError(w, r, handlers.ErrBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	version, err := h.reverter.Revert(ctx, taskID, userID, eventID, expectedVersion)
+	if err != nil {
+		logger.Error("failed to revert task", slog.String("err", err.Error()))
+		handlers.WriteError(w, r, handlers.PreconditionError(err, expectedVersion))
+		return
+	}
+
+	logger.Info("task reverted", slog.String("event_id", eventID))
+	w.Header().Set("ETag", handlers.FormatETag(version))
+	handlers.WriteJSON(w, http.StatusNoContent, nil)
+}
@@ -0,0 +1,118 @@
+package task
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
+	"github.com/go-playground/validator/v10"
+)
+
+type TaskSearcher interface {
+	Search(ctx context.Context, ownerID string, query services.SearchTasksQuery) ([]services.TaskSearchResult, error)
+}
+
+type SearchHandler struct {
+	searcher TaskSearcher
+	timeout  time.Duration
+	logger   *slog.Logger
+	validate *validator.Validate
+}
+
+func NewSearchHandler(
+	searcher TaskSearcher,
+	timeout time.Duration,
+	logger *slog.Logger,
+	validate *validator.Validate,
+) *SearchHandler {
+	return &SearchHandler{
+		searcher: searcher,
+		timeout:  timeout,
+		logger:   logger,
+		validate: validate,
+	}
+}
+
+// @Summary Search tasks
+// @Description Searches the title and the description of the tasks of the authenticated user that are not in the trash. Words are matched regardless of case, "quoted phrases" match adjacent words and a trailing * matches words by prefix. The snippets are HTML-escaped and the matched words in them are enclosed in <b> and </b>
+// @Tags tasks
+// @Produce json
+// @Security     BearerAuth
+// @Param q query string true "Search query"
+// @Param limit query int false "Maximum number of results" minimum(1) maximum(100) default(20)
+// @Param include_archived query bool false "Include archived tasks"
+// @Success 200 {object} SearchResponse
+// @Failure 400 {object} handlers.Problem
+// @Failure 401 {object} handlers.Problem
+// @Failure 500 {object} handlers.Problem
+// @Router /tasks/search [get]
+func (h *SearchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.Task.Search"
+
+	logger := slogx.FromContext(r.Context(), h.logger).With(slog.String("op", op))
+
+	userID := myMw.GetUserID(r.Context())
+	if userID == "" {
+		logger.Error("failed to extract owner id")
+		handlers.WriteError(w, r, handlers.ErrBadRequest)
+		return
+	}
+
+	query := services.SearchTasksQuery{
+		Text: r.URL.Query().Get("q"),
+	}
+
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 {
+			logger.Info("invalid limit parameter", slog.String("limit", raw))
+			handlers.WriteError(w, r, handlers.InvalidParameter("limit"))
+			return
+		}
+
+		query.Limit = limit
+	}
+
+	if raw := r.URL.Query().Get("include_archived"); raw != "" {
+		includeArchived, err := strconv.ParseBool(raw)
+		if err != nil {
+			logger.Info("invalid include_archived parameter", slog.String("err", err.Error()))
+			handlers.WriteError(w, r, handlers.InvalidParameter("include_archived"))
+			return
+		}
+
+		query.IncludeArchived = includeArchived
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	results, err := h.searcher.Search(ctx, userID, query)
+	if err != nil {
+		logger.Error("failed to search tasks", slog.String("err", err.Error()))
+		handlers.WriteError(w, r, err)
+		return
+	}
+
+	resultDTOs := make([]SearchResultDTO, len(results))
+	for i, result := range results {
+		resultDTOs[i] = SearchResultDTO{
+			Task:               newTaskDTO(result.Task),
+			Rank:               result.Rank,
+			TitleSnippet:       result.TitleSnippet,
+			DescriptionSnippet: result.DescriptionSnippet,
+		}
+	}
+
+	handlers.WriteJSON(w, http.StatusOK, SearchResponse{
+		OwnerID: userID,
+		Query:   query.Text,
+		Results: resultDTOs,
+	})
+}
@@ -0,0 +1,206 @@
+package task_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/search/memory"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task/mocks"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchHandler(t *testing.T) {
+	validUserID := gofakeit.UUID()
+	milkID := gofakeit.UUID()
+	archivedID := gofakeit.UUID()
+	updatedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	archivedAt := updatedAt.Add(time.Hour)
+
+	milk, err := models.NewTaskFromDB(models.TaskFromDBParams{
+		ID:          milkID,
+		OwnerID:     validUserID,
+		Title:       "Buy milk",
+		Description: "Fresh milk",
+		UpdatedAt:   updatedAt,
+		Version:     1,
+	})
+	require.NoError(t, err)
+
+	archived, err := models.NewTaskFromDB(models.TaskFromDBParams{
+		ID:         archivedID,
+		OwnerID:    validUserID,
+		Title:      "Old milk",
+		UpdatedAt:  archivedAt,
+		ArchivedAt: &archivedAt,
+		Version:    2,
+	})
+	require.NoError(t, err)
+
+	searcher := memory.NewTaskSearcher(milk, archived)
+
+	tests := []struct {
+		name         string
+		expectedCode int
+		expectedBody string
+		userID       string
+		query        string
+		searcher     func(t *testing.T) task.TaskSearcher
+	}{
+		{
+			name:         "success",
+			expectedCode: http.StatusOK,
+			expectedBody: func() string {
+				body, _ := json.Marshal(task.SearchResponse{
+					OwnerID: validUserID,
+					Query:   "mil*",
+					Results: []task.SearchResultDTO{
+						{
+							Task: task.TaskDTO{
+								ID:          milkID,
+								Title:       "Buy milk",
+								Description: "Fresh milk",
+								UpdatedAt:   updatedAt,
+								Version:     1,
+							},
+							Rank:               1.4,
+							TitleSnippet:       "Buy <b>milk</b>",
+							DescriptionSnippet: "Fresh <b>milk</b>",
+						},
+					},
+				})
+				return string(body)
+			}(),
+			userID:   validUserID,
+			query:    "?q=mil*",
+			searcher: func(t *testing.T) task.TaskSearcher { return searcher },
+		},
+		{
+			name:         "success with archived tasks and limit",
+			expectedCode: http.StatusOK,
+			expectedBody: func() string {
+				body, _ := json.Marshal(task.SearchResponse{
+					OwnerID: validUserID,
+					Query:   "old",
+					Results: []task.SearchResultDTO{
+						{
+							Task: task.TaskDTO{
+								ID:         archivedID,
+								Title:      "Old milk",
+								UpdatedAt:  archivedAt,
+								ArchivedAt: &archivedAt,
+								Version:    2,
+							},
+							Rank:         1,
+							TitleSnippet: "<b>Old</b> milk",
+						},
+					},
+				})
+				return string(body)
+			}(),
+			userID:   validUserID,
+			query:    "?q=old&include_archived=true&limit=1",
+			searcher: func(t *testing.T) task.TaskSearcher { return searcher },
+		},
+		{
+			name:         "nothing found",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"owner_id":"` + validUserID + `","query":"\"fresh buy\"","results":[]}`,
+			userID:       validUserID,
+			query:        `?q="fresh+buy"`,
+			searcher:     func(t *testing.T) task.TaskSearcher { return searcher },
+		},
+		{
+			name:         "empty query",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/search-query-empty","title":"Bad Request","status":400,"detail":"search query is empty","code":"SEARCH_QUERY_EMPTY","errors":[{"field":"q","rule":"required","error":"is required"}]}`,
+			userID:       validUserID,
+			query:        "",
+			searcher:     func(t *testing.T) task.TaskSearcher { return searcher },
+		},
+		{
+			name:         "limit is not a number",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/invalid-parameter","title":"Bad Request","status":400,"detail":"invalid limit parameter","code":"INVALID_PARAMETER"}`,
+			userID:       validUserID,
+			query:        "?q=milk&limit=many",
+			searcher:     nil,
+		},
+		{
+			name:         "limit is too large",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/invalid-parameter","title":"Bad Request","status":400,"detail":"invalid limit parameter","code":"INVALID_PARAMETER"}`,
+			userID:       validUserID,
+			query:        "?q=milk&limit=1000",
+			searcher:     func(t *testing.T) task.TaskSearcher { return searcher },
+		},
+		{
+			name:         "invalid include_archived",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/invalid-parameter","title":"Bad Request","status":400,"detail":"invalid include_archived parameter","code":"INVALID_PARAMETER"}`,
+			userID:       validUserID,
+			query:        "?q=milk&include_archived=maybe",
+			searcher:     nil,
+		},
+		{
+			name:         "internal error",
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"type":"/problems/internal-error","title":"Internal Server Error","status":500,"detail":"internal server error","code":"INTERNAL_ERROR"}`,
+			userID:       validUserID,
+			query:        "?q=milk",
+			searcher: func(t *testing.T) task.TaskSearcher {
+				searcher := mocks.NewTaskSearcher(t)
+				searcher.On("Search", mock.Anything, validUserID, services.SearchTasksQuery{Text: "milk"}).
+					Return(nil, services.ErrTaskSearchFailed)
+
+				return searcher
+			},
+		},
+		{
+			name:         "empty user id",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/bad-request","title":"Bad Request","status":400,"detail":"bad request","code":"BAD_REQUEST"}`,
+			userID:       "",
+			query:        "?q=milk",
+			searcher:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequestWithContext(
+				context.WithValue(context.Background(), myMw.UserIDKey, tt.userID),
+				http.MethodGet,
+				"/tasks/search"+tt.query,
+				nil,
+			)
+
+			rr := httptest.NewRecorder()
+
+			var s task.TaskSearcher = mocks.NewTaskSearcher(t)
+			if tt.searcher != nil {
+				s = tt.searcher(t)
+			}
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+
+			h := task.NewSearchHandler(s, 4*time.Second, logger, handlers.NewValidator())
+			h.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedCode, rr.Code)
+			require.JSONEq(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+}
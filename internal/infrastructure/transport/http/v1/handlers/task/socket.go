@@ -0,0 +1,435 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
+	"github.com/go-playground/validator/v10"
+)
+
+// The channels of the socket.
+const (
+	// SocketChannelTasks delivers the changes of all the tasks of the user.
+	SocketChannelTasks = "tasks"
+
+	// SocketChannelTaskPrefix followed by the ID of a task is the channel of the task,
+	// which delivers its changes and the users viewing it.
+	SocketChannelTaskPrefix = "task:"
+)
+
+// SocketProtocol is the subprotocol of the socket. The browsers offer it along with the token,
+// see middleware.WebSocketBearerProtocolPrefix, and the server selects it, so that the token
+// is never echoed back.
+const SocketProtocol = "taskery.v1"
+
+var errChannelUnknown = handlers.NewError(http.StatusBadRequest, "CHANNEL_UNKNOWN", "unknown channel")
+
+type SocketTaskService interface {
+	FindByID(ctx context.Context, id string, ownerID string) (*models.Task, error)
+	Complete(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+	Reopen(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+	RemoveDeadline(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+	Delete(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+	Restore(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+}
+
+// SocketOptions configure the WebSocket channel.
+type SocketOptions struct {
+	// MaxConnections limits the open connections of a user, and MaxSubscriptions limits
+	// the channel subscriptions of a user over all of them. Zero turns a limit off.
+	MaxConnections   int
+	MaxSubscriptions int
+
+	// MaxMessageSize limits the size of a message from the client in bytes.
+	MaxMessageSize int64
+
+	// PingInterval is the interval of the pings that detect the lost connections.
+	PingInterval time.Duration
+
+	// AllowedOrigins are the origins of the browser clients other than the API itself,
+	// e.g. "https://app.taskery.dev". "*" allows any origin.
+	AllowedOrigins []string
+}
+
+type SocketHandler struct {
+	service    SocketTaskService
+	subscriber ChangeSubscriber
+	hub        *socketHub
+	opts       SocketOptions
+	timeout    time.Duration
+	logger     *slog.Logger
+	validate   *validator.Validate
+}
+
+// NewSocketHandler creates a new SocketHandler. The commands of the clients are applied
+// with service, and the changes of the tasks are received from subscriber.
+func NewSocketHandler(
+	service SocketTaskService,
+	subscriber ChangeSubscriber,
+	opts SocketOptions,
+	timeout time.Duration,
+	logger *slog.Logger,
+	validate *validator.Validate,
+) *SocketHandler {
+	return &SocketHandler{
+		service:    service,
+		subscriber: subscriber,
+		hub:        newSocketHub(opts.MaxConnections, opts.MaxSubscriptions),
+		opts:       opts,
+		timeout:    timeout,
+		logger:     logger,
+		validate:   validate,
+	}
+}
+
+// @Summary Real-time channel
+// @Description Upgrades the connection to a WebSocket that exchanges JSON messages.
+// @Description The browsers, which can not set the Authorization header, pass the token as a subprotocol: new WebSocket(url, ["taskery.v1", "bearer.{token}"]).
+// @Description The client subscribes to the "tasks" channel with the changes of all its tasks or to a "task:{id}" channel with the changes of a task and the users viewing it:
+// @Description {"id": "1", "type": "subscribe", "channel": "task:{id}"}, and unsubscribes with the "unsubscribe" type.
+// @Description The complete, reopen, remove_deadline, delete and restore commands are applied to the tasks like the corresponding endpoints do:
+// @Description {"id": "2", "type": "command", "command": "complete", "task_id": "{id}", "version": 3}, where the optional version works like If-Match.
+// @Description Every message is acknowledged with {"type": "ack", "id": "1"}, which has the problem in the error field if the message has failed.
+// @Description The acknowledgement of a command has the new version of the task in the version field, like the ETag of the endpoints.
+// @Description The changes are pushed as {"type": "event", "channel": "tasks", "event": "completed", "data": {...}} and the viewers as {"type": "presence", "channel": "task:{id}", "viewers": [...]}.
+// @Tags tasks
+// @Security     BearerAuth
+// @Success 101
+// @Failure 400 {object} handlers.Problem
+// @Failure 401 {object} handlers.Problem
+// @Failure 429 {object} handlers.Problem
+// @Router /ws [get]
+func (h *SocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.Task.Socket"
+
+	logger := slogx.FromContext(r.Context(), h.logger).With(slog.String("op", op))
+
+	userID := myMw.GetUserID(r.Context())
+	if userID == "" {
+		logger.Error("failed to extract owner id")
+		handlers.WriteError(w, r, handlers.ErrBadRequest)
+		return
+	}
+
+	if err := h.hub.connect(userID); err != nil {
+		logger.Info("too many connections")
+		handlers.WriteError(w, r, err)
+		return
+	}
+
+	s := &socketSession{
+		handler:  h,
+		userID:   userID,
+		lang:     handlers.RequestLanguage(r),
+		logger:   logger,
+		channels: make(map[string]struct{}),
+		changed:  make(map[string]struct{}),
+		wake:     make(chan struct{}, 1),
+	}
+	defer h.hub.disconnect(s)
+
+	// the socket outlives the read and write timeouts of the server
+	rc := http.NewResponseController(w)
+	if err := rc.SetReadDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		logger.Error("failed to clear read deadline", slog.String("err", err.Error()))
+		handlers.WriteError(w, r, err)
+		return
+	}
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		logger.Error("failed to clear write deadline", slog.String("err", err.Error()))
+		handlers.WriteError(w, r, err)
+		return
+	}
+
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{
+		Subprotocols:       []string{SocketProtocol},
+		OriginPatterns:     h.opts.AllowedOrigins,
+		InsecureSkipVerify: slices.Contains(h.opts.AllowedOrigins, "*"),
+	})
+	if err != nil {
+		// Accept has already responded
+		logger.Info("failed to accept websocket", slog.String("err", err.Error()))
+		return
+	}
+	defer conn.CloseNow()
+
+	if h.opts.MaxMessageSize > 0 {
+		conn.SetReadLimit(h.opts.MaxMessageSize)
+	}
+
+	s.conn = conn
+
+	// the connection is hijacked, so it is not cancelled together with the request
+	ctx, cancel := context.WithCancel(context.WithoutCancel(r.Context()))
+	defer cancel()
+
+	s.run(ctx)
+}
+
+// socketSession is an open socket of a user.
+type socketSession struct {
+	handler *SocketHandler
+	conn    *websocket.Conn
+	userID  string
+	lang    handlers.Language
+	logger  *slog.Logger
+
+	// channels are the channels the session is subscribed to.
+	// They are changed by the hub on behalf of the session only.
+	channels map[string]struct{}
+
+	// changed are the channels whose viewers have changed since the session has sent them,
+	// and wake signals that there are some.
+	mu      sync.Mutex
+	changed map[string]struct{}
+	wake    chan struct{}
+}
+
+// run serves the socket until the client disconnects or the changes are no longer delivered.
+func (s *socketSession) run(ctx context.Context) {
+	changes := s.handler.subscriber.Subscribe(s.userID, "")
+	defer changes.Close()
+
+	messages := make(chan []byte)
+	readErr := make(chan error, 1)
+
+	go func() {
+		for {
+			typ, data, err := s.conn.Read(ctx)
+			if err != nil {
+				readErr <- err
+				return
+			}
+
+			if typ != websocket.MessageText {
+				data = nil
+			}
+
+			select {
+			case messages <- data:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(s.handler.opts.PingInterval)
+	defer ping.Stop()
+
+	for {
+		var err error
+
+		select {
+		case readErr := <-readErr:
+			if websocket.CloseStatus(readErr) == -1 {
+				s.logger.Info("websocket is lost", slog.String("err", readErr.Error()))
+			}
+			return
+
+		case data := <-messages:
+			err = s.handle(ctx, data)
+
+		case change, ok := <-changes.Changes():
+			if !ok {
+				// the session fell behind or the server is shutting down,
+				// the client reconnects and reloads the tasks
+				s.conn.Close(websocket.StatusTryAgainLater, "changes are no longer delivered")
+				return
+			}
+
+			err = s.deliver(ctx, change)
+
+		case <-s.wake:
+			err = s.sendPresence(ctx)
+
+		case <-ping.C:
+			pingCtx, cancel := context.WithTimeout(ctx, s.handler.timeout)
+			err = s.conn.Ping(pingCtx)
+			cancel()
+		}
+
+		if err != nil {
+			s.logger.Info("websocket is lost", slog.String("err", err.Error()))
+			return
+		}
+	}
+}
+
+// handle handles a message of the client and acknowledges it.
+func (s *socketSession) handle(ctx context.Context, data []byte) error {
+	if data == nil {
+		return s.send(ctx, SocketAckDTO{Type: "ack", Error: s.problem(handlers.ErrRequestBodyInvalid)})
+	}
+
+	msg, err := handlers.DecodeMessage[SocketRequest](data, true, s.handler.validate)
+	if err != nil {
+		return s.send(ctx, SocketAckDTO{Type: "ack", Error: s.problem(err)})
+	}
+
+	var version int64
+
+	switch msg.Type {
+	case "subscribe":
+		err = s.subscribe(ctx, msg.Channel)
+	case "unsubscribe":
+		s.handler.hub.unsubscribe(s, msg.Channel)
+	case "command":
+		version, err = s.command(ctx, msg)
+	}
+
+	ack := SocketAckDTO{Type: "ack", ID: msg.ID}
+	if err != nil {
+		ack.Error = s.problem(err)
+	} else {
+		ack.Version = version
+	}
+
+	return s.send(ctx, ack)
+}
+
+// subscribe subscribes the session to the channel if the user has access to it.
+func (s *socketSession) subscribe(ctx context.Context, channel string) error {
+	if channel != SocketChannelTasks {
+		taskID, ok := strings.CutPrefix(channel, SocketChannelTaskPrefix)
+		if !ok || taskID == "" {
+			return errChannelUnknown
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, s.handler.timeout)
+		defer cancel()
+
+		if _, err := s.handler.service.FindByID(ctx, taskID, s.userID); err != nil {
+			return err
+		}
+	}
+
+	return s.handler.hub.subscribe(s, channel)
+}
+
+// command applies the command to the task like the corresponding endpoint does
+// and returns the version of the task after the command.
+func (s *socketSession) command(ctx context.Context, msg *SocketRequest) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.handler.timeout)
+	defer cancel()
+
+	var apply func(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+
+	switch msg.Command {
+	case "complete":
+		apply = s.handler.service.Complete
+	case "reopen":
+		apply = s.handler.service.Reopen
+	case "remove_deadline":
+		apply = s.handler.service.RemoveDeadline
+	case "delete":
+		apply = s.handler.service.Delete
+	case "restore":
+		apply = s.handler.service.Restore
+	}
+
+	version, err := apply(ctx, msg.TaskID, s.userID, msg.Version)
+	if err != nil {
+		return 0, handlers.PreconditionError(err, msg.Version)
+	}
+
+	return version, nil
+}
+
+// deliver sends the change to the channels of the session it belongs to.
+func (s *socketSession) deliver(ctx context.Context, change services.TaskChange) error {
+	data := newTaskChangeDTO(change)
+
+	for _, channel := range []string{SocketChannelTasks, SocketChannelTaskPrefix + data.TaskID} {
+		if _, ok := s.channels[channel]; !ok {
+			continue
+		}
+
+		err := s.send(ctx, SocketEventDTO{
+			Type:    "event",
+			Channel: channel,
+			Event:   data.Type,
+			Data:    data,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// presenceChanged tells the session that the viewers of the channel have changed.
+// It never blocks, so that the hub can call it for every viewer.
+func (s *socketSession) presenceChanged(channel string) {
+	s.mu.Lock()
+	s.changed[channel] = struct{}{}
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// sendPresence sends the current viewers of the changed channels the session is still subscribed to.
+func (s *socketSession) sendPresence(ctx context.Context) error {
+	s.mu.Lock()
+	changed := s.changed
+	s.changed = make(map[string]struct{})
+	s.mu.Unlock()
+
+	for channel := range changed {
+		if _, ok := s.channels[channel]; !ok {
+			continue
+		}
+
+		err := s.send(ctx, SocketPresenceDTO{
+			Type:    "presence",
+			Channel: channel,
+			Viewers: s.handler.hub.presence(channel),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *socketSession) send(ctx context.Context, v any) error {
+	ctx, cancel := context.WithTimeout(ctx, s.handler.timeout)
+	defer cancel()
+
+	return wsjson.Write(ctx, s.conn, v)
+}
+
+// problem returns the problem for the failed message in the language of the client.
+func (s *socketSession) problem(err error) *handlers.Problem {
+	problem := handlers.NewLocalizedProblem(err, s.lang)
+
+	if problem.Status == http.StatusInternalServerError {
+		s.logger.Error("failed to handle websocket message", slog.String("err", err.Error()))
+	} else {
+		s.logger.Info("websocket message is rejected", slog.String("err", err.Error()))
+	}
+
+	return &problem
+}
+
+// hasPresence reports whether the viewers of the channel are tracked.
+func hasPresence(channel string) bool {
+	return strings.HasPrefix(channel, SocketChannelTaskPrefix)
+}
@@ -0,0 +1,167 @@
+package task
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+)
+
+var (
+	errTooManyConnections = handlers.NewError(
+		http.StatusTooManyRequests,
+		"TOO_MANY_CONNECTIONS",
+		"too many open connections",
+	)
+	errTooManySubscriptions = handlers.NewError(
+		http.StatusTooManyRequests,
+		"TOO_MANY_SUBSCRIPTIONS",
+		"too many channel subscriptions",
+	)
+)
+
+// socketHub keeps track of the open sockets: it enforces the limits of the connections
+// and the subscriptions of every user and tells the sessions who is viewing their channels.
+type socketHub struct {
+	mu sync.Mutex
+
+	maxConnections   int
+	maxSubscriptions int
+
+	connections   map[string]int
+	subscriptions map[string]int
+
+	// viewers are the sessions subscribed to each channel with presence.
+	viewers map[string]map[*socketSession]struct{}
+}
+
+// newSocketHub creates a hub that allows a user at most maxConnections connections
+// and maxSubscriptions subscriptions over all of them. Zero turns a limit off.
+func newSocketHub(maxConnections, maxSubscriptions int) *socketHub {
+	return &socketHub{
+		maxConnections:   maxConnections,
+		maxSubscriptions: maxSubscriptions,
+		connections:      make(map[string]int),
+		subscriptions:    make(map[string]int),
+		viewers:          make(map[string]map[*socketSession]struct{}),
+	}
+}
+
+// connect reserves a connection for the user.
+// It returns errTooManyConnections if the user has no connections left.
+func (h *socketHub) connect(userID string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.maxConnections > 0 && h.connections[userID] >= h.maxConnections {
+		return errTooManyConnections
+	}
+
+	h.connections[userID]++
+
+	return nil
+}
+
+// disconnect releases the connection of the session together with its subscriptions.
+func (h *socketHub) disconnect(s *socketSession) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for channel := range s.channels {
+		h.leave(s, channel)
+	}
+
+	h.connections[s.userID]--
+	if h.connections[s.userID] <= 0 {
+		delete(h.connections, s.userID)
+	}
+}
+
+// subscribe subscribes the session to the channel.
+// It returns errTooManySubscriptions if the user has no subscriptions left.
+func (h *socketHub) subscribe(s *socketSession, channel string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := s.channels[channel]; ok {
+		return nil
+	}
+
+	if h.maxSubscriptions > 0 && h.subscriptions[s.userID] >= h.maxSubscriptions {
+		return errTooManySubscriptions
+	}
+
+	h.subscriptions[s.userID]++
+	s.channels[channel] = struct{}{}
+
+	if hasPresence(channel) {
+		if h.viewers[channel] == nil {
+			h.viewers[channel] = make(map[*socketSession]struct{})
+		}
+		h.viewers[channel][s] = struct{}{}
+
+		h.notify(channel)
+	}
+
+	return nil
+}
+
+// unsubscribe unsubscribes the session from the channel, if it is subscribed.
+func (h *socketHub) unsubscribe(s *socketSession, channel string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := s.channels[channel]; ok {
+		h.leave(s, channel)
+	}
+}
+
+// presence returns the users viewing the channel ordered by their IDs.
+func (h *socketHub) presence(channel string) []SocketViewerDTO {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	connections := make(map[string]int)
+	for s := range h.viewers[channel] {
+		connections[s.userID]++
+	}
+
+	viewers := make([]SocketViewerDTO, 0, len(connections))
+	for userID, n := range connections {
+		viewers = append(viewers, SocketViewerDTO{UserID: userID, Connections: n})
+	}
+
+	slices.SortFunc(viewers, func(a, b SocketViewerDTO) int {
+		return strings.Compare(a.UserID, b.UserID)
+	})
+
+	return viewers
+}
+
+// leave removes the subscription of the session to the channel. h.mu must be held.
+func (h *socketHub) leave(s *socketSession, channel string) {
+	delete(s.channels, channel)
+
+	h.subscriptions[s.userID]--
+	if h.subscriptions[s.userID] <= 0 {
+		delete(h.subscriptions, s.userID)
+	}
+
+	if _, ok := h.viewers[channel][s]; ok {
+		delete(h.viewers[channel], s)
+		if len(h.viewers[channel]) == 0 {
+			delete(h.viewers, channel)
+		}
+
+		h.notify(channel)
+	}
+}
+
+// notify tells the viewers of the channel that its presence has changed. h.mu must be held.
+func (h *socketHub) notify(channel string) {
+	for s := range h.viewers[channel] {
+		s.presenceChanged(channel)
+	}
+}
@@ -0,0 +1,333 @@
+package task_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/pubsub"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task/mocks"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// socketMessage is any message of the server.
+type socketMessage struct {
+	Type    string                 `json:"type"`
+	ID      string                 `json:"id"`
+	Version int64                  `json:"version"`
+	Channel string                 `json:"channel"`
+	Event   string                 `json:"event"`
+	Data    task.TaskChangeDTO     `json:"data"`
+	Viewers []task.SocketViewerDTO `json:"viewers"`
+	Error   *handlers.Problem      `json:"error"`
+}
+
+type socketClient struct {
+	t    *testing.T
+	conn *websocket.Conn
+}
+
+func (c *socketClient) send(msg string) {
+	c.t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(c.t, c.conn.Write(ctx, websocket.MessageText, []byte(msg)))
+}
+
+func (c *socketClient) receive() socketMessage {
+	c.t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var msg socketMessage
+	require.NoError(c.t, wsjson.Read(ctx, c.conn, &msg))
+
+	return msg
+}
+
+func TestSocketHandler(t *testing.T) {
+	userID := uuid.New()
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	clk := clock.NewFake(now)
+
+	taskModel, err := models.NewTask("Test task", "", userID, clk)
+	require.NoError(t, err)
+	taskID := taskModel.ID().String()
+	taskChannel := task.SocketChannelTaskPrefix + taskID
+
+	before := taskModel.State()
+	taskModel.Complete(clk)
+	completed := services.TaskChange{
+		OwnerID: userID.String(),
+		Version: 2,
+		Event:   models.NewTaskEvent(taskModel, models.TaskEventCompleted, userID, &before, clk),
+	}
+
+	newServer := func(
+		t *testing.T,
+		service *mocks.SocketTaskService,
+		broker *pubsub.Broker,
+		opts task.SocketOptions,
+		configure ...func(*http.Server),
+	) *httptest.Server {
+		if opts.PingInterval == 0 {
+			opts.PingInterval = time.Hour
+		}
+
+		handler := task.NewSocketHandler(
+			service,
+			broker,
+			opts,
+			5*time.Second,
+			slog.New(slog.NewTextHandler(io.Discard, nil)),
+			handlers.NewValidator(),
+		)
+
+		srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), myMw.UserIDKey, userID.String())))
+		}))
+		for _, c := range configure {
+			c(srv.Config)
+		}
+		srv.Start()
+		t.Cleanup(srv.Close)
+
+		return srv
+	}
+
+	dial := func(t *testing.T, srv *httptest.Server) (*socketClient, *http.Response, error) {
+		conn, resp, err := websocket.Dial(context.Background(), "ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+		if err != nil {
+			return nil, resp, err
+		}
+		t.Cleanup(func() { conn.CloseNow() })
+
+		return &socketClient{t: t, conn: conn}, resp, nil
+	}
+
+	connect := func(t *testing.T, srv *httptest.Server) *socketClient {
+		client, _, err := dial(t, srv)
+		require.NoError(t, err)
+
+		return client
+	}
+
+	t.Run("subscribe to tasks and receive changes", func(t *testing.T) {
+		broker := pubsub.NewBroker(10, 10)
+		client := connect(t, newServer(t, new(mocks.SocketTaskService), broker, task.SocketOptions{}))
+
+		client.send(`{"id":"1","type":"subscribe","channel":"tasks"}`)
+		require.Equal(t, socketMessage{Type: "ack", ID: "1"}, client.receive())
+
+		require.NoError(t, broker.Publish(context.Background(), completed))
+
+		msg := client.receive()
+		require.Equal(t, "event", msg.Type)
+		require.Equal(t, "tasks", msg.Channel)
+		require.Equal(t, "completed", msg.Event)
+		require.Equal(t, taskID, msg.Data.TaskID)
+		require.Equal(t, int64(2), msg.Data.Version)
+		require.Equal(t, completed.Event.ID().String(), msg.Data.ID)
+	})
+	t.Run("presence of a task", func(t *testing.T) {
+		service := new(mocks.SocketTaskService)
+		service.On("FindByID", mock.Anything, taskID, userID.String()).Return(taskModel, nil)
+
+		broker := pubsub.NewBroker(10, 10)
+		srv := newServer(t, service, broker, task.SocketOptions{})
+
+		first := connect(t, srv)
+		first.send(`{"id":"1","type":"subscribe","channel":"` + taskChannel + `"}`)
+		require.Equal(t, socketMessage{Type: "ack", ID: "1"}, first.receive())
+
+		msg := first.receive()
+		require.Equal(t, "presence", msg.Type)
+		require.Equal(t, taskChannel, msg.Channel)
+		require.Equal(t, []task.SocketViewerDTO{{UserID: userID.String(), Connections: 1}}, msg.Viewers)
+
+		second := connect(t, srv)
+		second.send(`{"id":"1","type":"subscribe","channel":"` + taskChannel + `"}`)
+		require.Equal(t, socketMessage{Type: "ack", ID: "1"}, second.receive())
+
+		for _, client := range []*socketClient{first, second} {
+			msg := client.receive()
+			require.Equal(t, "presence", msg.Type)
+			require.Equal(t, []task.SocketViewerDTO{{UserID: userID.String(), Connections: 2}}, msg.Viewers)
+		}
+
+		second.send(`{"id":"2","type":"unsubscribe","channel":"` + taskChannel + `"}`)
+		require.Equal(t, socketMessage{Type: "ack", ID: "2"}, second.receive())
+
+		msg = first.receive()
+		require.Equal(t, "presence", msg.Type)
+		require.Equal(t, []task.SocketViewerDTO{{UserID: userID.String(), Connections: 1}}, msg.Viewers)
+
+		// the changes of the task are delivered to its channel only
+		require.NoError(t, broker.Publish(context.Background(), completed))
+
+		msg = first.receive()
+		require.Equal(t, "event", msg.Type)
+		require.Equal(t, taskChannel, msg.Channel)
+	})
+	t.Run("subscribe to a task of another user", func(t *testing.T) {
+		service := new(mocks.SocketTaskService)
+		service.On("FindByID", mock.Anything, taskID, userID.String()).Return(nil, services.ErrTaskAccessDenied)
+
+		client := connect(t, newServer(t, service, pubsub.NewBroker(10, 10), task.SocketOptions{}))
+
+		client.send(`{"id":"1","type":"subscribe","channel":"` + taskChannel + `"}`)
+
+		msg := client.receive()
+		require.Equal(t, "ack", msg.Type)
+		require.Equal(t, "1", msg.ID)
+		require.Equal(t, http.StatusForbidden, msg.Error.Status)
+		require.Equal(t, "TASK_ACCESS_DENIED", msg.Error.Code)
+	})
+	t.Run("subscribe to an unknown channel", func(t *testing.T) {
+		client := connect(t, newServer(t, new(mocks.SocketTaskService), pubsub.NewBroker(10, 10), task.SocketOptions{}))
+
+		client.send(`{"id":"1","type":"subscribe","channel":"project:1"}`)
+
+		msg := client.receive()
+		require.Equal(t, "CHANNEL_UNKNOWN", msg.Error.Code)
+	})
+	t.Run("commands", func(t *testing.T) {
+		service := new(mocks.SocketTaskService)
+		service.On("Complete", mock.Anything, taskID, userID.String(), (*int64)(nil)).Once().Return(int64(2), nil)
+		service.On("Reopen", mock.Anything, taskID, userID.String(), new(int64(3))).Once().Return(int64(0), services.ErrTaskConflict)
+
+		client := connect(t, newServer(t, service, pubsub.NewBroker(10, 10), task.SocketOptions{}))
+
+		client.send(`{"id":"1","type":"command","command":"complete","task_id":"` + taskID + `"}`)
+		require.Equal(t, socketMessage{Type: "ack", ID: "1", Version: 2}, client.receive())
+
+		client.send(`{"id":"2","type":"command","command":"reopen","task_id":"` + taskID + `","version":3}`)
+
+		msg := client.receive()
+		require.Equal(t, "2", msg.ID)
+		require.Equal(t, http.StatusPreconditionFailed, msg.Error.Status)
+		require.Equal(t, "TASK_VERSION_MISMATCH", msg.Error.Code)
+
+		service.AssertExpectations(t)
+	})
+	t.Run("invalid messages", func(t *testing.T) {
+		client := connect(t, newServer(t, new(mocks.SocketTaskService), pubsub.NewBroker(10, 10), task.SocketOptions{}))
+
+		client.send(`{"id":"1","type":"command","command":"rename"}`)
+
+		msg := client.receive()
+		require.Equal(t, "VALIDATION_FAILED", msg.Error.Code)
+		require.Equal(t, []handlers.FieldError{
+			{Field: "command", Rule: "oneof", Params: map[string]any{"values": []any{
+				"complete", "reopen", "remove_deadline", "delete", "restore",
+			}}, Error: "must be one of: complete, reopen, remove_deadline, delete, restore"},
+			{Field: "task_id", Rule: "required_if", Error: "is required"},
+		}, msg.Error.Errors)
+
+		client.send(`{"id":"2","type":"subscribe","channel":"tasks","extra":true}`)
+
+		msg = client.receive()
+		require.Equal(t, "REQUEST_BODY_INVALID", msg.Error.Code)
+		require.Equal(t, `unknown field "extra"`, msg.Error.Detail)
+	})
+	t.Run("selects the protocol instead of the token", func(t *testing.T) {
+		srv := newServer(t, new(mocks.SocketTaskService), pubsub.NewBroker(10, 10), task.SocketOptions{})
+
+		conn, _, err := websocket.Dial(context.Background(), "ws"+strings.TrimPrefix(srv.URL, "http"), &websocket.DialOptions{
+			Subprotocols: []string{task.SocketProtocol, myMw.WebSocketBearerProtocolPrefix + "token"},
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() { conn.CloseNow() })
+
+		require.Equal(t, task.SocketProtocol, conn.Subprotocol())
+	})
+	t.Run("outlives the timeouts of the server", func(t *testing.T) {
+		broker := pubsub.NewBroker(10, 10)
+		srv := newServer(t, new(mocks.SocketTaskService), broker, task.SocketOptions{}, func(s *http.Server) {
+			s.ReadTimeout = 100 * time.Millisecond
+			s.WriteTimeout = 100 * time.Millisecond
+		})
+		client := connect(t, srv)
+
+		time.Sleep(300 * time.Millisecond)
+
+		client.send(`{"id":"1","type":"subscribe","channel":"tasks"}`)
+		require.Equal(t, socketMessage{Type: "ack", ID: "1"}, client.receive())
+
+		require.NoError(t, broker.Publish(context.Background(), completed))
+		require.Equal(t, "event", client.receive().Type)
+	})
+	t.Run("connection limit", func(t *testing.T) {
+		srv := newServer(t, new(mocks.SocketTaskService), pubsub.NewBroker(10, 10), task.SocketOptions{MaxConnections: 1})
+
+		first := connect(t, srv)
+
+		_, resp, err := dial(t, srv)
+		require.Error(t, err)
+		require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+		var problem handlers.Problem
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+		require.Equal(t, "TOO_MANY_CONNECTIONS", problem.Code)
+
+		// the connection is released once it is closed
+		require.NoError(t, first.conn.Close(websocket.StatusNormalClosure, ""))
+		require.Eventually(t, func() bool {
+			_, _, err := dial(t, srv)
+			return err == nil
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+	t.Run("subscription limit", func(t *testing.T) {
+		service := new(mocks.SocketTaskService)
+		service.On("FindByID", mock.Anything, taskID, userID.String()).Return(taskModel, nil)
+
+		client := connect(t, newServer(t, service, pubsub.NewBroker(10, 10), task.SocketOptions{MaxSubscriptions: 1}))
+
+		client.send(`{"id":"1","type":"subscribe","channel":"tasks"}`)
+		require.Equal(t, socketMessage{Type: "ack", ID: "1"}, client.receive())
+
+		// subscribing again does not count
+		client.send(`{"id":"2","type":"subscribe","channel":"tasks"}`)
+		require.Equal(t, socketMessage{Type: "ack", ID: "2"}, client.receive())
+
+		client.send(`{"id":"3","type":"subscribe","channel":"` + taskChannel + `"}`)
+
+		msg := client.receive()
+		require.Equal(t, http.StatusTooManyRequests, msg.Error.Status)
+		require.Equal(t, "TOO_MANY_SUBSCRIPTIONS", msg.Error.Code)
+	})
+	t.Run("closing the broker closes the socket", func(t *testing.T) {
+		broker := pubsub.NewBroker(10, 10)
+		client := connect(t, newServer(t, new(mocks.SocketTaskService), broker, task.SocketOptions{}))
+
+		client.send(`{"id":"1","type":"subscribe","channel":"tasks"}`)
+		require.Equal(t, socketMessage{Type: "ack", ID: "1"}, client.receive())
+
+		broker.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		_, _, err := client.conn.Read(ctx)
+		require.Equal(t, websocket.StatusTryAgainLater, websocket.CloseStatus(err))
+	})
+}
@@ -0,0 +1,133 @@
+package task
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
+	"github.com/go-playground/validator/v10"
+)
+
+var errSyncTokenInvalid = errors.New("invalid sync token")
+
+type ChangeLister interface {
+	ChangesSince(ctx context.Context, ownerID string, since int64, limit int) (*services.TaskSyncPage, error)
+}
+
+type SyncHandler struct {
+	lister   ChangeLister
+	timeout  time.Duration
+	logger   *slog.Logger
+	validate *validator.Validate
+}
+
+func NewSyncHandler(
+	lister ChangeLister,
+	timeout time.Duration,
+	logger *slog.Logger,
+	validate *validator.Validate,
+) *SyncHandler {
+	return &SyncHandler{
+		lister:   lister,
+		timeout:  timeout,
+		logger:   logger,
+		validate: validate,
+	}
+}
+
+// @Summary Get changes of tasks since the last sync
+// @Description Returns the IDs of the tasks of the authenticated user that have been created, updated or permanently deleted since the sync the token was returned by, each of them once by its last change. A task in the trash is reported as updated.
+// @Description Without a token all tasks are returned as created. The returned token is passed as since to get the next changes; if has_more is true, there are more changes to get right away.
+// @Tags tasks
+// @Produce json
+// @Security     BearerAuth
+// @Param since query string false "Token returned by the previous sync"
+// @Param limit query int false "Maximum number of changes" minimum(1) maximum(1000) default(100)
+// @Success 200 {object} SyncResponse
+// @Failure 400 {object} handlers.Problem
+// @Failure 401 {object} handlers.Problem
+// @Failure 500 {object} handlers.Problem
+// @Router /sync [get]
+func (h *SyncHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.Task.Sync"
+
+	logger := slogx.FromContext(r.Context(), h.logger).With(slog.String("op", op))
+
+	userID := myMw.GetUserID(r.Context())
+	if userID == "" {
+		logger.Error("failed to extract owner id")
+		handlers.WriteError(w, r, handlers.ErrBadRequest)
+		return
+	}
+
+	var since int64
+	if raw := r.URL.Query().Get("since"); raw != "" {
+		seq, err := decodeSyncToken(raw)
+		if err != nil {
+			logger.Info("invalid since parameter", slog.String("since", raw))
+			handlers.WriteError(w, r, handlers.InvalidParameter("since"))
+			return
+		}
+
+		since = seq
+	}
+
+	var limit int
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			logger.Info("invalid limit parameter", slog.String("limit", raw))
+			handlers.WriteError(w, r, handlers.InvalidParameter("limit"))
+			return
+		}
+
+		limit = n
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	page, err := h.lister.ChangesSince(ctx, userID, since, limit)
+	if err != nil {
+		logger.Error("failed to get task changes", slog.String("err", err.Error()))
+		handlers.WriteError(w, r, err)
+		return
+	}
+
+	handlers.WriteJSON(w, http.StatusOK, SyncResponse{
+		Token:   encodeSyncToken(page.Seq),
+		HasMore: page.HasMore,
+		Created: page.Created,
+		Updated: page.Updated,
+		Deleted: page.Deleted,
+	})
+}
+
+// encodeSyncToken hides the change sequence number in an opaque token,
+// so that the clients do not rely on its format.
+func encodeSyncToken(seq int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(seq, 10)))
+}
+
+// decodeSyncToken returns the change sequence number of the token created by encodeSyncToken.
+func decodeSyncToken(token string) (int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, errSyncTokenInvalid
+	}
+
+	seq, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil || seq < 0 {
+		return 0, errSyncTokenInvalid
+	}
+
+	return seq, nil
+}
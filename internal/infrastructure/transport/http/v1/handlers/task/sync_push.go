@@ -0,0 +1,155 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
+	"github.com/go-playground/validator/v10"
+)
+
+type ChangePusher interface {
+	Push(ctx context.Context, ownerID string, ops []services.SyncOperation) []services.SyncResult
+}
+
+type SyncPushHandler struct {
+	pusher     ChangePusher
+	maxChanges int
+	timeout    time.Duration
+	logger     *slog.Logger
+	validate   *validator.Validate
+}
+
+// NewSyncPushHandler creates a new SyncPushHandler that accepts at most maxChanges changes in a single request.
+func NewSyncPushHandler(
+	pusher ChangePusher,
+	maxChanges int,
+	timeout time.Duration,
+	logger *slog.Logger,
+	validate *validator.Validate,
+) *SyncPushHandler {
+	return &SyncPushHandler{
+		pusher:     pusher,
+		maxChanges: maxChanges,
+		timeout:    timeout,
+		logger:     logger,
+		validate:   validate,
+	}
+}
+
+// @Summary Push changes made offline
+// @Description Applies the create, update, remove_deadline, complete, reopen and delete changes that a client queued while offline to the tasks of the authenticated user, in their order and independently of each other.
+// @Description Every change gets a result with the status code that the corresponding single-task endpoint would respond with; the optional version works like If-Match. A created task is matched to the change by its client_id.
+// @Description The result of an applied change and of a change rejected with 412 carries the current state of the task, so that the client can resolve the conflict.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param request body SyncPushRequest true "Changes to push"
+// @Security     BearerAuth
+// @Success 200 {object} SyncPushResponse
+// @Failure 400 {object} handlers.Problem
+// @Failure 401 {object} handlers.Problem
+// @Failure 500 {object} handlers.Problem
+// @Router /sync [post]
+func (h *SyncPushHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.Task.SyncPush"
+
+	logger := slogx.FromContext(r.Context(), h.logger).With(slog.String("op", op))
+
+	req, ok := handlers.DecodeAndValidate[SyncPushRequest](w, r, logger, h.validate)
+	if !ok {
+		return
+	}
+
+	if len(req.Changes) > h.maxChanges {
+		logger.Info("too many changes are pushed", slog.Int("size", len(req.Changes)))
+		handlers.WriteError(w, r, handlers.NewError(
+			http.StatusBadRequest,
+			"SYNC_TOO_LARGE",
+			fmt.Sprintf("sync must not push more than %d changes", h.maxChanges),
+		))
+		return
+	}
+
+	ownerID := myMw.GetUserID(r.Context())
+	if ownerID == "" {
+		logger.Error("failed to extract owner id")
+		handlers.WriteError(w, r, handlers.ErrBadRequest)
+		return
+	}
+
+	ops := make([]services.SyncOperation, len(req.Changes))
+	for i, c := range req.Changes {
+		ops[i].Type = services.BatchOperationType(c.Op)
+		ops[i].TaskID = c.TaskID
+		ops[i].ExpectedVersion = c.Version
+
+		if ops[i].Type == services.SyncOperationCreate {
+			ops[i].Create = services.CreateTaskCommand{
+				Title:       deref(c.Title),
+				Description: deref(c.Description),
+				Deadline:    c.Deadline,
+			}
+		} else {
+			ops[i].Update = services.UpdateTaskCommand{
+				Title:       c.Title,
+				Description: c.Description,
+				Deadline:    c.Deadline,
+			}
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	pushed := h.pusher.Push(ctx, ownerID, ops)
+
+	results := make([]SyncPushResult, len(pushed))
+	for i, result := range pushed {
+		results[i] = SyncPushResult{
+			Index:    i,
+			ClientID: req.Changes[i].ClientID,
+			TaskID:   result.TaskID,
+			Status:   http.StatusOK,
+		}
+
+		if result.Task != nil {
+			results[i].Task = new(newTaskDTO(result.Task))
+		}
+
+		if result.Err == nil {
+			if ops[i].Type == services.SyncOperationCreate {
+				results[i].Status = http.StatusCreated
+			}
+
+			continue
+		}
+
+		// the change gets the same problem as the single-task endpoint for it would respond with
+		problem := handlers.NewProblem(handlers.PreconditionError(result.Err, ops[i].ExpectedVersion))
+		if problem.Status == http.StatusInternalServerError {
+			logger.Error("failed to apply pushed change", slog.Int("index", i), slog.String("err", result.Err.Error()))
+		}
+
+		results[i].Status = problem.Status
+		results[i].Code = problem.Code
+		results[i].Error = problem.Detail
+	}
+
+	handlers.WriteJSON(w, http.StatusOK, SyncPushResponse{Results: results})
+}
+
+// deref returns the string s points to or an empty string if s is nil.
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
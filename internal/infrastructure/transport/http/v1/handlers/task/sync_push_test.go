@@ -0,0 +1,163 @@
+package task_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/vo"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task/mocks"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncPushHandler(t *testing.T) {
+	validUserID := gofakeit.UUID()
+	createdID := gofakeit.UUID()
+	staleID := gofakeit.UUID()
+	missingID := gofakeit.UUID()
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	created, err := models.NewTaskFromDB(models.TaskFromDBParams{
+		ID:        createdID,
+		OwnerID:   validUserID,
+		Title:     "Buy milk",
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+		Version:   1,
+	})
+	require.NoError(t, err)
+
+	stale, err := models.NewTaskFromDB(models.TaskFromDBParams{
+		ID:        staleID,
+		OwnerID:   validUserID,
+		Title:     "Call mom",
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt.Add(time.Hour),
+		Version:   4,
+	})
+	require.NoError(t, err)
+
+	taskJSON := func(id, title, updatedAt string, version int) string {
+		return `{"id":"` + id + `","title":"` + title + `","description":"","deadline":null,` +
+			`"is_completed":false,"completed_at":null,"created_at":"2026-01-02T03:04:05Z",` +
+			`"updated_at":"` + updatedAt + `","archived_at":null,"deleted_at":null,"version":` + strconv.Itoa(version) + `}`
+	}
+
+	tests := []struct {
+		name         string
+		expectedCode int
+		expectedBody string
+		userID       string
+		requestBody  string
+		mockSetup    func(pusher *mocks.ChangePusher)
+	}{
+		{
+			name:         "per-change results",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"results":[` +
+				`{"index":0,"client_id":"local-1","task_id":"` + createdID + `","status":201,` +
+				`"task":` + taskJSON(createdID, "Buy milk", "2026-01-02T03:04:05Z", 1) + `},` +
+				`{"index":1,"client_id":"local-2","status":400,"code":"TITLE_EMPTY","error":"title is empty"},` +
+				`{"index":2,"task_id":"` + staleID + `","status":412,"code":"TASK_VERSION_MISMATCH","error":"task version mismatch",` +
+				`"task":` + taskJSON(staleID, "Call mom", "2026-01-02T04:04:05Z", 4) + `},` +
+				`{"index":3,"task_id":"` + missingID + `","status":404,"code":"TASK_NOT_FOUND","error":"task not found"}` +
+				`]}`,
+			userID: validUserID,
+			requestBody: `{"changes":[` +
+				`{"op":"create","client_id":"local-1","title":"Buy milk"},` +
+				`{"op":"create","client_id":"local-2"},` +
+				`{"op":"update","task_id":"` + staleID + `","title":"Call dad","version":3},` +
+				`{"op":"delete","task_id":"` + missingID + `"}` +
+				`]}`,
+			mockSetup: func(pusher *mocks.ChangePusher) {
+				pusher.On("Push", mock.Anything, validUserID, []services.SyncOperation{
+					{
+						BatchOperation: services.BatchOperation{Type: services.SyncOperationCreate},
+						Create:         services.CreateTaskCommand{Title: "Buy milk"},
+					},
+					{BatchOperation: services.BatchOperation{Type: services.SyncOperationCreate}},
+					{BatchOperation: services.BatchOperation{
+						Type:            services.BatchOperationUpdate,
+						TaskID:          staleID,
+						Update:          services.UpdateTaskCommand{Title: new("Call dad")},
+						ExpectedVersion: new(int64(3)),
+					}},
+					{BatchOperation: services.BatchOperation{Type: services.BatchOperationDelete, TaskID: missingID}},
+				}).
+					Once().
+					Return([]services.SyncResult{
+						{TaskID: createdID, Task: created},
+						{Err: vo.ErrTitleEmpty},
+						{TaskID: staleID, Task: stale, Err: services.ErrTaskConflict},
+						{TaskID: missingID, Err: services.ErrTaskNotFound},
+					})
+			},
+		},
+		{
+			name:         "too many changes",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/sync-too-large","title":"Bad Request","status":400,"detail":"sync must not push more than 4 changes","code":"SYNC_TOO_LARGE"}`,
+			userID:       validUserID,
+			requestBody: `{"changes":[` +
+				strings.Repeat(`{"op":"complete","task_id":"`+staleID+`"},`, 4) +
+				`{"op":"complete","task_id":"` + staleID + `"}` +
+				`]}`,
+		},
+		{
+			name:         "create without client id",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/validation-failed","title":"Bad Request","status":400,"detail":"request body is invalid","code":"VALIDATION_FAILED","errors":[{"field":"changes[0].client_id","rule":"required_if","error":"is required"}]}`,
+			userID:       validUserID,
+			requestBody:  `{"changes":[{"op":"create","title":"Buy milk"}]}`,
+		},
+		{
+			name:         "empty user id",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/bad-request","title":"Bad Request","status":400,"detail":"bad request","code":"BAD_REQUEST"}`,
+			userID:       "",
+			requestBody:  `{"changes":[{"op":"complete","task_id":"` + staleID + `"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequestWithContext(
+				context.WithValue(context.Background(), myMw.UserIDKey, tt.userID),
+				http.MethodPost,
+				"/sync",
+				bytes.NewReader([]byte(tt.requestBody)),
+			)
+
+			rr := httptest.NewRecorder()
+
+			pusher := new(mocks.ChangePusher)
+			if tt.mockSetup != nil {
+				tt.mockSetup(pusher)
+			}
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+
+			h := task.NewSyncPushHandler(pusher, 4, 4*time.Second, logger, handlers.NewValidator())
+			h.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedCode, rr.Code)
+			require.JSONEq(t, tt.expectedBody, rr.Body.String())
+
+			pusher.AssertExpectations(t)
+		})
+	}
+}
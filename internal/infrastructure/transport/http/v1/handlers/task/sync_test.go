@@ -0,0 +1,148 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task/mocks"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncHandler(t *testing.T) {
+	validUserID := gofakeit.UUID()
+
+	tests := []struct {
+		name         string
+		expectedCode int
+		expectedBody string
+		userID       string
+		query        string
+		mockSetup    func(lister *mocks.ChangeLister)
+	}{
+		{
+			name:         "first sync",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"token":"NDI","has_more":true,"created":["a","b"],"updated":[],"deleted":[]}`,
+			userID:       validUserID,
+			query:        "",
+			mockSetup: func(lister *mocks.ChangeLister) {
+				lister.On("ChangesSince", mock.Anything, validUserID, int64(0), 0).
+					Once().
+					Return(&services.TaskSyncPage{
+						Created: []string{"a", "b"},
+						Updated: []string{},
+						Deleted: []string{},
+						Seq:     42,
+						HasMore: true,
+					}, nil)
+			},
+		},
+		{
+			name:         "sync since the token",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"token":"NDI","has_more":false,"created":[],"updated":["a"],"deleted":["c"]}`,
+			userID:       validUserID,
+			query:        "?since=Nw&limit=50",
+			mockSetup: func(lister *mocks.ChangeLister) {
+				lister.On("ChangesSince", mock.Anything, validUserID, int64(7), 50).
+					Once().
+					Return(&services.TaskSyncPage{
+						Created: []string{},
+						Updated: []string{"a"},
+						Deleted: []string{"c"},
+						Seq:     42,
+					}, nil)
+			},
+		},
+		{
+			name:         "malformed token",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/invalid-parameter","title":"Bad Request","status":400,"detail":"invalid since parameter","code":"INVALID_PARAMETER"}`,
+			userID:       validUserID,
+			query:        "?since=not-a-token",
+		},
+		{
+			name:         "negative sequence number in the token",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/invalid-parameter","title":"Bad Request","status":400,"detail":"invalid since parameter","code":"INVALID_PARAMETER"}`,
+			userID:       validUserID,
+			query:        "?since=LTE",
+		},
+		{
+			name:         "invalid limit",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/invalid-parameter","title":"Bad Request","status":400,"detail":"invalid limit parameter","code":"INVALID_PARAMETER"}`,
+			userID:       validUserID,
+			query:        "?limit=0",
+		},
+		{
+			name:         "limit is too large",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/invalid-parameter","title":"Bad Request","status":400,"detail":"invalid limit parameter","code":"INVALID_PARAMETER"}`,
+			userID:       validUserID,
+			query:        "?limit=5000",
+			mockSetup: func(lister *mocks.ChangeLister) {
+				lister.On("ChangesSince", mock.Anything, validUserID, int64(0), 5000).
+					Once().
+					Return(nil, services.ErrTaskSyncLimitInvalid)
+			},
+		},
+		{
+			name:         "internal error",
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"type":"/problems/internal-error","title":"Internal Server Error","status":500,"detail":"internal server error","code":"INTERNAL_ERROR"}`,
+			userID:       validUserID,
+			mockSetup: func(lister *mocks.ChangeLister) {
+				lister.On("ChangesSince", mock.Anything, validUserID, int64(0), 0).
+					Once().
+					Return(nil, errors.Join(services.ErrTaskSyncFailed, errors.New("db is down")))
+			},
+		},
+		{
+			name:         "empty user id",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/bad-request","title":"Bad Request","status":400,"detail":"bad request","code":"BAD_REQUEST"}`,
+			userID:       "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequestWithContext(
+				context.WithValue(context.Background(), myMw.UserIDKey, tt.userID),
+				http.MethodGet,
+				"/sync"+tt.query,
+				nil,
+			)
+
+			rr := httptest.NewRecorder()
+
+			lister := new(mocks.ChangeLister)
+			if tt.mockSetup != nil {
+				tt.mockSetup(lister)
+			}
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+
+			h := task.NewSyncHandler(lister, 4*time.Second, logger, handlers.NewValidator())
+			h.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedCode, rr.Code)
+			require.JSONEq(t, tt.expectedBody, rr.Body.String())
+
+			lister.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package task
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+type Unarchiver interface {
+	Unarchive(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+}
+
+type UnarchiveHandler struct {
+	unarchiver Unarchiver
+	timeout    time.Duration
+	logger     *slog.Logger
+	validate   *validator.Validate
+}
+
+func NewUnarchiveHandler(
+	unarchiver Unarchiver,
+	timeout time.Duration,
+	logger *slog.Logger,
+	validate *validator.Validate,
+) *UnarchiveHandler {
+	return &UnarchiveHandler{
+		unarchiver: unarchiver,
+		timeout:    timeout,
+		logger:     logger,
+		validate:   validate,
+	}
+}
+
+// @Summary Unarchive a task
+// @Description Takes a task of the authenticated user out of the archive
+// @Tags tasks
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param If-Match header string false "Expected task version (ETag)"
+// @Security     BearerAuth
+// @Success 204 {object} nil
+// @Header 204 {string} ETag "New task version"
+// @Failure 400 {object} handlers.Problem
+// @Failure 401 {object} handlers.Problem
+// @Failure 403 {object} handlers.Problem
+// @Failure 404 {object} handlers.Problem
+// @Failure 409 {object} handlers.Problem
+// @Failure 412 {object} handlers.Problem
+// @Failure 500 {object} handlers.Problem
+// @Router /tasks/{id}/unarchive [post]
+func (h *UnarchiveHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.Task.Unarchive"
+
+	logger := slogx.FromContext(r.Context(), h.logger).With(slog.String("op", op))
+
+	taskID := chi.URLParam(r, "id")
+	if taskID == "" {
+		logger.Error("task id is not provided")
+		handlers.WriteError(w, r, handlers.MissingParameter("task id"))
+		return
+	}
+
+	expectedVersion, err := handlers.ParseIfMatch(r)
+	if err != nil {
+		logger.Error("failed to parse If-Match header", slog.String("err", err.Error()))
+		handlers.WriteError(w, r, err)
+		return
+	}
+
+	userID := myMw.GetUserID(r.Context())
+	if userID == "" {
+		logger.Error("failed to extract owner id")
+		handlers.WriteError(w, r, handlers.ErrBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	version, err := h.unarchiver.Unarchive(ctx, taskID, userID, expectedVersion)
+	if err != nil {
+		logger.Error("failed to unarchive task", slog.String("err", err.Error()))
+		handlers.WriteError(w, r, handlers.PreconditionError(err, expectedVersion))
+		return
+	}
+
+	logger.Info("task unarchived")
+	w.Header().Set("ETag", handlers.FormatETag(version))
+	handlers.WriteJSON(w, http.StatusNoContent, nil)
+}
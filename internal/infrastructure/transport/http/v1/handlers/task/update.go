@@ -2,7 +2,6 @@ package task
 
 import (
 	"context"
-	"errors"
 	"log/slog"
 	"net/http"
 	"time"
@@ -10,12 +9,18 @@ import (
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
 	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
 	"github.com/cyberbrain-dev/taskery-api/internal/services"
-	"github.com/go-chi/chi/v5/middleware"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
 	"github.com/go-playground/validator/v10"
 )
 
 type Updater interface {
-	Update(ctx context.Context, id string, ownerID string, cmd services.UpdateTaskCommand) error
+	Update(
+		ctx context.Context,
+		id string,
+		ownerID string,
+		cmd services.UpdateTaskCommand,
+		expectedVersion *int64,
+	) (int64, error)
 }
 
 type UpdateHandler struct {
@@ -45,65 +50,58 @@ func NewUpdateHandler(
 // @Accept json
 // @Produce json
 // @Param request body UpdateRequest true "Task update request"
+// @Param If-Match header string false "Expected task version (ETag)"
 // @Security     BearerAuth
 // @Success 200 {object} UpdateResponse
-// @Failure 400 {object} handlers.ErrorResponse
-// @Failure 401 {object} handlers.ErrorResponse
-// @Failure 403 {object} handlers.ErrorResponse
-// @Failure 404 {object} handlers.ErrorResponse
-// @Failure 500 {object} handlers.ErrorResponse
+// @Header 200 {string} ETag "New task version"
+// @Failure 400 {object} handlers.Problem
+// @Failure 401 {object} handlers.Problem
+// @Failure 403 {object} handlers.Problem
+// @Failure 404 {object} handlers.Problem
+// @Failure 409 {object} handlers.Problem
+// @Failure 412 {object} handlers.Problem
+// @Failure 500 {object} handlers.Problem
 // @Router /tasks [patch]
 func (h *UpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	const op = "handlers.Task.Update"
 
-	logger := h.logger.With(
-		slog.String("op", op),
-		slog.String("request_id", middleware.GetReqID(r.Context())),
-	)
+	logger := slogx.FromContext(r.Context(), h.logger).With(slog.String("op", op))
 
 	req, ok := handlers.DecodeAndValidate[UpdateRequest](w, r, logger, h.validate)
 	if !ok {
 		return
 	}
 
+	expectedVersion, err := handlers.ParseIfMatch(r)
+	if err != nil {
+		logger.Error("failed to parse If-Match header", slog.String("err", err.Error()))
+		handlers.WriteError(w, r, err)
+		return
+	}
+
 	taskID := req.TaskID
 	ownerID := myMw.GetUserID(r.Context())
 	if ownerID == "" {
 		logger.Error("failed to extract owner id")
-		handlers.WriteError(w, http.StatusBadRequest, errors.New("bad request"))
+		handlers.WriteError(w, r, handlers.ErrBadRequest)
 		return
 	}
 
 	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
 	defer cancel()
 
-	err := h.updater.Update(ctx, taskID, ownerID, services.UpdateTaskCommand{
+	version, err := h.updater.Update(ctx, taskID, ownerID, services.UpdateTaskCommand{
 		Title:       req.Title,
 		Description: req.Description,
 		Deadline:    req.Deadline,
-	})
+	}, expectedVersion)
 	if err != nil {
 		logger.Error("failed to update task", slog.String("err", err.Error()))
-
-		if errors.Is(err, services.ErrTaskNotFound) {
-			handlers.WriteError(w, http.StatusNotFound, errors.New("task was not found"))
-			return
-		}
-
-		if errors.Is(err, services.ErrTaskAccessDenied) {
-			handlers.WriteError(w, http.StatusForbidden, errors.New("task access denied"))
-			return
-		}
-
-		if errors.Is(err, services.ErrTaskUpdateFailed) {
-			handlers.WriteError(w, http.StatusInternalServerError, errors.New("internal server error"))
-			return
-		}
-
-		handlers.WriteError(w, http.StatusBadRequest, err)
+		handlers.WriteError(w, r, handlers.PreconditionError(err, expectedVersion))
 		return
 	}
 
+	w.Header().Set("ETag", handlers.FormatETag(version))
 	handlers.WriteJSON(w, http.StatusOK, UpdateResponse{
 		TaskID: taskID,
 	})
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"io"
 	"log/slog"
 	"net/http"
@@ -13,11 +12,12 @@ import (
 	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/vo"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task"
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task/mocks"
 	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
 	"github.com/cyberbrain-dev/taskery-api/internal/services"
-	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -34,7 +34,9 @@ func TestUpdateHandler(t *testing.T) {
 		name         string
 		payload      task.UpdateRequest
 		expectedCode int
+		expectedETag string
 		expectedBody string
+		ifMatch      string
 		userID       string
 		mockSetup    func(updater *mocks.Updater)
 	}{
@@ -47,11 +49,12 @@ func TestUpdateHandler(t *testing.T) {
 				Deadline:    &validDeadline,
 			},
 			expectedCode: http.StatusOK,
+			expectedETag: `"2"`,
 			expectedBody: `{"task_id":"` + validTaskID + `"}`,
 			userID:       validUserID,
 			mockSetup: func(updater *mocks.Updater) {
-				updater.On("Update", mock.Anything, validTaskID, validUserID, mock.AnythingOfType("services.UpdateTaskCommand")).
-					Return(nil)
+				updater.On("Update", mock.Anything, validTaskID, validUserID, mock.AnythingOfType("services.UpdateTaskCommand"), (*int64)(nil)).
+					Return(int64(2), nil)
 			},
 		},
 		{
@@ -61,12 +64,13 @@ func TestUpdateHandler(t *testing.T) {
 				Title:  &validTitle,
 			},
 			expectedCode: http.StatusOK,
+			expectedETag: `"2"`,
 			expectedBody: `{"task_id":"` + validTaskID + `"}`,
 			userID:       validUserID,
 			mockSetup: func(updater *mocks.Updater) {
 				updater.On("Update", mock.Anything, validTaskID, validUserID, services.UpdateTaskCommand{
 					Title: &validTitle,
-				}).Return(nil)
+				}, (*int64)(nil)).Return(int64(2), nil)
 			},
 		},
 		{
@@ -78,11 +82,11 @@ func TestUpdateHandler(t *testing.T) {
 				Deadline:    &validDeadline,
 			},
 			expectedCode: http.StatusNotFound,
-			expectedBody: `{"error":"task was not found"}`,
+			expectedBody: `{"type":"/problems/task-not-found","title":"Not Found","status":404,"detail":"task not found","code":"TASK_NOT_FOUND"}`,
 			userID:       validUserID,
 			mockSetup: func(updater *mocks.Updater) {
-				updater.On("Update", mock.Anything, validTaskID, validUserID, mock.Anything).
-					Return(services.ErrTaskNotFound)
+				updater.On("Update", mock.Anything, validTaskID, validUserID, mock.Anything, (*int64)(nil)).
+					Return(int64(0), services.ErrTaskNotFound)
 			},
 		},
 		{
@@ -94,11 +98,11 @@ func TestUpdateHandler(t *testing.T) {
 				Deadline:    &validDeadline,
 			},
 			expectedCode: http.StatusForbidden,
-			expectedBody: `{"error":"task access denied"}`,
+			expectedBody: `{"type":"/problems/task-access-denied","title":"Forbidden","status":403,"detail":"access denied","code":"TASK_ACCESS_DENIED"}`,
 			userID:       validUserID,
 			mockSetup: func(updater *mocks.Updater) {
-				updater.On("Update", mock.Anything, validTaskID, validUserID, mock.Anything).
-					Return(services.ErrTaskAccessDenied)
+				updater.On("Update", mock.Anything, validTaskID, validUserID, mock.Anything, (*int64)(nil)).
+					Return(int64(0), services.ErrTaskAccessDenied)
 			},
 		},
 		{
@@ -110,11 +114,11 @@ func TestUpdateHandler(t *testing.T) {
 				Deadline:    &validDeadline,
 			},
 			expectedCode: http.StatusInternalServerError,
-			expectedBody: `{"error":"internal server error"}`,
+			expectedBody: `{"type":"/problems/internal-error","title":"Internal Server Error","status":500,"detail":"internal server error","code":"INTERNAL_ERROR"}`,
 			userID:       validUserID,
 			mockSetup: func(updater *mocks.Updater) {
-				updater.On("Update", mock.Anything, validTaskID, validUserID, mock.Anything).
-					Return(services.ErrTaskUpdateFailed)
+				updater.On("Update", mock.Anything, validTaskID, validUserID, mock.Anything, (*int64)(nil)).
+					Return(int64(0), services.ErrTaskUpdateFailed)
 			},
 		},
 		{
@@ -123,7 +127,7 @@ func TestUpdateHandler(t *testing.T) {
 				TaskID: "",
 			},
 			expectedCode: http.StatusBadRequest,
-			expectedBody: `{"errors":[{"field":"TaskID","error":"field is required"}]}`,
+			expectedBody: `{"type":"/problems/validation-failed","title":"Bad Request","status":400,"detail":"request body is invalid","code":"VALIDATION_FAILED","errors":[{"field":"task_id","rule":"required","error":"is required"}]}`,
 			userID:       validUserID,
 			mockSetup:    nil,
 		},
@@ -134,13 +138,70 @@ func TestUpdateHandler(t *testing.T) {
 				Title:  new(""),
 			},
 			expectedCode: http.StatusBadRequest,
-			expectedBody: `{"error":"title cannot be empty"}`,
+			expectedBody: `{"type":"/problems/title-empty","title":"Bad Request","status":400,"detail":"title is empty","code":"TITLE_EMPTY","errors":[{"field":"title","rule":"required","error":"is required"}]}`,
 			userID:       validUserID,
 			mockSetup: func(updater *mocks.Updater) {
-				updater.On("Update", mock.Anything, validTaskID, validUserID, mock.AnythingOfType("services.UpdateTaskCommand")).
-					Return(errors.New("title cannot be empty"))
+				updater.On("Update", mock.Anything, validTaskID, validUserID, mock.AnythingOfType("services.UpdateTaskCommand"), (*int64)(nil)).
+					Return(int64(0), vo.ErrTitleEmpty)
 			},
 		},
+		{
+			name: "successful update with If-Match",
+			payload: task.UpdateRequest{
+				TaskID: validTaskID,
+				Title:  &validTitle,
+			},
+			expectedCode: http.StatusOK,
+			expectedETag: `"4"`,
+			expectedBody: `{"task_id":"` + validTaskID + `"}`,
+			ifMatch:      `"3"`,
+			userID:       validUserID,
+			mockSetup: func(updater *mocks.Updater) {
+				updater.On("Update", mock.Anything, validTaskID, validUserID, mock.Anything, new(int64(3))).
+					Return(int64(4), nil)
+			},
+		},
+		{
+			name: "version mismatch",
+			payload: task.UpdateRequest{
+				TaskID: validTaskID,
+				Title:  &validTitle,
+			},
+			expectedCode: http.StatusPreconditionFailed,
+			expectedBody: `{"type":"/problems/task-version-mismatch","title":"Precondition Failed","status":412,"detail":"task version mismatch","code":"TASK_VERSION_MISMATCH"}`,
+			ifMatch:      `"3"`,
+			userID:       validUserID,
+			mockSetup: func(updater *mocks.Updater) {
+				updater.On("Update", mock.Anything, validTaskID, validUserID, mock.Anything, new(int64(3))).
+					Return(int64(0), services.ErrTaskConflict)
+			},
+		},
+		{
+			name: "concurrent modification",
+			payload: task.UpdateRequest{
+				TaskID: validTaskID,
+				Title:  &validTitle,
+			},
+			expectedCode: http.StatusConflict,
+			expectedBody: `{"type":"/problems/task-conflict","title":"Conflict","status":409,"detail":"task was modified concurrently","code":"TASK_CONFLICT"}`,
+			userID:       validUserID,
+			mockSetup: func(updater *mocks.Updater) {
+				updater.On("Update", mock.Anything, validTaskID, validUserID, mock.Anything, (*int64)(nil)).
+					Return(int64(0), services.ErrTaskConflict)
+			},
+		},
+		{
+			name: "invalid If-Match header",
+			payload: task.UpdateRequest{
+				TaskID: validTaskID,
+				Title:  &validTitle,
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/invalid-if-match","title":"Bad Request","status":400,"detail":"invalid If-Match header","code":"INVALID_IF_MATCH"}`,
+			ifMatch:      `W/"3"`,
+			userID:       validUserID,
+			mockSetup:    nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -155,6 +216,9 @@ func TestUpdateHandler(t *testing.T) {
 				bytes.NewBuffer(body),
 			)
 			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
 
 			rr := httptest.NewRecorder()
 
@@ -165,10 +229,11 @@ func TestUpdateHandler(t *testing.T) {
 
 			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
 
-			h := task.NewUpdateHandler(updater, 4*time.Second, logger, validator.New())
+			h := task.NewUpdateHandler(updater, 4*time.Second, logger, handlers.NewValidator())
 			h.ServeHTTP(rr, req)
 
 			require.Equal(t, tt.expectedCode, rr.Code)
+			require.Equal(t, tt.expectedETag, rr.Header().Get("ETag"))
 
 			if tt.expectedBody != "" {
 				require.Equal(t, tt.expectedBody, rr.Body.String())
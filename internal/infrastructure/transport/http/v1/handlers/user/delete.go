@@ -2,15 +2,13 @@ package user
 
 import (
 	"context"
-	"errors"
 	"log/slog"
 	"net/http"
 	"time"
 
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
 	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
-	"github.com/cyberbrain-dev/taskery-api/internal/services"
-	"github.com/go-chi/chi/v5/middleware"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -47,18 +45,15 @@ func NewDeleteHandler(
 // @Param request body DeleteRequest true "User delete request"
 // @Security BearerAuth
 // @Success 204 {object} nil
-// @Failure 400 {object} handlers.ErrorResponse
-// @Failure 401 {object} handlers.ErrorResponse
-// @Failure 404 {object} handlers.ErrorResponse
-// @Failure 500 {object} handlers.ErrorResponse
+// @Failure 400 {object} handlers.Problem
+// @Failure 401 {object} handlers.Problem
+// @Failure 404 {object} handlers.Problem
+// @Failure 500 {object} handlers.Problem
 // @Router /user [delete]
 func (h *DeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	const op = "handlers.User.Delete"
 
-	logger := h.logger.With(
-		slog.String("op", op),
-		slog.String("request_id", middleware.GetReqID(r.Context())),
-	)
+	logger := slogx.FromContext(r.Context(), h.logger).With(slog.String("op", op))
 
 	req, ok := handlers.DecodeAndValidate[DeleteRequest](w, r, logger, h.validate)
 	if !ok {
@@ -68,7 +63,7 @@ func (h *DeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	userID := myMw.GetUserID(r.Context())
 	if userID == "" {
 		logger.Error("failed to extract owner id")
-		handlers.WriteError(w, http.StatusBadRequest, errors.New("bad request"))
+		handlers.WriteError(w, r, handlers.ErrBadRequest)
 		return
 	}
 
@@ -81,18 +76,7 @@ func (h *DeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			slog.String("error", err.Error()),
 			slog.String("user_id", userID),
 		)
-
-		if errors.Is(err, services.ErrUserNotFound) {
-			handlers.WriteError(w, http.StatusNotFound, errors.New("user not found"))
-			return
-		}
-
-		if errors.Is(err, services.ErrUserUnauthorized) {
-			handlers.WriteError(w, http.StatusUnauthorized, errors.New("unauthorized"))
-			return
-		}
-
-		handlers.WriteError(w, http.StatusInternalServerError, errors.New("internal server error"))
+		handlers.WriteError(w, r, err)
 		return
 	}
 
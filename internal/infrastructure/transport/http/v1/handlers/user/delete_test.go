@@ -12,11 +12,11 @@ import (
 	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/user"
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/user/mocks"
 	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
 	"github.com/cyberbrain-dev/taskery-api/internal/services"
-	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -61,7 +61,7 @@ func TestDeleteHandler(t *testing.T) {
 			},
 
 			expectedCode: http.StatusNotFound,
-			expectedBody: `{"error":"user not found"}`,
+			expectedBody: `{"type":"/problems/user-not-found","title":"Not Found","status":404,"detail":"user not found","code":"USER_NOT_FOUND"}`,
 
 			mockSetup: func(d *mocks.Deleter) {
 				d.On("Delete", mock.Anything, mock.AnythingOfType("string"), correctPassword).
@@ -77,7 +77,7 @@ func TestDeleteHandler(t *testing.T) {
 			},
 
 			expectedCode: http.StatusUnauthorized,
-			expectedBody: `{"error":"unauthorized"}`,
+			expectedBody: `{"type":"/problems/unauthorized","title":"Unauthorized","status":401,"detail":"unauthorized","code":"UNAUTHORIZED"}`,
 
 			mockSetup: func(d *mocks.Deleter) {
 				d.On("Delete", mock.Anything, correctUserID, mock.AnythingOfType("string")).
@@ -93,7 +93,7 @@ func TestDeleteHandler(t *testing.T) {
 			},
 
 			expectedCode: http.StatusInternalServerError,
-			expectedBody: `{"error":"internal server error"}`,
+			expectedBody: `{"type":"/problems/internal-error","title":"Internal Server Error","status":500,"detail":"internal server error","code":"INTERNAL_ERROR"}`,
 
 			mockSetup: func(d *mocks.Deleter) {
 				d.On("Delete", mock.Anything, correctUserID, correctPassword).
@@ -119,7 +119,7 @@ func TestDeleteHandler(t *testing.T) {
 
 			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
 
-			h := user.NewDeleteHandler(deleter, 4*time.Second, logger, validator.New())
+			h := user.NewDeleteHandler(deleter, 4*time.Second, logger, handlers.NewValidator())
 			h.ServeHTTP(rr, req)
 
 			require.Equal(t, tt.expectedCode, rr.Code)
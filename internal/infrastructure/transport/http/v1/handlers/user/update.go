@@ -2,19 +2,22 @@ package user
 
 import (
 	"context"
-	"errors"
 	"log/slog"
 	"net/http"
 	"time"
 
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
 	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
-	"github.com/cyberbrain-dev/taskery-api/internal/services"
-	"github.com/cyberbrain-dev/taskery-api/pkg/errorsx"
-	"github.com/go-chi/chi/v5/middleware"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
 	"github.com/go-playground/validator/v10"
 )
 
+var errUsernameOrEmailRequired = handlers.NewError(
+	http.StatusBadRequest,
+	"MISSING_PARAMETER",
+	"username or email required",
+)
+
 type Updater interface {
 	ChangeUsername(ctx context.Context, id, newUsername, password string) error
 	ChangeEmail(ctx context.Context, id, newEmail, password string) error
@@ -49,19 +52,17 @@ func NewUpdateHandler(
 // @Param request body UpdateRequest true "User update request"
 // @Security BearerAuth
 // @Success 200 {object} UpdateResponse
-// @Failure 400 {object} handlers.ErrorResponse
-// @Failure 401 {object} handlers.ErrorResponse
-// @Failure 403 {object} handlers.ErrorResponse
-// @Failure 404 {object} handlers.ErrorResponse
-// @Failure 500 {object} handlers.ErrorResponse
+// @Failure 400 {object} handlers.Problem
+// @Failure 401 {object} handlers.Problem
+// @Failure 403 {object} handlers.Problem
+// @Failure 404 {object} handlers.Problem
+// @Failure 409 {object} handlers.Problem
+// @Failure 500 {object} handlers.Problem
 // @Router /user [patch]
 func (h *UpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	const op = "handlers.User.Update"
 
-	logger := h.logger.With(
-		slog.String("op", op),
-		slog.String("request_id", middleware.GetReqID(r.Context())),
-	)
+	logger := slogx.FromContext(r.Context(), h.logger).With(slog.String("op", op))
 
 	req, ok := handlers.DecodeAndValidate[UpdateRequest](w, r, logger, h.validate)
 	if !ok {
@@ -69,7 +70,7 @@ func (h *UpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if req.Username == "" && req.Email == "" {
-		handlers.WriteError(w, http.StatusBadRequest, errors.New("username or email required"))
+		handlers.WriteError(w, r, errUsernameOrEmailRequired)
 		return
 	}
 
@@ -79,7 +80,7 @@ func (h *UpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	userID := myMw.GetUserID(r.Context())
 	if userID == "" {
 		logger.Error("failed to extract owner id")
-		handlers.WriteError(w, http.StatusBadRequest, errors.New("bad request"))
+		handlers.WriteError(w, r, handlers.ErrBadRequest)
 		return
 	}
 
@@ -87,23 +88,7 @@ func (h *UpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		err := h.updater.ChangeUsername(ctx, userID, req.Username, req.Password)
 		if err != nil {
 			logger.Error("failed to change username", slog.String("error", err.Error()))
-
-			if errorsx.IsAny(err, services.ErrUserNotFound) {
-				handlers.WriteError(w, http.StatusNotFound, errors.New("user not found"))
-				return
-			}
-
-			if errorsx.IsAny(err, services.ErrUserUnauthorized) {
-				handlers.WriteError(w, http.StatusUnauthorized, errors.New("unauthorized"))
-				return
-			}
-
-			if errorsx.IsAny(err, services.ErrUserChangeUsernameFailed) {
-				handlers.WriteError(w, http.StatusInternalServerError, errors.New("username change failed"))
-				return
-			}
-
-			handlers.WriteError(w, http.StatusBadRequest, err)
+			handlers.WriteError(w, r, err)
 			return
 		}
 	}
@@ -112,28 +97,7 @@ func (h *UpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		err := h.updater.ChangeEmail(ctx, userID, req.Email, req.Password)
 		if err != nil {
 			logger.Error("failed to change email", slog.String("error", err.Error()))
-
-			if errorsx.IsAny(err, services.ErrUserNotFound) {
-				handlers.WriteError(w, http.StatusNotFound, errors.New("user not found"))
-				return
-			}
-
-			if errorsx.IsAny(err, services.ErrUserUnauthorized) {
-				handlers.WriteError(w, http.StatusUnauthorized, errors.New("unauthorized"))
-				return
-			}
-
-			if errorsx.IsAny(err, services.ErrUserEmailAlreadyTaken) {
-				handlers.WriteError(w, http.StatusConflict, errors.New("email already taken"))
-				return
-			}
-
-			if errorsx.IsAny(err, services.ErrUserChangeEmailFailed) {
-				handlers.WriteError(w, http.StatusInternalServerError, errors.New("email change failed"))
-				return
-			}
-
-			handlers.WriteError(w, http.StatusBadRequest, err)
+			handlers.WriteError(w, r, err)
 			return
 		}
 	}
@@ -12,11 +12,11 @@ import (
 	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/user"
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/user/mocks"
 	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
 	"github.com/cyberbrain-dev/taskery-api/internal/services"
-	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -84,7 +84,7 @@ func TestUpdateHandler(t *testing.T) {
 				Password: correctPassword,
 			},
 			expectedCode: http.StatusNotFound,
-			expectedBody: `{"error":"user not found"}`,
+			expectedBody: `{"type":"/problems/user-not-found","title":"Not Found","status":404,"detail":"user not found","code":"USER_NOT_FOUND"}`,
 			mockSetup: func(u *mocks.Updater) {
 				u.On("ChangeUsername", mock.Anything, userID, correctUsername, correctPassword).
 					Return(services.ErrUserNotFound)
@@ -97,7 +97,7 @@ func TestUpdateHandler(t *testing.T) {
 				Password: correctPassword,
 			},
 			expectedCode: http.StatusConflict,
-			expectedBody: `{"error":"email already taken"}`,
+			expectedBody: `{"type":"/problems/email-already-taken","title":"Conflict","status":409,"detail":"email already taken","code":"EMAIL_ALREADY_TAKEN"}`,
 			mockSetup: func(u *mocks.Updater) {
 				u.On("ChangeEmail", mock.Anything, userID, correctEmail, correctPassword).
 					Return(services.ErrUserEmailAlreadyTaken)
@@ -122,7 +122,7 @@ func TestUpdateHandler(t *testing.T) {
 
 			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
 
-			h := user.NewUpdateHandler(updater, 4*time.Second, logger, validator.New())
+			h := user.NewUpdateHandler(updater, 4*time.Second, logger, handlers.NewValidator())
 			h.ServeHTTP(rr, req)
 
 			require.Equal(t, tt.expectedCode, rr.Code)
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// NewValidator returns a validator of the request bodies that reports
+// the invalid fields by their JSON names.
+func NewValidator() *validator.Validate {
+	validate := validator.New()
+
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+
+		return name
+	})
+
+	return validate
+}
+
+// validationViolations converts the validation errors into the violated rules.
+func validationViolations(errs validator.ValidationErrors) []violation {
+	violations := make([]violation, 0, len(errs))
+
+	for _, err := range errs {
+		violations = append(violations, violation{
+			field:  fieldPath(err),
+			rule:   err.ActualTag(),
+			kind:   kindOf(err.Kind()),
+			params: ruleParams(err.ActualTag(), err.Param()),
+		})
+	}
+
+	return violations
+}
+
+// fieldPath returns the path of the invalid field without the name of the request struct,
+// e.g. "operations[0].op" for a field of a nested struct.
+func fieldPath(err validator.FieldError) string {
+	_, path, ok := strings.Cut(err.Namespace(), ".")
+	if !ok {
+		return err.Field()
+	}
+
+	return path
+}
+
+// ruleParams returns the parameters of a validation rule as they are reported to the client.
+func ruleParams(tag, param string) map[string]any {
+	if param == "" {
+		return nil
+	}
+
+	switch tag {
+	case "oneof":
+		return map[string]any{"values": strings.Fields(param)}
+
+	case "required_if", "required_unless":
+		// the parameters refer to the Go fields of the request
+		return nil
+
+	case "min", "max", "len":
+		if n, err := strconv.Atoi(param); err == nil {
+			return map[string]any{tag: n}
+		}
+
+		return map[string]any{tag: param}
+
+	default:
+		return map[string]any{"param": param}
+	}
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+)
+
+// RequestBody returns a middleware that limits the size of the request bodies
+// to opts.MaxBodySize with http.MaxBytesReader and passes opts to handlers.DecodeAndValidate
+// through the request context.
+//
+// Reading past the limit fails with *http.MaxBytesError, which DecodeAndValidate reports
+// as 413 Request Entity Too Large. The middleware must precede every middleware
+// that reads the body, such as Idempotency.
+func RequestBody(opts handlers.DecodeOptions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if opts.MaxBodySize > 0 {
+				r.Body = http.MaxBytesReader(w, r.Body, opts.MaxBodySize)
+			}
+
+			next.ServeHTTP(w, r.WithContext(handlers.WithDecodeOptions(r.Context(), opts)))
+		})
+	}
+}
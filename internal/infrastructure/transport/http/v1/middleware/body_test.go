@@ -0,0 +1,88 @@
+package middleware_test
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestBody(t *testing.T) {
+	type request struct {
+		Title string `json:"title"`
+	}
+
+	tests := []struct {
+		name        string
+		opts        handlers.DecodeOptions
+		contentType string
+		body        string
+		wantStatus  int
+	}{
+		{
+			name:        "body within the limit",
+			opts:        handlers.DecodeOptions{MaxBodySize: 64, Strict: true},
+			contentType: "application/json",
+			body:        `{"title":"Buy milk"}`,
+			wantStatus:  http.StatusCreated,
+		},
+		{
+			name:        "body over the limit",
+			opts:        handlers.DecodeOptions{MaxBodySize: 8, Strict: true},
+			contentType: "application/json",
+			body:        `{"title":"Buy milk"}`,
+			wantStatus:  http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:        "no limit",
+			contentType: "application/json",
+			body:        `{"title":"` + strings.Repeat("a", 1<<16) + `"}`,
+			wantStatus:  http.StatusCreated,
+		},
+		{
+			name:        "strict decoding",
+			opts:        handlers.DecodeOptions{Strict: true},
+			contentType: "application/json",
+			body:        `{"title":"Buy milk","color":"red"}`,
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:       "strict content type",
+			opts:       handlers.DecodeOptions{Strict: true},
+			body:       `{"title":"Buy milk"}`,
+			wantStatus: http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+			validate := handlers.NewValidator()
+
+			r := chi.NewRouter()
+			r.Use(myMw.RequestBody(tt.opts))
+			r.Post("/tasks", func(w http.ResponseWriter, r *http.Request) {
+				if _, ok := handlers.DecodeAndValidate[request](w, r, logger, validate); ok {
+					w.WriteHeader(http.StatusCreated)
+				}
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/clientip"
+)
+
+type ctxKeyClientIP struct{}
+
+// ClientIP returns a middleware that resolves the IP address of the client with resolver
+// and keeps it in the request context for the access log, the rate limits and the idempotency keys.
+// The X-Forwarded-For header is taken into account only if the request comes from a trusted proxy.
+//
+// The middleware must be used on the root router before RequestLogger.
+// Without it the address of the peer of the request is used.
+func ClientIP(resolver *clientip.Resolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := resolver.ClientIP(r.RemoteAddr, r.Header.Values(clientip.ForwardedForHeader))
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), ctxKeyClientIP{}, ip)))
+		})
+	}
+}
+
+// remoteIP returns the IP address of the client without the port,
+// as it is resolved by ClientIP, or the address of the peer of r.
+func remoteIP(r *http.Request) string {
+	if ip, ok := r.Context().Value(ctxKeyClientIP{}).(string); ok {
+		return ip
+	}
+
+	return new(clientip.Resolver).ClientIP(r.RemoteAddr, nil)
+}
@@ -0,0 +1,148 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSOptions configures the cross-origin requests allowed by CORS.
+type CORSOptions struct {
+	// AllowedOrigins are the origins that may call the API, e.g. "https://app.taskery.dev".
+	// "*" allows any origin. No origins turn CORS off.
+	AllowedOrigins []string
+
+	// AllowedMethods and AllowedHeaders are allowed in the cross-origin requests.
+	// The simple headers, such as Accept, are always allowed by the browsers.
+	AllowedMethods []string
+	AllowedHeaders []string
+
+	// ExposedHeaders are the response headers that the browser scripts may read.
+	ExposedHeaders []string
+
+	// AllowCredentials allows the requests with cookies and the Authorization header
+	// set by the browser. It can not be combined with "*" in AllowedOrigins,
+	// since it would let any website make credentialed calls to the API.
+	AllowCredentials bool
+
+	// MaxAge is the time for which the result of a preflight request is cached by the browser.
+	MaxAge time.Duration
+}
+
+// CORS returns a middleware that handles cross-origin requests as described in the Fetch standard.
+//
+// A preflight request, i.e. an OPTIONS request with the Access-Control-Request-Method header,
+// is answered with 204 No Content without calling the next handler. The preflight headers
+// are set only if the origin, the method and the headers of the actual request are allowed,
+// otherwise the browser rejects the actual request. An actual request from an allowed origin
+// gets the Access-Control-Allow-Origin header and is passed to the next handler.
+//
+// The middleware must be used on the root router, so that the preflight requests
+// to the routes without an OPTIONS handler are answered too.
+//
+// The options are expected to be validated by the configuration,
+// which does not allow any origin together with the credentials.
+func CORS(opts CORSOptions) func(http.Handler) http.Handler {
+	anyOrigin := slices.Contains(opts.AllowedOrigins, "*")
+
+	allowedMethods := make([]string, 0, len(opts.AllowedMethods))
+	for _, m := range opts.AllowedMethods {
+		allowedMethods = append(allowedMethods, strings.ToUpper(m))
+	}
+
+	allowedHeaders := make([]string, 0, len(opts.AllowedHeaders))
+	for _, h := range opts.AllowedHeaders {
+		allowedHeaders = append(allowedHeaders, http.CanonicalHeaderKey(h))
+	}
+
+	originAllowed := func(origin string) bool {
+		return anyOrigin || slices.Contains(opts.AllowedOrigins, origin)
+	}
+
+	allowOrigin := func(h http.Header, origin string) {
+		if anyOrigin {
+			h.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			h.Set("Access-Control-Allow-Origin", origin)
+		}
+
+		if opts.AllowCredentials {
+			h.Set("Access-Control-Allow-Credentials", "true")
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		if len(opts.AllowedOrigins) == 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+			if preflight {
+				h := w.Header()
+				h.Add("Vary", "Origin")
+				h.Add("Vary", "Access-Control-Request-Method")
+				h.Add("Vary", "Access-Control-Request-Headers")
+
+				method := strings.ToUpper(r.Header.Get("Access-Control-Request-Method"))
+				headers := requestedHeaders(r.Header.Get("Access-Control-Request-Headers"))
+
+				if origin != "" &&
+					originAllowed(origin) &&
+					slices.Contains(allowedMethods, method) &&
+					allContained(headers, allowedHeaders) {
+
+					allowOrigin(h, origin)
+					h.Set("Access-Control-Allow-Methods", strings.Join(allowedMethods, ", "))
+					if len(headers) > 0 {
+						h.Set("Access-Control-Allow-Headers", strings.Join(headers, ", "))
+					}
+					if opts.MaxAge > 0 {
+						h.Set("Access-Control-Max-Age", strconv.Itoa(int(opts.MaxAge.Seconds())))
+					}
+				}
+
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			w.Header().Add("Vary", "Origin")
+
+			if origin != "" && originAllowed(origin) {
+				allowOrigin(w.Header(), origin)
+				if len(opts.ExposedHeaders) > 0 {
+					w.Header().Set("Access-Control-Expose-Headers", strings.Join(opts.ExposedHeaders, ", "))
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// requestedHeaders parses the Access-Control-Request-Headers header into canonical header names.
+func requestedHeaders(value string) []string {
+	var headers []string
+
+	for h := range strings.SplitSeq(value, ",") {
+		if h = strings.TrimSpace(h); h != "" {
+			headers = append(headers, http.CanonicalHeaderKey(h))
+		}
+	}
+
+	return headers
+}
+
+func allContained(values, allowed []string) bool {
+	for _, v := range values {
+		if !slices.Contains(allowed, v) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,178 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCORS(t *testing.T) {
+	opts := myMw.CORSOptions{
+		AllowedOrigins: []string{"https://app.taskery.dev"},
+		AllowedMethods: []string{"GET", "POST", "PATCH", "DELETE"},
+		AllowedHeaders: []string{"Authorization", "Content-Type", "If-Match"},
+		ExposedHeaders: []string{"ETag"},
+		MaxAge:         10 * time.Minute,
+	}
+
+	tests := []struct {
+		name           string
+		opts           myMw.CORSOptions
+		method         string
+		header         map[string]string
+		wantStatus     int
+		wantHeader     map[string]string
+		wantNoHeaders  []string
+		wantNextCalled bool
+	}{
+		{
+			name:   "preflight of an allowed request",
+			opts:   opts,
+			method: http.MethodOptions,
+			header: map[string]string{
+				"Origin":                         "https://app.taskery.dev",
+				"Access-Control-Request-Method":  "PATCH",
+				"Access-Control-Request-Headers": "authorization, content-type, if-match",
+			},
+			wantStatus: http.StatusNoContent,
+			wantHeader: map[string]string{
+				"Access-Control-Allow-Origin":  "https://app.taskery.dev",
+				"Access-Control-Allow-Methods": "GET, POST, PATCH, DELETE",
+				"Access-Control-Allow-Headers": "Authorization, Content-Type, If-Match",
+				"Access-Control-Max-Age":       "600",
+			},
+			wantNoHeaders: []string{"Access-Control-Allow-Credentials"},
+		},
+		{
+			name:   "preflight from an unknown origin",
+			opts:   opts,
+			method: http.MethodOptions,
+			header: map[string]string{
+				"Origin":                        "https://evil.example",
+				"Access-Control-Request-Method": "DELETE",
+			},
+			wantStatus:    http.StatusNoContent,
+			wantNoHeaders: []string{"Access-Control-Allow-Origin", "Access-Control-Allow-Methods"},
+		},
+		{
+			name:   "preflight of a method that is not allowed",
+			opts:   opts,
+			method: http.MethodOptions,
+			header: map[string]string{
+				"Origin":                        "https://app.taskery.dev",
+				"Access-Control-Request-Method": "PUT",
+			},
+			wantStatus:    http.StatusNoContent,
+			wantNoHeaders: []string{"Access-Control-Allow-Origin"},
+		},
+		{
+			name:   "preflight of a header that is not allowed",
+			opts:   opts,
+			method: http.MethodOptions,
+			header: map[string]string{
+				"Origin":                         "https://app.taskery.dev",
+				"Access-Control-Request-Method":  "POST",
+				"Access-Control-Request-Headers": "X-Custom",
+			},
+			wantStatus:    http.StatusNoContent,
+			wantNoHeaders: []string{"Access-Control-Allow-Origin"},
+		},
+		{
+			name:       "actual request from an allowed origin",
+			opts:       opts,
+			method:     http.MethodDelete,
+			header:     map[string]string{"Origin": "https://app.taskery.dev"},
+			wantStatus: http.StatusOK,
+			wantHeader: map[string]string{
+				"Access-Control-Allow-Origin":   "https://app.taskery.dev",
+				"Access-Control-Expose-Headers": "ETag",
+				"Vary":                          "Origin",
+			},
+			wantNextCalled: true,
+		},
+		{
+			name:           "actual request from an unknown origin",
+			opts:           opts,
+			method:         http.MethodGet,
+			header:         map[string]string{"Origin": "https://evil.example"},
+			wantStatus:     http.StatusOK,
+			wantNoHeaders:  []string{"Access-Control-Allow-Origin"},
+			wantNextCalled: true,
+		},
+		{
+			name: "allowed origin with credentials",
+			opts: myMw.CORSOptions{
+				AllowedOrigins:   []string{"https://app.taskery.dev"},
+				AllowedMethods:   []string{"GET"},
+				AllowCredentials: true,
+			},
+			method:     http.MethodGet,
+			header:     map[string]string{"Origin": "https://app.taskery.dev"},
+			wantStatus: http.StatusOK,
+			wantHeader: map[string]string{
+				"Access-Control-Allow-Origin":      "https://app.taskery.dev",
+				"Access-Control-Allow-Credentials": "true",
+			},
+			wantNextCalled: true,
+		},
+		{
+			name: "any origin without credentials",
+			opts: myMw.CORSOptions{
+				AllowedOrigins: []string{"*"},
+				AllowedMethods: []string{"GET"},
+			},
+			method:         http.MethodGet,
+			header:         map[string]string{"Origin": "https://app.taskery.dev"},
+			wantStatus:     http.StatusOK,
+			wantHeader:     map[string]string{"Access-Control-Allow-Origin": "*"},
+			wantNextCalled: true,
+		},
+		{
+			name:           "no allowed origins",
+			opts:           myMw.CORSOptions{},
+			method:         http.MethodGet,
+			header:         map[string]string{"Origin": "https://app.taskery.dev"},
+			wantStatus:     http.StatusOK,
+			wantNoHeaders:  []string{"Access-Control-Allow-Origin", "Vary"},
+			wantNextCalled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nextCalled := false
+
+			r := chi.NewRouter()
+			r.Use(myMw.CORS(tt.opts))
+			r.Get("/api/v1/tasks/{id}", func(w http.ResponseWriter, r *http.Request) {
+				nextCalled = true
+			})
+			r.Delete("/api/v1/tasks/{id}", func(w http.ResponseWriter, r *http.Request) {
+				nextCalled = true
+			})
+
+			req := httptest.NewRequest(tt.method, "/api/v1/tasks/42", nil)
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code)
+			require.Equal(t, tt.wantNextCalled, nextCalled)
+
+			for k, v := range tt.wantHeader {
+				require.Equal(t, v, rr.Header().Get(k), k)
+			}
+			for _, k := range tt.wantNoHeaders {
+				require.Empty(t, rr.Header().Get(k), k)
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/idempotency"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header that carries the idempotency key.
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// IdempotentReplayedHeader is set to "true" in a response that was replayed from the store.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	// IdempotencyKeyMaxLength is the maximum length of an idempotency key.
+	IdempotencyKeyMaxLength = 255
+)
+
+var (
+	errIdempotencyKeyInvalid = handlers.NewError(
+		http.StatusBadRequest,
+		"IDEMPOTENCY_KEY_INVALID",
+		"invalid Idempotency-Key header",
+	)
+	errIdempotencyKeyReused = handlers.NewError(
+		http.StatusUnprocessableEntity,
+		"IDEMPOTENCY_KEY_REUSED",
+		"idempotency key was already used with a different request",
+	)
+	errIdempotencyKeyInProgress = handlers.NewError(
+		http.StatusConflict,
+		"IDEMPOTENCY_KEY_IN_PROGRESS",
+		"request with this idempotency key is still being processed",
+	)
+	errRequestBodyUnreadable = handlers.NewError(
+		http.StatusBadRequest,
+		"REQUEST_BODY_UNREADABLE",
+		"failed to read request body",
+	)
+)
+
+// Idempotency returns a middleware that makes requests with the Idempotency-Key header
+// safe to retry. Requests without the header are passed to the next handler as is.
+//
+// The first request with a key is processed and its response is saved in store
+// for ttl. A repeated request with the same key gets the saved response with the
+// Idempotent-Replayed header instead of being processed again. The keys are scoped
+// by the user ID from the request context, so the middleware must follow JWTAuth
+// on the protected routes. The keys of anonymous requests are scoped by the client IP.
+//
+// The middleware responds with 422 Unprocessable Entity if the key was used
+// with a different method, path or body and with 409 Conflict if the first request
+// with the key is still being processed. A body larger than the limit set by RequestBody
+// gets 413 Request Entity Too Large. A 5xx response is not saved, so that the request can be retried.
+func Idempotency(store idempotency.Store, ttl time.Duration, clk clock.Clock, logger *slog.Logger) func(http.Handler) http.Handler {
+	return newIdempotency(store, ttl, clk, logger, false)
+}
+
+// IdempotencyKeyOnly returns a middleware that works like Idempotency, except that
+// it saves only the status code of the response and processes a repeated request
+// with the same key again instead of replaying the response. It is meant for the requests
+// whose responses must not be stored, such as a login that issues tokens, while a key
+// reused with a different request or a retry that races the first request are still rejected.
+func IdempotencyKeyOnly(store idempotency.Store, ttl time.Duration, clk clock.Clock, logger *slog.Logger) func(http.Handler) http.Handler {
+	return newIdempotency(store, ttl, clk, logger, true)
+}
+
+// newIdempotency returns the middleware of Idempotency, or of IdempotencyKeyOnly if keyOnly is true.
+func newIdempotency(
+	store idempotency.Store,
+	ttl time.Duration,
+	clk clock.Clock,
+	logger *slog.Logger,
+	keyOnly bool,
+) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			const op = "middleware.Idempotency"
+
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			logger := slogx.FromContext(r.Context(), logger).With(slog.String("op", op))
+
+			if !validIdempotencyKey(key) {
+				logger.Info("invalid idempotency key")
+				handlers.WriteError(w, r, errIdempotencyKeyInvalid)
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if _, ok := errors.AsType[*http.MaxBytesError](err); ok {
+				logger.Info("request body is too large")
+				handlers.WriteError(w, r, handlers.ErrRequestBodyTooLarge)
+				return
+			}
+			if err != nil {
+				logger.Error("failed to read request body", slog.String("err", err.Error()))
+				handlers.WriteError(w, r, errRequestBodyUnreadable)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			storeKey := idempotencyScope(r) + ":" + key
+			fingerprint := requestFingerprint(r, body)
+			now := clk.Now()
+
+			record, err := store.Reserve(r.Context(), storeKey, fingerprint, now, now.Add(ttl))
+			if err != nil {
+				logger.Error("failed to reserve idempotency key", slog.String("err", err.Error()))
+				handlers.WriteError(w, r, handlers.ErrInternal)
+				return
+			}
+
+			if record != nil {
+				switch {
+				case record.Fingerprint != fingerprint:
+					logger.Info("idempotency key reused with a different request")
+					handlers.WriteError(w, r, errIdempotencyKeyReused)
+
+				case record.Response == nil:
+					logger.Info("request with the idempotency key is in progress")
+					handlers.WriteError(w, r, errIdempotencyKeyInProgress)
+
+				case keyOnly:
+					logger.Debug("processing repeated request")
+					next.ServeHTTP(w, r)
+
+				default:
+					logger.Debug("replaying stored response")
+					replay(w, record.Response)
+				}
+
+				return
+			}
+
+			// the outcome is saved even if the client has gone away, since it is going to retry
+			ctx := context.WithoutCancel(r.Context())
+
+			defer func() {
+				if p := recover(); p != nil {
+					if err := store.Release(ctx, storeKey); err != nil {
+						logger.Error("failed to release idempotency key", slog.String("err", err.Error()))
+					}
+
+					panic(p)
+				}
+			}()
+
+			rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			if rec.status >= http.StatusInternalServerError {
+				if err := store.Release(ctx, storeKey); err != nil {
+					logger.Error("failed to release idempotency key", slog.String("err", err.Error()))
+				}
+
+				return
+			}
+
+			response := idempotency.Response{StatusCode: rec.status}
+			if !keyOnly {
+				response.Header = w.Header().Clone()
+				response.Body = rec.body.Bytes()
+			}
+
+			if err := store.Complete(ctx, storeKey, response); err != nil {
+				logger.Error("failed to save idempotent response", slog.String("err", err.Error()))
+			}
+		})
+	}
+}
+
+// validIdempotencyKey reports whether the key is not too long and consists of printable ASCII characters.
+func validIdempotencyKey(key string) bool {
+	if len(key) > IdempotencyKeyMaxLength {
+		return false
+	}
+
+	for i := 0; i < len(key); i++ {
+		if key[i] < ' ' || key[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// idempotencyScope returns the scope of the idempotency keys of the request:
+// the ID of the user, or the client IP if the request is anonymous.
+func idempotencyScope(r *http.Request) string {
+	if userID := GetUserID(r.Context()); userID != "" {
+		return userID
+	}
+
+	return "ip:" + remoteIP(r)
+}
+
+// requestFingerprint returns a hash of the method, the path and the body of the request.
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+
+	h.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// replay writes the stored response to w.
+func replay(w http.ResponseWriter, response *idempotency.Response) {
+	for name, values := range response.Header {
+		w.Header()[name] = values
+	}
+
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(response.StatusCode)
+	_, _ = w.Write(response.Body)
+}
+
+// responseRecorder passes the response through to the underlying writer
+// and keeps its status code and body.
+type responseRecorder struct {
+	http.ResponseWriter
+
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (rec *responseRecorder) WriteHeader(code int) {
+	if !rec.wroteHeader {
+		rec.status = code
+		rec.wroteHeader = true
+	}
+
+	rec.ResponseWriter.WriteHeader(code)
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	rec.wroteHeader = true
+	rec.body.Write(b)
+
+	return rec.ResponseWriter.Write(b)
+}
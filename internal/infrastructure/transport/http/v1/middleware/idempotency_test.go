@@ -0,0 +1,410 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/idempotency"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
+	"github.com/stretchr/testify/require"
+)
+
+// countingHandler echoes the request body with the given status and counts the calls.
+type countingHandler struct {
+	status int
+	calls  int
+}
+
+func (h *countingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.calls++
+
+	body, _ := io.ReadAll(r.Body)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(h.status)
+	_, _ = w.Write(body)
+}
+
+// failingStore is an idempotency.Store that fails to reserve keys.
+type failingStore struct {
+	idempotency.Store
+}
+
+func (failingStore) Reserve(context.Context, string, string, time.Time, time.Time) (*idempotency.Record, error) {
+	return nil, errors.New("failed to connect to db")
+}
+
+func TestIdempotency(t *testing.T) {
+	type request struct {
+		userID     string
+		remoteAddr string
+		key        string
+		path       string
+		body       string
+
+		wantCode     int
+		wantBody     string
+		wantProblem  bool
+		wantReplayed bool
+	}
+
+	tests := []struct {
+		name       string
+		status     int
+		store      idempotency.Store
+		keyOnly    bool
+		requests   []request
+		wantCalls  int
+		advanceTTL bool
+	}{
+		{
+			name:   "request without key is not stored",
+			status: http.StatusCreated,
+			requests: []request{
+				{body: `{"a":1}`, wantCode: http.StatusCreated, wantBody: `{"a":1}`},
+				{body: `{"a":1}`, wantCode: http.StatusCreated, wantBody: `{"a":1}`},
+			},
+			wantCalls: 2,
+		},
+		{
+			name:   "retry gets the stored response",
+			status: http.StatusCreated,
+			requests: []request{
+				{key: "key-1", body: `{"a":1}`, wantCode: http.StatusCreated, wantBody: `{"a":1}`},
+				{key: "key-1", body: `{"a":1}`, wantCode: http.StatusCreated, wantBody: `{"a":1}`, wantReplayed: true},
+			},
+			wantCalls: 1,
+		},
+		{
+			name:   "client errors are stored",
+			status: http.StatusConflict,
+			requests: []request{
+				{key: "key-1", body: `{"a":1}`, wantCode: http.StatusConflict, wantBody: `{"a":1}`},
+				{key: "key-1", body: `{"a":1}`, wantCode: http.StatusConflict, wantBody: `{"a":1}`, wantReplayed: true},
+			},
+			wantCalls: 1,
+		},
+		{
+			name:   "server errors are not stored",
+			status: http.StatusInternalServerError,
+			requests: []request{
+				{key: "key-1", body: `{"a":1}`, wantCode: http.StatusInternalServerError, wantBody: `{"a":1}`},
+				{key: "key-1", body: `{"a":1}`, wantCode: http.StatusInternalServerError, wantBody: `{"a":1}`},
+			},
+			wantCalls: 2,
+		},
+		{
+			name:   "key reused with a different body",
+			status: http.StatusCreated,
+			requests: []request{
+				{key: "key-1", body: `{"a":1}`, wantCode: http.StatusCreated, wantBody: `{"a":1}`},
+				{
+					key:         "key-1",
+					body:        `{"a":2}`,
+					wantCode:    http.StatusUnprocessableEntity,
+					wantBody:    `{"type":"/problems/idempotency-key-reused","title":"Unprocessable Entity","status":422,"detail":"idempotency key was already used with a different request","code":"IDEMPOTENCY_KEY_REUSED"}`,
+					wantProblem: true,
+				},
+			},
+			wantCalls: 1,
+		},
+		{
+			name:   "key reused on a different path",
+			status: http.StatusCreated,
+			requests: []request{
+				{key: "key-1", path: "/tasks", body: `{"a":1}`, wantCode: http.StatusCreated, wantBody: `{"a":1}`},
+				{
+					key:         "key-1",
+					path:        "/auth/login",
+					body:        `{"a":1}`,
+					wantCode:    http.StatusUnprocessableEntity,
+					wantBody:    `{"type":"/problems/idempotency-key-reused","title":"Unprocessable Entity","status":422,"detail":"idempotency key was already used with a different request","code":"IDEMPOTENCY_KEY_REUSED"}`,
+					wantProblem: true,
+				},
+			},
+			wantCalls: 1,
+		},
+		{
+			name:   "keys are scoped by user",
+			status: http.StatusCreated,
+			requests: []request{
+				{userID: "user-1", key: "key-1", body: `{"a":1}`, wantCode: http.StatusCreated, wantBody: `{"a":1}`},
+				{userID: "user-2", key: "key-1", body: `{"a":2}`, wantCode: http.StatusCreated, wantBody: `{"a":2}`},
+			},
+			wantCalls: 2,
+		},
+		{
+			name:   "anonymous keys are scoped by client IP",
+			status: http.StatusCreated,
+			requests: []request{
+				{remoteAddr: "192.0.2.1:1234", key: "key-1", body: `{"a":1}`, wantCode: http.StatusCreated, wantBody: `{"a":1}`},
+				{remoteAddr: "192.0.2.2:1234", key: "key-1", body: `{"a":2}`, wantCode: http.StatusCreated, wantBody: `{"a":2}`},
+				{
+					remoteAddr:   "192.0.2.1:5678",
+					key:          "key-1",
+					body:         `{"a":1}`,
+					wantCode:     http.StatusCreated,
+					wantBody:     `{"a":1}`,
+					wantReplayed: true,
+				},
+			},
+			wantCalls: 2,
+		},
+		{
+			name:   "anonymous key reused with a different body",
+			status: http.StatusCreated,
+			requests: []request{
+				{remoteAddr: "192.0.2.1:1234", key: "key-1", body: `{"a":1}`, wantCode: http.StatusCreated, wantBody: `{"a":1}`},
+				{
+					remoteAddr:  "192.0.2.1:5678",
+					key:         "key-1",
+					body:        `{"a":2}`,
+					wantCode:    http.StatusUnprocessableEntity,
+					wantBody:    `{"type":"/problems/idempotency-key-reused","title":"Unprocessable Entity","status":422,"detail":"idempotency key was already used with a different request","code":"IDEMPOTENCY_KEY_REUSED"}`,
+					wantProblem: true,
+				},
+			},
+			wantCalls: 1,
+		},
+		{
+			name:    "key only retry is processed again",
+			status:  http.StatusOK,
+			keyOnly: true,
+			requests: []request{
+				{key: "key-1", body: `{"a":1}`, wantCode: http.StatusOK, wantBody: `{"a":1}`},
+				{key: "key-1", body: `{"a":1}`, wantCode: http.StatusOK, wantBody: `{"a":1}`},
+			},
+			wantCalls: 2,
+		},
+		{
+			name:    "key only key reused with a different body",
+			status:  http.StatusOK,
+			keyOnly: true,
+			requests: []request{
+				{key: "key-1", body: `{"a":1}`, wantCode: http.StatusOK, wantBody: `{"a":1}`},
+				{
+					key:         "key-1",
+					body:        `{"a":2}`,
+					wantCode:    http.StatusUnprocessableEntity,
+					wantBody:    `{"type":"/problems/idempotency-key-reused","title":"Unprocessable Entity","status":422,"detail":"idempotency key was already used with a different request","code":"IDEMPOTENCY_KEY_REUSED"}`,
+					wantProblem: true,
+				},
+			},
+			wantCalls: 1,
+		},
+		{
+			name:   "expired key is processed again",
+			status: http.StatusCreated,
+			requests: []request{
+				{key: "key-1", body: `{"a":1}`, wantCode: http.StatusCreated, wantBody: `{"a":1}`},
+				{key: "key-1", body: `{"a":2}`, wantCode: http.StatusCreated, wantBody: `{"a":2}`},
+			},
+			wantCalls:  2,
+			advanceTTL: true,
+		},
+		{
+			name:   "invalid key",
+			status: http.StatusCreated,
+			requests: []request{
+				{
+					key:         strings.Repeat("k", myMw.IdempotencyKeyMaxLength+1),
+					body:        `{"a":1}`,
+					wantCode:    http.StatusBadRequest,
+					wantBody:    `{"type":"/problems/idempotency-key-invalid","title":"Bad Request","status":400,"detail":"invalid Idempotency-Key header","code":"IDEMPOTENCY_KEY_INVALID"}`,
+					wantProblem: true,
+				},
+			},
+			wantCalls: 0,
+		},
+		{
+			name:   "store failure",
+			status: http.StatusCreated,
+			store:  failingStore{},
+			requests: []request{
+				{
+					key:         "key-1",
+					body:        `{"a":1}`,
+					wantCode:    http.StatusInternalServerError,
+					wantBody:    `{"type":"/problems/internal-error","title":"Internal Server Error","status":500,"detail":"internal server error","code":"INTERNAL_ERROR"}`,
+					wantProblem: true,
+				},
+			},
+			wantCalls: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := tt.store
+			if store == nil {
+				store = idempotency.NewMemoryStore()
+			}
+
+			clk := clock.NewFake(time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC))
+			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+
+			next := &countingHandler{status: tt.status}
+			middleware := myMw.Idempotency
+			if tt.keyOnly {
+				middleware = myMw.IdempotencyKeyOnly
+			}
+
+			h := middleware(store, time.Hour, clk, logger)(next)
+
+			for i, req := range tt.requests {
+				if i > 0 && tt.advanceTTL {
+					clk.Advance(time.Hour)
+				}
+
+				path := req.path
+				if path == "" {
+					path = "/tasks"
+				}
+
+				r := httptest.NewRequestWithContext(
+					context.WithValue(context.Background(), myMw.UserIDKey, req.userID),
+					http.MethodPost,
+					path,
+					strings.NewReader(req.body),
+				)
+				if req.remoteAddr != "" {
+					r.RemoteAddr = req.remoteAddr
+				}
+				if req.key != "" {
+					r.Header.Set(myMw.IdempotencyKeyHeader, req.key)
+				}
+
+				rr := httptest.NewRecorder()
+				h.ServeHTTP(rr, r)
+
+				require.Equal(t, req.wantCode, rr.Code, "request %d", i)
+				require.JSONEq(t, req.wantBody, rr.Body.String(), "request %d", i)
+				if req.wantProblem {
+					require.Equal(t, handlers.ProblemContentType, rr.Header().Get("Content-Type"), "request %d", i)
+				} else {
+					require.Equal(t, "application/json", rr.Header().Get("Content-Type"), "request %d", i)
+				}
+
+				if req.wantReplayed {
+					require.Equal(t, "true", rr.Header().Get(myMw.IdempotentReplayedHeader), "request %d", i)
+				} else {
+					require.Empty(t, rr.Header().Get(myMw.IdempotentReplayedHeader), "request %d", i)
+				}
+			}
+
+			require.Equal(t, tt.wantCalls, next.calls)
+		})
+	}
+}
+
+func TestIdempotency_InProgress(t *testing.T) {
+	store := idempotency.NewMemoryStore()
+	clk := clock.NewFake(time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC))
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+
+	var inner *httptest.ResponseRecorder
+
+	var h http.Handler
+	h = myMw.Idempotency(store, time.Hour, clk, logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the same request arrives while the first one is being processed
+		inner = httptest.NewRecorder()
+		retry := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(`{"a":1}`))
+		retry.Header.Set(myMw.IdempotencyKeyHeader, "key-1")
+		h.ServeHTTP(inner, retry)
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(`{"a":1}`))
+	r.Header.Set(myMw.IdempotencyKeyHeader, "key-1")
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, r)
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+	require.Equal(t, http.StatusConflict, inner.Code)
+	require.JSONEq(t, `{"type":"/problems/idempotency-key-in-progress","title":"Conflict","status":409,"detail":"request with this idempotency key is still being processed","code":"IDEMPOTENCY_KEY_IN_PROGRESS"}`, inner.Body.String())
+}
+
+func TestIdempotency_PanicReleasesKey(t *testing.T) {
+	store := idempotency.NewMemoryStore()
+	clk := clock.NewFake(time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC))
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+
+	h := myMw.Idempotency(store, time.Hour, clk, logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(`{"a":1}`))
+	r.Header.Set(myMw.IdempotencyKeyHeader, "key-1")
+
+	require.Panics(t, func() { h.ServeHTTP(httptest.NewRecorder(), r) })
+
+	// the retry is processed instead of waiting for the failed request
+	next := &countingHandler{status: http.StatusCreated}
+	h = myMw.Idempotency(store, time.Hour, clk, logger)(next)
+
+	r = httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(`{"a":1}`))
+	r.Header.Set(myMw.IdempotencyKeyHeader, "key-1")
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, r)
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+	require.Equal(t, 1, next.calls)
+}
+
+func TestIdempotencyKeyOnly_ResponseIsNotStored(t *testing.T) {
+	store := idempotency.NewMemoryStore()
+	clk := clock.NewFake(time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC))
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+
+	h := myMw.IdempotencyKeyOnly(store, time.Hour, clk, logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"access_token":"secret"}`))
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(`{"a":1}`))
+	r.Header.Set(myMw.IdempotencyKeyHeader, "key-1")
+
+	h.ServeHTTP(httptest.NewRecorder(), r)
+
+	record, err := store.Reserve(context.Background(), "ip:192.0.2.1:key-1", "", clk.Now(), clk.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	require.NotNil(t, record.Response)
+	require.Equal(t, http.StatusOK, record.Response.StatusCode)
+	require.Nil(t, record.Response.Header)
+	require.Empty(t, record.Response.Body)
+}
+
+func TestIdempotency_BodyTooLarge(t *testing.T) {
+	store := idempotency.NewMemoryStore()
+	clk := clock.NewFake(time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC))
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+
+	next := &countingHandler{status: http.StatusCreated}
+	h := myMw.RequestBody(handlers.DecodeOptions{MaxBodySize: 4})(
+		myMw.Idempotency(store, time.Hour, clk, logger)(next),
+	)
+
+	r := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(`{"a":1}`))
+	r.Header.Set(myMw.IdempotencyKeyHeader, "key-1")
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, r)
+
+	require.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+	require.Equal(t, 0, next.calls)
+	require.JSONEq(t, `{"type":"/problems/request-body-too-large","title":"Request Entity Too Large","status":413,"detail":"request body is too large","code":"REQUEST_BODY_TOO_LARGE"}`, rr.Body.String())
+}
@@ -3,12 +3,14 @@ package middleware
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"strings"
 
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/auth/jwt"
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
-	"github.com/go-chi/chi/v5/middleware"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
 )
 
 type ctxKeyUserID string
@@ -22,37 +24,54 @@ type JWTValidator interface {
 	Validate(token string) (string, error)
 }
 
+// WebSocketBearerProtocolPrefix followed by a token is the subprotocol that carries the token
+// of a WebSocket handshake, since the browsers can not set the Authorization header on it.
+// The client offers it along with the actual subprotocol, which the server selects instead,
+// e.g. "Sec-WebSocket-Protocol: taskery.v1, bearer.<token>".
+const WebSocketBearerProtocolPrefix = "bearer."
+
+var (
+	errTokenMissing = handlers.NewError(http.StatusUnauthorized, "TOKEN_MISSING", "authorization token is missing")
+	errTokenExpired = handlers.NewError(http.StatusUnauthorized, "TOKEN_EXPIRED", "token is expired")
+	errTokenInvalid = handlers.NewError(http.StatusUnauthorized, "TOKEN_INVALID", "token is invalid")
+)
+
 // JWTAuth returns a middleware that authenticates requests using a JWT.
 // It extracts the token from the "Authorization" header, which must use
-// the "Bearer " schema.
+// the "Bearer " schema. A WebSocket handshake without the header may carry the token
+// in the Sec-WebSocket-Protocol header instead, see WebSocketBearerProtocolPrefix.
 //
 // If the token is valid, the middleware adds the resulting user ID to the
-// request context using UserIDKey and calls the next handler.
-// Otherwise, it logs the error using logger and returns a
-// 401 Unauthorized response to the client.
-func JWTAuth(validator JWTValidator, logger *slog.Logger) func(http.Handler) http.Handler {
+// request context using UserIDKey, adds it to the request-scoped logger
+// and calls the next handler.
+// Otherwise, it logs the error using the request-scoped logger, or baseLogger if there is none,
+// and returns a 401 Unauthorized problem response with the WWW-Authenticate header
+// as described in RFC 6750.
+// An expired token is reported with the TOKEN_EXPIRED code, so that the client
+// knows that it has to obtain a new one.
+func JWTAuth(validator JWTValidator, baseLogger *slog.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			const op = "middleware.JWT"
 
-			logger := logger.With(
-				slog.String("op", op),
-				slog.String("request_id", middleware.GetReqID(r.Context())),
-			)
+			logger := slogx.FromContext(r.Context(), baseLogger).With(slog.String("op", op))
 
 			authHeader := r.Header.Get("Authorization")
+			if authHeader == "" {
+				authHeader = webSocketBearer(r)
+			}
 			if authHeader == "" {
 				logger.Error("no token provided")
 
-				handlers.WriteError(w, http.StatusUnauthorized, errors.New("unauthorized"))
+				writeAuthError(w, r, errTokenMissing)
 				return
 			}
 
-			tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-			if tokenString == authHeader {
+			scheme, tokenString, ok := strings.Cut(authHeader, " ")
+			if !ok || !strings.EqualFold(scheme, "Bearer") || tokenString == "" {
 				logger.Error("invalid Authorization format")
 
-				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				writeAuthError(w, r, errTokenMissing)
 				return
 			}
 
@@ -60,16 +79,55 @@ func JWTAuth(validator JWTValidator, logger *slog.Logger) func(http.Handler) htt
 			if err != nil {
 				logger.Error("failed to validate token", slog.String("error", err.Error()))
 
-				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				if errors.Is(err, jwt.ErrTokenExpired) {
+					writeAuthError(w, r, errTokenExpired)
+					return
+				}
+
+				writeAuthError(w, r, errTokenInvalid)
 				return
 			}
 
 			ctx := context.WithValue(r.Context(), UserIDKey, userID)
+			ctx = slogx.WithLogger(ctx, slogx.FromContext(ctx, baseLogger).With(slog.String("user_id", userID)))
+			setAccessLogUserID(ctx, userID)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
+// webSocketBearer returns the token that a WebSocket handshake carries in its subprotocols
+// in the form of the Authorization header, or the empty string if there is none.
+func webSocketBearer(r *http.Request) string {
+	if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		return ""
+	}
+
+	for _, header := range r.Header.Values("Sec-WebSocket-Protocol") {
+		for protocol := range strings.SplitSeq(header, ",") {
+			token, ok := strings.CutPrefix(strings.TrimSpace(protocol), WebSocketBearerProtocolPrefix)
+			if ok {
+				return "Bearer " + token
+			}
+		}
+	}
+
+	return ""
+}
+
+// writeAuthError writes the problem for err along with the WWW-Authenticate header.
+// A request without a bearer token gets the challenge without an error code,
+// as RFC 6750 recommends for requests that lack any authentication information.
+func writeAuthError(w http.ResponseWriter, r *http.Request, err *handlers.Error) {
+	challenge := "Bearer"
+	if err != errTokenMissing {
+		challenge += fmt.Sprintf(` error="invalid_token", error_description=%q`, err.Detail)
+	}
+
+	w.Header().Set("WWW-Authenticate", challenge)
+	handlers.WriteError(w, r, err)
+}
+
 // GetUserID returns a user ID from the given context if one is present.
 // Returns the empty string if a user ID cannot be found.
 func GetUserID(ctx context.Context) string {
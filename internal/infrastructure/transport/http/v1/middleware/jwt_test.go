@@ -0,0 +1,165 @@
+package middleware_test
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/auth/jwt"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/stretchr/testify/require"
+)
+
+// validatorFunc is a myMw.JWTValidator that calls the function.
+type validatorFunc func(token string) (string, error)
+
+func (f validatorFunc) Validate(token string) (string, error) {
+	return f(token)
+}
+
+func TestJWTAuth(t *testing.T) {
+	validator := validatorFunc(func(token string) (string, error) {
+		switch token {
+		case "valid":
+			return "user-1", nil
+		case "expired":
+			return "", fmt.Errorf("jwt.Provider.Validate: %w", jwt.ErrTokenExpired)
+		default:
+			return "", fmt.Errorf("jwt.Provider.Validate: %w", jwt.ErrTokenSignatureInvalid)
+		}
+	})
+
+	tests := []struct {
+		name                string
+		authorization       string
+		webSocketProtocol   string
+		wantCode            int
+		wantBody            string
+		wantWWWAuthenticate string
+	}{
+		{
+			name:          "valid token",
+			authorization: "Bearer valid",
+			wantCode:      http.StatusOK,
+			wantBody:      "user-1",
+		},
+		{
+			name:          "scheme is case-insensitive",
+			authorization: "bearer valid",
+			wantCode:      http.StatusOK,
+			wantBody:      "user-1",
+		},
+		{
+			name:     "no token",
+			wantCode: http.StatusUnauthorized,
+			wantBody: `{"type":"/problems/token-missing","title":"Unauthorized","status":401,` +
+				`"detail":"authorization token is missing","code":"TOKEN_MISSING"}`,
+			wantWWWAuthenticate: "Bearer",
+		},
+		{
+			name:          "another scheme",
+			authorization: "Basic dXNlcjpwYXNz",
+			wantCode:      http.StatusUnauthorized,
+			wantBody: `{"type":"/problems/token-missing","title":"Unauthorized","status":401,` +
+				`"detail":"authorization token is missing","code":"TOKEN_MISSING"}`,
+			wantWWWAuthenticate: "Bearer",
+		},
+		{
+			name:          "expired token",
+			authorization: "Bearer expired",
+			wantCode:      http.StatusUnauthorized,
+			wantBody: `{"type":"/problems/token-expired","title":"Unauthorized","status":401,` +
+				`"detail":"token is expired","code":"TOKEN_EXPIRED"}`,
+			wantWWWAuthenticate: `Bearer error="invalid_token", error_description="token is expired"`,
+		},
+		{
+			name:          "invalid token",
+			authorization: "Bearer forged",
+			wantCode:      http.StatusUnauthorized,
+			wantBody: `{"type":"/problems/token-invalid","title":"Unauthorized","status":401,` +
+				`"detail":"token is invalid","code":"TOKEN_INVALID"}`,
+			wantWWWAuthenticate: `Bearer error="invalid_token", error_description="token is invalid"`,
+		},
+		{
+			name:              "token in the subprotocols of a websocket handshake",
+			webSocketProtocol: "taskery.v1, bearer.valid",
+			wantCode:          http.StatusOK,
+			wantBody:          "user-1",
+		},
+		{
+			name:              "invalid token in the subprotocols of a websocket handshake",
+			webSocketProtocol: "taskery.v1, bearer.forged",
+			wantCode:          http.StatusUnauthorized,
+			wantBody: `{"type":"/problems/token-invalid","title":"Unauthorized","status":401,` +
+				`"detail":"token is invalid","code":"TOKEN_INVALID"}`,
+			wantWWWAuthenticate: `Bearer error="invalid_token", error_description="token is invalid"`,
+		},
+		{
+			name:              "websocket handshake without a token",
+			webSocketProtocol: "taskery.v1",
+			wantCode:          http.StatusUnauthorized,
+			wantBody: `{"type":"/problems/token-missing","title":"Unauthorized","status":401,` +
+				`"detail":"authorization token is missing","code":"TOKEN_MISSING"}`,
+			wantWWWAuthenticate: "Bearer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(myMw.GetUserID(r.Context())))
+			})
+
+			r := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+			if tt.authorization != "" {
+				r.Header.Set("Authorization", tt.authorization)
+			}
+			if tt.webSocketProtocol != "" {
+				r.Header.Set("Connection", "Upgrade")
+				r.Header.Set("Upgrade", "websocket")
+				r.Header.Set("Sec-WebSocket-Protocol", tt.webSocketProtocol)
+			}
+
+			rr := httptest.NewRecorder()
+			myMw.JWTAuth(validator, logger)(next).ServeHTTP(rr, r)
+
+			require.Equal(t, tt.wantCode, rr.Code)
+			require.Equal(t, tt.wantWWWAuthenticate, rr.Header().Get("WWW-Authenticate"))
+
+			if tt.wantCode == http.StatusOK {
+				require.Equal(t, tt.wantBody, rr.Body.String())
+				return
+			}
+
+			require.Equal(t, handlers.ProblemContentType, rr.Header().Get("Content-Type"))
+			require.JSONEq(t, tt.wantBody, rr.Body.String())
+		})
+	}
+}
+
+func TestJWTAuth_WebSocketProtocolWithoutUpgrade(t *testing.T) {
+	validator := validatorFunc(func(token string) (string, error) {
+		return "user-1", nil
+	})
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(myMw.GetUserID(r.Context())))
+	})
+
+	// only a websocket handshake may carry the token in its subprotocols
+	r := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+	r.Header.Set("Sec-WebSocket-Protocol", "bearer.valid")
+
+	rr := httptest.NewRecorder()
+	myMw.JWTAuth(validator, logger)(next).ServeHTTP(rr, r)
+
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+	require.Equal(t, "Bearer", rr.Header().Get("WWW-Authenticate"))
+}
@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// AccessLogOptions configures the access log written by RequestLogger.
+type AccessLogOptions struct {
+	// Enabled turns the access log on. The request-scoped logger is injected regardless of it.
+	Enabled bool
+
+	// SampleRate is the fraction of the successful requests that are logged, from 0 to 1.
+	// Requests that end with a 4xx or 5xx status are always logged.
+	SampleRate float64
+
+	// LogHeaders adds the request headers to the access log.
+	// The values of the sensitive headers, such as Authorization, are redacted.
+	LogHeaders bool
+}
+
+type ctxKeyAccessLogEntry struct{}
+
+// accessLogEntry holds the request details that become known
+// only to the inner middlewares, such as the authenticated user.
+type accessLogEntry struct {
+	userID string
+
+	// path replaces the path of the request, if set, see RedactPath.
+	path string
+}
+
+// RequestLogger returns a middleware that injects a request-scoped logger into the request context
+// and writes an access log entry for every request.
+//
+// The request-scoped logger is logger with the request ID and, if the request is traced,
+// the trace ID. Handlers get it with slogx.FromContext,
+// and JWTAuth adds the ID of the authenticated user to it. The middleware must follow
+// middleware.RequestID and Tracing.
+//
+// The access log entry contains the method, the path, the status code, the number of bytes written,
+// the latency, the user ID and the remote IP of the request.
+func RequestLogger(logger *slog.Logger, opts AccessLogOptions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestLogger := logger.With(slog.String("request_id", middleware.GetReqID(r.Context())))
+			if sc := trace.SpanContextFromContext(r.Context()); sc.HasTraceID() {
+				requestLogger = requestLogger.With(slog.String("trace_id", sc.TraceID().String()))
+			}
+
+			entry := &accessLogEntry{}
+
+			ctx := slogx.WithLogger(r.Context(), requestLogger)
+			ctx = context.WithValue(ctx, ctxKeyAccessLogEntry{}, entry)
+
+			if !opts.Enabled {
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			start := time.Now()
+
+			defer func() {
+				status := ww.Status()
+				if status == 0 {
+					status = http.StatusOK
+				}
+
+				if status < http.StatusBadRequest && !sampled(opts.SampleRate) {
+					return
+				}
+
+				path := r.URL.Path
+				if entry.path != "" {
+					path = entry.path
+				}
+
+				attrs := []slog.Attr{
+					slog.String("method", r.Method),
+					slog.String("path", path),
+					slog.Int("status", status),
+					slog.Int("bytes", ww.BytesWritten()),
+					slog.Duration("latency", time.Since(start)),
+					slog.String("user_id", entry.userID),
+					slog.String("remote_ip", remoteIP(r)),
+				}
+
+				if opts.LogHeaders {
+					attrs = append(attrs, headersAttr(r.Header))
+				}
+
+				level := slog.LevelInfo
+				if status >= http.StatusInternalServerError {
+					level = slog.LevelError
+				}
+
+				requestLogger.LogAttrs(r.Context(), level, "request completed", attrs...)
+			}()
+
+			next.ServeHTTP(ww, r.WithContext(ctx))
+		})
+	}
+}
+
+// setAccessLogUserID records the ID of the authenticated user for the access log entry of the request.
+func setAccessLogUserID(ctx context.Context, userID string) {
+	if entry, ok := ctx.Value(ctxKeyAccessLogEntry{}).(*accessLogEntry); ok {
+		entry.userID = userID
+	}
+}
+
+// setAccessLogPath records the path that is logged instead of the path of the request.
+func setAccessLogPath(ctx context.Context, path string) {
+	if entry, ok := ctx.Value(ctxKeyAccessLogEntry{}).(*accessLogEntry); ok {
+		entry.path = path
+	}
+}
+
+// sampled reports whether a request is chosen for the access log with the given rate.
+func sampled(rate float64) bool {
+	switch {
+	case rate >= 1:
+		return true
+	case rate <= 0:
+		return false
+	default:
+		return rand.Float64() < rate
+	}
+}
+
+// headersAttr returns the request headers as a group with the sensitive values redacted.
+func headersAttr(header http.Header) slog.Attr {
+	attrs := make([]any, 0, len(header))
+
+	for name, values := range header {
+		if slogx.IsSensitive(name) {
+			attrs = append(attrs, slog.String(name, slogx.RedactedValue))
+			continue
+		}
+
+		attrs = append(attrs, slog.Any(name, values))
+	}
+
+	return slog.Group("headers", attrs...)
+}
@@ -0,0 +1,170 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/require"
+)
+
+// logEntries decodes the JSON log lines written to buf.
+func logEntries(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var entries []map[string]any
+
+	for line := range strings.Lines(buf.String()) {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+func TestRequestLogger(t *testing.T) {
+	validator := validatorFunc(func(token string) (string, error) {
+		return "user-1", nil
+	})
+
+	tests := []struct {
+		name          string
+		opts          myMw.AccessLogOptions
+		status        int
+		authorization string
+		wantLogged    bool
+		wantUserID    string
+		wantHeaders   map[string]any
+	}{
+		{
+			name:          "successful request",
+			opts:          myMw.AccessLogOptions{Enabled: true, SampleRate: 1},
+			status:        http.StatusCreated,
+			authorization: "Bearer valid",
+			wantLogged:    true,
+			wantUserID:    "user-1",
+		},
+		{
+			name:       "anonymous request",
+			opts:       myMw.AccessLogOptions{Enabled: true, SampleRate: 1},
+			status:     http.StatusOK,
+			wantLogged: true,
+		},
+		{
+			name:       "successful request is not sampled",
+			opts:       myMw.AccessLogOptions{Enabled: true, SampleRate: 0},
+			status:     http.StatusOK,
+			wantLogged: false,
+		},
+		{
+			name:       "failed request is always logged",
+			opts:       myMw.AccessLogOptions{Enabled: true, SampleRate: 0},
+			status:     http.StatusNotFound,
+			wantLogged: true,
+		},
+		{
+			name:       "access log is disabled",
+			opts:       myMw.AccessLogOptions{Enabled: false, SampleRate: 1},
+			status:     http.StatusInternalServerError,
+			wantLogged: false,
+		},
+		{
+			name:          "headers are redacted",
+			opts:          myMw.AccessLogOptions{Enabled: true, SampleRate: 1, LogHeaders: true},
+			status:        http.StatusOK,
+			authorization: "Bearer valid",
+			wantLogged:    true,
+			wantUserID:    "user-1",
+			wantHeaders: map[string]any{
+				"Authorization": slogx.RedactedValue,
+				"Accept":        []any{"application/json"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{}))
+
+			var handlerLogger *slog.Logger
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				handlerLogger = slogx.FromContext(r.Context(), nil)
+
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte("hello"))
+			})
+
+			h := next
+			if tt.authorization != "" {
+				h = myMw.JWTAuth(validator, logger)(next).ServeHTTP
+			}
+
+			handler := middleware.RequestID(myMw.RequestLogger(logger, tt.opts)(h))
+
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/tasks?limit=1", nil)
+			r.RemoteAddr = "192.0.2.1:54321"
+			r.Header.Set("Accept", "application/json")
+			if tt.authorization != "" {
+				r.Header.Set("Authorization", tt.authorization)
+			}
+
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+
+			// the handler logs with the request-scoped logger
+			require.NotNil(t, handlerLogger)
+			buf.Reset()
+			handlerLogger.Info("from handler")
+
+			entries := logEntries(t, &buf)
+			require.Len(t, entries, 1)
+			require.NotEmpty(t, entries[0]["request_id"])
+			if tt.wantUserID != "" {
+				require.Equal(t, tt.wantUserID, entries[0]["user_id"])
+			}
+
+			buf.Reset()
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+
+			var access []map[string]any
+			for _, entry := range logEntries(t, &buf) {
+				if entry["msg"] == "request completed" {
+					access = append(access, entry)
+				}
+			}
+
+			if !tt.wantLogged {
+				require.Empty(t, access)
+				return
+			}
+
+			require.Len(t, access, 1)
+
+			entry := access[0]
+			require.Equal(t, http.MethodPost, entry["method"])
+			require.Equal(t, "/api/v1/tasks", entry["path"])
+			require.EqualValues(t, tt.status, entry["status"])
+			require.EqualValues(t, len("hello"), entry["bytes"])
+			require.Contains(t, entry, "latency")
+			require.Equal(t, tt.wantUserID, entry["user_id"])
+			require.Equal(t, "192.0.2.1", entry["remote_ip"])
+			require.NotEmpty(t, entry["request_id"])
+
+			if tt.wantHeaders != nil {
+				require.Equal(t, tt.wantHeaders, entry["headers"])
+			} else {
+				require.NotContains(t, entry, "headers")
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// UnmatchedRoute is the route reported for the requests that do not match any route,
+// so that arbitrary paths do not blow up the number of series.
+const UnmatchedRoute = "unmatched"
+
+// HTTPMetrics wraps a method for recording the served HTTP requests.
+type HTTPMetrics interface {
+	// ObserveRequest records a request with the given method, route pattern, status code and latency.
+	ObserveRequest(method, route string, status int, duration time.Duration)
+}
+
+// Metrics returns a middleware that records every request in metrics.
+// The requests are grouped by the chi route pattern, e.g. "/api/v1/tasks/{id}", rather than
+// by the path. The middleware must be used on the root router, so that the pattern is complete.
+func Metrics(metrics HTTPMetrics) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			start := time.Now()
+
+			next.ServeHTTP(ww, r)
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			route := UnmatchedRoute
+			if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+				route = rctx.RoutePattern()
+			}
+
+			metrics.ObserveRequest(r.Method, route, status, time.Since(start))
+		})
+	}
+}
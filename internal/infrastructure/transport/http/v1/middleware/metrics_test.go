@@ -0,0 +1,77 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+)
+
+type observedRequest struct {
+	method string
+	route  string
+	status int
+}
+
+// fakeHTTPMetrics remembers the observed requests.
+type fakeHTTPMetrics struct {
+	requests []observedRequest
+}
+
+func (m *fakeHTTPMetrics) ObserveRequest(method, route string, status int, _ time.Duration) {
+	m.requests = append(m.requests, observedRequest{method: method, route: route, status: status})
+}
+
+func TestMetrics(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		want   observedRequest
+	}{
+		{
+			name:   "route with a parameter",
+			method: http.MethodGet,
+			path:   "/api/v1/tasks/42",
+			want:   observedRequest{method: http.MethodGet, route: "/api/v1/tasks/{id}", status: http.StatusOK},
+		},
+		{
+			name:   "status written by the handler",
+			method: http.MethodPost,
+			path:   "/api/v1/tasks",
+			want:   observedRequest{method: http.MethodPost, route: "/api/v1/tasks", status: http.StatusCreated},
+		},
+		{
+			name:   "unknown path",
+			method: http.MethodGet,
+			path:   "/unknown/path",
+			want:   observedRequest{method: http.MethodGet, route: myMw.UnmatchedRoute, status: http.StatusNotFound},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := &fakeHTTPMetrics{}
+
+			r := chi.NewRouter()
+			r.Use(myMw.Metrics(metrics))
+			r.Route("/api/v1/tasks", func(r chi.Router) {
+				r.Post("/", func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusCreated)
+				})
+				r.Get("/{id}", func(w http.ResponseWriter, r *http.Request) {
+					_, _ = w.Write([]byte("ok"))
+				})
+			})
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			require.Equal(t, []observedRequest{tt.want}, metrics.requests)
+		})
+	}
+}
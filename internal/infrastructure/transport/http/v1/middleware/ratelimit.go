@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/ratelimit"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
+)
+
+// Rate limit headers, as described in the IETF draft "RateLimit header fields for HTTP".
+const (
+	RateLimitLimitHeader     = "RateLimit-Limit"
+	RateLimitRemainingHeader = "RateLimit-Remaining"
+	RateLimitResetHeader     = "RateLimit-Reset"
+	RetryAfterHeader         = "Retry-After"
+)
+
+var errRateLimitExceeded = handlers.NewError(
+	http.StatusTooManyRequests,
+	"RATE_LIMIT_EXCEEDED",
+	"rate limit exceeded",
+)
+
+// RateLimit returns a middleware that limits the rate of requests to the routes of group
+// with a token bucket per client kept in store.
+//
+// A client is the authenticated user, if the middleware follows JWTAuth, and the IP address
+// of the client otherwise. The buckets are scoped by group, so that the routes of different
+// groups have separate limits.
+//
+// Every response gets the RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers.
+// A request over the limit gets 429 Too Many Requests with the Retry-After header.
+// If store fails, the request is let through, so that the limiter does not take the API down.
+// An unlimited limit turns the middleware off.
+func RateLimit(
+	store ratelimit.Store,
+	group string,
+	limit ratelimit.Limit,
+	clk clock.Clock,
+	logger *slog.Logger,
+) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if limit.Unlimited() {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			const op = "middleware.RateLimit"
+
+			result, err := store.Take(r.Context(), rateLimitKey(r, group), limit, clk.Now())
+			if err != nil {
+				slogx.FromContext(r.Context(), logger).With(slog.String("op", op)).Error(
+					"failed to take a rate limit token",
+					slog.String("error", err.Error()),
+				)
+
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set(RateLimitLimitHeader, strconv.Itoa(result.Limit))
+			w.Header().Set(RateLimitRemainingHeader, strconv.Itoa(result.Remaining))
+			w.Header().Set(RateLimitResetHeader, ceilSeconds(result.ResetAfter))
+
+			if !result.Allowed {
+				slogx.FromContext(r.Context(), logger).With(slog.String("op", op)).Info(
+					"rate limit exceeded",
+					slog.String("group", group),
+				)
+
+				w.Header().Set(RetryAfterHeader, ceilSeconds(result.RetryAfter))
+				handlers.WriteError(w, r, errRateLimitExceeded)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// rateLimitKey returns the key of the bucket of the client that made r.
+func rateLimitKey(r *http.Request, group string) string {
+	if userID := GetUserID(r.Context()); userID != "" {
+		return group + ":user:" + userID
+	}
+
+	return group + ":ip:" + remoteIP(r)
+}
+
+// ceilSeconds formats d as a whole number of seconds rounded up.
+func ceilSeconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
@@ -0,0 +1,162 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/clientip"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/ratelimit"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
+	"github.com/stretchr/testify/require"
+)
+
+// failingRateLimitStore fails to take a token.
+type failingRateLimitStore struct {
+	ratelimit.Store
+}
+
+func (failingRateLimitStore) Take(context.Context, string, ratelimit.Limit, time.Time) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("failed to connect to db")
+}
+
+func TestRateLimit(t *testing.T) {
+	now := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+	limit := ratelimit.Limit{Requests: 2, Period: time.Minute}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	request := func(remoteAddr, userID string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", nil)
+		req.RemoteAddr = remoteAddr
+
+		if userID != "" {
+			req = req.WithContext(context.WithValue(req.Context(), myMw.UserIDKey, userID))
+		}
+
+		return req
+	}
+
+	t.Run("limits a client by IP", func(t *testing.T) {
+		mw := myMw.RateLimit(ratelimit.NewMemoryStore(), "auth", limit, clock.NewFake(now), logger)(handler)
+
+		for _, wantRemaining := range []string{"1", "0"} {
+			rr := httptest.NewRecorder()
+			mw.ServeHTTP(rr, request("10.0.0.1:5000", ""))
+
+			require.Equal(t, http.StatusNoContent, rr.Code)
+			require.Equal(t, "2", rr.Header().Get(myMw.RateLimitLimitHeader))
+			require.Equal(t, wantRemaining, rr.Header().Get(myMw.RateLimitRemainingHeader))
+			require.Empty(t, rr.Header().Get(myMw.RetryAfterHeader))
+		}
+
+		// another port of the same client shares the bucket
+		rr := httptest.NewRecorder()
+		mw.ServeHTTP(rr, request("10.0.0.1:5001", ""))
+
+		require.Equal(t, http.StatusTooManyRequests, rr.Code)
+		require.Equal(t, "0", rr.Header().Get(myMw.RateLimitRemainingHeader))
+		require.Equal(t, "60", rr.Header().Get(myMw.RateLimitResetHeader))
+		require.Equal(t, "30", rr.Header().Get(myMw.RetryAfterHeader))
+		require.Equal(t, handlers.ProblemContentType, rr.Header().Get("Content-Type"))
+		require.JSONEq(
+			t,
+			`{"type":"/problems/rate-limit-exceeded","title":"Too Many Requests","status":429,`+
+				`"detail":"rate limit exceeded","code":"RATE_LIMIT_EXCEEDED"}`,
+			rr.Body.String(),
+		)
+
+		rr = httptest.NewRecorder()
+		mw.ServeHTTP(rr, request("10.0.0.2:5000", ""))
+		require.Equal(t, http.StatusNoContent, rr.Code)
+	})
+
+	t.Run("limits an authenticated client by user ID", func(t *testing.T) {
+		mw := myMw.RateLimit(ratelimit.NewMemoryStore(), "tasks", limit, clock.NewFake(now), logger)(handler)
+
+		for _, remoteAddr := range []string{"10.0.0.1:5000", "10.0.0.2:5000"} {
+			rr := httptest.NewRecorder()
+			mw.ServeHTTP(rr, request(remoteAddr, "user-1"))
+			require.Equal(t, http.StatusNoContent, rr.Code)
+		}
+
+		rr := httptest.NewRecorder()
+		mw.ServeHTTP(rr, request("10.0.0.3:5000", "user-1"))
+		require.Equal(t, http.StatusTooManyRequests, rr.Code)
+
+		rr = httptest.NewRecorder()
+		mw.ServeHTTP(rr, request("10.0.0.3:5000", "user-2"))
+		require.Equal(t, http.StatusNoContent, rr.Code)
+	})
+
+	t.Run("limits the clients behind a trusted proxy separately", func(t *testing.T) {
+		resolver, err := clientip.NewResolver([]string{"10.0.0.0/8"})
+		require.NoError(t, err)
+
+		mw := myMw.ClientIP(resolver)(
+			myMw.RateLimit(ratelimit.NewMemoryStore(), "auth", limit, clock.NewFake(now), logger)(handler),
+		)
+
+		forwarded := func(remoteAddr, forwardedFor string) *http.Request {
+			req := request(remoteAddr, "")
+			req.Header.Set(clientip.ForwardedForHeader, forwardedFor)
+			return req
+		}
+
+		for range 2 {
+			rr := httptest.NewRecorder()
+			mw.ServeHTTP(rr, forwarded("10.0.0.1:5000", "203.0.113.1"))
+			require.Equal(t, http.StatusNoContent, rr.Code)
+		}
+
+		rr := httptest.NewRecorder()
+		mw.ServeHTTP(rr, forwarded("10.0.0.2:5000", "203.0.113.1"))
+		require.Equal(t, http.StatusTooManyRequests, rr.Code)
+
+		// another client behind the same proxy has a bucket of its own
+		rr = httptest.NewRecorder()
+		mw.ServeHTTP(rr, forwarded("10.0.0.1:5000", "203.0.113.2"))
+		require.Equal(t, http.StatusNoContent, rr.Code)
+
+		// an untrusted peer can not pick a bucket with the header
+		for range 2 {
+			rr = httptest.NewRecorder()
+			mw.ServeHTTP(rr, forwarded("198.51.100.1:5000", "203.0.113.3"))
+			require.Equal(t, http.StatusNoContent, rr.Code)
+		}
+
+		rr = httptest.NewRecorder()
+		mw.ServeHTTP(rr, forwarded("198.51.100.1:5000", "203.0.113.4"))
+		require.Equal(t, http.StatusTooManyRequests, rr.Code)
+	})
+
+	t.Run("lets the request through if the store fails", func(t *testing.T) {
+		mw := myMw.RateLimit(failingRateLimitStore{}, "auth", limit, clock.NewFake(now), logger)(handler)
+
+		rr := httptest.NewRecorder()
+		mw.ServeHTTP(rr, request("10.0.0.1:5000", ""))
+
+		require.Equal(t, http.StatusNoContent, rr.Code)
+		require.Empty(t, rr.Header().Get(myMw.RateLimitLimitHeader))
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		mw := myMw.RateLimit(failingRateLimitStore{}, "auth", ratelimit.Limit{}, clock.NewFake(now), logger)(handler)
+
+		rr := httptest.NewRecorder()
+		mw.ServeHTTP(rr, request("10.0.0.1:5000", ""))
+
+		require.Equal(t, http.StatusNoContent, rr.Code)
+		require.Empty(t, rr.Header().Get(myMw.RateLimitLimitHeader))
+	})
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RedactPath returns a middleware for the routes whose path carries a secret, e.g. a token.
+// It replaces the path of the request with the chi route pattern in the access log
+// and in the span of the request, so that the secret is neither logged nor traced.
+//
+// The middleware must be used on the route itself, e.g. with chi.Router.With,
+// so that the pattern is complete when it is called.
+func RedactPath(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			pattern := rctx.RoutePattern()
+
+			setAccessLogPath(r.Context(), pattern)
+			trace.SpanFromContext(r.Context()).SetAttributes(semconv.URLPath(pattern))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,57 @@
+package middleware_test
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRedactPath(t *testing.T) {
+	const secret = "s3cr3t-token"
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	var logged bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logged, nil))
+
+	var gotToken string
+
+	r := chi.NewRouter()
+	r.Use(myMw.Tracing(provider, propagation.TraceContext{}))
+	r.Use(myMw.RequestLogger(logger, myMw.AccessLogOptions{Enabled: true, SampleRate: 1}))
+	r.With(myMw.RedactPath).Get("/calendar/{token}.ics", func(w http.ResponseWriter, r *http.Request) {
+		gotToken = chi.URLParam(r, "token")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/calendar/"+secret+".ics", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, secret, gotToken)
+
+	require.NotContains(t, logged.String(), secret)
+
+	entries := logEntries(t, &logged)
+	require.Len(t, entries, 1)
+	require.Equal(t, "/calendar/{token}.ics", entries[0]["path"])
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+
+	for _, attr := range spans[0].Attributes() {
+		require.NotContains(t, attr.Value.Emit(), secret)
+	}
+	require.Contains(t, spans[0].Attributes(), attribute.String("url.path", "/calendar/{token}.ics"))
+}
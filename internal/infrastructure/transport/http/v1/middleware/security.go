@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// APIContentSecurityPolicy forbids the responses of the API to load anything or to be framed,
+	// as none of them is meant to be rendered by the browser.
+	APIContentSecurityPolicy = "default-src 'none'; frame-ancestors 'none'"
+
+	// SwaggerContentSecurityPolicy allows the Swagger UI to run its inline scripts and styles
+	// and to load its assets from the same origin.
+	SwaggerContentSecurityPolicy = "default-src 'self'; script-src 'self' 'unsafe-inline'; " +
+		"style-src 'self' 'unsafe-inline'; img-src 'self' data:; frame-ancestors 'none'"
+)
+
+// SecurityHeadersOptions configures the headers set by SecurityHeaders.
+type SecurityHeadersOptions struct {
+	// HSTSMaxAge is the time for which the browsers must use HTTPS only.
+	// Zero turns the Strict-Transport-Security header off, e.g. for a local server over HTTP.
+	HSTSMaxAge time.Duration
+}
+
+// SecurityHeaders returns a middleware that sets the security headers of every response:
+// Strict-Transport-Security, X-Content-Type-Options, X-Frame-Options, Referrer-Policy
+// and Content-Security-Policy with APIContentSecurityPolicy.
+//
+// The policy of the routes that serve web pages is replaced with ContentSecurityPolicy.
+func SecurityHeaders(opts SecurityHeadersOptions) func(http.Handler) http.Handler {
+	hsts := ""
+	if opts.HSTSMaxAge > 0 {
+		hsts = "max-age=" + strconv.Itoa(int(opts.HSTSMaxAge.Seconds())) + "; includeSubDomains"
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h := w.Header()
+
+			if hsts != "" {
+				h.Set("Strict-Transport-Security", hsts)
+			}
+			h.Set("X-Content-Type-Options", "nosniff")
+			h.Set("X-Frame-Options", "DENY")
+			h.Set("Referrer-Policy", "no-referrer")
+			h.Set("Content-Security-Policy", APIContentSecurityPolicy)
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ContentSecurityPolicy returns a middleware that replaces the Content-Security-Policy header
+// set by SecurityHeaders with policy. It must follow SecurityHeaders.
+func ContentSecurityPolicy(policy string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Security-Policy", policy)
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecurityHeaders(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     myMw.SecurityHeadersOptions
+		path     string
+		wantHSTS string
+		wantCSP  string
+	}{
+		{
+			name:     "api route",
+			opts:     myMw.SecurityHeadersOptions{HSTSMaxAge: 365 * 24 * time.Hour},
+			path:     "/api/v1/tasks",
+			wantHSTS: "max-age=31536000; includeSubDomains",
+			wantCSP:  myMw.APIContentSecurityPolicy,
+		},
+		{
+			name:    "hsts turned off",
+			path:    "/api/v1/tasks",
+			wantCSP: myMw.APIContentSecurityPolicy,
+		},
+		{
+			name:     "route with its own policy",
+			opts:     myMw.SecurityHeadersOptions{HSTSMaxAge: time.Hour},
+			path:     "/swagger/index.html",
+			wantHSTS: "max-age=3600; includeSubDomains",
+			wantCSP:  myMw.SwaggerContentSecurityPolicy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok := func(w http.ResponseWriter, r *http.Request) {}
+
+			r := chi.NewRouter()
+			r.Use(myMw.SecurityHeaders(tt.opts))
+			r.Get("/api/v1/tasks", ok)
+			r.With(myMw.ContentSecurityPolicy(myMw.SwaggerContentSecurityPolicy)).Get("/swagger/*", ok)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			require.Equal(t, tt.wantHSTS, rr.Header().Get("Strict-Transport-Security"))
+			require.Equal(t, tt.wantCSP, rr.Header().Get("Content-Security-Policy"))
+			require.Equal(t, "nosniff", rr.Header().Get("X-Content-Type-Options"))
+			require.Equal(t, "DENY", rr.Header().Get("X-Frame-Options"))
+			require.Equal(t, "no-referrer", rr.Header().Get("Referrer-Policy"))
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the name of the tracer that creates the spans of the HTTP requests.
+const tracerName = "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1"
+
+// Tracing returns a middleware that serves every request within a server span.
+//
+// The span continues the trace that is propagated by the client in the traceparent header,
+// if any. It is named after the method and the chi route pattern, e.g. "GET /api/v1/tasks/{id}",
+// and is marked as failed if the response status is 5xx. The middleware must be used on the
+// root router before RequestLogger, so that the request logger gets the trace ID.
+func Tracing(tp trace.TracerProvider, propagator propagation.TextMapPropagator) func(http.Handler) http.Handler {
+	tracer := tp.Tracer(tracerName)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+			ctx, span := tracer.Start(
+				ctx,
+				r.Method,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(semconv.HTTPRequestMethodKey.String(r.Method), semconv.URLPath(r.URL.Path)),
+			)
+			defer span.End()
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+
+			if rctx := chi.RouteContext(ctx); rctx != nil && rctx.RoutePattern() != "" {
+				span.SetName(r.Method + " " + rctx.RoutePattern())
+				span.SetAttributes(semconv.HTTPRoute(rctx.RoutePattern()))
+			}
+
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+		})
+	}
+}
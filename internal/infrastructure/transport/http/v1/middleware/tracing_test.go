@@ -0,0 +1,124 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
+	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracing(t *testing.T) {
+	const (
+		traceID      = "4bf92f3577b34da6a3ce929d0e0e4736"
+		parentSpanID = "00f067aa0ba902b7"
+	)
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		traceparent string
+		wantName    string
+		wantStatus  codes.Code
+		wantRoute   string
+	}{
+		{
+			name:        "continues the propagated trace",
+			method:      http.MethodGet,
+			path:        "/api/v1/tasks/42",
+			traceparent: "00-" + traceID + "-" + parentSpanID + "-01",
+			wantName:    "GET /api/v1/tasks/{id}",
+			wantStatus:  codes.Unset,
+			wantRoute:   "/api/v1/tasks/{id}",
+		},
+		{
+			name:       "starts a new trace",
+			method:     http.MethodDelete,
+			path:       "/api/v1/tasks/42",
+			wantName:   "DELETE /api/v1/tasks/{id}",
+			wantStatus: codes.Error,
+			wantRoute:  "/api/v1/tasks/{id}",
+		},
+		{
+			name:       "unknown path",
+			method:     http.MethodGet,
+			path:       "/unknown",
+			wantName:   http.MethodGet,
+			wantStatus: codes.Unset,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+			var logged bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&logged, nil))
+
+			r := chi.NewRouter()
+			r.Use(myMw.Tracing(provider, propagation.TraceContext{}))
+			r.Use(myMw.RequestLogger(logger, myMw.AccessLogOptions{}))
+			r.NotFound(handlers.NotFound)
+			r.Get("/api/v1/tasks/{id}", func(w http.ResponseWriter, r *http.Request) {
+				slogx.FromContext(r.Context(), nil).Info("task found")
+				w.WriteHeader(http.StatusOK)
+			})
+			r.Delete("/api/v1/tasks/{id}", func(w http.ResponseWriter, r *http.Request) {
+				handlers.WriteError(w, r, handlers.ErrInternal)
+			})
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.traceparent != "" {
+				req.Header.Set("traceparent", tt.traceparent)
+			}
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+
+			span := spans[0]
+			require.Equal(t, tt.wantName, span.Name())
+			require.Equal(t, trace.SpanKindServer, span.SpanKind())
+			require.Equal(t, tt.wantStatus, span.Status().Code)
+			require.Contains(t, span.Attributes(), attribute.Int("http.response.status_code", rr.Code))
+
+			if tt.wantRoute != "" {
+				require.Contains(t, span.Attributes(), attribute.String("http.route", tt.wantRoute))
+			}
+
+			if tt.traceparent != "" {
+				require.Equal(t, traceID, span.SpanContext().TraceID().String())
+				require.Equal(t, parentSpanID, span.Parent().SpanID().String())
+			}
+
+			wantTraceID := span.SpanContext().TraceID().String()
+
+			if rr.Code >= http.StatusBadRequest {
+				var problem handlers.Problem
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+				require.Equal(t, wantTraceID, problem.TraceID)
+			}
+
+			for _, entry := range logEntries(t, &logged) {
+				require.Equal(t, wantTraceID, entry["trace_id"])
+			}
+		})
+	}
+}
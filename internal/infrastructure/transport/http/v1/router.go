@@ -3,17 +3,28 @@ package v1
 import (
 	"context"
 	"log/slog"
+	"net/http"
 	"time"
 
 	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	userModels "github.com/cyberbrain-dev/taskery-api/internal/domain/user/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/clientip"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/idempotency"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/ratelimit"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers"
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/auth"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/calendar"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/graphql"
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/task"
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/handlers/user"
 	myMw "github.com/cyberbrain-dev/taskery-api/internal/infrastructure/transport/http/v1/middleware"
 	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-playground/validator/v10"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type UserService interface {
@@ -23,16 +34,44 @@ type UserService interface {
 	ChangeEmail(ctx context.Context, id, newEmail, password string) error
 	ChangePassword(ctx context.Context, id, old, new string) error
 	Delete(ctx context.Context, id, password string) error
+	FindByIDs(ctx context.Context, ids []string) ([]*userModels.User, error)
 }
 
 type TaskService interface {
 	Create(ctx context.Context, cmd services.CreateTaskCommand) (string, error)
-	Update(ctx context.Context, id string, ownerID string, cmd services.UpdateTaskCommand) error
-	RemoveDeadline(ctx context.Context, id string, ownerID string) error
-	Complete(ctx context.Context, id string, ownerID string) error
-	Reopen(ctx context.Context, id string, ownerID string) error
-	FindByOwner(ctx context.Context, ownerID string) ([]*models.Task, error)
-	Delete(ctx context.Context, id string, ownerID string) error
+	Update(
+		ctx context.Context,
+		id string,
+		ownerID string,
+		cmd services.UpdateTaskCommand,
+		expectedVersion *int64,
+	) (int64, error)
+	RemoveDeadline(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+	Complete(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+	Reopen(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+	FindByID(ctx context.Context, id string, ownerID string) (*models.Task, error)
+	FindByIDs(ctx context.Context, ids []string, ownerID string) ([]*models.Task, error)
+	FindByOwner(ctx context.Context, ownerID string, query services.FindByOwnerQuery) ([]*models.Task, error)
+	Delete(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+	DeletePermanently(ctx context.Context, id string, ownerID string, expectedVersion *int64) error
+	Restore(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+	FindTrash(ctx context.Context, ownerID string) ([]*models.Task, error)
+	Search(ctx context.Context, ownerID string, query services.SearchTasksQuery) ([]services.TaskSearchResult, error)
+	Archive(ctx context.Context, id string, ownerID string, force bool, expectedVersion *int64) (int64, error)
+	Unarchive(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error)
+	ArchiveCompleted(ctx context.Context, ownerID string, olderThan time.Duration) (int64, error)
+	History(ctx context.Context, id string, ownerID string) ([]*models.TaskEvent, error)
+	Histories(ctx context.Context, ids []string, ownerID string) (map[string][]*models.TaskEvent, error)
+	Revert(ctx context.Context, id string, ownerID string, eventID string, expectedVersion *int64) (int64, error)
+	Batch(ctx context.Context, ownerID string, ops []services.BatchOperation, atomic bool) ([]error, error)
+	ChangesSince(ctx context.Context, ownerID string, since int64, limit int) (*services.TaskSyncPage, error)
+	Push(ctx context.Context, ownerID string, ops []services.SyncOperation) []services.SyncResult
+}
+
+type CalendarService interface {
+	RotateFeedToken(ctx context.Context, userID string) (string, error)
+	RevokeFeedToken(ctx context.Context, userID string) error
+	Feed(ctx context.Context, token string) ([]*models.Task, error)
 }
 
 type TokenProvider interface {
@@ -40,32 +79,125 @@ type TokenProvider interface {
 	Validate(token string) (string, error)
 }
 
+// RateLimits are the request rate limits of the route groups.
+type RateLimits struct {
+	Auth     ratelimit.Limit
+	Users    ratelimit.Limit
+	Tasks    ratelimit.Limit
+	Calendar ratelimit.Limit
+}
+
 type RouterOptions struct {
-	UserService UserService
-	TaskService TaskService
+	UserService     UserService
+	TaskService     TaskService
+	CalendarService CalendarService
 
 	Logger        *slog.Logger
 	TokenProvider TokenProvider
 	Validator     *validator.Validate
+	Clock         clock.Clock
+
+	IdempotencyStore idempotency.Store
+	IdempotencyTTL   time.Duration
+
+	AccessLog myMw.AccessLogOptions
+
+	// ClientIP resolves the IP addresses of the clients behind the trusted proxies.
+	// If it is nil, the address of the peer of a request is the address of its client.
+	ClientIP *clientip.Resolver
+
+	CORS            myMw.CORSOptions
+	SecurityHeaders myMw.SecurityHeadersOptions
+
+	// RequestBody limits the size of the request bodies and configures their decoding.
+	RequestBody handlers.DecodeOptions
+
+	// RateLimitStore keeps the rate limit buckets. The routes are not limited if it is nil.
+	RateLimitStore ratelimit.Store
+	RateLimits     RateLimits
+
+	// Metrics records the HTTP requests, if set.
+	// MetricsHandler is served on /metrics, if set.
+	Metrics        myMw.HTTPMetrics
+	MetricsHandler http.Handler
+
+	// LivenessHandler is served on /healthz and ReadinessHandler on /readyz, if set.
+	LivenessHandler  http.Handler
+	ReadinessHandler http.Handler
+
+	// TracerProvider traces the HTTP requests, if set.
+	// Propagator extracts the trace context propagated by the clients.
+	TracerProvider trace.TracerProvider
+	Propagator     propagation.TextMapPropagator
+
+	// TaskEvents streams the changes of tasks on /tasks/events, if set.
+	// TaskEventsHeartbeat is the interval of the heartbeats that keep the streams alive.
+	TaskEvents          task.ChangeSubscriber
+	TaskEventsHeartbeat time.Duration
+
+	// Socket configures the WebSocket channel on /ws, which is served if TaskEvents is set.
+	Socket task.SocketOptions
+
+	// GraphQL limits the queries of the GraphQL API on /graphql.
+	GraphQL graphql.Limits
 
-	Timeout time.Duration
+	Timeout      time.Duration
+	MaxBatchSize int
 }
 
 func NewRouter(opts RouterOptions) *chi.Mux {
 	r := chi.NewRouter()
 
 	r.Use(middleware.CleanPath)
-	r.Use(middleware.RequestID, middleware.Recoverer)
+	r.Use(middleware.RequestID)
+	r.Use(myMw.ClientIP(opts.ClientIP))
+	if opts.TracerProvider != nil {
+		r.Use(myMw.Tracing(opts.TracerProvider, opts.Propagator))
+	}
+	r.Use(myMw.RequestLogger(opts.Logger, opts.AccessLog))
+	if opts.Metrics != nil {
+		r.Use(myMw.Metrics(opts.Metrics))
+	}
+	r.Use(middleware.Recoverer)
+	r.Use(myMw.SecurityHeaders(opts.SecurityHeaders), myMw.CORS(opts.CORS))
+	r.Use(myMw.RequestBody(opts.RequestBody))
+
+	r.NotFound(handlers.NotFound)
+	r.MethodNotAllowed(handlers.MethodNotAllowed)
+
+	idempotent := myMw.Idempotency(opts.IdempotencyStore, opts.IdempotencyTTL, opts.Clock, opts.Logger)
+	idempotentKeyOnly := myMw.IdempotencyKeyOnly(opts.IdempotencyStore, opts.IdempotencyTTL, opts.Clock, opts.Logger)
+
+	rateLimit := func(group string, limit ratelimit.Limit) func(http.Handler) http.Handler {
+		if opts.RateLimitStore == nil {
+			return func(next http.Handler) http.Handler { return next }
+		}
+
+		return myMw.RateLimit(opts.RateLimitStore, group, limit, opts.Clock, opts.Logger)
+	}
+
+	if opts.MetricsHandler != nil {
+		r.Method("GET", "/metrics", opts.MetricsHandler)
+	}
+	if opts.LivenessHandler != nil {
+		r.Method("GET", "/healthz", opts.LivenessHandler)
+	}
+	if opts.ReadinessHandler != nil {
+		r.Method("GET", "/readyz", opts.ReadinessHandler)
+	}
 
 	r.Route("/api/v1", func(r chi.Router) {
 		r.Route("/auth", func(r chi.Router) {
-			r.Method("POST", "/register", auth.NewRegisterHandler(
+			r.Use(rateLimit("auth", opts.RateLimits.Auth))
+
+			r.With(idempotent).Method("POST", "/register", auth.NewRegisterHandler(
 				opts.UserService,
 				opts.Timeout,
 				opts.Logger,
 				opts.Validator,
 			))
-			r.Method("POST", "/login", auth.NewLoginHandler(
+			// the response to a login is not stored, since it carries the issued tokens
+			r.With(idempotentKeyOnly).Method("POST", "/login", auth.NewLoginHandler(
 				opts.UserService,
 				opts.Timeout,
 				opts.Logger,
@@ -75,6 +207,7 @@ func NewRouter(opts RouterOptions) *chi.Mux {
 
 		r.Group(func(r chi.Router) {
 			r.Use(myMw.JWTAuth(opts.TokenProvider, opts.Logger))
+			r.Use(rateLimit("users", opts.RateLimits.Users))
 			r.Method("PATCH", "/users", user.NewUpdateHandler(
 				opts.UserService,
 				opts.Timeout,
@@ -87,13 +220,48 @@ func NewRouter(opts RouterOptions) *chi.Mux {
 				opts.Logger,
 				opts.Validator,
 			))
+
+			r.Method("POST", "/calendar/token", calendar.NewRotateHandler(
+				opts.CalendarService,
+				opts.Timeout,
+				opts.Logger,
+				opts.Validator,
+			))
+			r.Method("DELETE", "/calendar/token", calendar.NewRevokeHandler(
+				opts.CalendarService,
+				opts.Timeout,
+				opts.Logger,
+				opts.Validator,
+			))
+		})
+
+		// the calendar clients can not send the bearer token, so a feed is authorized
+		// by the secret token in its path, which is kept out of the logs and the traces
+		r.Group(func(r chi.Router) {
+			r.Use(rateLimit("calendar", opts.RateLimits.Calendar))
+
+			r.With(myMw.RedactPath).Method("GET", "/calendar/{token}.ics", calendar.NewFeedHandler(
+				opts.CalendarService,
+				opts.Timeout,
+				opts.Logger,
+				opts.Validator,
+			))
 		})
 
 		r.Group(func(r chi.Router) {
 			r.Use(myMw.JWTAuth(opts.TokenProvider, opts.Logger))
+			r.Use(rateLimit("tasks", opts.RateLimits.Tasks))
+
+			r.With(idempotent).Method("POST", "/tasks", task.NewCreateHandler(
+				opts.TaskService,
+				opts.Timeout,
+				opts.Logger,
+				opts.Validator,
+			))
 
-			r.Method("POST", "/tasks", task.NewCreateHandler(
+			r.Method("POST", "/tasks/batch", task.NewBatchHandler(
 				opts.TaskService,
+				opts.MaxBatchSize,
 				opts.Timeout,
 				opts.Logger,
 				opts.Validator,
@@ -106,6 +274,44 @@ func NewRouter(opts RouterOptions) *chi.Mux {
 				opts.Validator,
 			))
 
+			r.Method("GET", "/tasks/trash", task.NewFindTrashHandler(
+				opts.TaskService,
+				opts.Timeout,
+				opts.Logger,
+				opts.Validator,
+			))
+
+			r.Method("GET", "/tasks/search", task.NewSearchHandler(
+				opts.TaskService,
+				opts.Timeout,
+				opts.Logger,
+				opts.Validator,
+			))
+
+			if opts.TaskEvents != nil {
+				r.Method("GET", "/tasks/events", task.NewEventsHandler(
+					opts.TaskEvents,
+					opts.TaskEventsHeartbeat,
+					opts.Logger,
+				))
+
+				r.Method("GET", "/ws", task.NewSocketHandler(
+					opts.TaskService,
+					opts.TaskEvents,
+					opts.Socket,
+					opts.Timeout,
+					opts.Logger,
+					opts.Validator,
+				))
+			}
+
+			r.Method("GET", "/tasks/{id}", task.NewFindByIDHandler(
+				opts.TaskService,
+				opts.Timeout,
+				opts.Logger,
+				opts.Validator,
+			))
+
 			r.Method("PATCH", "/tasks", task.NewUpdateHandler(
 				opts.TaskService,
 				opts.Timeout,
@@ -120,6 +326,55 @@ func NewRouter(opts RouterOptions) *chi.Mux {
 				opts.Validator,
 			))
 
+			r.Method("DELETE", "/tasks/{id}", task.NewDeleteHandler(
+				opts.TaskService,
+				opts.Timeout,
+				opts.Logger,
+				opts.Validator,
+			))
+
+			r.Method("POST", "/tasks/{id}/restore", task.NewRestoreHandler(
+				opts.TaskService,
+				opts.Timeout,
+				opts.Logger,
+				opts.Validator,
+			))
+
+			r.Method("POST", "/tasks/archive", task.NewArchiveCompletedHandler(
+				opts.TaskService,
+				opts.Timeout,
+				opts.Logger,
+				opts.Validator,
+			))
+
+			r.Method("POST", "/tasks/{id}/archive", task.NewArchiveHandler(
+				opts.TaskService,
+				opts.Timeout,
+				opts.Logger,
+				opts.Validator,
+			))
+
+			r.Method("POST", "/tasks/{id}/unarchive", task.NewUnarchiveHandler(
+				opts.TaskService,
+				opts.Timeout,
+				opts.Logger,
+				opts.Validator,
+			))
+
+			r.Method("GET", "/tasks/{id}/history", task.NewHistoryHandler(
+				opts.TaskService,
+				opts.Timeout,
+				opts.Logger,
+				opts.Validator,
+			))
+
+			r.Method("POST", "/tasks/{id}/revert", task.NewRevertHandler(
+				opts.TaskService,
+				opts.Timeout,
+				opts.Logger,
+				opts.Validator,
+			))
+
 			r.Method("PATCH", "/tasks/complete", task.NewCompleteHandler(
 				opts.TaskService,
 				opts.Timeout,
@@ -140,6 +395,30 @@ func NewRouter(opts RouterOptions) *chi.Mux {
 				opts.Logger,
 				opts.Validator,
 			))
+
+			r.Method("GET", "/sync", task.NewSyncHandler(
+				opts.TaskService,
+				opts.Timeout,
+				opts.Logger,
+				opts.Validator,
+			))
+
+			r.With(idempotent).Method("POST", "/sync", task.NewSyncPushHandler(
+				opts.TaskService,
+				opts.MaxBatchSize,
+				opts.Timeout,
+				opts.Logger,
+				opts.Validator,
+			))
+
+			r.Method("POST", "/graphql", graphql.NewHandler(
+				opts.TaskService,
+				opts.UserService,
+				opts.GraphQL,
+				opts.Timeout,
+				opts.Logger,
+				opts.Validator,
+			))
 		})
 	})
 
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
+)
+
+// CalendarService is a service that manages the calendar feeds of the users.
+//
+// A feed is a read-only list of the tasks with deadlines that the calendar clients subscribe to.
+// They can not send the bearer tokens, so a feed is protected by a secret token of its own,
+// which is a part of the URL of the feed. Only the hash of the token is stored,
+// so the token is returned only once, when it is issued.
+type CalendarService struct {
+	feedsRepo CalendarFeedRepository
+	tasksRepo TaskRepository
+	clock     clock.Clock
+}
+
+// CalendarFeedRepository defines the methods for managing the feed tokens of the users
+// in a persistent storage. A user has at most one feed token.
+type CalendarFeedRepository interface {
+	// Save stores the hash of the feed token of the user, replacing the previous one, if any.
+	// Returns ErrCalendarFeedRepoUserNotFound if the user does not exist.
+	Save(ctx context.Context, userID string, tokenHash string, createdAt time.Time) error
+
+	// FindUserID returns the id of the user whose feed token has the given hash.
+	// Returns ErrCalendarFeedRepoNotFound if there is no such token.
+	FindUserID(ctx context.Context, tokenHash string) (string, error)
+
+	// Delete removes the feed token of the user. It does nothing if the user has no token.
+	Delete(ctx context.Context, userID string) error
+}
+
+// calendarFeedTokenSize is the number of random bytes in a feed token.
+const calendarFeedTokenSize = 32
+
+// ErrCalendarFeedRepositoryNil is an error that indicates that the calendar feed repository
+// that is passed to NewCalendarService is nil.
+var ErrCalendarFeedRepositoryNil = errors.New("calendar feed repository is nil")
+
+// Repository-level errors
+var (
+	// ErrCalendarFeedRepoNotFound is returned by repository if the feed token was not found there
+	ErrCalendarFeedRepoNotFound = errors.New("calendar feed was not found in the repository")
+
+	// ErrCalendarFeedRepoUserNotFound is returned by repository
+	// if the user that the feed token is issued to was not found
+	ErrCalendarFeedRepoUserNotFound = errors.New("user of calendar feed was not found in the repository")
+)
+
+// Application-level errors
+var (
+	// ErrCalendarFeedNotFound is returned by CalendarService if the feed token is unknown or revoked
+	ErrCalendarFeedNotFound = errors.New("calendar feed was not found")
+
+	// ErrCalendarFeedRotateFailed is returned by CalendarService if an internal error occurred during issuing a feed token
+	ErrCalendarFeedRotateFailed = errors.New("failed to rotate calendar feed token")
+
+	// ErrCalendarFeedRevokeFailed is returned by CalendarService if an internal error occurred during revoking a feed token
+	ErrCalendarFeedRevokeFailed = errors.New("failed to revoke calendar feed token")
+
+	// ErrCalendarFeedFailed is returned by CalendarService if an internal error occurred during loading a feed
+	ErrCalendarFeedFailed = errors.New("failed to load calendar feed")
+)
+
+// NewCalendarService creates a new instance of CalendarService with
+// given feed and task repositories and clock. In case any of them is nil,
+// NewCalendarService returns nil and an error.
+func NewCalendarService(
+	feedsRepo CalendarFeedRepository,
+	tasksRepo TaskRepository,
+	clk clock.Clock,
+) (*CalendarService, error) {
+	if feedsRepo == nil {
+		return nil, ErrCalendarFeedRepositoryNil
+	}
+
+	if tasksRepo == nil {
+		return nil, ErrTaskRepositoryNil
+	}
+
+	if clk == nil {
+		return nil, ErrClockNil
+	}
+
+	return &CalendarService{
+		feedsRepo: feedsRepo,
+		tasksRepo: tasksRepo,
+		clock:     clk,
+	}, nil
+}
+
+// RotateFeedToken issues a new feed token to the user and returns it.
+// The previous token of the user, if any, stops working.
+//
+// RotateFeedToken returns ErrUserNotFound if the user does not exist,
+// or ErrCalendarFeedRotateFailed if an internal error occurred.
+func (cs *CalendarService) RotateFeedToken(ctx context.Context, userID string) (string, error) {
+	raw := make([]byte, calendarFeedTokenSize)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("%w: %s", ErrCalendarFeedRotateFailed, err)
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	err := cs.feedsRepo.Save(ctx, userID, hashFeedToken(token), cs.clock.Now())
+	if errors.Is(err, ErrCalendarFeedRepoUserNotFound) {
+		return "", ErrUserNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrCalendarFeedRotateFailed, err)
+	}
+
+	return token, nil
+}
+
+// RevokeFeedToken revokes the feed token of the user, so that the feed is no longer available.
+// Revoking the token of a user that has none is not an error.
+//
+// RevokeFeedToken returns ErrCalendarFeedRevokeFailed if an internal error occurred.
+func (cs *CalendarService) RevokeFeedToken(ctx context.Context, userID string) error {
+	if err := cs.feedsRepo.Delete(ctx, userID); err != nil {
+		return fmt.Errorf("%w: %s", ErrCalendarFeedRevokeFailed, err)
+	}
+
+	return nil
+}
+
+// Feed returns the active tasks with deadlines of the user that the feed token is issued to.
+//
+// Feed returns ErrCalendarFeedNotFound if the token is unknown or revoked,
+// or ErrCalendarFeedFailed if an internal error occurred.
+func (cs *CalendarService) Feed(ctx context.Context, token string) ([]*models.Task, error) {
+	if token == "" {
+		return nil, ErrCalendarFeedNotFound
+	}
+
+	userID, err := cs.feedsRepo.FindUserID(ctx, hashFeedToken(token))
+	if errors.Is(err, ErrCalendarFeedRepoNotFound) {
+		return nil, ErrCalendarFeedNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrCalendarFeedFailed, err)
+	}
+
+	tasks, err := cs.tasksRepo.FindWithDeadlines(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrCalendarFeedFailed, err)
+	}
+
+	return tasks, nil
+}
+
+// hashFeedToken returns the hash of the feed token that is stored instead of the token itself.
+// The token is random and long enough, so a fast hash without salt does not weaken it.
+func hashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,269 @@
+package services_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/cyberbrain-dev/taskery-api/internal/services/mocks"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCalendarService(t *testing.T) {
+	tests := []struct {
+		name      string
+		feedsRepo services.CalendarFeedRepository
+		tasksRepo services.TaskRepository
+		clock     clock.Clock
+		wantErr   error
+	}{
+		{
+			name:      "success",
+			feedsRepo: new(mocks.CalendarFeedRepository),
+			tasksRepo: new(mocks.TaskRepository),
+			clock:     clock.Real{},
+			wantErr:   nil,
+		},
+		{
+			name:      "nil feed repository",
+			feedsRepo: nil,
+			tasksRepo: new(mocks.TaskRepository),
+			clock:     clock.Real{},
+			wantErr:   services.ErrCalendarFeedRepositoryNil,
+		},
+		{
+			name:      "nil task repository",
+			feedsRepo: new(mocks.CalendarFeedRepository),
+			tasksRepo: nil,
+			clock:     clock.Real{},
+			wantErr:   services.ErrTaskRepositoryNil,
+		},
+		{
+			name:      "nil clock",
+			feedsRepo: new(mocks.CalendarFeedRepository),
+			tasksRepo: new(mocks.TaskRepository),
+			clock:     nil,
+			wantErr:   services.ErrClockNil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs, err := services.NewCalendarService(tt.feedsRepo, tt.tasksRepo, tt.clock)
+			if tt.wantErr != nil {
+				require.Nil(t, cs)
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, cs)
+		})
+	}
+}
+
+func TestCalendarService_RotateFeedToken(t *testing.T) {
+	userID := uuid.New().String()
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		var savedHash string
+
+		feeds := new(mocks.CalendarFeedRepository)
+		feeds.On("Save", mock.Anything, userID, mock.AnythingOfType("string"), now).
+			Run(func(args mock.Arguments) { savedHash = args.String(2) }).
+			Twice().
+			Return(nil)
+
+		service, err := services.NewCalendarService(feeds, new(mocks.TaskRepository), clock.NewFake(now))
+		require.NoError(t, err)
+
+		token, err := service.RotateFeedToken(context.Background(), userID)
+		require.NoError(t, err)
+		require.NotEmpty(t, token)
+
+		sum := sha256.Sum256([]byte(token))
+		require.Equal(t, hex.EncodeToString(sum[:]), savedHash)
+
+		rotated, err := service.RotateFeedToken(context.Background(), userID)
+		require.NoError(t, err)
+		require.NotEqual(t, token, rotated)
+
+		feeds.AssertExpectations(t)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		feeds := new(mocks.CalendarFeedRepository)
+		feeds.On("Save", mock.Anything, userID, mock.Anything, now).
+			Once().
+			Return(services.ErrCalendarFeedRepoUserNotFound)
+
+		service, err := services.NewCalendarService(feeds, new(mocks.TaskRepository), clock.NewFake(now))
+		require.NoError(t, err)
+
+		token, err := service.RotateFeedToken(context.Background(), userID)
+		require.ErrorIs(t, err, services.ErrUserNotFound)
+		require.Empty(t, token)
+
+		feeds.AssertExpectations(t)
+	})
+
+	t.Run("internal db error", func(t *testing.T) {
+		feeds := new(mocks.CalendarFeedRepository)
+		feeds.On("Save", mock.Anything, userID, mock.Anything, now).
+			Once().
+			Return(errors.New("failed to connect to db"))
+
+		service, err := services.NewCalendarService(feeds, new(mocks.TaskRepository), clock.NewFake(now))
+		require.NoError(t, err)
+
+		token, err := service.RotateFeedToken(context.Background(), userID)
+		require.ErrorIs(t, err, services.ErrCalendarFeedRotateFailed)
+		require.Empty(t, token)
+
+		feeds.AssertExpectations(t)
+	})
+}
+
+func TestCalendarService_RevokeFeedToken(t *testing.T) {
+	userID := uuid.New().String()
+
+	tests := []struct {
+		name       string
+		wantErr    error
+		mocksSetup func(feeds *mocks.CalendarFeedRepository)
+	}{
+		{
+			name:    "success",
+			wantErr: nil,
+			mocksSetup: func(feeds *mocks.CalendarFeedRepository) {
+				feeds.On("Delete", mock.Anything, userID).Once().Return(nil)
+			},
+		},
+		{
+			name:    "internal db error",
+			wantErr: services.ErrCalendarFeedRevokeFailed,
+			mocksSetup: func(feeds *mocks.CalendarFeedRepository) {
+				feeds.On("Delete", mock.Anything, userID).
+					Once().
+					Return(errors.New("failed to connect to db"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feeds := new(mocks.CalendarFeedRepository)
+			tt.mocksSetup(feeds)
+
+			service, err := services.NewCalendarService(feeds, new(mocks.TaskRepository), clock.Real{})
+			require.NoError(t, err)
+
+			err = service.RevokeFeedToken(context.Background(), userID)
+			require.ErrorIs(t, err, tt.wantErr)
+
+			feeds.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCalendarService_Feed(t *testing.T) {
+	const token = "feed-token"
+
+	sum := sha256.Sum256([]byte(token))
+	tokenHash := hex.EncodeToString(sum[:])
+
+	ownerID := uuid.New()
+
+	withDeadline, err := models.NewTask("with deadline", "", ownerID, clock.Real{})
+	require.NoError(t, err)
+	require.NoError(t, withDeadline.SetDeadline(time.Now().Add(time.Hour), clock.Real{}))
+
+	tests := []struct {
+		name       string
+		token      string
+		want       []*models.Task
+		wantErr    error
+		mocksSetup func(feeds *mocks.CalendarFeedRepository, tasks *mocks.TaskRepository)
+	}{
+		{
+			name:  "success",
+			token: token,
+			want:  []*models.Task{withDeadline},
+			mocksSetup: func(feeds *mocks.CalendarFeedRepository, tasks *mocks.TaskRepository) {
+				feeds.On("FindUserID", mock.Anything, tokenHash).Once().Return(ownerID.String(), nil)
+				tasks.On("FindWithDeadlines", mock.Anything, ownerID.String()).
+					Once().
+					Return([]*models.Task{withDeadline}, nil)
+			},
+		},
+		{
+			name:       "empty token",
+			token:      "",
+			wantErr:    services.ErrCalendarFeedNotFound,
+			mocksSetup: func(feeds *mocks.CalendarFeedRepository, tasks *mocks.TaskRepository) {},
+		},
+		{
+			name:    "unknown token",
+			token:   token,
+			wantErr: services.ErrCalendarFeedNotFound,
+			mocksSetup: func(feeds *mocks.CalendarFeedRepository, tasks *mocks.TaskRepository) {
+				feeds.On("FindUserID", mock.Anything, tokenHash).
+					Once().
+					Return("", services.ErrCalendarFeedRepoNotFound)
+			},
+		},
+		{
+			name:    "feed lookup error",
+			token:   token,
+			wantErr: services.ErrCalendarFeedFailed,
+			mocksSetup: func(feeds *mocks.CalendarFeedRepository, tasks *mocks.TaskRepository) {
+				feeds.On("FindUserID", mock.Anything, tokenHash).
+					Once().
+					Return("", errors.New("failed to connect to db"))
+			},
+		},
+		{
+			name:    "tasks lookup error",
+			token:   token,
+			wantErr: services.ErrCalendarFeedFailed,
+			mocksSetup: func(feeds *mocks.CalendarFeedRepository, tasks *mocks.TaskRepository) {
+				feeds.On("FindUserID", mock.Anything, tokenHash).Once().Return(ownerID.String(), nil)
+				tasks.On("FindWithDeadlines", mock.Anything, ownerID.String()).
+					Once().
+					Return(nil, errors.New("failed to connect to db"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feeds := new(mocks.CalendarFeedRepository)
+			tasks := new(mocks.TaskRepository)
+			tt.mocksSetup(feeds, tasks)
+
+			service, err := services.NewCalendarService(feeds, tasks, clock.Real{})
+			require.NoError(t, err)
+
+			got, err := service.Feed(context.Background(), tt.token)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.want, got)
+			}
+
+			feeds.AssertExpectations(t)
+			tasks.AssertExpectations(t)
+		})
+	}
+}
@@ -6,19 +6,1037 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/vo"
 	models0 "github.com/cyberbrain-dev/taskery-api/internal/domain/user/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
 	mock "github.com/stretchr/testify/mock"
 )
 
+// NewCalendarFeedRepository creates a new instance of CalendarFeedRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCalendarFeedRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CalendarFeedRepository {
+	mock := &CalendarFeedRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// CalendarFeedRepository is an autogenerated mock type for the CalendarFeedRepository type
+type CalendarFeedRepository struct {
+	mock.Mock
+}
+
+type CalendarFeedRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *CalendarFeedRepository) EXPECT() *CalendarFeedRepository_Expecter {
+	return &CalendarFeedRepository_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function for the type CalendarFeedRepository
+func (_mock *CalendarFeedRepository) Delete(ctx context.Context, userID string) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// CalendarFeedRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type CalendarFeedRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *CalendarFeedRepository_Expecter) Delete(ctx interface{}, userID interface{}) *CalendarFeedRepository_Delete_Call {
+	return &CalendarFeedRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, userID)}
+}
+
+func (_c *CalendarFeedRepository_Delete_Call) Run(run func(ctx context.Context, userID string)) *CalendarFeedRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *CalendarFeedRepository_Delete_Call) Return(err error) *CalendarFeedRepository_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *CalendarFeedRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, userID string) error) *CalendarFeedRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindUserID provides a mock function for the type CalendarFeedRepository
+func (_mock *CalendarFeedRepository) FindUserID(ctx context.Context, tokenHash string) (string, error) {
+	ret := _mock.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUserID")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return returnFunc(ctx, tokenHash)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// CalendarFeedRepository_FindUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindUserID'
+type CalendarFeedRepository_FindUserID_Call struct {
+	*mock.Call
+}
+
+// FindUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenHash string
+func (_e *CalendarFeedRepository_Expecter) FindUserID(ctx interface{}, tokenHash interface{}) *CalendarFeedRepository_FindUserID_Call {
+	return &CalendarFeedRepository_FindUserID_Call{Call: _e.mock.On("FindUserID", ctx, tokenHash)}
+}
+
+func (_c *CalendarFeedRepository_FindUserID_Call) Run(run func(ctx context.Context, tokenHash string)) *CalendarFeedRepository_FindUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *CalendarFeedRepository_FindUserID_Call) Return(s string, err error) *CalendarFeedRepository_FindUserID_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *CalendarFeedRepository_FindUserID_Call) RunAndReturn(run func(ctx context.Context, tokenHash string) (string, error)) *CalendarFeedRepository_FindUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function for the type CalendarFeedRepository
+func (_mock *CalendarFeedRepository) Save(ctx context.Context, userID string, tokenHash string, createdAt time.Time) error {
+	ret := _mock.Called(ctx, userID, tokenHash, createdAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = returnFunc(ctx, userID, tokenHash, createdAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// CalendarFeedRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type CalendarFeedRepository_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - tokenHash string
+//   - createdAt time.Time
+func (_e *CalendarFeedRepository_Expecter) Save(ctx interface{}, userID interface{}, tokenHash interface{}, createdAt interface{}) *CalendarFeedRepository_Save_Call {
+	return &CalendarFeedRepository_Save_Call{Call: _e.mock.On("Save", ctx, userID, tokenHash, createdAt)}
+}
+
+func (_c *CalendarFeedRepository_Save_Call) Run(run func(ctx context.Context, userID string, tokenHash string, createdAt time.Time)) *CalendarFeedRepository_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *CalendarFeedRepository_Save_Call) Return(err error) *CalendarFeedRepository_Save_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *CalendarFeedRepository_Save_Call) RunAndReturn(run func(ctx context.Context, userID string, tokenHash string, createdAt time.Time) error) *CalendarFeedRepository_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewTaskRepository creates a new instance of TaskRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewTaskRepository(t interface {
+func NewTaskRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TaskRepository {
+	mock := &TaskRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// TaskRepository is an autogenerated mock type for the TaskRepository type
+type TaskRepository struct {
+	mock.Mock
+}
+
+type TaskRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TaskRepository) EXPECT() *TaskRepository_Expecter {
+	return &TaskRepository_Expecter{mock: &_m.Mock}
+}
+
+// ArchiveCompletedBefore provides a mock function for the type TaskRepository
+func (_mock *TaskRepository) ArchiveCompletedBefore(ctx context.Context, ownerID string, completedBefore time.Time, archivedAt time.Time) ([]*models.Task, error) {
+	ret := _mock.Called(ctx, ownerID, completedBefore, archivedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArchiveCompletedBefore")
+	}
+
+	var r0 []*models.Task
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) ([]*models.Task, error)); ok {
+		return returnFunc(ctx, ownerID, completedBefore, archivedAt)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) []*models.Task); ok {
+		r0 = returnFunc(ctx, ownerID, completedBefore, archivedAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Task)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = returnFunc(ctx, ownerID, completedBefore, archivedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TaskRepository_ArchiveCompletedBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchiveCompletedBefore'
+type TaskRepository_ArchiveCompletedBefore_Call struct {
+	*mock.Call
+}
+
+// ArchiveCompletedBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ownerID string
+//   - completedBefore time.Time
+//   - archivedAt time.Time
+func (_e *TaskRepository_Expecter) ArchiveCompletedBefore(ctx interface{}, ownerID interface{}, completedBefore interface{}, archivedAt interface{}) *TaskRepository_ArchiveCompletedBefore_Call {
+	return &TaskRepository_ArchiveCompletedBefore_Call{Call: _e.mock.On("ArchiveCompletedBefore", ctx, ownerID, completedBefore, archivedAt)}
+}
+
+func (_c *TaskRepository_ArchiveCompletedBefore_Call) Run(run func(ctx context.Context, ownerID string, completedBefore time.Time, archivedAt time.Time)) *TaskRepository_ArchiveCompletedBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskRepository_ArchiveCompletedBefore_Call) Return(tasks []*models.Task, err error) *TaskRepository_ArchiveCompletedBefore_Call {
+	_c.Call.Return(tasks, err)
+	return _c
+}
+
+func (_c *TaskRepository_ArchiveCompletedBefore_Call) RunAndReturn(run func(ctx context.Context, ownerID string, completedBefore time.Time, archivedAt time.Time) ([]*models.Task, error)) *TaskRepository_ArchiveCompletedBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type TaskRepository
+func (_mock *TaskRepository) Create(ctx context.Context, task *models.Task) error {
+	ret := _mock.Called(ctx, task)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Task) error); ok {
+		r0 = returnFunc(ctx, task)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// TaskRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type TaskRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - task *models.Task
+func (_e *TaskRepository_Expecter) Create(ctx interface{}, task interface{}) *TaskRepository_Create_Call {
+	return &TaskRepository_Create_Call{Call: _e.mock.On("Create", ctx, task)}
+}
+
+func (_c *TaskRepository_Create_Call) Run(run func(ctx context.Context, task *models.Task)) *TaskRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.Task
+		if args[1] != nil {
+			arg1 = args[1].(*models.Task)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskRepository_Create_Call) Return(err error) *TaskRepository_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *TaskRepository_Create_Call) RunAndReturn(run func(ctx context.Context, task *models.Task) error) *TaskRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type TaskRepository
+func (_mock *TaskRepository) Delete(ctx context.Context, id string, version int64) error {
+	ret := _mock.Called(ctx, id, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = returnFunc(ctx, id, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// TaskRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type TaskRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - version int64
+func (_e *TaskRepository_Expecter) Delete(ctx interface{}, id interface{}, version interface{}) *TaskRepository_Delete_Call {
+	return &TaskRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id, version)}
+}
+
+func (_c *TaskRepository_Delete_Call) Run(run func(ctx context.Context, id string, version int64)) *TaskRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskRepository_Delete_Call) Return(err error) *TaskRepository_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *TaskRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, id string, version int64) error) *TaskRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTrashedBefore provides a mock function for the type TaskRepository
+func (_mock *TaskRepository) DeleteTrashedBefore(ctx context.Context, before time.Time) ([]*models.Task, error) {
+	ret := _mock.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTrashedBefore")
+	}
+
+	var r0 []*models.Task
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) ([]*models.Task, error)); ok {
+		return returnFunc(ctx, before)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) []*models.Task); ok {
+		r0 = returnFunc(ctx, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Task)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TaskRepository_DeleteTrashedBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTrashedBefore'
+type TaskRepository_DeleteTrashedBefore_Call struct {
+	*mock.Call
+}
+
+// DeleteTrashedBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *TaskRepository_Expecter) DeleteTrashedBefore(ctx interface{}, before interface{}) *TaskRepository_DeleteTrashedBefore_Call {
+	return &TaskRepository_DeleteTrashedBefore_Call{Call: _e.mock.On("DeleteTrashedBefore", ctx, before)}
+}
+
+func (_c *TaskRepository_DeleteTrashedBefore_Call) Run(run func(ctx context.Context, before time.Time)) *TaskRepository_DeleteTrashedBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskRepository_DeleteTrashedBefore_Call) Return(tasks []*models.Task, err error) *TaskRepository_DeleteTrashedBefore_Call {
+	_c.Call.Return(tasks, err)
+	return _c
+}
+
+func (_c *TaskRepository_DeleteTrashedBefore_Call) RunAndReturn(run func(ctx context.Context, before time.Time) ([]*models.Task, error)) *TaskRepository_DeleteTrashedBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function for the type TaskRepository
+func (_mock *TaskRepository) FindByID(ctx context.Context, id string) (*models.Task, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *models.Task
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*models.Task, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *models.Task); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Task)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TaskRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type TaskRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *TaskRepository_Expecter) FindByID(ctx interface{}, id interface{}) *TaskRepository_FindByID_Call {
+	return &TaskRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
+}
+
+func (_c *TaskRepository_FindByID_Call) Run(run func(ctx context.Context, id string)) *TaskRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskRepository_FindByID_Call) Return(task *models.Task, err error) *TaskRepository_FindByID_Call {
+	_c.Call.Return(task, err)
+	return _c
+}
+
+func (_c *TaskRepository_FindByID_Call) RunAndReturn(run func(ctx context.Context, id string) (*models.Task, error)) *TaskRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByIDs provides a mock function for the type TaskRepository
+func (_mock *TaskRepository) FindByIDs(ctx context.Context, ids []string) ([]*models.Task, error) {
+	ret := _mock.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByIDs")
+	}
+
+	var r0 []*models.Task
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) ([]*models.Task, error)); ok {
+		return returnFunc(ctx, ids)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) []*models.Task); ok {
+		r0 = returnFunc(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Task)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TaskRepository_FindByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByIDs'
+type TaskRepository_FindByIDs_Call struct {
+	*mock.Call
+}
+
+// FindByIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []string
+func (_e *TaskRepository_Expecter) FindByIDs(ctx interface{}, ids interface{}) *TaskRepository_FindByIDs_Call {
+	return &TaskRepository_FindByIDs_Call{Call: _e.mock.On("FindByIDs", ctx, ids)}
+}
+
+func (_c *TaskRepository_FindByIDs_Call) Run(run func(ctx context.Context, ids []string)) *TaskRepository_FindByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskRepository_FindByIDs_Call) Return(tasks []*models.Task, err error) *TaskRepository_FindByIDs_Call {
+	_c.Call.Return(tasks, err)
+	return _c
+}
+
+func (_c *TaskRepository_FindByIDs_Call) RunAndReturn(run func(ctx context.Context, ids []string) ([]*models.Task, error)) *TaskRepository_FindByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByOwner provides a mock function for the type TaskRepository
+func (_mock *TaskRepository) FindByOwner(ctx context.Context, ownerID string, query services.FindByOwnerQuery) ([]*models.Task, error) {
+	ret := _mock.Called(ctx, ownerID, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByOwner")
+	}
+
+	var r0 []*models.Task
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, services.FindByOwnerQuery) ([]*models.Task, error)); ok {
+		return returnFunc(ctx, ownerID, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, services.FindByOwnerQuery) []*models.Task); ok {
+		r0 = returnFunc(ctx, ownerID, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Task)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, services.FindByOwnerQuery) error); ok {
+		r1 = returnFunc(ctx, ownerID, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TaskRepository_FindByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByOwner'
+type TaskRepository_FindByOwner_Call struct {
+	*mock.Call
+}
+
+// FindByOwner is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ownerID string
+//   - query services.FindByOwnerQuery
+func (_e *TaskRepository_Expecter) FindByOwner(ctx interface{}, ownerID interface{}, query interface{}) *TaskRepository_FindByOwner_Call {
+	return &TaskRepository_FindByOwner_Call{Call: _e.mock.On("FindByOwner", ctx, ownerID, query)}
+}
+
+func (_c *TaskRepository_FindByOwner_Call) Run(run func(ctx context.Context, ownerID string, query services.FindByOwnerQuery)) *TaskRepository_FindByOwner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 services.FindByOwnerQuery
+		if args[2] != nil {
+			arg2 = args[2].(services.FindByOwnerQuery)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskRepository_FindByOwner_Call) Return(tasks []*models.Task, err error) *TaskRepository_FindByOwner_Call {
+	_c.Call.Return(tasks, err)
+	return _c
+}
+
+func (_c *TaskRepository_FindByOwner_Call) RunAndReturn(run func(ctx context.Context, ownerID string, query services.FindByOwnerQuery) ([]*models.Task, error)) *TaskRepository_FindByOwner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindChangedSince provides a mock function for the type TaskRepository
+func (_mock *TaskRepository) FindChangedSince(ctx context.Context, ownerID string, since int64, limit int) ([]services.TaskSyncChange, error) {
+	ret := _mock.Called(ctx, ownerID, since, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindChangedSince")
+	}
+
+	var r0 []services.TaskSyncChange
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64, int) ([]services.TaskSyncChange, error)); ok {
+		return returnFunc(ctx, ownerID, since, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64, int) []services.TaskSyncChange); ok {
+		r0 = returnFunc(ctx, ownerID, since, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]services.TaskSyncChange)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int64, int) error); ok {
+		r1 = returnFunc(ctx, ownerID, since, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TaskRepository_FindChangedSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindChangedSince'
+type TaskRepository_FindChangedSince_Call struct {
+	*mock.Call
+}
+
+// FindChangedSince is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ownerID string
+//   - since int64
+//   - limit int
+func (_e *TaskRepository_Expecter) FindChangedSince(ctx interface{}, ownerID interface{}, since interface{}, limit interface{}) *TaskRepository_FindChangedSince_Call {
+	return &TaskRepository_FindChangedSince_Call{Call: _e.mock.On("FindChangedSince", ctx, ownerID, since, limit)}
+}
+
+func (_c *TaskRepository_FindChangedSince_Call) Run(run func(ctx context.Context, ownerID string, since int64, limit int)) *TaskRepository_FindChangedSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskRepository_FindChangedSince_Call) Return(taskSyncChanges []services.TaskSyncChange, err error) *TaskRepository_FindChangedSince_Call {
+	_c.Call.Return(taskSyncChanges, err)
+	return _c
+}
+
+func (_c *TaskRepository_FindChangedSince_Call) RunAndReturn(run func(ctx context.Context, ownerID string, since int64, limit int) ([]services.TaskSyncChange, error)) *TaskRepository_FindChangedSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindWithDeadlines provides a mock function for the type TaskRepository
+func (_mock *TaskRepository) FindWithDeadlines(ctx context.Context, ownerID string) ([]*models.Task, error) {
+	ret := _mock.Called(ctx, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindWithDeadlines")
+	}
+
+	var r0 []*models.Task
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*models.Task, error)); ok {
+		return returnFunc(ctx, ownerID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*models.Task); ok {
+		r0 = returnFunc(ctx, ownerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Task)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TaskRepository_FindWithDeadlines_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindWithDeadlines'
+type TaskRepository_FindWithDeadlines_Call struct {
+	*mock.Call
+}
+
+// FindWithDeadlines is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ownerID string
+func (_e *TaskRepository_Expecter) FindWithDeadlines(ctx interface{}, ownerID interface{}) *TaskRepository_FindWithDeadlines_Call {
+	return &TaskRepository_FindWithDeadlines_Call{Call: _e.mock.On("FindWithDeadlines", ctx, ownerID)}
+}
+
+func (_c *TaskRepository_FindWithDeadlines_Call) Run(run func(ctx context.Context, ownerID string)) *TaskRepository_FindWithDeadlines_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskRepository_FindWithDeadlines_Call) Return(tasks []*models.Task, err error) *TaskRepository_FindWithDeadlines_Call {
+	_c.Call.Return(tasks, err)
+	return _c
+}
+
+func (_c *TaskRepository_FindWithDeadlines_Call) RunAndReturn(run func(ctx context.Context, ownerID string) ([]*models.Task, error)) *TaskRepository_FindWithDeadlines_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Search provides a mock function for the type TaskRepository
+func (_mock *TaskRepository) Search(ctx context.Context, ownerID string, query vo.SearchQuery, limit int, includeArchived bool) ([]services.TaskSearchResult, error) {
+	ret := _mock.Called(ctx, ownerID, query, limit, includeArchived)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []services.TaskSearchResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, vo.SearchQuery, int, bool) ([]services.TaskSearchResult, error)); ok {
+		return returnFunc(ctx, ownerID, query, limit, includeArchived)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, vo.SearchQuery, int, bool) []services.TaskSearchResult); ok {
+		r0 = returnFunc(ctx, ownerID, query, limit, includeArchived)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]services.TaskSearchResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, vo.SearchQuery, int, bool) error); ok {
+		r1 = returnFunc(ctx, ownerID, query, limit, includeArchived)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TaskRepository_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type TaskRepository_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ownerID string
+//   - query vo.SearchQuery
+//   - limit int
+//   - includeArchived bool
+func (_e *TaskRepository_Expecter) Search(ctx interface{}, ownerID interface{}, query interface{}, limit interface{}, includeArchived interface{}) *TaskRepository_Search_Call {
+	return &TaskRepository_Search_Call{Call: _e.mock.On("Search", ctx, ownerID, query, limit, includeArchived)}
+}
+
+func (_c *TaskRepository_Search_Call) Run(run func(ctx context.Context, ownerID string, query vo.SearchQuery, limit int, includeArchived bool)) *TaskRepository_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 vo.SearchQuery
+		if args[2] != nil {
+			arg2 = args[2].(vo.SearchQuery)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		var arg4 bool
+		if args[4] != nil {
+			arg4 = args[4].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskRepository_Search_Call) Return(taskSearchResults []services.TaskSearchResult, err error) *TaskRepository_Search_Call {
+	_c.Call.Return(taskSearchResults, err)
+	return _c
+}
+
+func (_c *TaskRepository_Search_Call) RunAndReturn(run func(ctx context.Context, ownerID string, query vo.SearchQuery, limit int, includeArchived bool) ([]services.TaskSearchResult, error)) *TaskRepository_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type TaskRepository
+func (_mock *TaskRepository) Update(ctx context.Context, task *models.Task) error {
+	ret := _mock.Called(ctx, task)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Task) error); ok {
+		r0 = returnFunc(ctx, task)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// TaskRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type TaskRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - task *models.Task
+func (_e *TaskRepository_Expecter) Update(ctx interface{}, task interface{}) *TaskRepository_Update_Call {
+	return &TaskRepository_Update_Call{Call: _e.mock.On("Update", ctx, task)}
+}
+
+func (_c *TaskRepository_Update_Call) Run(run func(ctx context.Context, task *models.Task)) *TaskRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.Task
+		if args[1] != nil {
+			arg1 = args[1].(*models.Task)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskRepository_Update_Call) Return(err error) *TaskRepository_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *TaskRepository_Update_Call) RunAndReturn(run func(ctx context.Context, task *models.Task) error) *TaskRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTaskEventRepository creates a new instance of TaskEventRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTaskEventRepository(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *TaskRepository {
-	mock := &TaskRepository{}
+}) *TaskEventRepository {
+	mock := &TaskEventRepository{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -26,57 +1044,57 @@ func NewTaskRepository(t interface {
 	return mock
 }
 
-// TaskRepository is an autogenerated mock type for the TaskRepository type
-type TaskRepository struct {
+// TaskEventRepository is an autogenerated mock type for the TaskEventRepository type
+type TaskEventRepository struct {
 	mock.Mock
 }
 
-type TaskRepository_Expecter struct {
+type TaskEventRepository_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *TaskRepository) EXPECT() *TaskRepository_Expecter {
-	return &TaskRepository_Expecter{mock: &_m.Mock}
+func (_m *TaskEventRepository) EXPECT() *TaskEventRepository_Expecter {
+	return &TaskEventRepository_Expecter{mock: &_m.Mock}
 }
 
-// Create provides a mock function for the type TaskRepository
-func (_mock *TaskRepository) Create(ctx context.Context, task *models.Task) error {
-	ret := _mock.Called(ctx, task)
+// Create provides a mock function for the type TaskEventRepository
+func (_mock *TaskEventRepository) Create(ctx context.Context, event *models.TaskEvent) error {
+	ret := _mock.Called(ctx, event)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Task) error); ok {
-		r0 = returnFunc(ctx, task)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.TaskEvent) error); ok {
+		r0 = returnFunc(ctx, event)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// TaskRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
-type TaskRepository_Create_Call struct {
+// TaskEventRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type TaskEventRepository_Create_Call struct {
 	*mock.Call
 }
 
 // Create is a helper method to define mock.On call
 //   - ctx context.Context
-//   - task *models.Task
-func (_e *TaskRepository_Expecter) Create(ctx interface{}, task interface{}) *TaskRepository_Create_Call {
-	return &TaskRepository_Create_Call{Call: _e.mock.On("Create", ctx, task)}
+//   - event *models.TaskEvent
+func (_e *TaskEventRepository_Expecter) Create(ctx interface{}, event interface{}) *TaskEventRepository_Create_Call {
+	return &TaskEventRepository_Create_Call{Call: _e.mock.On("Create", ctx, event)}
 }
 
-func (_c *TaskRepository_Create_Call) Run(run func(ctx context.Context, task *models.Task)) *TaskRepository_Create_Call {
+func (_c *TaskEventRepository_Create_Call) Run(run func(ctx context.Context, event *models.TaskEvent)) *TaskEventRepository_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.Task
+		var arg1 *models.TaskEvent
 		if args[1] != nil {
-			arg1 = args[1].(*models.Task)
+			arg1 = args[1].(*models.TaskEvent)
 		}
 		run(
 			arg0,
@@ -86,46 +1104,57 @@ func (_c *TaskRepository_Create_Call) Run(run func(ctx context.Context, task *mo
 	return _c
 }
 
-func (_c *TaskRepository_Create_Call) Return(err error) *TaskRepository_Create_Call {
+func (_c *TaskEventRepository_Create_Call) Return(err error) *TaskEventRepository_Create_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *TaskRepository_Create_Call) RunAndReturn(run func(ctx context.Context, task *models.Task) error) *TaskRepository_Create_Call {
+func (_c *TaskEventRepository_Create_Call) RunAndReturn(run func(ctx context.Context, event *models.TaskEvent) error) *TaskEventRepository_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Delete provides a mock function for the type TaskRepository
-func (_mock *TaskRepository) Delete(ctx context.Context, id string) error {
+// FindByID provides a mock function for the type TaskEventRepository
+func (_mock *TaskEventRepository) FindByID(ctx context.Context, id string) (*models.TaskEvent, error) {
 	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Delete")
+		panic("no return value specified for FindByID")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+	var r0 *models.TaskEvent
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*models.TaskEvent, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *models.TaskEvent); ok {
 		r0 = returnFunc(ctx, id)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TaskEvent)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// TaskRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
-type TaskRepository_Delete_Call struct {
+// TaskEventRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type TaskEventRepository_FindByID_Call struct {
 	*mock.Call
 }
 
-// Delete is a helper method to define mock.On call
+// FindByID is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id string
-func (_e *TaskRepository_Expecter) Delete(ctx interface{}, id interface{}) *TaskRepository_Delete_Call {
-	return &TaskRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+func (_e *TaskEventRepository_Expecter) FindByID(ctx interface{}, id interface{}) *TaskEventRepository_FindByID_Call {
+	return &TaskEventRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
 }
 
-func (_c *TaskRepository_Delete_Call) Run(run func(ctx context.Context, id string)) *TaskRepository_Delete_Call {
+func (_c *TaskEventRepository_FindByID_Call) Run(run func(ctx context.Context, id string)) *TaskEventRepository_FindByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -143,57 +1172,57 @@ func (_c *TaskRepository_Delete_Call) Run(run func(ctx context.Context, id strin
 	return _c
 }
 
-func (_c *TaskRepository_Delete_Call) Return(err error) *TaskRepository_Delete_Call {
-	_c.Call.Return(err)
+func (_c *TaskEventRepository_FindByID_Call) Return(taskEvent *models.TaskEvent, err error) *TaskEventRepository_FindByID_Call {
+	_c.Call.Return(taskEvent, err)
 	return _c
 }
 
-func (_c *TaskRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, id string) error) *TaskRepository_Delete_Call {
+func (_c *TaskEventRepository_FindByID_Call) RunAndReturn(run func(ctx context.Context, id string) (*models.TaskEvent, error)) *TaskEventRepository_FindByID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// FindByID provides a mock function for the type TaskRepository
-func (_mock *TaskRepository) FindByID(ctx context.Context, id string) (*models.Task, error) {
-	ret := _mock.Called(ctx, id)
+// FindByTask provides a mock function for the type TaskEventRepository
+func (_mock *TaskEventRepository) FindByTask(ctx context.Context, taskID string) ([]*models.TaskEvent, error) {
+	ret := _mock.Called(ctx, taskID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindByID")
+		panic("no return value specified for FindByTask")
 	}
 
-	var r0 *models.Task
+	var r0 []*models.TaskEvent
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*models.Task, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*models.TaskEvent, error)); ok {
+		return returnFunc(ctx, taskID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *models.Task); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*models.TaskEvent); ok {
+		r0 = returnFunc(ctx, taskID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Task)
+			r0 = ret.Get(0).([]*models.TaskEvent)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, id)
+		r1 = returnFunc(ctx, taskID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// TaskRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
-type TaskRepository_FindByID_Call struct {
+// TaskEventRepository_FindByTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByTask'
+type TaskEventRepository_FindByTask_Call struct {
 	*mock.Call
 }
 
-// FindByID is a helper method to define mock.On call
+// FindByTask is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *TaskRepository_Expecter) FindByID(ctx interface{}, id interface{}) *TaskRepository_FindByID_Call {
-	return &TaskRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
+//   - taskID string
+func (_e *TaskEventRepository_Expecter) FindByTask(ctx interface{}, taskID interface{}) *TaskEventRepository_FindByTask_Call {
+	return &TaskEventRepository_FindByTask_Call{Call: _e.mock.On("FindByTask", ctx, taskID)}
 }
 
-func (_c *TaskRepository_FindByID_Call) Run(run func(ctx context.Context, id string)) *TaskRepository_FindByID_Call {
+func (_c *TaskEventRepository_FindByTask_Call) Run(run func(ctx context.Context, taskID string)) *TaskEventRepository_FindByTask_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -211,65 +1240,65 @@ func (_c *TaskRepository_FindByID_Call) Run(run func(ctx context.Context, id str
 	return _c
 }
 
-func (_c *TaskRepository_FindByID_Call) Return(task *models.Task, err error) *TaskRepository_FindByID_Call {
-	_c.Call.Return(task, err)
+func (_c *TaskEventRepository_FindByTask_Call) Return(taskEvents []*models.TaskEvent, err error) *TaskEventRepository_FindByTask_Call {
+	_c.Call.Return(taskEvents, err)
 	return _c
 }
 
-func (_c *TaskRepository_FindByID_Call) RunAndReturn(run func(ctx context.Context, id string) (*models.Task, error)) *TaskRepository_FindByID_Call {
+func (_c *TaskEventRepository_FindByTask_Call) RunAndReturn(run func(ctx context.Context, taskID string) ([]*models.TaskEvent, error)) *TaskEventRepository_FindByTask_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// FindByOwner provides a mock function for the type TaskRepository
-func (_mock *TaskRepository) FindByOwner(ctx context.Context, ownerID string) ([]*models.Task, error) {
-	ret := _mock.Called(ctx, ownerID)
+// FindByTasks provides a mock function for the type TaskEventRepository
+func (_mock *TaskEventRepository) FindByTasks(ctx context.Context, taskIDs []string) ([]*models.TaskEvent, error) {
+	ret := _mock.Called(ctx, taskIDs)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindByOwner")
+		panic("no return value specified for FindByTasks")
 	}
 
-	var r0 []*models.Task
+	var r0 []*models.TaskEvent
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*models.Task, error)); ok {
-		return returnFunc(ctx, ownerID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) ([]*models.TaskEvent, error)); ok {
+		return returnFunc(ctx, taskIDs)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*models.Task); ok {
-		r0 = returnFunc(ctx, ownerID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) []*models.TaskEvent); ok {
+		r0 = returnFunc(ctx, taskIDs)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.Task)
+			r0 = ret.Get(0).([]*models.TaskEvent)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, ownerID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, taskIDs)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// TaskRepository_FindByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByOwner'
-type TaskRepository_FindByOwner_Call struct {
+// TaskEventRepository_FindByTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByTasks'
+type TaskEventRepository_FindByTasks_Call struct {
 	*mock.Call
 }
 
-// FindByOwner is a helper method to define mock.On call
+// FindByTasks is a helper method to define mock.On call
 //   - ctx context.Context
-//   - ownerID string
-func (_e *TaskRepository_Expecter) FindByOwner(ctx interface{}, ownerID interface{}) *TaskRepository_FindByOwner_Call {
-	return &TaskRepository_FindByOwner_Call{Call: _e.mock.On("FindByOwner", ctx, ownerID)}
+//   - taskIDs []string
+func (_e *TaskEventRepository_Expecter) FindByTasks(ctx interface{}, taskIDs interface{}) *TaskEventRepository_FindByTasks_Call {
+	return &TaskEventRepository_FindByTasks_Call{Call: _e.mock.On("FindByTasks", ctx, taskIDs)}
 }
 
-func (_c *TaskRepository_FindByOwner_Call) Run(run func(ctx context.Context, ownerID string)) *TaskRepository_FindByOwner_Call {
+func (_c *TaskEventRepository_FindByTasks_Call) Run(run func(ctx context.Context, taskIDs []string)) *TaskEventRepository_FindByTasks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].([]string)
 		}
 		run(
 			arg0,
@@ -279,54 +1308,81 @@ func (_c *TaskRepository_FindByOwner_Call) Run(run func(ctx context.Context, own
 	return _c
 }
 
-func (_c *TaskRepository_FindByOwner_Call) Return(tasks []*models.Task, err error) *TaskRepository_FindByOwner_Call {
-	_c.Call.Return(tasks, err)
+func (_c *TaskEventRepository_FindByTasks_Call) Return(taskEvents []*models.TaskEvent, err error) *TaskEventRepository_FindByTasks_Call {
+	_c.Call.Return(taskEvents, err)
 	return _c
 }
 
-func (_c *TaskRepository_FindByOwner_Call) RunAndReturn(run func(ctx context.Context, ownerID string) ([]*models.Task, error)) *TaskRepository_FindByOwner_Call {
+func (_c *TaskEventRepository_FindByTasks_Call) RunAndReturn(run func(ctx context.Context, taskIDs []string) ([]*models.TaskEvent, error)) *TaskEventRepository_FindByTasks_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Update provides a mock function for the type TaskRepository
-func (_mock *TaskRepository) Update(ctx context.Context, task *models.Task) error {
-	ret := _mock.Called(ctx, task)
+// NewTransactor creates a new instance of Transactor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTransactor(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Transactor {
+	mock := &Transactor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// Transactor is an autogenerated mock type for the Transactor type
+type Transactor struct {
+	mock.Mock
+}
+
+type Transactor_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Transactor) EXPECT() *Transactor_Expecter {
+	return &Transactor_Expecter{mock: &_m.Mock}
+}
+
+// WithinTx provides a mock function for the type Transactor
+func (_mock *Transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	ret := _mock.Called(ctx, fn)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Update")
+		panic("no return value specified for WithinTx")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Task) error); ok {
-		r0 = returnFunc(ctx, task)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, func(ctx context.Context) error) error); ok {
+		r0 = returnFunc(ctx, fn)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// TaskRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
-type TaskRepository_Update_Call struct {
+// Transactor_WithinTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithinTx'
+type Transactor_WithinTx_Call struct {
 	*mock.Call
 }
 
-// Update is a helper method to define mock.On call
+// WithinTx is a helper method to define mock.On call
 //   - ctx context.Context
-//   - task *models.Task
-func (_e *TaskRepository_Expecter) Update(ctx interface{}, task interface{}) *TaskRepository_Update_Call {
-	return &TaskRepository_Update_Call{Call: _e.mock.On("Update", ctx, task)}
+//   - fn func(ctx context.Context) error
+func (_e *Transactor_Expecter) WithinTx(ctx interface{}, fn interface{}) *Transactor_WithinTx_Call {
+	return &Transactor_WithinTx_Call{Call: _e.mock.On("WithinTx", ctx, fn)}
 }
 
-func (_c *TaskRepository_Update_Call) Run(run func(ctx context.Context, task *models.Task)) *TaskRepository_Update_Call {
+func (_c *Transactor_WithinTx_Call) Run(run func(ctx context.Context, fn func(ctx context.Context) error)) *Transactor_WithinTx_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.Task
+		var arg1 func(ctx context.Context) error
 		if args[1] != nil {
-			arg1 = args[1].(*models.Task)
+			arg1 = args[1].(func(ctx context.Context) error)
 		}
 		run(
 			arg0,
@@ -336,12 +1392,96 @@ func (_c *TaskRepository_Update_Call) Run(run func(ctx context.Context, task *mo
 	return _c
 }
 
-func (_c *TaskRepository_Update_Call) Return(err error) *TaskRepository_Update_Call {
+func (_c *Transactor_WithinTx_Call) Return(err error) *Transactor_WithinTx_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *TaskRepository_Update_Call) RunAndReturn(run func(ctx context.Context, task *models.Task) error) *TaskRepository_Update_Call {
+func (_c *Transactor_WithinTx_Call) RunAndReturn(run func(ctx context.Context, fn func(ctx context.Context) error) error) *Transactor_WithinTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTaskChangePublisher creates a new instance of TaskChangePublisher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTaskChangePublisher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TaskChangePublisher {
+	mock := &TaskChangePublisher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// TaskChangePublisher is an autogenerated mock type for the TaskChangePublisher type
+type TaskChangePublisher struct {
+	mock.Mock
+}
+
+type TaskChangePublisher_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TaskChangePublisher) EXPECT() *TaskChangePublisher_Expecter {
+	return &TaskChangePublisher_Expecter{mock: &_m.Mock}
+}
+
+// Publish provides a mock function for the type TaskChangePublisher
+func (_mock *TaskChangePublisher) Publish(ctx context.Context, change services.TaskChange) error {
+	ret := _mock.Called(ctx, change)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Publish")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, services.TaskChange) error); ok {
+		r0 = returnFunc(ctx, change)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// TaskChangePublisher_Publish_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Publish'
+type TaskChangePublisher_Publish_Call struct {
+	*mock.Call
+}
+
+// Publish is a helper method to define mock.On call
+//   - ctx context.Context
+//   - change services.TaskChange
+func (_e *TaskChangePublisher_Expecter) Publish(ctx interface{}, change interface{}) *TaskChangePublisher_Publish_Call {
+	return &TaskChangePublisher_Publish_Call{Call: _e.mock.On("Publish", ctx, change)}
+}
+
+func (_c *TaskChangePublisher_Publish_Call) Run(run func(ctx context.Context, change services.TaskChange)) *TaskChangePublisher_Publish_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 services.TaskChange
+		if args[1] != nil {
+			arg1 = args[1].(services.TaskChange)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskChangePublisher_Publish_Call) Return(err error) *TaskChangePublisher_Publish_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *TaskChangePublisher_Publish_Call) RunAndReturn(run func(ctx context.Context, change services.TaskChange) error) *TaskChangePublisher_Publish_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -623,6 +1763,74 @@ func (_c *UserRepository_FindByID_Call) RunAndReturn(run func(ctx context.Contex
 	return _c
 }
 
+// FindByIDs provides a mock function for the type UserRepository
+func (_mock *UserRepository) FindByIDs(ctx context.Context, ids []string) ([]*models0.User, error) {
+	ret := _mock.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByIDs")
+	}
+
+	var r0 []*models0.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) ([]*models0.User, error)); ok {
+		return returnFunc(ctx, ids)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) []*models0.User); ok {
+		r0 = returnFunc(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models0.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserRepository_FindByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByIDs'
+type UserRepository_FindByIDs_Call struct {
+	*mock.Call
+}
+
+// FindByIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []string
+func (_e *UserRepository_Expecter) FindByIDs(ctx interface{}, ids interface{}) *UserRepository_FindByIDs_Call {
+	return &UserRepository_FindByIDs_Call{Call: _e.mock.On("FindByIDs", ctx, ids)}
+}
+
+func (_c *UserRepository_FindByIDs_Call) Run(run func(ctx context.Context, ids []string)) *UserRepository_FindByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepository_FindByIDs_Call) Return(users []*models0.User, err error) *UserRepository_FindByIDs_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *UserRepository_FindByIDs_Call) RunAndReturn(run func(ctx context.Context, ids []string) ([]*models0.User, error)) *UserRepository_FindByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function for the type UserRepository
 func (_mock *UserRepository) Update(ctx context.Context, u *models0.User) error {
 	ret := _mock.Called(ctx, u)
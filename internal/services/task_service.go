@@ -4,15 +4,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/vo"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
 	"github.com/google/uuid"
 )
 
 // TaskService is a service that handles task operations.
 type TaskService struct {
-	tasksRepo TaskRepository
+	tasksRepo  TaskRepository
+	eventsRepo TaskEventRepository
+	transactor Transactor
+	clock      clock.Clock
+	publisher  TaskChangePublisher
 }
 
 // TaskRepository defines the methods for managing task data in a persistent storage.
@@ -22,29 +30,124 @@ type TaskRepository interface {
 	// Returns an error if the operation fails.
 	Create(ctx context.Context, task *models.Task) error
 
-	// FindByID retrieves a task by its unique identifier.
+	// FindByID retrieves a task by its unique identifier, including a task that is in the trash.
 	// Returns the task and nil error if found, otherwise returns nil and an error.
 	FindByID(ctx context.Context, id string) (*models.Task, error)
 
-	// FindByOwner fetches all tasks for the given ownerID.
+	// FindByIDs retrieves the tasks with the given identifiers in no particular order,
+	// including the ones that are in the trash. The identifiers that do not belong to any task are skipped.
+	FindByIDs(ctx context.Context, ids []string) ([]*models.Task, error)
+
+	// FindByOwner fetches all tasks for the given ownerID that match the query.
+	// Tasks in the trash are returned only if query.InTrash is true, and then exclusively.
 	// Returns a slice of tasks and a nil error if tasks exist,
 	// an empty slice and nil if no tasks are found,
 	// or nil and an error if something goes wrong.
-	FindByOwner(ctx context.Context, ownerID string) ([]*models.Task, error)
+	FindByOwner(ctx context.Context, ownerID string, query FindByOwnerQuery) ([]*models.Task, error)
+
+	// FindWithDeadlines fetches the active tasks of the owner that have a deadline,
+	// from the earliest deadline to the latest. Archived tasks and tasks in the trash are skipped.
+	// Returns an empty slice if no tasks are found.
+	FindWithDeadlines(ctx context.Context, ownerID string) ([]*models.Task, error)
 
-	// Update modifies an existing task's data in the repository.
-	// Returns an error if the operation fails or the task does not exist.
+	// Update modifies an existing task's data in the repository and increments its version.
+	// Returns ErrTaskRepoConflict if the stored version differs from task.Version(),
+	// or an error if the operation fails or the task does not exist.
 	Update(ctx context.Context, task *models.Task) error
 
-	// Delete removes a user from the repository by their unique identifier.
-	// Returns an error if the operation fails or the user does not exist.
-	Delete(ctx context.Context, id string) error
+	// Delete permanently removes a task from the repository by its unique identifier,
+	// provided its stored version equals version.
+	// Returns ErrTaskRepoConflict if the stored version differs from version,
+	// or an error if the operation fails or the task does not exist.
+	Delete(ctx context.Context, id string, version int64) error
+
+	// DeleteTrashedBefore permanently removes all tasks that were moved
+	// to the trash before the given time and returns the removed tasks.
+	DeleteTrashedBefore(ctx context.Context, before time.Time) ([]*models.Task, error)
+
+	// ArchiveCompletedBefore archives all active tasks of the owner that were completed
+	// before completedBefore, marking them as archived at archivedAt, and returns them
+	// as they are after archiving.
+	ArchiveCompletedBefore(
+		ctx context.Context,
+		ownerID string,
+		completedBefore time.Time,
+		archivedAt time.Time,
+	) ([]*models.Task, error)
+
+	// Search returns at most limit active tasks of the owner that match the query,
+	// from the most relevant to the least. Archived tasks are included only if includeArchived is true.
+	// Returns an empty slice if no tasks match the query.
+	Search(ctx context.Context, ownerID string, query vo.SearchQuery, limit int, includeArchived bool) ([]TaskSearchResult, error)
+
+	// FindChangedSince returns at most limit changes of the tasks of the owner
+	// with a change sequence number greater than since, in the order of their numbers.
+	// Every change of a task, including its permanent deletion, gets the next number of its owner,
+	// and only the last change of each task is returned.
+	// Returns an empty slice if the tasks have not changed since then.
+	FindChangedSince(ctx context.Context, ownerID string, since int64, limit int) ([]TaskSyncChange, error)
+}
+
+// TaskEventRepository defines the methods for storing the change history of tasks.
+type TaskEventRepository interface {
+	// Create saves a new task event in the repository.
+	// Returns an error if the operation fails.
+	Create(ctx context.Context, event *models.TaskEvent) error
+
+	// FindByID retrieves a task event by its unique identifier.
+	// Returns ErrTaskEventRepoNotFound if the event does not exist.
+	FindByID(ctx context.Context, id string) (*models.TaskEvent, error)
+
+	// FindByTask returns all events of the task with the given id from the oldest to the newest.
+	// Returns an empty slice if the task has no events.
+	FindByTask(ctx context.Context, taskID string) ([]*models.TaskEvent, error)
+
+	// FindByTasks returns all events of the tasks with the given ids from the oldest to the newest.
+	// Returns an empty slice if the tasks have no events.
+	FindByTasks(ctx context.Context, taskIDs []string) ([]*models.TaskEvent, error)
+}
+
+// Transactor runs a function atomically: the repository calls made
+// with the context passed to fn are either all applied or none of them are.
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// TaskChange is a committed change of a task that is published to the subscribers of its owner.
+type TaskChange struct {
+	OwnerID string
+
+	// Version is the version of the task after the change.
+	Version int64
+
+	// Event is the event that records the change in the history of the task.
+	Event *models.TaskEvent
+}
+
+// TaskChangePublisher delivers the changes of tasks to their subscribers,
+// e.g. to the clients that stream the changes of their tasks.
+type TaskChangePublisher interface {
+	// Publish publishes a change that has already been committed.
+	// The change is lost if Publish returns an error.
+	Publish(ctx context.Context, change TaskChange) error
 }
 
 // ErrTaskRepositoryNil is an error that indicates that the task repository
 // that is passed to NewTaskService is nil.
 var ErrTaskRepositoryNil = errors.New("task repository is nil")
 
+// ErrTaskEventRepositoryNil is an error that indicates that the task event repository
+// that is passed to NewTaskService is nil.
+var ErrTaskEventRepositoryNil = errors.New("task event repository is nil")
+
+// ErrTransactorNil is an error that indicates that the transactor
+// that is passed to NewTaskService is nil.
+var ErrTransactorNil = errors.New("transactor is nil")
+
+// ErrClockNil is an error that indicates that the clock
+// that is passed to a service constructor is nil.
+var ErrClockNil = errors.New("clock is nil")
+
 // Repository-level errors
 var (
 	// ErrTaskRepoExists is returned by repository if the task
@@ -57,6 +160,13 @@ var (
 	// ErrTaskRepoOwnerNotFound is returned by repository
 	// when the owner with the given ID does not exist there.
 	ErrTaskRepoOwnerNotFound = errors.New("owner was not found in the repository")
+
+	// ErrTaskRepoConflict is returned by repository if the task
+	// was modified by someone else since it has been loaded
+	ErrTaskRepoConflict = errors.New("task version conflict in the repository")
+
+	// ErrTaskEventRepoNotFound is returned by repository if the task event was not found there
+	ErrTaskEventRepoNotFound = errors.New("task event was not found in the repository")
 )
 
 // Application-level errors
@@ -91,22 +201,117 @@ var (
 
 	ErrTaskFindByOwnerFailed = errors.New("failed to find by owner")
 
+	// ErrTaskSortInvalid is returned by TaskService if the requested sort order is not supported
+	ErrTaskSortInvalid = errors.New("invalid task sort order")
+
+	// ErrTaskFindByIDFailed is returned by TaskService if an internal error occurred during lookup
+	ErrTaskFindByIDFailed = errors.New("failed to find by id")
+
 	// ErrTaskDeleteFailed is returned by TaskService if an internal error occurred during task deletion
 	ErrTaskDeleteFailed = errors.New("failed to delete task")
 
+	// ErrTaskNotInTrash is returned by TaskService if a task that is to be restored is not in the trash
+	ErrTaskNotInTrash = errors.New("task is not in the trash")
+
+	// ErrTaskRestoreFailed is returned by TaskService if an internal error occurred during restoring the task
+	ErrTaskRestoreFailed = errors.New("failed to restore task")
+
+	// ErrTaskFindTrashFailed is returned by TaskService if an internal error occurred during listing the trash
+	ErrTaskFindTrashFailed = errors.New("failed to find tasks in trash")
+
+	// ErrTaskPurgeTrashFailed is returned by TaskService if an internal error occurred during purging the trash
+	ErrTaskPurgeTrashFailed = errors.New("failed to purge trash")
+
+	// ErrTaskArchiveFailed is returned by TaskService if an internal error occurred during archiving the task
+	ErrTaskArchiveFailed = errors.New("failed to archive task")
+
+	// ErrTaskUnarchiveFailed is returned by TaskService if an internal error occurred during unarchiving the task
+	ErrTaskUnarchiveFailed = errors.New("failed to unarchive task")
+
+	// ErrTaskArchiveCompletedFailed is returned by TaskService
+	// if an internal error occurred during archiving completed tasks in bulk
+	ErrTaskArchiveCompletedFailed = errors.New("failed to archive completed tasks")
+
+	// ErrTaskSearchLimitInvalid is returned by TaskService if the requested number of search results is out of range
+	ErrTaskSearchLimitInvalid = errors.New("invalid task search limit")
+
+	// ErrTaskSearchFailed is returned by TaskService if an internal error occurred during searching tasks
+	ErrTaskSearchFailed = errors.New("failed to search tasks")
+
+	// ErrTaskBatchOperationInvalid is returned by TaskService for a batch operation of an unknown type
+	ErrTaskBatchOperationInvalid = errors.New("invalid batch operation")
+
+	// ErrTaskBatchAborted is returned by TaskService for the operations of an atomic batch
+	// that were not applied because another operation of the batch failed
+	ErrTaskBatchAborted = errors.New("batch was aborted")
+
+	// ErrTaskBatchFailed is returned by TaskService if an internal error occurred during applying an atomic batch
+	ErrTaskBatchFailed = errors.New("failed to apply batch")
+
+	// ErrTaskSyncLimitInvalid is returned by TaskService if the requested number of changes is out of range
+	ErrTaskSyncLimitInvalid = errors.New("invalid task sync limit")
+
+	// ErrTaskSyncSeqInvalid is returned by TaskService if the change sequence number to sync from is negative
+	ErrTaskSyncSeqInvalid = errors.New("invalid task change sequence number")
+
+	// ErrTaskSyncFailed is returned by TaskService if an internal error occurred during loading the changes of tasks
+	ErrTaskSyncFailed = errors.New("failed to sync tasks")
+
+	// ErrTaskEventNotFound is returned by TaskService if the history of the task has no event with the given ID
+	ErrTaskEventNotFound = errors.New("task event was not found")
+
+	// ErrTaskHistoryFailed is returned by TaskService if an internal error occurred during loading the task history
+	ErrTaskHistoryFailed = errors.New("failed to load task history")
+
+	// ErrTaskRevertFailed is returned by TaskService if an internal error occurred during reverting the task
+	ErrTaskRevertFailed = errors.New("failed to revert task")
+
 	// ErrTaskAccessDenied is returned when an operation on a task is not allowed
 	// because the caller does not have permission to access the task.
 	ErrTaskAccessDenied = errors.New("task access denied")
+
+	// ErrTaskConflict is returned by TaskService if the task version does not match
+	// the expected one or the task was concurrently modified by another request.
+	ErrTaskConflict = errors.New("task version conflict")
 )
 
 // NewTaskService creates a new TaskService instance.
-// It returns nil and error if the task repository is nil
-func NewTaskService(tasksRepo TaskRepository) (*TaskService, error) {
+// Every change of a task is recorded in eventsRepo within the same transaction
+// of the transactor as the change itself. The clock is used to set the audit timestamps of tasks.
+//
+// Once the transaction is committed, the change is published with publisher.
+// The publisher is optional: if it is nil, the changes are not published.
+// It returns nil and error if any of the other dependencies is nil
+func NewTaskService(
+	tasksRepo TaskRepository,
+	eventsRepo TaskEventRepository,
+	transactor Transactor,
+	clk clock.Clock,
+	publisher TaskChangePublisher,
+) (*TaskService, error) {
 	if tasksRepo == nil {
 		return nil, ErrTaskRepositoryNil
 	}
 
-	return &TaskService{tasksRepo}, nil
+	if eventsRepo == nil {
+		return nil, ErrTaskEventRepositoryNil
+	}
+
+	if transactor == nil {
+		return nil, ErrTransactorNil
+	}
+
+	if clk == nil {
+		return nil, ErrClockNil
+	}
+
+	return &TaskService{
+		tasksRepo:  tasksRepo,
+		eventsRepo: eventsRepo,
+		transactor: transactor,
+		clock:      clk,
+		publisher:  publisher,
+	}, nil
 }
 
 // CreateTaskCommand contains all data required to create a new Task.
@@ -132,15 +337,15 @@ func (ts *TaskService) Create(ctx context.Context, cmd CreateTaskCommand) (strin
 	var err error
 
 	if cmd.Deadline == nil {
-		task, err = models.NewTask(cmd.Title, cmd.Description, cmd.OwnerID)
+		task, err = models.NewTask(cmd.Title, cmd.Description, cmd.OwnerID, ts.clock)
 	} else {
-		task, err = models.NewTaskWithDeadline(cmd.Title, cmd.Description, cmd.OwnerID, *cmd.Deadline)
+		task, err = models.NewTaskWithDeadline(cmd.Title, cmd.Description, cmd.OwnerID, *cmd.Deadline, ts.clock)
 	}
 	if err != nil {
 		return "", err
 	}
 
-	if err := ts.tasksRepo.Create(ctx, task); err != nil {
+	if err := ts.create(ctx, task); err != nil {
 		if errors.Is(err, ErrTaskRepoExists) {
 			return "", ErrTaskExists
 		}
@@ -163,147 +368,337 @@ type UpdateTaskCommand struct {
 	Deadline    *time.Time
 }
 
-// Update edits the task with given id, using the data from UpdateTaskCommand.
-// If each field is nil, the Update function return nil error and does nothing more.
-func (ts *TaskService) Update(ctx context.Context, id string, ownerID string, cmd UpdateTaskCommand) error {
-	if cmd.Title == nil && cmd.Description == nil && cmd.Deadline == nil {
-		return nil
-	}
-
+// Update edits the task with given id, using the data from UpdateTaskCommand,
+// and returns the version of the task after the update.
+// If each field is nil, the task is left as is and its current version is returned.
+//
+// If expectedVersion is not nil and differs from the stored version of the task,
+// or the task is modified concurrently, Update returns ErrTaskConflict.
+func (ts *TaskService) Update(
+	ctx context.Context,
+	id string,
+	ownerID string,
+	cmd UpdateTaskCommand,
+	expectedVersion *int64,
+) (int64, error) {
 	task, err := ts.tasksRepo.FindByID(ctx, id)
 	if errors.Is(err, ErrTaskRepoNotFound) {
-		return ErrTaskNotFound
+		return 0, ErrTaskNotFound
 	}
 	if err != nil {
-		return fmt.Errorf("%w: %s", ErrTaskUpdateFailed, err)
+		return 0, fmt.Errorf("%w: %s", ErrTaskUpdateFailed, err)
+	}
+
+	if task.IsDeleted() {
+		return 0, ErrTaskNotFound
 	}
 
 	if task.OwnerID().String() != ownerID {
-		return ErrTaskAccessDenied
+		return 0, ErrTaskAccessDenied
+	}
+
+	if !versionMatches(task, expectedVersion) {
+		return 0, ErrTaskConflict
+	}
+
+	if cmd.Title == nil && cmd.Description == nil && cmd.Deadline == nil {
+		return task.Version(), nil
 	}
 
+	before := task.State()
+
 	if cmd.Title != nil {
-		if err := task.ChangeTitle(*cmd.Title); err != nil {
-			return err
+		if err := task.ChangeTitle(*cmd.Title, ts.clock); err != nil {
+			return 0, err
 		}
 	}
 
 	if cmd.Description != nil {
-		if err := task.ChangeDescription(*cmd.Description); err != nil {
-			return err
+		if err := task.ChangeDescription(*cmd.Description, ts.clock); err != nil {
+			return 0, err
 		}
 	}
 
 	if cmd.Deadline != nil {
-		if err := task.SetDeadline(*cmd.Deadline); err != nil {
-			return err
+		if err := task.SetDeadline(*cmd.Deadline, ts.clock); err != nil {
+			return 0, err
 		}
 	}
 
-	if err := ts.tasksRepo.Update(ctx, task); err != nil {
-		return fmt.Errorf("%w: %s", ErrTaskUpdateFailed, err)
+	if err := ts.update(ctx, task, models.TaskEventUpdated, before); err != nil {
+		if errors.Is(err, ErrTaskRepoConflict) {
+			return 0, ErrTaskConflict
+		}
+
+		return 0, fmt.Errorf("%w: %s", ErrTaskUpdateFailed, err)
 	}
 
-	return nil
+	return task.Version() + 1, nil
 }
 
 // RemoveDeadline removes the deadline from the task with the given id.
 //
-// It looks up the task in the repository, clears its deadline, and
-// persists the updated task.
+// It looks up the task in the repository, clears its deadline, persists
+// the updated task and returns its new version.
 //
 // RemoveDeadline returns ErrTaskRepoNotFound if a task with the given
-// id does not exist. It returns a wrapped error if fetching or updating
-// the task fails for any other reason.
-func (ts *TaskService) RemoveDeadline(ctx context.Context, id string, ownerID string) error {
+// id does not exist. It returns ErrTaskConflict if expectedVersion is not nil
+// and does not match the task version. It returns a wrapped error if fetching
+// or updating the task fails for any other reason.
+func (ts *TaskService) RemoveDeadline(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error) {
 	task, err := ts.tasksRepo.FindByID(ctx, id)
 	if errors.Is(err, ErrTaskRepoNotFound) {
-		return ErrTaskNotFound
+		return 0, ErrTaskNotFound
 	}
 	if err != nil {
-		return fmt.Errorf("%w: %s", ErrTaskRemoveDeadlineFailed, err)
+		return 0, fmt.Errorf("%w: %s", ErrTaskRemoveDeadlineFailed, err)
+	}
+
+	if task.IsDeleted() {
+		return 0, ErrTaskNotFound
 	}
 
 	if task.OwnerID().String() != ownerID {
-		return ErrTaskAccessDenied
+		return 0, ErrTaskAccessDenied
 	}
 
-	task.RemoveDeadline()
+	if !versionMatches(task, expectedVersion) {
+		return 0, ErrTaskConflict
+	}
+
+	before := task.State()
+	task.RemoveDeadline(ts.clock)
+
+	if err := ts.update(ctx, task, models.TaskEventDeadlineRemoved, before); err != nil {
+		if errors.Is(err, ErrTaskRepoConflict) {
+			return 0, ErrTaskConflict
+		}
 
-	if err := ts.tasksRepo.Update(ctx, task); err != nil {
-		return fmt.Errorf("%w: %s", ErrTaskRemoveDeadlineFailed, err)
+		return 0, fmt.Errorf("%w: %s", ErrTaskRemoveDeadlineFailed, err)
 	}
 
-	return nil
+	return task.Version() + 1, nil
 }
 
-// Complete marks the task with the given id as completed.
+// Complete marks the task with the given id as completed
+// and returns the version of the task after the change.
+// Completing a completed task does nothing and returns its current version.
 //
 // It returns ErrTaskNotFound if the task does not exist.
 // If the task is owned by a different user than ownerID,
 // Complete returns ErrTaskAccessDenied.
 //
+// If expectedVersion is not nil and does not match the task version,
+// Complete returns ErrTaskConflict.
+//
 // If updating the task fails, Complete returns ErrTaskCompleteFailed
-func (ts *TaskService) Complete(ctx context.Context, id string, ownerID string) error {
+func (ts *TaskService) Complete(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error) {
 	task, err := ts.tasksRepo.FindByID(ctx, id)
 	if errors.Is(err, ErrTaskRepoNotFound) {
-		return ErrTaskNotFound
+		return 0, ErrTaskNotFound
 	}
 	if err != nil {
-		return fmt.Errorf("%w: %s", ErrTaskCompleteFailed, err)
+		return 0, fmt.Errorf("%w: %s", ErrTaskCompleteFailed, err)
+	}
+
+	if task.IsDeleted() {
+		return 0, ErrTaskNotFound
 	}
 
 	if task.OwnerID().String() != ownerID {
-		return ErrTaskAccessDenied
+		return 0, ErrTaskAccessDenied
+	}
+
+	if !versionMatches(task, expectedVersion) {
+		return 0, ErrTaskConflict
 	}
 
 	if task.IsCompleted() {
-		return nil
+		return task.Version(), nil
 	}
 
-	task.Complete()
+	before := task.State()
+	task.Complete(ts.clock)
+
+	if err := ts.update(ctx, task, models.TaskEventCompleted, before); err != nil {
+		if errors.Is(err, ErrTaskRepoConflict) {
+			return 0, ErrTaskConflict
+		}
 
-	if err := ts.tasksRepo.Update(ctx, task); err != nil {
-		return fmt.Errorf("%w: %s", ErrTaskCompleteFailed, err)
+		return 0, fmt.Errorf("%w: %s", ErrTaskCompleteFailed, err)
 	}
 
-	return nil
+	return task.Version() + 1, nil
 }
 
-// Reopen marks a completed task as not completed.
+// Reopen marks a completed task as not completed and returns the version of the task after the change.
+// Reopening a task that is not completed does nothing and returns its current version.
 // Returns ErrTaskNotFound if the task does not exist.
 // Returns ErrTaskAccessDenied if the ownerID does not match.
+// Returns ErrTaskConflict if expectedVersion is not nil and does not match the task version.
 // Returns ErrTaskReopenFailed if updating the task fails.
-func (ts *TaskService) Reopen(ctx context.Context, id string, ownerID string) error {
+func (ts *TaskService) Reopen(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error) {
 	task, err := ts.tasksRepo.FindByID(ctx, id)
 	if errors.Is(err, ErrTaskRepoNotFound) {
-		return ErrTaskNotFound
+		return 0, ErrTaskNotFound
 	}
 	if err != nil {
-		return fmt.Errorf("%w: %s", ErrTaskReopenFailed, err)
+		return 0, fmt.Errorf("%w: %s", ErrTaskReopenFailed, err)
+	}
+
+	if task.IsDeleted() {
+		return 0, ErrTaskNotFound
 	}
 
 	if task.OwnerID().String() != ownerID {
-		return ErrTaskAccessDenied
+		return 0, ErrTaskAccessDenied
+	}
+
+	if !versionMatches(task, expectedVersion) {
+		return 0, ErrTaskConflict
 	}
 
 	if !task.IsCompleted() {
-		return nil
+		return task.Version(), nil
 	}
 
-	task.Reopen()
+	before := task.State()
+	task.Reopen(ts.clock)
+
+	if err := ts.update(ctx, task, models.TaskEventReopened, before); err != nil {
+		if errors.Is(err, ErrTaskRepoConflict) {
+			return 0, ErrTaskConflict
+		}
 
-	if err := ts.tasksRepo.Update(ctx, task); err != nil {
-		return fmt.Errorf("%w: %s", ErrTaskReopenFailed, err)
+		return 0, fmt.Errorf("%w: %s", ErrTaskReopenFailed, err)
 	}
 
-	return nil
+	return task.Version() + 1, nil
+}
+
+// FindByID returns the task with the given id if it belongs to ownerID.
+//
+// It returns ErrTaskNotFound if the task does not exist and ErrTaskAccessDenied
+// if the task is owned by another user. If the lookup fails for any other reason,
+// FindByID returns ErrTaskFindByIDFailed.
+func (ts *TaskService) FindByID(ctx context.Context, id string, ownerID string) (*models.Task, error) {
+	task, err := ts.tasksRepo.FindByID(ctx, id)
+	if errors.Is(err, ErrTaskRepoNotFound) {
+		return nil, ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrTaskFindByIDFailed, err)
+	}
+
+	if task.IsDeleted() {
+		return nil, ErrTaskNotFound
+	}
+
+	if task.OwnerID().String() != ownerID {
+		return nil, ErrTaskAccessDenied
+	}
+
+	return task, nil
+}
+
+// FindByIDs returns the tasks with the given ids that belong to ownerID, in no particular order,
+// so that many tasks can be loaded at once. Unlike FindByID, it does not fail for a single task:
+// the ids that are not valid, do not exist, are in the trash or are owned by another user are skipped.
+//
+// If the lookup fails, FindByIDs returns ErrTaskFindByIDFailed.
+func (ts *TaskService) FindByIDs(ctx context.Context, ids []string, ownerID string) ([]*models.Task, error) {
+	tasks, err := ts.findOwnedByIDs(ctx, ids, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrTaskFindByIDFailed, err)
+	}
+
+	active := make([]*models.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if !task.IsDeleted() {
+			active = append(active, task)
+		}
+	}
+
+	return active, nil
+}
+
+// findOwnedByIDs returns the tasks with the given ids that belong to ownerID, including the ones in the trash.
+func (ts *TaskService) findOwnedByIDs(ctx context.Context, ids []string, ownerID string) ([]*models.Task, error) {
+	ids = validIDs(ids)
+	if len(ids) == 0 {
+		return make([]*models.Task, 0), nil
+	}
+
+	tasks, err := ts.tasksRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	owned := make([]*models.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if task.OwnerID().String() == ownerID {
+			owned = append(owned, task)
+		}
+	}
+
+	return owned, nil
+}
+
+// TaskSort defines the order in which tasks are listed.
+type TaskSort string
+
+const (
+	// TaskSortDefault leaves the order of tasks up to the repository.
+	TaskSortDefault TaskSort = ""
+
+	// TaskSortCreatedAt orders tasks from the oldest to the newest.
+	TaskSortCreatedAt TaskSort = "created_at"
+
+	// TaskSortCreatedAtDesc orders tasks from the newest to the oldest.
+	TaskSortCreatedAtDesc TaskSort = "-created_at"
+)
+
+// IsValid reports whether s is one of the supported sort orders.
+func (s TaskSort) IsValid() bool {
+	switch s {
+	case TaskSortDefault, TaskSortCreatedAt, TaskSortCreatedAtDesc:
+		return true
+	default:
+		return false
+	}
+}
+
+// FindByOwnerQuery contains optional parameters of TaskService.FindByOwner.
+// The zero value returns all tasks of the owner in the default order.
+type FindByOwnerQuery struct {
+	// Sort is the order of the returned tasks.
+	Sort TaskSort
+
+	// UpdatedSince, if not nil, limits the result to the tasks
+	// that were updated at or after the given time.
+	UpdatedSince *time.Time
+
+	// InTrash makes the query return only the tasks that are in the trash
+	// instead of the active ones. With the default sort order such tasks
+	// are listed from the most recently deleted.
+	InTrash bool
+
+	// IncludeArchived makes the query return archived tasks along with the other active ones.
+	// It has no effect when listing the trash, which always includes archived tasks.
+	IncludeArchived bool
 }
 
-// FindByOwner returns all tasks that belong to the given ownerID.
-// If no tasks are found, it returns an empty slice. If an error occurred
-func (ts *TaskService) FindByOwner(ctx context.Context, ownerID string) ([]*models.Task, error) {
-	tasks, err := ts.tasksRepo.FindByOwner(ctx, ownerID)
+// FindByOwner returns all tasks that belong to the given ownerID and match the query.
+// If no tasks are found, it returns an empty slice.
+//
+// It returns ErrTaskSortInvalid if query.Sort is not supported
+// and ErrTaskFindByOwnerFailed if the repository fails to find the tasks.
+func (ts *TaskService) FindByOwner(ctx context.Context, ownerID string, query FindByOwnerQuery) ([]*models.Task, error) {
+	if !query.Sort.IsValid() {
+		return nil, ErrTaskSortInvalid
+	}
+
+	tasks, err := ts.tasksRepo.FindByOwner(ctx, ownerID, query)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrTaskFindByOwnerFailed, err)
 	}
@@ -311,26 +706,934 @@ func (ts *TaskService) FindByOwner(ctx context.Context, ownerID string) ([]*mode
 	return tasks, nil
 }
 
-// Delete removes a task by its ID, provided the ownerID matches.
+const (
+	// DefaultTaskSearchLimit is the number of search results returned when SearchTasksQuery.Limit is zero.
+	DefaultTaskSearchLimit = 20
+
+	// MaxTaskSearchLimit is the maximum number of search results that can be requested.
+	MaxTaskSearchLimit = 100
+)
+
+// SearchTasksQuery contains the parameters of TaskService.Search.
+type SearchTasksQuery struct {
+	// Text is the raw search query, see vo.SearchQuery for its syntax.
+	Text string
+
+	// Limit is the maximum number of returned results.
+	// If it is zero, DefaultTaskSearchLimit is used.
+	Limit int
+
+	// IncludeArchived makes the search cover archived tasks along with the other active ones.
+	IncludeArchived bool
+}
+
+// Parse validates the query and returns its parsed text and the effective limit.
 //
-// Returns ErrTaskNotFound if the task doesn't exist, ErrTaskAccessDenied
-// if the owner is incorrect, or ErrTaskDeleteFailed for system errors.
-func (ts *TaskService) Delete(ctx context.Context, id string, ownerID string) error {
+// It returns ErrTaskSearchLimitInvalid if Limit is negative or greater than MaxTaskSearchLimit,
+// or one of the vo.NewSearchQuery errors if Text is not a valid search query.
+func (q SearchTasksQuery) Parse() (vo.SearchQuery, int, error) {
+	limit := q.Limit
+	if limit == 0 {
+		limit = DefaultTaskSearchLimit
+	}
+
+	if limit < 0 || limit > MaxTaskSearchLimit {
+		return vo.SearchQuery{}, 0, ErrTaskSearchLimitInvalid
+	}
+
+	text, err := vo.NewSearchQuery(q.Text)
+	if err != nil {
+		return vo.SearchQuery{}, 0, err
+	}
+
+	return text, limit, nil
+}
+
+// TaskSearchResult is a task that matches a search query.
+type TaskSearchResult struct {
+	Task *models.Task
+
+	// Rank is the relevance of the task to the query; a greater rank means a more relevant task.
+	Rank float64
+
+	// TitleSnippet and DescriptionSnippet are the fragments of the title and the description
+	// with the matched words enclosed in <b> and </b>. The text itself is HTML-escaped.
+	TitleSnippet       string
+	DescriptionSnippet string
+}
+
+// Search returns the active tasks of the owner that match the query, from the most relevant to the least.
+// Tasks in the trash are never searched. If no tasks match the query, it returns an empty slice.
+//
+// It returns ErrTaskSearchLimitInvalid if query.Limit is out of range, one of the vo.NewSearchQuery
+// errors if query.Text is not a valid search query and ErrTaskSearchFailed for system errors.
+func (ts *TaskService) Search(ctx context.Context, ownerID string, query SearchTasksQuery) ([]TaskSearchResult, error) {
+	text, limit, err := query.Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := ts.tasksRepo.Search(ctx, ownerID, text, limit, query.IncludeArchived)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrTaskSearchFailed, err)
+	}
+
+	return results, nil
+}
+
+// Archive archives the task with the given id, hiding it from FindByOwner
+// unless archived tasks are requested explicitly, and returns the version of the task after the change.
+// Only a completed task can be archived unless force is true.
+// Archiving a task that is already archived does nothing and returns its current version.
+//
+// It returns ErrTaskNotFound if the task does not exist or is in the trash,
+// ErrTaskAccessDenied if the owner is incorrect, models.ErrTaskNotCompleted
+// if the task is not completed and force is false, ErrTaskConflict if expectedVersion
+// is not nil and does not match the task version, or ErrTaskArchiveFailed for system errors.
+func (ts *TaskService) Archive(ctx context.Context, id string, ownerID string, force bool, expectedVersion *int64) (int64, error) {
 	task, err := ts.tasksRepo.FindByID(ctx, id)
 	if errors.Is(err, ErrTaskRepoNotFound) {
-		return ErrTaskNotFound
+		return 0, ErrTaskNotFound
 	}
 	if err != nil {
-		return fmt.Errorf("%w: %s", ErrTaskDeleteFailed, err)
+		return 0, fmt.Errorf("%w: %s", ErrTaskArchiveFailed, err)
+	}
+
+	if task.IsDeleted() {
+		return 0, ErrTaskNotFound
 	}
 
 	if task.OwnerID().String() != ownerID {
-		return ErrTaskAccessDenied
+		return 0, ErrTaskAccessDenied
 	}
 
-	if err := ts.tasksRepo.Delete(ctx, id); err != nil {
-		return fmt.Errorf("%w: %s", ErrTaskDeleteFailed, err)
+	if !versionMatches(task, expectedVersion) {
+		return 0, ErrTaskConflict
 	}
 
-	return nil
+	if task.IsArchived() {
+		return task.Version(), nil
+	}
+
+	before := task.State()
+	if err := task.Archive(force, ts.clock); err != nil {
+		return 0, err
+	}
+
+	if err := ts.update(ctx, task, models.TaskEventArchived, before); err != nil {
+		if errors.Is(err, ErrTaskRepoConflict) {
+			return 0, ErrTaskConflict
+		}
+
+		return 0, fmt.Errorf("%w: %s", ErrTaskArchiveFailed, err)
+	}
+
+	return task.Version() + 1, nil
+}
+
+// Unarchive takes the task with the given id out of the archive
+// and returns the version of the task after the change.
+// Unarchiving a task that is not archived does nothing and returns its current version.
+//
+// It returns ErrTaskNotFound if the task does not exist or is in the trash,
+// ErrTaskAccessDenied if the owner is incorrect, ErrTaskConflict if expectedVersion
+// is not nil and does not match the task version, or ErrTaskUnarchiveFailed for system errors.
+func (ts *TaskService) Unarchive(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error) {
+	task, err := ts.tasksRepo.FindByID(ctx, id)
+	if errors.Is(err, ErrTaskRepoNotFound) {
+		return 0, ErrTaskNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrTaskUnarchiveFailed, err)
+	}
+
+	if task.IsDeleted() {
+		return 0, ErrTaskNotFound
+	}
+
+	if task.OwnerID().String() != ownerID {
+		return 0, ErrTaskAccessDenied
+	}
+
+	if !versionMatches(task, expectedVersion) {
+		return 0, ErrTaskConflict
+	}
+
+	if !task.IsArchived() {
+		return task.Version(), nil
+	}
+
+	before := task.State()
+	task.Unarchive(ts.clock)
+
+	if err := ts.update(ctx, task, models.TaskEventUnarchived, before); err != nil {
+		if errors.Is(err, ErrTaskRepoConflict) {
+			return 0, ErrTaskConflict
+		}
+
+		return 0, fmt.Errorf("%w: %s", ErrTaskUnarchiveFailed, err)
+	}
+
+	return task.Version() + 1, nil
+}
+
+// ArchiveCompleted archives all active tasks of the given owner
+// that were completed more than olderThan ago and returns their number.
+// Each archived task gets an archived event in its history, like it does with Archive.
+//
+// It returns ErrTaskArchiveCompletedFailed if the repository fails to archive the tasks.
+func (ts *TaskService) ArchiveCompleted(ctx context.Context, ownerID string, olderThan time.Duration) (int64, error) {
+	now := ts.clock.Now()
+
+	var changes []TaskChange
+
+	err := ts.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		archived, err := ts.tasksRepo.ArchiveCompletedBefore(ctx, ownerID, now.Add(-olderThan), now)
+		if err != nil {
+			return err
+		}
+
+		changes = make([]TaskChange, 0, len(archived))
+		for _, task := range archived {
+			// the tasks are returned archived, and nothing else has changed
+			before := task.State()
+			before.ArchivedAt = nil
+
+			event, err := ts.record(ctx, task, models.TaskEventArchived, &before)
+			if err != nil {
+				return err
+			}
+
+			changes = append(changes, TaskChange{OwnerID: ownerID, Version: task.Version(), Event: event})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrTaskArchiveCompletedFailed, err)
+	}
+
+	ts.publish(ctx, changes...)
+
+	return int64(len(changes)), nil
+}
+
+// Delete moves the task with the given ID to the trash, provided the ownerID matches,
+// and returns the version of the task in the trash.
+// Tasks in the trash are hidden from FindByID and FindByOwner and can be
+// brought back with Restore until they are purged.
+//
+// Returns ErrTaskNotFound if the task doesn't exist or is already in the trash,
+// ErrTaskAccessDenied if the owner is incorrect, ErrTaskConflict if expectedVersion
+// is not nil and does not match the task version, or ErrTaskDeleteFailed for system errors.
+func (ts *TaskService) Delete(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error) {
+	task, err := ts.tasksRepo.FindByID(ctx, id)
+	if errors.Is(err, ErrTaskRepoNotFound) {
+		return 0, ErrTaskNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrTaskDeleteFailed, err)
+	}
+
+	if task.IsDeleted() {
+		return 0, ErrTaskNotFound
+	}
+
+	if task.OwnerID().String() != ownerID {
+		return 0, ErrTaskAccessDenied
+	}
+
+	if !versionMatches(task, expectedVersion) {
+		return 0, ErrTaskConflict
+	}
+
+	before := task.State()
+	task.MoveToTrash(ts.clock)
+
+	if err := ts.update(ctx, task, models.TaskEventDeleted, before); err != nil {
+		if errors.Is(err, ErrTaskRepoConflict) {
+			return 0, ErrTaskConflict
+		}
+
+		return 0, fmt.Errorf("%w: %s", ErrTaskDeleteFailed, err)
+	}
+
+	return task.Version() + 1, nil
+}
+
+// DeletePermanently removes the task with the given ID from the repository,
+// whether it is in the trash or not, provided the ownerID matches.
+// The history of the task is kept, ending with the event of its removal.
+//
+// Returns ErrTaskNotFound if the task doesn't exist, ErrTaskAccessDenied
+// if the owner is incorrect, ErrTaskConflict if expectedVersion is not nil
+// and does not match the task version, or ErrTaskDeleteFailed for system errors.
+func (ts *TaskService) DeletePermanently(ctx context.Context, id string, ownerID string, expectedVersion *int64) error {
+	task, err := ts.tasksRepo.FindByID(ctx, id)
+	if errors.Is(err, ErrTaskRepoNotFound) {
+		return ErrTaskNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrTaskDeleteFailed, err)
+	}
+
+	if task.OwnerID().String() != ownerID {
+		return ErrTaskAccessDenied
+	}
+
+	if !versionMatches(task, expectedVersion) {
+		return ErrTaskConflict
+	}
+
+	event, err := ts.delete(ctx, task)
+	if err != nil {
+		if errors.Is(err, ErrTaskRepoNotFound) {
+			return ErrTaskNotFound
+		}
+
+		if errors.Is(err, ErrTaskRepoConflict) {
+			return ErrTaskConflict
+		}
+
+		return fmt.Errorf("%w: %s", ErrTaskDeleteFailed, err)
+	}
+
+	ts.publish(ctx, TaskChange{OwnerID: task.OwnerID().String(), Version: task.Version(), Event: event})
+
+	return nil
+}
+
+// Restore takes the task with the given ID out of the trash, provided the ownerID matches,
+// and returns the version of the restored task.
+//
+// Returns ErrTaskNotFound if the task doesn't exist, ErrTaskAccessDenied
+// if the owner is incorrect, ErrTaskNotInTrash if the task is not in the trash,
+// ErrTaskConflict if expectedVersion is not nil and does not match the task version,
+// ErrTaskExists if the owner already has another task with the same title,
+// or ErrTaskRestoreFailed for system errors.
+func (ts *TaskService) Restore(ctx context.Context, id string, ownerID string, expectedVersion *int64) (int64, error) {
+	task, err := ts.tasksRepo.FindByID(ctx, id)
+	if errors.Is(err, ErrTaskRepoNotFound) {
+		return 0, ErrTaskNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrTaskRestoreFailed, err)
+	}
+
+	if task.OwnerID().String() != ownerID {
+		return 0, ErrTaskAccessDenied
+	}
+
+	if !versionMatches(task, expectedVersion) {
+		return 0, ErrTaskConflict
+	}
+
+	if !task.IsDeleted() {
+		return 0, ErrTaskNotInTrash
+	}
+
+	before := task.State()
+	task.Restore(ts.clock)
+
+	if err := ts.update(ctx, task, models.TaskEventRestored, before); err != nil {
+		if errors.Is(err, ErrTaskRepoConflict) {
+			return 0, ErrTaskConflict
+		}
+
+		if errors.Is(err, ErrTaskRepoExists) {
+			return 0, ErrTaskExists
+		}
+
+		return 0, fmt.Errorf("%w: %s", ErrTaskRestoreFailed, err)
+	}
+
+	return task.Version() + 1, nil
+}
+
+// FindTrash returns the tasks of the given owner that are in the trash,
+// the most recently deleted first. If the trash is empty, it returns an empty slice.
+//
+// It returns ErrTaskFindTrashFailed if the repository fails to find the tasks.
+func (ts *TaskService) FindTrash(ctx context.Context, ownerID string) ([]*models.Task, error) {
+	tasks, err := ts.tasksRepo.FindByOwner(ctx, ownerID, FindByOwnerQuery{InTrash: true})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrTaskFindTrashFailed, err)
+	}
+
+	return tasks, nil
+}
+
+// PurgeTrash permanently removes the tasks of all users that have been
+// in the trash for longer than retention and returns their number.
+// The history of each removed task is kept, ending with the event of its removal.
+//
+// It returns ErrTaskPurgeTrashFailed if the repository fails to remove the tasks.
+func (ts *TaskService) PurgeTrash(ctx context.Context, retention time.Duration) (int64, error) {
+	var changes []TaskChange
+
+	err := ts.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		purged, err := ts.tasksRepo.DeleteTrashedBefore(ctx, ts.clock.Now().Add(-retention))
+		if err != nil {
+			return err
+		}
+
+		changes = make([]TaskChange, 0, len(purged))
+		for _, task := range purged {
+			state := task.State()
+
+			event, err := ts.record(ctx, task, models.TaskEventPurged, &state)
+			if err != nil {
+				return err
+			}
+
+			changes = append(changes, TaskChange{OwnerID: task.OwnerID().String(), Version: task.Version(), Event: event})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrTaskPurgeTrashFailed, err)
+	}
+
+	ts.publish(ctx, changes...)
+
+	return int64(len(changes)), nil
+}
+
+// History returns the change history of the task with the given id
+// from the oldest event to the newest, provided the ownerID matches.
+// The history of a task in the trash is available as well.
+//
+// It returns ErrTaskNotFound if the task does not exist, ErrTaskAccessDenied
+// if the owner is incorrect, or ErrTaskHistoryFailed for system errors.
+func (ts *TaskService) History(ctx context.Context, id string, ownerID string) ([]*models.TaskEvent, error) {
+	task, err := ts.tasksRepo.FindByID(ctx, id)
+	if errors.Is(err, ErrTaskRepoNotFound) {
+		return nil, ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrTaskHistoryFailed, err)
+	}
+
+	if task.OwnerID().String() != ownerID {
+		return nil, ErrTaskAccessDenied
+	}
+
+	events, err := ts.eventsRepo.FindByTask(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrTaskHistoryFailed, err)
+	}
+
+	return events, nil
+}
+
+// Histories returns the change histories of the tasks with the given ids that belong to ownerID
+// by the IDs of the tasks, each from the oldest event to the newest, so that the histories
+// of many tasks can be loaded at once. As with History, the tasks in the trash are included.
+// The ids that are not valid, do not exist or are owned by another user are skipped.
+//
+// If the lookup fails, Histories returns ErrTaskHistoryFailed.
+func (ts *TaskService) Histories(
+	ctx context.Context,
+	ids []string,
+	ownerID string,
+) (map[string][]*models.TaskEvent, error) {
+	tasks, err := ts.findOwnedByIDs(ctx, ids, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrTaskHistoryFailed, err)
+	}
+
+	histories := make(map[string][]*models.TaskEvent, len(tasks))
+	if len(tasks) == 0 {
+		return histories, nil
+	}
+
+	taskIDs := make([]string, len(tasks))
+	for i, task := range tasks {
+		taskIDs[i] = task.ID().String()
+		histories[taskIDs[i]] = make([]*models.TaskEvent, 0)
+	}
+
+	events, err := ts.eventsRepo.FindByTasks(ctx, taskIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrTaskHistoryFailed, err)
+	}
+
+	for _, event := range events {
+		taskID := event.TaskID().String()
+		histories[taskID] = append(histories[taskID], event)
+	}
+
+	return histories, nil
+}
+
+// Revert brings the task with the given id back to the state it had right after
+// the event with the given eventID, provided the ownerID matches. The values are
+// applied through the domain model, so they are validated again; e.g. a deadline
+// that has passed since then cannot be restored. Reverting is recorded in the history too.
+//
+// It returns ErrTaskNotFound if the task doesn't exist or is in the trash,
+// ErrTaskAccessDenied if the owner is incorrect, ErrTaskEventNotFound if the task
+// has no such event, ErrTaskConflict if expectedVersion is not nil and does not match
+// the task version, or ErrTaskRevertFailed for system errors. Validation errors
+// of the domain model are returned as is.
+func (ts *TaskService) Revert(
+	ctx context.Context,
+	id string,
+	ownerID string,
+	eventID string,
+	expectedVersion *int64,
+) (int64, error) {
+	task, err := ts.tasksRepo.FindByID(ctx, id)
+	if errors.Is(err, ErrTaskRepoNotFound) {
+		return 0, ErrTaskNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrTaskRevertFailed, err)
+	}
+
+	if task.IsDeleted() {
+		return 0, ErrTaskNotFound
+	}
+
+	if task.OwnerID().String() != ownerID {
+		return 0, ErrTaskAccessDenied
+	}
+
+	if !versionMatches(task, expectedVersion) {
+		return 0, ErrTaskConflict
+	}
+
+	event, err := ts.eventsRepo.FindByID(ctx, eventID)
+	if errors.Is(err, ErrTaskEventRepoNotFound) {
+		return 0, ErrTaskEventNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrTaskRevertFailed, err)
+	}
+
+	if event.TaskID() != task.ID() {
+		return 0, ErrTaskEventNotFound
+	}
+
+	before := task.State()
+	if err := task.RevertTo(event.After(), ts.clock); err != nil {
+		return 0, err
+	}
+
+	if len(before.Diff(task.State())) == 0 {
+		return task.Version(), nil
+	}
+
+	if err := ts.update(ctx, task, models.TaskEventReverted, before); err != nil {
+		if errors.Is(err, ErrTaskRepoConflict) {
+			return 0, ErrTaskConflict
+		}
+
+		return 0, fmt.Errorf("%w: %s", ErrTaskRevertFailed, err)
+	}
+
+	return task.Version() + 1, nil
+}
+
+// BatchOperationType defines the change that a batch operation makes to a task.
+type BatchOperationType string
+
+const (
+	BatchOperationUpdate         BatchOperationType = "update"
+	BatchOperationRemoveDeadline BatchOperationType = "remove_deadline"
+	BatchOperationComplete       BatchOperationType = "complete"
+	BatchOperationReopen         BatchOperationType = "reopen"
+	BatchOperationDelete         BatchOperationType = "delete"
+)
+
+// BatchOperation is a single change of a task applied by TaskService.Batch.
+// It is applied by the TaskService method of the same name, so it fails in the same way.
+type BatchOperation struct {
+	Type   BatchOperationType
+	TaskID string
+
+	// Update is the data of the BatchOperationUpdate operation and is ignored by the other ones.
+	Update UpdateTaskCommand
+
+	// ExpectedVersion, if not nil, is the version the task must have for the operation to be applied.
+	ExpectedVersion *int64
+}
+
+// Batch applies the operations to the tasks of the owner in their order
+// and returns the result of each of them: nil for an applied operation and an error otherwise.
+// An operation of an unknown type fails with ErrTaskBatchOperationInvalid.
+//
+// If atomic is false, every operation is applied independently of the others and the returned error is nil.
+//
+// If atomic is true, the operations are applied within a single transaction and the batch
+// stops at the first failed operation. In that case the changes of all operations are discarded,
+// the results of the other operations are ErrTaskBatchAborted and the returned error
+// wraps both ErrTaskBatchAborted and the error of the failed operation.
+// If the transaction itself fails, every result is ErrTaskBatchAborted and ErrTaskBatchFailed is returned.
+func (ts *TaskService) Batch(ctx context.Context, ownerID string, ops []BatchOperation, atomic bool) ([]error, error) {
+	results := make([]error, len(ops))
+
+	if !atomic {
+		for i, op := range ops {
+			results[i] = ts.apply(ctx, ownerID, op)
+		}
+
+		return results, nil
+	}
+
+	failed := -1
+
+	// the changes are published once the whole batch is committed
+	pending := &pendingChanges{}
+
+	err := ts.transactor.WithinTx(withPendingChanges(ctx, pending), func(ctx context.Context) error {
+		for i, op := range ops {
+			if err := ts.apply(ctx, ownerID, op); err != nil {
+				failed = i
+				results[i] = err
+
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err == nil {
+		ts.publish(ctx, pending.changes...)
+		return results, nil
+	}
+
+	for i := range results {
+		if i != failed {
+			results[i] = ErrTaskBatchAborted
+		}
+	}
+
+	if failed < 0 {
+		return results, fmt.Errorf("%w: %s", ErrTaskBatchFailed, err)
+	}
+
+	slogx.FromContext(ctx, slog.Default()).Info(
+		"atomic batch was rolled back",
+		slog.Int("operation", failed),
+		slog.Int("size", len(ops)),
+	)
+
+	return results, fmt.Errorf("%w: operation %d: %w", ErrTaskBatchAborted, failed, results[failed])
+}
+
+// apply applies a single batch operation to the task of the owner.
+func (ts *TaskService) apply(ctx context.Context, ownerID string, op BatchOperation) error {
+	var err error
+
+	switch op.Type {
+	case BatchOperationUpdate:
+		_, err = ts.Update(ctx, op.TaskID, ownerID, op.Update, op.ExpectedVersion)
+	case BatchOperationRemoveDeadline:
+		_, err = ts.RemoveDeadline(ctx, op.TaskID, ownerID, op.ExpectedVersion)
+	case BatchOperationComplete:
+		_, err = ts.Complete(ctx, op.TaskID, ownerID, op.ExpectedVersion)
+	case BatchOperationReopen:
+		_, err = ts.Reopen(ctx, op.TaskID, ownerID, op.ExpectedVersion)
+	case BatchOperationDelete:
+		_, err = ts.Delete(ctx, op.TaskID, ownerID, op.ExpectedVersion)
+	default:
+		err = ErrTaskBatchOperationInvalid
+	}
+
+	return err
+}
+
+const (
+	// DefaultTaskSyncLimit is the number of changes returned by TaskService.ChangesSince when the limit is zero.
+	DefaultTaskSyncLimit = 100
+
+	// MaxTaskSyncLimit is the maximum number of changes that can be requested from TaskService.ChangesSince.
+	MaxTaskSyncLimit = 1000
+)
+
+// TaskSyncChangeKind defines how a task has changed since the last sync.
+type TaskSyncChangeKind string
+
+const (
+	TaskSyncCreated TaskSyncChangeKind = "created"
+	TaskSyncUpdated TaskSyncChangeKind = "updated"
+	TaskSyncDeleted TaskSyncChangeKind = "deleted"
+)
+
+// TaskSyncChange is the last change of a task with its change sequence number.
+type TaskSyncChange struct {
+	TaskID string
+	Seq    int64
+	Kind   TaskSyncChangeKind
+}
+
+// TaskSyncPage contains the IDs of the tasks that have changed since a change sequence number.
+type TaskSyncPage struct {
+	Created []string
+	Updated []string
+	Deleted []string
+
+	// Seq is the sequence number of the last change in the page, to sync from next time.
+	// It is the requested one if nothing has changed.
+	Seq int64
+
+	// HasMore reports whether there are changes after Seq that did not fit in the page.
+	HasMore bool
+}
+
+// ChangesSince returns the IDs of the tasks of the owner that have been created, updated
+// or permanently deleted after the change with the sequence number since, at most limit of them.
+// A task is listed once, by its last change; a task in the trash counts as updated.
+// Zero since returns all tasks of the owner and zero limit means DefaultTaskSyncLimit.
+//
+// It returns ErrTaskSyncSeqInvalid if since is negative, ErrTaskSyncLimitInvalid
+// if limit is negative or greater than MaxTaskSyncLimit and ErrTaskSyncFailed for system errors.
+func (ts *TaskService) ChangesSince(ctx context.Context, ownerID string, since int64, limit int) (*TaskSyncPage, error) {
+	if since < 0 {
+		return nil, ErrTaskSyncSeqInvalid
+	}
+
+	if limit == 0 {
+		limit = DefaultTaskSyncLimit
+	}
+
+	if limit < 0 || limit > MaxTaskSyncLimit {
+		return nil, ErrTaskSyncLimitInvalid
+	}
+
+	// one more change tells whether there is another page
+	changes, err := ts.tasksRepo.FindChangedSince(ctx, ownerID, since, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrTaskSyncFailed, err)
+	}
+
+	page := &TaskSyncPage{
+		Created: make([]string, 0),
+		Updated: make([]string, 0),
+		Deleted: make([]string, 0),
+		Seq:     since,
+	}
+
+	if len(changes) > limit {
+		changes = changes[:limit]
+		page.HasMore = true
+	}
+
+	for _, change := range changes {
+		switch change.Kind {
+		case TaskSyncCreated:
+			page.Created = append(page.Created, change.TaskID)
+		case TaskSyncDeleted:
+			page.Deleted = append(page.Deleted, change.TaskID)
+		default:
+			page.Updated = append(page.Updated, change.TaskID)
+		}
+
+		page.Seq = change.Seq
+	}
+
+	return page, nil
+}
+
+// SyncOperationCreate is the type of the SyncOperation that creates a task.
+const SyncOperationCreate BatchOperationType = "create"
+
+// SyncOperation is a change that a client made to its copy of the tasks while offline
+// and pushes with TaskService.Push. Every type of BatchOperation is supported along with SyncOperationCreate.
+type SyncOperation struct {
+	BatchOperation
+
+	// Create is the data of the SyncOperationCreate operation and is ignored by the other ones.
+	// Its OwnerID is set by Push.
+	Create CreateTaskCommand
+}
+
+// SyncResult is the result of a single SyncOperation.
+type SyncResult struct {
+	// TaskID is the ID of the task of the operation or of the created one.
+	TaskID string
+
+	// Task is the current state of the task if the operation has been applied
+	// or failed with ErrTaskConflict, so that the client can resolve the conflict.
+	// It is nil otherwise or if the task cannot be loaded.
+	Task *models.Task
+
+	// Err is nil if the operation has been applied.
+	Err error
+}
+
+// Push applies the operations to the tasks of the owner in their order, each of them
+// independently of the others, and returns their results.
+// An operation fails in the same way as the TaskService method of the same name, and the one
+// of an unknown type fails with ErrTaskBatchOperationInvalid.
+func (ts *TaskService) Push(ctx context.Context, ownerID string, ops []SyncOperation) []SyncResult {
+	results := make([]SyncResult, len(ops))
+
+	for i, op := range ops {
+		result := &results[i]
+		result.TaskID = op.TaskID
+
+		if op.Type == SyncOperationCreate {
+			ownerUUID, err := uuid.Parse(ownerID)
+			if err != nil {
+				result.Err = ErrTaskOwnerNotFound
+				continue
+			}
+
+			cmd := op.Create
+			cmd.OwnerID = ownerUUID
+
+			result.TaskID, result.Err = ts.Create(ctx, cmd)
+		} else {
+			result.Err = ts.apply(ctx, ownerID, op.BatchOperation)
+		}
+
+		if result.Err != nil && !errors.Is(result.Err, ErrTaskConflict) {
+			continue
+		}
+
+		task, err := ts.tasksRepo.FindByID(ctx, result.TaskID)
+		if err != nil || task.OwnerID().String() != ownerID {
+			continue
+		}
+
+		result.Task = task
+	}
+
+	return results
+}
+
+// create saves the new task together with the event of its creation and publishes the change.
+func (ts *TaskService) create(ctx context.Context, task *models.Task) error {
+	var event *models.TaskEvent
+
+	err := ts.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if err := ts.tasksRepo.Create(ctx, task); err != nil {
+			return err
+		}
+
+		var err error
+		event, err = ts.record(ctx, task, models.TaskEventCreated, nil)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	ts.publish(ctx, TaskChange{OwnerID: task.OwnerID().String(), Version: task.Version(), Event: event})
+
+	return nil
+}
+
+// update saves the changed task together with an event of the given type
+// that records the change of the task from the before state, and publishes the change.
+func (ts *TaskService) update(
+	ctx context.Context,
+	task *models.Task,
+	eventType models.TaskEventType,
+	before models.TaskState,
+) error {
+	var event *models.TaskEvent
+
+	err := ts.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if err := ts.tasksRepo.Update(ctx, task); err != nil {
+			return err
+		}
+
+		var err error
+		event, err = ts.record(ctx, task, eventType, &before)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// the repository increments the version of the task on update
+	ts.publish(ctx, TaskChange{OwnerID: task.OwnerID().String(), Version: task.Version() + 1, Event: event})
+
+	return nil
+}
+
+// delete permanently removes the task together with recording the event of its removal,
+// which it returns. The task is removed only if it has not changed since it was loaded.
+func (ts *TaskService) delete(ctx context.Context, task *models.Task) (*models.TaskEvent, error) {
+	var event *models.TaskEvent
+
+	err := ts.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if err := ts.tasksRepo.Delete(ctx, task.ID().String(), task.Version()); err != nil {
+			return err
+		}
+
+		state := task.State()
+
+		var err error
+		event, err = ts.record(ctx, task, models.TaskEventPurged, &state)
+
+		return err
+	})
+
+	return event, err
+}
+
+// record saves an event of the given type for the task and returns it. Since only the owner
+// of a task is allowed to change it, the owner is recorded as the actor.
+func (ts *TaskService) record(
+	ctx context.Context,
+	task *models.Task,
+	eventType models.TaskEventType,
+	before *models.TaskState,
+) (*models.TaskEvent, error) {
+	event := models.NewTaskEvent(task, eventType, task.OwnerID(), before, ts.clock)
+
+	if err := ts.eventsRepo.Create(ctx, event); err != nil {
+		return nil, fmt.Errorf("record %s event: %w", eventType, err)
+	}
+
+	return event, nil
+}
+
+// pendingChanges collects the changes made within an outer transaction,
+// so that they are published only after it is committed.
+type pendingChanges struct {
+	changes []TaskChange
+}
+
+type pendingChangesKey struct{}
+
+func withPendingChanges(ctx context.Context, pending *pendingChanges) context.Context {
+	return context.WithValue(ctx, pendingChangesKey{}, pending)
+}
+
+// publish publishes the committed changes, or defers them until the outer transaction
+// of ctx is committed. A change that fails to be published is logged and dropped,
+// since the change itself has already been committed.
+func (ts *TaskService) publish(ctx context.Context, changes ...TaskChange) {
+	if ts.publisher == nil {
+		return
+	}
+
+	if pending, ok := ctx.Value(pendingChangesKey{}).(*pendingChanges); ok {
+		pending.changes = append(pending.changes, changes...)
+		return
+	}
+
+	for _, change := range changes {
+		if err := ts.publisher.Publish(ctx, change); err != nil {
+			slogx.FromContext(ctx, slog.Default()).Error(
+				"failed to publish task change",
+				slog.String("event_id", change.Event.ID().String()),
+				slog.String("err", err.Error()),
+			)
+		}
+	}
+}
+
+// versionMatches reports whether the task has the expected version.
+// A nil expectedVersion matches any version.
+func versionMatches(task *models.Task, expectedVersion *int64) bool {
+	return expectedVersion == nil || task.Version() == *expectedVersion
 }
@@ -3,6 +3,7 @@ package services_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/vo"
 	"github.com/cyberbrain-dev/taskery-api/internal/services"
 	"github.com/cyberbrain-dev/taskery-api/internal/services/mocks"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -17,25 +19,58 @@ import (
 
 func TestNewTaskService(t *testing.T) {
 	tests := []struct {
-		name      string
-		tasksRepo services.TaskRepository
-		wantErr   error
+		name       string
+		tasksRepo  services.TaskRepository
+		eventsRepo services.TaskEventRepository
+		transactor services.Transactor
+		clock      clock.Clock
+		wantErr    error
 	}{
 		{
-			name:      "success",
-			tasksRepo: new(mocks.TaskRepository),
-			wantErr:   nil,
+			name:       "success",
+			tasksRepo:  new(mocks.TaskRepository),
+			eventsRepo: new(mocks.TaskEventRepository),
+			transactor: new(mocks.Transactor),
+			clock:      clock.Real{},
+			wantErr:    nil,
+		},
+		{
+			name:       "nil tasks repo",
+			tasksRepo:  nil,
+			eventsRepo: new(mocks.TaskEventRepository),
+			transactor: new(mocks.Transactor),
+			clock:      clock.Real{},
+			wantErr:    services.ErrTaskRepositoryNil,
 		},
 		{
-			name:      "nil tasks repo",
-			tasksRepo: nil,
-			wantErr:   services.ErrTaskRepositoryNil,
+			name:       "nil events repo",
+			tasksRepo:  new(mocks.TaskRepository),
+			eventsRepo: nil,
+			transactor: new(mocks.Transactor),
+			clock:      clock.Real{},
+			wantErr:    services.ErrTaskEventRepositoryNil,
+		},
+		{
+			name:       "nil transactor",
+			tasksRepo:  new(mocks.TaskRepository),
+			eventsRepo: new(mocks.TaskEventRepository),
+			transactor: nil,
+			clock:      clock.Real{},
+			wantErr:    services.ErrTransactorNil,
+		},
+		{
+			name:       "nil clock",
+			tasksRepo:  new(mocks.TaskRepository),
+			eventsRepo: new(mocks.TaskEventRepository),
+			transactor: new(mocks.Transactor),
+			clock:      nil,
+			wantErr:    services.ErrClockNil,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			service, err := services.NewTaskService(tt.tasksRepo)
+			service, err := services.NewTaskService(tt.tasksRepo, tt.eventsRepo, tt.transactor, tt.clock, nil)
 			if tt.wantErr != nil {
 				require.ErrorIs(t, err, tt.wantErr)
 				require.Nil(t, service)
@@ -48,22 +83,47 @@ func TestNewTaskService(t *testing.T) {
 	}
 }
 
+// inlineTransactor runs functions in place, without a real transaction.
+type inlineTransactor struct{}
+
+func (inlineTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// failingTransactor fails to start a transaction.
+type failingTransactor struct{}
+
+func (failingTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return errors.New("failed to begin transaction")
+}
+
+// expectEvent sets up events to expect a single event of the given type.
+func expectEvent(events *mocks.TaskEventRepository, eventType models.TaskEventType) {
+	events.On("Create", mock.Anything, mock.MatchedBy(func(event *models.TaskEvent) bool {
+		return event.Type() == eventType
+	})).
+		Once().
+		Return(nil)
+}
+
 func TestTaskService_Create(t *testing.T) {
 	realUserID := uuid.New()
 	validDeadline := time.Now().Add(1 * time.Hour)
 	invalidDeadline := time.Now().Add(-1 * time.Hour)
 
 	tests := []struct {
-		name    string
-		cmd     services.CreateTaskCommand
-		wantErr error
+		name      string
+		cmd       services.CreateTaskCommand
+		wantErr   error
+		wantEvent models.TaskEventType
 
 		isTaskIDExpected bool
 
 		mocksSetup func(repo *mocks.TaskRepository)
 	}{
 		{
-			name: "success without deadline",
+			name:      "success without deadline",
+			wantEvent: models.TaskEventCreated,
 			cmd: services.CreateTaskCommand{
 				Title:       "title",
 				Description: "description",
@@ -81,7 +141,8 @@ func TestTaskService_Create(t *testing.T) {
 			},
 		},
 		{
-			name: "success with deadline",
+			name:      "success with deadline",
+			wantEvent: models.TaskEventCreated,
 			cmd: services.CreateTaskCommand{
 				Title:       "title",
 				Description: "description",
@@ -173,12 +234,20 @@ func TestTaskService_Create(t *testing.T) {
 				tt.mocksSetup(repo)
 			}
 
-			service, err := services.NewTaskService(repo)
+			events := new(mocks.TaskEventRepository)
+			if tt.wantEvent != "" {
+				expectEvent(events, tt.wantEvent)
+			}
+
+			service, err := services.NewTaskService(repo, events, inlineTransactor{}, clock.Real{}, nil)
 			require.NoError(t, err)
 			require.NotNil(t, service)
 
 			ctx := context.Background()
 			taskID, err := service.Create(ctx, tt.cmd)
+
+			events.AssertExpectations(t)
+
 			if tt.wantErr != nil {
 				require.ErrorIs(t, err, tt.wantErr)
 				return
@@ -198,22 +267,27 @@ func TestTaskService_Update(t *testing.T) {
 	realOwnerID := uuid.New()
 
 	tests := []struct {
-		name        string
-		id          string
-		cmd         services.UpdateTaskCommand
-		expectedErr error
+		name            string
+		id              string
+		cmd             services.UpdateTaskCommand
+		expectedVersion *int64
+		expectedErr     error
+		wantVersion     int64
+		wantEvent       models.TaskEventType
 
 		mocksSetup func(repo *mocks.TaskRepository, taskToReturn *models.Task)
 	}{
 		{
-			name: "success with all fields",
-			id:   realTaskID.String(),
+			name:      "success with all fields",
+			wantEvent: models.TaskEventUpdated,
+			id:        realTaskID.String(),
 			cmd: services.UpdateTaskCommand{
 				Title:       new("new title"),
 				Description: new("some new description"),
 				Deadline:    new(time.Now().Add(2 * time.Hour)),
 			},
 			expectedErr: nil,
+			wantVersion: 2,
 
 			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
 				repo.On("FindByID", mock.Anything, realTaskID.String()).
@@ -226,8 +300,9 @@ func TestTaskService_Update(t *testing.T) {
 			},
 		},
 		{
-			name: "success with one field",
-			id:   realTaskID.String(),
+			name:      "success with one field",
+			wantEvent: models.TaskEventUpdated,
+			id:        realTaskID.String(),
 			cmd: services.UpdateTaskCommand{
 				Title:       new("new title"),
 				Description: nil,
@@ -235,6 +310,7 @@ func TestTaskService_Update(t *testing.T) {
 			},
 
 			expectedErr: nil,
+			wantVersion: 2,
 
 			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
 				repo.On("FindByID", mock.Anything, realTaskID.String()).
@@ -256,7 +332,13 @@ func TestTaskService_Update(t *testing.T) {
 			},
 
 			expectedErr: nil,
-			mocksSetup:  nil,
+			wantVersion: 1,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, realTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+			},
 		},
 		{
 			name: "task not found",
@@ -296,6 +378,63 @@ func TestTaskService_Update(t *testing.T) {
 					Return(errors.New("internal db error"))
 			},
 		},
+		{
+			name: "version mismatch",
+			id:   realTaskID.String(),
+			cmd: services.UpdateTaskCommand{
+				Title: new("new title"),
+			},
+			expectedVersion: new(int64(2)),
+
+			expectedErr: services.ErrTaskConflict,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, realTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+			},
+		},
+		{
+			name:      "success with matching version",
+			wantEvent: models.TaskEventUpdated,
+			id:        realTaskID.String(),
+			cmd: services.UpdateTaskCommand{
+				Title: new("new title"),
+			},
+			expectedVersion: new(int64(1)),
+
+			expectedErr: nil,
+			wantVersion: 2,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, realTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+
+				repo.On("Update", mock.Anything, mock.AnythingOfType("*models.Task")).
+					Once().
+					Return(nil)
+			},
+		},
+		{
+			name: "concurrent modification",
+			id:   realTaskID.String(),
+			cmd: services.UpdateTaskCommand{
+				Title: new("new title"),
+			},
+
+			expectedErr: services.ErrTaskConflict,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, realTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+
+				repo.On("Update", mock.Anything, mock.AnythingOfType("*models.Task")).
+					Once().
+					Return(services.ErrTaskRepoConflict)
+			},
+		},
 		{
 			name: "invalid field",
 			id:   realTaskID.String(),
@@ -325,6 +464,7 @@ func TestTaskService_Update(t *testing.T) {
 				Deadline:    new(time.Now().Add(time.Hour)),
 				IsCompleted: false,
 				CompletedAt: nil,
+				Version:     1,
 			})
 			require.NoError(t, err)
 			require.NotNil(t, taskToReturn)
@@ -334,12 +474,19 @@ func TestTaskService_Update(t *testing.T) {
 				tt.mocksSetup(repo, taskToReturn)
 			}
 
-			service, err := services.NewTaskService(repo)
+			events := new(mocks.TaskEventRepository)
+			if tt.wantEvent != "" {
+				expectEvent(events, tt.wantEvent)
+			}
+
+			service, err := services.NewTaskService(repo, events, inlineTransactor{}, clock.Real{}, nil)
 			require.NoError(t, err)
 			require.NotNil(t, service)
 
 			ctx := context.Background()
-			err = service.Update(ctx, tt.id, realOwnerID.String(), tt.cmd)
+			version, err := service.Update(ctx, tt.id, realOwnerID.String(), tt.cmd, tt.expectedVersion)
+
+			events.AssertExpectations(t)
 
 			if tt.expectedErr != nil {
 				require.ErrorIs(t, err, tt.expectedErr)
@@ -359,6 +506,7 @@ func TestTaskService_Update(t *testing.T) {
 			}
 
 			require.NoError(t, err)
+			require.Equal(t, tt.wantVersion, version)
 		})
 	}
 }
@@ -368,7 +516,8 @@ func TestTaskService_Complete(t *testing.T) {
 	realOwnerID := uuid.New()
 	notRealTaskID := uuid.New()
 
-	realCompletedAt := time.Now().Add(-1 * time.Hour)
+	now := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+	realCompletedAt := now.Add(-1 * time.Hour)
 
 	tests := []struct {
 		name    string
@@ -377,16 +526,22 @@ func TestTaskService_Complete(t *testing.T) {
 
 		wasCompleted bool
 
-		wantErr error
+		expectedVersion *int64
+
+		wantErr     error
+		wantVersion int64
+		wantEvent   models.TaskEventType
 
 		mocksSetup func(repo *mocks.TaskRepository, taskToReturn *models.Task)
 	}{
 		{
 			name:         "success if not completed",
+			wantEvent:    models.TaskEventCompleted,
 			id:           realTaskID.String(),
 			ownerID:      realOwnerID.String(),
 			wasCompleted: false,
 			wantErr:      nil,
+			wantVersion:  2,
 			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
 				repo.On("FindByID", mock.Anything, realTaskID.String()).
 					Once().
@@ -403,6 +558,7 @@ func TestTaskService_Complete(t *testing.T) {
 			ownerID:      realOwnerID.String(),
 			wasCompleted: true,
 			wantErr:      nil,
+			wantVersion:  1,
 			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
 				repo.On("FindByID", mock.Anything, realTaskID.String()).
 					Once().
@@ -437,6 +593,35 @@ func TestTaskService_Complete(t *testing.T) {
 					Return(taskToReturn, nil)
 			},
 		},
+		{
+			name:            "version mismatch",
+			id:              realTaskID.String(),
+			ownerID:         realOwnerID.String(),
+			wasCompleted:    false,
+			expectedVersion: new(int64(4)),
+			wantErr:         services.ErrTaskConflict,
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, realTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+			},
+		},
+		{
+			name:         "concurrent modification",
+			id:           realTaskID.String(),
+			ownerID:      realOwnerID.String(),
+			wasCompleted: false,
+			wantErr:      services.ErrTaskConflict,
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, realTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+
+				repo.On("Update", mock.Anything, mock.AnythingOfType("*models.Task")).
+					Once().
+					Return(services.ErrTaskRepoConflict)
+			},
+		},
 		{
 			name:         "update failed",
 			id:           realTaskID.String(),
@@ -470,6 +655,7 @@ func TestTaskService_Complete(t *testing.T) {
 				Deadline:    nil,
 				IsCompleted: tt.wasCompleted,
 				CompletedAt: completedAt,
+				Version:     1,
 			})
 			require.NoError(t, err)
 			require.NotNil(t, taskToReturn)
@@ -479,12 +665,20 @@ func TestTaskService_Complete(t *testing.T) {
 				tt.mocksSetup(repo, taskToReturn)
 			}
 
-			service, err := services.NewTaskService(repo)
+			events := new(mocks.TaskEventRepository)
+			if tt.wantEvent != "" {
+				expectEvent(events, tt.wantEvent)
+			}
+
+			service, err := services.NewTaskService(repo, events, inlineTransactor{}, clock.NewFake(now), nil)
 			require.NoError(t, err)
 			require.NotNil(t, service)
 
 			ctx := context.Background()
-			err = service.Complete(ctx, tt.id, tt.ownerID)
+			version, err := service.Complete(ctx, tt.id, tt.ownerID, tt.expectedVersion)
+
+			events.AssertExpectations(t)
+
 			if tt.wantErr != nil {
 				require.ErrorIs(t, err, tt.wantErr)
 				return
@@ -492,6 +686,14 @@ func TestTaskService_Complete(t *testing.T) {
 
 			require.True(t, taskToReturn.IsCompleted())
 			require.NoError(t, err)
+			require.Equal(t, tt.wantVersion, version)
+
+			if tt.wasCompleted {
+				require.Equal(t, realCompletedAt, *taskToReturn.CompletedAt())
+			} else {
+				require.Equal(t, now, *taskToReturn.CompletedAt())
+				require.Equal(t, now, taskToReturn.UpdatedAt())
+			}
 		})
 	}
 }
@@ -510,16 +712,20 @@ func TestTaskService_Reopen(t *testing.T) {
 
 		wasCompleted bool
 
-		wantErr error
+		wantErr     error
+		wantVersion int64
+		wantEvent   models.TaskEventType
 
 		mocksSetup func(repo *mocks.TaskRepository, taskToReturn *models.Task)
 	}{
 		{
 			name:         "success if already completed",
+			wantEvent:    models.TaskEventReopened,
 			id:           realTaskID.String(),
 			ownerID:      realOwnerID.String(),
 			wasCompleted: true,
 			wantErr:      nil,
+			wantVersion:  2,
 			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
 				repo.On("FindByID", mock.Anything, realTaskID.String()).
 					Once().
@@ -536,6 +742,7 @@ func TestTaskService_Reopen(t *testing.T) {
 			ownerID:      realOwnerID.String(),
 			wasCompleted: false,
 			wantErr:      nil,
+			wantVersion:  1,
 			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
 				repo.On("FindByID", mock.Anything, realTaskID.String()).
 					Once().
@@ -603,6 +810,7 @@ func TestTaskService_Reopen(t *testing.T) {
 				Deadline:    nil,
 				IsCompleted: tt.wasCompleted,
 				CompletedAt: completedAt,
+				Version:     1,
 			})
 			require.NoError(t, err)
 			require.NotNil(t, taskToReturn)
@@ -612,12 +820,20 @@ func TestTaskService_Reopen(t *testing.T) {
 				tt.mocksSetup(repo, taskToReturn)
 			}
 
-			service, err := services.NewTaskService(repo)
+			events := new(mocks.TaskEventRepository)
+			if tt.wantEvent != "" {
+				expectEvent(events, tt.wantEvent)
+			}
+
+			service, err := services.NewTaskService(repo, events, inlineTransactor{}, clock.Real{}, nil)
 			require.NoError(t, err)
 			require.NotNil(t, service)
 
 			ctx := context.Background()
-			err = service.Reopen(ctx, tt.id, tt.ownerID)
+			version, err := service.Reopen(ctx, tt.id, tt.ownerID, nil)
+
+			events.AssertExpectations(t)
+
 			if tt.wantErr != nil {
 				require.ErrorIs(t, err, tt.wantErr)
 				return
@@ -625,16 +841,188 @@ func TestTaskService_Reopen(t *testing.T) {
 
 			require.False(t, taskToReturn.IsCompleted())
 			require.NoError(t, err)
+			require.Equal(t, tt.wantVersion, version)
+		})
+	}
+}
+
+func TestTaskService_FindByID(t *testing.T) {
+	realTaskID := uuid.New()
+	realOwnerID := uuid.New()
+
+	taskToReturn, err := models.NewTaskFromDB(models.TaskFromDBParams{
+		ID:          realTaskID.String(),
+		OwnerID:     realOwnerID.String(),
+		Title:       "Some Title",
+		Description: "Some Description",
+		Version:     1,
+	})
+	require.NoError(t, err)
+
+	deletedAt := time.Now().Add(-time.Hour)
+	deletedTask, err := models.NewTaskFromDB(models.TaskFromDBParams{
+		ID:          realTaskID.String(),
+		OwnerID:     realOwnerID.String(),
+		Title:       "Some Title",
+		Description: "Some Description",
+		DeletedAt:   &deletedAt,
+		Version:     2,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		id      string
+		ownerID string
+		wantErr error
+
+		mocksSetup func(repo *mocks.TaskRepository)
+	}{
+		{
+			name:    "success",
+			id:      realTaskID.String(),
+			ownerID: realOwnerID.String(),
+			wantErr: nil,
+			mocksSetup: func(repo *mocks.TaskRepository) {
+				repo.On("FindByID", mock.Anything, realTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+			},
+		},
+		{
+			name:    "task not found",
+			id:      realTaskID.String(),
+			ownerID: realOwnerID.String(),
+			wantErr: services.ErrTaskNotFound,
+			mocksSetup: func(repo *mocks.TaskRepository) {
+				repo.On("FindByID", mock.Anything, realTaskID.String()).
+					Once().
+					Return(nil, services.ErrTaskRepoNotFound)
+			},
+		},
+		{
+			name:    "task is in trash",
+			id:      realTaskID.String(),
+			ownerID: realOwnerID.String(),
+			wantErr: services.ErrTaskNotFound,
+			mocksSetup: func(repo *mocks.TaskRepository) {
+				repo.On("FindByID", mock.Anything, realTaskID.String()).
+					Once().
+					Return(deletedTask, nil)
+			},
+		},
+		{
+			name:    "access denied",
+			id:      realTaskID.String(),
+			ownerID: uuid.New().String(),
+			wantErr: services.ErrTaskAccessDenied,
+			mocksSetup: func(repo *mocks.TaskRepository) {
+				repo.On("FindByID", mock.Anything, realTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+			},
+		},
+		{
+			name:    "internal error",
+			id:      realTaskID.String(),
+			ownerID: realOwnerID.String(),
+			wantErr: services.ErrTaskFindByIDFailed,
+			mocksSetup: func(repo *mocks.TaskRepository) {
+				repo.On("FindByID", mock.Anything, realTaskID.String()).
+					Once().
+					Return(nil, errors.New("failed to connect to db"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.TaskRepository)
+			if tt.mocksSetup != nil {
+				tt.mocksSetup(repo)
+			}
+
+			events := new(mocks.TaskEventRepository)
+
+			service, err := services.NewTaskService(repo, events, inlineTransactor{}, clock.Real{}, nil)
+			require.NoError(t, err)
+
+			task, err := service.FindByID(context.Background(), tt.id, tt.ownerID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, task)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, taskToReturn, task)
 		})
 	}
 }
 
+func TestTaskService_FindByIDs(t *testing.T) {
+	ownerID := uuid.New()
+	deletedAt := time.Now().Add(-time.Hour)
+
+	active, err := models.NewTask("active", "", ownerID, clock.Real{})
+	require.NoError(t, err)
+
+	trashed, err := models.NewTaskFromDB(models.TaskFromDBParams{
+		ID:        uuid.NewString(),
+		OwnerID:   ownerID.String(),
+		Title:     "trashed",
+		DeletedAt: &deletedAt,
+		Version:   2,
+	})
+	require.NoError(t, err)
+
+	foreign, err := models.NewTask("foreign", "", uuid.New(), clock.Real{})
+	require.NoError(t, err)
+
+	ids := []string{active.ID().String(), trashed.ID().String(), foreign.ID().String(), active.ID().String()}
+	validIDs := []string{active.ID().String(), trashed.ID().String(), foreign.ID().String()}
+
+	t.Run("owned active tasks", func(t *testing.T) {
+		repo := new(mocks.TaskRepository)
+		repo.On("FindByIDs", mock.Anything, validIDs).
+			Once().
+			Return([]*models.Task{active, trashed, foreign}, nil)
+
+		service, err := services.NewTaskService(repo, new(mocks.TaskEventRepository), inlineTransactor{}, clock.Real{}, nil)
+		require.NoError(t, err)
+
+		tasks, err := service.FindByIDs(context.Background(), ids, ownerID.String())
+		require.NoError(t, err)
+		require.Equal(t, []*models.Task{active}, tasks)
+
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("internal db error", func(t *testing.T) {
+		repo := new(mocks.TaskRepository)
+		repo.On("FindByIDs", mock.Anything, validIDs).
+			Once().
+			Return(nil, errors.New("failed to connect to db"))
+
+		service, err := services.NewTaskService(repo, new(mocks.TaskEventRepository), inlineTransactor{}, clock.Real{}, nil)
+		require.NoError(t, err)
+
+		tasks, err := service.FindByIDs(context.Background(), ids, ownerID.String())
+		require.ErrorIs(t, err, services.ErrTaskFindByIDFailed)
+		require.Nil(t, tasks)
+
+		repo.AssertExpectations(t)
+	})
+}
+
 func TestTaskService_FindByOwner(t *testing.T) {
 	realOwnerID := uuid.New()
+	updatedSince := time.Now().Add(-time.Hour)
 
 	tests := []struct {
 		name       string
 		ownerID    string
+		query      services.FindByOwnerQuery
 		wantErr    error
 		wantLen    int
 		mocksSetup func(repo *mocks.TaskRepository)
@@ -648,14 +1036,14 @@ func TestTaskService_FindByOwner(t *testing.T) {
 			mocksSetup: func(repo *mocks.TaskRepository) {
 				t.Helper()
 
-				task1, err := models.NewTask("title", "some description", realOwnerID)
+				task1, err := models.NewTask("title", "some description", realOwnerID, clock.Real{})
 				require.NoError(t, err)
 
 				sliceToReturn := []*models.Task{
 					task1,
 				}
 
-				repo.On("FindByOwner", mock.Anything, realOwnerID.String()).
+				repo.On("FindByOwner", mock.Anything, realOwnerID.String(), services.FindByOwnerQuery{}).
 					Once().
 					Return(sliceToReturn, nil)
 			},
@@ -669,13 +1057,13 @@ func TestTaskService_FindByOwner(t *testing.T) {
 			mocksSetup: func(repo *mocks.TaskRepository) {
 				t.Helper()
 
-				task1, err := models.NewTask("title", "some description", realOwnerID)
+				task1, err := models.NewTask("title", "some description", realOwnerID, clock.Real{})
 				require.NoError(t, err)
 
-				task2, err := models.NewTask("title2", "some description2", realOwnerID)
+				task2, err := models.NewTask("title2", "some description2", realOwnerID, clock.Real{})
 				require.NoError(t, err)
 
-				task3, err := models.NewTask("title3", "", realOwnerID)
+				task3, err := models.NewTask("title3", "", realOwnerID, clock.Real{})
 				require.NoError(t, err)
 
 				sliceToReturn := []*models.Task{
@@ -684,7 +1072,7 @@ func TestTaskService_FindByOwner(t *testing.T) {
 					task3,
 				}
 
-				repo.On("FindByOwner", mock.Anything, realOwnerID.String()).
+				repo.On("FindByOwner", mock.Anything, realOwnerID.String(), services.FindByOwnerQuery{}).
 					Once().
 					Return(sliceToReturn, nil)
 			},
@@ -698,11 +1086,44 @@ func TestTaskService_FindByOwner(t *testing.T) {
 			mocksSetup: func(repo *mocks.TaskRepository) {
 				t.Helper()
 
-				repo.On("FindByOwner", mock.Anything, realOwnerID.String()).
+				repo.On("FindByOwner", mock.Anything, realOwnerID.String(), services.FindByOwnerQuery{}).
 					Once().
 					Return([]*models.Task{}, nil)
 			},
 		},
+		{
+			name:    "success with sort and updated since",
+			ownerID: realOwnerID.String(),
+			query: services.FindByOwnerQuery{
+				Sort:         services.TaskSortCreatedAtDesc,
+				UpdatedSince: &updatedSince,
+			},
+			wantErr: nil,
+			wantLen: 1,
+
+			mocksSetup: func(repo *mocks.TaskRepository) {
+				t.Helper()
+
+				task1, err := models.NewTask("title", "some description", realOwnerID, clock.Real{})
+				require.NoError(t, err)
+
+				query := services.FindByOwnerQuery{
+					Sort:         services.TaskSortCreatedAtDesc,
+					UpdatedSince: &updatedSince,
+				}
+
+				repo.On("FindByOwner", mock.Anything, realOwnerID.String(), query).
+					Once().
+					Return([]*models.Task{task1}, nil)
+			},
+		},
+		{
+			name:       "invalid sort",
+			ownerID:    realOwnerID.String(),
+			query:      services.FindByOwnerQuery{Sort: "title"},
+			wantErr:    services.ErrTaskSortInvalid,
+			mocksSetup: nil,
+		},
 		{
 			name:    "internal db error",
 			ownerID: realOwnerID.String(),
@@ -711,7 +1132,7 @@ func TestTaskService_FindByOwner(t *testing.T) {
 			mocksSetup: func(repo *mocks.TaskRepository) {
 				t.Helper()
 
-				repo.On("FindByOwner", mock.Anything, realOwnerID.String()).
+				repo.On("FindByOwner", mock.Anything, realOwnerID.String(), services.FindByOwnerQuery{}).
 					Once().
 					Return(nil, errors.New("failed to connect to db"))
 			},
@@ -725,12 +1146,14 @@ func TestTaskService_FindByOwner(t *testing.T) {
 				tt.mocksSetup(repo)
 			}
 
-			service, err := services.NewTaskService(repo)
+			events := new(mocks.TaskEventRepository)
+
+			service, err := services.NewTaskService(repo, events, inlineTransactor{}, clock.Real{}, nil)
 			require.NoError(t, err)
 
 			ctx := context.Background()
 
-			result, err := service.FindByOwner(ctx, tt.ownerID)
+			result, err := service.FindByOwner(ctx, tt.ownerID, tt.query)
 			if tt.wantErr != nil {
 				require.ErrorIs(t, err, tt.wantErr)
 				require.Nil(t, result)
@@ -747,79 +1170,115 @@ func TestTaskService_FindByOwner(t *testing.T) {
 func TestTaskService_Delete(t *testing.T) {
 	validTaskID := uuid.New()
 	validOwnerID := uuid.New()
+	now := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+	deletedAt := now.Add(-time.Hour)
 
 	tests := []struct {
-		name    string
-		taskID  string
-		ownerID string
-		wantErr error
+		name      string
+		taskID    string
+		ownerID   string
+		version   *int64
+		deletedAt *time.Time
+		wantErr   error
+		wantEvent models.TaskEventType
 
-		mocksSetup func(repo *mocks.TaskRepository)
+		mocksSetup func(repo *mocks.TaskRepository, taskToReturn *models.Task)
 	}{
 		{
-			name:    "success",
-			taskID:  validTaskID.String(),
-			ownerID: validOwnerID.String(),
-			wantErr: nil,
+			name:      "success",
+			wantEvent: models.TaskEventDeleted,
+			taskID:    validTaskID.String(),
+			ownerID:   validOwnerID.String(),
+			wantErr:   nil,
 
-			mocksSetup: func(repo *mocks.TaskRepository) {
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
 				repo.On("FindByID", mock.Anything, validTaskID.String()).
 					Once().
-					Return(models.NewTaskFromDB(models.TaskFromDBParams{
-						ID:          validTaskID.String(),
-						OwnerID:     validOwnerID.String(),
-						Title:       "some title",
-						Description: "some description",
-						Deadline:    nil,
-						IsCompleted: false,
-						CompletedAt: nil,
-					}))
+					Return(taskToReturn, nil)
 
-				repo.On("Delete", mock.Anything, validTaskID.String()).
+				repo.On("Update", mock.Anything, taskToReturn).
 					Once().
 					Return(nil)
 			},
 		},
 		{
-			name:    "access denied",
-			taskID:  validTaskID.String(),
-			ownerID: uuid.New().String(),
-			wantErr: services.ErrTaskAccessDenied,
+			name:      "already in trash",
+			taskID:    validTaskID.String(),
+			ownerID:   validOwnerID.String(),
+			deletedAt: &deletedAt,
+			wantErr:   services.ErrTaskNotFound,
 
-			mocksSetup: func(repo *mocks.TaskRepository) {
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
 				repo.On("FindByID", mock.Anything, validTaskID.String()).
 					Once().
-					Return(models.NewTaskFromDB(models.TaskFromDBParams{
-						ID:          validTaskID.String(),
-						OwnerID:     validOwnerID.String(),
-						Title:       "some title",
-						Description: "some description",
-						Deadline:    nil,
-						IsCompleted: false,
-						CompletedAt: nil,
-					}))
+					Return(taskToReturn, nil)
 			},
 		},
 		{
-			name:    "internal db error",
+			name:    "task not found",
 			taskID:  validTaskID.String(),
 			ownerID: validOwnerID.String(),
-			wantErr: services.ErrTaskDeleteFailed,
+			wantErr: services.ErrTaskNotFound,
 
-			mocksSetup: func(repo *mocks.TaskRepository) {
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
 				repo.On("FindByID", mock.Anything, validTaskID.String()).
 					Once().
-					Return(models.NewTaskFromDB(models.TaskFromDBParams{
-						ID:          validTaskID.String(),
-						OwnerID:     validOwnerID.String(),
-						Title:       "some title",
-						Description: "some description",
-						Deadline:    nil,
-						IsCompleted: false,
-						CompletedAt: nil,
-					}))
-
-				repo.On("Delete", mock.Anything, validTaskID.String()).
+					Return(nil, services.ErrTaskRepoNotFound)
+			},
+		},
+		{
+			name:    "access denied",
+			taskID:  validTaskID.String(),
+			ownerID: uuid.New().String(),
+			wantErr: services.ErrTaskAccessDenied,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+			},
+		},
+		{
+			name:    "version mismatch",
+			taskID:  validTaskID.String(),
+			ownerID: validOwnerID.String(),
+			version: new(int64(3)),
+			wantErr: services.ErrTaskConflict,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+			},
+		},
+		{
+			name:    "concurrent modification",
+			taskID:  validTaskID.String(),
+			ownerID: validOwnerID.String(),
+			wantErr: services.ErrTaskConflict,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+
+				repo.On("Update", mock.Anything, taskToReturn).
+					Once().
+					Return(services.ErrTaskRepoConflict)
+			},
+		},
+		{
+			name:    "internal db error",
+			taskID:  validTaskID.String(),
+			ownerID: validOwnerID.String(),
+			wantErr: services.ErrTaskDeleteFailed,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+
+				repo.On("Update", mock.Anything, taskToReturn).
 					Once().
 					Return(errors.New("failed to connect to db"))
 			},
@@ -828,16 +1287,201 @@ func TestTaskService_Delete(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			taskToReturn, err := models.NewTaskFromDB(models.TaskFromDBParams{
+				ID:          validTaskID.String(),
+				OwnerID:     validOwnerID.String(),
+				Title:       "some title",
+				Description: "some description",
+				DeletedAt:   tt.deletedAt,
+				Version:     2,
+			})
+			require.NoError(t, err)
+
 			repo := new(mocks.TaskRepository)
 			if tt.mocksSetup != nil {
-				tt.mocksSetup(repo)
+				tt.mocksSetup(repo, taskToReturn)
+			}
+
+			events := new(mocks.TaskEventRepository)
+			if tt.wantEvent != "" {
+				expectEvent(events, tt.wantEvent)
+			}
+
+			service, err := services.NewTaskService(repo, events, inlineTransactor{}, clock.NewFake(now), nil)
+			require.NoError(t, err)
+
+			ctx := context.Background()
+			version, err := service.Delete(ctx, tt.taskID, tt.ownerID, tt.version)
+
+			repo.AssertExpectations(t)
+			events.AssertExpectations(t)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, int64(3), version)
+			require.True(t, taskToReturn.IsDeleted())
+			require.Equal(t, now, *taskToReturn.DeletedAt())
+		})
+	}
+}
+
+func TestTaskService_DeletePermanently(t *testing.T) {
+	validTaskID := uuid.New()
+	validOwnerID := uuid.New()
+	deletedAt := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name      string
+		taskID    string
+		ownerID   string
+		version   *int64
+		deletedAt *time.Time
+		wantErr   error
+		wantEvent models.TaskEventType
+
+		mocksSetup func(repo *mocks.TaskRepository, taskToReturn *models.Task)
+	}{
+		{
+			name:      "success with active task",
+			wantEvent: models.TaskEventPurged,
+			taskID:    validTaskID.String(),
+			ownerID:   validOwnerID.String(),
+			wantErr:   nil,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+
+				repo.On("Delete", mock.Anything, validTaskID.String(), int64(2)).
+					Once().
+					Return(nil)
+			},
+		},
+		{
+			name:      "success with task in trash",
+			wantEvent: models.TaskEventPurged,
+			taskID:    validTaskID.String(),
+			ownerID:   validOwnerID.String(),
+			deletedAt: &deletedAt,
+			wantErr:   nil,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+
+				repo.On("Delete", mock.Anything, validTaskID.String(), int64(2)).
+					Once().
+					Return(nil)
+			},
+		},
+		{
+			name:    "task not found",
+			taskID:  validTaskID.String(),
+			ownerID: validOwnerID.String(),
+			wantErr: services.ErrTaskNotFound,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(nil, services.ErrTaskRepoNotFound)
+			},
+		},
+		{
+			name:    "access denied",
+			taskID:  validTaskID.String(),
+			ownerID: uuid.New().String(),
+			wantErr: services.ErrTaskAccessDenied,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+			},
+		},
+		{
+			name:    "version mismatch",
+			taskID:  validTaskID.String(),
+			ownerID: validOwnerID.String(),
+			version: new(int64(3)),
+			wantErr: services.ErrTaskConflict,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+			},
+		},
+		{
+			name:    "internal db error",
+			taskID:  validTaskID.String(),
+			ownerID: validOwnerID.String(),
+			wantErr: services.ErrTaskDeleteFailed,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+
+				repo.On("Delete", mock.Anything, validTaskID.String(), int64(2)).
+					Once().
+					Return(errors.New("failed to connect to db"))
+			},
+		},
+		{
+			name:    "concurrent modification",
+			taskID:  validTaskID.String(),
+			ownerID: validOwnerID.String(),
+			wantErr: services.ErrTaskConflict,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+
+				repo.On("Delete", mock.Anything, validTaskID.String(), int64(2)).
+					Once().
+					Return(services.ErrTaskRepoConflict)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskToReturn, err := models.NewTaskFromDB(models.TaskFromDBParams{
+				ID:          validTaskID.String(),
+				OwnerID:     validOwnerID.String(),
+				Title:       "some title",
+				Description: "some description",
+				DeletedAt:   tt.deletedAt,
+				Version:     2,
+			})
+			require.NoError(t, err)
+
+			repo := new(mocks.TaskRepository)
+			if tt.mocksSetup != nil {
+				tt.mocksSetup(repo, taskToReturn)
 			}
 
-			service, err := services.NewTaskService(repo)
+			events := new(mocks.TaskEventRepository)
+			if tt.wantEvent != "" {
+				expectEvent(events, tt.wantEvent)
+			}
+
+			service, err := services.NewTaskService(repo, events, inlineTransactor{}, clock.Real{}, nil)
 			require.NoError(t, err)
 
 			ctx := context.Background()
-			err = service.Delete(ctx, tt.taskID, tt.ownerID)
+			err = service.DeletePermanently(ctx, tt.taskID, tt.ownerID, tt.version)
+
+			repo.AssertExpectations(t)
+			events.AssertExpectations(t)
+
 			if tt.wantErr != nil {
 				require.ErrorIs(t, err, tt.wantErr)
 				return
@@ -847,3 +1491,1907 @@ func TestTaskService_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskService_Restore(t *testing.T) {
+	validTaskID := uuid.New()
+	validOwnerID := uuid.New()
+	now := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+	deletedAt := now.Add(-time.Hour)
+
+	tests := []struct {
+		name      string
+		taskID    string
+		ownerID   string
+		version   *int64
+		deletedAt *time.Time
+		wantErr   error
+		wantEvent models.TaskEventType
+
+		mocksSetup func(repo *mocks.TaskRepository, taskToReturn *models.Task)
+	}{
+		{
+			name:      "success",
+			wantEvent: models.TaskEventRestored,
+			taskID:    validTaskID.String(),
+			ownerID:   validOwnerID.String(),
+			deletedAt: &deletedAt,
+			wantErr:   nil,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+
+				repo.On("Update", mock.Anything, taskToReturn).
+					Once().
+					Return(nil)
+			},
+		},
+		{
+			name:    "not in trash",
+			taskID:  validTaskID.String(),
+			ownerID: validOwnerID.String(),
+			wantErr: services.ErrTaskNotInTrash,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+			},
+		},
+		{
+			name:      "task not found",
+			taskID:    validTaskID.String(),
+			ownerID:   validOwnerID.String(),
+			deletedAt: &deletedAt,
+			wantErr:   services.ErrTaskNotFound,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(nil, services.ErrTaskRepoNotFound)
+			},
+		},
+		{
+			name:      "access denied",
+			taskID:    validTaskID.String(),
+			ownerID:   uuid.New().String(),
+			deletedAt: &deletedAt,
+			wantErr:   services.ErrTaskAccessDenied,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+			},
+		},
+		{
+			name:      "version mismatch",
+			taskID:    validTaskID.String(),
+			ownerID:   validOwnerID.String(),
+			version:   new(int64(3)),
+			deletedAt: &deletedAt,
+			wantErr:   services.ErrTaskConflict,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+			},
+		},
+		{
+			name:      "title is taken",
+			taskID:    validTaskID.String(),
+			ownerID:   validOwnerID.String(),
+			deletedAt: &deletedAt,
+			wantErr:   services.ErrTaskExists,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+
+				repo.On("Update", mock.Anything, taskToReturn).
+					Once().
+					Return(services.ErrTaskRepoExists)
+			},
+		},
+		{
+			name:      "internal db error",
+			taskID:    validTaskID.String(),
+			ownerID:   validOwnerID.String(),
+			deletedAt: &deletedAt,
+			wantErr:   services.ErrTaskRestoreFailed,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+
+				repo.On("Update", mock.Anything, taskToReturn).
+					Once().
+					Return(errors.New("failed to connect to db"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskToReturn, err := models.NewTaskFromDB(models.TaskFromDBParams{
+				ID:          validTaskID.String(),
+				OwnerID:     validOwnerID.String(),
+				Title:       "some title",
+				Description: "some description",
+				DeletedAt:   tt.deletedAt,
+				Version:     2,
+			})
+			require.NoError(t, err)
+
+			repo := new(mocks.TaskRepository)
+			if tt.mocksSetup != nil {
+				tt.mocksSetup(repo, taskToReturn)
+			}
+
+			events := new(mocks.TaskEventRepository)
+			if tt.wantEvent != "" {
+				expectEvent(events, tt.wantEvent)
+			}
+
+			service, err := services.NewTaskService(repo, events, inlineTransactor{}, clock.NewFake(now), nil)
+			require.NoError(t, err)
+
+			ctx := context.Background()
+			version, err := service.Restore(ctx, tt.taskID, tt.ownerID, tt.version)
+
+			repo.AssertExpectations(t)
+			events.AssertExpectations(t)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, int64(3), version)
+			require.False(t, taskToReturn.IsDeleted())
+			require.Equal(t, now, taskToReturn.UpdatedAt())
+		})
+	}
+}
+
+func TestTaskService_FindTrash(t *testing.T) {
+	ownerID := uuid.New()
+
+	tests := []struct {
+		name    string
+		wantErr error
+		wantLen int
+
+		mocksSetup func(repo *mocks.TaskRepository)
+	}{
+		{
+			name:    "success",
+			wantErr: nil,
+			wantLen: 1,
+
+			mocksSetup: func(repo *mocks.TaskRepository) {
+				task, err := models.NewTask("title", "some description", ownerID, clock.Real{})
+				require.NoError(t, err)
+				task.MoveToTrash(clock.Real{})
+
+				repo.On("FindByOwner", mock.Anything, ownerID.String(), services.FindByOwnerQuery{InTrash: true}).
+					Once().
+					Return([]*models.Task{task}, nil)
+			},
+		},
+		{
+			name:    "internal db error",
+			wantErr: services.ErrTaskFindTrashFailed,
+
+			mocksSetup: func(repo *mocks.TaskRepository) {
+				repo.On("FindByOwner", mock.Anything, ownerID.String(), services.FindByOwnerQuery{InTrash: true}).
+					Once().
+					Return(nil, errors.New("failed to connect to db"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.TaskRepository)
+			tt.mocksSetup(repo)
+
+			events := new(mocks.TaskEventRepository)
+
+			service, err := services.NewTaskService(repo, events, inlineTransactor{}, clock.Real{}, nil)
+			require.NoError(t, err)
+
+			result, err := service.FindTrash(context.Background(), ownerID.String())
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, result, tt.wantLen)
+		})
+	}
+}
+
+func TestTaskService_Search(t *testing.T) {
+	ownerID := uuid.New()
+
+	tests := []struct {
+		name    string
+		query   services.SearchTasksQuery
+		wantErr error
+		wantLen int
+
+		mocksSetup func(repo *mocks.TaskRepository)
+	}{
+		{
+			name:    "success with default limit",
+			query:   services.SearchTasksQuery{Text: `"buy milk" rep*`},
+			wantErr: nil,
+			wantLen: 1,
+
+			mocksSetup: func(repo *mocks.TaskRepository) {
+				task, err := models.NewTask("buy milk", "weekly report", ownerID, clock.Real{})
+				require.NoError(t, err)
+
+				repo.On(
+					"Search",
+					mock.Anything,
+					ownerID.String(),
+					mock.MatchedBy(func(q vo.SearchQuery) bool { return q.String() == `"buy milk" rep*` }),
+					services.DefaultTaskSearchLimit,
+					false,
+				).
+					Once().
+					Return([]services.TaskSearchResult{{Task: task, Rank: 0.5}}, nil)
+			},
+		},
+		{
+			name:    "success with archived tasks and custom limit",
+			query:   services.SearchTasksQuery{Text: "milk", Limit: 5, IncludeArchived: true},
+			wantErr: nil,
+			wantLen: 0,
+
+			mocksSetup: func(repo *mocks.TaskRepository) {
+				repo.On("Search", mock.Anything, ownerID.String(), mock.Anything, 5, true).
+					Once().
+					Return([]services.TaskSearchResult{}, nil)
+			},
+		},
+		{
+			name:    "empty query",
+			query:   services.SearchTasksQuery{Text: "  "},
+			wantErr: vo.ErrSearchQueryEmpty,
+
+			mocksSetup: func(repo *mocks.TaskRepository) {},
+		},
+		{
+			name:    "limit too large",
+			query:   services.SearchTasksQuery{Text: "milk", Limit: services.MaxTaskSearchLimit + 1},
+			wantErr: services.ErrTaskSearchLimitInvalid,
+
+			mocksSetup: func(repo *mocks.TaskRepository) {},
+		},
+		{
+			name:    "negative limit",
+			query:   services.SearchTasksQuery{Text: "milk", Limit: -1},
+			wantErr: services.ErrTaskSearchLimitInvalid,
+
+			mocksSetup: func(repo *mocks.TaskRepository) {},
+		},
+		{
+			name:    "internal db error",
+			query:   services.SearchTasksQuery{Text: "milk"},
+			wantErr: services.ErrTaskSearchFailed,
+
+			mocksSetup: func(repo *mocks.TaskRepository) {
+				repo.On("Search", mock.Anything, ownerID.String(), mock.Anything, services.DefaultTaskSearchLimit, false).
+					Once().
+					Return(nil, errors.New("failed to connect to db"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.TaskRepository)
+			tt.mocksSetup(repo)
+
+			events := new(mocks.TaskEventRepository)
+
+			service, err := services.NewTaskService(repo, events, inlineTransactor{}, clock.Real{}, nil)
+			require.NoError(t, err)
+
+			result, err := service.Search(context.Background(), ownerID.String(), tt.query)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, result, tt.wantLen)
+
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskService_PurgeTrash(t *testing.T) {
+	now := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+	retention := 30 * 24 * time.Hour
+
+	newTrashedTask := func(t *testing.T) *models.Task {
+		deletedAt := now.Add(-2 * retention)
+
+		task, err := models.NewTaskFromDB(models.TaskFromDBParams{
+			ID:          uuid.New().String(),
+			OwnerID:     uuid.New().String(),
+			Title:       "some title",
+			Description: "some description",
+			DeletedAt:   &deletedAt,
+			Version:     2,
+		})
+		require.NoError(t, err)
+
+		return task
+	}
+
+	tests := []struct {
+		name       string
+		wantPurged int64
+		wantErr    error
+
+		mocksSetup func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository)
+	}{
+		{
+			name:       "success",
+			wantPurged: 2,
+			wantErr:    nil,
+
+			mocksSetup: func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository) {
+				repo.On("DeleteTrashedBefore", mock.Anything, now.Add(-retention)).
+					Once().
+					Return([]*models.Task{newTrashedTask(t), newTrashedTask(t)}, nil)
+
+				expectEvent(events, models.TaskEventPurged)
+				expectEvent(events, models.TaskEventPurged)
+			},
+		},
+		{
+			name:       "nothing to purge",
+			wantPurged: 0,
+			wantErr:    nil,
+
+			mocksSetup: func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository) {
+				repo.On("DeleteTrashedBefore", mock.Anything, now.Add(-retention)).
+					Once().
+					Return([]*models.Task{}, nil)
+			},
+		},
+		{
+			name:    "internal db error",
+			wantErr: services.ErrTaskPurgeTrashFailed,
+
+			mocksSetup: func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository) {
+				repo.On("DeleteTrashedBefore", mock.Anything, now.Add(-retention)).
+					Once().
+					Return(nil, errors.New("failed to connect to db"))
+			},
+		},
+		{
+			name:    "recording history fails",
+			wantErr: services.ErrTaskPurgeTrashFailed,
+
+			mocksSetup: func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository) {
+				repo.On("DeleteTrashedBefore", mock.Anything, now.Add(-retention)).
+					Once().
+					Return([]*models.Task{newTrashedTask(t)}, nil)
+
+				events.On("Create", mock.Anything, mock.AnythingOfType("*models.TaskEvent")).
+					Once().
+					Return(errors.New("failed to connect to db"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.TaskRepository)
+			events := new(mocks.TaskEventRepository)
+			tt.mocksSetup(repo, events)
+
+			service, err := services.NewTaskService(repo, events, inlineTransactor{}, clock.NewFake(now), nil)
+			require.NoError(t, err)
+
+			purged, err := service.PurgeTrash(context.Background(), retention)
+
+			repo.AssertExpectations(t)
+			events.AssertExpectations(t)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantPurged, purged)
+		})
+	}
+}
+func TestTaskService_Archive(t *testing.T) {
+	validTaskID := uuid.New()
+	validOwnerID := uuid.New()
+	now := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+	completedAt := now.Add(-time.Hour)
+
+	tests := []struct {
+		name        string
+		taskID      string
+		ownerID     string
+		force       bool
+		version     *int64
+		completedAt *time.Time
+		archivedAt  *time.Time
+		deletedAt   *time.Time
+		wantErr     error
+		wantVersion int64
+		wantEvent   models.TaskEventType
+
+		mocksSetup func(repo *mocks.TaskRepository, taskToReturn *models.Task)
+	}{
+		{
+			name:        "success",
+			wantEvent:   models.TaskEventArchived,
+			taskID:      validTaskID.String(),
+			ownerID:     validOwnerID.String(),
+			completedAt: &completedAt,
+			wantErr:     nil,
+			wantVersion: 3,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+
+				repo.On("Update", mock.Anything, taskToReturn).
+					Once().
+					Return(nil)
+			},
+		},
+		{
+			name:        "success with force",
+			wantEvent:   models.TaskEventArchived,
+			taskID:      validTaskID.String(),
+			ownerID:     validOwnerID.String(),
+			force:       true,
+			wantErr:     nil,
+			wantVersion: 3,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+
+				repo.On("Update", mock.Anything, taskToReturn).
+					Once().
+					Return(nil)
+			},
+		},
+		{
+			name:        "already archived",
+			taskID:      validTaskID.String(),
+			ownerID:     validOwnerID.String(),
+			completedAt: &completedAt,
+			archivedAt:  &completedAt,
+			wantErr:     nil,
+			wantVersion: 2,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+			},
+		},
+		{
+			name:    "not completed",
+			taskID:  validTaskID.String(),
+			ownerID: validOwnerID.String(),
+			wantErr: models.ErrTaskNotCompleted,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+			},
+		},
+		{
+			name:        "task is in trash",
+			taskID:      validTaskID.String(),
+			ownerID:     validOwnerID.String(),
+			completedAt: &completedAt,
+			deletedAt:   &completedAt,
+			wantErr:     services.ErrTaskNotFound,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+			},
+		},
+		{
+			name:        "task not found",
+			taskID:      validTaskID.String(),
+			ownerID:     validOwnerID.String(),
+			completedAt: &completedAt,
+			wantErr:     services.ErrTaskNotFound,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(nil, services.ErrTaskRepoNotFound)
+			},
+		},
+		{
+			name:        "access denied",
+			taskID:      validTaskID.String(),
+			ownerID:     uuid.New().String(),
+			completedAt: &completedAt,
+			wantErr:     services.ErrTaskAccessDenied,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+			},
+		},
+		{
+			name:        "version mismatch",
+			taskID:      validTaskID.String(),
+			ownerID:     validOwnerID.String(),
+			version:     new(int64(3)),
+			completedAt: &completedAt,
+			wantErr:     services.ErrTaskConflict,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+			},
+		},
+		{
+			name:        "internal db error",
+			taskID:      validTaskID.String(),
+			ownerID:     validOwnerID.String(),
+			completedAt: &completedAt,
+			wantErr:     services.ErrTaskArchiveFailed,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+
+				repo.On("Update", mock.Anything, taskToReturn).
+					Once().
+					Return(errors.New("failed to connect to db"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskToReturn, err := models.NewTaskFromDB(models.TaskFromDBParams{
+				ID:          validTaskID.String(),
+				OwnerID:     validOwnerID.String(),
+				Title:       "some title",
+				Description: "some description",
+				IsCompleted: tt.completedAt != nil,
+				CompletedAt: tt.completedAt,
+				ArchivedAt:  tt.archivedAt,
+				DeletedAt:   tt.deletedAt,
+				Version:     2,
+			})
+			require.NoError(t, err)
+
+			repo := new(mocks.TaskRepository)
+			if tt.mocksSetup != nil {
+				tt.mocksSetup(repo, taskToReturn)
+			}
+
+			events := new(mocks.TaskEventRepository)
+			if tt.wantEvent != "" {
+				expectEvent(events, tt.wantEvent)
+			}
+
+			service, err := services.NewTaskService(repo, events, inlineTransactor{}, clock.NewFake(now), nil)
+			require.NoError(t, err)
+
+			ctx := context.Background()
+			version, err := service.Archive(ctx, tt.taskID, tt.ownerID, tt.force, tt.version)
+
+			repo.AssertExpectations(t)
+			events.AssertExpectations(t)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantVersion, version)
+			require.True(t, taskToReturn.IsArchived())
+		})
+	}
+}
+
+func TestTaskService_Unarchive(t *testing.T) {
+	validTaskID := uuid.New()
+	validOwnerID := uuid.New()
+	now := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+	archivedAt := now.Add(-time.Hour)
+
+	tests := []struct {
+		name        string
+		taskID      string
+		ownerID     string
+		version     *int64
+		archivedAt  *time.Time
+		wantErr     error
+		wantVersion int64
+		wantEvent   models.TaskEventType
+
+		mocksSetup func(repo *mocks.TaskRepository, taskToReturn *models.Task)
+	}{
+		{
+			name:        "success",
+			wantEvent:   models.TaskEventUnarchived,
+			taskID:      validTaskID.String(),
+			ownerID:     validOwnerID.String(),
+			archivedAt:  &archivedAt,
+			wantErr:     nil,
+			wantVersion: 3,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+
+				repo.On("Update", mock.Anything, taskToReturn).
+					Once().
+					Return(nil)
+			},
+		},
+		{
+			name:        "not archived",
+			taskID:      validTaskID.String(),
+			ownerID:     validOwnerID.String(),
+			wantErr:     nil,
+			wantVersion: 2,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+			},
+		},
+		{
+			name:       "access denied",
+			taskID:     validTaskID.String(),
+			ownerID:    uuid.New().String(),
+			archivedAt: &archivedAt,
+			wantErr:    services.ErrTaskAccessDenied,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+			},
+		},
+		{
+			name:       "concurrent modification",
+			taskID:     validTaskID.String(),
+			ownerID:    validOwnerID.String(),
+			archivedAt: &archivedAt,
+			wantErr:    services.ErrTaskConflict,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+
+				repo.On("Update", mock.Anything, taskToReturn).
+					Once().
+					Return(services.ErrTaskRepoConflict)
+			},
+		},
+		{
+			name:       "internal db error",
+			taskID:     validTaskID.String(),
+			ownerID:    validOwnerID.String(),
+			archivedAt: &archivedAt,
+			wantErr:    services.ErrTaskUnarchiveFailed,
+
+			mocksSetup: func(repo *mocks.TaskRepository, taskToReturn *models.Task) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(nil, errors.New("failed to connect to db"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskToReturn, err := models.NewTaskFromDB(models.TaskFromDBParams{
+				ID:          validTaskID.String(),
+				OwnerID:     validOwnerID.String(),
+				Title:       "some title",
+				Description: "some description",
+				ArchivedAt:  tt.archivedAt,
+				Version:     2,
+			})
+			require.NoError(t, err)
+
+			repo := new(mocks.TaskRepository)
+			if tt.mocksSetup != nil {
+				tt.mocksSetup(repo, taskToReturn)
+			}
+
+			events := new(mocks.TaskEventRepository)
+			if tt.wantEvent != "" {
+				expectEvent(events, tt.wantEvent)
+			}
+
+			service, err := services.NewTaskService(repo, events, inlineTransactor{}, clock.NewFake(now), nil)
+			require.NoError(t, err)
+
+			ctx := context.Background()
+			version, err := service.Unarchive(ctx, tt.taskID, tt.ownerID, tt.version)
+
+			repo.AssertExpectations(t)
+			events.AssertExpectations(t)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantVersion, version)
+			require.False(t, taskToReturn.IsArchived())
+		})
+	}
+}
+
+func TestTaskService_ArchiveCompleted(t *testing.T) {
+	ownerID := uuid.New()
+	now := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+	olderThan := 7 * 24 * time.Hour
+	completedAt := now.Add(-30 * 24 * time.Hour)
+
+	archived := make([]*models.Task, 3)
+	for i := range archived {
+		task, err := models.NewTaskFromDB(models.TaskFromDBParams{
+			ID:          uuid.NewString(),
+			OwnerID:     ownerID.String(),
+			Title:       fmt.Sprintf("task %d", i),
+			IsCompleted: true,
+			CompletedAt: &completedAt,
+			ArchivedAt:  &now,
+			Version:     2,
+		})
+		require.NoError(t, err)
+
+		archived[i] = task
+	}
+
+	tests := []struct {
+		name         string
+		wantArchived int64
+		wantErr      error
+
+		mocksSetup func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository)
+	}{
+		{
+			name:         "success",
+			wantArchived: 3,
+			wantErr:      nil,
+
+			mocksSetup: func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository) {
+				repo.On("ArchiveCompletedBefore", mock.Anything, ownerID.String(), now.Add(-olderThan), now).
+					Once().
+					Return(archived, nil)
+
+				events.On("Create", mock.Anything, mock.MatchedBy(func(event *models.TaskEvent) bool {
+					changes := event.Changes()
+
+					return event.Type() == models.TaskEventArchived &&
+						len(changes) == 1 && changes[0].Field == "archived_at"
+				})).
+					Times(3).
+					Return(nil)
+			},
+		},
+		{
+			name:         "nothing to archive",
+			wantArchived: 0,
+			wantErr:      nil,
+
+			mocksSetup: func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository) {
+				repo.On("ArchiveCompletedBefore", mock.Anything, ownerID.String(), now.Add(-olderThan), now).
+					Once().
+					Return([]*models.Task{}, nil)
+			},
+		},
+		{
+			name:    "internal db error",
+			wantErr: services.ErrTaskArchiveCompletedFailed,
+
+			mocksSetup: func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository) {
+				repo.On("ArchiveCompletedBefore", mock.Anything, ownerID.String(), now.Add(-olderThan), now).
+					Once().
+					Return(nil, errors.New("failed to connect to db"))
+			},
+		},
+		{
+			name:    "event is not recorded",
+			wantErr: services.ErrTaskArchiveCompletedFailed,
+
+			mocksSetup: func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository) {
+				repo.On("ArchiveCompletedBefore", mock.Anything, ownerID.String(), now.Add(-olderThan), now).
+					Once().
+					Return(archived, nil)
+
+				events.On("Create", mock.Anything, mock.Anything).
+					Once().
+					Return(errors.New("failed to connect to db"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.TaskRepository)
+			events := new(mocks.TaskEventRepository)
+			tt.mocksSetup(repo, events)
+
+			service, err := services.NewTaskService(repo, events, inlineTransactor{}, clock.NewFake(now), nil)
+			require.NoError(t, err)
+
+			archived, err := service.ArchiveCompleted(context.Background(), ownerID.String(), olderThan)
+
+			repo.AssertExpectations(t)
+			events.AssertExpectations(t)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantArchived, archived)
+		})
+	}
+}
+
+func TestTaskService_History(t *testing.T) {
+	validTaskID := uuid.New()
+	validOwnerID := uuid.New()
+	deletedAt := time.Now().Add(-time.Hour)
+
+	taskToReturn, err := models.NewTaskFromDB(models.TaskFromDBParams{
+		ID:          validTaskID.String(),
+		OwnerID:     validOwnerID.String(),
+		Title:       "some title",
+		Description: "some description",
+		DeletedAt:   &deletedAt,
+		Version:     2,
+	})
+	require.NoError(t, err)
+
+	eventsToReturn := []*models.TaskEvent{
+		models.NewTaskEvent(taskToReturn, models.TaskEventCreated, validOwnerID, nil, clock.Real{}),
+	}
+
+	tests := []struct {
+		name    string
+		ownerID string
+		wantErr error
+
+		mocksSetup func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository)
+	}{
+		{
+			name:    "success with task in trash",
+			ownerID: validOwnerID.String(),
+			wantErr: nil,
+
+			mocksSetup: func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+
+				events.On("FindByTask", mock.Anything, validTaskID.String()).
+					Once().
+					Return(eventsToReturn, nil)
+			},
+		},
+		{
+			name:    "task not found",
+			ownerID: validOwnerID.String(),
+			wantErr: services.ErrTaskNotFound,
+
+			mocksSetup: func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(nil, services.ErrTaskRepoNotFound)
+			},
+		},
+		{
+			name:    "access denied",
+			ownerID: uuid.New().String(),
+			wantErr: services.ErrTaskAccessDenied,
+
+			mocksSetup: func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+			},
+		},
+		{
+			name:    "internal db error",
+			ownerID: validOwnerID.String(),
+			wantErr: services.ErrTaskHistoryFailed,
+
+			mocksSetup: func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository) {
+				repo.On("FindByID", mock.Anything, validTaskID.String()).
+					Once().
+					Return(taskToReturn, nil)
+
+				events.On("FindByTask", mock.Anything, validTaskID.String()).
+					Once().
+					Return(nil, errors.New("failed to connect to db"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.TaskRepository)
+			events := new(mocks.TaskEventRepository)
+			tt.mocksSetup(repo, events)
+
+			service, err := services.NewTaskService(repo, events, inlineTransactor{}, clock.Real{}, nil)
+			require.NoError(t, err)
+
+			history, err := service.History(context.Background(), validTaskID.String(), tt.ownerID)
+
+			repo.AssertExpectations(t)
+			events.AssertExpectations(t)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, history)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, eventsToReturn, history)
+		})
+	}
+}
+
+func TestTaskService_Histories(t *testing.T) {
+	ownerID := uuid.New()
+
+	newTask := func(owner uuid.UUID) *models.Task {
+		task, err := models.NewTask("some title", "some description", owner, clock.Real{})
+		require.NoError(t, err)
+
+		return task
+	}
+
+	first := newTask(ownerID)
+	second := newTask(ownerID)
+	foreign := newTask(uuid.New())
+
+	firstCreated := models.NewTaskEvent(first, models.TaskEventCreated, ownerID, nil, clock.Real{})
+	firstCompleted := models.NewTaskEvent(first, models.TaskEventCompleted, ownerID, nil, clock.Real{})
+
+	ids := []string{first.ID().String(), second.ID().String(), foreign.ID().String(), "not-a-uuid"}
+	validIDs := []string{first.ID().String(), second.ID().String(), foreign.ID().String()}
+	ownedIDs := []string{first.ID().String(), second.ID().String()}
+
+	tests := []struct {
+		name    string
+		ids     []string
+		wantErr error
+		want    map[string][]*models.TaskEvent
+
+		mocksSetup func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository)
+	}{
+		{
+			name: "histories of owned tasks",
+			ids:  ids,
+			want: map[string][]*models.TaskEvent{
+				first.ID().String():  {firstCreated, firstCompleted},
+				second.ID().String(): {},
+			},
+
+			mocksSetup: func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository) {
+				repo.On("FindByIDs", mock.Anything, validIDs).
+					Once().
+					Return([]*models.Task{first, second, foreign}, nil)
+
+				events.On("FindByTasks", mock.Anything, ownedIDs).
+					Once().
+					Return([]*models.TaskEvent{firstCreated, firstCompleted}, nil)
+			},
+		},
+		{
+			name: "no valid ids",
+			ids:  []string{"not-a-uuid"},
+			want: map[string][]*models.TaskEvent{},
+
+			mocksSetup: func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository) {},
+		},
+		{
+			name:    "tasks lookup failed",
+			ids:     ids,
+			wantErr: services.ErrTaskHistoryFailed,
+
+			mocksSetup: func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository) {
+				repo.On("FindByIDs", mock.Anything, validIDs).
+					Once().
+					Return(nil, errors.New("failed to connect to db"))
+			},
+		},
+		{
+			name:    "events lookup failed",
+			ids:     ids,
+			wantErr: services.ErrTaskHistoryFailed,
+
+			mocksSetup: func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository) {
+				repo.On("FindByIDs", mock.Anything, validIDs).
+					Once().
+					Return([]*models.Task{first, second}, nil)
+
+				events.On("FindByTasks", mock.Anything, ownedIDs).
+					Once().
+					Return(nil, errors.New("failed to connect to db"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.TaskRepository)
+			events := new(mocks.TaskEventRepository)
+			tt.mocksSetup(repo, events)
+
+			service, err := services.NewTaskService(repo, events, inlineTransactor{}, clock.Real{}, nil)
+			require.NoError(t, err)
+
+			histories, err := service.Histories(context.Background(), tt.ids, ownerID.String())
+
+			repo.AssertExpectations(t)
+			events.AssertExpectations(t)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, histories)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, histories)
+		})
+	}
+}
+
+func TestTaskService_Revert(t *testing.T) {
+	validOwnerID := uuid.New()
+	now := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+
+	// newTask returns a task that was renamed after its creation
+	// together with the event of its creation.
+	newTask := func(t *testing.T, deadline *time.Time) (*models.Task, *models.TaskEvent) {
+		clk := clock.NewFake(now.Add(-2 * time.Hour))
+
+		var (
+			task *models.Task
+			err  error
+		)
+		if deadline != nil {
+			task, err = models.NewTaskWithDeadline("old title", "description", validOwnerID, *deadline, clk)
+		} else {
+			task, err = models.NewTask("old title", "description", validOwnerID, clk)
+		}
+		require.NoError(t, err)
+
+		created := models.NewTaskEvent(task, models.TaskEventCreated, validOwnerID, nil, clk)
+
+		clk.Advance(time.Hour)
+		require.NoError(t, task.ChangeTitle("new title", clk))
+		if deadline != nil {
+			task.RemoveDeadline(clk)
+		}
+
+		return task, created
+	}
+
+	tests := []struct {
+		name      string
+		ownerID   func(task *models.Task) string
+		version   *int64
+		deadline  *time.Time
+		wantErr   error
+		wantTitle string
+
+		mocksSetup func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository, task *models.Task, event *models.TaskEvent)
+	}{
+		{
+			name:      "success",
+			wantErr:   nil,
+			wantTitle: "old title",
+
+			mocksSetup: func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository, task *models.Task, event *models.TaskEvent) {
+				repo.On("FindByID", mock.Anything, task.ID().String()).
+					Once().
+					Return(task, nil)
+
+				events.On("FindByID", mock.Anything, event.ID().String()).
+					Once().
+					Return(event, nil)
+
+				repo.On("Update", mock.Anything, task).
+					Once().
+					Return(nil)
+
+				expectEvent(events, models.TaskEventReverted)
+			},
+		},
+		{
+			name:      "deadline has passed",
+			deadline:  new(now.Add(-time.Hour / 2)),
+			wantErr:   vo.ErrDeadlineBeforeNow,
+			wantTitle: "new title",
+
+			mocksSetup: func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository, task *models.Task, event *models.TaskEvent) {
+				repo.On("FindByID", mock.Anything, task.ID().String()).
+					Once().
+					Return(task, nil)
+
+				events.On("FindByID", mock.Anything, event.ID().String()).
+					Once().
+					Return(event, nil)
+			},
+		},
+		{
+			name:      "event not found",
+			wantErr:   services.ErrTaskEventNotFound,
+			wantTitle: "new title",
+
+			mocksSetup: func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository, task *models.Task, event *models.TaskEvent) {
+				repo.On("FindByID", mock.Anything, task.ID().String()).
+					Once().
+					Return(task, nil)
+
+				events.On("FindByID", mock.Anything, event.ID().String()).
+					Once().
+					Return(nil, services.ErrTaskEventRepoNotFound)
+			},
+		},
+		{
+			name:      "event of another task",
+			wantErr:   services.ErrTaskEventNotFound,
+			wantTitle: "new title",
+
+			mocksSetup: func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository, task *models.Task, event *models.TaskEvent) {
+				repo.On("FindByID", mock.Anything, task.ID().String()).
+					Once().
+					Return(task, nil)
+
+				otherTask, err := models.NewTask("other", "", validOwnerID, clock.Real{})
+				require.NoError(t, err)
+
+				events.On("FindByID", mock.Anything, event.ID().String()).
+					Once().
+					Return(models.NewTaskEvent(otherTask, models.TaskEventCreated, validOwnerID, nil, clock.Real{}), nil)
+			},
+		},
+		{
+			name:      "task not found",
+			wantErr:   services.ErrTaskNotFound,
+			wantTitle: "new title",
+
+			mocksSetup: func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository, task *models.Task, event *models.TaskEvent) {
+				repo.On("FindByID", mock.Anything, task.ID().String()).
+					Once().
+					Return(nil, services.ErrTaskRepoNotFound)
+			},
+		},
+		{
+			name:      "access denied",
+			ownerID:   func(task *models.Task) string { return uuid.New().String() },
+			wantErr:   services.ErrTaskAccessDenied,
+			wantTitle: "new title",
+
+			mocksSetup: func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository, task *models.Task, event *models.TaskEvent) {
+				repo.On("FindByID", mock.Anything, task.ID().String()).
+					Once().
+					Return(task, nil)
+			},
+		},
+		{
+			name:      "version mismatch",
+			version:   new(int64(5)),
+			wantErr:   services.ErrTaskConflict,
+			wantTitle: "new title",
+
+			mocksSetup: func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository, task *models.Task, event *models.TaskEvent) {
+				repo.On("FindByID", mock.Anything, task.ID().String()).
+					Once().
+					Return(task, nil)
+			},
+		},
+		{
+			name:      "recording history fails",
+			wantErr:   services.ErrTaskRevertFailed,
+			wantTitle: "old title",
+
+			mocksSetup: func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository, task *models.Task, event *models.TaskEvent) {
+				repo.On("FindByID", mock.Anything, task.ID().String()).
+					Once().
+					Return(task, nil)
+
+				events.On("FindByID", mock.Anything, event.ID().String()).
+					Once().
+					Return(event, nil)
+
+				repo.On("Update", mock.Anything, task).
+					Once().
+					Return(nil)
+
+				events.On("Create", mock.Anything, mock.AnythingOfType("*models.TaskEvent")).
+					Once().
+					Return(errors.New("failed to connect to db"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task, event := newTask(t, tt.deadline)
+
+			repo := new(mocks.TaskRepository)
+			events := new(mocks.TaskEventRepository)
+			tt.mocksSetup(repo, events, task, event)
+
+			service, err := services.NewTaskService(repo, events, inlineTransactor{}, clock.NewFake(now), nil)
+			require.NoError(t, err)
+
+			ownerID := validOwnerID.String()
+			if tt.ownerID != nil {
+				ownerID = tt.ownerID(task)
+			}
+
+			version, err := service.Revert(context.Background(), task.ID().String(), ownerID, event.ID().String(), tt.version)
+
+			repo.AssertExpectations(t)
+			events.AssertExpectations(t)
+
+			require.Equal(t, tt.wantTitle, task.Title().String())
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, int64(2), version)
+			require.Equal(t, now, task.UpdatedAt())
+		})
+	}
+}
+
+func TestTaskService_Batch(t *testing.T) {
+	ownerID := uuid.New()
+	missingID := uuid.New()
+	now := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		atomic     bool
+		transactor services.Transactor
+		ops        func(open, completed *models.Task) []services.BatchOperation
+
+		wantResults []error
+		wantErr     []error
+
+		mocksSetup func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository, open, completed *models.Task)
+	}{
+		{
+			name:       "best effort applies every operation independently",
+			atomic:     false,
+			transactor: inlineTransactor{},
+			ops: func(open, completed *models.Task) []services.BatchOperation {
+				return []services.BatchOperation{
+					{Type: services.BatchOperationComplete, TaskID: open.ID().String()},
+					{Type: services.BatchOperationComplete, TaskID: missingID.String()},
+					{Type: services.BatchOperationReopen, TaskID: completed.ID().String()},
+					{Type: "rename", TaskID: open.ID().String()},
+				}
+			},
+			wantResults: []error{nil, services.ErrTaskNotFound, nil, services.ErrTaskBatchOperationInvalid},
+			mocksSetup: func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository, open, completed *models.Task) {
+				repo.On("FindByID", mock.Anything, open.ID().String()).Once().Return(open, nil)
+				repo.On("FindByID", mock.Anything, missingID.String()).Once().Return(nil, services.ErrTaskRepoNotFound)
+				repo.On("FindByID", mock.Anything, completed.ID().String()).Once().Return(completed, nil)
+				repo.On("Update", mock.Anything, mock.AnythingOfType("*models.Task")).Twice().Return(nil)
+
+				expectEvent(events, models.TaskEventCompleted)
+				expectEvent(events, models.TaskEventReopened)
+			},
+		},
+		{
+			name:       "atomic success",
+			atomic:     true,
+			transactor: inlineTransactor{},
+			ops: func(open, completed *models.Task) []services.BatchOperation {
+				return []services.BatchOperation{
+					{
+						Type:   services.BatchOperationUpdate,
+						TaskID: open.ID().String(),
+						Update: services.UpdateTaskCommand{Title: new("new title")},
+					},
+					{Type: services.BatchOperationDelete, TaskID: completed.ID().String(), ExpectedVersion: new(int64(1))},
+				}
+			},
+			wantResults: []error{nil, nil},
+			mocksSetup: func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository, open, completed *models.Task) {
+				repo.On("FindByID", mock.Anything, open.ID().String()).Once().Return(open, nil)
+				repo.On("FindByID", mock.Anything, completed.ID().String()).Once().Return(completed, nil)
+				repo.On("Update", mock.Anything, mock.AnythingOfType("*models.Task")).Twice().Return(nil)
+
+				expectEvent(events, models.TaskEventUpdated)
+				expectEvent(events, models.TaskEventDeleted)
+			},
+		},
+		{
+			name:       "atomic stops at the first failed operation",
+			atomic:     true,
+			transactor: inlineTransactor{},
+			ops: func(open, completed *models.Task) []services.BatchOperation {
+				return []services.BatchOperation{
+					{Type: services.BatchOperationComplete, TaskID: open.ID().String()},
+					{Type: services.BatchOperationReopen, TaskID: completed.ID().String(), ExpectedVersion: new(int64(7))},
+					{Type: services.BatchOperationRemoveDeadline, TaskID: open.ID().String()},
+				}
+			},
+			wantResults: []error{services.ErrTaskBatchAborted, services.ErrTaskConflict, services.ErrTaskBatchAborted},
+			wantErr:     []error{services.ErrTaskBatchAborted, services.ErrTaskConflict},
+			mocksSetup: func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository, open, completed *models.Task) {
+				repo.On("FindByID", mock.Anything, open.ID().String()).Once().Return(open, nil)
+				repo.On("FindByID", mock.Anything, completed.ID().String()).Once().Return(completed, nil)
+				repo.On("Update", mock.Anything, mock.AnythingOfType("*models.Task")).Once().Return(nil)
+
+				expectEvent(events, models.TaskEventCompleted)
+			},
+		},
+		{
+			name:       "atomic transaction fails",
+			atomic:     true,
+			transactor: failingTransactor{},
+			ops: func(open, completed *models.Task) []services.BatchOperation {
+				return []services.BatchOperation{
+					{Type: services.BatchOperationComplete, TaskID: open.ID().String()},
+				}
+			},
+			wantResults: []error{services.ErrTaskBatchAborted},
+			wantErr:     []error{services.ErrTaskBatchFailed},
+			mocksSetup:  func(repo *mocks.TaskRepository, events *mocks.TaskEventRepository, open, completed *models.Task) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clk := clock.NewFake(now)
+
+			open, err := models.NewTaskWithDeadline("open", "", ownerID, now.Add(time.Hour), clk)
+			require.NoError(t, err)
+
+			completed, err := models.NewTask("completed", "", ownerID, clk)
+			require.NoError(t, err)
+			completed.Complete(clk)
+
+			repo := new(mocks.TaskRepository)
+			events := new(mocks.TaskEventRepository)
+			tt.mocksSetup(repo, events, open, completed)
+
+			service, err := services.NewTaskService(repo, events, tt.transactor, clk, nil)
+			require.NoError(t, err)
+
+			results, err := service.Batch(context.Background(), ownerID.String(), tt.ops(open, completed), tt.atomic)
+
+			if len(tt.wantErr) == 0 {
+				require.NoError(t, err)
+			}
+			for _, wantErr := range tt.wantErr {
+				require.ErrorIs(t, err, wantErr)
+			}
+
+			require.Len(t, results, len(tt.wantResults))
+			for i, wantResult := range tt.wantResults {
+				if wantResult == nil {
+					require.NoError(t, results[i], "operation %d", i)
+				} else {
+					require.ErrorIs(t, results[i], wantResult, "operation %d", i)
+				}
+			}
+
+			repo.AssertExpectations(t)
+			events.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskService_ChangesSince(t *testing.T) {
+	ownerID := uuid.New().String()
+
+	tests := []struct {
+		name  string
+		since int64
+		limit int
+
+		want    *services.TaskSyncPage
+		wantErr error
+
+		mocksSetup func(repo *mocks.TaskRepository)
+	}{
+		{
+			name:  "groups changes by their kind",
+			since: 10,
+			limit: 3,
+			want: &services.TaskSyncPage{
+				Created: []string{"a"},
+				Updated: []string{"b"},
+				Deleted: []string{"c"},
+				Seq:     14,
+			},
+			mocksSetup: func(repo *mocks.TaskRepository) {
+				repo.On("FindChangedSince", mock.Anything, ownerID, int64(10), 4).Once().Return([]services.TaskSyncChange{
+					{TaskID: "a", Seq: 11, Kind: services.TaskSyncCreated},
+					{TaskID: "b", Seq: 12, Kind: services.TaskSyncUpdated},
+					{TaskID: "c", Seq: 14, Kind: services.TaskSyncDeleted},
+				}, nil)
+			},
+		},
+		{
+			name:  "reports more changes beyond the limit",
+			since: 0,
+			limit: 1,
+			want: &services.TaskSyncPage{
+				Created: []string{"a"},
+				Updated: []string{},
+				Deleted: []string{},
+				Seq:     1,
+				HasMore: true,
+			},
+			mocksSetup: func(repo *mocks.TaskRepository) {
+				repo.On("FindChangedSince", mock.Anything, ownerID, int64(0), 2).Once().Return([]services.TaskSyncChange{
+					{TaskID: "a", Seq: 1, Kind: services.TaskSyncCreated},
+					{TaskID: "b", Seq: 2, Kind: services.TaskSyncCreated},
+				}, nil)
+			},
+		},
+		{
+			name:  "keeps the sequence number if nothing has changed",
+			since: 7,
+			want: &services.TaskSyncPage{
+				Created: []string{},
+				Updated: []string{},
+				Deleted: []string{},
+				Seq:     7,
+			},
+			mocksSetup: func(repo *mocks.TaskRepository) {
+				repo.On("FindChangedSince", mock.Anything, ownerID, int64(7), services.DefaultTaskSyncLimit+1).
+					Once().
+					Return([]services.TaskSyncChange{}, nil)
+			},
+		},
+		{
+			name:       "negative sequence number",
+			since:      -1,
+			wantErr:    services.ErrTaskSyncSeqInvalid,
+			mocksSetup: func(repo *mocks.TaskRepository) {},
+		},
+		{
+			name:       "limit is too large",
+			limit:      services.MaxTaskSyncLimit + 1,
+			wantErr:    services.ErrTaskSyncLimitInvalid,
+			mocksSetup: func(repo *mocks.TaskRepository) {},
+		},
+		{
+			name:    "repository fails",
+			wantErr: services.ErrTaskSyncFailed,
+			mocksSetup: func(repo *mocks.TaskRepository) {
+				repo.On("FindChangedSince", mock.Anything, ownerID, int64(0), services.DefaultTaskSyncLimit+1).
+					Once().
+					Return(nil, errors.New("db is down"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.TaskRepository)
+			events := new(mocks.TaskEventRepository)
+			tt.mocksSetup(repo)
+
+			service, err := services.NewTaskService(repo, events, inlineTransactor{}, clock.Real{}, nil)
+			require.NoError(t, err)
+
+			page, err := service.ChangesSince(context.Background(), ownerID, tt.since, tt.limit)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, page)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.want, page)
+			}
+
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskService_Push(t *testing.T) {
+	ownerID := uuid.New()
+	now := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(now)
+
+	open, err := models.NewTask("open", "", ownerID, clk)
+	require.NoError(t, err)
+
+	stale, err := models.NewTask("stale", "", ownerID, clk)
+	require.NoError(t, err)
+
+	foreign, err := models.NewTask("foreign", "", uuid.New(), clk)
+	require.NoError(t, err)
+
+	repo := new(mocks.TaskRepository)
+	events := new(mocks.TaskEventRepository)
+
+	var created *models.Task
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*models.Task")).
+		Once().
+		Run(func(args mock.Arguments) { created = args.Get(1).(*models.Task) }).
+		Return(nil)
+	repo.On("FindByID", mock.Anything, mock.MatchedBy(func(id string) bool {
+		return created != nil && id == created.ID().String()
+	})).Return(func(ctx context.Context, id string) (*models.Task, error) { return created, nil })
+	repo.On("FindByID", mock.Anything, open.ID().String()).Return(open, nil)
+	repo.On("FindByID", mock.Anything, stale.ID().String()).Return(stale, nil)
+	repo.On("FindByID", mock.Anything, foreign.ID().String()).Return(foreign, nil)
+	repo.On("Update", mock.Anything, open).Once().Return(nil)
+
+	expectEvent(events, models.TaskEventCreated)
+	expectEvent(events, models.TaskEventCompleted)
+
+	service, err := services.NewTaskService(repo, events, inlineTransactor{}, clk, nil)
+	require.NoError(t, err)
+
+	results := service.Push(context.Background(), ownerID.String(), []services.SyncOperation{
+		{
+			BatchOperation: services.BatchOperation{Type: services.SyncOperationCreate},
+			Create:         services.CreateTaskCommand{Title: "offline"},
+		},
+		{BatchOperation: services.BatchOperation{Type: services.BatchOperationComplete, TaskID: open.ID().String()}},
+		{BatchOperation: services.BatchOperation{
+			Type:            services.BatchOperationComplete,
+			TaskID:          stale.ID().String(),
+			ExpectedVersion: new(int64(2)),
+		}},
+		{BatchOperation: services.BatchOperation{Type: services.BatchOperationReopen, TaskID: foreign.ID().String()}},
+		{BatchOperation: services.BatchOperation{Type: "rename", TaskID: open.ID().String()}},
+	})
+
+	require.Len(t, results, 5)
+
+	require.NoError(t, results[0].Err)
+	require.Equal(t, created.ID().String(), results[0].TaskID)
+	require.Equal(t, ownerID, created.OwnerID())
+	require.Same(t, created, results[0].Task)
+
+	require.NoError(t, results[1].Err)
+	require.Same(t, open, results[1].Task)
+	require.True(t, open.IsCompleted())
+
+	// the conflicting change gets the current task to resolve the conflict with
+	require.ErrorIs(t, results[2].Err, services.ErrTaskConflict)
+	require.Same(t, stale, results[2].Task)
+
+	require.ErrorIs(t, results[3].Err, services.ErrTaskAccessDenied)
+	require.Nil(t, results[3].Task)
+
+	require.ErrorIs(t, results[4].Err, services.ErrTaskBatchOperationInvalid)
+	require.Nil(t, results[4].Task)
+
+	repo.AssertExpectations(t)
+	events.AssertExpectations(t)
+}
+
+// committingTransactor runs functions in place and tracks whether the transaction is committed.
+type committingTransactor struct {
+	committed bool
+}
+
+func (tx *committingTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		return err
+	}
+
+	tx.committed = true
+
+	return nil
+}
+
+// expectChange sets up publisher to expect a single change of the given type and version
+// that is published after tx is committed.
+func expectChange(
+	publisher *mocks.TaskChangePublisher,
+	tx *committingTransactor,
+	ownerID uuid.UUID,
+	eventType models.TaskEventType,
+	version int64,
+) {
+	publisher.On("Publish", mock.Anything, mock.MatchedBy(func(change services.TaskChange) bool {
+		return tx.committed &&
+			change.OwnerID == ownerID.String() &&
+			change.Version == version &&
+			change.Event.Type() == eventType
+	})).
+		Once().
+		Return(nil)
+}
+
+func TestTaskService_PublishChanges(t *testing.T) {
+	ownerID := uuid.New()
+	now := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+
+	t.Run("create", func(t *testing.T) {
+		clk := clock.NewFake(now)
+		tx := &committingTransactor{}
+
+		repo := new(mocks.TaskRepository)
+		repo.On("Create", mock.Anything, mock.AnythingOfType("*models.Task")).Once().Return(nil)
+
+		events := new(mocks.TaskEventRepository)
+		expectEvent(events, models.TaskEventCreated)
+
+		publisher := new(mocks.TaskChangePublisher)
+		expectChange(publisher, tx, ownerID, models.TaskEventCreated, 1)
+
+		service, err := services.NewTaskService(repo, events, tx, clk, publisher)
+		require.NoError(t, err)
+
+		_, err = service.Create(context.Background(), services.CreateTaskCommand{Title: "title", OwnerID: ownerID})
+		require.NoError(t, err)
+
+		publisher.AssertExpectations(t)
+	})
+	t.Run("update publishes the new version", func(t *testing.T) {
+		clk := clock.NewFake(now)
+		tx := &committingTransactor{}
+
+		task, err := models.NewTask("title", "", ownerID, clk)
+		require.NoError(t, err)
+
+		repo := new(mocks.TaskRepository)
+		repo.On("FindByID", mock.Anything, task.ID().String()).Once().Return(task, nil)
+		repo.On("Update", mock.Anything, task).Once().Return(nil)
+
+		events := new(mocks.TaskEventRepository)
+		expectEvent(events, models.TaskEventCompleted)
+
+		publisher := new(mocks.TaskChangePublisher)
+		expectChange(publisher, tx, ownerID, models.TaskEventCompleted, task.Version()+1)
+
+		service, err := services.NewTaskService(repo, events, tx, clk, publisher)
+		require.NoError(t, err)
+
+		_, err = service.Complete(context.Background(), task.ID().String(), ownerID.String(), nil)
+		require.NoError(t, err)
+
+		publisher.AssertExpectations(t)
+	})
+	t.Run("failed change is not published", func(t *testing.T) {
+		clk := clock.NewFake(now)
+
+		task, err := models.NewTask("title", "", ownerID, clk)
+		require.NoError(t, err)
+
+		repo := new(mocks.TaskRepository)
+		repo.On("FindByID", mock.Anything, task.ID().String()).Once().Return(task, nil)
+		repo.On("Update", mock.Anything, task).Once().Return(services.ErrTaskRepoConflict)
+
+		publisher := new(mocks.TaskChangePublisher)
+
+		service, err := services.NewTaskService(repo, new(mocks.TaskEventRepository), inlineTransactor{}, clk, publisher)
+		require.NoError(t, err)
+
+		_, err = service.Complete(context.Background(), task.ID().String(), ownerID.String(), nil)
+		require.Error(t, err)
+
+		publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+	})
+	t.Run("publish failure does not fail the change", func(t *testing.T) {
+		clk := clock.NewFake(now)
+
+		repo := new(mocks.TaskRepository)
+		repo.On("Create", mock.Anything, mock.AnythingOfType("*models.Task")).Once().Return(nil)
+
+		events := new(mocks.TaskEventRepository)
+		expectEvent(events, models.TaskEventCreated)
+
+		publisher := new(mocks.TaskChangePublisher)
+		publisher.On("Publish", mock.Anything, mock.Anything).Once().Return(errors.New("connection refused"))
+
+		service, err := services.NewTaskService(repo, events, inlineTransactor{}, clk, publisher)
+		require.NoError(t, err)
+
+		_, err = service.Create(context.Background(), services.CreateTaskCommand{Title: "title", OwnerID: ownerID})
+		require.NoError(t, err)
+
+		publisher.AssertExpectations(t)
+	})
+	t.Run("atomic batch publishes after the whole batch is committed", func(t *testing.T) {
+		clk := clock.NewFake(now)
+		tx := &committingTransactor{}
+
+		first, err := models.NewTask("first", "", ownerID, clk)
+		require.NoError(t, err)
+		second, err := models.NewTask("second", "", ownerID, clk)
+		require.NoError(t, err)
+
+		repo := new(mocks.TaskRepository)
+		repo.On("FindByID", mock.Anything, first.ID().String()).Once().Return(first, nil)
+		repo.On("FindByID", mock.Anything, second.ID().String()).Once().Return(second, nil)
+		repo.On("Update", mock.Anything, mock.AnythingOfType("*models.Task")).Twice().Return(nil)
+
+		events := new(mocks.TaskEventRepository)
+		expectEvent(events, models.TaskEventCompleted)
+		expectEvent(events, models.TaskEventDeleted)
+
+		publisher := new(mocks.TaskChangePublisher)
+		expectChange(publisher, tx, ownerID, models.TaskEventCompleted, 2)
+		expectChange(publisher, tx, ownerID, models.TaskEventDeleted, 2)
+
+		service, err := services.NewTaskService(repo, events, tx, clk, publisher)
+		require.NoError(t, err)
+
+		_, err = service.Batch(context.Background(), ownerID.String(), []services.BatchOperation{
+			{Type: services.BatchOperationComplete, TaskID: first.ID().String()},
+			{Type: services.BatchOperationDelete, TaskID: second.ID().String()},
+		}, true)
+		require.NoError(t, err)
+
+		publisher.AssertExpectations(t)
+	})
+	t.Run("permanent deletion", func(t *testing.T) {
+		clk := clock.NewFake(now)
+		tx := &committingTransactor{}
+
+		task, err := models.NewTask("title", "", ownerID, clk)
+		require.NoError(t, err)
+
+		repo := new(mocks.TaskRepository)
+		repo.On("FindByID", mock.Anything, task.ID().String()).Once().Return(task, nil)
+		repo.On("Delete", mock.Anything, task.ID().String(), task.Version()).Once().Return(nil)
+
+		events := new(mocks.TaskEventRepository)
+		expectEvent(events, models.TaskEventPurged)
+
+		publisher := new(mocks.TaskChangePublisher)
+		expectChange(publisher, tx, ownerID, models.TaskEventPurged, task.Version())
+
+		service, err := services.NewTaskService(repo, events, tx, clk, publisher)
+		require.NoError(t, err)
+
+		err = service.DeletePermanently(context.Background(), task.ID().String(), ownerID.String(), nil)
+		require.NoError(t, err)
+
+		publisher.AssertExpectations(t)
+	})
+	t.Run("archiving of the completed tasks", func(t *testing.T) {
+		clk := clock.NewFake(now)
+		tx := &committingTransactor{}
+
+		task, err := models.NewTask("title", "", ownerID, clk)
+		require.NoError(t, err)
+		task.Complete(clk)
+		require.NoError(t, task.Archive(false, clk))
+
+		repo := new(mocks.TaskRepository)
+		repo.On("ArchiveCompletedBefore", mock.Anything, ownerID.String(), now.Add(-time.Hour), now).
+			Once().
+			Return([]*models.Task{task}, nil)
+
+		events := new(mocks.TaskEventRepository)
+		expectEvent(events, models.TaskEventArchived)
+
+		publisher := new(mocks.TaskChangePublisher)
+		expectChange(publisher, tx, ownerID, models.TaskEventArchived, task.Version())
+
+		service, err := services.NewTaskService(repo, events, tx, clk, publisher)
+		require.NoError(t, err)
+
+		_, err = service.ArchiveCompleted(context.Background(), ownerID.String(), time.Hour)
+		require.NoError(t, err)
+
+		publisher.AssertExpectations(t)
+	})
+	t.Run("purge of the trash", func(t *testing.T) {
+		clk := clock.NewFake(now)
+		tx := &committingTransactor{}
+		otherOwnerID := uuid.New()
+
+		first, err := models.NewTask("first", "", ownerID, clk)
+		require.NoError(t, err)
+		second, err := models.NewTask("second", "", otherOwnerID, clk)
+		require.NoError(t, err)
+
+		repo := new(mocks.TaskRepository)
+		repo.On("DeleteTrashedBefore", mock.Anything, now.Add(-time.Hour)).
+			Once().
+			Return([]*models.Task{first, second}, nil)
+
+		events := new(mocks.TaskEventRepository)
+		expectEvent(events, models.TaskEventPurged)
+		expectEvent(events, models.TaskEventPurged)
+
+		publisher := new(mocks.TaskChangePublisher)
+		expectChange(publisher, tx, ownerID, models.TaskEventPurged, first.Version())
+		expectChange(publisher, tx, otherOwnerID, models.TaskEventPurged, second.Version())
+
+		service, err := services.NewTaskService(repo, events, tx, clk, publisher)
+		require.NoError(t, err)
+
+		_, err = service.PurgeTrash(context.Background(), time.Hour)
+		require.NoError(t, err)
+
+		publisher.AssertExpectations(t)
+	})
+	t.Run("aborted atomic batch is not published", func(t *testing.T) {
+		clk := clock.NewFake(now)
+
+		task, err := models.NewTask("title", "", ownerID, clk)
+		require.NoError(t, err)
+
+		repo := new(mocks.TaskRepository)
+		repo.On("FindByID", mock.Anything, task.ID().String()).Once().Return(task, nil)
+		repo.On("FindByID", mock.Anything, mock.Anything).Once().Return(nil, services.ErrTaskRepoNotFound)
+		repo.On("Update", mock.Anything, task).Once().Return(nil)
+
+		events := new(mocks.TaskEventRepository)
+		expectEvent(events, models.TaskEventCompleted)
+
+		publisher := new(mocks.TaskChangePublisher)
+
+		service, err := services.NewTaskService(repo, events, inlineTransactor{}, clk, publisher)
+		require.NoError(t, err)
+
+		_, err = service.Batch(context.Background(), ownerID.String(), []services.BatchOperation{
+			{Type: services.BatchOperationComplete, TaskID: task.ID().String()},
+			{Type: services.BatchOperationComplete, TaskID: uuid.NewString()},
+		}, true)
+		require.ErrorIs(t, err, services.ErrTaskBatchAborted)
+
+		publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+	})
+}
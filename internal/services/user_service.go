@@ -4,15 +4,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 
 	"github.com/cyberbrain-dev/taskery-api/internal/domain/user/models"
 	"github.com/cyberbrain-dev/taskery-api/internal/domain/user/vo"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
+	"github.com/google/uuid"
 )
 
 // UserService is a service that handles user operations.
 type UserService struct {
 	usersRepo     UserRepository
 	tokenProvider TokenProvider
+	clock         clock.Clock
 }
 
 // UserRepository defines the methods for managing user data in a persistent storage.
@@ -26,12 +31,17 @@ type UserRepository interface {
 	// Returns the user and nil error if found, otherwise returns nil and an error.
 	FindByID(ctx context.Context, id string) (*models.User, error)
 
+	// FindByIDs retrieves the users with the given identifiers in no particular order.
+	// The identifiers that do not belong to any user are skipped.
+	FindByIDs(ctx context.Context, ids []string) ([]*models.User, error)
+
 	// FindByEmail retrieves a user by their email address.
 	// Returns the user and nil error if found, otherwise returns nil and an error.
 	FindByEmail(ctx context.Context, email string) (*models.User, error)
 
-	// Update modifies an existing user's data in the repository.
-	// Returns an error if the operation fails or the user does not exist.
+	// Update modifies an existing user's data in the repository and increments its version.
+	// Returns ErrUserRepoConflict if the stored version differs from u.Version(),
+	// or an error if the operation fails or the user does not exist.
 	Update(ctx context.Context, u *models.User) error
 
 	// Delete removes a user from the repository by their unique identifier.
@@ -61,6 +71,10 @@ var (
 
 	// ErrUserRepoNotFound is returned by repository if the user was not found there
 	ErrUserRepoNotFound = errors.New("user was not found in the repository")
+
+	// ErrUserRepoConflict is returned by repository if the user
+	// was modified by someone else since it has been loaded
+	ErrUserRepoConflict = errors.New("user version conflict in the repository")
 )
 
 // Application-level errors
@@ -89,6 +103,9 @@ var (
 	// ErrUserDeleteFailed is returned by UserService if an internal error occurred during deletion
 	ErrUserDeleteFailed = errors.New("failed to delete user")
 
+	// ErrUserFindFailed is returned by UserService if an internal error occurred during the lookup of users
+	ErrUserFindFailed = errors.New("failed to find users")
+
 	// ErrUserUnauthorized is returned by UserService
 	// if the user does not have the necessary permissions to perform the operation.
 	ErrUserUnauthorized = errors.New("unauthorized access")
@@ -96,12 +113,16 @@ var (
 	// ErrUserEmailAlreadyTaken is returned by UserService
 	// if the email that is to change the old one is already taken.
 	ErrUserEmailAlreadyTaken = errors.New("email is already taken")
+
+	// ErrUserConflict is returned by UserService
+	// if the user was concurrently modified by another request.
+	ErrUserConflict = errors.New("user version conflict")
 )
 
 // NewUserService creates a new instance of UserService with
-// given user repository. In case the given repository is nil,
+// given user repository, token provider and clock. In case any of them is nil,
 // NewUserService returns nil and an error.
-func NewUserService(usersRepo UserRepository, tokenProvider TokenProvider) (*UserService, error) {
+func NewUserService(usersRepo UserRepository, tokenProvider TokenProvider, clk clock.Clock) (*UserService, error) {
 	if usersRepo == nil {
 		return nil, ErrUserRepositoryNil
 	}
@@ -110,9 +131,14 @@ func NewUserService(usersRepo UserRepository, tokenProvider TokenProvider) (*Use
 		return nil, ErrTokenProviderNil
 	}
 
+	if clk == nil {
+		return nil, ErrClockNil
+	}
+
 	return &UserService{
 		usersRepo:     usersRepo,
 		tokenProvider: tokenProvider,
+		clock:         clk,
 	}, nil
 }
 
@@ -120,7 +146,7 @@ func NewUserService(usersRepo UserRepository, tokenProvider TokenProvider) (*Use
 // Returns ErrUserExists if a user with the same identifier exists,
 // or ErrUserCreateFailed for other creation errors.
 func (us *UserService) Register(ctx context.Context, username, email, password string) error {
-	user, err := models.NewUser(username, email, password)
+	user, err := models.NewUser(username, email, password, us.clock)
 	if err != nil {
 		return err
 	}
@@ -153,6 +179,11 @@ func (us *UserService) Login(ctx context.Context, email, password string) (strin
 
 	err = user.PasswordHash().Verify(password)
 	if errors.Is(err, vo.ErrPasswordNotMatch) {
+		slogx.FromContext(ctx, slog.Default()).Warn(
+			"login with a wrong password",
+			slog.String("user_id", user.ID().String()),
+		)
+
 		return "", ErrUserUnauthorized
 	}
 	if err != nil {
@@ -172,6 +203,7 @@ func (us *UserService) Login(ctx context.Context, email, password string) (strin
 // The operation verifies the provided password before applying the change.
 // If the user does not exist, it returns ErrUserNotFound.
 // If the password is invalid, it returns ErrUserUnauthorized.
+// If the user was modified concurrently, it returns ErrUserConflict.
 // If updating the user fails, it returns an error wrapping ErrUserChangeEmailFailed.
 //
 // On success, ChangeUsername returns nil.
@@ -192,11 +224,15 @@ func (us *UserService) ChangeUsername(ctx context.Context, id, newUsername, pass
 		return fmt.Errorf("%w: %s", ErrUserChangeUsernameFailed, err)
 	}
 
-	if err := user.ChangeUsername(newUsername); err != nil {
+	if err := user.ChangeUsername(newUsername, us.clock); err != nil {
 		return err
 	}
 
 	if err := us.usersRepo.Update(ctx, user); err != nil {
+		if errors.Is(err, ErrUserRepoConflict) {
+			return ErrUserConflict
+		}
+
 		return fmt.Errorf("%w: %s", ErrUserChangeUsernameFailed, err)
 	}
 
@@ -210,6 +246,7 @@ func (us *UserService) ChangeUsername(ctx context.Context, id, newUsername, pass
 // If the user does not exist, it returns ErrUserNotFound.
 // If the password is invalid, it returns ErrUserUnauthorized.
 // If the new email is already taken, it returns ErrEmailAlreadyTaken.
+// If the user was modified concurrently, it returns ErrUserConflict.
 // If any repository operation fails, it returns an error wrapping
 // ErrUserChangeEmailFailed.
 //
@@ -239,11 +276,15 @@ func (us *UserService) ChangeEmail(ctx context.Context, id, newEmail, password s
 		return fmt.Errorf("%w: %s", ErrUserChangeEmailFailed, err)
 	}
 
-	if err := user.ChangeEmail(newEmail); err != nil {
+	if err := user.ChangeEmail(newEmail, us.clock); err != nil {
 		return err
 	}
 
 	if err := us.usersRepo.Update(ctx, user); err != nil {
+		if errors.Is(err, ErrUserRepoConflict) {
+			return ErrUserConflict
+		}
+
 		return fmt.Errorf("%w: %s", ErrUserChangeEmailFailed, err)
 	}
 
@@ -256,6 +297,7 @@ func (us *UserService) ChangeEmail(ctx context.Context, id, newEmail, password s
 // If the user does not exist, it returns ErrUserNotFound.
 // If changing the password fails, it returns the corresponding error from
 // the user object.
+// If the user was modified concurrently, it returns ErrUserConflict.
 // If updating the user in the repository fails, it returns an error wrapping
 // ErrUserChangePasswordFailed.
 //
@@ -269,7 +311,7 @@ func (us *UserService) ChangePassword(ctx context.Context, id, old, new string)
 		return fmt.Errorf("%w: %s", ErrUserChangePasswordFailed, err)
 	}
 
-	err = user.ChangePassword(old, new)
+	err = user.ChangePassword(old, new, us.clock)
 	if errors.Is(err, vo.ErrPasswordNotMatch) {
 		return ErrUserUnauthorized
 	}
@@ -278,6 +320,10 @@ func (us *UserService) ChangePassword(ctx context.Context, id, old, new string)
 	}
 
 	if err := us.usersRepo.Update(ctx, user); err != nil {
+		if errors.Is(err, ErrUserRepoConflict) {
+			return ErrUserConflict
+		}
+
 		return fmt.Errorf("%w: %s", ErrUserChangePasswordFailed, err)
 	}
 
@@ -313,3 +359,42 @@ func (us *UserService) Delete(ctx context.Context, id, password string) error {
 
 	return nil
 }
+
+// FindByIDs returns the users with the given ids in no particular order,
+// so that the users of many entities can be loaded at once.
+// The ids that are not valid or do not belong to any user are skipped.
+//
+// If the lookup fails, FindByIDs returns ErrUserFindFailed.
+func (us *UserService) FindByIDs(ctx context.Context, ids []string) ([]*models.User, error) {
+	ids = validIDs(ids)
+	if len(ids) == 0 {
+		return make([]*models.User, 0), nil
+	}
+
+	users, err := us.usersRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUserFindFailed, err)
+	}
+
+	return users, nil
+}
+
+// validIDs returns the distinct ids that are valid UUIDs, so that a malformed one
+// does not fail the lookup of the others.
+func validIDs(ids []string) []string {
+	valid := make([]string, 0, len(ids))
+	seen := make(map[string]struct{}, len(ids))
+
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+
+		if uuid.Validate(id) == nil {
+			valid = append(valid, id)
+		}
+	}
+
+	return valid
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/cyberbrain-dev/taskery-api/internal/domain/user/vo"
 	"github.com/cyberbrain-dev/taskery-api/internal/services"
 	"github.com/cyberbrain-dev/taskery-api/internal/services/mocks"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -18,31 +19,42 @@ func TestNewUserService(t *testing.T) {
 		name          string
 		usersRepo     services.UserRepository
 		tokenProvider services.TokenProvider
+		clock         clock.Clock
 		wantErr       error
 	}{
 		{
 			name:          "valid repository",
 			usersRepo:     new(mocks.UserRepository),
 			tokenProvider: new(mocks.TokenProvider),
+			clock:         clock.Real{},
 			wantErr:       nil,
 		},
 		{
 			name:          "nil repository",
 			usersRepo:     nil,
 			tokenProvider: new(mocks.TokenProvider),
+			clock:         clock.Real{},
 			wantErr:       services.ErrUserRepositoryNil,
 		},
 		{
 			name:          "nil repository",
 			usersRepo:     new(mocks.UserRepository),
 			tokenProvider: nil,
+			clock:         clock.Real{},
 			wantErr:       services.ErrTokenProviderNil,
 		},
+		{
+			name:          "nil clock",
+			usersRepo:     new(mocks.UserRepository),
+			tokenProvider: new(mocks.TokenProvider),
+			clock:         nil,
+			wantErr:       services.ErrClockNil,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			us, err := services.NewUserService(tt.usersRepo, tt.tokenProvider)
+			us, err := services.NewUserService(tt.usersRepo, tt.tokenProvider, tt.clock)
 			if tt.wantErr != nil {
 				require.Nil(t, us)
 				require.ErrorIs(t, err, tt.wantErr)
@@ -126,7 +138,7 @@ func TestUserService_Register(t *testing.T) {
 				tt.mocksSetup(repo)
 			}
 
-			us, err := services.NewUserService(repo, tokenProvider)
+			us, err := services.NewUserService(repo, tokenProvider, clock.Real{})
 			require.NoError(t, err)
 			require.NotNil(t, us)
 
@@ -143,7 +155,7 @@ func TestUserService_Register(t *testing.T) {
 }
 
 func TestUserService_Login(t *testing.T) {
-	correctUser, err := models.NewUser("alex123", "correct@example.com", "correct_pass")
+	correctUser, err := models.NewUser("alex123", "correct@example.com", "correct_pass", clock.Real{})
 	require.NoError(t, err)
 	require.NotNil(t, correctUser)
 
@@ -237,7 +249,7 @@ func TestUserService_Login(t *testing.T) {
 				tt.mocksSetup(repo, tokenProvider)
 			}
 
-			us, err := services.NewUserService(repo, tokenProvider)
+			us, err := services.NewUserService(repo, tokenProvider, clock.Real{})
 			require.NoError(t, err)
 			require.NotNil(t, us)
 
@@ -255,7 +267,7 @@ func TestUserService_Login(t *testing.T) {
 }
 
 func TestUserService_ChangeEmail(t *testing.T) {
-	correctUser, _ := models.NewUser("alex123", "correct@example.com", "correct_pass")
+	correctUser, _ := models.NewUser("alex123", "correct@example.com", "correct_pass", clock.Real{})
 
 	tests := []struct {
 		name     string
@@ -368,7 +380,7 @@ func TestUserService_ChangeEmail(t *testing.T) {
 				tt.mocksSetup(repo, tokenProvider)
 			}
 
-			us, err := services.NewUserService(repo, tokenProvider)
+			us, err := services.NewUserService(repo, tokenProvider, clock.Real{})
 			require.NoError(t, err)
 			require.NotNil(t, us)
 
@@ -385,7 +397,7 @@ func TestUserService_ChangeEmail(t *testing.T) {
 }
 
 func TestUserService_ChangePassword(t *testing.T) {
-	correctUser, _ := models.NewUser("alex123", "correct@example.com", "correct_pass")
+	correctUser, _ := models.NewUser("alex123", "correct@example.com", "correct_pass", clock.Real{})
 
 	tests := []struct {
 		name        string
@@ -443,6 +455,24 @@ func TestUserService_ChangePassword(t *testing.T) {
 					Return(userToReturn, nil)
 			},
 		},
+		{
+			name:        "concurrent modification",
+			id:          correctUser.ID().String(),
+			oldPassword: "correct_pass",
+			newPassword: "new_correct_pass",
+
+			wantErr: services.ErrUserConflict,
+
+			mocksSetup: func(repo *mocks.UserRepository, tokenProvider *mocks.TokenProvider, userToReturn *models.User) {
+				repo.On("FindByID", mock.Anything, correctUser.ID().String()).
+					Once().
+					Return(userToReturn, nil)
+
+				repo.On("Update", mock.Anything, mock.AnythingOfType("*models.User")).
+					Once().
+					Return(services.ErrUserRepoConflict)
+			},
+		},
 		{
 			name:        "update internal error",
 			id:          correctUser.ID().String(),
@@ -474,7 +504,7 @@ func TestUserService_ChangePassword(t *testing.T) {
 				tt.mocksSetup(repo, tokenProvider, &userCopy)
 			}
 
-			us, err := services.NewUserService(repo, tokenProvider)
+			us, err := services.NewUserService(repo, tokenProvider, clock.Real{})
 			require.NoError(t, err)
 			require.NotNil(t, us)
 
@@ -489,3 +519,59 @@ func TestUserService_ChangePassword(t *testing.T) {
 		})
 	}
 }
+
+func TestUserService_FindByIDs(t *testing.T) {
+	first, err := models.NewUser("alex123", "alex@example.com", "correct_pass", clock.Real{})
+	require.NoError(t, err)
+
+	second, err := models.NewUser("maria123", "maria@example.com", "correct_pass", clock.Real{})
+	require.NoError(t, err)
+
+	ids := []string{first.ID().String(), "not-a-uuid", second.ID().String(), first.ID().String()}
+	validIDs := []string{first.ID().String(), second.ID().String()}
+
+	t.Run("success", func(t *testing.T) {
+		repo := new(mocks.UserRepository)
+		repo.On("FindByIDs", mock.Anything, validIDs).
+			Once().
+			Return([]*models.User{second, first}, nil)
+
+		service, err := services.NewUserService(repo, new(mocks.TokenProvider), clock.Real{})
+		require.NoError(t, err)
+
+		users, err := service.FindByIDs(context.Background(), ids)
+		require.NoError(t, err)
+		require.Equal(t, []*models.User{second, first}, users)
+
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("no valid ids", func(t *testing.T) {
+		repo := new(mocks.UserRepository)
+
+		service, err := services.NewUserService(repo, new(mocks.TokenProvider), clock.Real{})
+		require.NoError(t, err)
+
+		users, err := service.FindByIDs(context.Background(), []string{"not-a-uuid"})
+		require.NoError(t, err)
+		require.Empty(t, users)
+
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("internal db error", func(t *testing.T) {
+		repo := new(mocks.UserRepository)
+		repo.On("FindByIDs", mock.Anything, validIDs).
+			Once().
+			Return(nil, errors.New("failed to connect to db"))
+
+		service, err := services.NewUserService(repo, new(mocks.TokenProvider), clock.Real{})
+		require.NoError(t, err)
+
+		users, err := service.FindByIDs(context.Background(), ids)
+		require.ErrorIs(t, err, services.ErrUserFindFailed)
+		require.Nil(t, users)
+
+		repo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,3 @@
+ALTER TABLE tasks DROP COLUMN IF EXISTS version;
+
+ALTER TABLE users DROP COLUMN IF EXISTS version;
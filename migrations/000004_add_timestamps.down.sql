@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_tasks_owner_id_updated_at;
+DROP INDEX IF EXISTS idx_tasks_owner_id_created_at;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS created_at;
+
+ALTER TABLE users DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE users DROP COLUMN IF EXISTS created_at;
@@ -0,0 +1,22 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS created_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE users SET created_at = now() WHERE created_at IS NULL;
+UPDATE users SET updated_at = created_at WHERE updated_at IS NULL;
+
+ALTER TABLE users ALTER COLUMN created_at SET NOT NULL;
+ALTER TABLE users ALTER COLUMN updated_at SET NOT NULL;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS created_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP WITH TIME ZONE;
+
+-- The real creation time of existing tasks is unknown, so completed tasks
+-- are backfilled with their completion time and the rest with the migration time.
+UPDATE tasks SET created_at = LEAST(completed_at, now()) WHERE created_at IS NULL;
+UPDATE tasks SET updated_at = GREATEST(created_at, completed_at) WHERE updated_at IS NULL;
+
+ALTER TABLE tasks ALTER COLUMN created_at SET NOT NULL;
+ALTER TABLE tasks ALTER COLUMN updated_at SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_owner_id_created_at ON tasks(owner_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_tasks_owner_id_updated_at ON tasks(owner_id, updated_at);
@@ -0,0 +1,9 @@
+DELETE FROM tasks WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_tasks_deleted_at;
+
+DROP INDEX IF EXISTS idx_unique_owner_id_title;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_unique_owner_id_title
+ON tasks (owner_id, title);
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS deleted_at;
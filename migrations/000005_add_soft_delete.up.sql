@@ -0,0 +1,8 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE NULL;
+
+DROP INDEX IF EXISTS idx_unique_owner_id_title;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_unique_owner_id_title
+ON tasks (owner_id, title) WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_deleted_at
+ON tasks (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,4 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP WITH TIME ZONE NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_owner_id_completed_at
+ON tasks (owner_id, completed_at) WHERE is_completed AND archived_at IS NULL;
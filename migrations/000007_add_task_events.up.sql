@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS task_events (
+    id UUID PRIMARY KEY,
+    -- not a foreign key, so the history outlives a permanently deleted task
+    task_id UUID NOT NULL,
+    actor_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    type TEXT NOT NULL,
+
+    before JSONB NULL,
+    after JSONB NOT NULL,
+
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_events_task_id_occurred_at
+ON task_events (task_id, occurred_at);
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS task_tombstones;
+
+DROP INDEX IF EXISTS idx_tasks_owner_id_change_seq;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS change_seq;
+ALTER TABLE tasks DROP COLUMN IF EXISTS created_seq;
+
+ALTER TABLE users DROP COLUMN IF EXISTS change_seq;
@@ -0,0 +1,28 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS change_seq BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS created_seq BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS change_seq BIGINT NOT NULL DEFAULT 0;
+
+-- the existing tasks are numbered in the order of their last update
+UPDATE tasks SET created_seq = numbered.seq, change_seq = numbered.seq
+FROM (
+    SELECT id, row_number() OVER (PARTITION BY owner_id ORDER BY updated_at, id) AS seq
+    FROM tasks
+) AS numbered
+WHERE tasks.id = numbered.id;
+
+UPDATE users SET change_seq = counts.seq
+FROM (SELECT owner_id, count(*) AS seq FROM tasks GROUP BY owner_id) AS counts
+WHERE users.id = counts.owner_id;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_owner_id_change_seq ON tasks (owner_id, change_seq);
+
+CREATE TABLE IF NOT EXISTS task_tombstones (
+    task_id UUID PRIMARY KEY,
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    change_seq BIGINT NOT NULL,
+    deleted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_tombstones_owner_id_change_seq ON task_tombstones (owner_id, change_seq);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tasks_owner_id_deadline;
+
+DROP TABLE IF EXISTS calendar_feeds;
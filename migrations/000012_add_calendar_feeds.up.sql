@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+
+    -- only the hash of the token is stored, the token itself is known only to the user
+    token_hash TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- the calendar feeds list the active tasks with deadlines
+CREATE INDEX IF NOT EXISTS idx_tasks_owner_id_deadline
+ON tasks (owner_id, deadline) WHERE deadline IS NOT NULL AND deleted_at IS NULL AND archived_at IS NULL;
@@ -0,0 +1,61 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is a source of the current time.
+// It allows replacing time.Now with a controllable implementation.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+}
+
+// Real is a Clock that returns the current system time.
+type Real struct{}
+
+// Now returns the result of time.Now.
+func (Real) Now() time.Time {
+	return time.Now()
+}
+
+var _ Clock = Real{}
+
+// Fake is a Clock whose current time is set manually.
+// It is safe for concurrent use and intended for tests.
+type Fake struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFake returns a Fake clock that is set to now.
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+// Now returns the time the clock is currently set to.
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.now
+}
+
+// Set sets the clock to now.
+func (f *Fake) Set(now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.now = now
+}
+
+// Advance moves the clock forward by d. A negative d moves it backward.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.now = f.now.Add(d)
+}
+
+var _ Clock = (*Fake)(nil)
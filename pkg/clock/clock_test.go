@@ -0,0 +1,59 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReal_Now(t *testing.T) {
+	before := time.Now()
+	now := clock.Real{}.Now()
+	after := time.Now()
+
+	require.False(t, now.Before(before))
+	require.False(t, now.After(after))
+}
+
+func TestFake(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		action func(c *clock.Fake)
+		want   time.Time
+	}{
+		{
+			name:   "initial time",
+			action: func(c *clock.Fake) {},
+			want:   start,
+		},
+		{
+			name:   "advance forward",
+			action: func(c *clock.Fake) { c.Advance(90 * time.Minute) },
+			want:   start.Add(90 * time.Minute),
+		},
+		{
+			name:   "advance backward",
+			action: func(c *clock.Fake) { c.Advance(-time.Hour) },
+			want:   start.Add(-time.Hour),
+		},
+		{
+			name:   "set",
+			action: func(c *clock.Fake) { c.Set(start.AddDate(1, 0, 0)) },
+			want:   start.AddDate(1, 0, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := clock.NewFake(start)
+			tt.action(c)
+
+			require.Equal(t, tt.want, c.Now())
+			require.Equal(t, tt.want, c.Now(), "time must not move by itself")
+		})
+	}
+}
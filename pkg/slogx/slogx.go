@@ -0,0 +1,65 @@
+// Package slogx contains helpers for log/slog: a logger carried by the context
+// and redaction of the sensitive attributes.
+package slogx
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+type ctxKeyLogger struct{}
+
+// WithLogger returns a copy of ctx that carries logger.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, ctxKeyLogger{}, logger)
+}
+
+// FromContext returns the logger carried by ctx.
+// If ctx carries no logger, FromContext returns fallback.
+func FromContext(ctx context.Context, fallback *slog.Logger) *slog.Logger {
+	if ctx == nil {
+		return fallback
+	}
+
+	if logger, ok := ctx.Value(ctxKeyLogger{}).(*slog.Logger); ok {
+		return logger
+	}
+
+	return fallback
+}
+
+// RedactedValue replaces the values of the sensitive attributes.
+const RedactedValue = "[REDACTED]"
+
+// sensitiveKeys are the keys of the attributes whose values must never be logged.
+var sensitiveKeys = map[string]struct{}{
+	"authorization": {},
+	"cookie":        {},
+	"set-cookie":    {},
+	"password":      {},
+	"new_password":  {},
+	"old_password":  {},
+	"token":         {},
+	"secret":        {},
+
+	// carries the token of a WebSocket handshake from a browser
+	"sec-websocket-protocol": {},
+}
+
+// IsSensitive reports whether the value of the attribute or the header with the given key must be redacted.
+// The key is compared case-insensitively.
+func IsSensitive(key string) bool {
+	_, ok := sensitiveKeys[strings.ToLower(key)]
+	return ok
+}
+
+// Redact replaces the values of the sensitive attributes with RedactedValue.
+// It is meant to be used as slog.HandlerOptions.ReplaceAttr.
+func Redact(_ []string, a slog.Attr) slog.Attr {
+	if IsSensitive(a.Key) {
+		return slog.String(a.Key, RedactedValue)
+	}
+
+	return a
+}
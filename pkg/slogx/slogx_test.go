@@ -0,0 +1,40 @@
+package slogx_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/cyberbrain-dev/taskery-api/pkg/slogx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromContext(t *testing.T) {
+	fallback := slog.New(slog.NewTextHandler(io.Discard, nil))
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	require.Same(t, fallback, slogx.FromContext(context.Background(), fallback))
+	require.Same(t, logger, slogx.FromContext(slogx.WithLogger(context.Background(), logger), fallback))
+}
+
+func TestRedact(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: slogx.Redact}))
+	logger.Info(
+		"login",
+		slog.String("email", "user@example.com"),
+		slog.String("Password", "qwerty123"),
+		slog.Group("headers", slog.String("Authorization", "Bearer abc"), slog.String("Accept", "*/*")),
+	)
+
+	out := buf.String()
+	require.Contains(t, out, `"email":"user@example.com"`)
+	require.Contains(t, out, `"Password":"[REDACTED]"`)
+	require.Contains(t, out, `"Authorization":"[REDACTED]"`)
+	require.Contains(t, out, `"Accept":"*/*"`)
+	require.NotContains(t, out, "qwerty123")
+	require.NotContains(t, out, "Bearer abc")
+}
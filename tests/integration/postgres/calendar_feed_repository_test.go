@@ -0,0 +1,92 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	userModels "github.com/cyberbrain-dev/taskery-api/internal/domain/user/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/database/postgres"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func migrateCalendarFeeds(t *testing.T, db *sql.DB) {
+	t.Helper()
+
+	_, err := db.Exec(`
+		CREATE TABLE calendar_feeds (
+			user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+			token_hash TEXT NOT NULL UNIQUE,
+			created_at TIMESTAMPTZ NOT NULL
+		);
+	`)
+	require.NoError(t, err)
+}
+
+func TestCalendarFeedRepository(t *testing.T) {
+	db, cleanup := setupPostgres(t)
+	defer cleanup()
+
+	migrateUsers(t, db)
+	migrateCalendarFeeds(t, db)
+
+	userRepo, err := postgres.NewUserRepository(db)
+	require.NoError(t, err)
+
+	feedRepo, err := postgres.NewCalendarFeedRepository(db)
+	require.NoError(t, err)
+
+	realUser, err := userModels.NewUserFromDB(userModels.UserFromDBParams{
+		ID:           uuid.New().String(),
+		Username:     "Test User",
+		Email:        "test@example.com",
+		PasswordHash: "password123",
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	userID := realUser.ID().String()
+
+	require.NoError(t, userRepo.Create(ctx, realUser))
+
+	now := time.Now().Truncate(time.Microsecond)
+
+	t.Run("save and find", func(t *testing.T) {
+		require.NoError(t, feedRepo.Save(ctx, userID, "first-hash", now))
+
+		found, err := feedRepo.FindUserID(ctx, "first-hash")
+		require.NoError(t, err)
+		require.Equal(t, userID, found)
+	})
+
+	t.Run("save replaces the previous token", func(t *testing.T) {
+		require.NoError(t, feedRepo.Save(ctx, userID, "second-hash", now.Add(time.Minute)))
+
+		_, err := feedRepo.FindUserID(ctx, "first-hash")
+		require.ErrorIs(t, err, services.ErrCalendarFeedRepoNotFound)
+
+		found, err := feedRepo.FindUserID(ctx, "second-hash")
+		require.NoError(t, err)
+		require.Equal(t, userID, found)
+	})
+
+	t.Run("save for unknown user", func(t *testing.T) {
+		err := feedRepo.Save(ctx, uuid.New().String(), "third-hash", now)
+		require.ErrorIs(t, err, services.ErrCalendarFeedRepoUserNotFound)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, feedRepo.Delete(ctx, userID))
+
+		_, err := feedRepo.FindUserID(ctx, "second-hash")
+		require.ErrorIs(t, err, services.ErrCalendarFeedRepoNotFound)
+
+		// deleting a missing token is not an error
+		require.NoError(t, feedRepo.Delete(ctx, userID))
+	})
+}
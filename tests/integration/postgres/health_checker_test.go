@@ -0,0 +1,54 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/database/postgres"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthChecker(t *testing.T) {
+	db, cleanup := setupPostgres(t)
+	defer cleanup()
+
+	checker, err := postgres.NewHealthChecker(db)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	t.Run("migrations are not applied", func(t *testing.T) {
+		_, err := db.Exec(`CREATE TABLE schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)`)
+		require.NoError(t, err)
+
+		_, err = checker.Check(ctx)
+		require.ErrorIs(t, err, postgres.ErrMigrationsNotApplied)
+	})
+
+	t.Run("migrations are applied", func(t *testing.T) {
+		_, err := db.Exec(`INSERT INTO schema_migrations (version, dirty) VALUES (7, FALSE)`)
+		require.NoError(t, err)
+
+		details, err := checker.Check(ctx)
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"migration_version": int64(7), "migration_dirty": false}, details)
+	})
+
+	t.Run("migration is dirty", func(t *testing.T) {
+		_, err := db.Exec(`UPDATE schema_migrations SET dirty = TRUE`)
+		require.NoError(t, err)
+
+		details, err := checker.Check(ctx)
+		require.ErrorIs(t, err, postgres.ErrMigrationDirty)
+		require.Equal(t, true, details["migration_dirty"])
+	})
+
+	t.Run("database is closed", func(t *testing.T) {
+		require.NoError(t, db.Close())
+
+		_, err := checker.Check(ctx)
+		require.Error(t, err)
+	})
+}
@@ -17,6 +17,15 @@ import (
 func setupPostgres(t *testing.T) (*sql.DB, func()) {
 	t.Helper()
 
+	db, _, cleanup := setupPostgresWithDSN(t)
+
+	return db, cleanup
+}
+
+// setupPostgresWithDSN returns a db, the DSN of the database for the extra connections and a cleanup func
+func setupPostgresWithDSN(t *testing.T) (*sql.DB, string, func()) {
+	t.Helper()
+
 	ctx := context.Background()
 	dbContainer, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
 		ContainerRequest: testcontainers.ContainerRequest{
@@ -56,5 +65,5 @@ func setupPostgres(t *testing.T) (*sql.DB, func()) {
 		_ = dbContainer.Terminate(ctx)
 	}
 
-	return db, cleanup
+	return db, dsn, cleanup
 }
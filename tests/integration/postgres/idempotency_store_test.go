@@ -0,0 +1,122 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/database/postgres"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/idempotency"
+	"github.com/stretchr/testify/require"
+)
+
+func migrateIdempotencyKeys(t *testing.T, db *sql.DB) {
+	t.Helper()
+
+	_, err := db.Exec(`
+		CREATE TABLE idempotency_keys (
+			key TEXT PRIMARY KEY,
+			fingerprint TEXT NOT NULL,
+
+			status_code INTEGER NULL,
+			header JSONB NULL,
+			body BYTEA NULL,
+
+			expires_at TIMESTAMPTZ NOT NULL
+		);
+	`)
+
+	require.NoError(t, err)
+}
+
+func TestIdempotencyStore(t *testing.T) {
+	db, cleanup := setupPostgres(t)
+	defer cleanup()
+
+	migrateIdempotencyKeys(t, db)
+
+	store, err := postgres.NewIdempotencyStore(db)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Microsecond)
+
+	t.Run("reserve and complete", func(t *testing.T) {
+		record, err := store.Reserve(ctx, "user:key-1", "fingerprint", now, now.Add(time.Hour))
+		require.NoError(t, err)
+		require.Nil(t, record)
+
+		record, err = store.Reserve(ctx, "user:key-1", "other", now, now.Add(time.Hour))
+		require.NoError(t, err)
+		require.NotNil(t, record)
+		require.Equal(t, "fingerprint", record.Fingerprint)
+		require.Nil(t, record.Response)
+
+		err = store.Complete(ctx, "user:key-1", idempotency.Response{
+			StatusCode: http.StatusCreated,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       []byte(`{"task_id":"1"}`),
+		})
+		require.NoError(t, err)
+
+		record, err = store.Reserve(ctx, "user:key-1", "fingerprint", now, now.Add(time.Hour))
+		require.NoError(t, err)
+		require.NotNil(t, record)
+		require.NotNil(t, record.Response)
+		require.Equal(t, http.StatusCreated, record.Response.StatusCode)
+		require.Equal(t, "application/json", record.Response.Header.Get("Content-Type"))
+		require.Equal(t, `{"task_id":"1"}`, string(record.Response.Body))
+		require.WithinDuration(t, now.Add(time.Hour), record.ExpiresAt, time.Microsecond)
+	})
+	t.Run("complete without reservation", func(t *testing.T) {
+		err := store.Complete(ctx, "user:missing", idempotency.Response{StatusCode: http.StatusOK})
+		require.ErrorIs(t, err, idempotency.ErrKeyNotReserved)
+
+		err = store.Complete(ctx, "user:key-1", idempotency.Response{StatusCode: http.StatusOK})
+		require.ErrorIs(t, err, idempotency.ErrKeyNotReserved)
+	})
+	t.Run("release", func(t *testing.T) {
+		record, err := store.Reserve(ctx, "user:key-2", "fingerprint", now, now.Add(time.Hour))
+		require.NoError(t, err)
+		require.Nil(t, record)
+
+		require.NoError(t, store.Release(ctx, "user:key-2"))
+
+		record, err = store.Reserve(ctx, "user:key-2", "other", now, now.Add(time.Hour))
+		require.NoError(t, err)
+		require.Nil(t, record)
+	})
+	t.Run("completed key is not released", func(t *testing.T) {
+		require.NoError(t, store.Release(ctx, "user:key-1"))
+
+		record, err := store.Reserve(ctx, "user:key-1", "fingerprint", now, now.Add(time.Hour))
+		require.NoError(t, err)
+		require.NotNil(t, record)
+	})
+	t.Run("expired key is reserved again", func(t *testing.T) {
+		later := now.Add(2 * time.Hour)
+
+		record, err := store.Reserve(ctx, "user:key-1", "new fingerprint", later, later.Add(time.Hour))
+		require.NoError(t, err)
+		require.Nil(t, record)
+
+		record, err = store.Reserve(ctx, "user:key-1", "other", later, later.Add(time.Hour))
+		require.NoError(t, err)
+		require.NotNil(t, record)
+		require.Equal(t, "new fingerprint", record.Fingerprint)
+		require.Nil(t, record.Response)
+	})
+	t.Run("delete expired", func(t *testing.T) {
+		deleted, err := store.DeleteExpired(ctx, now.Add(90*time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, int64(1), deleted)
+
+		deleted, err = store.DeleteExpired(ctx, now.Add(90*time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, int64(0), deleted)
+	})
+}
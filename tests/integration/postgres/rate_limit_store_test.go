@@ -0,0 +1,101 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/database/postgres"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/ratelimit"
+	"github.com/stretchr/testify/require"
+)
+
+func migrateRateLimitBuckets(t *testing.T, db *sql.DB) {
+	t.Helper()
+
+	_, err := db.Exec(`
+		CREATE TABLE rate_limit_buckets (
+			key TEXT PRIMARY KEY,
+			tokens DOUBLE PRECISION NOT NULL,
+			allowed BOOLEAN NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL,
+			full_at TIMESTAMPTZ NOT NULL
+		);
+	`)
+
+	require.NoError(t, err)
+}
+
+func TestRateLimitStore(t *testing.T) {
+	db, cleanup := setupPostgres(t)
+	defer cleanup()
+
+	migrateRateLimitBuckets(t, db)
+
+	store, err := postgres.NewRateLimitStore(db)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Microsecond)
+	limit := ratelimit.Limit{Requests: 2, Period: time.Minute}
+
+	t.Run("take tokens", func(t *testing.T) {
+		result, err := store.Take(ctx, "auth:ip:10.0.0.1", limit, now)
+		require.NoError(t, err)
+		require.True(t, result.Allowed)
+		require.Equal(t, 1, result.Remaining)
+
+		result, err = store.Take(ctx, "auth:ip:10.0.0.1", limit, now)
+		require.NoError(t, err)
+		require.True(t, result.Allowed)
+		require.Equal(t, 0, result.Remaining)
+
+		result, err = store.Take(ctx, "auth:ip:10.0.0.1", limit, now)
+		require.NoError(t, err)
+		require.False(t, result.Allowed)
+		require.Equal(t, 30*time.Second, result.RetryAfter)
+
+		result, err = store.Take(ctx, "auth:ip:10.0.0.1", limit, now.Add(30*time.Second))
+		require.NoError(t, err)
+		require.True(t, result.Allowed)
+	})
+
+	t.Run("concurrent takes", func(t *testing.T) {
+		limit := ratelimit.Limit{Requests: 5, Period: time.Hour}
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		allowed := 0
+
+		for range 20 {
+			wg.Go(func() {
+				result, err := store.Take(ctx, "tasks:user:user-1", limit, now)
+				require.NoError(t, err)
+
+				if result.Allowed {
+					mu.Lock()
+					allowed++
+					mu.Unlock()
+				}
+			})
+		}
+
+		wg.Wait()
+
+		require.Equal(t, 5, allowed)
+	})
+
+	t.Run("delete expired", func(t *testing.T) {
+		deleted, err := store.DeleteExpired(ctx, now)
+		require.NoError(t, err)
+		require.Zero(t, deleted)
+
+		deleted, err = store.DeleteExpired(ctx, now.Add(2*time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, int64(2), deleted)
+	})
+}
@@ -0,0 +1,104 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	taskModels "github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	userModels "github.com/cyberbrain-dev/taskery-api/internal/domain/user/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/database/postgres"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/pubsub"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskChangeNotifier(t *testing.T) {
+	db, dsn, cleanup := setupPostgresWithDSN(t)
+	defer cleanup()
+
+	migrateUsers(t, db)
+	migrateTasks(t, db)
+	migrateTaskEvents(t, db)
+
+	userRepo, err := postgres.NewUserRepository(db)
+	require.NoError(t, err)
+
+	taskRepo, err := postgres.NewTaskRepository(db)
+	require.NoError(t, err)
+
+	eventRepo, err := postgres.NewTaskEventRepository(db)
+	require.NoError(t, err)
+
+	transactor, err := postgres.NewTransactor(db)
+	require.NoError(t, err)
+
+	notifier, err := postgres.NewTaskChangeNotifier(db)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	// every replica delivers the changes made by any of them to its own subscribers
+	replicas := make([]*pubsub.Broker, 2)
+	for i := range replicas {
+		replicas[i] = pubsub.NewBroker(10, 10)
+
+		listener, err := postgres.NewTaskChangeListener(dsn, eventRepo, replicas[i], logger)
+		require.NoError(t, err)
+
+		go func() { _ = listener.Run(ctx) }()
+	}
+
+	require.Eventually(t, func() bool {
+		var listeners int
+		err := db.QueryRow(`SELECT count(*) FROM pg_stat_activity WHERE query LIKE 'LISTEN%'`).Scan(&listeners)
+		return err == nil && listeners == len(replicas)
+	}, 10*time.Second, 50*time.Millisecond)
+
+	realUser, err := userModels.NewUserFromDB(userModels.UserFromDBParams{
+		ID:           uuid.New().String(),
+		Username:     "Test User",
+		Email:        "test@example.com",
+		PasswordHash: "password123",
+	})
+	require.NoError(t, err)
+	require.NoError(t, userRepo.Create(ctx, realUser))
+
+	clk := clock.NewFake(time.Now().Truncate(time.Microsecond))
+
+	service, err := services.NewTaskService(taskRepo, eventRepo, transactor, clk, notifier)
+	require.NoError(t, err)
+
+	subs := make([]*pubsub.Subscription, len(replicas))
+	for i, replica := range replicas {
+		subs[i] = replica.Subscribe(realUser.ID().String(), "")
+		defer subs[i].Close()
+	}
+
+	taskID, err := service.Create(ctx, services.CreateTaskCommand{
+		Title:   "title",
+		OwnerID: realUser.ID(),
+	})
+	require.NoError(t, err)
+
+	for i, sub := range subs {
+		select {
+		case change := <-sub.Changes():
+			require.Equal(t, realUser.ID().String(), change.OwnerID, "replica %d", i)
+			require.Equal(t, int64(1), change.Version, "replica %d", i)
+			require.Equal(t, taskID, change.Event.TaskID().String(), "replica %d", i)
+			require.Equal(t, taskModels.TaskEventCreated, change.Event.Type(), "replica %d", i)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "change is not delivered", "replica %d", i)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	taskModels "github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	userModels "github.com/cyberbrain-dev/taskery-api/internal/domain/user/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/database/postgres"
+	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func migrateTaskEvents(t *testing.T, db *sql.DB) {
+	t.Helper()
+
+	_, err := db.Exec(`
+		CREATE TABLE task_events (
+			id UUID PRIMARY KEY,
+			task_id UUID NOT NULL,
+			actor_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+			type TEXT NOT NULL,
+
+			before JSONB NULL,
+			after JSONB NOT NULL,
+
+			occurred_at TIMESTAMPTZ NOT NULL
+		);
+	`)
+
+	require.NoError(t, err)
+}
+
+func TestTaskEventRepository(t *testing.T) {
+	db, cleanup := setupPostgres(t)
+	defer cleanup()
+
+	migrateUsers(t, db)
+	migrateTasks(t, db)
+	migrateTaskEvents(t, db)
+
+	userRepo, err := postgres.NewUserRepository(db)
+	require.NoError(t, err)
+
+	taskRepo, err := postgres.NewTaskRepository(db)
+	require.NoError(t, err)
+
+	eventRepo, err := postgres.NewTaskEventRepository(db)
+	require.NoError(t, err)
+
+	transactor, err := postgres.NewTransactor(db)
+	require.NoError(t, err)
+
+	realUser, err := userModels.NewUserFromDB(userModels.UserFromDBParams{
+		ID:           uuid.New().String(),
+		Username:     "Test User",
+		Email:        "test@example.com",
+		PasswordHash: "password123",
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	err = userRepo.Create(ctx, realUser)
+	require.NoError(t, err)
+
+	clk := clock.NewFake(time.Now().Truncate(time.Microsecond))
+
+	task, err := taskModels.NewTaskWithDeadline("title", "description", realUser.ID(), clk.Now().Add(time.Hour), clk)
+	require.NoError(t, err)
+
+	err = taskRepo.Create(ctx, task)
+	require.NoError(t, err)
+
+	created := taskModels.NewTaskEvent(task, taskModels.TaskEventCreated, realUser.ID(), nil, clk)
+
+	before := task.State()
+	clk.Advance(time.Minute)
+	task.Complete(clk)
+	completed := taskModels.NewTaskEvent(task, taskModels.TaskEventCompleted, realUser.ID(), &before, clk)
+
+	t.Run("create and find by task", func(t *testing.T) {
+		require.NoError(t, eventRepo.Create(ctx, completed))
+		require.NoError(t, eventRepo.Create(ctx, created))
+
+		events, err := eventRepo.FindByTask(ctx, task.ID().String())
+		require.NoError(t, err)
+		require.Equal(t, 2, len(events))
+
+		require.Equal(t, created.ID(), events[0].ID())
+		require.Nil(t, events[0].Before())
+		require.Equal(t, completed.ID(), events[1].ID())
+		require.Equal(t, taskModels.TaskEventCompleted, events[1].Type())
+		require.Equal(t, realUser.ID(), events[1].ActorID())
+		require.True(t, events[1].After().IsCompleted)
+		require.Equal(t, len(completed.Changes()), len(events[1].Changes()))
+	})
+	t.Run("find by tasks", func(t *testing.T) {
+		events, err := eventRepo.FindByTasks(ctx, []string{task.ID().String(), uuid.New().String()})
+		require.NoError(t, err)
+		require.Equal(t, 2, len(events))
+
+		require.Equal(t, created.ID(), events[0].ID())
+		require.Equal(t, completed.ID(), events[1].ID())
+		require.Equal(t, task.ID(), events[1].TaskID())
+	})
+	t.Run("find by id", func(t *testing.T) {
+		event, err := eventRepo.FindByID(ctx, completed.ID().String())
+		require.NoError(t, err)
+		require.Equal(t, completed.TaskID(), event.TaskID())
+		require.Equal(t, "title", event.Before().Title)
+		require.WithinDuration(t, completed.OccurredAt(), event.OccurredAt(), time.Microsecond)
+	})
+	t.Run("find by id not found", func(t *testing.T) {
+		event, err := eventRepo.FindByID(ctx, uuid.New().String())
+		require.ErrorIs(t, err, services.ErrTaskEventRepoNotFound)
+		require.Nil(t, event)
+	})
+	t.Run("empty history", func(t *testing.T) {
+		events, err := eventRepo.FindByTask(ctx, uuid.New().String())
+		require.NoError(t, err)
+		require.NotNil(t, events)
+		require.Equal(t, 0, len(events))
+	})
+	t.Run("history outlives deleted task", func(t *testing.T) {
+		stored, err := taskRepo.FindByID(ctx, task.ID().String())
+		require.NoError(t, err)
+
+		err = taskRepo.Delete(ctx, task.ID().String(), stored.Version())
+		require.NoError(t, err)
+
+		events, err := eventRepo.FindByTask(ctx, task.ID().String())
+		require.NoError(t, err)
+		require.Equal(t, 2, len(events))
+	})
+	t.Run("transaction is rolled back", func(t *testing.T) {
+		errRollback := errors.New("rollback")
+
+		other, err := taskModels.NewTask("other title", "", realUser.ID(), clk)
+		require.NoError(t, err)
+
+		err = transactor.WithinTx(ctx, func(ctx context.Context) error {
+			if err := taskRepo.Create(ctx, other); err != nil {
+				return err
+			}
+
+			event := taskModels.NewTaskEvent(other, taskModels.TaskEventCreated, realUser.ID(), nil, clk)
+			if err := eventRepo.Create(ctx, event); err != nil {
+				return err
+			}
+
+			return errRollback
+		})
+		require.ErrorIs(t, err, errRollback)
+
+		_, err = taskRepo.FindByID(ctx, other.ID().String())
+		require.ErrorIs(t, err, services.ErrTaskRepoNotFound)
+	})
+	t.Run("transaction is committed", func(t *testing.T) {
+		other, err := taskModels.NewTask("committed title", "", realUser.ID(), clk)
+		require.NoError(t, err)
+
+		err = transactor.WithinTx(ctx, func(ctx context.Context) error {
+			if err := taskRepo.Create(ctx, other); err != nil {
+				return err
+			}
+
+			return eventRepo.Create(ctx, taskModels.NewTaskEvent(other, taskModels.TaskEventCreated, realUser.ID(), nil, clk))
+		})
+		require.NoError(t, err)
+
+		events, err := eventRepo.FindByTask(ctx, other.ID().String())
+		require.NoError(t, err)
+		require.Equal(t, 1, len(events))
+	})
+}
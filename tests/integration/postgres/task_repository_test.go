@@ -9,8 +9,10 @@ import (
 	"time"
 
 	taskModels "github.com/cyberbrain-dev/taskery-api/internal/domain/task/models"
+	"github.com/cyberbrain-dev/taskery-api/internal/domain/task/vo"
 	userModels "github.com/cyberbrain-dev/taskery-api/internal/domain/user/models"
 	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
 
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/database/postgres"
 	"github.com/google/uuid"
@@ -31,7 +33,31 @@ func migrateTasks(t *testing.T, db *sql.DB) {
 			deadline TIMESTAMPTZ NULL,
 		
 			is_completed BOOLEAN NOT NULL DEFAULT FALSE,
-			completed_at TIMESTAMPTZ NULL
+			completed_at TIMESTAMPTZ NULL,
+
+			created_at TIMESTAMPTZ NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL,
+			archived_at TIMESTAMPTZ NULL,
+			deleted_at TIMESTAMPTZ NULL,
+
+			version BIGINT NOT NULL DEFAULT 1,
+
+			created_seq BIGINT NOT NULL DEFAULT 0,
+			change_seq BIGINT NOT NULL DEFAULT 0,
+
+			search_vector TSVECTOR GENERATED ALWAYS AS (
+				setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+				setweight(to_tsvector('simple', coalesce(description, '')), 'B')
+			) STORED
+		);
+
+		CREATE INDEX idx_tasks_search_vector ON tasks USING GIN (search_vector);
+
+		CREATE TABLE task_tombstones (
+			task_id UUID PRIMARY KEY,
+			owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			change_seq BIGINT NOT NULL,
+			deleted_at TIMESTAMPTZ NOT NULL DEFAULT now()
 		);
 	`)
 
@@ -64,7 +90,7 @@ func TestTaskRepository_Create(t *testing.T) {
 	err = userRepo.Create(ctx, realUser)
 	require.NoError(t, err)
 
-	validTask, err := taskModels.NewTask("title", "no description", realUser.ID())
+	validTask, err := taskModels.NewTask("title", "no description", realUser.ID(), clock.Real{})
 	require.NoError(t, err)
 
 	t.Run("success with nil deadline", func(t *testing.T) {
@@ -80,7 +106,7 @@ func TestTaskRepository_Create(t *testing.T) {
 
 	t.Run("success with deadline", func(t *testing.T) {
 		deadline := time.Now().Add(24 * time.Hour)
-		task, err := taskModels.NewTaskWithDeadline("other title", "some description", realUser.ID(), deadline)
+		task, err := taskModels.NewTaskWithDeadline("other title", "some description", realUser.ID(), deadline, clock.Real{})
 		require.NoError(t, err)
 
 		err = taskRepo.Create(ctx, task)
@@ -94,7 +120,7 @@ func TestTaskRepository_Create(t *testing.T) {
 	})
 
 	t.Run("not existing owner", func(t *testing.T) {
-		task, err := taskModels.NewTask("not exist", "", uuid.New())
+		task, err := taskModels.NewTask("not exist", "", uuid.New(), clock.Real{})
 		require.NoError(t, err)
 
 		err = taskRepo.Create(ctx, task)
@@ -145,7 +171,7 @@ func TestTaskRepository_FindByID(t *testing.T) {
 	err = userRepo.Create(ctx, realUser)
 	require.NoError(t, err)
 
-	validTask, err := taskModels.NewTask("title", "no description", realUser.ID())
+	validTask, err := taskModels.NewTask("title", "no description", realUser.ID(), clock.Real{})
 	require.NoError(t, err)
 
 	err = taskRepo.Create(ctx, validTask)
@@ -155,7 +181,7 @@ func TestTaskRepository_FindByID(t *testing.T) {
 		taskFromDB, err := taskRepo.FindByID(ctx, validTask.ID().String())
 		require.NoError(t, err)
 
-		require.Equal(t, *validTask, *taskFromDB)
+		requireTaskEqual(t, validTask, taskFromDB)
 	})
 
 	t.Run("not found", func(t *testing.T) {
@@ -165,6 +191,61 @@ func TestTaskRepository_FindByID(t *testing.T) {
 	})
 }
 
+func TestTaskRepository_FindByIDs(t *testing.T) {
+	db, cleanup := setupPostgres(t)
+	defer cleanup()
+
+	migrateUsers(t, db)
+	migrateTasks(t, db)
+
+	userRepo, err := postgres.NewUserRepository(db)
+	require.NoError(t, err)
+
+	taskRepo, err := postgres.NewTaskRepository(db)
+	require.NoError(t, err)
+
+	realUser, err := userModels.NewUserFromDB(userModels.UserFromDBParams{
+		ID:           uuid.New().String(),
+		Username:     "Test User",
+		Email:        "test@example.com",
+		PasswordHash: "password123",
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	err = userRepo.Create(ctx, realUser)
+	require.NoError(t, err)
+
+	first, err := taskModels.NewTask("first", "", realUser.ID(), clock.Real{})
+	require.NoError(t, err)
+	require.NoError(t, taskRepo.Create(ctx, first))
+
+	second, err := taskModels.NewTask("second", "", realUser.ID(), clock.Real{})
+	require.NoError(t, err)
+	require.NoError(t, taskRepo.Create(ctx, second))
+
+	t.Run("success", func(t *testing.T) {
+		tasks, err := taskRepo.FindByIDs(ctx, []string{first.ID().String(), uuid.New().String(), second.ID().String()})
+		require.NoError(t, err)
+		require.Len(t, tasks, 2)
+
+		byID := make(map[uuid.UUID]*taskModels.Task, len(tasks))
+		for _, task := range tasks {
+			byID[task.ID()] = task
+		}
+
+		requireTaskEqual(t, first, byID[first.ID()])
+		requireTaskEqual(t, second, byID[second.ID()])
+	})
+
+	t.Run("no ids", func(t *testing.T) {
+		tasks, err := taskRepo.FindByIDs(ctx, []string{})
+		require.NoError(t, err)
+		require.Empty(t, tasks)
+	})
+}
+
 func TestTaskRepository_Update(t *testing.T) {
 	db, cleanup := setupPostgres(t)
 	defer cleanup()
@@ -191,14 +272,14 @@ func TestTaskRepository_Update(t *testing.T) {
 	err = userRepo.Create(ctx, realUser)
 	require.NoError(t, err)
 
-	validTask, err := taskModels.NewTask("title", "no description", realUser.ID())
+	validTask, err := taskModels.NewTask("title", "no description", realUser.ID(), clock.Real{})
 	require.NoError(t, err)
 
 	err = taskRepo.Create(ctx, validTask)
 	require.NoError(t, err)
 
 	t.Run("success", func(t *testing.T) {
-		err := validTask.ChangeDescription("new description")
+		err := validTask.ChangeDescription("new description", clock.Real{})
 		require.NoError(t, err)
 
 		err = taskRepo.Update(ctx, validTask)
@@ -208,11 +289,20 @@ func TestTaskRepository_Update(t *testing.T) {
 		taskFromDB, err = taskRepo.FindByID(ctx, validTask.ID().String())
 		require.NoError(t, err)
 
-		require.Equal(t, *validTask, *taskFromDB)
+		require.Equal(t, validTask.Description(), taskFromDB.Description())
+		require.Equal(t, validTask.Version()+1, taskFromDB.Version())
+		require.WithinDuration(t, validTask.UpdatedAt(), taskFromDB.UpdatedAt(), time.Microsecond)
+		require.WithinDuration(t, validTask.CreatedAt(), taskFromDB.CreatedAt(), time.Microsecond)
+	})
+
+	t.Run("version conflict", func(t *testing.T) {
+		// validTask still holds the version it had before the previous update
+		err := taskRepo.Update(ctx, validTask)
+		require.ErrorIs(t, err, services.ErrTaskRepoConflict)
 	})
 
 	t.Run("task not found", func(t *testing.T) {
-		notExistingTask, err := taskModels.NewTask("not existing title", "no description", realUser.ID())
+		notExistingTask, err := taskModels.NewTask("not existing title", "no description", realUser.ID(), clock.Real{})
 		require.NoError(t, err)
 
 		err = taskRepo.Update(ctx, notExistingTask)
@@ -246,14 +336,22 @@ func TestTaskRepository_Delete(t *testing.T) {
 	err = userRepo.Create(ctx, realUser)
 	require.NoError(t, err)
 
-	validTask, err := taskModels.NewTask("title", "no description", realUser.ID())
+	validTask, err := taskModels.NewTask("title", "no description", realUser.ID(), clock.Real{})
 	require.NoError(t, err)
 
 	err = taskRepo.Create(ctx, validTask)
 	require.NoError(t, err)
 
+	t.Run("version conflict", func(t *testing.T) {
+		err := taskRepo.Delete(ctx, validTask.ID().String(), validTask.Version()+1)
+		require.ErrorIs(t, err, services.ErrTaskRepoConflict)
+
+		_, err = taskRepo.FindByID(ctx, validTask.ID().String())
+		require.NoError(t, err)
+	})
+
 	t.Run("success", func(t *testing.T) {
-		err := taskRepo.Delete(ctx, validTask.ID().String())
+		err := taskRepo.Delete(ctx, validTask.ID().String(), validTask.Version())
 		require.NoError(t, err)
 
 		taskFromDB, err := taskRepo.FindByID(ctx, validTask.ID().String())
@@ -262,8 +360,334 @@ func TestTaskRepository_Delete(t *testing.T) {
 	})
 
 	t.Run("task not found", func(t *testing.T) {
-		err = taskRepo.Delete(ctx, uuid.New().String())
+		err = taskRepo.Delete(ctx, uuid.New().String(), 1)
+		require.ErrorIs(t, err, services.ErrTaskRepoNotFound)
+	})
+}
+
+func TestTaskRepository_DeleteTrashedBefore(t *testing.T) {
+	db, cleanup := setupPostgres(t)
+	defer cleanup()
+
+	migrateUsers(t, db)
+	migrateTasks(t, db)
+
+	userRepo, err := postgres.NewUserRepository(db)
+	require.NoError(t, err)
+
+	taskRepo, err := postgres.NewTaskRepository(db)
+	require.NoError(t, err)
+
+	realUser, err := userModels.NewUserFromDB(userModels.UserFromDBParams{
+		ID:           uuid.New().String(),
+		Username:     "Test User",
+		Email:        "test@example.com",
+		PasswordHash: "password123",
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	err = userRepo.Create(ctx, realUser)
+	require.NoError(t, err)
+
+	now := time.Now()
+
+	oldTrash, err := taskModels.NewTask("old trash", "", realUser.ID(), clock.Real{})
+	require.NoError(t, err)
+	oldTrash.MoveToTrash(clock.NewFake(now.Add(-48 * time.Hour)))
+	err = taskRepo.Create(ctx, oldTrash)
+	require.NoError(t, err)
+
+	freshTrash, err := taskModels.NewTask("fresh trash", "", realUser.ID(), clock.Real{})
+	require.NoError(t, err)
+	freshTrash.MoveToTrash(clock.NewFake(now.Add(-time.Hour)))
+	err = taskRepo.Create(ctx, freshTrash)
+	require.NoError(t, err)
+
+	active, err := taskModels.NewTask("active", "", realUser.ID(), clock.Real{})
+	require.NoError(t, err)
+	err = taskRepo.Create(ctx, active)
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		purged, err := taskRepo.DeleteTrashedBefore(ctx, now.Add(-24*time.Hour))
+		require.NoError(t, err)
+		require.Len(t, purged, 1)
+		require.Equal(t, oldTrash.ID(), purged[0].ID())
+		require.Equal(t, oldTrash.Title(), purged[0].Title())
+
+		_, err = taskRepo.FindByID(ctx, oldTrash.ID().String())
 		require.ErrorIs(t, err, services.ErrTaskRepoNotFound)
+
+		_, err = taskRepo.FindByID(ctx, freshTrash.ID().String())
+		require.NoError(t, err)
+
+		_, err = taskRepo.FindByID(ctx, active.ID().String())
+		require.NoError(t, err)
+	})
+	t.Run("nothing to purge", func(t *testing.T) {
+		purged, err := taskRepo.DeleteTrashedBefore(ctx, now.Add(-24*time.Hour))
+		require.NoError(t, err)
+		require.Empty(t, purged)
+	})
+}
+
+func TestTaskRepository_ArchiveCompletedBefore(t *testing.T) {
+	db, cleanup := setupPostgres(t)
+	defer cleanup()
+
+	migrateUsers(t, db)
+	migrateTasks(t, db)
+
+	userRepo, err := postgres.NewUserRepository(db)
+	require.NoError(t, err)
+
+	taskRepo, err := postgres.NewTaskRepository(db)
+	require.NoError(t, err)
+
+	realUser, err := userModels.NewUserFromDB(userModels.UserFromDBParams{
+		ID:           uuid.New().String(),
+		Username:     "Test User",
+		Email:        "test@example.com",
+		PasswordHash: "password123",
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	err = userRepo.Create(ctx, realUser)
+	require.NoError(t, err)
+
+	now := time.Now()
+
+	oldCompleted, err := taskModels.NewTask("old completed", "", realUser.ID(), clock.Real{})
+	require.NoError(t, err)
+	oldCompleted.Complete(clock.NewFake(now.Add(-48 * time.Hour)))
+	err = taskRepo.Create(ctx, oldCompleted)
+	require.NoError(t, err)
+
+	freshCompleted, err := taskModels.NewTask("fresh completed", "", realUser.ID(), clock.Real{})
+	require.NoError(t, err)
+	freshCompleted.Complete(clock.NewFake(now.Add(-time.Hour)))
+	err = taskRepo.Create(ctx, freshCompleted)
+	require.NoError(t, err)
+
+	active, err := taskModels.NewTask("active", "", realUser.ID(), clock.Real{})
+	require.NoError(t, err)
+	err = taskRepo.Create(ctx, active)
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		archived, err := taskRepo.ArchiveCompletedBefore(ctx, realUser.ID().String(), now.Add(-24*time.Hour), now)
+		require.NoError(t, err)
+		require.Len(t, archived, 1)
+		require.Equal(t, oldCompleted.ID(), archived[0].ID())
+		require.True(t, archived[0].IsArchived())
+		require.Equal(t, oldCompleted.Version()+1, archived[0].Version())
+
+		taskFromDB, err := taskRepo.FindByID(ctx, oldCompleted.ID().String())
+		require.NoError(t, err)
+		require.True(t, taskFromDB.IsArchived())
+		require.WithinDuration(t, now, *taskFromDB.ArchivedAt(), time.Microsecond)
+		require.Equal(t, oldCompleted.Version()+1, taskFromDB.Version())
+
+		taskFromDB, err = taskRepo.FindByID(ctx, freshCompleted.ID().String())
+		require.NoError(t, err)
+		require.False(t, taskFromDB.IsArchived())
+	})
+	t.Run("archived tasks are hidden from listing", func(t *testing.T) {
+		tasksFromDB, err := taskRepo.FindByOwner(ctx, realUser.ID().String(), services.FindByOwnerQuery{})
+		require.NoError(t, err)
+		require.Equal(t, 2, len(tasksFromDB))
+
+		tasksFromDB, err = taskRepo.FindByOwner(ctx, realUser.ID().String(), services.FindByOwnerQuery{
+			IncludeArchived: true,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 3, len(tasksFromDB))
+	})
+	t.Run("nothing to archive", func(t *testing.T) {
+		archived, err := taskRepo.ArchiveCompletedBefore(ctx, realUser.ID().String(), now.Add(-24*time.Hour), now)
+		require.NoError(t, err)
+		require.Empty(t, archived)
+	})
+}
+
+func TestTaskRepository_FindWithDeadlines(t *testing.T) {
+	db, cleanup := setupPostgres(t)
+	defer cleanup()
+
+	migrateUsers(t, db)
+	migrateTasks(t, db)
+
+	userRepo, err := postgres.NewUserRepository(db)
+	require.NoError(t, err)
+
+	taskRepo, err := postgres.NewTaskRepository(db)
+	require.NoError(t, err)
+
+	realUser, err := userModels.NewUserFromDB(userModels.UserFromDBParams{
+		ID:           uuid.New().String(),
+		Username:     "Test User",
+		Email:        "test@example.com",
+		PasswordHash: "password123",
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	err = userRepo.Create(ctx, realUser)
+	require.NoError(t, err)
+
+	now := time.Now()
+
+	newTask := func(title string, deadline *time.Time, change func(task *taskModels.Task)) *taskModels.Task {
+		task, err := taskModels.NewTask(title, "", realUser.ID(), clock.Real{})
+		require.NoError(t, err)
+
+		if deadline != nil {
+			require.NoError(t, task.SetDeadline(*deadline, clock.Real{}))
+		}
+		if change != nil {
+			change(task)
+		}
+
+		require.NoError(t, taskRepo.Create(ctx, task))
+		return task
+	}
+
+	later := newTask("later", new(now.Add(48*time.Hour)), nil)
+	sooner := newTask("sooner", new(now.Add(24*time.Hour)), nil)
+	newTask("without deadline", nil, nil)
+	newTask("archived", new(now.Add(time.Hour)), func(task *taskModels.Task) {
+		require.NoError(t, task.Archive(true, clock.Real{}))
+	})
+	newTask("trashed", new(now.Add(time.Hour)), func(task *taskModels.Task) {
+		task.MoveToTrash(clock.Real{})
+	})
+
+	t.Run("success", func(t *testing.T) {
+		tasksFromDB, err := taskRepo.FindWithDeadlines(ctx, realUser.ID().String())
+		require.NoError(t, err)
+		require.Len(t, tasksFromDB, 2)
+		require.Equal(t, sooner.ID(), tasksFromDB[0].ID())
+		require.Equal(t, later.ID(), tasksFromDB[1].ID())
+	})
+	t.Run("no tasks", func(t *testing.T) {
+		tasksFromDB, err := taskRepo.FindWithDeadlines(ctx, uuid.New().String())
+		require.NoError(t, err)
+		require.Empty(t, tasksFromDB)
+	})
+}
+
+func TestTaskRepository_Search(t *testing.T) {
+	db, cleanup := setupPostgres(t)
+	defer cleanup()
+
+	migrateUsers(t, db)
+	migrateTasks(t, db)
+
+	userRepo, err := postgres.NewUserRepository(db)
+	require.NoError(t, err)
+
+	taskRepo, err := postgres.NewTaskRepository(db)
+	require.NoError(t, err)
+
+	realUser, err := userModels.NewUserFromDB(userModels.UserFromDBParams{
+		ID:           uuid.New().String(),
+		Username:     "Test User",
+		Email:        "test@example.com",
+		PasswordHash: "password123",
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	err = userRepo.Create(ctx, realUser)
+	require.NoError(t, err)
+
+	create := func(title, description string) *taskModels.Task {
+		task, err := taskModels.NewTask(title, description, realUser.ID(), clock.Real{})
+		require.NoError(t, err)
+
+		err = taskRepo.Create(ctx, task)
+		require.NoError(t, err)
+
+		return task
+	}
+
+	milk := create("Buy milk", "Two bottles of fresh milk")
+	report := create("Write report", "Quarterly report about milk sales")
+	reportage := create("Reportage", "")
+	markup := create("<b>Bread</b> & butter", `Say "bread" to the baker`)
+
+	archived, err := taskModels.NewTask("Archived milk", "", realUser.ID(), clock.Real{})
+	require.NoError(t, err)
+	require.NoError(t, archived.Archive(true, clock.Real{}))
+	require.NoError(t, taskRepo.Create(ctx, archived))
+
+	trashed, err := taskModels.NewTask("Trashed milk", "", realUser.ID(), clock.Real{})
+	require.NoError(t, err)
+	trashed.MoveToTrash(clock.Real{})
+	require.NoError(t, taskRepo.Create(ctx, trashed))
+
+	search := func(t *testing.T, text string, limit int, includeArchived bool) []services.TaskSearchResult {
+		t.Helper()
+
+		query, err := vo.NewSearchQuery(text)
+		require.NoError(t, err)
+
+		results, err := taskRepo.Search(ctx, realUser.ID().String(), query, limit, includeArchived)
+		require.NoError(t, err)
+
+		return results
+	}
+
+	ids := func(results []services.TaskSearchResult) []uuid.UUID {
+		ids := make([]uuid.UUID, len(results))
+		for i, result := range results {
+			ids[i] = result.Task.ID()
+		}
+
+		return ids
+	}
+
+	t.Run("title matches rank higher", func(t *testing.T) {
+		results := search(t, "MILK", 10, false)
+		require.Equal(t, []uuid.UUID{milk.ID(), report.ID()}, ids(results))
+		require.Greater(t, results[0].Rank, results[1].Rank)
+		require.Equal(t, "Buy <b>milk</b>", results[0].TitleSnippet)
+		require.Contains(t, results[1].DescriptionSnippet, "<b>milk</b>")
+	})
+	t.Run("archived tasks are included on request", func(t *testing.T) {
+		results := search(t, "milk", 10, true)
+		require.ElementsMatch(t, []uuid.UUID{milk.ID(), report.ID(), archived.ID()}, ids(results))
+	})
+	t.Run("phrase", func(t *testing.T) {
+		require.Equal(t, []uuid.UUID{milk.ID()}, ids(search(t, `"fresh milk"`, 10, false)))
+		require.Empty(t, search(t, `"bottles milk"`, 10, false))
+	})
+	t.Run("prefix", func(t *testing.T) {
+		require.ElementsMatch(t, []uuid.UUID{report.ID(), reportage.ID()}, ids(search(t, "rep*", 10, false)))
+	})
+	t.Run("snippets are escaped", func(t *testing.T) {
+		results := search(t, "bread", 10, false)
+		require.Equal(t, []uuid.UUID{markup.ID()}, ids(results))
+		require.Contains(t, results[0].TitleSnippet, "<b>Bread</b>")
+		require.Contains(t, results[0].TitleSnippet, "&amp; butter")
+		require.Contains(t, results[0].DescriptionSnippet, "&#34;<b>bread</b>&#34;")
+	})
+	t.Run("all terms must match", func(t *testing.T) {
+		require.Equal(t, []uuid.UUID{report.ID()}, ids(search(t, "report sales", 10, false)))
+	})
+	t.Run("limit", func(t *testing.T) {
+		require.Len(t, search(t, "milk", 1, false), 1)
+	})
+	t.Run("nothing found", func(t *testing.T) {
+		results := search(t, "bread", 10, false)
+		require.NotNil(t, results)
+		require.Empty(t, results)
 	})
 }
 
@@ -294,27 +718,199 @@ func TestTaskRepository_FindByOwner(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("success", func(t *testing.T) {
-		task1, err := taskModels.NewTask("first task", "no description", realUser.ID())
+		task1, err := taskModels.NewTask("first task", "no description", realUser.ID(), clock.Real{})
 		require.NoError(t, err)
 		err = taskRepo.Create(ctx, task1)
 		require.NoError(t, err)
 
-		task2, err := taskModels.NewTask("second task", "other description", realUser.ID())
+		task2, err := taskModels.NewTask("second task", "other description", realUser.ID(), clock.Real{})
 		require.NoError(t, err)
 		err = taskRepo.Create(ctx, task2)
 		require.NoError(t, err)
 
-		tasksFromDB, err := taskRepo.FindByOwner(ctx, realUser.ID().String())
+		tasksFromDB, err := taskRepo.FindByOwner(ctx, realUser.ID().String(), services.FindByOwnerQuery{})
 		require.NoError(t, err)
 
 		require.Equal(t, 2, len(tasksFromDB))
-		require.Equal(t, *task1, *(tasksFromDB[0]))
-		require.Equal(t, *task2, *(tasksFromDB[1]))
+		requireTaskEqual(t, task1, tasksFromDB[0])
+		requireTaskEqual(t, task2, tasksFromDB[1])
+	})
+	t.Run("sort by created_at descending", func(t *testing.T) {
+		tasksFromDB, err := taskRepo.FindByOwner(ctx, realUser.ID().String(), services.FindByOwnerQuery{
+			Sort: services.TaskSortCreatedAtDesc,
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, 2, len(tasksFromDB))
+		require.Equal(t, "second task", tasksFromDB[0].Title().String())
+		require.Equal(t, "first task", tasksFromDB[1].Title().String())
+	})
+	t.Run("updated since", func(t *testing.T) {
+		since := time.Now()
+
+		task3, err := taskModels.NewTask("third task", "", realUser.ID(), clock.Real{})
+		require.NoError(t, err)
+		err = taskRepo.Create(ctx, task3)
+		require.NoError(t, err)
+
+		tasksFromDB, err := taskRepo.FindByOwner(ctx, realUser.ID().String(), services.FindByOwnerQuery{
+			Sort:         services.TaskSortCreatedAt,
+			UpdatedSince: &since,
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, 1, len(tasksFromDB))
+		requireTaskEqual(t, task3, tasksFromDB[0])
+	})
+	t.Run("tasks in trash", func(t *testing.T) {
+		tasksFromDB, err := taskRepo.FindByOwner(ctx, realUser.ID().String(), services.FindByOwnerQuery{})
+		require.NoError(t, err)
+		require.Equal(t, 3, len(tasksFromDB))
+
+		trashed := tasksFromDB[0]
+		trashed.MoveToTrash(clock.Real{})
+		err = taskRepo.Update(ctx, trashed)
+		require.NoError(t, err)
+
+		tasksFromDB, err = taskRepo.FindByOwner(ctx, realUser.ID().String(), services.FindByOwnerQuery{})
+		require.NoError(t, err)
+		require.Equal(t, 2, len(tasksFromDB))
+
+		trashFromDB, err := taskRepo.FindByOwner(ctx, realUser.ID().String(), services.FindByOwnerQuery{InTrash: true})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(trashFromDB))
+		require.Equal(t, trashed.ID(), trashFromDB[0].ID())
+		require.True(t, trashFromDB[0].IsDeleted())
 	})
 	t.Run("empty slice", func(t *testing.T) {
-		tasks, err := taskRepo.FindByOwner(ctx, uuid.New().String())
+		tasks, err := taskRepo.FindByOwner(ctx, uuid.New().String(), services.FindByOwnerQuery{})
 		require.NoError(t, err)
 		require.NotNil(t, tasks)
 		require.Equal(t, 0, len(tasks))
 	})
 }
+
+// requireTaskEqual asserts that actual has the same state as expected.
+// Timestamps are compared with microsecond tolerance because PostgreSQL
+// does not store nanoseconds and returns times in the session time zone.
+func requireTaskEqual(t *testing.T, expected, actual *taskModels.Task) {
+	t.Helper()
+
+	require.Equal(t, expected.ID(), actual.ID())
+	require.Equal(t, expected.OwnerID(), actual.OwnerID())
+	require.Equal(t, expected.Title(), actual.Title())
+	require.Equal(t, expected.Description(), actual.Description())
+	require.Equal(t, expected.IsCompleted(), actual.IsCompleted())
+	require.Equal(t, expected.Version(), actual.Version())
+	require.WithinDuration(t, expected.CreatedAt(), actual.CreatedAt(), time.Microsecond)
+	require.WithinDuration(t, expected.UpdatedAt(), actual.UpdatedAt(), time.Microsecond)
+}
+
+func TestTaskRepository_FindChangedSince(t *testing.T) {
+	db, cleanup := setupPostgres(t)
+	defer cleanup()
+
+	migrateUsers(t, db)
+	migrateTasks(t, db)
+
+	userRepo, err := postgres.NewUserRepository(db)
+	require.NoError(t, err)
+
+	taskRepo, err := postgres.NewTaskRepository(db)
+	require.NoError(t, err)
+
+	realUser, err := userModels.NewUserFromDB(userModels.UserFromDBParams{
+		ID:           uuid.New().String(),
+		Username:     "Test User",
+		Email:        "test@example.com",
+		PasswordHash: "password123",
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	ownerID := realUser.ID().String()
+
+	err = userRepo.Create(ctx, realUser)
+	require.NoError(t, err)
+
+	tasks := make([]*taskModels.Task, 3)
+	for i, title := range []string{"first", "second", "third"} {
+		tasks[i], err = taskModels.NewTask(title, "", realUser.ID(), clock.Real{})
+		require.NoError(t, err)
+
+		err = taskRepo.Create(ctx, tasks[i])
+		require.NoError(t, err)
+	}
+
+	t.Run("every task is created", func(t *testing.T) {
+		changes, err := taskRepo.FindChangedSince(ctx, ownerID, 0, 10)
+		require.NoError(t, err)
+		require.Equal(t, []services.TaskSyncChange{
+			{TaskID: tasks[0].ID().String(), Seq: 1, Kind: services.TaskSyncCreated},
+			{TaskID: tasks[1].ID().String(), Seq: 2, Kind: services.TaskSyncCreated},
+			{TaskID: tasks[2].ID().String(), Seq: 3, Kind: services.TaskSyncCreated},
+		}, changes)
+	})
+
+	tasks[1].Complete(clock.Real{})
+	err = taskRepo.Update(ctx, tasks[1])
+	require.NoError(t, err)
+
+	err = taskRepo.Delete(ctx, tasks[2].ID().String(), tasks[2].Version())
+	require.NoError(t, err)
+
+	t.Run("updated and deleted tasks", func(t *testing.T) {
+		changes, err := taskRepo.FindChangedSince(ctx, ownerID, 3, 10)
+		require.NoError(t, err)
+		require.Equal(t, []services.TaskSyncChange{
+			{TaskID: tasks[1].ID().String(), Seq: 4, Kind: services.TaskSyncUpdated},
+			{TaskID: tasks[2].ID().String(), Seq: 5, Kind: services.TaskSyncDeleted},
+		}, changes)
+	})
+	t.Run("task created after since is reported as created", func(t *testing.T) {
+		changes, err := taskRepo.FindChangedSince(ctx, ownerID, 1, 1)
+		require.NoError(t, err)
+		require.Equal(t, []services.TaskSyncChange{
+			{TaskID: tasks[1].ID().String(), Seq: 4, Kind: services.TaskSyncCreated},
+		}, changes)
+	})
+	t.Run("failed update takes no sequence number", func(t *testing.T) {
+		stale, err := taskRepo.FindByID(ctx, tasks[0].ID().String())
+		require.NoError(t, err)
+
+		err = taskRepo.Update(ctx, stale)
+		require.NoError(t, err)
+
+		err = taskRepo.Update(ctx, stale)
+		require.ErrorIs(t, err, services.ErrTaskRepoConflict)
+
+		changes, err := taskRepo.FindChangedSince(ctx, ownerID, 5, 10)
+		require.NoError(t, err)
+		require.Equal(t, []services.TaskSyncChange{
+			{TaskID: tasks[0].ID().String(), Seq: 6, Kind: services.TaskSyncUpdated},
+		}, changes)
+	})
+	t.Run("purged tasks leave tombstones", func(t *testing.T) {
+		trashed, err := taskRepo.FindByID(ctx, tasks[1].ID().String())
+		require.NoError(t, err)
+
+		trashed.MoveToTrash(clock.NewFake(time.Now().Add(-48 * time.Hour)))
+		err = taskRepo.Update(ctx, trashed)
+		require.NoError(t, err)
+
+		purged, err := taskRepo.DeleteTrashedBefore(ctx, time.Now().Add(-24*time.Hour))
+		require.NoError(t, err)
+		require.Len(t, purged, 1)
+
+		changes, err := taskRepo.FindChangedSince(ctx, ownerID, 6, 10)
+		require.NoError(t, err)
+		require.Equal(t, []services.TaskSyncChange{
+			{TaskID: tasks[1].ID().String(), Seq: 8, Kind: services.TaskSyncDeleted},
+		}, changes)
+	})
+	t.Run("no changes", func(t *testing.T) {
+		changes, err := taskRepo.FindChangedSince(ctx, ownerID, 8, 10)
+		require.NoError(t, err)
+		require.Empty(t, changes)
+	})
+}
@@ -6,10 +6,12 @@ import (
 	"context"
 	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/cyberbrain-dev/taskery-api/internal/domain/user/models"
 	"github.com/cyberbrain-dev/taskery-api/internal/infrastructure/database/postgres"
 	"github.com/cyberbrain-dev/taskery-api/internal/services"
+	"github.com/cyberbrain-dev/taskery-api/pkg/clock"
 	"github.com/google/uuid"
 	_ "github.com/lib/pq"
 	"github.com/stretchr/testify/require"
@@ -23,7 +25,11 @@ func migrateUsers(t *testing.T, db *sql.DB) {
 			id UUID PRIMARY KEY,
 			username TEXT NOT NULL,
 			email TEXT NOT NULL UNIQUE,
-			password_hash TEXT NOT NULL
+			password_hash TEXT NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL,
+			version BIGINT NOT NULL DEFAULT 1,
+			change_seq BIGINT NOT NULL DEFAULT 0
 		);
 	`)
 	require.NoError(t, err)
@@ -111,6 +117,45 @@ func TestUserRepository_FindByID(t *testing.T) {
 	})
 }
 
+func TestUserRepository_FindByIDs(t *testing.T) {
+	db, cleanup := setupPostgres(t)
+	defer cleanup()
+
+	migrateUsers(t, db)
+
+	repo, err := postgres.NewUserRepository(db)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	user, err := models.NewUserFromDB(models.UserFromDBParams{
+		ID:           uuid.New().String(),
+		Username:     "Test User",
+		Email:        "test@example.com",
+		PasswordHash: "password123",
+	})
+	require.NoError(t, err)
+
+	err = repo.Create(ctx, user)
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		users, err := repo.FindByIDs(ctx, []string{user.ID().String(), uuid.New().String()})
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+
+		require.Equal(t, user.ID(), users[0].ID())
+		require.Equal(t, user.Username(), users[0].Username())
+		require.Equal(t, user.Email(), users[0].Email())
+	})
+
+	t.Run("no users found", func(t *testing.T) {
+		users, err := repo.FindByIDs(ctx, []string{uuid.New().String()})
+		require.NoError(t, err)
+		require.Empty(t, users)
+	})
+}
+
 func TestUserRepository_FindByEmail(t *testing.T) {
 	db, cleanup := setupPostgres(t)
 	defer cleanup()
@@ -178,7 +223,7 @@ func TestUserRepository_Update(t *testing.T) {
 		require.NoError(t, err)
 
 		newUsername := "new user name"
-		err = userFromDB.ChangeUsername(newUsername)
+		err = userFromDB.ChangeUsername(newUsername, clock.Real{})
 		require.NoError(t, err)
 
 		err = repo.Update(ctx, userFromDB)
@@ -188,6 +233,14 @@ func TestUserRepository_Update(t *testing.T) {
 		require.NoError(t, err)
 
 		require.Equal(t, newUsername, updateUserFromDB.Username().String())
+		require.Equal(t, userFromDB.Version()+1, updateUserFromDB.Version())
+		require.WithinDuration(t, userFromDB.UpdatedAt(), updateUserFromDB.UpdatedAt(), time.Microsecond)
+	})
+
+	t.Run("version conflict", func(t *testing.T) {
+		// user still holds the version it had before the previous update
+		err := repo.Update(ctx, user)
+		require.ErrorIs(t, err, services.ErrUserRepoConflict)
 	})
 
 	t.Run("user not found", func(t *testing.T) {